@@ -20,6 +20,8 @@ package account
 
 import (
 	"errors"
+	"sync"
+	"time"
 
 	"path/filepath"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/nebulasio/go-nebulas/crypto/cipher"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/sirupsen/logrus"
 )
@@ -36,6 +39,8 @@ import (
 const (
 	EccSecp256K1      = "ECC_SECP256K1"
 	EccSecp256K1Value = 1
+	EdDSAEd25519      = "EDDSA_ED25519"
+	EdDSAEd25519Value = 2
 )
 
 var (
@@ -74,6 +79,12 @@ type Manager struct {
 
 	// account slice
 	accounts []*account
+
+	// usage policies, spendings since unlocked and the audit log of signing
+	policyMu sync.Mutex
+	policies map[string]*AccountPolicy
+	spent    map[string]*util.Uint128
+	auditLog []*SigningRecord
 }
 
 // NewManager new a account manager
@@ -83,6 +94,8 @@ func NewManager(neblet Neblet) *Manager {
 	m.signatureAlg = keystore.SECP256K1
 	m.encryptAlg = keystore.SCRYPT
 	m.keydir, _ = filepath.Abs("keydir")
+	m.policies = make(map[string]*AccountPolicy)
+	m.spent = make(map[string]*util.Uint128)
 
 	if neblet != nil {
 		// conf := neblet.Config().Account
@@ -96,8 +109,11 @@ func NewManager(neblet Neblet) *Manager {
 		}
 
 		if len(conf.SignatureCiphers) > 0 {
-			if conf.SignatureCiphers[0] == EccSecp256K1 {
+			switch conf.SignatureCiphers[0] {
+			case EccSecp256K1:
 				m.signatureAlg = keystore.Algorithm(EccSecp256K1Value)
+			case EdDSAEd25519:
+				m.signatureAlg = keystore.Algorithm(EdDSAEd25519Value)
 			}
 		}
 
@@ -145,8 +161,15 @@ func (m *Manager) storeAddress(priv keystore.PrivateKey, passphrase []byte, writ
 	return addr, nil
 }
 
-// Unlock unlock address with passphrase
+// Unlock unlock address with passphrase, for the duration of its policy
 func (m *Manager) Unlock(addr *core.Address, passphrase []byte) error {
+	return m.UnlockWithDuration(addr, passphrase, 0)
+}
+
+// UnlockWithDuration unlock address with passphrase for the duration, capped by its policy or
+// DefaultUnlockDuration of keystore without one, the cap if 0. It's locked again at timeout, and its
+// spending starts over.
+func (m *Manager) UnlockWithDuration(addr *core.Address, passphrase []byte, duration time.Duration) error {
 	res, err := m.ks.ContainsAlias(addr.String())
 	if err != nil || res == false {
 		err = m.loadFile(addr, passphrase)
@@ -154,7 +177,11 @@ func (m *Manager) Unlock(addr *core.Address, passphrase []byte) error {
 			return err
 		}
 	}
-	return m.ks.Unlock(addr.String(), passphrase, keystore.DefaultUnlockDuration)
+	if err := m.ks.Unlock(addr.String(), passphrase, m.unlockDuration(addr, duration)); err != nil {
+		return err
+	}
+	m.resetSpent(addr)
+	return nil
 }
 
 // Lock lock address
@@ -162,13 +189,22 @@ func (m *Manager) Lock(addr *core.Address) error {
 	return m.ks.Lock(addr.String())
 }
 
-// Accounts returns slice of address
+// SignatureAlgorithm returns the signature algorithm of the keys in config, the key of the miner held
+// by remote signers is of it.
+func (m *Manager) SignatureAlgorithm() keystore.Algorithm {
+	return m.signatureAlg
+}
+
+// Accounts returns slice of address, the ones derived from the HD wallet follow the key files
 func (m *Manager) Accounts() []*core.Address {
 	m.refreshAccounts()
 	addrs := make([]*core.Address, len(m.accounts))
 	for index, a := range m.accounts {
 		addrs[index] = a.addr
 	}
+	if hdAddrs, err := m.HDAccounts(); err == nil {
+		addrs = append(addrs, hdAddrs...)
+	}
 	return addrs
 }
 
@@ -201,7 +237,7 @@ func (m *Manager) readKey(keyjson, passphrase []byte, write bool) (*core.Address
 	if err != nil {
 		return nil, err
 	}
-	priv, err := crypto.NewPrivateKey(m.signatureAlg, data)
+	priv, err := crypto.NewPrivateKey(crypto.PrivateKeyAlgorithm(data), data)
 	if err != nil {
 		return nil, err
 	}
@@ -229,6 +265,25 @@ func (m *Manager) Export(addr *core.Address, passphrase []byte) ([]byte, error)
 	return out, nil
 }
 
+// ExportV3 export address to key file of Web3 Secret Storage by the kdf, scrypt or pbkdf2, to be
+// imported by other tooling. The address is left out as it's not of their format.
+func (m *Manager) ExportV3(addr *core.Address, passphrase []byte, kdf string) ([]byte, error) {
+	if res, err := m.ks.ContainsAlias(addr.String()); err != nil || !res {
+		if err := m.loadFile(addr, passphrase); err != nil {
+			return nil, err
+		}
+	}
+	key, err := m.ks.GetKey(addr.String(), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	data, err := key.Encoded()
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewCipher(uint8(m.encryptAlg)).EncryptKeyV3("", data, passphrase, kdf)
+}
+
 // Delete delete address
 func (m *Manager) Delete(a string, passphrase []byte) error {
 	addr, err := core.AddressParse(a)
@@ -256,15 +311,21 @@ func (m *Manager) SignTransaction(addr *core.Address, tx *core.Transaction) erro
 			"err":  ErrTxAddressLocked,
 			"tx":   tx,
 		}).Error("transaction address locked")
+		m.audit(addr, SignKindTransaction, tx.Hash().String(), tx.Value().String(), err)
 		return err
 	}
 
-	signature, err := crypto.NewSignature(m.signatureAlg)
+	signature, err := crypto.NewSignature(key.Algorithm())
 	if err != nil {
 		return err
 	}
 	signature.InitSign(key.(keystore.PrivateKey))
-	return tx.Sign(signature)
+	// the spending is counted only if signed.
+	err = m.spend(tx, func() error {
+		return tx.Sign(signature)
+	})
+	m.audit(addr, SignKindTransaction, tx.Hash().String(), tx.Value().String(), err)
+	return err
 }
 
 // SignBlock sign block with the specified algorithm
@@ -276,15 +337,18 @@ func (m *Manager) SignBlock(addr *core.Address, block *core.Block) error {
 			"err":   ErrBlockAddressLocked,
 			"block": block,
 		}).Error("block signer's address locked")
+		m.audit(addr, SignKindBlock, block.Hash().String(), "", err)
 		return err
 	}
 
-	signature, err := crypto.NewSignature(m.signatureAlg)
+	signature, err := crypto.NewSignature(key.Algorithm())
 	if err != nil {
 		return err
 	}
 	signature.InitSign(key.(keystore.PrivateKey))
-	return block.Sign(signature)
+	err = block.Sign(signature)
+	m.audit(addr, SignKindBlock, block.Hash().String(), "", err)
+	return err
 }
 
 // SignTransactionWithPassphrase sign transaction with the from passphrase
@@ -308,13 +372,16 @@ func (m *Manager) SignTransactionWithPassphrase(addr *core.Address, tx *core.Tra
 			"err":  ErrTxAddressLocked,
 			"tx":   tx,
 		}).Error("transaction address get failed")
+		m.audit(addr, SignKindTransaction, tx.Hash().String(), tx.Value().String(), err)
 		return err
 	}
 
-	signature, err := crypto.NewSignature(m.signatureAlg)
+	signature, err := crypto.NewSignature(key.Algorithm())
 	if err != nil {
 		return err
 	}
 	signature.InitSign(key.(keystore.PrivateKey))
-	return tx.Sign(signature)
+	err = tx.Sign(signature)
+	m.audit(addr, SignKindTransaction, tx.Hash().String(), tx.Value().String(), err)
+	return err
 }
@@ -101,11 +101,11 @@ func (m *Manager) refreshAccounts() error {
 	return nil
 }
 
-// loadFile import key to keystore in keydir
+// loadFile import key to keystore in keydir, or derive it from the HD wallet
 func (m *Manager) loadFile(addr *core.Address, passphrase []byte) error {
 	acc := m.getAccount(addr)
 	if acc == nil {
-		return ErrAddrNotFind
+		return m.loadHDAccount(addr, passphrase)
 	}
 	raw, err := ioutil.ReadFile(acc.path)
 	if err != nil {
@@ -0,0 +1,195 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/cipher"
+	"github.com/nebulasio/go-nebulas/crypto/hd"
+)
+
+// HDMnemonicBits is the entropy of the mnemonic of a new HD wallet, in 12 words.
+const HDMnemonicBits = 128
+
+// the HD wallet is kept in a dir of keydir, which is skipped as key files.
+const (
+	hdWalletDir     = "hdwallet"
+	hdWalletFile    = "wallet.json"
+	hdWalletVersion = 1
+)
+
+var (
+	// ErrHDWalletExists a HD wallet exists in keydir.
+	ErrHDWalletExists = errors.New("hd wallet exists")
+
+	// ErrHDWalletNotFound no HD wallet in keydir.
+	ErrHDWalletNotFound = errors.New("hd wallet not found")
+)
+
+// hdWalletJSON keeps the seed of the HD wallet encrypted by the passphrase, and the addresses derived
+// from it, the keys of the accounts are derived again when they are loaded.
+type hdWalletJSON struct {
+	Version  int              `json:"version"`
+	Path     string           `json:"path"`
+	Seed     json.RawMessage  `json:"seed"`
+	Accounts []*hdAccountJSON `json:"accounts"`
+}
+
+type hdAccountJSON struct {
+	Index   uint32 `json:"index"`
+	Address string `json:"address"`
+}
+
+// NewHDWallet creates a HD wallet of a new mnemonic, which is returned to be backed up.
+func (m *Manager) NewHDWallet(passphrase []byte) (string, error) {
+	mnemonic, err := hd.NewMnemonic(HDMnemonicBits)
+	if err != nil {
+		return "", err
+	}
+	if err := m.ImportHDWallet(mnemonic, passphrase); err != nil {
+		return "", err
+	}
+	return mnemonic, nil
+}
+
+// ImportHDWallet creates the HD wallet of the mnemonic, only its seed encrypted by the passphrase is kept.
+func (m *Manager) ImportHDWallet(mnemonic string, passphrase []byte) error {
+	if _, err := os.Stat(m.hdWalletPath()); err == nil {
+		return ErrHDWalletExists
+	}
+	seed, err := hd.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return err
+	}
+	encrypted, err := cipher.NewCipher(uint8(m.encryptAlg)).Encrypt(seed, passphrase)
+	if err != nil {
+		return err
+	}
+	return m.writeHDWallet(&hdWalletJSON{
+		Version: hdWalletVersion,
+		Path:    hd.AccountPath,
+		Seed:    encrypted,
+	})
+}
+
+// DeriveHDAccount derives the account of the index in the HD wallet, and keeps it in keystore locked
+// by the passphrase of the wallet.
+func (m *Manager) DeriveHDAccount(index uint32, passphrase []byte) (*core.Address, error) {
+	wallet, err := m.readHDWallet()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := m.deriveHDAccount(wallet, index, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	for _, acc := range wallet.Accounts {
+		if acc.Index == index {
+			return addr, nil
+		}
+	}
+	wallet.Accounts = append(wallet.Accounts, &hdAccountJSON{Index: index, Address: addr.String()})
+	return addr, m.writeHDWallet(wallet)
+}
+
+// HDAccounts returns the addresses derived from the HD wallet, in the order of derivation.
+func (m *Manager) HDAccounts() ([]*core.Address, error) {
+	wallet, err := m.readHDWallet()
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]*core.Address, 0, len(wallet.Accounts))
+	for _, acc := range wallet.Accounts {
+		addr, err := core.AddressParse(acc.Address)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// loadHDAccount derives the key of the address again if it's from the HD wallet.
+func (m *Manager) loadHDAccount(addr *core.Address, passphrase []byte) error {
+	wallet, err := m.readHDWallet()
+	if err != nil {
+		return ErrAddrNotFind
+	}
+	for _, acc := range wallet.Accounts {
+		if acc.Address == addr.String() {
+			_, err := m.deriveHDAccount(wallet, acc.Index, passphrase)
+			return err
+		}
+	}
+	return ErrAddrNotFind
+}
+
+func (m *Manager) deriveHDAccount(wallet *hdWalletJSON, index uint32, passphrase []byte) (*core.Address, error) {
+	seed, err := cipher.NewCipher(uint8(m.encryptAlg)).Decrypt(wallet.Seed, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	master, err := hd.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.Derive(fmt.Sprintf("%s/%d", wallet.Path, index))
+	if err != nil {
+		return nil, err
+	}
+	priv, err := key.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return m.storeAddress(priv, passphrase, false)
+}
+
+func (m *Manager) hdWalletPath() string {
+	return filepath.Join(m.keydir, hdWalletDir, hdWalletFile)
+}
+
+func (m *Manager) readHDWallet() (*hdWalletJSON, error) {
+	raw, err := ioutil.ReadFile(m.hdWalletPath())
+	if os.IsNotExist(err) {
+		return nil, ErrHDWalletNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	wallet := new(hdWalletJSON)
+	if err := json.Unmarshal(raw, wallet); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func (m *Manager) writeHDWallet(wallet *hdWalletJSON) error {
+	raw, err := json.Marshal(wallet)
+	if err != nil {
+		return err
+	}
+	return WriteFile(m.hdWalletPath(), raw)
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_HDWallet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keydir")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	manager := NewManager(nil)
+	manager.keydir = dir
+	passphrase := []byte("passphrase")
+
+	_, err = manager.DeriveHDAccount(0, passphrase)
+	assert.Equal(t, ErrHDWalletNotFound, err)
+
+	mnemonic, err := manager.NewHDWallet(passphrase)
+	assert.Nil(t, err)
+	assert.Nil(t, hd.ValidateMnemonic(mnemonic))
+	assert.Equal(t, ErrHDWalletExists, manager.ImportHDWallet(mnemonic, passphrase))
+
+	addr0, err := manager.DeriveHDAccount(0, passphrase)
+	assert.Nil(t, err)
+	addr1, err := manager.DeriveHDAccount(1, passphrase)
+	assert.Nil(t, err)
+	assert.NotEqual(t, addr0, addr1)
+	again, err := manager.DeriveHDAccount(0, passphrase)
+	assert.Nil(t, err)
+	assert.Equal(t, addr0, again)
+	_, err = manager.DeriveHDAccount(2, []byte("wrong"))
+	assert.NotNil(t, err)
+
+	addrs, err := manager.HDAccounts()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(addrs))
+	assert.Contains(t, manager.Accounts(), addr1)
+
+	// the key is derived again to unlock, without key files.
+	assert.Nil(t, manager.ks.Delete(addr1.String(), passphrase))
+	assert.Nil(t, manager.Unlock(addr1, passphrase))
+	assert.Nil(t, manager.Lock(addr1))
+
+	// the same accounts are derived from the mnemonic.
+	restored := NewManager(nil)
+	restored.keydir, err = ioutil.TempDir("", "keydir")
+	assert.Nil(t, err)
+	defer os.RemoveAll(restored.keydir)
+	assert.Nil(t, restored.ImportHDWallet(mnemonic, []byte("another")))
+	addr, err := restored.DeriveHDAccount(1, []byte("another"))
+	assert.Nil(t, err)
+	assert.Equal(t, addr1, addr)
+}
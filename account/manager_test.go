@@ -24,6 +24,8 @@ import (
 	"os"
 
 	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/cipher"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -185,6 +187,25 @@ func TestManager_Export(t *testing.T) {
 	os.RemoveAll(manager.keydir)
 }
 
+func TestManager_ExportV3(t *testing.T) {
+	manager := NewManager(nil)
+	passphrase := []byte("passphrase")
+	addr, err := manager.NewAccount(passphrase)
+	assert.Nil(t, err, "new address err")
+
+	for _, kdf := range []string{cipher.ScryptKDF, cipher.PBKDF2KDF} {
+		keyjson, err := manager.ExportV3(addr, passphrase, kdf)
+		assert.Nil(t, err, "export err")
+		assert.Nil(t, manager.ks.Delete(addr.String(), passphrase))
+		got, err := manager.Load(keyjson, passphrase)
+		assert.Nil(t, err, "load err")
+		assert.Equal(t, addr, got)
+	}
+	_, err = manager.ExportV3(addr, passphrase, "bcrypt")
+	assert.Equal(t, cipher.ErrKDFInvalid, err)
+	os.RemoveAll(manager.keydir)
+}
+
 func TestManager_SignTransaction(t *testing.T) {
 	manager := NewManager(nil)
 	tests := []struct {
@@ -217,3 +238,25 @@ func TestManager_SignTransaction(t *testing.T) {
 	}
 	os.RemoveAll(manager.keydir)
 }
+
+func TestManager_Ed25519Account(t *testing.T) {
+	manager := NewManager(nil)
+	defer os.RemoveAll(manager.keydir)
+	manager.signatureAlg = keystore.ED25519
+	passphrase := []byte("passphrase")
+
+	addr, err := manager.NewAccount(passphrase)
+	assert.Nil(t, err)
+	assert.Nil(t, manager.Unlock(addr, passphrase))
+	tx := core.NewTransaction(0, addr, addr, util.NewUint128FromInt(5), 1, core.TxPayloadBinaryType, nil, util.NewUint128FromInt(1), util.NewUint128FromInt(5))
+	assert.Nil(t, manager.SignTransaction(addr, tx))
+	assert.Nil(t, tx.VerifyIntegrity(0))
+
+	// the key file of the account is loaded as ed25519, whatever the algorithm of new accounts.
+	keyjson, err := manager.Export(addr, passphrase)
+	assert.Nil(t, err)
+	manager.signatureAlg = keystore.SECP256K1
+	loaded, err := manager.Load(keyjson, passphrase)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, loaded)
+}
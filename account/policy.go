@@ -0,0 +1,191 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// AuditLogSize is the count of the latest signing operations kept in the audit log.
+const AuditLogSize = 1024
+
+// kinds of signing operations
+const (
+	SignKindTransaction = "transaction"
+	SignKindBlock       = "block"
+)
+
+var (
+	// ErrSpendLimitExceeded the transaction spends more than the limit left since the unlock.
+	ErrSpendLimitExceeded = errors.New("spend limit of the account exceeded since unlocked")
+
+	// ErrInvalidSpendLimit the spend limit is not a valid uint128.
+	ErrInvalidSpendLimit = errors.New("invalid spend limit")
+)
+
+// AccountPolicy is the usage policy of an account.
+type AccountPolicy struct {
+	// UnlockDuration is the duration the account is unlocked for, and the longest one requested,
+	// DefaultUnlockDuration of keystore if 0.
+	UnlockDuration time.Duration
+
+	// SpendLimit is the most value and fees of the transactions signed between unlocks, no limit if nil.
+	SpendLimit *util.Uint128
+}
+
+// SigningRecord is an entry of the audit log of the signing operations.
+type SigningRecord struct {
+	Time    int64
+	Address string
+	Kind    string
+	Hash    string
+	Value   string
+	Err     string
+}
+
+// SetPolicy sets the usage policy of the address, it's removed if nil.
+func (m *Manager) SetPolicy(addr *core.Address, policy *AccountPolicy) error {
+	if policy != nil && policy.SpendLimit != nil {
+		if err := policy.SpendLimit.Validate(); err != nil {
+			return ErrInvalidSpendLimit
+		}
+	}
+	m.policyMu.Lock()
+	defer m.policyMu.Unlock()
+	if policy == nil {
+		delete(m.policies, addr.String())
+	} else {
+		m.policies[addr.String()] = policy
+	}
+	return nil
+}
+
+// Policy returns the usage policy of the address, nil if none.
+func (m *Manager) Policy(addr *core.Address) *AccountPolicy {
+	m.policyMu.Lock()
+	defer m.policyMu.Unlock()
+	return m.policies[addr.String()]
+}
+
+// unlockDuration returns the duration to unlock the address for, the requested one capped by the policy,
+// or by DefaultUnlockDuration of keystore without one.
+func (m *Manager) unlockDuration(addr *core.Address, requested time.Duration) time.Duration {
+	m.policyMu.Lock()
+	defer m.policyMu.Unlock()
+	limit := keystore.DefaultUnlockDuration
+	if policy := m.policies[addr.String()]; policy != nil && policy.UnlockDuration > 0 {
+		limit = policy.UnlockDuration
+	}
+	if requested <= 0 || requested > limit {
+		return limit
+	}
+	return requested
+}
+
+// resetSpent starts the spending of the address over, at its unlock.
+func (m *Manager) resetSpent(addr *core.Address) {
+	m.policyMu.Lock()
+	defer m.policyMu.Unlock()
+	delete(m.spent, addr.String())
+}
+
+// spend signs the transaction by sign, and counts its value and the most fees to the spending of its
+// sender since unlocked once it's signed. It fails without signing if the spend limit of the policy
+// would be exceeded.
+func (m *Manager) spend(tx *core.Transaction, sign func() error) error {
+	m.policyMu.Lock()
+	defer m.policyMu.Unlock()
+	from := tx.From().String()
+	policy := m.policies[from]
+	if policy == nil || policy.SpendLimit == nil {
+		return sign()
+	}
+	spent := m.spent[from]
+	if spent == nil {
+		spent = util.NewUint128()
+	}
+	total := util.NewUint128()
+	total.Mul(tx.GasPrice().Int, tx.GasLimit().Int)
+	total.Add(total.Int, tx.Value().Int)
+	total.Add(total.Int, spent.Int)
+	if total.Cmp(policy.SpendLimit.Int) > 0 {
+		return ErrSpendLimitExceeded
+	}
+	if err := sign(); err != nil {
+		return err
+	}
+	m.spent[from] = total
+	return nil
+}
+
+// audit records a signing operation in the audit log.
+func (m *Manager) audit(addr *core.Address, kind string, hash string, value string, err error) {
+	record := &SigningRecord{
+		Time:    time.Now().Unix(),
+		Address: addr.String(),
+		Kind:    kind,
+		Hash:    hash,
+		Value:   value,
+	}
+	if err != nil {
+		record.Err = err.Error()
+	}
+	m.policyMu.Lock()
+	m.auditLog = append(m.auditLog, record)
+	if len(m.auditLog) > AuditLogSize {
+		m.auditLog = m.auditLog[len(m.auditLog)-AuditLogSize:]
+	}
+	m.policyMu.Unlock()
+
+	logging.CLog().WithFields(logrus.Fields{
+		"address": record.Address,
+		"kind":    kind,
+		"hash":    hash,
+		"value":   value,
+		"err":     err,
+	}).Info("Audited a signing operation.")
+}
+
+// SigningAudit returns the latest signing operations of the address in the audit log, of all
+// addresses if nil, at most limit of them if it's positive.
+func (m *Manager) SigningAudit(addr *core.Address, limit int) []*SigningRecord {
+	m.policyMu.Lock()
+	defer m.policyMu.Unlock()
+	var records []*SigningRecord
+	for i := len(m.auditLog) - 1; i >= 0; i-- {
+		if limit > 0 && len(records) >= limit {
+			break
+		}
+		if addr == nil || m.auditLog[i].Address == addr.String() {
+			records = append(records, m.auditLog[i])
+		}
+	}
+	// in the order of signing.
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	return records
+}
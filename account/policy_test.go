@@ -0,0 +1,115 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_SpendLimit(t *testing.T) {
+	manager := NewManager(nil)
+	defer os.RemoveAll(manager.keydir)
+	passphrase := []byte("passphrase")
+	addr, err := manager.NewAccount(passphrase)
+	assert.Nil(t, err)
+
+	// each transaction spends 5 of value and 1*5 of fees.
+	newTx := func(nonce uint64) *core.Transaction {
+		return core.NewTransaction(0, addr, addr, util.NewUint128FromInt(5), nonce, core.TxPayloadBinaryType, nil, util.NewUint128FromInt(1), util.NewUint128FromInt(5))
+	}
+	assert.Equal(t, ErrInvalidSpendLimit, manager.SetPolicy(addr, &AccountPolicy{SpendLimit: util.NewUint128FromInt(-1)}))
+	assert.Nil(t, manager.SetPolicy(addr, &AccountPolicy{SpendLimit: util.NewUint128FromInt(25)}))
+	assert.Nil(t, manager.Unlock(addr, passphrase))
+	assert.Nil(t, manager.SignTransaction(addr, newTx(1)))
+	assert.Nil(t, manager.SignTransaction(addr, newTx(2)))
+	assert.Equal(t, ErrSpendLimitExceeded, manager.SignTransaction(addr, newTx(3)))
+
+	// the spending starts over at unlock, and a transaction failed to sign is not counted.
+	assert.Nil(t, manager.Unlock(addr, passphrase))
+	errSign := errors.New("sign failed")
+	assert.Equal(t, errSign, manager.spend(newTx(3), func() error { return errSign }))
+	assert.Nil(t, manager.SignTransaction(addr, newTx(3)))
+	assert.Nil(t, manager.SignTransaction(addr, newTx(4)))
+	assert.Equal(t, ErrSpendLimitExceeded, manager.SignTransaction(addr, newTx(5)))
+
+	assert.Nil(t, manager.SetPolicy(addr, nil))
+	assert.Nil(t, manager.SignTransaction(addr, newTx(4)))
+	assert.Nil(t, manager.SignTransaction(addr, newTx(5)))
+}
+
+func TestManager_UnlockDuration(t *testing.T) {
+	manager := NewManager(nil)
+	defer os.RemoveAll(manager.keydir)
+	passphrase := []byte("passphrase")
+	addr, err := manager.NewAccount(passphrase)
+	assert.Nil(t, err)
+
+	assert.Equal(t, keystore.DefaultUnlockDuration, manager.unlockDuration(addr, 0))
+	assert.Equal(t, keystore.DefaultUnlockDuration, manager.unlockDuration(addr, time.Hour))
+	assert.Equal(t, time.Minute, manager.unlockDuration(addr, time.Minute))
+	assert.Nil(t, manager.SetPolicy(addr, &AccountPolicy{UnlockDuration: time.Minute}))
+	assert.Equal(t, time.Minute, manager.unlockDuration(addr, 0))
+	assert.Equal(t, time.Minute, manager.unlockDuration(addr, time.Hour))
+	assert.Equal(t, time.Second, manager.unlockDuration(addr, time.Second))
+
+	// locked at timeout.
+	assert.Nil(t, manager.UnlockWithDuration(addr, passphrase, 50*time.Millisecond))
+	_, err = manager.ks.GetUnlocked(addr.String())
+	assert.Nil(t, err)
+	time.Sleep(200 * time.Millisecond)
+	_, err = manager.ks.GetUnlocked(addr.String())
+	assert.Equal(t, keystore.ErrNotUnlocked, err)
+}
+
+func TestManager_SigningAudit(t *testing.T) {
+	manager := NewManager(nil)
+	defer os.RemoveAll(manager.keydir)
+	passphrase := []byte("passphrase")
+	addr, err := manager.NewAccount(passphrase)
+	assert.Nil(t, err)
+	other, err := manager.NewAccount(passphrase)
+	assert.Nil(t, err)
+
+	tx := core.NewTransaction(0, addr, addr, util.NewUint128FromInt(5), 1, core.TxPayloadBinaryType, nil, util.NewUint128FromInt(1), util.NewUint128FromInt(5))
+	assert.NotNil(t, manager.SignTransaction(addr, tx))
+	assert.Nil(t, manager.Unlock(addr, passphrase))
+	assert.Nil(t, manager.SignTransaction(addr, tx))
+	otherTx := core.NewTransaction(0, other, other, util.NewUint128FromInt(1), 1, core.TxPayloadBinaryType, nil, util.NewUint128FromInt(1), util.NewUint128FromInt(5))
+	assert.Nil(t, manager.SignTransactionWithPassphrase(other, otherTx, passphrase))
+
+	records := manager.SigningAudit(addr, 0)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, keystore.ErrNotUnlocked.Error(), records[0].Err)
+	assert.Equal(t, "", records[1].Err)
+	assert.Equal(t, SignKindTransaction, records[1].Kind)
+	assert.Equal(t, tx.Hash().String(), records[1].Hash)
+	assert.Equal(t, "5", records[1].Value)
+
+	records = manager.SigningAudit(nil, 2)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, other.String(), records[1].Address)
+}
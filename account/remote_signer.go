@@ -0,0 +1,327 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultRemoteSignerTimeout is the timeout of a request to a remote signer, it must answer in a slot.
+var DefaultRemoteSignerTimeout = 2 * time.Second
+
+// unixSocketScheme is the scheme of the endpoints of remote signers on local sockets.
+const unixSocketScheme = "unix://"
+
+// SignerAuthHeader is the header of the token sent to the remote signers, as "Bearer <token>".
+const SignerAuthHeader = "Authorization"
+
+// Errors of remote signer
+var (
+	ErrNoRemoteSigner         = errors.New("no remote signer configured")
+	ErrRemoteSignerRefused    = errors.New("remote signer refused to sign")
+	ErrInvalidRemoteSignature = errors.New("remote signer returned a signature not of the miner")
+	ErrInsecureRemoteSigner   = errors.New("remote signer must be in https://, http:// of loopback or unix://")
+	ErrInvalidSignerCA        = errors.New("no certificate found in the CA file")
+)
+
+var (
+	remoteSignMeter     = metrics.GetOrRegisterMeter("neb.signer.remote.sign", nil)
+	remoteSignFailMeter = metrics.GetOrRegisterMeter("neb.signer.remote.failed", nil)
+	remoteFailoverMeter = metrics.GetOrRegisterMeter("neb.signer.remote.failover", nil)
+)
+
+// RemoteSignRequest is the request posted to a remote signer.
+type RemoteSignRequest struct {
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+// RemoteSignResponse is the response of a remote signer, the signature in hex or the error.
+type RemoteSignResponse struct {
+	Signature string `json:"signature"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SignerAuth is the credential of the node to the remote signers. The token is sent in header
+// "Authorization", and the TLS files in PEM are used by the endpoints in https://.
+type SignerAuth struct {
+	Token    string
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// tlsConfig returns the TLS config of the endpoints in https://, the system roots verify the signers
+// if no CA file is given.
+func (auth *SignerAuth) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{}
+	if auth == nil {
+		return config, nil
+	}
+	if len(auth.CAFile) > 0 {
+		pool, err := loadCertPool(auth.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if len(auth.CertFile) > 0 || len(auth.KeyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(auth.CertFile, auth.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// loadCertPool returns the pool of the certificates in the PEM file.
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, ErrInvalidSignerCA
+	}
+	return pool, nil
+}
+
+func (auth *SignerAuth) token() string {
+	if auth == nil {
+		return ""
+	}
+	return auth.Token
+}
+
+// checkRemoteEndpoint refuses the endpoints putting the hashes and signatures on the network in plaintext.
+func checkRemoteEndpoint(endpoint string) error {
+	if strings.HasPrefix(endpoint, unixSocketScheme) {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if isLoopbackHost(u.Hostname()) {
+			return nil
+		}
+	}
+	return ErrInsecureRemoteSigner
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+type remoteEndpoint struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func newRemoteEndpoint(endpoint string, timeout time.Duration, token string, tlsConfig *tls.Config) *remoteEndpoint {
+	if !strings.HasPrefix(endpoint, unixSocketScheme) {
+		transport := &http.Transport{TLSClientConfig: tlsConfig}
+		return &remoteEndpoint{url: endpoint, token: token, client: &http.Client{Timeout: timeout, Transport: transport}}
+	}
+	path := strings.TrimPrefix(endpoint, unixSocketScheme)
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}
+	return &remoteEndpoint{url: "http://unix/", token: token, client: &http.Client{Timeout: timeout, Transport: transport}}
+}
+
+// post posts the request in JSON with the token.
+func (e *remoteEndpoint) post(v interface{}) (*http.Response, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(e.token) > 0 {
+		req.Header.Set(SignerAuthHeader, "Bearer "+e.token)
+	}
+	return e.client.Do(req)
+}
+
+// RemoteSigner signs blocks with the key of the miner held by remote signer services, posting the
+// hashes to them in JSON. The first endpoint is the primary, and the standbys are tried in order if
+// it fails, the one succeeded is kept in use. The signatures are checked to be of the miner, and
+// every request is audited in log.
+type RemoteSigner struct {
+	miner     *core.Address
+	alg       keystore.Algorithm
+	endpoints []*remoteEndpoint
+
+	mu     sync.Mutex
+	active int
+}
+
+// NewRemoteSigner returns the remote signer of the miner on the endpoints, the auth is optional.
+func NewRemoteSigner(miner *core.Address, alg keystore.Algorithm, endpoints []string, auth *SignerAuth) (*RemoteSigner, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoRemoteSigner
+	}
+	tlsConfig, err := auth.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	s := &RemoteSigner{miner: miner, alg: alg}
+	for _, v := range endpoints {
+		if err := checkRemoteEndpoint(v); err != nil {
+			return nil, err
+		}
+		s.endpoints = append(s.endpoints, newRemoteEndpoint(v, DefaultRemoteSignerTimeout, auth.token(), tlsConfig))
+	}
+	return s, nil
+}
+
+// Algorithm returns the signature algorithm of the miner's key.
+func (s *RemoteSigner) Algorithm() keystore.Algorithm {
+	return s.alg
+}
+
+// Sign returns the signature of the data by the miner, failing over to the standbys.
+func (s *RemoteSigner) Sign(data []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for i := 0; i < len(s.endpoints); i++ {
+		index := (s.active + i) % len(s.endpoints)
+		var sign []byte
+		if sign, err = s.request(s.endpoints[index], data); err != nil {
+			continue
+		}
+		if index != s.active {
+			remoteFailoverMeter.Mark(1)
+			logging.CLog().WithFields(logrus.Fields{
+				"from": s.endpoints[s.active].url,
+				"to":   s.endpoints[index].url,
+			}).Warn("Failed over to another remote signer.")
+			s.active = index
+		}
+		return sign, nil
+	}
+	return nil, err
+}
+
+func (s *RemoteSigner) request(endpoint *remoteEndpoint, data []byte) (sign []byte, err error) {
+	start := time.Now()
+	remoteSignMeter.Mark(1)
+	defer func() {
+		fields := logrus.Fields{
+			"signer":  endpoint.url,
+			"miner":   s.miner.String(),
+			"hash":    byteutils.Hex(data),
+			"elapsed": time.Since(start),
+		}
+		if err != nil {
+			remoteSignFailMeter.Mark(1)
+			fields["err"] = err
+			logging.VLog().WithFields(fields).Error("Remote signer failed to sign.")
+			return
+		}
+		logging.VLog().WithFields(fields).Info("Remote signer signed.")
+	}()
+
+	resp, err := endpoint.post(&RemoteSignRequest{Address: s.miner.String(), Hash: byteutils.Hex(data)})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := new(RemoteSignResponse)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK || len(result.Error) > 0 {
+		return nil, ErrRemoteSignerRefused
+	}
+	sign, err = byteutils.FromHex(result.Signature)
+	if err != nil {
+		return nil, ErrInvalidRemoteSignature
+	}
+	if err := s.verify(data, sign); err != nil {
+		return nil, err
+	}
+	return sign, nil
+}
+
+// verify checks the signature is of the miner, a compromised or misconfigured signer never
+// makes the miner broadcast blocks of others.
+func (s *RemoteSigner) verify(data []byte, sign []byte) error {
+	signature, err := crypto.NewSignature(s.alg)
+	if err != nil {
+		return err
+	}
+	pub, err := signature.RecoverPublic(data, sign)
+	if err != nil {
+		return ErrInvalidRemoteSignature
+	}
+	pubdata, err := pub.Encoded()
+	if err != nil {
+		return err
+	}
+	addr, err := core.NewAddressFromPublicKey(pubdata)
+	if err != nil {
+		return err
+	}
+	if !addr.Equals(s.miner) {
+		return ErrInvalidRemoteSignature
+	}
+	return nil
+}
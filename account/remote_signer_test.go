@@ -0,0 +1,163 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockRemoteSigner(t *testing.T, key *secp256k1.PrivateKey) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		req := new(RemoteSignRequest)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(req))
+		hash, err := byteutils.FromHex(req.Hash)
+		assert.Nil(t, err)
+		sign, err := key.Sign(hash)
+		assert.Nil(t, err)
+		json.NewEncoder(w).Encode(&RemoteSignResponse{Signature: byteutils.Hex(sign)})
+	}))
+	return server, &requests
+}
+
+func keyAddress(t *testing.T, key *secp256k1.PrivateKey) *core.Address {
+	pub, err := key.PublicKey().Encoded()
+	assert.Nil(t, err)
+	addr, err := core.NewAddressFromPublicKey(pub)
+	assert.Nil(t, err)
+	return addr
+}
+
+func TestRemoteSigner_Failover(t *testing.T) {
+	key := secp256k1.GeneratePrivateKey()
+	miner := keyAddress(t, key)
+	standby, requests := mockRemoteSigner(t, key)
+	defer standby.Close()
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(&RemoteSignResponse{Error: "unavailable"})
+	}))
+	defer primary.Close()
+
+	_, err := NewRemoteSigner(miner, keystore.SECP256K1, nil, nil)
+	assert.Equal(t, ErrNoRemoteSigner, err)
+
+	signer, err := NewRemoteSigner(miner, keystore.SECP256K1, []string{primary.URL, standby.URL}, nil)
+	assert.Nil(t, err)
+	hash := byteutils.Hash(make([]byte, 32))
+	hash[0] = 1
+	sign, err := signer.Sign(hash)
+	assert.Nil(t, err)
+	assert.Nil(t, signer.verify(hash, sign))
+	assert.Equal(t, 1, *requests)
+	assert.Equal(t, 1, signer.active)
+
+	// the standby is kept in use.
+	primary.Close()
+	_, err = signer.Sign(hash)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, *requests)
+}
+
+func TestRemoteSigner_InvalidSignature(t *testing.T) {
+	// the signer holds the key of another miner.
+	server, _ := mockRemoteSigner(t, secp256k1.GeneratePrivateKey())
+	defer server.Close()
+
+	miner := keyAddress(t, secp256k1.GeneratePrivateKey())
+	signer, err := NewRemoteSigner(miner, keystore.SECP256K1, []string{server.URL}, nil)
+	assert.Nil(t, err)
+	_, err = signer.Sign(make([]byte, 32))
+	assert.Equal(t, ErrInvalidRemoteSignature, err)
+}
+
+func TestRemoteSigner_Auth(t *testing.T) {
+	key := secp256k1.GeneratePrivateKey()
+	miner := keyAddress(t, key)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(SignerAuthHeader) != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(&RemoteSignResponse{Error: "unauthorized"})
+			return
+		}
+		req := new(RemoteSignRequest)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(req))
+		hash, err := byteutils.FromHex(req.Hash)
+		assert.Nil(t, err)
+		sign, err := key.Sign(hash)
+		assert.Nil(t, err)
+		json.NewEncoder(w).Encode(&RemoteSignResponse{Signature: byteutils.Hex(sign)})
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "signer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ca := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, ioutil.WriteFile(ca, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	// the signer is not trusted without the CA.
+	signer, err := NewRemoteSigner(miner, keystore.SECP256K1, []string{server.URL}, &SignerAuth{Token: "secret"})
+	assert.Nil(t, err)
+	_, err = signer.Sign(make([]byte, 32))
+	assert.NotNil(t, err)
+
+	signer, err = NewRemoteSigner(miner, keystore.SECP256K1, []string{server.URL}, &SignerAuth{Token: "wrong", CAFile: ca})
+	assert.Nil(t, err)
+	_, err = signer.Sign(make([]byte, 32))
+	assert.Equal(t, ErrRemoteSignerRefused, err)
+
+	signer, err = NewRemoteSigner(miner, keystore.SECP256K1, []string{server.URL}, &SignerAuth{Token: "secret", CAFile: ca})
+	assert.Nil(t, err)
+	_, err = signer.Sign(make([]byte, 32))
+	assert.Nil(t, err)
+}
+
+func TestRemoteSigner_InsecureEndpoint(t *testing.T) {
+	miner := keyAddress(t, secp256k1.GeneratePrivateKey())
+	tests := []struct {
+		endpoint string
+		err      error
+	}{
+		{"https://10.0.0.1:8686", nil},
+		{"http://127.0.0.1:8686", nil},
+		{"http://localhost:8686", nil},
+		{"unix:///var/run/signer.sock", nil},
+		{"http://10.0.0.1:8686", ErrInsecureRemoteSigner},
+		{"tcp://127.0.0.1:8686", ErrInsecureRemoteSigner},
+	}
+	for _, tt := range tests {
+		_, err := NewRemoteSigner(miner, keystore.SECP256K1, []string{tt.endpoint}, nil)
+		assert.Equal(t, tt.err, err, tt.endpoint)
+	}
+}
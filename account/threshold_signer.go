@@ -0,0 +1,482 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/cipher"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/threshold"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultShareTimeout is the timeout of a request to a share holder, the shares must be in a slot.
+var DefaultShareTimeout = 2 * time.Second
+
+// maxShareCombinations is the most combinations of the shares tried to exclude the bad ones.
+const maxShareCombinations = 1024
+
+// fileScheme is the scheme of the shares in local files.
+const fileScheme = "file://"
+
+// Errors of threshold signer
+var (
+	ErrThresholdNotReached   = errors.New("too few key shares of the miner collected")
+	ErrInvalidKeyShares      = errors.New("key shares collected do not combine into the key of the miner")
+	ErrThresholdSignerInit   = errors.New("threshold signer signs by key shares, not a private key")
+	ErrShareHolderRefused    = errors.New("share holder refused to give the share")
+	ErrShareOfAnotherAccount = errors.New("key share is of another account")
+	ErrShareFileNotEncrypted = errors.New("key share file is not encrypted")
+	ErrInsecureShareHolder   = errors.New("share holder needs the token, its certificate and key, and the CA of the signers")
+	ErrShareHolderAuthFailed = errors.New("share holder refused the signer without a valid token")
+	ErrInvalidShareHash      = errors.New("key share is only given out for a hash")
+)
+
+var (
+	thresholdSignMeter      = metrics.GetOrRegisterMeter("neb.signer.threshold.sign", nil)
+	thresholdSignFailMeter  = metrics.GetOrRegisterMeter("neb.signer.threshold.failed", nil)
+	thresholdShareFailMeter = metrics.GetOrRegisterMeter("neb.signer.threshold.share_failed", nil)
+	thresholdFallbackMeter  = metrics.GetOrRegisterMeter("neb.signer.threshold.fallback", nil)
+)
+
+// ShareRequest is the request posted to a share holder.
+type ShareRequest struct {
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+// ShareResponse is the response of a share holder, the share in hex or the error.
+type ShareResponse struct {
+	Index uint32 `json:"index"`
+	Share string `json:"share"`
+	Error string `json:"error,omitempty"`
+}
+
+// ShareFile is a key share kept by a holder, in JSON. The share is encrypted by a passphrase in the
+// file like the keys in keystore.
+type ShareFile struct {
+	Address   string          `json:"address"`
+	Threshold uint32          `json:"threshold"`
+	Index     uint32          `json:"index"`
+	Crypto    json.RawMessage `json:"crypto"`
+
+	// Share is the decrypted share in hex, never written.
+	Share string `json:"-"`
+}
+
+// ReadShareFile reads the key share in the file, decrypted by the passphrase.
+func ReadShareFile(path string, passphrase []byte) (*ShareFile, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := new(ShareFile)
+	if err := json.Unmarshal(raw, file); err != nil {
+		return nil, err
+	}
+	if len(file.Crypto) == 0 {
+		return nil, ErrShareFileNotEncrypted
+	}
+	value, err := cipher.NewCipher(uint8(keystore.SCRYPT)).Decrypt(file.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer secp256k1.ZeroBytes(value)
+	file.Share = byteutils.Hex(value)
+	return file, nil
+}
+
+// WriteShareFile writes the key share into the file encrypted by the passphrase, readable by the owner only.
+func WriteShareFile(path string, file *ShareFile, passphrase []byte) error {
+	value, err := byteutils.FromHex(file.Share)
+	if err != nil {
+		return threshold.ErrInvalidShare
+	}
+	defer secp256k1.ZeroBytes(value)
+	if file.Crypto, err = cipher.NewCipher(uint8(keystore.SCRYPT)).Encrypt(value, passphrase); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, raw)
+}
+
+// KeyShare returns the key share of the file.
+func (f *ShareFile) KeyShare() (*threshold.Share, error) {
+	value, err := byteutils.FromHex(f.Share)
+	if err != nil {
+		return nil, threshold.ErrInvalidShare
+	}
+	return &threshold.Share{Index: f.Index, Value: value}, nil
+}
+
+// ShareProvider supplies a key share of an account to sign a hash.
+type ShareProvider interface {
+	Share(addr *core.Address, hash []byte) (*threshold.Share, error)
+	String() string
+}
+
+// NewShareProvider returns the provider of the share in a local file of file://, decrypted by the
+// passphrase, or held by a holder at an endpoint of https:// or unix:// as remote signers, requested
+// by the auth.
+func NewShareProvider(endpoint string, timeout time.Duration, passphrase []byte, auth *SignerAuth) (ShareProvider, error) {
+	if strings.HasPrefix(endpoint, fileScheme) {
+		return &fileShareProvider{path: strings.TrimPrefix(endpoint, fileScheme), passphrase: passphrase}, nil
+	}
+	if err := checkRemoteEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := auth.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &remoteShareProvider{name: endpoint, endpoint: newRemoteEndpoint(endpoint, timeout, auth.token(), tlsConfig)}, nil
+}
+
+type fileShareProvider struct {
+	path       string
+	passphrase []byte
+}
+
+func (p *fileShareProvider) Share(addr *core.Address, hash []byte) (*threshold.Share, error) {
+	file, err := ReadShareFile(p.path, p.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if file.Address != addr.String() {
+		return nil, ErrShareOfAnotherAccount
+	}
+	return file.KeyShare()
+}
+
+func (p *fileShareProvider) String() string {
+	return fileScheme + p.path
+}
+
+type remoteShareProvider struct {
+	name     string
+	endpoint *remoteEndpoint
+}
+
+func (p *remoteShareProvider) Share(addr *core.Address, hash []byte) (*threshold.Share, error) {
+	resp, err := p.endpoint.post(&ShareRequest{Address: addr.String(), Hash: byteutils.Hex(hash)})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := new(ShareResponse)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK || len(result.Error) > 0 {
+		return nil, ErrShareHolderRefused
+	}
+	value, err := byteutils.FromHex(result.Share)
+	if err != nil {
+		return nil, threshold.ErrInvalidShare
+	}
+	return &threshold.Share{Index: result.Index, Value: value}, nil
+}
+
+func (p *remoteShareProvider) String() string {
+	return p.name
+}
+
+// NewShareHandler returns the http handler of a share holder serving the share in the file to the
+// signers with the token, only for the hash of a block. Every hash it's given out for is audited in log.
+func NewShareHandler(file *ShareFile, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := new(ShareRequest)
+		resp := &ShareResponse{}
+		status := http.StatusOK
+		if len(token) == 0 || subtle.ConstantTimeCompare([]byte(r.Header.Get(SignerAuthHeader)), []byte("Bearer "+token)) != 1 {
+			status, resp.Error = http.StatusUnauthorized, ErrShareHolderAuthFailed.Error()
+		} else if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			status, resp.Error = http.StatusBadRequest, err.Error()
+		} else if hash, err := byteutils.FromHex(req.Hash); err != nil || len(hash) != core.BlockHashLength {
+			status, resp.Error = http.StatusBadRequest, ErrInvalidShareHash.Error()
+		} else if req.Address != file.Address {
+			status, resp.Error = http.StatusForbidden, ErrShareOfAnotherAccount.Error()
+		} else {
+			resp.Index, resp.Share = file.Index, file.Share
+		}
+		logging.CLog().WithFields(logrus.Fields{
+			"remote":  r.RemoteAddr,
+			"address": req.Address,
+			"hash":    req.Hash,
+			"err":     resp.Error,
+		}).Info("Requested the key share.")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// NewShareServer returns the https server of a share holder on the listen address, serving the share
+// in the file to the signers presenting both a certificate of the CA and the token. The certificate
+// and key of the auth are of the holder, and its CA verifies the signers.
+func NewShareServer(listen string, file *ShareFile, auth *SignerAuth) (*http.Server, error) {
+	if auth == nil || len(auth.Token) == 0 || len(auth.CAFile) == 0 || len(auth.CertFile) == 0 || len(auth.KeyFile) == 0 {
+		return nil, ErrInsecureShareHolder
+	}
+	pool, err := loadCertPool(auth.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(auth.CertFile, auth.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Server{
+		Addr:    listen,
+		Handler: NewShareHandler(file, auth.Token),
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    pool,
+		},
+	}, nil
+}
+
+// ThresholdSigner signs blocks with the key of the miner split into key shares kept by holders on
+// different machines, any threshold of them combine into the key. The shares are collected for each
+// block, the key is combined in memory for the signature only and cleared after it. The shares are
+// checked to combine into the key of the miner, a bad one is excluded by the other combinations,
+// and the fallback signer signs if too few good shares are collected.
+type ThresholdSigner struct {
+	miner     *core.Address
+	alg       keystore.Algorithm
+	threshold int
+	providers []ShareProvider
+	fallback  core.BlockSigner
+	verifier  keystore.Signature
+
+	mu sync.Mutex
+}
+
+// NewThresholdSigner returns the threshold signer of the miner's key of the algorithm by the shares
+// at the endpoints. The passphrase decrypts the share files, and the auth requests the holders.
+func NewThresholdSigner(miner *core.Address, alg keystore.Algorithm, t int, endpoints []string, passphrase []byte, auth *SignerAuth) (*ThresholdSigner, error) {
+	if t < 1 || len(endpoints) < t || len(endpoints) > threshold.MaxShares {
+		return nil, threshold.ErrInvalidThreshold
+	}
+	verifier, err := crypto.NewSignature(alg)
+	if err != nil {
+		return nil, err
+	}
+	s := &ThresholdSigner{miner: miner, alg: alg, threshold: t, verifier: verifier}
+	for _, v := range endpoints {
+		provider, err := NewShareProvider(v, DefaultShareTimeout, passphrase, auth)
+		if err != nil {
+			return nil, err
+		}
+		s.providers = append(s.providers, provider)
+	}
+	return s, nil
+}
+
+// SetFallback sets the signer used if the shares fail.
+func (s *ThresholdSigner) SetFallback(signer core.BlockSigner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fallback = signer
+}
+
+// Algorithm returns the signature algorithm of the miner's key.
+func (s *ThresholdSigner) Algorithm() keystore.Algorithm {
+	return s.alg
+}
+
+// InitSign fails, the threshold signer signs by the key shares.
+func (s *ThresholdSigner) InitSign(privateKey keystore.PrivateKey) error {
+	return ErrThresholdSignerInit
+}
+
+// Sign returns the signature of the data by the key combined of the shares, or by the fallback signer.
+func (s *ThresholdSigner) Sign(data []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thresholdSignMeter.Mark(1)
+	sign, err := s.sign(data)
+	if err == nil {
+		return sign, nil
+	}
+	thresholdSignFailMeter.Mark(1)
+	logging.VLog().WithFields(logrus.Fields{
+		"miner": s.miner.String(),
+		"hash":  byteutils.Hex(data),
+		"err":   err,
+	}).Error("Failed to sign by key shares.")
+	if s.fallback == nil {
+		return nil, err
+	}
+	thresholdFallbackMeter.Mark(1)
+	logging.CLog().WithFields(logrus.Fields{
+		"miner": s.miner.String(),
+		"err":   err,
+	}).Warn("Fell back from key shares to another signer.")
+	return s.fallback.Sign(data)
+}
+
+func (s *ThresholdSigner) sign(data []byte) ([]byte, error) {
+	shares := s.collect(data)
+	if len(shares) < s.threshold {
+		return nil, ErrThresholdNotReached
+	}
+
+	var sign []byte
+	tried := 0
+	found := combinations(len(shares), s.threshold, func(picked []int) bool {
+		if tried++; tried > maxShareCombinations {
+			return true
+		}
+		subset := make([]*threshold.Share, len(picked))
+		for i, v := range picked {
+			subset[i] = shares[v]
+		}
+		key, err := threshold.Combine(subset, s.threshold)
+		if err != nil {
+			return false
+		}
+		defer secp256k1.ZeroBytes(key)
+		sign, err = s.signByKey(key, data)
+		return err == nil
+	})
+	if !found || sign == nil {
+		return nil, ErrInvalidKeyShares
+	}
+	return sign, nil
+}
+
+// collect requests the shares from all the holders at once.
+func (s *ThresholdSigner) collect(data []byte) []*threshold.Share {
+	results := make([]*threshold.Share, len(s.providers))
+	var wg sync.WaitGroup
+	for i, provider := range s.providers {
+		wg.Add(1)
+		go func(i int, provider ShareProvider) {
+			defer wg.Done()
+			share, err := provider.Share(s.miner, data)
+			if err != nil {
+				thresholdShareFailMeter.Mark(1)
+				logging.VLog().WithFields(logrus.Fields{
+					"holder": provider.String(),
+					"miner":  s.miner.String(),
+					"err":    err,
+				}).Warn("Failed to collect the key share.")
+				return
+			}
+			results[i] = share
+		}(i, provider)
+	}
+	wg.Wait()
+
+	var shares []*threshold.Share
+	for _, share := range results {
+		if share != nil {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
+// signByKey signs the data by the key if it's the key of the miner.
+func (s *ThresholdSigner) signByKey(key []byte, data []byte) ([]byte, error) {
+	if crypto.PrivateKeyAlgorithm(key) != s.alg {
+		return nil, ErrInvalidKeyShares
+	}
+	priv, err := crypto.NewPrivateKey(s.alg, key)
+	if err != nil {
+		return nil, err
+	}
+	defer priv.Clear()
+	pub, err := priv.PublicKey().Encoded()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := core.NewAddressFromPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	if !addr.Equals(s.miner) {
+		return nil, ErrInvalidKeyShares
+	}
+	signature, err := crypto.NewSignature(s.alg)
+	if err != nil {
+		return nil, err
+	}
+	if err := signature.InitSign(priv); err != nil {
+		return nil, err
+	}
+	return signature.Sign(data)
+}
+
+// RecoverPublic returns the public key recovered of the data and signature.
+func (s *ThresholdSigner) RecoverPublic(data []byte, signature []byte) (keystore.PublicKey, error) {
+	return s.verifier.RecoverPublic(data, signature)
+}
+
+// InitVerify initializes the verification by the public key.
+func (s *ThresholdSigner) InitVerify(publicKey keystore.PublicKey) error {
+	return s.verifier.InitVerify(publicKey)
+}
+
+// Verify verifies the signature by the public key of InitVerify, the signatures of the combined key
+// are plain ones of its algorithm.
+func (s *ThresholdSigner) Verify(data []byte, signature []byte) (bool, error) {
+	return s.verifier.Verify(data, signature)
+}
+
+// combinations calls fn with the combinations of k of [0, n) in order, till it returns true.
+func combinations(n, k int, fn func([]int) bool) bool {
+	picked := make([]int, k)
+	var pick func(start, depth int) bool
+	pick = func(start, depth int) bool {
+		if depth == k {
+			return fn(picked)
+		}
+		for i := start; i <= n-(k-depth); i++ {
+			picked[depth] = i
+			if pick(i+1, depth+1) {
+				return true
+			}
+		}
+		return false
+	}
+	return pick(0, 0)
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/threshold"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ keystore.Signature = (*ThresholdSigner)(nil)
+
+// mockTLSFiles writes a CA, the certificate and key of a holder on 127.0.0.1 and of a signer in PEM into dir.
+func mockTLSFiles(t *testing.T, dir string) (holder, signer *SignerAuth) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	assert.Nil(t, err)
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0600))
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err)
+		cert := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, cert, ca, &key.PublicKey, caKey)
+		assert.Nil(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		assert.Nil(t, err)
+		certFile, keyFile := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+".key")
+		assert.Nil(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+		assert.Nil(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+		return certFile, keyFile
+	}
+	holderCert, holderKey := issue("holder", 2, x509.ExtKeyUsageServerAuth)
+	signerCert, signerKey := issue("signer", 3, x509.ExtKeyUsageClientAuth)
+	holder = &SignerAuth{Token: "secret", CAFile: caFile, CertFile: holderCert, KeyFile: holderKey}
+	signer = &SignerAuth{Token: "secret", CAFile: caFile, CertFile: signerCert, KeyFile: signerKey}
+	return holder, signer
+}
+
+// mockShareHolder serves the share file on a local port by https, it returns the endpoint.
+func mockShareHolder(t *testing.T, file *ShareFile, auth *SignerAuth) (string, func()) {
+	server, err := NewShareServer("127.0.0.1:0", file, auth)
+	assert.Nil(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	go server.ServeTLS(listener, "", "")
+	return "https://" + listener.Addr().String(), func() { server.Close() }
+}
+
+func TestThresholdSigner_Sign(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shares")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	key := secp256k1.GeneratePrivateKey()
+	miner := keyAddress(t, key)
+	data, err := key.Encoded()
+	assert.Nil(t, err)
+	shares, err := threshold.Split(data, 2, 3)
+	assert.Nil(t, err)
+	files := make([]*ShareFile, len(shares))
+	for i, share := range shares {
+		files[i] = &ShareFile{Address: miner.String(), Threshold: 2, Index: share.Index, Share: byteutils.Hex(share.Value)}
+	}
+
+	// a good share in file, one corrupted, one served by its holder, and a holder missing.
+	passphrase := []byte("passphrase")
+	good := filepath.Join(dir, "good")
+	assert.Nil(t, WriteShareFile(good, files[0], passphrase))
+	corrupted := filepath.Join(dir, "corrupted")
+	files[1].Share = byteutils.Hex(make([]byte, 32))
+	assert.Nil(t, WriteShareFile(corrupted, files[1], passphrase))
+	holderAuth, auth := mockTLSFiles(t, dir)
+	holder, stop := mockShareHolder(t, files[2], holderAuth)
+	defer stop()
+	missing := fileScheme + filepath.Join(dir, "missing")
+
+	_, err = NewThresholdSigner(miner, keystore.SECP256K1, 3, []string{good, missing}, passphrase, auth)
+	assert.Equal(t, threshold.ErrInvalidThreshold, err)
+
+	signer, err := NewThresholdSigner(miner, keystore.SECP256K1, 2, []string{fileScheme + corrupted, missing, fileScheme + good, holder}, passphrase, auth)
+	assert.Nil(t, err)
+	hash := byteutils.Hash(make([]byte, 32))
+	hash[0] = 1
+	sign, err := signer.Sign(hash)
+	assert.Nil(t, err)
+	pub, err := signer.RecoverPublic(hash, sign)
+	assert.Nil(t, err)
+	assert.Nil(t, signer.InitVerify(pub))
+	ok, err := signer.Verify(hash, sign)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ErrThresholdSignerInit, signer.InitSign(key))
+
+	// too few good shares, the fallback signs.
+	signer, err = NewThresholdSigner(miner, keystore.SECP256K1, 2, []string{fileScheme + corrupted, missing, fileScheme + good}, passphrase, auth)
+	assert.Nil(t, err)
+	_, err = signer.Sign(hash)
+	assert.Equal(t, ErrInvalidKeyShares, err)
+	signer.SetFallback(key)
+	sign, err = signer.Sign(hash)
+	assert.Nil(t, err)
+	pub, err = signer.RecoverPublic(hash, sign)
+	assert.Nil(t, err)
+	encoded, _ := pub.Encoded()
+	expected, _ := key.PublicKey().Encoded()
+	assert.Equal(t, expected, encoded)
+
+	// the shares of another account are refused.
+	other := keyAddress(t, secp256k1.GeneratePrivateKey())
+	signer, err = NewThresholdSigner(other, keystore.SECP256K1, 1, []string{fileScheme + good, holder}, passphrase, auth)
+	assert.Nil(t, err)
+	_, err = signer.Sign(hash)
+	assert.Equal(t, ErrThresholdNotReached, err)
+}
+
+func TestShareFile_Encrypted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shares")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "share")
+	file := &ShareFile{Address: "n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE", Threshold: 2, Index: 1, Share: byteutils.Hex([]byte("the key share"))}
+	assert.Nil(t, WriteShareFile(path, file, []byte("passphrase")))
+
+	raw, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(raw), file.Share))
+
+	_, err = ReadShareFile(path, []byte("wrong"))
+	assert.NotNil(t, err)
+	read, err := ReadShareFile(path, []byte("passphrase"))
+	assert.Nil(t, err)
+	assert.Equal(t, file.Share, read.Share)
+	assert.Equal(t, file.Index, read.Index)
+}
+
+func TestShareHolder_Auth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shares")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	key := secp256k1.GeneratePrivateKey()
+	miner := keyAddress(t, key)
+	file := &ShareFile{Address: miner.String(), Threshold: 1, Index: 1, Share: byteutils.Hex([]byte("the key share"))}
+	holderAuth, auth := mockTLSFiles(t, dir)
+
+	_, err = NewShareServer("127.0.0.1:0", file, &SignerAuth{CAFile: holderAuth.CAFile, CertFile: holderAuth.CertFile, KeyFile: holderAuth.KeyFile})
+	assert.Equal(t, ErrInsecureShareHolder, err)
+
+	holder, stop := mockShareHolder(t, file, holderAuth)
+	defer stop()
+	hash := make([]byte, 32)
+
+	provider, err := NewShareProvider(holder, DefaultShareTimeout, nil, auth)
+	assert.Nil(t, err)
+	share, err := provider.Share(miner, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("the key share"), share.Value)
+
+	// the share is only given out for a hash.
+	_, err = provider.Share(miner, []byte("not a hash"))
+	assert.Equal(t, ErrShareHolderRefused, err)
+
+	// the signers without the token are refused.
+	provider, err = NewShareProvider(holder, DefaultShareTimeout, nil, &SignerAuth{Token: "wrong", CAFile: auth.CAFile, CertFile: auth.CertFile, KeyFile: auth.KeyFile})
+	assert.Nil(t, err)
+	_, err = provider.Share(miner, hash)
+	assert.Equal(t, ErrShareHolderRefused, err)
+
+	// the signers without a certificate of the CA never complete the handshake.
+	provider, err = NewShareProvider(holder, DefaultShareTimeout, nil, &SignerAuth{Token: auth.Token, CAFile: auth.CAFile})
+	assert.Nil(t, err)
+	_, err = provider.Share(miner, hash)
+	assert.NotNil(t, err)
+
+	// the holders in plain http of another host are refused.
+	_, err = NewShareProvider("http://10.0.0.1:8686", DefaultShareTimeout, nil, auth)
+	assert.Equal(t, ErrInsecureRemoteSigner, err)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// auditor verifies a proof bundle exported by "neb audit export" without running a node.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nebulasio/go-nebulas/core/audit"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+func main() {
+	bundlePath := flag.String("bundle", "bundle.json", "the proof bundle file")
+	trustedHash := flag.String("trusted", "", "the hash of the last block in bundle, got from a trusted source")
+	flag.Parse()
+
+	file, err := os.Open(*bundlePath)
+	if err != nil {
+		fatal(err)
+	}
+	defer file.Close()
+	bundle, err := audit.Decode(file)
+	if err != nil {
+		fatal(err)
+	}
+
+	var trusted byteutils.Hash
+	if len(*trustedHash) > 0 {
+		if trusted, err = byteutils.FromHex(*trustedHash); err != nil {
+			fatal(err)
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, "warning: no trusted hash given, the bundle is only verified to be self-consistent.")
+	}
+
+	report, err := audit.Verify(bundle, trusted)
+	if err != nil {
+		fatal(err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fatal(err)
+	}
+	fmt.Println(string(data))
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "verify failed:", err)
+	os.Exit(1)
+}
@@ -16,10 +16,15 @@ Admin.prototype.newAccount = function (passphrase, callback) {
     return this.request("post", "/v1/admin/account/new", params, callback);
 };
 
-Admin.prototype.unlockAccount = function (address, passphrase, callback) {
+Admin.prototype.unlockAccount = function (address, passphrase, duration, callback) {
+    if (utils.isFunction(duration)) {
+        callback = duration;
+        duration = 0;
+    }
     var params = {
         "address": address,
-        "passphrase": passphrase
+        "passphrase": passphrase,
+        "duration": duration
     };
     return this.request("post", "/v1/admin/account/unlock", params, callback);
 };
@@ -77,6 +82,84 @@ Admin.prototype.getDelegateVoters = function (delegatee, callback) {
     return this.request("post", "/v1/admin/delegateVoters", params, callback);
 };
 
+Admin.prototype.inspectTransactionPool = function (callback) {
+    return this.request("get", "/v1/admin/txpool/inspect", null, callback);
+};
+
+Admin.prototype.dropTransaction = function (hash, callback) {
+    var params = { "hash": hash };
+    return this.request("post", "/v1/admin/txpool/drop", params, callback);
+};
+
+Admin.prototype.setAccountPolicy = function (address, unlockDuration, spendLimit, callback) {
+    var params = {
+        "address": address,
+        "unlock_duration": unlockDuration,
+        "spend_limit": spendLimit
+    };
+    return this.request("post", "/v1/admin/account/policy", params, callback);
+};
+
+Admin.prototype.getSigningAudit = function (address, limit, callback) {
+    var params = {
+        "address": address,
+        "limit": limit
+    };
+    return this.request("post", "/v1/admin/account/audit", params, callback);
+};
+
+Admin.prototype.getPeers = function (callback) {
+    return this.request("get", "/v1/admin/peers", null, callback);
+};
+
+Admin.prototype.banPeer = function (id, duration, callback) {
+    var params = { "id": id, "duration": duration };
+    return this.request("post", "/v1/admin/peers/ban", params, callback);
+};
+
+Admin.prototype.unbanPeer = function (id, callback) {
+    var params = { "id": id };
+    return this.request("post", "/v1/admin/peers/unban", params, callback);
+};
+
+Admin.prototype.exportPeers = function (callback) {
+    return this.request("get", "/v1/admin/peers/export", null, callback);
+};
+
+Admin.prototype.importPeers = function (peers, callback) {
+    var params = { "peers": peers };
+    return this.request("post", "/v1/admin/peers/import", params, callback);
+};
+
+Admin.prototype.getBootNodes = function (callback) {
+    return this.request("get", "/v1/admin/bootNodes", null, callback);
+};
+
+Admin.prototype.addBootNode = function (addr, callback) {
+    var params = { "addr": addr };
+    return this.request("post", "/v1/admin/bootNodes/add", params, callback);
+};
+
+Admin.prototype.removeBootNode = function (addr, callback) {
+    var params = { "addr": addr };
+    return this.request("post", "/v1/admin/bootNodes/remove", params, callback);
+};
+
+Admin.prototype.setLogLevel = function (level, callback) {
+    var params = { "level": level };
+    return this.request("post", "/v1/admin/logLevel", params, callback);
+};
+
+Admin.prototype.exportChain = function (file, from, to, callback) {
+    var params = { "file": file, "from": from, "to": to };
+    return this.request("post", "/v1/admin/chain/export", params, callback);
+};
+
+Admin.prototype.setProfiling = function (enable, listen, callback) {
+    var params = { "enable": enable, "listen": listen };
+    return this.request("post", "/v1/admin/pprof", params, callback);
+};
+
 Admin.prototype.request = function (method, api, params, callback) {
     if (utils.isFunction(callback)) {
         this._request.asyncRequest(method, api, params, callback);
@@ -30,6 +30,9 @@ API.prototype.blockDump = function (count, callback) {
 
 API.prototype.getAccountState = function (address, block, callback) {
     var params = { "address": address, "block": block };
+    if (typeof block === "number") {
+        params = { "address": address, "height": block };
+    }
 	return this.request("post", "/v1/user/accountstate", params, callback);
 };
 
@@ -64,11 +67,31 @@ API.prototype.sendRawTransaction = function (data, callback) {
 	return this.request("post", "/v1/user/rawtransaction", params, callback);
 };
 
+API.prototype.getNextNonce = function (address, callback) {
+	var params = { "address": address };
+	return this.request("post", "/v1/user/nonce", params, callback);
+};
+
+API.prototype.releaseNonce = function (address, nonce, callback) {
+	var params = { "address": address, "nonce": nonce };
+	return this.request("post", "/v1/user/nonce/release", params, callback);
+};
+
 API.prototype.getBlockByHash = function (hash, callback) {
 	var params = { "hash": hash };
 	return this.request("post", "/v1/user/getBlockByHash", params, callback);
 };
 
+API.prototype.getBlockByHeight = function (height, callback) {
+	var params = { "height": height };
+	return this.request("post", "/v1/user/getBlockByHeight", params, callback);
+};
+
+API.prototype.getTransactionByHash = function (hash, callback) {
+	var params = { "hash": hash };
+	return this.request("post", "/v1/user/getTransactionByHash", params, callback);
+};
+
 API.prototype.getTransactionReceipt = function (hash, callback) {
 	var params = { "hash": hash };
 	return this.request("post", "/v1/user/getTransactionReceipt", params, callback);
@@ -102,6 +125,44 @@ API.prototype.getEventsByHash = function (hash, callback) {
     return this.request("post", "/v1/user/getEventsByHash", params, callback);
 };
 
+API.prototype.newEventFilter = function (topics, contract, fromHeight, toHeight, callback) {
+	var params = { "topics": topics, "contract": contract, "from_height": fromHeight, "to_height": toHeight };
+	return this.request("post", "/v1/user/filter/new", params, callback);
+};
+
+API.prototype.getFilterChanges = function (id, callback) {
+	var params = { "id": id };
+	return this.request("post", "/v1/user/filter/changes", params, callback);
+};
+
+API.prototype.getFilterLogs = function (id, callback) {
+	var params = { "id": id };
+	return this.request("post", "/v1/user/filter/logs", params, callback);
+};
+
+API.prototype.uninstallEventFilter = function (id, callback) {
+	var params = { "id": id };
+	return this.request("post", "/v1/user/filter/uninstall", params, callback);
+};
+
+API.prototype.getDynastySnapshot = function (height, callback) {
+    var params = { "height": height };
+    return this.request("post", "/v1/user/dynastySnapshot", params, callback);
+};
+
+API.prototype.getProof = function (kind, key, height, callback) {
+    var params = { "kind": kind, "key": key, "height": height };
+    return this.request("post", "/v1/user/proof", params, callback);
+};
+
+API.prototype.getContractMetadata = function (address, block, callback) {
+    var params = { "address": address, "block": block };
+    if (typeof block === "number") {
+        params = { "address": address, "height": block };
+    }
+    return this.request("post", "/v1/user/contract/metadata", params, callback);
+};
+
 API.prototype.request = function (method, api, params, callback) {
     if (utils.isFunction(callback)) {
         this._request.asyncRequest(method, api, params, callback);
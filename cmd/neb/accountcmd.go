@@ -19,11 +19,21 @@
 package main
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strconv"
 
+	"github.com/nebulasio/go-nebulas/account"
 	"github.com/nebulasio/go-nebulas/cmd/console"
 	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/cipher"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/threshold"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/urfave/cli"
 )
 
@@ -72,7 +82,117 @@ Update an existing account.`,
 				Description: `
     neb account import <keyfile>
 
-Imports an encrypted private key from <keyfile> and creates a new account.`,
+Imports an encrypted private key from <keyfile> and creates a new account.
+The key files of Web3 Secret Storage by scrypt or pbkdf2 are accepted.`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Export an account into a key file of Web3 Secret Storage",
+				Action:    MergeFlags(accountExport),
+				ArgsUsage: "<address> <keyFile> [scrypt|pbkdf2]",
+				Description: `
+    neb account export <address> <keyfile> [scrypt|pbkdf2]
+
+Exports the private key of <address> encrypted by its passphrase into <keyfile>, in the
+format of Web3 Secret Storage read by other tooling, by scrypt if the kdf not given.`,
+			},
+			{
+				Name:      "signtx",
+				Usage:     "Sign a transfer offline by a key file",
+				Action:    MergeFlags(accountSignTransaction),
+				ArgsUsage: "<keyFile> <to> <value> <nonce> [gasPrice] [gasLimit]",
+				Description: `
+    neb account signtx <keyfile> <to> <value> <nonce> [gasPrice] [gasLimit]
+
+Signs a transfer from the account of <keyfile> on the chain of the config without a node, to sign
+on an air-gapped machine. The transaction is printed in base64, to be sent as the data of
+/v1/user/rawtransaction.`,
+			},
+			{
+				Name:  "hd",
+				Usage: "Manage the HD wallet",
+				Description: `
+Manage the hierarchical deterministic wallet, all its accounts are derived from one mnemonic,
+only the seed encrypted by the passphrase is kept in keydir.`,
+				Subcommands: []cli.Command{
+					{
+						Name:   "new",
+						Usage:  "Create a HD wallet of a new mnemonic",
+						Action: MergeFlags(hdWalletCreate),
+						Description: `
+    neb account hd new
+
+Creates a HD wallet and prints its mnemonic, back it up to restore the accounts.`,
+					},
+					{
+						Name:   "import",
+						Usage:  "Create the HD wallet of a mnemonic",
+						Action: MergeFlags(hdWalletImport),
+						Description: `
+    neb account hd import
+
+Creates the HD wallet of the mnemonic prompted for.`,
+					},
+					{
+						Name:      "derive",
+						Usage:     "Derive an account from the HD wallet",
+						Action:    MergeFlags(hdWalletDerive),
+						ArgsUsage: "<index>",
+						Description: `
+    neb account hd derive <index>
+
+Derives the account of the index on the path m/44'/2718'/0'/0 and prints the address.`,
+					},
+					{
+						Name:   "list",
+						Usage:  "Print the addresses derived from the HD wallet",
+						Action: MergeFlags(hdWalletList),
+					},
+				},
+			},
+			{
+				Name:  "threshold",
+				Usage: "Manage the key shares to sign blocks",
+				Description: `
+Manage the key shares of a miner, any threshold of them combine into its key to sign blocks, see
+threshold_signers in the chain config.`,
+				Subcommands: []cli.Command{
+					{
+						Name:      "split",
+						Usage:     "Split the key of a key file into shares",
+						Action:    MergeFlags(thresholdSplit),
+						ArgsUsage: "<keyFile> <threshold> <count> <dir>",
+						Description: `
+    neb account threshold split <keyfile> <threshold> <count> <dir>
+
+Splits the key of <keyfile> into <count> share files in <dir>, any <threshold> of them combine into
+the key. The shares are encrypted by a new passphrase in the files. Hand the share files to the
+holders and remove the key file from the signing machine.`,
+					},
+					{
+						Name:      "refresh",
+						Usage:     "Refresh the shares of a key",
+						Action:    MergeFlags(thresholdRefresh),
+						ArgsUsage: "<shareFile>...",
+						Description: `
+    neb account threshold refresh <shareFile>...
+
+Rewrites all the share files of a key by new shares of the same key, the old shares never combine
+with the new ones, so shares leaked before are useless. The share files are of the same passphrase.`,
+					},
+					{
+						Name:      "serve",
+						Usage:     "Serve a share file to the signers",
+						Action:    MergeFlags(thresholdServe),
+						ArgsUsage: "<shareFile> <listen> <certFile> <keyFile> <caFile>",
+						Description: `
+    neb account threshold serve <shareFile> <listen> <certFile> <keyFile> <caFile>
+
+Serves the share of <shareFile> on https://<listen> with the certificate and key in PEM, only to the
+threshold signers presenting a certificate of the CA in <caFile> and the token prompted for, see
+signer_tls_cert and signer_token in the chain config.`,
+					},
+				},
 			},
 		},
 	}
@@ -163,6 +283,300 @@ func accountImport(ctx *cli.Context) error {
 	return nil
 }
 
+// hdWalletCreate creates a HD wallet and prints its mnemonic
+func hdWalletCreate(ctx *cli.Context) error {
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	passphrase := getPassPhrase("Your HD wallet is locked with a passphrase. Please give a passphrase. Do not forget this passphrase.", true)
+	mnemonic, err := neb.AccountManager().NewHDWallet([]byte(passphrase))
+	if err != nil {
+		FatalF("hd wallet create failed:%s", err)
+	}
+	fmt.Printf("Mnemonic: %s\nWrite it down and keep it safe, the accounts are restored from it.\n", mnemonic)
+	return nil
+}
+
+// hdWalletImport creates the HD wallet of a mnemonic
+func hdWalletImport(ctx *cli.Context) error {
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	mnemonic, err := console.Stdin.PromptPassphrase("Mnemonic: ")
+	if err != nil {
+		FatalF("Failed to read mnemonic: %v", err)
+	}
+	passphrase := getPassPhrase("Your HD wallet is locked with a passphrase. Please give a passphrase. Do not forget this passphrase.", true)
+	if err := neb.AccountManager().ImportHDWallet(mnemonic, []byte(passphrase)); err != nil {
+		FatalF("hd wallet import failed:%s", err)
+	}
+	fmt.Println("Imported HD wallet, derive its accounts by index.")
+	return nil
+}
+
+// hdWalletDerive derives an account from the HD wallet
+func hdWalletDerive(ctx *cli.Context) error {
+	index, err := strconv.ParseUint(ctx.Args().First(), 10, 31)
+	if err != nil {
+		FatalF("index must be given as argument")
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	passphrase := getPassPhrase("", false)
+	addr, err := neb.AccountManager().DeriveHDAccount(uint32(index), []byte(passphrase))
+	if err != nil {
+		FatalF("hd account derive failed:%s", err)
+	}
+	fmt.Printf("Account #%d: %s\n", index, addr.String())
+	return nil
+}
+
+// hdWalletList lists the accounts derived from the HD wallet
+func hdWalletList(ctx *cli.Context) error {
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := neb.AccountManager().HDAccounts()
+	if err != nil {
+		FatalF("hd wallet list failed:%s", err)
+	}
+	for _, addr := range addrs {
+		fmt.Println(addr.String())
+	}
+	return nil
+}
+
+// accountExport export key to a file of Web3 Secret Storage
+func accountExport(ctx *cli.Context) error {
+	if len(ctx.Args()) < 2 {
+		FatalF("address and keyfile must be given as arguments")
+	}
+	addr, err := core.AddressParse(ctx.Args().Get(0))
+	if err != nil {
+		FatalF("address parse failed:%s,%s", ctx.Args().Get(0), err)
+	}
+	keyfile := ctx.Args().Get(1)
+	kdf := cipher.ScryptKDF
+	if len(ctx.Args()) > 2 {
+		kdf = ctx.Args().Get(2)
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	passphrase := getPassPhrase("", false)
+	keyJSON, err := neb.AccountManager().ExportV3(addr, []byte(passphrase), kdf)
+	if err != nil {
+		FatalF("key export failed:%s", err)
+	}
+	if err := ioutil.WriteFile(keyfile, keyJSON, 0600); err != nil {
+		FatalF("file write failed:%s", err)
+	}
+	fmt.Printf("Export address: %s\n", addr.String())
+	return nil
+}
+
+// accountSignTransaction sign a transfer offline
+func accountSignTransaction(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) < 4 {
+		FatalF("keyfile, to, value and nonce must be given as arguments")
+	}
+	keyJSON, err := ioutil.ReadFile(args.Get(0))
+	if err != nil {
+		FatalF("file read failed:%s", err)
+	}
+	to, err := core.AddressParse(args.Get(1))
+	if err != nil {
+		FatalF("address parse failed:%s,%s", args.Get(1), err)
+	}
+	value, ok := util.NewUint128().FromString(args.Get(2))
+	if !ok {
+		FatalF("invalid value:%s", args.Get(2))
+	}
+	nonce, err := strconv.ParseUint(args.Get(3), 10, 64)
+	if err != nil {
+		FatalF("invalid nonce:%s", args.Get(3))
+	}
+	var gasPrice, gasLimit *util.Uint128
+	if len(args) > 4 {
+		if gasPrice, ok = util.NewUint128().FromString(args.Get(4)); !ok {
+			FatalF("invalid gas price:%s", args.Get(4))
+		}
+	}
+	if len(args) > 5 {
+		if gasLimit, ok = util.NewUint128().FromString(args.Get(5)); !ok {
+			FatalF("invalid gas limit:%s", args.Get(5))
+		}
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	passphrase := getPassPhrase("", false)
+	data, err := cipher.NewCipher(uint8(keystore.SCRYPT)).DecryptKey(keyJSON, []byte(passphrase))
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+	priv, err := crypto.NewPrivateKey(crypto.PrivateKeyAlgorithm(data), data)
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+	pub, err := priv.PublicKey().Encoded()
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+	from, err := core.NewAddressFromPublicKey(pub)
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+
+	tx := core.NewTransaction(neb.Config().Chain.ChainId, from, to, value, nonce, core.TxPayloadBinaryType, nil, gasPrice, gasLimit)
+	raw, err := core.SignTransactionOffline(tx, priv)
+	if err != nil {
+		FatalF("transaction sign failed:%s", err)
+	}
+	fmt.Printf("Hash: %s\nRaw: %s\n", tx.Hash().String(), base64.StdEncoding.EncodeToString(raw))
+	return nil
+}
+
+// thresholdSplit split the key of a key file into share files
+func thresholdSplit(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) < 4 {
+		FatalF("keyfile, threshold, count and dir must be given as arguments")
+	}
+	keyJSON, err := ioutil.ReadFile(args.Get(0))
+	if err != nil {
+		FatalF("file read failed:%s", err)
+	}
+	t, err := strconv.Atoi(args.Get(1))
+	if err != nil {
+		FatalF("invalid threshold:%s", args.Get(1))
+	}
+	n, err := strconv.Atoi(args.Get(2))
+	if err != nil {
+		FatalF("invalid count:%s", args.Get(2))
+	}
+
+	passphrase := getPassPhrase("", false)
+	data, err := cipher.NewCipher(uint8(keystore.SCRYPT)).DecryptKey(keyJSON, []byte(passphrase))
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+	priv, err := crypto.NewPrivateKey(crypto.PrivateKeyAlgorithm(data), data)
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+	pub, err := priv.PublicKey().Encoded()
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+	addr, err := core.NewAddressFromPublicKey(pub)
+	if err != nil {
+		FatalF("key decrypt failed:%s", err)
+	}
+
+	shares, err := threshold.Split(data, t, n)
+	if err != nil {
+		FatalF("key split failed:%s", err)
+	}
+	sharePassphrase := getPassPhrase("Please input a passphrase of the share files.", true)
+	for _, share := range shares {
+		path := filepath.Join(args.Get(3), fmt.Sprintf("%s.share.%d", addr.String(), share.Index))
+		file := &account.ShareFile{
+			Address:   addr.String(),
+			Threshold: uint32(t),
+			Index:     share.Index,
+			Share:     byteutils.Hex(share.Value),
+		}
+		if err := account.WriteShareFile(path, file, []byte(sharePassphrase)); err != nil {
+			FatalF("share file write failed:%s", err)
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+// thresholdRefresh rewrite the share files of a key by new shares
+func thresholdRefresh(ctx *cli.Context) error {
+	paths := ctx.Args()
+	if len(paths) == 0 {
+		FatalF("share files must be given as arguments")
+	}
+	passphrase := getPassPhrase("", false)
+	files := make([]*account.ShareFile, len(paths))
+	shares := make([]*threshold.Share, len(paths))
+	for i, path := range paths {
+		file, err := account.ReadShareFile(path, []byte(passphrase))
+		if err != nil {
+			FatalF("share file read failed:%s,%s", path, err)
+		}
+		if i > 0 && (file.Address != files[0].Address || file.Threshold != files[0].Threshold) {
+			FatalF("share file of another key:%s", path)
+		}
+		if shares[i], err = file.KeyShare(); err != nil {
+			FatalF("share file read failed:%s,%s", path, err)
+		}
+		files[i] = file
+	}
+
+	refreshed, err := threshold.Refresh(shares, int(files[0].Threshold))
+	if err != nil {
+		FatalF("share refresh failed:%s", err)
+	}
+	for i, path := range paths {
+		files[i].Share = byteutils.Hex(refreshed[i].Value)
+		if err := account.WriteShareFile(path, files[i], []byte(passphrase)); err != nil {
+			FatalF("share file write failed:%s", err)
+		}
+	}
+	fmt.Printf("Refreshed %d shares of %s\n", len(paths), files[0].Address)
+	return nil
+}
+
+// thresholdServe serve a share file to the threshold signers
+func thresholdServe(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) < 5 {
+		FatalF("share file, listen address, cert file, key file and CA file must be given as arguments")
+	}
+	passphrase := getPassPhrase("", false)
+	file, err := account.ReadShareFile(args.Get(0), []byte(passphrase))
+	if err != nil {
+		FatalF("share file read failed:%s", err)
+	}
+	token, err := console.Stdin.PromptPassphrase("Token: ")
+	if err != nil {
+		FatalF("Failed to read token: %v", err)
+	}
+	server, err := account.NewShareServer(args.Get(1), file, &account.SignerAuth{
+		Token:    token,
+		CertFile: args.Get(2),
+		KeyFile:  args.Get(3),
+		CAFile:   args.Get(4),
+	})
+	if err != nil {
+		FatalF("share server init failed:%s", err)
+	}
+	fmt.Printf("Serving the share %d of %s on %s\n", file.Index, file.Address, args.Get(1))
+	return server.ListenAndServeTLS("", "")
+}
+
 // getPassPhrase get passphrase from consle
 func getPassPhrase(prompt string, confirmation bool) string {
 	if prompt != "" {
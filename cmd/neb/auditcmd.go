@@ -0,0 +1,99 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/urfave/cli"
+)
+
+var (
+	auditCommand = cli.Command{
+		Name:     "audit",
+		Usage:    "Export chain data proofs for auditors",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Export the proofs of chain data for external auditors.`,
+
+		Subcommands: []cli.Command{
+			{
+				Name:      "export",
+				Usage:     "Export a proof bundle of a height range",
+				Action:    MergeFlags(exportAuditBundle),
+				ArgsUsage: "<from> <to> <output> [address...]",
+				Description: `
+    neb audit export 100 200 bundle.json <address>
+
+Export the headers of blocks in height range [from, to] and the proofs of
+the accounts in the state of block at height to.
+
+The bundle can be verified by cmd/auditor without running a node.`,
+			},
+		},
+	}
+)
+
+func exportAuditBundle(ctx *cli.Context) error {
+	if ctx.NArg() < 3 {
+		FatalF("audit export: invalid arguments, see neb audit export --help")
+	}
+	from, err := strconv.ParseUint(ctx.Args().Get(0), 10, 64)
+	if err != nil {
+		FatalF("audit export: invalid from height: %v", err)
+	}
+	to, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+	if err != nil {
+		FatalF("audit export: invalid to height: %v", err)
+	}
+	var addrs []*core.Address
+	for _, v := range ctx.Args()[3:] {
+		addr, err := core.AddressParse(v)
+		if err != nil {
+			FatalF("audit export: invalid address %s: %v", v, err)
+		}
+		addrs = append(addrs, addr)
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+	if err := neb.Setup(); err != nil {
+		return err
+	}
+	bundle, err := neb.BlockChain().ExportAuditBundle(from, to, addrs)
+	if err != nil {
+		FatalF("audit export: %v", err)
+	}
+
+	file, err := os.Create(ctx.Args().Get(2))
+	if err != nil {
+		FatalF("audit export: %v", err)
+	}
+	defer file.Close()
+	if err := bundle.Encode(file); err != nil {
+		FatalF("audit export: %v", err)
+	}
+	fmt.Printf("audit export: %d headers and %d accounts exported to %s\n", len(bundle.Headers), len(bundle.Accounts), ctx.Args().Get(2))
+	return nil
+}
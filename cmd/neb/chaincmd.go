@@ -20,6 +20,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 
 	"bytes"
@@ -67,6 +68,38 @@ Dump the genesis config info.`,
 		Description: `
 Use "./neb dump 10" to dump 10 blocks before tail block.`,
 	}
+
+	exportCommand = cli.Command{
+		Action:    MergeFlags(exportChain),
+		Name:      "export",
+		Usage:     "Export the blocks of canonical chain into file",
+		ArgsUsage: "<filename> [<fromHeight> [<toHeight>]]",
+		Category:  "BLOCKCHAIN COMMANDS",
+		Description: `
+Use "./neb export chain.dump 1 1000" to export the blocks from height 1 to 1000,
+the blocks are exported up to the tail block if toHeight is omitted.`,
+	}
+
+	importCommand = cli.Command{
+		Action:    MergeFlags(importChain),
+		Name:      "import",
+		Usage:     "Import the blocks exported into blockchain",
+		ArgsUsage: "<filename>",
+		Category:  "BLOCKCHAIN COMMANDS",
+		Description: `
+Use "./neb import chain.dump" to verify the blocks in file and put them on chain.`,
+	}
+
+	repairCommand = cli.Command{
+		Action:    MergeFlags(repairChain),
+		Name:      "repair",
+		Usage:     "Check the stored blocks and quarantine the corrupted ones",
+		ArgsUsage: "[<fromHeight> [<toHeight>]]",
+		Category:  "BLOCKCHAIN COMMANDS",
+		Description: `
+Use "./neb repair" to re-hash the stored blocks of canonical chain and verify their links,
+the corrupted blocks are quarantined and re-fetched from peers once the node starts.`,
+	}
 )
 
 func initGenesis(ctx *cli.Context) error {
@@ -131,3 +164,100 @@ func dumpblock(ctx *cli.Context) error {
 	fmt.Printf("blockchain dump: %s\n", neb.BlockChain().Dump(count))
 	return nil
 }
+
+func exportChain(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		FatalF("export chain faild: filename is missing")
+	}
+	var from, to uint64 = 1, 0
+	var err error
+	if len(ctx.Args()) > 1 {
+		if from, err = strconv.ParseUint(ctx.Args().Get(1), 10, 64); err != nil {
+			FatalF("export chain faild: %v", err)
+		}
+	}
+	if len(ctx.Args()) > 2 {
+		if to, err = strconv.ParseUint(ctx.Args().Get(2), 10, 64); err != nil {
+			FatalF("export chain faild: %v", err)
+		}
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		FatalF("export chain faild: %v", err)
+	}
+	if err := neb.Setup(); err != nil {
+		FatalF("export chain faild: %v", err)
+	}
+
+	file, err := os.Create(ctx.Args().First())
+	if err != nil {
+		FatalF("export chain faild: %v", err)
+	}
+	defer file.Close()
+	if err := neb.BlockChain().Export(file, from, to); err != nil {
+		FatalF("export chain faild: %v", err)
+	}
+	fmt.Println("export chain success.")
+	return nil
+}
+
+func importChain(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		FatalF("import chain faild: filename is missing")
+	}
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		FatalF("import chain faild: %v", err)
+	}
+	if err := neb.Setup(); err != nil {
+		FatalF("import chain faild: %v", err)
+	}
+
+	file, err := os.Open(ctx.Args().First())
+	if err != nil {
+		FatalF("import chain faild: %v", err)
+	}
+	defer file.Close()
+	if err := neb.BlockChain().Import(file); err != nil {
+		FatalF("import chain faild: %v", err)
+	}
+	fmt.Printf("import chain success, tail: %s\n", neb.BlockChain().TailBlock())
+	return nil
+}
+
+func repairChain(ctx *cli.Context) error {
+	var from, to uint64 = 1, 0
+	var err error
+	if len(ctx.Args()) > 0 {
+		if from, err = strconv.ParseUint(ctx.Args().Get(0), 10, 64); err != nil {
+			FatalF("repair chain faild: %v", err)
+		}
+	}
+	if len(ctx.Args()) > 1 {
+		if to, err = strconv.ParseUint(ctx.Args().Get(1), 10, 64); err != nil {
+			FatalF("repair chain faild: %v", err)
+		}
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		FatalF("repair chain faild: %v", err)
+	}
+	if err := neb.Setup(); err != nil {
+		FatalF("repair chain faild: %v", err)
+	}
+
+	report, err := neb.BlockChain().CheckIntegrity(from, to)
+	if err != nil {
+		FatalF("repair chain faild: %v", err)
+	}
+	fmt.Printf("checked %d blocks, quarantined %d corrupted blocks.\n", report.Checked, len(report.Quarantined))
+	for _, hash := range report.Quarantined {
+		fmt.Printf("quarantined: %s\n", hash.Hex())
+	}
+	for _, height := range report.BrokenLinks {
+		fmt.Printf("broken link at height: %d\n", height)
+	}
+	return nil
+}
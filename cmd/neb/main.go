@@ -69,6 +69,11 @@ func main() {
 		licenseCommand,
 		configCommand,
 		blockDumpCommand,
+		exportCommand,
+		importCommand,
+		repairCommand,
+		storageCommand,
+		auditCommand,
 		serializeCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
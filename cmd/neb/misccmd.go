@@ -54,7 +54,7 @@ func _version(ctx *cli.Context) error {
 	if commit != "" {
 		fmt.Println("Git Commit:", commit)
 	}
-	fmt.Println("Protocol Versions:", p2p.ProtocolID)
+	fmt.Println("Protocol Versions:", p2p.ChainProtocolID(neb.Config().Chain.ChainId), p2p.LegacyProtocolID)
 	fmt.Println("Protocol ClientVersion:", p2p.ClientVersion)
 	fmt.Printf("Chain Id: %d\n", neb.Config().Chain.ChainId)
 	fmt.Println("Go Version:", runtime.Version())
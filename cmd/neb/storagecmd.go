@@ -0,0 +1,131 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/urfave/cli"
+)
+
+var (
+	storageCommand = cli.Command{
+		Name:     "storage",
+		Usage:    "Manage the namespaces of storage",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Show the sizes of the namespaces of storage, export or prune a single namespace.`,
+
+		Subcommands: []cli.Command{
+			{
+				Name:   "stats",
+				Usage:  "Show the statistics of storage and the sizes of namespaces",
+				Action: MergeFlags(storageStats),
+			},
+			{
+				Name:      "export",
+				Usage:     "Export the entries of a namespace into file",
+				Action:    MergeFlags(exportNamespace),
+				ArgsUsage: "<namespace> <filename>",
+				Description: `
+    neb storage export txindex txindex.dump`,
+			},
+			{
+				Name:      "prune",
+				Usage:     "Delete all the entries of a namespace",
+				Action:    MergeFlags(pruneNamespace),
+				ArgsUsage: "<namespace>",
+				Description: `
+    neb storage prune txindex
+
+The index of canonical chain is rebuilt on the next start, the headers are
+taken from the blocks. Pruning the other namespaces may lose the data.`,
+			},
+		},
+	}
+)
+
+// openDatabase returns the storage of the neblet, which must be iterable by namespace.
+func openDatabase(ctx *cli.Context) storage.Database {
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		FatalF("open storage faild: %v", err)
+	}
+	if err := neb.Setup(); err != nil {
+		FatalF("open storage faild: %v", err)
+	}
+	db, ok := neb.Storage().(storage.Database)
+	if !ok {
+		FatalF("open storage faild: %v", storage.ErrNamespaceNotIterable)
+	}
+	return db
+}
+
+func storageStats(ctx *cli.Context) error {
+	db := openDatabase(ctx)
+	stats, err := storage.GetStats(db, nil)
+	if err != nil {
+		FatalF("storage stats faild: %v", err)
+	}
+	fmt.Printf("size: %d, pending compactions: %d\n", stats.Size, stats.PendingCompactions)
+	for _, level := range stats.Levels {
+		fmt.Printf("level %d: %d tables, %d bytes\n", level.Level, level.Tables, level.Size)
+	}
+	sizes, err := storage.NamespaceSizes(db)
+	if err != nil {
+		FatalF("storage stats faild: %v", err)
+	}
+	for _, name := range storage.Namespaces() {
+		fmt.Printf("namespace %s: %d bytes\n", name, sizes[name])
+	}
+	return nil
+}
+
+func exportNamespace(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		FatalF("export namespace faild: invalid arguments, see neb storage export --help")
+	}
+	db := openDatabase(ctx)
+	file, err := os.Create(ctx.Args().Get(1))
+	if err != nil {
+		FatalF("export namespace faild: %v", err)
+	}
+	defer file.Close()
+	count, err := storage.ExportNamespace(db, ctx.Args().First(), file)
+	if err != nil {
+		FatalF("export namespace faild: %v", err)
+	}
+	fmt.Printf("export namespace success, %d entries.\n", count)
+	return nil
+}
+
+func pruneNamespace(ctx *cli.Context) error {
+	if ctx.NArg() < 1 {
+		FatalF("prune namespace faild: invalid arguments, see neb storage prune --help")
+	}
+	db := openDatabase(ctx)
+	count, err := storage.PruneNamespace(db, ctx.Args().First())
+	if err != nil {
+		FatalF("prune namespace faild: %v", err)
+	}
+	fmt.Printf("prune namespace success, %d entries deleted.\n", count)
+	return nil
+}
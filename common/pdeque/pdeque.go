@@ -85,6 +85,25 @@ func (q *PriorityDeque) PopMin() interface{} {
 	return nil
 }
 
+// RemoveIf removes the items matching remove, and returns them.
+func (q *PriorityDeque) RemoveIf(remove func(ele interface{}) bool) []interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var removed []interface{}
+	heap := q.heap
+	q.heap = nil
+	for _, ele := range heap {
+		if remove(ele) {
+			removed = append(removed, ele)
+			continue
+		}
+		q.heap = append(q.heap, ele)
+		q.bubbleUp(q.Len() - 1)
+	}
+	return removed
+}
+
 func (q *PriorityDeque) deleteAt(pos int) {
 	heap := q.heap
 	size := len(heap)
@@ -66,3 +66,24 @@ func TestPdeq_2(t *testing.T) {
 	assert.Equal(t, q.PopMin(), 4)
 	assert.Equal(t, q.PopMin(), 5)
 }
+
+func TestPdeq_RemoveIf(t *testing.T) {
+	q := NewPriorityDeque(func(a interface{}, b interface{}) bool { return a.(int) < b.(int) })
+	for _, v := range []int{31, 46, 51, 10, 30, 21, 71, 41, 11, 13, 16, 8} {
+		q.Insert(v)
+	}
+	removed := q.RemoveIf(func(ele interface{}) bool { return ele.(int)%2 == 1 })
+	sum := 0
+	for _, v := range removed {
+		sum += v.(int)
+	}
+	assert.Equal(t, 7, len(removed))
+	assert.Equal(t, 31+51+21+71+41+11+13, sum)
+	assert.Equal(t, 5, q.Len())
+	assert.Equal(t, q.PopMin(), 8)
+	assert.Equal(t, q.PopMax(), 46)
+	assert.Equal(t, q.PopMin(), 10)
+	assert.Equal(t, q.PopMin(), 16)
+	assert.Equal(t, q.PopMax(), 30)
+	assert.Nil(t, q.PopMin())
+}
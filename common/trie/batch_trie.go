@@ -119,10 +119,14 @@ func (bt *BatchTrie) SyncPath(rootHash []byte, key []byte) error {
 }
 
 // Prove the associated node to the key exists in trie
-// if exists, MerkleProof is a complete path from root to the node
-// otherwise, MerkleProof is nil
-func (bt *BatchTrie) Prove(key []byte) (MerkleProof, error) {
-	return bt.trie.Prove(key)
+// if exists, Proof is the serialized path from root to the node
+// otherwise, Proof is nil
+func (bt *BatchTrie) Prove(key []byte) (Proof, error) {
+	proof, err := bt.trie.Prove(key)
+	if err != nil {
+		return nil, err
+	}
+	return NewProof(proof)
 }
 
 // Verify whether the merkle proof from root to the associated node is right
@@ -140,6 +144,21 @@ func (bt *BatchTrie) Iterator(prefix []byte) (*Iterator, error) {
 	return bt.trie.Iterator(prefix)
 }
 
+// RangeIterator return an trie Iterator to traverse leaf node's value with keys in [start, end) in this trie
+func (bt *BatchTrie) RangeIterator(start []byte, end []byte) (*Iterator, error) {
+	return bt.trie.RangeIterator(start, end)
+}
+
+// ScanPrefix calls fn with the leaves with keys of the prefix in this trie
+func (bt *BatchTrie) ScanPrefix(prefix []byte, fn func(key []byte, value []byte) error) error {
+	return bt.trie.ScanPrefix(prefix, fn)
+}
+
+// ScanRange calls fn with the leaves with keys in [start, end) in this trie
+func (bt *BatchTrie) ScanRange(start []byte, end []byte, fn func(key []byte, value []byte) error) error {
+	return bt.trie.ScanRange(start, end, fn)
+}
+
 // BeginBatch to process a batch task
 func (bt *BatchTrie) BeginBatch() error {
 	if bt.batching {
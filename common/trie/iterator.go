@@ -19,25 +19,36 @@
 package trie
 
 import (
+	"bytes"
 	"errors"
 )
 
 // errors constants
 var (
 	ErrNotIterable = errors.New("leaf node is not iterable")
+
+	// ErrStopScan stops a scan without error when returned by the callback.
+	ErrStopScan = errors.New("stop scan")
 )
 
 // IteratorState represents the intermediate statue in iterator
 type IteratorState struct {
 	node *node
 	pos  int
+
+	// the route from root to the node.
+	route []byte
 }
 
-// Iterator to traverse leaf node in a trie
+// Iterator to traverse leaf node in a trie, in ascending order of keys
 type Iterator struct {
 	stack []*IteratorState
+	key   []byte
 	value []byte
 	root  *Trie
+
+	// the keys at or after end are not iterated, no bound if nil.
+	end []byte
 }
 
 func validElementsInBranchNode(offset int, node *node) []int {
@@ -54,9 +65,9 @@ func validElementsInBranchNode(offset int, node *node) []int {
 	return valid
 }
 
-// Iterator return an iterator
+// Iterator return an iterator of the leaves with keys of the prefix
 func (t *Trie) Iterator(prefix []byte) (*Iterator, error) {
-	rootHash, err := t.getSubTrieWithMaxCommonPrefix(prefix)
+	rootHash, route, err := t.getSubTrieWithMaxCommonPrefix(prefix)
 	if err != nil {
 		return nil, err
 	}
@@ -71,53 +82,142 @@ func (t *Trie) Iterator(prefix []byte) (*Iterator, error) {
 	}
 	return &Iterator{
 		root:  t,
-		stack: []*IteratorState{&IteratorState{node, pos}},
+		stack: []*IteratorState{&IteratorState{node, pos, route}},
 		value: nil,
 	}, nil
 }
 
-func (t *Trie) getSubTrieWithMaxCommonPrefix(prefix []byte) ([]byte, error) {
+// RangeIterator returns an iterator of the leaves with keys in [start, end), in ascending order.
+// It starts from the first key if start is nil, and runs to the last if end is nil. The leaves
+// before start are skipped by the route of start, not visited.
+func (t *Trie) RangeIterator(start []byte, end []byte) (*Iterator, error) {
+	it := &Iterator{root: t, end: end}
+	node, err := t.fetchNode(t.rootHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := it.seek(node, keyToRoute(start)); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// seek pushes the states to visit the leaves at or after the route in the sub trie of the node,
+// the states of later siblings are pushed before the earlier ones, so they are popped after.
+func (it *Iterator) seek(n *node, route []byte) error {
+	var prefix []byte
+	for {
+		ty, err := n.Type()
+		if err != nil {
+			return err
+		}
+		switch ty {
+		case branch:
+			if len(route) == 0 {
+				it.pushFirst(n, 0, prefix)
+				return nil
+			}
+			it.pushFirst(n, int(route[0])+1, prefix)
+			child := n.Val[route[0]]
+			if len(child) == 0 {
+				return nil
+			}
+			if n, err = it.root.fetchNode(child); err != nil {
+				return err
+			}
+			prefix = append(append([]byte{}, prefix...), route[0])
+			route = route[1:]
+		case ext:
+			path := n.Val[1]
+			cmp := compareRoute(path, route)
+			if cmp < 0 {
+				// the sub trie is before the route.
+				return nil
+			}
+			if cmp > 0 {
+				it.push(n, 0, prefix)
+				return nil
+			}
+			if n, err = it.root.fetchNode(n.Val[2]); err != nil {
+				return err
+			}
+			prefix = append(append([]byte{}, prefix...), path...)
+			route = route[len(path):]
+		case leaf:
+			if compareRoute(n.Val[1], route) >= 0 {
+				it.push(n, 0, prefix)
+			}
+			return nil
+		default:
+			return errors.New("unknown node type")
+		}
+	}
+}
+
+// compareRoute compares the path with the route in its length, a path of the route compares equal.
+func compareRoute(path []byte, route []byte) int {
+	if len(route) > len(path) {
+		route = route[:len(path)]
+	}
+	if cmp := bytes.Compare(path, route); cmp != 0 || len(route) == len(path) {
+		return cmp
+	}
+	// the route ends in the path, the path is after it.
+	return 1
+}
+
+// pushFirst pushes the state to visit the children of the branch node from pos, if any.
+func (it *Iterator) pushFirst(n *node, pos int, route []byte) {
+	if valid := validElementsInBranchNode(pos, n); len(valid) > 0 {
+		it.push(n, valid[0], route)
+	}
+}
+
+func (t *Trie) getSubTrieWithMaxCommonPrefix(prefix []byte) ([]byte, []byte, error) {
 	curRootHash := t.rootHash
 	curRoute := keyToRoute(prefix)
+	var route []byte
 	for len(curRoute) > 0 {
 		rootNode, err := t.fetchNode(curRootHash)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		flag, err := rootNode.Type()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		switch flag {
 		case branch:
 			curRootHash = rootNode.Val[curRoute[0]]
+			route = append(route, curRoute[0])
 			curRoute = curRoute[1:]
 		case ext:
 			path := rootNode.Val[1]
 			next := rootNode.Val[2]
 			matchLen := prefixLen(path, curRoute)
 			if matchLen != len(path) && matchLen != len(curRoute) {
-				return nil, ErrNotFound
+				return nil, nil, ErrNotFound
 			}
 			curRootHash = next
+			route = append(route, path...)
 			curRoute = curRoute[matchLen:]
 		case leaf:
 			path := rootNode.Val[1]
 			matchLen := prefixLen(path, curRoute)
 			if matchLen != len(path) && matchLen != len(curRoute) {
-				return nil, ErrNotFound
+				return nil, nil, ErrNotFound
 			}
 			curRootHash = rootNode.Hash
 			curRoute = curRoute[matchLen:]
 		default:
-			return nil, errors.New("unknown node type")
+			return nil, nil, errors.New("unknown node type")
 		}
 	}
-	return curRootHash, nil
+	return curRootHash, route, nil
 }
 
-func (it *Iterator) push(node *node, pos int) {
-	it.stack = append(it.stack, &IteratorState{node, pos})
+func (it *Iterator) push(node *node, pos int, route []byte) {
+	it.stack = append(it.stack, &IteratorState{node, pos, route})
 }
 
 func (it *Iterator) pop() (*IteratorState, error) {
@@ -138,6 +238,7 @@ func (it *Iterator) Next() (bool, error) {
 	}
 	node := state.node
 	pos := state.pos
+	route := state.route
 	ty, err := node.Type()
 	for {
 		switch ty {
@@ -147,21 +248,29 @@ func (it *Iterator) Next() (bool, error) {
 				return false, errors.New("empty branch node")
 			}
 			if len(valid) > 1 {
-				it.push(node, valid[1])
+				it.push(node, valid[1], route)
 			}
+			route = append(append([]byte{}, route...), byte(valid[0]))
 			node, err = it.root.fetchNode(node.Val[valid[0]])
 			if err != nil {
 				return false, err
 			}
 			ty, err = node.Type()
 		case ext:
+			route = append(append([]byte{}, route...), node.Val[1]...)
 			node, err = it.root.fetchNode(node.Val[2])
 			if err != nil {
 				return false, err
 			}
 			ty, err = node.Type()
 		case leaf:
+			it.key = routeToKey(append(append([]byte{}, route...), node.Val[1]...))
 			it.value = node.Val[2]
+			if it.end != nil && bytes.Compare(it.key, it.end) >= 0 {
+				it.stack = nil
+				it.key, it.value = nil, nil
+				return false, nil
+			}
 			return true, nil
 		default:
 			return false, err
@@ -170,7 +279,61 @@ func (it *Iterator) Next() (bool, error) {
 	}
 }
 
+// Key return current leaf node's key
+func (it *Iterator) Key() []byte {
+	return it.key
+}
+
 // Value return current leaf node's value
 func (it *Iterator) Value() []byte {
 	return it.value
 }
+
+// ScanPrefix calls fn with the keys and values of the leaves with keys of the prefix in ascending order,
+// it visits the nodes as it goes, never loading the sub trie at once. The scan stops at the first error
+// returned by fn, which is returned unless it's ErrStopScan. Nothing is scanned if no key is of the prefix.
+func (t *Trie) ScanPrefix(prefix []byte, fn func(key []byte, value []byte) error) error {
+	it, err := t.Iterator(prefix)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return it.scan(fn)
+}
+
+// ScanRange calls fn with the keys and values of the leaves with keys in [start, end) in ascending order,
+// like ScanPrefix.
+func (t *Trie) ScanRange(start []byte, end []byte, fn func(key []byte, value []byte) error) error {
+	it, err := t.RangeIterator(start, end)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return it.scan(fn)
+}
+
+func (it *Iterator) scan(fn func(key []byte, value []byte) error) error {
+	exist, err := it.Next()
+	for ; exist && err == nil; exist, err = it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			if err == ErrStopScan {
+				return nil
+			}
+			return err
+		}
+	}
+	return err
+}
+
+// routeToKey returns the key of the route, the inverse of keyToRoute.
+func routeToKey(route []byte) []byte {
+	key := make([]byte, len(route)/2)
+	for i := range key {
+		key[i] = route[i*2]<<4 | route[i*2+1]
+	}
+	return key
+}
@@ -177,3 +177,112 @@ func TestIteratorEmpty(t *testing.T) {
 	assert.Nil(t, iter)
 	assert.Equal(t, err, storage.ErrKeyNotFound)
 }
+
+func mockRangeTrie(t *testing.T) (*Trie, []string) {
+	stor, _ := storage.NewMemoryStorage()
+	tr, _ := NewTrie(nil, stor)
+	names := []string{"123450", "123350", "122450", "223350", "133350", "12345a"}
+	for _, v := range names {
+		key, err := byteutils.FromHex(v)
+		assert.Nil(t, err)
+		_, err = tr.Put(key, []byte(v))
+		assert.Nil(t, err)
+	}
+	return tr, []string{"122450", "123350", "123450", "12345a", "133350", "223350"}
+}
+
+func collectScan(t *testing.T, scan func(fn func(key []byte, value []byte) error) error) []string {
+	var keys []string
+	assert.Nil(t, scan(func(key []byte, value []byte) error {
+		assert.Equal(t, byteutils.Hex(key), string(value))
+		keys = append(keys, byteutils.Hex(key))
+		return nil
+	}))
+	return keys
+}
+
+func TestIteratorKey(t *testing.T) {
+	tr, sorted := mockRangeTrie(t)
+	it, err := tr.Iterator(nil)
+	assert.Nil(t, err)
+	var keys []string
+	for next, err := it.Next(); next; next, err = it.Next() {
+		assert.Nil(t, err)
+		keys = append(keys, byteutils.Hex(it.Key()))
+	}
+	assert.Equal(t, sorted, keys)
+
+	it, err = tr.Iterator([]byte{0x12, 0x34})
+	assert.Nil(t, err)
+	keys = nil
+	for next, _ := it.Next(); next; next, _ = it.Next() {
+		keys = append(keys, byteutils.Hex(it.Key()))
+	}
+	assert.Equal(t, []string{"123450", "12345a"}, keys)
+}
+
+func TestRangeIterator(t *testing.T) {
+	tr, sorted := mockRangeTrie(t)
+	tests := []struct {
+		name  string
+		start string
+		end   string
+		want  []string
+	}{
+		{"all", "", "", sorted},
+		{"from start", "123350", "", sorted[1:]},
+		{"between keys", "123400", "133350", sorted[2:4]},
+		{"before all", "000000", "123350", sorted[:1]},
+		{"after all", "ffffff", "", nil},
+		{"empty range", "123450", "123450", nil},
+		{"short start", "13", "22", sorted[4:5]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, _ := byteutils.FromHex(tt.start)
+			end, _ := byteutils.FromHex(tt.end)
+			if tt.end == "" {
+				end = nil
+			}
+			keys := collectScan(t, func(fn func(key []byte, value []byte) error) error {
+				return tr.ScanRange(start, end, fn)
+			})
+			assert.Equal(t, tt.want, keys)
+		})
+	}
+}
+
+func TestScanPrefix(t *testing.T) {
+	tr, _ := mockRangeTrie(t)
+	keys := collectScan(t, func(fn func(key []byte, value []byte) error) error {
+		return tr.ScanPrefix([]byte{0x12, 0x34}, fn)
+	})
+	assert.Equal(t, []string{"123450", "12345a"}, keys)
+
+	// no key of the prefix.
+	keys = collectScan(t, func(fn func(key []byte, value []byte) error) error {
+		return tr.ScanPrefix([]byte{0x99}, fn)
+	})
+	assert.Equal(t, 0, len(keys))
+
+	// stopped by the callback.
+	count := 0
+	err := tr.ScanPrefix(nil, func(key []byte, value []byte) error {
+		count++
+		if count == 2 {
+			return ErrStopScan
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	err = tr.ScanPrefix(nil, func(key []byte, value []byte) error {
+		return ErrNotIterable
+	})
+	assert.Equal(t, ErrNotIterable, err)
+
+	// nothing to scan in an empty trie.
+	stor, _ := storage.NewMemoryStorage()
+	empty, _ := NewTrie(nil, stor)
+	assert.Nil(t, empty.ScanRange(nil, nil, func(key []byte, value []byte) error { return ErrNotIterable }))
+}
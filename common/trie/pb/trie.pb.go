@@ -9,6 +9,7 @@ It is generated from these files:
 
 It has these top-level messages:
 	Node
+	Proof
 */
 package triepb
 
@@ -43,17 +44,36 @@ func (m *Node) GetVal() [][]byte {
 	return nil
 }
 
+type Proof struct {
+	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *Proof) Reset()                    { *m = Proof{} }
+func (m *Proof) String() string            { return proto.CompactTextString(m) }
+func (*Proof) ProtoMessage()               {}
+func (*Proof) Descriptor() ([]byte, []int) { return fileDescriptorTrie, []int{1} }
+
+func (m *Proof) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Node)(nil), "triepb.Node")
+	proto.RegisterType((*Proof)(nil), "triepb.Proof")
 }
 
 func init() { proto.RegisterFile("trie.proto", fileDescriptorTrie) }
 
 var fileDescriptorTrie = []byte{
-	// 71 bytes of a gzipped FileDescriptorProto
+	// 99 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0x29, 0xca, 0x4c,
 	0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x03, 0xb1, 0x0b, 0x92, 0x94, 0x24, 0xb8, 0x58,
 	0xfc, 0xf2, 0x53, 0x52, 0x85, 0x04, 0xb8, 0x98, 0xcb, 0x12, 0x73, 0x24, 0x18, 0x15, 0x98, 0x35,
-	0x78, 0x82, 0x40, 0xcc, 0x24, 0x36, 0xb0, 0x42, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x1c,
-	0xf2, 0x40, 0x6b, 0x36, 0x00, 0x00, 0x00,
+	0x78, 0x82, 0x40, 0x4c, 0x25, 0x6d, 0x2e, 0xd6, 0x80, 0xa2, 0xfc, 0xfc, 0x34, 0x21, 0x25, 0x2e,
+	0xd6, 0xbc, 0xfc, 0x94, 0xd4, 0x62, 0xb0, 0x24, 0xb7, 0x11, 0x8f, 0x1e, 0x44, 0xab, 0x1e, 0x48,
+	0x5f, 0x10, 0x44, 0x2a, 0x89, 0x0d, 0x6c, 0xaa, 0x31, 0x60, 0x00, 0x61, 0x29, 0xe1, 0xac, 0x63,
+	0x00, 0x00, 0x00,
 }
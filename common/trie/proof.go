@@ -21,6 +21,15 @@ package trie
 import (
 	"bytes"
 	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+)
+
+// Errors
+var (
+	ErrInvalidProof = errors.New("invalid merkle proof")
 )
 
 // MerkleProof is a path from root to the proved node
@@ -119,3 +128,124 @@ func (t *Trie) Verify(rootHash []byte, key []byte, proof MerkleProof) error {
 	}
 	return nil
 }
+
+// Proof is the compact serialized merkle proof of a key, the nodes from root to the leaf in a triepb.Proof.
+// It's verified by VerifyProof without the trie.
+type Proof []byte
+
+// NewProof returns the serialized proof of the merkle proof.
+func NewProof(proof MerkleProof) (Proof, error) {
+	pb := new(triepb.Proof)
+	for _, val := range proof {
+		pb.Nodes = append(pb.Nodes, &triepb.Node{Val: val})
+	}
+	return proto.Marshal(pb)
+}
+
+// MerkleProof returns the merkle proof serialized.
+func (p Proof) MerkleProof() (MerkleProof, error) {
+	pb := new(triepb.Proof)
+	if err := proto.Unmarshal(p, pb); err != nil {
+		return nil, ErrInvalidProof
+	}
+	var proof MerkleProof
+	for _, n := range pb.Nodes {
+		proof = append(proof, n.Val)
+	}
+	return proof, nil
+}
+
+// VerifyProof checks the proof is of the key in the trie of rootHash, and returns the value proved.
+// It needs no storage, so clients without the trie can verify the proofs from others.
+func VerifyProof(rootHash []byte, key []byte, proof Proof) ([]byte, error) {
+	merkle, err := proof.MerkleProof()
+	if err != nil {
+		return nil, err
+	}
+	return merkle.verify(rootHash, key)
+}
+
+// Verify checks the merkle proof proves the value is associated to the key in the trie of rootHash.
+// Unlike Trie.Verify, it needs no storage.
+func (proof MerkleProof) Verify(rootHash []byte, key []byte, value []byte) error {
+	proved, err := proof.verify(rootHash, key)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(proved, value) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// verify walks the proof from root to the leaf of the key, and returns the value in the leaf.
+func (proof MerkleProof) verify(rootHash []byte, key []byte) ([]byte, error) {
+	route := keyToRoute(key)
+	wantHash := rootHash
+	for _, val := range proof {
+		data, err := proto.Marshal(&triepb.Node{Val: val})
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(wantHash, hash.Sha3256(data)) {
+			return nil, ErrInvalidProof
+		}
+		if len(val) == 3 && len(val[0]) == 0 {
+			return nil, ErrInvalidProof
+		}
+		n := &node{Val: val}
+		flag, err := n.Type()
+		if err != nil {
+			return nil, ErrInvalidProof
+		}
+		switch flag {
+		case branch:
+			if len(route) == 0 {
+				return nil, ErrInvalidProof
+			}
+			wantHash = val[route[0]]
+			route = route[1:]
+		case ext:
+			if prefixLen(val[1], route) != len(val[1]) {
+				return nil, ErrInvalidProof
+			}
+			wantHash = val[2]
+			route = route[len(val[1]):]
+		case leaf:
+			if !bytes.Equal(val[1], route) {
+				return nil, ErrInvalidProof
+			}
+			return val[2], nil
+		default:
+			return nil, ErrInvalidProof
+		}
+	}
+	// the proof doesn't reach a leaf.
+	return nil, ErrInvalidProof
+}
+
+// ToBytes returns the marshaled nodes of the proof, to send it over network.
+func (proof MerkleProof) ToBytes() ([][]byte, error) {
+	var nodes [][]byte
+	for _, val := range proof {
+		data, err := proto.Marshal(&triepb.Node{Val: val})
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, data)
+	}
+	return nodes, nil
+}
+
+// MerkleProofFromBytes returns the proof of the marshaled nodes.
+func MerkleProofFromBytes(nodes [][]byte) (MerkleProof, error) {
+	var proof MerkleProof
+	for _, data := range nodes {
+		pb := new(triepb.Node)
+		if err := proto.Unmarshal(data, pb); err != nil {
+			return nil, err
+		}
+		proof = append(proof, pb.Val)
+	}
+	return proof, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Errors
+var (
+	ErrMissingNode = errors.New("trie node to retain is missing in storage")
+)
+
+// refCountPrefix is the prefix of the keys of reference counts in storage.
+var refCountPrefix = []byte("trie_refs_")
+
+// RefCounter counts the references to the trie nodes in storage.
+// A node is referred by each retained root and by each referred node pointing to it,
+// when its count drops to zero it's deleted, and the references of its children are released.
+// The nodes never retained, e.g. the intermediate nodes written during execution, are left untouched.
+type RefCounter struct {
+	mu      sync.Mutex
+	storage storage.Storage
+}
+
+// NewRefCounter returns a reference counter of the trie nodes in storage.
+func NewRefCounter(storage storage.Storage) *RefCounter {
+	return &RefCounter{storage: storage}
+}
+
+func refCountKey(hash []byte) []byte {
+	return append(append([]byte{}, refCountPrefix...), hash...)
+}
+
+// Count returns the references to the node.
+func (rc *RefCounter) Count(hash []byte) (uint64, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.count(hash)
+}
+
+func (rc *RefCounter) count(hash []byte) (uint64, error) {
+	data, err := rc.storage.Get(refCountKey(hash))
+	if err == storage.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return byteutils.Uint64(data), nil
+}
+
+func (rc *RefCounter) setCount(hash []byte, count uint64) error {
+	if count == 0 {
+		return rc.storage.Del(refCountKey(hash))
+	}
+	return rc.storage.Put(refCountKey(hash), byteutils.FromUint64(count))
+}
+
+// Retain references the trie of root, its nodes are kept until the reference is released.
+// An empty root is ignored, onLeaf returns the sub tries referred by the leaves and can be nil.
+func (rc *RefCounter) Retain(root []byte, onLeaf LeafCallback) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	hashes := [][]byte{root}
+	for len(hashes) > 0 {
+		h := hashes[len(hashes)-1]
+		hashes = hashes[:len(hashes)-1]
+		if len(h) == 0 {
+			continue
+		}
+		count, err := rc.count(h)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			// the node is referred for the first time, so are its children.
+			data, err := rc.storage.Get(h)
+			if err == storage.ErrKeyNotFound {
+				return ErrMissingNode
+			}
+			if err != nil {
+				return err
+			}
+			children, err := nodeChildren(data, onLeaf)
+			if err != nil {
+				return err
+			}
+			hashes = append(hashes, children...)
+		}
+		if err := rc.setCount(h, count+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Release drops a reference to the trie of root retained before, and returns the count of nodes deleted.
+func (rc *RefCounter) Release(root []byte, onLeaf LeafCallback) (int, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	deleted := 0
+	hashes := [][]byte{root}
+	for len(hashes) > 0 {
+		h := hashes[len(hashes)-1]
+		hashes = hashes[:len(hashes)-1]
+		if len(h) == 0 {
+			continue
+		}
+		count, err := rc.count(h)
+		if err != nil {
+			return deleted, err
+		}
+		if count == 0 {
+			// never retained, the node isn't managed by the counter.
+			continue
+		}
+		if count > 1 {
+			if err := rc.setCount(h, count-1); err != nil {
+				return deleted, err
+			}
+			continue
+		}
+		data, err := rc.storage.Get(h)
+		if err != nil && err != storage.ErrKeyNotFound {
+			return deleted, err
+		}
+		if err == nil {
+			children, err := nodeChildren(data, onLeaf)
+			if err != nil {
+				return deleted, err
+			}
+			hashes = append(hashes, children...)
+			if err := rc.storage.Del(h); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if err := rc.setCount(h, 0); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// Retain references the nodes of the current root in counter, see RefCounter.Retain.
+func (bt *BatchTrie) Retain(rc *RefCounter, onLeaf LeafCallback) error {
+	return rc.Retain(bt.RootHash(), onLeaf)
+}
+
+// Release drops the reference to the nodes of the current root in counter, see RefCounter.Release.
+func (bt *BatchTrie) Release(rc *RefCounter, onLeaf LeafCallback) (int, error) {
+	return rc.Release(bt.RootHash(), onLeaf)
+}
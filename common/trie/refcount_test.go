@@ -0,0 +1,103 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefCounter(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	rc := NewRefCounter(stor)
+	onLeaf := func(value []byte) [][]byte {
+		if len(value) == 32 {
+			return [][]byte{value}
+		}
+		return nil
+	}
+
+	sub, _ := NewBatchTrie(nil, stor)
+	sub.Put(hash.Sha3256([]byte("var")), []byte("sub value"))
+
+	bt, _ := NewBatchTrie(nil, stor)
+	for _, v := range []string{"a", "b", "c", "d"} {
+		bt.Put(hash.Sha3256([]byte(v)), []byte("value "+v))
+	}
+	subKey := hash.Sha3256([]byte("sub"))
+	bt.Put(subKey, sub.RootHash())
+	assert.Nil(t, bt.Retain(rc, onLeaf))
+	v1 := bt.RootHash()
+
+	v2Trie, _ := bt.Clone()
+	v2Trie.Put(hash.Sha3256([]byte("a")), []byte("new value a"))
+	assert.Nil(t, v2Trie.Retain(rc, onLeaf))
+	v2 := v2Trie.RootHash()
+
+	// the nodes shared by both versions are referred twice.
+	count, err := rc.Count(sub.RootHash())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), count)
+	count, _ = rc.Count(v1)
+	assert.Equal(t, uint64(1), count)
+
+	deleted, err := rc.Release(v1, onLeaf)
+	assert.Nil(t, err)
+	assert.True(t, deleted > 0)
+	_, err = stor.Get(v1)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	_, err = NewTrie(v1, stor)
+	assert.NotNil(t, err)
+
+	// the newer version is intact.
+	tr, err := NewTrie(v2, stor)
+	assert.Nil(t, err)
+	for _, v := range []string{"b", "c", "d"} {
+		value, err := tr.Get(hash.Sha3256([]byte(v)))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value "+v), value)
+	}
+	value, err := tr.Get(hash.Sha3256([]byte("a")))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new value a"), value)
+	subTrie, err := NewTrie(sub.RootHash(), stor)
+	assert.Nil(t, err)
+	value, err = subTrie.Get(hash.Sha3256([]byte("var")))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("sub value"), value)
+
+	// releasing the last reference deletes the sub tries as well.
+	_, err = rc.Release(v2, onLeaf)
+	assert.Nil(t, err)
+	_, err = stor.Get(v2)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	_, err = stor.Get(sub.RootHash())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	count, _ = rc.Count(v2)
+	assert.Equal(t, uint64(0), count)
+
+	// the roots not retained are ignored.
+	deleted, err = rc.Release(v2, onLeaf)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, deleted)
+	assert.Equal(t, ErrMissingNode, rc.Retain(v2, onLeaf))
+}
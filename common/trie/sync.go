@@ -18,6 +18,20 @@
 
 package trie
 
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+)
+
+// Errors
+var (
+	ErrUnexpectedNode = errors.New("unexpected trie node, it isn't requested")
+)
+
 // SyncTrie data from other servers
 // Sync whole trie to build snapshot
 func (t *Trie) SyncTrie(rootHash []byte) error {
@@ -29,3 +43,139 @@ func (t *Trie) SyncTrie(rootHash []byte) error {
 func (t *Trie) SyncPath(rootHash []byte, key []byte) error {
 	return nil
 }
+
+// LeafCallback returns the roots of the sub tries referred by a leaf value, e.g. the variables of an account.
+type LeafCallback func(value []byte) [][]byte
+
+// Sync fetches tries from other nodes node by node, from the roots to the leaves.
+// A node is verified by its hash before it is stored, so the fetched tries are proved by their root hashes.
+// The nodes already in storage are not fetched again, but their children are checked.
+type Sync struct {
+	storage  storage.Storage
+	onLeaf   LeafCallback
+	queue    [][]byte
+	known    map[string]bool
+	inflight map[string]bool
+}
+
+// NewSync returns a trie sync storing the nodes into storage, onLeaf can be nil.
+func NewSync(storage storage.Storage, onLeaf LeafCallback) *Sync {
+	return &Sync{
+		storage:  storage,
+		onLeaf:   onLeaf,
+		known:    make(map[string]bool),
+		inflight: make(map[string]bool),
+	}
+}
+
+// AddRoot schedules a trie to fetch, an empty root is ignored.
+func (s *Sync) AddRoot(root []byte) error {
+	return s.schedule([][]byte{root})
+}
+
+// schedules the missing nodes, and walks the nodes in storage.
+func (s *Sync) schedule(hashes [][]byte) error {
+	for len(hashes) > 0 {
+		h := hashes[len(hashes)-1]
+		hashes = hashes[:len(hashes)-1]
+		if len(h) == 0 || s.known[string(h)] {
+			continue
+		}
+		s.known[string(h)] = true
+
+		data, err := s.storage.Get(h)
+		if err == storage.ErrKeyNotFound {
+			s.queue = append(s.queue, h)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		children, err := s.children(data)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, children...)
+	}
+	return nil
+}
+
+// children returns the hashes referred by a node.
+func (s *Sync) children(data []byte) ([][]byte, error) {
+	return nodeChildren(data, s.onLeaf)
+}
+
+// nodeChildren returns the hashes referred by the node data, the sub tries of leaves are returned by onLeaf.
+func nodeChildren(data []byte, onLeaf LeafCallback) ([][]byte, error) {
+	pb := new(triepb.Node)
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, err
+	}
+	n := &node{Val: pb.Val}
+	t, err := n.Type()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case branch:
+		return n.Val, nil
+	case ext:
+		return [][]byte{n.Val[2]}, nil
+	case leaf:
+		if onLeaf != nil {
+			return onLeaf(n.Val[2]), nil
+		}
+		return nil, nil
+	}
+	return nil, errors.New("unknown node type")
+}
+
+// Missing returns at most max hashes of the nodes to fetch, they are in flight until processed or retried.
+func (s *Sync) Missing(max int) [][]byte {
+	if max > len(s.queue) {
+		max = len(s.queue)
+	}
+	hashes := s.queue[:max]
+	s.queue = s.queue[max:]
+	for _, h := range hashes {
+		s.inflight[string(h)] = true
+	}
+	return hashes
+}
+
+// Retry schedules the hashes in flight again, e.g. the request of them failed.
+func (s *Sync) Retry(hashes [][]byte) {
+	for _, h := range hashes {
+		if s.inflight[string(h)] {
+			delete(s.inflight, string(h))
+			s.queue = append(s.queue, h)
+		}
+	}
+}
+
+// Process verifies and stores the fetched nodes, and schedules their children.
+func (s *Sync) Process(nodes [][]byte) error {
+	for _, data := range nodes {
+		h := hash.Sha3256(data)
+		if !s.inflight[string(h)] {
+			return ErrUnexpectedNode
+		}
+		children, err := s.children(data)
+		if err != nil {
+			return err
+		}
+		if err := s.storage.Put(h, data); err != nil {
+			return err
+		}
+		delete(s.inflight, string(h))
+		if err := s.schedule(children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pending returns the count of nodes not fetched yet.
+func (s *Sync) Pending() int {
+	return len(s.queue) + len(s.inflight)
+}
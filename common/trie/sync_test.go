@@ -0,0 +1,105 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync(t *testing.T) {
+	src, _ := storage.NewMemoryStorage()
+	sub, _ := NewTrie(nil, src)
+	sub.Put(hash.Sha3256([]byte("var")), []byte("sub value"))
+
+	tr, _ := NewTrie(nil, src)
+	keys := [][]byte{}
+	for _, v := range []string{"a", "b", "c", "d", "e", "f"} {
+		key := hash.Sha3256([]byte(v))
+		keys = append(keys, key)
+		tr.Put(key, []byte("value "+v))
+	}
+	subKey := hash.Sha3256([]byte("sub"))
+	tr.Put(subKey, sub.RootHash())
+
+	// the leaves of 32 bytes are the roots of sub tries.
+	onLeaf := func(value []byte) [][]byte {
+		if len(value) == 32 {
+			return [][]byte{value}
+		}
+		return nil
+	}
+
+	dst, _ := storage.NewMemoryStorage()
+	s := NewSync(dst, onLeaf)
+	assert.Nil(t, s.AddRoot(tr.RootHash()))
+	for s.Pending() > 0 {
+		missing := s.Missing(2)
+		assert.NotEmpty(t, missing)
+		var nodes [][]byte
+		for _, h := range missing {
+			data, err := src.Get(h)
+			assert.Nil(t, err)
+			nodes = append(nodes, data)
+		}
+		// the hashes not replied are requested again.
+		s.Retry(missing[1:])
+		assert.Nil(t, s.Process(nodes[:1]))
+	}
+
+	synced, err := NewTrie(tr.RootHash(), dst)
+	assert.Nil(t, err)
+	for _, key := range keys {
+		want, _ := tr.Get(key)
+		got, err := synced.Get(key)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+	subSynced, err := NewTrie(sub.RootHash(), dst)
+	assert.Nil(t, err)
+	val, err := subSynced.Get(hash.Sha3256([]byte("var")))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("sub value"), val)
+
+	// a known root needs nothing.
+	s = NewSync(dst, onLeaf)
+	assert.Nil(t, s.AddRoot(tr.RootHash()))
+	assert.Equal(t, 0, s.Pending())
+}
+
+func TestSync_UnexpectedNode(t *testing.T) {
+	src, _ := storage.NewMemoryStorage()
+	tr, _ := NewTrie(nil, src)
+	tr.Put(hash.Sha3256([]byte("a")), []byte("value"))
+
+	dst, _ := storage.NewMemoryStorage()
+	s := NewSync(dst, nil)
+	assert.Nil(t, s.AddRoot(tr.RootHash()))
+	missing := s.Missing(1)
+	data, _ := src.Get(missing[0])
+
+	tampered := append([]byte{}, data...)
+	tampered[len(tampered)-1] ^= 0xff
+	assert.Equal(t, ErrUnexpectedNode, s.Process([][]byte{tampered}))
+	_, err := dst.Get(missing[0])
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
@@ -192,6 +192,30 @@ func TestTrie_Operation(t *testing.T) {
 	if err := tr.Verify(tr.rootHash, addr1, proof); err != nil {
 		t.Errorf("1 Trie.Verify() %v", err.Error())
 	}
+	if err := proof.Verify(tr.rootHash, addr1, val11); err != nil {
+		t.Errorf("1 MerkleProof.Verify() %v", err.Error())
+	}
+	if err := proof.Verify(tr.rootHash, addr1, val2); err != ErrInvalidProof {
+		t.Errorf("1 MerkleProof.Verify() wrong value err = %v, want %v", err, ErrInvalidProof)
+	}
+	if err := proof[:len(proof)-1].Verify(tr.rootHash, addr1, val11); err != ErrInvalidProof {
+		t.Errorf("1 MerkleProof.Verify() cut proof err = %v, want %v", err, ErrInvalidProof)
+	}
+	serialized, _ := NewProof(proof)
+	if val, err := VerifyProof(tr.rootHash, addr1, serialized); err != nil || !reflect.DeepEqual(val, val11) {
+		t.Errorf("1 VerifyProof() = %v, %v, want %v", val, err, val11)
+	}
+	if _, err := VerifyProof(tr.rootHash, addr2, serialized); err != ErrInvalidProof {
+		t.Errorf("1 VerifyProof() wrong key err = %v, want %v", err, ErrInvalidProof)
+	}
+	if _, err := VerifyProof(tr.rootHash, addr1, serialized[:len(serialized)-1]); err != ErrInvalidProof {
+		t.Errorf("1 VerifyProof() truncated err = %v, want %v", err, ErrInvalidProof)
+	}
+	nodes, _ := proof.ToBytes()
+	decoded, _ := MerkleProofFromBytes(nodes)
+	if !reflect.DeepEqual(decoded, proof) {
+		t.Errorf("1 MerkleProofFromBytes() = %v, want %v", decoded, proof)
+	}
 	// get node "1f345678e9"
 	checkVal1, _ := tr.Get(addr1)
 	if !reflect.DeepEqual(checkVal1, val11) {
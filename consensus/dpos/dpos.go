@@ -30,7 +30,9 @@ import (
 	"github.com/nebulasio/go-nebulas/common/trie"
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/net"
 	"github.com/nebulasio/go-nebulas/net/p2p"
+	"github.com/nebulasio/go-nebulas/storage"
 
 	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/nebulasio/go-nebulas/util/logging"
@@ -43,6 +45,9 @@ var (
 	ErrMissingConfigForDpos = errors.New("missing configuration for Dpos")
 	ErrInvalidBlockProposer = errors.New("invalid block proposer")
 	ErrCannotMintBlockNow   = errors.New("cannot mint block now, waiting for sync over")
+
+	ErrUnknownConsensusMessage = errors.New("unknown consensus message")
+	ErrSlotMinted              = errors.New("the block of the slot is minted")
 )
 
 // Neblet interface breaks cycle import dependency and hides unused services.
@@ -51,11 +56,13 @@ type Neblet interface {
 	BlockChain() *core.BlockChain
 	NetManager() p2p.Manager
 	AccountManager() *account.Manager
+	Storage() storage.Storage
 }
 
 // Dpos Delegate Proof-of-Stake
 type Dpos struct {
-	quitCh chan bool
+	quitCh    chan bool
+	messageCh chan net.Message
 
 	chain *core.BlockChain
 	nm    p2p.Manager
@@ -65,6 +72,11 @@ type Dpos struct {
 	miner      *core.Address
 	passphrase string
 
+	guard   *signGuard
+	standby *standby
+	clock   *slotClock
+	signer  core.BlockSigner
+
 	blockInterval   int64
 	dynastyInterval int64
 	txsPerBlock     int
@@ -75,7 +87,8 @@ type Dpos struct {
 // NewDpos create Dpos instance.
 func NewDpos(neblet Neblet) (*Dpos, error) {
 	p := &Dpos{
-		quitCh: make(chan bool, 5),
+		quitCh:    make(chan bool, 5),
+		messageCh: make(chan net.Message, 256),
 
 		chain: neblet.BlockChain(),
 		nm:    neblet.NetManager(),
@@ -108,23 +121,110 @@ func NewDpos(neblet Neblet) (*Dpos, error) {
 	p.coinbase = coinbase
 	p.miner = miner
 	p.passphrase = config.Passphrase
+	// the records of the guard are written at once, not in the commits of blocks.
+	p.guard = newSignGuard(neblet.Storage(), miner)
+	auth := &account.SignerAuth{
+		Token:    config.SignerToken,
+		CAFile:   config.SignerTlsCa,
+		CertFile: config.SignerTlsCert,
+		KeyFile:  config.SignerTlsKey,
+	}
+	if len(config.RemoteSigners) > 0 {
+		signer, err := account.NewRemoteSigner(miner, p.am.SignatureAlgorithm(), config.RemoteSigners, auth)
+		if err != nil {
+			return nil, err
+		}
+		p.signer = signer
+		logging.CLog().WithFields(logrus.Fields{
+			"miner":   miner,
+			"signers": config.RemoteSigners,
+		}).Info("Sign blocks by remote signers.")
+	}
+	if len(config.ThresholdSigners) > 0 {
+		signer, err := account.NewThresholdSigner(miner, p.am.SignatureAlgorithm(), int(config.SigningThreshold), config.ThresholdSigners, []byte(config.SharePassphrase), auth)
+		if err != nil {
+			return nil, err
+		}
+		if p.signer != nil {
+			signer.SetFallback(p.signer)
+		}
+		p.signer = signer
+		logging.CLog().WithFields(logrus.Fields{
+			"miner":     miner,
+			"threshold": config.SigningThreshold,
+			"holders":   len(config.ThresholdSigners),
+		}).Info("Sign blocks by key shares.")
+	}
+	if config.MintLeadTime > 0 {
+		p.clock = &slotClock{
+			interval: p.blockInterval,
+			lead:     time.Duration(config.MintLeadTime) * time.Millisecond,
+		}
+	}
+	if config.Standby {
+		p.standby = newStandby(int64(config.StandbySilence), time.Now().Unix())
+		logging.CLog().WithFields(logrus.Fields{
+			"miner": miner,
+		}).Info("Run as standby of the miner.")
+	}
 	return p, nil
 }
 
 // Start start pow service.
 func (p *Dpos) Start() {
+	p.nm.Register(net.NewSubscriber(p, p.messageCh, net.MessageTypeConsensusPrepare, net.MessageTypeConsensusVote))
 	go p.blockLoop()
+	go p.messageLoop()
 }
 
 // Stop stop pow service.
 func (p *Dpos) Stop() {
 	p.quitCh <- true
+	p.quitCh <- true
 }
 
+// HandleConsensusMessage handles the prepares and votes of proposers. The blocks are final by
+// the latest irreversible block in dpos, so the messages are only recorded for now.
+func (p *Dpos) HandleConsensusMessage(msg net.Message) error {
+	switch msg.MessageType() {
+	case net.MessageTypeConsensusPrepare, net.MessageTypeConsensusVote:
+		logging.VLog().WithFields(logrus.Fields{
+			"type": msg.MessageType(),
+			"from": msg.MessageFrom(),
+		}).Debug("Received consensus message.")
+		return nil
+	}
+	return ErrUnknownConsensusMessage
+}
+
+// messageLoop handles the consensus messages apart from minting, so they never wait for blocks.
+func (p *Dpos) messageLoop() {
+	for {
+		select {
+		case msg := <-p.messageCh:
+			if err := p.HandleConsensusMessage(msg); err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"type": msg.MessageType(),
+					"from": msg.MessageFrom(),
+					"err":  err,
+				}).Warn("Failed to handle consensus message.")
+			}
+		case <-p.quitCh:
+			return
+		}
+	}
+}
+
+// less prefers the higher block, then the one of the proposer to the ones of the backups.
 func less(a *core.Block, b *core.Block) bool {
 	if a.Height() != b.Height() {
 		return a.Height() < b.Height()
 	}
+	rankA, _ := core.ProposerRank(a.Timestamp())
+	rankB, _ := core.ProposerRank(b.Timestamp())
+	if rankA != rankB {
+		return rankA > rankB
+	}
 	return core.Less(a, b)
 }
 
@@ -163,6 +263,7 @@ func (p *Dpos) forkChoice() {
 			}).Info("change to new tail.")
 		}
 	}
+	p.observe(bc.TailBlock())
 }
 
 // CanMining return if consensus can do mining now
@@ -208,19 +309,37 @@ func verifyBlockSign(miner *core.Address, block *core.Block) error {
 	return nil
 }
 
+// findProposer returns the proposer of the block, the backup of the rank its timestamp is stamped with.
+func findProposer(params *core.DposParams, block *core.Block, dynasty *trie.BatchTrie) (*core.Address, error) {
+	proposer, err := params.FindProposer(block.Timestamp(), dynasty)
+	if err != nil {
+		return nil, err
+	}
+	return core.AddressParseFromBytes(proposer)
+}
+
 // FastVerifyBlock verify the block before its parent found
 // can be verified if the block's dynasty == tail's dynasty
 // can be verified if the block's dynasty == tails's next dynasty
 func (p *Dpos) FastVerifyBlock(block *core.Block) error {
 	tail := p.chain.TailBlock()
-	// check timestamp
-	elapsedSecond := block.Timestamp() - tail.Timestamp()
-	if elapsedSecond%p.blockInterval != 0 {
+	// check timestamp, a slot or the delay of a backup in it.
+	if _, err := core.ProposerRank(block.Timestamp()); err != nil {
 		return ErrInvalidBlockInterval
 	}
-	// check proposer
-	currentHour := block.Timestamp() / core.DynastyInterval
-	tailHour := tail.Timestamp() / core.DynastyInterval
+	// check proposer, the dynasties are elected again at a fork of dpos params.
+	params := tail.DposParams()
+	if !p.chain.DposParams(tail.Height() + 1).Equals(params) {
+		return nil
+	}
+	currentHour, err := tail.DynastyID(block.Timestamp())
+	if err != nil {
+		return err
+	}
+	tailHour, err := tail.DynastyID(tail.Timestamp())
+	if err != nil {
+		return err
+	}
 	var dynastyRoot byteutils.Hash
 	if currentHour == tailHour {
 		dynastyRoot = tail.DposContext().DynastyRoot
@@ -233,11 +352,7 @@ func (p *Dpos) FastVerifyBlock(block *core.Block) error {
 	if err != nil {
 		return err
 	}
-	proposer, err := core.FindProposer(block.Timestamp(), dynasty)
-	if err != nil {
-		return err
-	}
-	miner, err := core.AddressParseFromBytes(proposer)
+	miner, err := findProposer(params, block, dynasty)
 	if err != nil {
 		return err
 	}
@@ -251,11 +366,7 @@ func (p *Dpos) VerifyBlock(block *core.Block, parent *core.Block) error {
 	if err != nil {
 		return err
 	}
-	proposer, err := core.FindProposer(block.Timestamp(), dynasty)
-	if err != nil {
-		return err
-	}
-	miner, err := core.AddressParseFromBytes(proposer)
+	miner, err := findProposer(block.DposParams(), block, dynasty)
 	if err != nil {
 		return err
 	}
@@ -275,9 +386,28 @@ func (p *Dpos) mintBlock(now int64) error {
 		return ErrCannotMintBlockNow
 	}
 
-	// check proposer
+	// check proposer, a backup mints the block of the slot started before now
+	// if none of the ones before it did, stamped with now to be verified by its rank.
+	rank, err := core.ProposerRank(now)
+	if err != nil {
+		return ErrInvalidBlockProposer
+	}
+	block, err := p.newBlock(now, now-rank*core.BackupProposerDelay, rank)
+	if err != nil {
+		return err
+	}
+	block.CollectTransactions(p.txsPerBlock)
+	return p.sealBlock(block)
+}
+
+// newBlock returns the block of the slot on tail stamped with the delay of the rank, if the miner
+// is the proposer of the rank.
+func (p *Dpos) newBlock(now int64, slot int64, rank int64) (*core.Block, error) {
 	tail := p.chain.TailBlock()
-	elapsedSecond := now - tail.Timestamp()
+	if tail.Timestamp() >= slot {
+		return nil, ErrSlotMinted
+	}
+	elapsedSecond := slot + rank*core.BackupProposerDelay - tail.Timestamp()
 	context, err := tail.NextDynastyContext(elapsedSecond)
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
@@ -285,25 +415,35 @@ func (p *Dpos) mintBlock(now int64) error {
 			"elapsed": elapsedSecond,
 			"err":     err,
 		}).Error("Failed to generate next dynasty context.")
-		return core.ErrGenerateNextDynastyContext
+		return nil, core.ErrGenerateNextDynastyContext
 	}
-	if context.Proposer == nil || !context.Proposer.Equals(p.miner.Bytes()) {
+	expected := context.Proposer
+	if expected == nil || !expected.Equals(p.miner.Bytes()) {
 		proposer := "nil"
-		if context.Proposer != nil {
-			proposer = string(context.Proposer.Hex())
+		if expected != nil {
+			proposer = string(expected.Hex())
 		}
 		logging.VLog().WithFields(logrus.Fields{
 			"tail":     tail,
 			"elapsed":  elapsedSecond,
+			"rank":     rank,
 			"expected": proposer,
 			"actual":   p.miner.String(),
 		}).Info("Not my turn, waiting...")
-		return ErrInvalidBlockProposer
+		return nil, ErrInvalidBlockProposer
+	}
+	if p.standby != nil && !p.standby.CanSeal(now) {
+		logging.VLog().WithFields(logrus.Fields{
+			"tail": tail,
+			"now":  now,
+		}).Info("My turn, but the primary is sealing.")
+		return nil, ErrStandbyWaiting
 	}
 	logging.VLog().WithFields(logrus.Fields{
 		"tail":     tail,
 		"elapsed":  elapsedSecond,
-		"expected": context.Proposer.Hex(),
+		"rank":     rank,
+		"expected": expected.Hex(),
 		"actual":   p.coinbase.String(),
 	}).Info("My turn to mint block")
 
@@ -316,12 +456,25 @@ func (p *Dpos) mintBlock(now int64) error {
 			"chainid":  p.chain.ChainID(),
 			"err":      err,
 		}).Error("Failed to create new block")
-		return err
+		return nil, err
 	}
 	block.LoadDynastyContext(context)
-	block.CollectTransactions(p.txsPerBlock)
+	return block, nil
+}
+
+// signBlock signs the block by the key shares or the remote signer if configured, otherwise the key of
+// the miner in keystore.
+func (p *Dpos) signBlock(block *core.Block) error {
+	if p.signer != nil {
+		return block.Sign(p.signer)
+	}
+	return p.am.SignBlock(p.miner, block)
+}
+
+// sealBlock seals and signs the block, and broadcasts it.
+func (p *Dpos) sealBlock(block *core.Block) error {
 	block.SetMiner(p.miner)
-	if err = block.Seal(); err != nil {
+	if err := block.Seal(); err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"block": block,
 			"err":   err,
@@ -329,14 +482,24 @@ func (p *Dpos) mintBlock(now int64) error {
 		return err
 	}
 	// TODO: move passphrase from config to console
-	if err = p.am.Unlock(p.miner, []byte(p.passphrase)); err != nil {
+	if p.signer == nil {
+		if err := p.am.Unlock(p.miner, []byte(p.passphrase)); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"miner": p.miner.String(),
+				"err":   err,
+			}).Error("Failed to unlock the miner")
+			return err
+		}
+	}
+	if err := p.guard.Acquire(block.Timestamp()); err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"miner": p.miner.String(),
+			"block": block,
 			"err":   err,
-		}).Error("Failed to unlock the miner")
+		}).Error("Failed to acquire the slot to sign")
 		return err
 	}
-	if err = p.am.SignBlock(p.miner, block); err != nil {
+	if err := p.signBlock(block); err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"miner": p.miner.String(),
 			"block": block,
@@ -345,10 +508,8 @@ func (p *Dpos) mintBlock(now int64) error {
 		return err
 	}
 	// broadcast it
-	err = p.chain.BlockPool().PushAndBroadcast(block)
-	if err != nil {
+	if err := p.chain.BlockPool().PushAndBroadcast(block); err != nil {
 		logging.VLog().WithFields(logrus.Fields{
-			"tail":  tail,
 			"block": block,
 			"err":   err,
 		}).Error("Failed to broadcast new block")
@@ -356,7 +517,6 @@ func (p *Dpos) mintBlock(now int64) error {
 	}
 
 	logging.VLog().WithFields(logrus.Fields{
-		"tail":  tail,
 		"block": block,
 	}).Info("Minted new block")
 	return nil
@@ -366,8 +526,21 @@ func (p *Dpos) blockLoop() {
 	logging.CLog().Info("Launched Dpos Mining.")
 
 	timeChan := time.NewTicker(time.Second).C
+	// the blocks are pre-built before the slots if the lead time is configured.
+	var slot int64
+	var prepackCh <-chan time.Time
+	if p.clock != nil {
+		var start time.Time
+		slot, start = p.clock.Next(time.Now())
+		prepackCh = time.After(time.Until(start))
+	}
 	for {
 		select {
+		case <-prepackCh:
+			p.prepack(slot)
+			var start time.Time
+			slot, start = p.clock.Next(time.Unix(slot, 0))
+			prepackCh = time.After(time.Until(start))
 		case now := <-timeChan:
 			p.mintBlock(now.Unix())
 		case <-p.chain.BlockPool().ReceivedLinkedBlockCh():
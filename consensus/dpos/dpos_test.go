@@ -19,6 +19,7 @@
 package dpos
 
 import (
+	"io"
 	"testing"
 
 	"time"
@@ -32,6 +33,7 @@ import (
 	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/messages"
 	"github.com/nebulasio/go-nebulas/net/p2p"
 	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/stretchr/testify/assert"
@@ -178,8 +180,9 @@ func (n MockNetManager) Node() *p2p.Node { return nil }
 func (n MockNetManager) Sync(net.Serializable) error            { return nil }
 func (n MockNetManager) SendSyncReply(string, net.Serializable) {}
 
-func (n MockNetManager) Register(...*net.Subscriber)   {}
-func (n MockNetManager) Deregister(...*net.Subscriber) {}
+func (n MockNetManager) Register(...*net.Subscriber)             {}
+func (n MockNetManager) Deregister(...*net.Subscriber)           {}
+func (n MockNetManager) RegisterValidator(string, net.Validator) {}
 
 func (n MockNetManager) Broadcast(name string, msg net.Serializable) {
 	pb, _ := msg.ToProto()
@@ -200,6 +203,17 @@ func (n MockNetManager) BroadcastNetworkID([]byte) {}
 
 func (n MockNetManager) BuildData([]byte, string) []byte { return nil }
 
+func (n MockNetManager) ExportPeers(io.Writer) error        { return nil }
+func (n MockNetManager) ImportPeers(io.Reader) (int, error) { return 0, nil }
+
+func (n MockNetManager) AddBootNode(string) error        { return nil }
+func (n MockNetManager) RemoveBootNode(string) error     { return nil }
+func (n MockNetManager) BootNodes() []p2p.BootNodeStatus { return nil }
+
+func (n MockNetManager) Peers() []*p2p.PeerRecord            { return nil }
+func (n MockNetManager) BanPeer(string, time.Duration) error { return nil }
+func (n MockNetManager) UnbanPeer(string) error              { return nil }
+
 func TestDpos_New(t *testing.T) {
 	neb := mockNeb()
 	_, err := NewDpos(neb)
@@ -396,3 +410,104 @@ func TestDpos_MintBlock(t *testing.T) {
 	assert.Equal(t, dpos.mintBlock(core.DynastyInterval), nil)
 	assert.NotEqual(t, received, []byte{})
 }
+
+func TestDpos_MintBackupBlock(t *testing.T) {
+	dpos, err := NewDpos(mockNeb())
+	assert.Nil(t, err)
+	var c MockConsensus
+	dpos.chain.SetConsensusHandler(c)
+	dpos.SetCanMining(true)
+	tail := dpos.chain.TailBlock()
+
+	// the miner is the first validator, the backup of the last slot in a round.
+	slot := int64(core.DynastySize-1) * core.BlockInterval
+	assert.Equal(t, dpos.mintBlock(slot), ErrInvalidBlockProposer)
+	assert.Equal(t, dpos.mintBlock(slot+1), ErrInvalidBlockProposer)
+	assert.Equal(t, dpos.mintBlock(slot+2*core.BackupProposerDelay), ErrInvalidBlockProposer)
+
+	received = []byte{}
+	assert.Nil(t, dpos.mintBlock(slot+core.BackupProposerDelay))
+	assert.NotEqual(t, received, []byte{})
+
+	// the block of the backup is stamped with the delay of its rank, and verified by consensus.
+	block := mockSignedBlock(t, dpos, tail, slot+core.BackupProposerDelay)
+	assert.Equal(t, dpos.miner.Bytes(), block.Miner().Bytes())
+	assert.Nil(t, dpos.VerifyBlock(block, tail))
+	assert.Nil(t, dpos.FastVerifyBlock(block))
+
+	// the backup can not pass its block as the one of the proposer.
+	context, err := tail.NextDynastyContext(slot)
+	assert.Nil(t, err)
+	block, err = core.NewBlock(dpos.chain.ChainID(), dpos.coinbase, tail)
+	assert.Nil(t, err)
+	block.LoadDynastyContext(context)
+	block.SetMiner(dpos.miner)
+	block.Seal()
+	manager := account.NewManager(nil)
+	assert.Nil(t, manager.Unlock(dpos.miner, []byte("passphrase")))
+	assert.Nil(t, manager.SignBlock(dpos.miner, block))
+	assert.Equal(t, ErrInvalidBlockProposer, dpos.VerifyBlock(block, tail))
+	assert.Equal(t, ErrInvalidBlockProposer, dpos.FastVerifyBlock(block))
+}
+
+// mockSignedBlock returns the block on parent at the timestamp, signed by its proposer.
+func mockSignedBlock(t *testing.T, dpos *Dpos, parent *core.Block, timestamp int64) *core.Block {
+	context, err := parent.NextDynastyContext(timestamp - parent.Timestamp())
+	assert.Nil(t, err)
+	miner, err := core.AddressParseFromBytes(context.Proposer)
+	assert.Nil(t, err)
+	block, err := core.NewBlock(dpos.chain.ChainID(), miner, parent)
+	assert.Nil(t, err)
+	block.LoadDynastyContext(context)
+	block.SetMiner(miner)
+	block.Seal()
+	manager := account.NewManager(nil)
+	assert.Nil(t, manager.Unlock(miner, []byte("passphrase")))
+	assert.Nil(t, manager.SignBlock(miner, block))
+	return block
+}
+
+func TestDpos_BackupBlockOutOfOrder(t *testing.T) {
+	dpos, err := NewDpos(mockNeb())
+	assert.Nil(t, err)
+	var c MockConsensus
+	dpos.chain.SetConsensusHandler(c)
+	tail := dpos.chain.TailBlock()
+
+	// the miner proposes the first slot of a dynasty, the next validator is its backup.
+	slot := int64(core.DynastyInterval)
+	primary := mockSignedBlock(t, dpos, tail, slot)
+	backup := mockSignedBlock(t, dpos, tail, slot+core.BackupProposerDelay)
+	assert.False(t, primary.Miner().Equals(backup.Miner()))
+	assert.Nil(t, dpos.VerifyBlock(primary, tail))
+	assert.Nil(t, dpos.VerifyBlock(backup, tail))
+
+	// the block of the backup arrives first, then the one of the proposer delayed.
+	assert.Nil(t, dpos.chain.BlockPool().Push(backup))
+	dpos.forkChoice()
+	assert.Equal(t, backup.Hash(), dpos.chain.TailBlock().Hash())
+
+	assert.Nil(t, dpos.chain.BlockPool().Push(primary))
+	dpos.forkChoice()
+	assert.Equal(t, primary.Hash(), dpos.chain.TailBlock().Hash())
+
+	// the proposer is still preferred if the blocks arrive in order.
+	dpos, err = NewDpos(mockNeb())
+	assert.Nil(t, err)
+	dpos.chain.SetConsensusHandler(c)
+	tail = dpos.chain.TailBlock()
+	primary = mockSignedBlock(t, dpos, tail, slot)
+	backup = mockSignedBlock(t, dpos, tail, slot+core.BackupProposerDelay)
+	assert.Nil(t, dpos.chain.BlockPool().Push(primary))
+	assert.Nil(t, dpos.chain.BlockPool().Push(backup))
+	dpos.forkChoice()
+	assert.Equal(t, primary.Hash(), dpos.chain.TailBlock().Hash())
+}
+
+func TestDpos_HandleConsensusMessage(t *testing.T) {
+	dpos, err := NewDpos(mockNeb())
+	assert.Nil(t, err)
+	assert.Nil(t, dpos.HandleConsensusMessage(messages.NewBaseMessage(net.MessageTypeConsensusPrepare, "", nil)))
+	assert.Nil(t, dpos.HandleConsensusMessage(messages.NewBaseMessage(net.MessageTypeConsensusVote, "", nil)))
+	assert.Equal(t, ErrUnknownConsensusMessage, dpos.HandleConsensusMessage(messages.NewBaseMessage(net.MessageTypeNewBlock, "", nil)))
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// PrepackPollInterval is how long the miner waits for new transactions while pre-building its block.
+var PrepackPollInterval = 100 * time.Millisecond
+
+// slotClock schedules the pre-building of the blocks a lead time before the slots.
+type slotClock struct {
+	interval int64
+	lead     time.Duration
+}
+
+// Next returns the first slot after now, and when to start pre-building its block.
+func (c *slotClock) Next(now time.Time) (int64, time.Time) {
+	slot := (now.Unix()/c.interval + 1) * c.interval
+	start := time.Unix(slot, 0).Add(-c.lead)
+	if start.Before(now) {
+		start = now
+	}
+	return slot, start
+}
+
+// prepack pre-builds the block of the slot if the miner is its proposer, collecting the transactions
+// till the slot starts, then seals and signs it at the slot. The block is built again at the slot
+// if the tail changed meanwhile.
+func (p *Dpos) prepack(slot int64) error {
+	if !p.canMining {
+		return ErrCannotMintBlockNow
+	}
+	tail := p.chain.TailBlock()
+	block, err := p.newBlock(time.Now().Unix(), slot, 0)
+	if err != nil {
+		return err
+	}
+	deadline := time.Unix(slot, 0)
+	p.collectTransactions(block, deadline)
+	time.Sleep(time.Until(deadline))
+
+	if !p.chain.TailBlock().Hash().Equals(tail.Hash()) {
+		logging.VLog().WithFields(logrus.Fields{
+			"tail": p.chain.TailBlock(),
+			"slot": slot,
+		}).Info("The tail changed while pre-building the block, build it again.")
+		block.ReturnTransactions()
+		return p.mintBlock(slot)
+	}
+	return p.sealBlock(block)
+}
+
+// collectTransactions collects the transactions into the block till the deadline, waiting for the new ones
+// if the pool is drained.
+func (p *Dpos) collectTransactions(block *core.Block, deadline time.Time) {
+	for n := p.txsPerBlock; n > 0 && time.Now().Before(deadline); {
+		packed := block.CollectTransactionsUntil(n, deadline)
+		n -= packed
+		if packed == 0 {
+			wait := time.Until(deadline)
+			if wait > PrepackPollInterval {
+				wait = PrepackPollInterval
+			}
+			time.Sleep(wait)
+		}
+	}
+}
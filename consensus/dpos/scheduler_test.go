@@ -0,0 +1,65 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlotClock_Next(t *testing.T) {
+	clock := &slotClock{interval: core.BlockInterval, lead: 1500 * time.Millisecond}
+
+	slot, start := clock.Next(time.Unix(100, 0))
+	assert.Equal(t, int64(105), slot)
+	assert.Equal(t, time.Unix(103, 500*int64(time.Millisecond)), start)
+
+	// the lead time is passed, start now.
+	now := time.Unix(104, 0)
+	slot, start = clock.Next(now)
+	assert.Equal(t, int64(105), slot)
+	assert.Equal(t, now, start)
+
+	slot, _ = clock.Next(time.Unix(slot, 0))
+	assert.Equal(t, int64(110), slot)
+}
+
+func TestDpos_Prepack(t *testing.T) {
+	dpos, err := NewDpos(mockNeb())
+	assert.Nil(t, err)
+	var c MockConsensus
+	dpos.chain.SetConsensusHandler(c)
+
+	slot := int64(core.DynastyInterval)
+	assert.Equal(t, ErrCannotMintBlockNow, dpos.prepack(slot))
+	dpos.SetCanMining(true)
+	assert.Equal(t, ErrInvalidBlockProposer, dpos.prepack(slot+core.BlockInterval))
+
+	received = []byte{}
+	assert.Nil(t, dpos.prepack(slot))
+	assert.NotEqual(t, received, []byte{})
+	dpos.forkChoice()
+	assert.Equal(t, slot, dpos.chain.TailBlock().Timestamp())
+
+	// the slot is minted by the pre-built block.
+	assert.Equal(t, ErrSlotMinted, dpos.mintBlock(slot))
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Errors of failover
+var (
+	ErrDoubleSign     = errors.New("refuse to sign, the slot has been signed by the miner")
+	ErrStandbyWaiting = errors.New("standby is waiting, the primary is not silent")
+)
+
+// DefaultStandbySilence is the seconds without blocks of the primary before the standby takes over,
+// a miner proposes once in a round of dynasty, so two rounds are missed.
+var DefaultStandbySilence = 2 * int64(core.DynastySize) * core.BlockInterval
+
+// signGuard records the latest slot signed by a miner, and refuses to sign the slots not after it.
+// The slots of blocks by the same miner from other nodes are recorded too,
+// so the primary and standby sharing a signing identity never sign two blocks in a slot.
+type signGuard struct {
+	mu      sync.Mutex
+	storage storage.Storage
+	key     []byte
+	last    int64
+}
+
+func newSignGuard(stor storage.Storage, miner *core.Address) *signGuard {
+	g := &signGuard{
+		storage: stor,
+		key:     append([]byte("dpos_signed_"), miner.Bytes()...),
+	}
+	if data, err := stor.Get(g.key); err == nil {
+		g.last = byteutils.Int64(data)
+	}
+	return g
+}
+
+// Last returns the latest slot signed.
+func (g *signGuard) Last() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.last
+}
+
+// Acquire records the slot to sign, it fails if the slot is not after the latest one.
+func (g *signGuard) Acquire(slot int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if slot <= g.last {
+		return ErrDoubleSign
+	}
+	return g.mark(slot)
+}
+
+// Observe records a slot signed by another node of the miner.
+func (g *signGuard) Observe(slot int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if slot <= g.last {
+		return nil
+	}
+	return g.mark(slot)
+}
+
+func (g *signGuard) mark(slot int64) error {
+	// persist before signing, a restarted node never signs the slot again.
+	if err := g.storage.Put(g.key, byteutils.FromInt64(slot)); err != nil {
+		return err
+	}
+	g.last = slot
+	return nil
+}
+
+// standby follows the chain and takes over sealing after the primary is silent for a window,
+// it steps back once a new block of the primary is seen.
+type standby struct {
+	silence  int64
+	lastSeen int64
+	active   bool
+}
+
+func newStandby(silence int64, now int64) *standby {
+	if silence <= 0 {
+		silence = DefaultStandbySilence
+	}
+	// the primary is assumed alive when the standby starts.
+	return &standby{silence: silence, lastSeen: now}
+}
+
+// PrimarySeen records a block sealed by the primary.
+func (s *standby) PrimarySeen(timestamp int64) {
+	if timestamp > s.lastSeen {
+		s.lastSeen = timestamp
+	}
+	if s.active {
+		s.active = false
+		logging.CLog().WithFields(logrus.Fields{
+			"timestamp": timestamp,
+		}).Warn("The primary is back, standby stops sealing.")
+	}
+}
+
+// CanSeal returns whether the standby seals blocks at now.
+func (s *standby) CanSeal(now int64) bool {
+	if !s.active && now-s.lastSeen >= s.silence {
+		s.active = true
+		logging.CLog().WithFields(logrus.Fields{
+			"lastSeen": s.lastSeen,
+			"silence":  s.silence,
+		}).Warn("The primary is silent, standby takes over sealing.")
+	}
+	return s.active
+}
+
+// observe records the block of the miner sealed by other nodes.
+func (p *Dpos) observe(block *core.Block) {
+	miner := block.Miner()
+	if miner == nil || !miner.Equals(p.miner) {
+		return
+	}
+	// the slots signed by this node are never after the latest recorded one.
+	if block.Timestamp() <= p.guard.Last() {
+		return
+	}
+	if err := p.guard.Observe(block.Timestamp()); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Error("Failed to record the slot signed by another node.")
+	}
+	if p.standby != nil {
+		p.standby.PrimarySeen(block.Timestamp())
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignGuard(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	miner, _ := core.AddressParse("1a263547d167c74cf4b8f9166cfa244de0481c514a45aa2c")
+	guard := newSignGuard(stor, miner)
+
+	assert.Nil(t, guard.Acquire(10))
+	assert.Equal(t, ErrDoubleSign, guard.Acquire(10))
+	assert.Equal(t, ErrDoubleSign, guard.Acquire(5))
+
+	// the slot signed by another node is refused.
+	assert.Nil(t, guard.Observe(15))
+	assert.Nil(t, guard.Observe(12))
+	assert.Equal(t, ErrDoubleSign, guard.Acquire(15))
+
+	// the record survives restart.
+	guard = newSignGuard(stor, miner)
+	assert.Equal(t, int64(15), guard.Last())
+	assert.Nil(t, guard.Acquire(20))
+}
+
+func TestStandby(t *testing.T) {
+	s := newStandby(0, 100)
+	assert.Equal(t, DefaultStandbySilence, s.silence)
+	assert.False(t, s.CanSeal(100+DefaultStandbySilence-1))
+
+	s.PrimarySeen(120)
+	assert.False(t, s.CanSeal(100+DefaultStandbySilence))
+	assert.True(t, s.CanSeal(120+DefaultStandbySilence))
+	assert.True(t, s.CanSeal(125+DefaultStandbySilence))
+
+	// steps back when the primary is back.
+	s.PrimarySeen(130 + DefaultStandbySilence)
+	assert.False(t, s.CanSeal(135+DefaultStandbySilence))
+}
+
+func TestDpos_Standby(t *testing.T) {
+	neb := mockNeb()
+	neb.config.Chain.Standby = true
+	neb.config.Chain.StandbySilence = 30
+	dpos, err := NewDpos(neb)
+	assert.Nil(t, err)
+	assert.NotNil(t, dpos.standby)
+	assert.Equal(t, int64(30), dpos.standby.silence)
+}
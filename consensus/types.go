@@ -18,7 +18,10 @@
 
 package consensus
 
-import "github.com/nebulasio/go-nebulas/core"
+import (
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/net"
+)
 
 // EventType list
 const (
@@ -39,6 +42,12 @@ type Consensus interface {
 	FastVerifyBlock(block *core.Block) error
 }
 
+// MessageHandler handles the consensus messages of proposers, prepares and votes, which are
+// dispatched apart from the gossip of blocks and transactions.
+type MessageHandler interface {
+	HandleConsensusMessage(msg net.Message) error
+}
+
 // EventType of Events in Consensus State-Machine
 type EventType string
 
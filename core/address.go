@@ -19,6 +19,7 @@
 package core
 
 import (
+	"encoding/hex"
 	"strings"
 
 	"github.com/nebulasio/go-nebulas/crypto/hash"
@@ -111,8 +112,27 @@ func NewContractAddressFromHash(s []byte) (*Address, error) {
 	return NewAddress(s[len(s)-AddressDataLength:])
 }
 
-// AddressParse parse address string.
+// AddressParse parse address string, in the legacy hex format or the versioned format of the current
+// network.
 func AddressParse(s string) (*Address, error) {
+	if isLegacyAddress(s) {
+		return parseLegacyAddress(s)
+	}
+	addr, _, err := ParseVersionedAddress(s)
+	return addr, err
+}
+
+// isLegacyAddress returns whether the string is in the legacy hex format.
+func isLegacyAddress(s string) bool {
+	s = strings.TrimPrefix(s, "0x")
+	if len(s) != AddressLength*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func parseLegacyAddress(s string) (*Address, error) {
 	if strings.HasPrefix(s, "0x") {
 		s = s[2:]
 	}
@@ -0,0 +1,156 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"sync/atomic"
+
+	"github.com/mr-tron/base58/base58"
+)
+
+// AddressType is the type of the account of an address in the versioned format.
+type AddressType byte
+
+// AddressNetwork is the prefix of the network of an address in the versioned format.
+type AddressNetwork byte
+
+const (
+	// AccountAddress the type of the addresses of user accounts.
+	AccountAddress AddressType = 0x57
+
+	// ContractAddress the type of the addresses of contracts.
+	ContractAddress AddressType = 0x58
+
+	// MainNetwork the network of the addresses on the EagleNebula chain.
+	MainNetwork AddressNetwork = 0x19
+
+	// TestNetwork the network of the addresses on the test net.
+	TestNetwork AddressNetwork = 0x1a
+
+	// LocalNetwork the network of the addresses on the other chains.
+	LocalNetwork AddressNetwork = 0x1b
+
+	// VersionedAddressLength the length of an address in the versioned format in byte.
+	VersionedAddressLength = 2 + AddressDataLength + AddressChecksumLength
+)
+
+/*
+The versioned format of an address is prefixed by the network and the type of the address, and the
+checksum covers both of them:
+
+  Data = sha3_256(Public Key)[-20:]
+  CheckSum = sha3_256(Network + Type + Data)[0:4]
+  Address = Base58(Network + Type + Data + CheckSum)
+
+So an address of another network, of the wrong type, or mistyped, never passes AddressParse. The
+addresses in the versioned format are the same as the legacy ones in blocks and state, only the string
+form differs.
+*/
+
+// addressNetwork is the network of the addresses parsed by AddressParse.
+var addressNetwork = uint32(LocalNetwork)
+
+// NetworkOfChain returns the network of the addresses on the chain.
+func NetworkOfChain(chainID uint32) AddressNetwork {
+	switch chainID {
+	case EagleNebula:
+		return MainNetwork
+	case TestNetID:
+		return TestNetwork
+	default:
+		return LocalNetwork
+	}
+}
+
+// SetAddressNetwork sets the network of the addresses parsed by AddressParse, the addresses in the
+// versioned format of other networks are rejected.
+func SetAddressNetwork(network AddressNetwork) {
+	atomic.StoreUint32(&addressNetwork, uint32(network))
+}
+
+// CurrentAddressNetwork returns the network of the addresses parsed by AddressParse.
+func CurrentAddressNetwork() AddressNetwork {
+	return AddressNetwork(atomic.LoadUint32(&addressNetwork))
+}
+
+// Versioned returns the address in the versioned format of the type, on the current network.
+func (a *Address) Versioned(typ AddressType) string {
+	return EncodeVersionedAddress(a, CurrentAddressNetwork(), typ)
+}
+
+// EncodeVersionedAddress returns the address in the versioned format of the network and the type.
+func EncodeVersionedAddress(a *Address, network AddressNetwork, typ AddressType) string {
+	s := make([]byte, 0, VersionedAddressLength)
+	s = append(s, byte(network), byte(typ))
+	s = append(s, a.address[:AddressDataLength]...)
+	s = append(s, checkSum(s)...)
+	return base58.Encode(s)
+}
+
+// ParseVersionedAddress parses the address in the versioned format of the current network, and
+// returns its type.
+func ParseVersionedAddress(s string) (*Address, AddressType, error) {
+	return parseVersionedAddress(s, CurrentAddressNetwork())
+}
+
+func parseVersionedAddress(s string, network AddressNetwork) (*Address, AddressType, error) {
+	r, err := base58.Decode(s)
+	if err != nil || len(r) != VersionedAddressLength {
+		return nil, 0, ErrInvalidAddress
+	}
+	if !bytes.Equal(checkSum(r[:VersionedAddressLength-AddressChecksumLength]), r[VersionedAddressLength-AddressChecksumLength:]) {
+		return nil, 0, ErrInvalidAddress
+	}
+	typ := AddressType(r[1])
+	if typ != AccountAddress && typ != ContractAddress {
+		return nil, 0, ErrInvalidAddressType
+	}
+	if AddressNetwork(r[0]) != network {
+		return nil, 0, ErrAddressOfAnotherNetwork
+	}
+	addr, err := NewAddress(r[2 : 2+AddressDataLength])
+	if err != nil {
+		return nil, 0, err
+	}
+	return addr, typ, nil
+}
+
+// ToVersionedAddress converts an address in the legacy hex format into the versioned format of the
+// type, on the current network.
+func ToVersionedAddress(legacy string, typ AddressType) (string, error) {
+	if typ != AccountAddress && typ != ContractAddress {
+		return "", ErrInvalidAddressType
+	}
+	addr, err := parseLegacyAddress(legacy)
+	if err != nil {
+		return "", err
+	}
+	return addr.Versioned(typ), nil
+}
+
+// ToLegacyAddress converts an address in the versioned format of the current network into the legacy
+// hex format.
+func ToLegacyAddress(versioned string) (string, error) {
+	addr, _, err := ParseVersionedAddress(versioned)
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
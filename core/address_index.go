@@ -0,0 +1,153 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+const (
+	// MaxAddressTxsPerQuery is the max count of transactions returned by a query of address.
+	MaxAddressTxsPerQuery = 100
+
+	// addressTxsPrefix is the prefix of the keys of the address index, the count of transactions
+	// of an address is stored at the prefix with the address, and the hashes at the positions appended.
+	addressTxsPrefix = "addr_txs_"
+)
+
+func addressTxsCountKey(addr []byte) []byte {
+	return append([]byte(addressTxsPrefix), addr...)
+}
+
+func addressTxsKey(addr []byte, index uint64) []byte {
+	return append(addressTxsCountKey(addr), byteutils.FromUint64(index)...)
+}
+
+// AddressTransactionCount returns the count of transactions sent or received by the address in canonical chain.
+func (bc *BlockChain) AddressTransactionCount(addr *Address) uint64 {
+	return bc.addressTxsCount(addr.Bytes())
+}
+
+func (bc *BlockChain) addressTxsCount(addr []byte) uint64 {
+	data, err := bc.storage.Get(addressTxsCountKey(addr))
+	if err != nil {
+		return 0
+	}
+	return byteutils.Uint64(data)
+}
+
+// GetTransactionsByAddress returns the transactions sent or received by the address in canonical chain,
+// newest first, skipping offset ones. At most MaxAddressTxsPerQuery transactions are returned.
+// The transactions are indexed when their blocks become canonical, those before the index is built
+// or before a snapshot fast synced are not found.
+func (bc *BlockChain) GetTransactionsByAddress(addr *Address, offset, limit uint64) ([]*Transaction, error) {
+	if limit > MaxAddressTxsPerQuery {
+		limit = MaxAddressTxsPerQuery
+	}
+	count := bc.addressTxsCount(addr.Bytes())
+	if offset >= count {
+		return nil, nil
+	}
+	tail := bc.TailBlock()
+	var txs []*Transaction
+	for index := count - offset; index > 0 && uint64(len(txs)) < limit; index-- {
+		hash, err := bc.storage.Get(addressTxsKey(addr.Bytes(), index-1))
+		if err != nil {
+			return nil, err
+		}
+		tx, err := tail.GetTransaction(hash)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// indexAddressTransactions unwinds the transactions of the blocks reverted, and indexes those of the blocks applied.
+func (bc *BlockChain) indexAddressTransactions(reverted, applied []*Block) error {
+	for _, block := range reverted {
+		for i := len(block.transactions) - 1; i >= 0; i-- {
+			if err := bc.unindexTransaction(block.transactions[i]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, block := range applied {
+		for _, tx := range block.transactions {
+			if err := bc.indexTransaction(tx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// txAddresses returns the addresses a transaction is indexed for.
+func txAddresses(tx *Transaction) [][]byte {
+	from, to := tx.From().Bytes(), tx.To().Bytes()
+	if byteutils.Equal(from, to) {
+		return [][]byte{from}
+	}
+	return [][]byte{from, to}
+}
+
+func (bc *BlockChain) indexTransaction(tx *Transaction) error {
+	for _, addr := range txAddresses(tx) {
+		count := bc.addressTxsCount(addr)
+		if err := bc.storage.Put(addressTxsKey(addr, count), tx.Hash()); err != nil {
+			return err
+		}
+		if err := bc.storage.Put(addressTxsCountKey(addr), byteutils.FromUint64(count+1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexTransaction removes the transaction indexed last.
+func (bc *BlockChain) unindexTransaction(tx *Transaction) error {
+	for _, addr := range txAddresses(tx) {
+		count := bc.addressTxsCount(addr)
+		if count == 0 {
+			continue
+		}
+		hash, err := bc.storage.Get(addressTxsKey(addr, count-1))
+		if err != nil && err != storage.ErrKeyNotFound {
+			return err
+		}
+		if !byteutils.Equal(hash, tx.Hash()) {
+			// the transaction wasn't indexed.
+			continue
+		}
+		if err := bc.storage.Del(addressTxsKey(addr, count-1)); err != nil {
+			return err
+		}
+		if count == 1 {
+			err = bc.storage.Del(addressTxsCountKey(addr))
+		} else {
+			err = bc.storage.Put(addressTxsCountKey(addr), byteutils.FromUint64(count-1))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
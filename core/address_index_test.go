@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_GetTransactionsByAddress(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	to := &Address{[]byte("01234567890123456789012345")}
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	bc.tailBlock.header.stateRoot = bc.tailBlock.accState.RootHash()
+	bc.tailBlock.commit()
+	bc.storeBlockToStorage(bc.tailBlock)
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	var timestamp int64
+	mint := func(parent *Block, txs ...*Transaction) *Block {
+		for _, tx := range txs {
+			assert.Nil(t, tx.Sign(signature))
+			assert.Nil(t, bc.txPool.Push(tx))
+		}
+		timestamp += BlockInterval
+		coinbase := &Address{validators[int(timestamp/BlockInterval)%len(validators)]}
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(len(txs))
+		assert.Equal(t, len(txs), len(block.transactions))
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		block = bc.GetBlock(block.Hash())
+		assert.Nil(t, bc.SetTailBlock(block))
+		return block
+	}
+	transfer := func(to *Address, nonce uint64) *Transaction {
+		return NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), nonce, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+	}
+
+	/*
+		genesis -- 2 -- 3
+		             \_ 3' -- 4'
+	*/
+	tx1, tx2, tx3 := transfer(to, 1), transfer(from, 2), transfer(to, 2)
+	block2 := mint(bc.tailBlock, tx1)
+	mint(block2, tx2)
+	assert.Equal(t, uint64(2), bc.AddressTransactionCount(from))
+	assert.Equal(t, uint64(1), bc.AddressTransactionCount(to))
+
+	txs, err := bc.GetTransactionsByAddress(from, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(txs))
+	assert.Equal(t, tx2.Hash(), txs[0].Hash())
+	assert.Equal(t, tx1.Hash(), txs[1].Hash())
+	txs, err = bc.GetTransactionsByAddress(from, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(txs))
+	assert.Equal(t, tx1.Hash(), txs[0].Hash())
+	txs, err = bc.GetTransactionsByAddress(from, 2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(txs))
+
+	// the transactions of the blocks reverted are unwound.
+	block3 := mint(block2)
+	bc.txPool.Pop()
+	mint(block3, tx3)
+	assert.Equal(t, uint64(2), bc.AddressTransactionCount(from))
+	assert.Equal(t, uint64(2), bc.AddressTransactionCount(to))
+	txs, err = bc.GetTransactionsByAddress(to, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(txs))
+	assert.Equal(t, tx3.Hash(), txs[0].Hash())
+	assert.Equal(t, tx1.Hash(), txs[1].Hash())
+}
@@ -20,11 +20,13 @@ package core
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
 	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/stretchr/testify/assert"
 )
 
 func mockAddress() *Address {
@@ -189,3 +191,58 @@ func TestNewContractAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionedAddress(t *testing.T) {
+	defer SetAddressNetwork(CurrentAddressNetwork())
+	SetAddressNetwork(MainNetwork)
+
+	legacy := "df4d22611412132d3e9bd322f82e2940674ec1bc03b20e40"
+	addr, err := AddressParse(legacy)
+	assert.Nil(t, err)
+
+	account := addr.Versioned(AccountAddress)
+	contract := addr.Versioned(ContractAddress)
+	assert.True(t, strings.HasPrefix(account, "n1"))
+	assert.NotEqual(t, account, contract)
+
+	// both formats parse into the same address.
+	got, err := AddressParse(account)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, got)
+	got, typ, err := ParseVersionedAddress(contract)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, got)
+	assert.Equal(t, ContractAddress, typ)
+
+	converted, err := ToVersionedAddress(legacy, AccountAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, account, converted)
+	converted, err = ToLegacyAddress(contract)
+	assert.Nil(t, err)
+	assert.Equal(t, legacy, converted)
+	_, err = ToVersionedAddress(legacy, AddressType(0))
+	assert.Equal(t, ErrInvalidAddressType, err)
+
+	// a mistyped address fails the checksum.
+	mistyped := []byte(account)
+	if mistyped[10] == 'a' {
+		mistyped[10] = 'b'
+	} else {
+		mistyped[10] = 'a'
+	}
+	_, err = AddressParse(string(mistyped))
+	assert.Equal(t, ErrInvalidAddress, err)
+
+	// an address of an unknown type, or of another network.
+	_, err = AddressParse(EncodeVersionedAddress(addr, MainNetwork, AddressType(0x59)))
+	assert.Equal(t, ErrInvalidAddressType, err)
+	_, err = AddressParse(EncodeVersionedAddress(addr, TestNetwork, AccountAddress))
+	assert.Equal(t, ErrAddressOfAnotherNetwork, err)
+	SetAddressNetwork(TestNetwork)
+	_, err = AddressParse(account)
+	assert.Equal(t, ErrAddressOfAnotherNetwork, err)
+
+	assert.Equal(t, MainNetwork, NetworkOfChain(EagleNebula))
+	assert.Equal(t, TestNetwork, NetworkOfChain(TestNetID))
+	assert.Equal(t, LocalNetwork, NetworkOfChain(100))
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/audit"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// ExportAuditBundle exports the proof bundle of the canonical chain in height range [from, to],
+// with the proofs of the accounts in the state of the block at height to.
+func (bc *BlockChain) ExportAuditBundle(from uint64, to uint64, addrs []*Address) (*audit.Bundle, error) {
+	tail := bc.TailBlock()
+	if from == 0 || from > to || to > tail.Height() {
+		return nil, ErrInvalidAuditRange
+	}
+
+	// start from the height index, the headers are of the parents of the block at to,
+	// so the range is of one chain even if the canonical chain is reverted meanwhile.
+	last := bc.GetBlockByHeight(to)
+	if last == nil {
+		return nil, ErrNotBlockInCanonicalChain
+	}
+	headers := make([]*audit.Header, to-from+1)
+	block := last
+	for {
+		headers[block.Height()-from] = auditHeader(block)
+		if block.Height() == from {
+			break
+		}
+		if block = bc.GetBlock(block.ParentHash()); block == nil {
+			return nil, ErrNotBlockInCanonicalChain
+		}
+	}
+
+	bundle := &audit.Bundle{
+		Version: audit.BundleVersion,
+		ChainID: bc.ChainID(),
+		Headers: headers,
+	}
+	stateTrie, err := trie.NewTrie(last.StateRoot(), last.Storage())
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		account, err := stateTrie.Get(addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		proof, err := stateTrie.Prove(addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		p, err := audit.NewAccountProof(last.Height(), addr.Bytes(), account, proof)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Accounts = append(bundle.Accounts, p)
+	}
+	return bundle, nil
+}
+
+func auditHeader(block *Block) *audit.Header {
+	h := &audit.Header{
+		Height:          block.Height(),
+		Hash:            block.Hash().String(),
+		ParentHash:      block.ParentHash().String(),
+		StateRoot:       block.StateRoot().String(),
+		TxsRoot:         block.TxsRoot().String(),
+		EventsRoot:      block.EventsRoot().String(),
+		ReceiptsRoot:    block.ReceiptsRoot().String(),
+		DposContextHash: block.DposContextHash().String(),
+		GasLimit:        block.GasLimit().String(),
+		GasUsed:         block.GasUsed().String(),
+		EventBloom:      byteutils.Hex(block.header.eventBloom),
+		Nonce:           block.Nonce(),
+		Coinbase:        byteutils.Hex(block.header.coinbase.address),
+		Timestamp:       block.Timestamp(),
+	}
+	for _, tx := range block.transactions {
+		h.TxHashes = append(h.TxHashes, tx.Hash().String())
+	}
+	return h
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Package audit defines the proof bundle of chain data exported for auditors, and verifies it without a node.
+// It must not depend on package core, so that the auditor tool doesn't link the node.
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"golang.org/x/crypto/sha3"
+)
+
+// Errors
+var (
+	ErrEmptyBundle          = errors.New("audit: no header in bundle")
+	ErrInvalidChainID       = errors.New("audit: invalid chain id of header")
+	ErrInvalidHeight        = errors.New("audit: headers are not continuous")
+	ErrInvalidHeaderHash    = errors.New("audit: invalid header hash")
+	ErrBrokenChain          = errors.New("audit: header is not linked to its parent")
+	ErrUntrustedTail        = errors.New("audit: the last header doesn't match the trusted hash")
+	ErrHeightNotInBundle    = errors.New("audit: account proof height is not in bundle")
+	ErrInvalidAccountProof  = errors.New("audit: invalid account proof")
+	ErrAccountMismatch      = errors.New("audit: account fields don't match the proved value")
+	ErrInvalidBundleVersion = errors.New("audit: unsupported bundle version")
+	ErrInvalidGas           = errors.New("audit: invalid gas of header")
+)
+
+// BundleVersion the version of bundle format.
+const BundleVersion = 3
+
+// Bundle is a compact proof of the chain data in a height range: the linked headers,
+// which form the chain of state roots, and the proofs of selected accounts in the states.
+type Bundle struct {
+	Version  uint32          `json:"version"`
+	ChainID  uint32          `json:"chain_id"`
+	Headers  []*Header       `json:"headers"`
+	Accounts []*AccountProof `json:"accounts"`
+}
+
+// Header is the part of a block header needed to recompute the block hash.
+type Header struct {
+	Height          uint64   `json:"height"`
+	Hash            string   `json:"hash"`
+	ParentHash      string   `json:"parent_hash"`
+	StateRoot       string   `json:"state_root"`
+	TxsRoot         string   `json:"txs_root"`
+	EventsRoot      string   `json:"events_root"`
+	ReceiptsRoot    string   `json:"receipts_root"`
+	DposContextHash string   `json:"dpos_context_hash"`
+	GasLimit        string   `json:"gas_limit"`
+	GasUsed         string   `json:"gas_used"`
+	EventBloom      string   `json:"event_bloom,omitempty"`
+	Nonce           uint64   `json:"nonce"`
+	Coinbase        string   `json:"coinbase"`
+	Timestamp       int64    `json:"timestamp"`
+	TxHashes        []string `json:"tx_hashes"`
+}
+
+// AccountProof proves an account in the state of the block at Height.
+// Account is the value in state trie, Balance and Nonce are decoded from it for readers.
+type AccountProof struct {
+	Height  uint64     `json:"height"`
+	Address string     `json:"address"`
+	Balance string     `json:"balance"`
+	Nonce   uint64     `json:"nonce"`
+	Account string     `json:"account"`
+	Proof   [][]string `json:"proof"`
+}
+
+// Report is the verified content of a bundle.
+type Report struct {
+	ChainID  uint32          `json:"chain_id"`
+	From     uint64          `json:"from"`
+	To       uint64          `json:"to"`
+	TailHash string          `json:"tail_hash"`
+	Accounts []*AccountProof `json:"accounts"`
+}
+
+// Encode writes the bundle in JSON.
+func (b *Bundle) Encode(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// Decode reads a bundle in JSON.
+func Decode(r io.Reader) (*Bundle, error) {
+	b := new(Bundle)
+	if err := json.NewDecoder(r).Decode(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// NewAccountProof builds the proof of an account from its value in state trie and the merkle proof.
+func NewAccountProof(height uint64, address []byte, account []byte, proof trie.MerkleProof) (*AccountProof, error) {
+	pb := new(corepb.Account)
+	if err := proto.Unmarshal(account, pb); err != nil {
+		return nil, err
+	}
+	balance, err := util.NewUint128FromFixedSizeByteSlice(pb.Balance)
+	if err != nil {
+		return nil, err
+	}
+	p := &AccountProof{
+		Height:  height,
+		Address: byteutils.Hex(address),
+		Balance: balance.String(),
+		Nonce:   pb.Nonce,
+		Account: byteutils.Hex(account),
+	}
+	for _, node := range proof {
+		var vals []string
+		for _, v := range node {
+			vals = append(vals, byteutils.Hex(v))
+		}
+		p.Proof = append(p.Proof, vals)
+	}
+	return p, nil
+}
+
+// HashHeader computes the block hash of a header the same way as core.HashBlock.
+func HashHeader(h *Header, chainID uint32) (byteutils.Hash, error) {
+	fields := []string{h.ParentHash, h.StateRoot, h.TxsRoot, h.EventsRoot, h.ReceiptsRoot, h.DposContextHash}
+	hasher := sha3.New256()
+	for _, v := range fields {
+		data, err := byteutils.FromHex(v)
+		if err != nil {
+			return nil, err
+		}
+		hasher.Write(data)
+	}
+	for _, v := range []string{h.GasLimit, h.GasUsed} {
+		// the gas of the blocks created before the gas limit of blocks is empty.
+		gas := util.NewUint128()
+		if len(v) > 0 {
+			var ok bool
+			if gas, ok = util.NewUint128().FromString(v); !ok {
+				return nil, ErrInvalidGas
+			}
+		}
+		data, err := gas.ToFixedSizeByteSlice()
+		if err != nil {
+			return nil, err
+		}
+		hasher.Write(data)
+	}
+	// the bloom of the blocks created before the event bloom of blocks is empty.
+	bloom, err := byteutils.FromHex(h.EventBloom)
+	if err != nil {
+		return nil, err
+	}
+	hasher.Write(bloom)
+	coinbase, err := byteutils.FromHex(h.Coinbase)
+	if err != nil {
+		return nil, err
+	}
+	hasher.Write(byteutils.FromUint64(h.Nonce))
+	hasher.Write(coinbase)
+	hasher.Write(byteutils.FromInt64(h.Timestamp))
+	hasher.Write(byteutils.FromUint32(chainID))
+	for _, v := range h.TxHashes {
+		data, err := byteutils.FromHex(v)
+		if err != nil {
+			return nil, err
+		}
+		hasher.Write(data)
+	}
+	return hasher.Sum(nil), nil
+}
+
+// Verify checks the headers are continuous, correctly hashed and linked, that the last one
+// matches the trusted hash if given, and that every account proof leads to a state root in the chain.
+// The trusted hash should come from a source independent of the bundle's provider.
+func Verify(b *Bundle, trusted byteutils.Hash) (*Report, error) {
+	if b.Version != BundleVersion {
+		return nil, ErrInvalidBundleVersion
+	}
+	if len(b.Headers) == 0 {
+		return nil, ErrEmptyBundle
+	}
+
+	stateRoots := make(map[uint64]byteutils.Hash)
+	var parent *Header
+	for _, h := range b.Headers {
+		if parent != nil {
+			if h.Height != parent.Height+1 {
+				return nil, ErrInvalidHeight
+			}
+			if h.ParentHash != parent.Hash {
+				return nil, ErrBrokenChain
+			}
+		}
+		hash, err := HashHeader(h, b.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		if hash.Hex() != byteutils.HexHash(h.Hash) {
+			return nil, ErrInvalidHeaderHash
+		}
+		root, err := byteutils.FromHex(h.StateRoot)
+		if err != nil {
+			return nil, err
+		}
+		stateRoots[h.Height] = root
+		parent = h
+	}
+	if trusted != nil && trusted.Hex() != byteutils.HexHash(parent.Hash) {
+		return nil, ErrUntrustedTail
+	}
+
+	for _, acc := range b.Accounts {
+		root, ok := stateRoots[acc.Height]
+		if !ok {
+			return nil, ErrHeightNotInBundle
+		}
+		if err := verifyAccountProof(root, acc); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Report{
+		ChainID:  b.ChainID,
+		From:     b.Headers[0].Height,
+		To:       parent.Height,
+		TailHash: parent.Hash,
+		Accounts: b.Accounts,
+	}, nil
+}
+
+func verifyAccountProof(root byteutils.Hash, acc *AccountProof) error {
+	address, err := byteutils.FromHex(acc.Address)
+	if err != nil {
+		return err
+	}
+	account, err := byteutils.FromHex(acc.Account)
+	if err != nil {
+		return err
+	}
+	var proof trie.MerkleProof
+	for _, node := range acc.Proof {
+		var vals [][]byte
+		for _, v := range node {
+			val, err := byteutils.FromHex(v)
+			if err != nil {
+				return err
+			}
+			vals = append(vals, val)
+		}
+		proof = append(proof, vals)
+	}
+	if len(proof) == 0 {
+		return ErrInvalidAccountProof
+	}
+
+	// the proof must end with the leaf holding the account.
+	leaf := proof[len(proof)-1]
+	if len(leaf) != 3 || !bytes.Equal(leaf[2], account) {
+		return ErrInvalidAccountProof
+	}
+	stor, err := storage.NewMemoryStorage()
+	if err != nil {
+		return err
+	}
+	t, err := trie.NewTrie(nil, stor)
+	if err != nil {
+		return err
+	}
+	if err := t.Verify(root, address, proof); err != nil {
+		return ErrInvalidAccountProof
+	}
+
+	decoded, err := NewAccountProof(acc.Height, address, account, nil)
+	if err != nil {
+		return err
+	}
+	if decoded.Balance != acc.Balance || decoded.Nonce != acc.Nonce {
+		return ErrAccountMismatch
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockHeaders(t *testing.T, chainID uint32, from uint64, count int, stateRoot []byte) []*Header {
+	var headers []*Header
+	parent := byteutils.Hex(make([]byte, 32))
+	for i := 0; i < count; i++ {
+		h := &Header{
+			Height:     from + uint64(i),
+			ParentHash: parent,
+			StateRoot:  byteutils.Hex(stateRoot),
+			Coinbase:   byteutils.Hex([]byte("coinbase")),
+			Timestamp:  int64(i),
+		}
+		hash, err := HashHeader(h, chainID)
+		assert.Nil(t, err)
+		h.Hash = hash.String()
+		parent = h.Hash
+		headers = append(headers, h)
+	}
+	return headers
+}
+
+func TestVerify(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	stateTrie, _ := trie.NewTrie(nil, stor)
+
+	balance, _ := util.NewUint128FromInt(100).ToFixedSizeByteSlice()
+	address := []byte("address")
+	account, _ := proto.Marshal(&corepb.Account{Address: address, Balance: balance, Nonce: 3})
+	stateTrie.Put(address, account)
+	stateTrie.Put([]byte("another"), []byte("value"))
+	proof, err := stateTrie.Prove(address)
+	assert.Nil(t, err)
+
+	acc, err := NewAccountProof(12, address, account, proof)
+	assert.Nil(t, err)
+	assert.Equal(t, "100", acc.Balance)
+	assert.Equal(t, uint64(3), acc.Nonce)
+
+	bundle := &Bundle{
+		Version:  BundleVersion,
+		ChainID:  100,
+		Headers:  mockHeaders(t, 100, 10, 3, stateTrie.RootHash()),
+		Accounts: []*AccountProof{acc},
+	}
+	report, err := Verify(bundle, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), report.From)
+	assert.Equal(t, uint64(12), report.To)
+
+	tests := []struct {
+		name   string
+		mutate func(b *Bundle)
+		err    error
+	}{
+		{"empty", func(b *Bundle) { b.Headers = nil }, ErrEmptyBundle},
+		{"version", func(b *Bundle) { b.Version = 0 }, ErrInvalidBundleVersion},
+		{"chainID", func(b *Bundle) { b.ChainID = 1 }, ErrInvalidHeaderHash},
+		{"gap", func(b *Bundle) { b.Headers = append(b.Headers[:1], b.Headers[2:]...) }, ErrInvalidHeight},
+		{"link", func(b *Bundle) { b.Headers[1].ParentHash = b.Headers[2].Hash }, ErrBrokenChain},
+		{"height", func(b *Bundle) { b.Accounts[0].Height = 13 }, ErrHeightNotInBundle},
+		{"value", func(b *Bundle) { b.Accounts[0].Account = byteutils.Hex([]byte("value")) }, ErrInvalidAccountProof},
+		{"proof", func(b *Bundle) { b.Accounts[0].Proof = b.Accounts[0].Proof[1:] }, ErrInvalidAccountProof},
+		{"nonce", func(b *Bundle) { b.Accounts[0].Nonce = 4 }, ErrAccountMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Bundle{
+				Version:  BundleVersion,
+				ChainID:  100,
+				Headers:  mockHeaders(t, 100, 10, 3, stateTrie.RootHash()),
+				Accounts: []*AccountProof{{}},
+			}
+			*b.Accounts[0] = *acc
+			tt.mutate(b)
+			_, err := Verify(b, nil)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_ExportAuditBundle(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := &Address{[]byte("012345678901234567890000")}
+
+	var blocks []*Block
+	for i := 0; i < 5; i++ {
+		block, _ := bc.NewBlock(coinbase)
+		block.header.timestamp = BlockInterval * int64(i+1)
+		blocks = append(blocks, block)
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		block.Seal()
+		bc.BlockPool().Push(block)
+		bc.SetTailBlock(block)
+	}
+
+	_, err := bc.ExportAuditBundle(blocks[3].Height(), blocks[1].Height(), nil)
+	assert.Equal(t, ErrInvalidAuditRange, err)
+	_, err = bc.ExportAuditBundle(blocks[1].Height(), blocks[4].Height()+1, nil)
+	assert.Equal(t, ErrInvalidAuditRange, err)
+
+	bundle, err := bc.ExportAuditBundle(blocks[1].Height(), blocks[3].Height(), []*Address{coinbase})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(bundle.Headers))
+	assert.Equal(t, 1, len(bundle.Accounts))
+	assert.Equal(t, blocks[3].GetBalance(coinbase.Bytes()).String(), bundle.Accounts[0].Balance)
+
+	// the bundle can be verified after encoded.
+	var buf bytes.Buffer
+	assert.Nil(t, bundle.Encode(&buf))
+	decoded, err := audit.Decode(&buf)
+	assert.Nil(t, err)
+	report, err := audit.Verify(decoded, blocks[3].Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, blocks[1].Height(), report.From)
+	assert.Equal(t, blocks[3].Height(), report.To)
+
+	_, err = audit.Verify(bundle, blocks[4].Hash())
+	assert.Equal(t, audit.ErrUntrustedTail, err)
+
+	bundle.Accounts[0].Balance = "1"
+	_, err = audit.Verify(bundle, nil)
+	assert.Equal(t, audit.ErrAccountMismatch, err)
+
+	bundle.Headers[1].Timestamp++
+	_, err = audit.Verify(bundle, nil)
+	assert.Equal(t, audit.ErrInvalidHeaderHash, err)
+}
@@ -24,9 +24,8 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/nebulasio/go-nebulas/crypto/keystore"
-
 	"github.com/gogo/protobuf/proto"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/nebulasio/go-nebulas/common/trie"
 	"github.com/nebulasio/go-nebulas/core/pb"
 	"github.com/nebulasio/go-nebulas/core/state"
@@ -56,10 +55,18 @@ type BlockHeader struct {
 	parentHash byteutils.Hash
 
 	// world state
-	stateRoot   byteutils.Hash
-	txsRoot     byteutils.Hash
-	eventsRoot  byteutils.Hash
-	dposContext *corepb.DposContext
+	stateRoot    byteutils.Hash
+	txsRoot      byteutils.Hash
+	eventsRoot   byteutils.Hash
+	receiptsRoot byteutils.Hash
+	dposContext  *corepb.DposContext
+
+	// gas
+	gasLimit *util.Uint128
+	gasUsed  *util.Uint128
+
+	// bloom of event topics and contract addresses
+	eventBloom []byte
 
 	coinbase  *Address
 	nonce     uint64
@@ -73,19 +80,31 @@ type BlockHeader struct {
 
 // ToProto converts domain BlockHeader to proto BlockHeader
 func (b *BlockHeader) ToProto() (proto.Message, error) {
+	gasLimit, err := gasBytes(b.gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	gasUsed, err := gasBytes(b.gasUsed)
+	if err != nil {
+		return nil, err
+	}
 	return &corepb.BlockHeader{
-		Hash:        b.hash,
-		ParentHash:  b.parentHash,
-		StateRoot:   b.stateRoot,
-		TxsRoot:     b.txsRoot,
-		EventsRoot:  b.eventsRoot,
-		DposContext: b.dposContext,
-		Nonce:       b.nonce,
-		Coinbase:    b.coinbase.address,
-		Timestamp:   b.timestamp,
-		ChainId:     b.chainID,
-		Alg:         uint32(b.alg),
-		Sign:        b.sign,
+		Hash:         b.hash,
+		ParentHash:   b.parentHash,
+		StateRoot:    b.stateRoot,
+		TxsRoot:      b.txsRoot,
+		EventsRoot:   b.eventsRoot,
+		ReceiptsRoot: b.receiptsRoot,
+		DposContext:  b.dposContext,
+		GasLimit:     gasLimit,
+		GasUsed:      gasUsed,
+		EventBloom:   b.eventBloom,
+		Nonce:        b.nonce,
+		Coinbase:     b.coinbase.address,
+		Timestamp:    b.timestamp,
+		ChainId:      b.chainID,
+		Alg:          uint32(b.alg),
+		Sign:         b.sign,
 	}, nil
 }
 
@@ -97,7 +116,19 @@ func (b *BlockHeader) FromProto(msg proto.Message) error {
 		b.stateRoot = msg.StateRoot
 		b.txsRoot = msg.TxsRoot
 		b.eventsRoot = msg.EventsRoot
+		b.receiptsRoot = msg.ReceiptsRoot
 		b.dposContext = msg.DposContext
+		gasLimit, err := gasFromBytes(msg.GasLimit)
+		if err != nil {
+			return err
+		}
+		b.gasLimit = gasLimit
+		gasUsed, err := gasFromBytes(msg.GasUsed)
+		if err != nil {
+			return err
+		}
+		b.gasUsed = gasUsed
+		b.eventBloom = msg.EventBloom
 		b.nonce = msg.Nonce
 		b.coinbase = &Address{msg.Coinbase}
 		b.timestamp = msg.Timestamp
@@ -120,17 +151,34 @@ type Block struct {
 	accState     state.AccountState
 	txsTrie      *trie.BatchTrie
 	eventsTrie   *trie.BatchTrie
+	receiptsTrie *trie.BatchTrie
 	dposContext  *DposContext
 	txPool       *TransactionPool
 	miner        *Address
+	gasUsed      *util.Uint128
+	eventBloom   Bloom
+
+	// the participations of validators in the dynasties ended by the block.
+	participations []*ValidatorParticipation
+
+	// the changes of the dynasties ended by the block.
+	dynastyChanges []*DynastyChange
 
 	storage      storage.Storage
 	eventEmitter *EventEmitter
+	rewards      *RewardSchedule
+	dpos         *DposSchedule
+
+	// verifiedBlocks is the cache of the chain of the blocks verified recently.
+	verifiedBlocks *lru.Cache
 }
 
 // ToProto converts domain Block into proto Block
 func (block *Block) ToProto() (proto.Message, error) {
-	header, _ := block.header.ToProto()
+	header, err := block.header.ToProto()
+	if err != nil {
+		return nil, err
+	}
 	if header, ok := header.(*corepb.BlockHeader); ok {
 		var txs []*corepb.Transaction
 		for _, v := range block.transactions {
@@ -184,6 +232,9 @@ func (block *Block) SerializeTxByHash(hash byteutils.Hash) (proto.Message, error
 
 // NewBlock return new block.
 func NewBlock(chainID uint32, coinbase *Address, parent *Block) (*Block, error) {
+	if parent.StatesPruned() {
+		return nil, ErrBlockStatePruned
+	}
 	accState, err := parent.accState.Clone()
 	if err != nil {
 		return nil, err
@@ -196,6 +247,10 @@ func NewBlock(chainID uint32, coinbase *Address, parent *Block) (*Block, error)
 	if err != nil {
 		return nil, err
 	}
+	receiptsTrie, err := parent.receiptsTrie.Clone()
+	if err != nil {
+		return nil, err
+	}
 	dposContext, err := parent.dposContext.Clone()
 	if err != nil {
 		return nil, err
@@ -204,6 +259,7 @@ func NewBlock(chainID uint32, coinbase *Address, parent *Block) (*Block, error)
 		header: &BlockHeader{
 			parentHash:  parent.Hash(),
 			dposContext: &corepb.DposContext{},
+			gasLimit:    NextBlockGasLimit(parent),
 			coinbase:    coinbase,
 			nonce:       0,
 			timestamp:   time.Now().Unix(),
@@ -214,12 +270,18 @@ func NewBlock(chainID uint32, coinbase *Address, parent *Block) (*Block, error)
 		accState:     accState,
 		txsTrie:      txsTrie,
 		eventsTrie:   eventsTrie,
+		receiptsTrie: receiptsTrie,
 		dposContext:  dposContext,
 		txPool:       parent.txPool,
+		gasUsed:      util.NewUint128(),
 		height:       parent.height + 1,
 		sealed:       false,
 		storage:      parent.storage,
 		eventEmitter: parent.eventEmitter,
+		rewards:      parent.rewards,
+		dpos:         parent.dpos,
+
+		verifiedBlocks: parent.verifiedBlocks,
 	}
 
 	block.begin()
@@ -229,13 +291,13 @@ func NewBlock(chainID uint32, coinbase *Address, parent *Block) (*Block, error)
 	return block, nil
 }
 
-// Sign sign transaction,sign algorithm is
-func (block *Block) Sign(signature keystore.Signature) error {
-	sign, err := signature.Sign(block.header.hash)
+// Sign sign block with the signer of the miner, a signature initialized with its key or a remote signer.
+func (block *Block) Sign(signer BlockSigner) error {
+	sign, err := signer.Sign(block.header.hash)
 	if err != nil {
 		return err
 	}
-	block.header.alg = uint8(signature.Algorithm())
+	block.header.alg = uint8(signer.Algorithm())
 	block.header.sign = sign
 	return nil
 }
@@ -322,6 +384,11 @@ func (block *Block) EventsRoot() byteutils.Hash {
 	return block.header.eventsRoot
 }
 
+// ReceiptsRoot return receipts root hash.
+func (block *Block) ReceiptsRoot() byteutils.Hash {
+	return block.header.receiptsRoot
+}
+
 // DposContext return dpos context
 func (block *Block) DposContext() *corepb.DposContext {
 	return block.header.dposContext
@@ -355,6 +422,9 @@ func (block *Block) ParentBlock() (*Block, error) {
 	if err != nil {
 		return nil, ErrMissingParentBlock
 	}
+	parentBlock.rewards = block.rewards
+	parentBlock.dpos = block.dpos
+	parentBlock.verifiedBlocks = block.verifiedBlocks
 	return parentBlock, nil
 }
 
@@ -384,6 +454,9 @@ func (block *Block) LinkParentBlock(parentBlock *Block) error {
 	if block.ParentHash().Equals(parentBlock.Hash()) == false {
 		return ErrLinkToWrongParentBlock
 	}
+	if parentBlock.StatesPruned() {
+		return ErrBlockStatePruned
+	}
 
 	var err error
 	if block.accState, err = parentBlock.accState.Clone(); err != nil {
@@ -395,6 +468,9 @@ func (block *Block) LinkParentBlock(parentBlock *Block) error {
 	if block.eventsTrie, err = parentBlock.eventsTrie.Clone(); err != nil {
 		return ErrCloneEventsState
 	}
+	if block.receiptsTrie, err = parentBlock.receiptsTrie.Clone(); err != nil {
+		return ErrCloneReceiptsState
+	}
 
 	elapsedSecond := block.Timestamp() - parentBlock.Timestamp()
 	context, err := parentBlock.NextDynastyContext(elapsedSecond)
@@ -410,6 +486,9 @@ func (block *Block) LinkParentBlock(parentBlock *Block) error {
 	block.storage = parentBlock.storage
 	block.height = parentBlock.height + 1
 	block.eventEmitter = parentBlock.eventEmitter
+	block.rewards = parentBlock.rewards
+	block.dpos = parentBlock.dpos
+	block.verifiedBlocks = parentBlock.verifiedBlocks
 
 	logging.VLog().WithFields(logrus.Fields{
 		"parent": parentBlock,
@@ -425,6 +504,7 @@ func (block *Block) begin() {
 	block.accState.BeginBatch()
 	block.txsTrie.BeginBatch()
 	block.eventsTrie.BeginBatch()
+	block.receiptsTrie.BeginBatch()
 	block.dposContext.BeginBatch()
 }
 
@@ -432,6 +512,7 @@ func (block *Block) commit() {
 	block.accState.Commit()
 	block.txsTrie.Commit()
 	block.eventsTrie.Commit()
+	block.receiptsTrie.Commit()
 	block.dposContext.Commit()
 	logging.VLog().WithFields(logrus.Fields{
 		"block": block,
@@ -442,6 +523,7 @@ func (block *Block) rollback() {
 	block.accState.RollBack()
 	block.txsTrie.RollBack()
 	block.eventsTrie.RollBack()
+	block.receiptsTrie.RollBack()
 	block.dposContext.RollBack()
 	logging.VLog().WithFields(logrus.Fields{
 		"block": block,
@@ -460,20 +542,32 @@ func (block *Block) ReturnTransactions() {
 
 // CollectTransactions and add them to block.
 func (block *Block) CollectTransactions(n int) {
+	block.CollectTransactionsUntil(n, time.Time{})
+}
+
+// CollectTransactionsUntil collect transactions from tx pool till the deadline, no deadline if zero.
+// It returns the count of transactions packed.
+func (block *Block) CollectTransactionsUntil(n int, deadline time.Time) int {
 	if block.sealed {
 		logging.VLog().WithFields(logrus.Fields{
 			"block": block,
 		}).Error("Sealed block can't be changed.")
-		return
+		return 0
 	}
+	packed := len(block.transactions)
 
+	// the transactions are popped in order of gas price, until the gas left can't hold one more.
 	pool := block.txPool
-	var givebacks []*Transaction
-	for !pool.Empty() && n > 0 {
+	var givebacks, gaps []*Transaction
+	for !pool.Empty() && n > 0 && block.gasLeft().Cmp(MinGasCountPerTransaction.Int) >= 0 &&
+		(deadline.IsZero() || time.Now().Before(deadline)) {
 		tx := pool.Pop()
 		block.begin()
 		giveback, err := block.executeTransaction(tx)
-		if giveback {
+		if err == ErrLargeTransactionNonce {
+			// queued until the nonce gap is filled, rather than popped again for next block.
+			gaps = append(gaps, tx)
+		} else if giveback {
 			givebacks = append(givebacks, tx)
 		}
 		if err == nil {
@@ -495,6 +589,15 @@ func (block *Block) CollectTransactions(n int) {
 			block.rollback()
 		}
 	}
+	for _, tx := range gaps {
+		if err := pool.Requeue(tx); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": block,
+				"tx":    tx,
+				"err":   err,
+			}).Error("Failed to requeue the tx.")
+		}
+	}
 	for _, tx := range givebacks {
 		err := pool.Push(tx)
 		if err != nil {
@@ -505,6 +608,7 @@ func (block *Block) CollectTransactions(n int) {
 			}).Error("Failed to giveback the tx.")
 		}
 	}
+	return len(block.transactions) - packed
 }
 
 // Sealed return true if block seals. Otherwise return false.
@@ -529,6 +633,14 @@ func (block *Block) Seal() error {
 	block.header.stateRoot = block.accState.RootHash()
 	block.header.txsRoot = block.txsTrie.RootHash()
 	block.header.eventsRoot = block.eventsTrie.RootHash()
+	if block.afterHeaderFork() {
+		block.header.receiptsRoot = block.receiptsTrie.RootHash()
+		block.header.gasUsed = block.gasUsed
+		block.header.eventBloom = block.eventBloom.Bytes()
+	} else {
+		// the header is of the nodes before the fork, without the fields it never commits to.
+		block.header.gasLimit = nil
+	}
 	if block.header.dposContext, err = block.dposContext.ToProto(); err != nil {
 		return err
 	}
@@ -560,6 +672,19 @@ func (block *Block) VerifyExecution(parent *Block, consensus Consensus) error {
 		return err
 	}
 
+	if err := block.verifyGasLimit(parent); err != nil {
+		return err
+	}
+
+	// the states of a block verified before are in storage already.
+	if block.verifiedBefore() {
+		if err := block.loadStates(); err == nil {
+			verifiedBlockHitMeter.Mark(1)
+			block.triggerEvent()
+			return nil
+		}
+	}
+
 	block.begin()
 
 	start := time.Now().Unix()
@@ -576,6 +701,7 @@ func (block *Block) VerifyExecution(parent *Block, consensus Consensus) error {
 	}
 
 	block.commit()
+	block.markVerified()
 
 	// release all events
 	block.triggerEvent()
@@ -594,12 +720,22 @@ func (block *Block) triggerEvent() {
 			topic = TopicDeploySmartContract
 		case TxPayloadCallType:
 			topic = TopicCallSmartContract
+		case TxPayloadUpgradeType:
+			topic = TopicUpgradeSmartContract
+		case TxPayloadOracleType:
+			topic = TopicOracle
 		case TxPayloadDelegateType:
 			topic = TopicDelegate
 		case TxPayloadCandidateType:
 			topic = TopicCandidate
+		case TxPayloadMultisigType:
+			topic = TopicMultisig
+		case TxPayloadBatchTransferType:
+			topic = TopicBatchTransfer
+		case TxPayloadSlashType:
+			topic = TopicSlash
 		}
-		data, err := json.Marshal(v)
+		data, _ := json.Marshal(block.txResult(v))
 		event := &Event{
 			Topic: topic,
 			Data:  string(data),
@@ -607,13 +743,16 @@ func (block *Block) triggerEvent() {
 		block.eventEmitter.Trigger(event)
 
 		events, err := block.FetchEvents(v.hash)
-		if err != nil {
+		if err == nil {
 			for _, e := range events {
 				block.eventEmitter.Trigger(e)
 			}
 		}
 	}
 
+	block.triggerParticipations()
+	block.triggerDynastyChanges()
+
 	blockData, _ := json.Marshal(block)
 	e := &Event{
 		Topic: TopicLinkBlock,
@@ -622,6 +761,22 @@ func (block *Block) triggerEvent() {
 	block.eventEmitter.Trigger(e)
 }
 
+// txResult is the execution result of a tx in the event of its payload type.
+type txResult struct {
+	Hash   string          `json:"hash"`
+	Status uint32          `json:"status"`
+	Error  *ExecutionError `json:"error,omitempty"`
+}
+
+func (block *Block) txResult(tx *Transaction) *txResult {
+	result := &txResult{Hash: tx.hash.String(), Status: ReceiptStatusFailed}
+	if receipt, err := block.GetReceipt(tx.hash); err == nil {
+		result.Status = receipt.Status()
+		result.Error = receipt.Failure()
+	}
+	return result
+}
+
 // VerifyIntegrity verify block's hash, txs' integrity and consensus acceptable.
 func (block *Block) VerifyIntegrity(chainID uint32, consensus Consensus) error {
 	// check ChainID.
@@ -644,10 +799,10 @@ func (block *Block) VerifyIntegrity(chainID uint32, consensus Consensus) error {
 	}
 
 	// verify transactions integrity.
-	for _, tx := range block.transactions {
-		if err := tx.VerifyIntegrity(block.header.chainID); err != nil {
+	for i, err := range VerifyTransactions(block.header.chainID, block.transactions) {
+		if err != nil {
 			logging.VLog().WithFields(logrus.Fields{
-				"tx":  tx,
+				"tx":  block.transactions[i],
 				"err": err,
 			}).Error("Failed to verify tx's integrity.")
 			return err
@@ -684,6 +839,23 @@ func (block *Block) verifyState() error {
 		return ErrInvalidBlockEventsRoot
 	}
 
+	if block.afterHeaderFork() {
+		// verify receipts root.
+		if !byteutils.Equal(block.receiptsTrie.RootHash(), block.ReceiptsRoot()) {
+			return ErrInvalidBlockReceiptsRoot
+		}
+
+		// verify gas used.
+		if block.GasUsed().Cmp(block.gasUsed.Int) != 0 {
+			return ErrInvalidBlockGasUsed
+		}
+
+		// verify event bloom.
+		if !byteutils.Equal(block.header.eventBloom, block.eventBloom.Bytes()) {
+			return ErrInvalidBlockEventBloom
+		}
+	}
+
 	// verify transaction root.
 	if !byteutils.Equal(block.dposContext.RootHash(), block.DposContextHash()) {
 		return ErrInvalidBlockDposContextRoot
@@ -694,6 +866,8 @@ func (block *Block) verifyState() error {
 
 // Execute block and return result.
 func (block *Block) execute() error {
+	block.gasUsed = util.NewUint128()
+	block.eventBloom = Bloom{}
 	block.rewardCoinbase()
 
 	for _, tx := range block.transactions {
@@ -732,23 +906,13 @@ func (block *Block) RecordEvent(txHash byteutils.Hash, topic, data string) error
 }
 
 func (block *Block) recordEvent(txHash byteutils.Hash, event *Event) error {
-	iter, err := block.eventsTrie.Iterator(txHash)
-	if err != nil && err != storage.ErrKeyNotFound {
-		return err
-	}
 	cnt := int64(0)
-	if err != storage.ErrKeyNotFound {
-		exist, err := iter.Next()
-		if err != nil {
-			return err
-		}
-		for exist {
-			cnt++
-			exist, err = iter.Next()
-			if err != nil {
-				return err
-			}
-		}
+	err := block.eventsTrie.ScanPrefix(txHash, func(key []byte, value []byte) error {
+		cnt++
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 	cnt++
 	key := append(txHash, byteutils.FromInt64(cnt)...)
@@ -771,33 +935,26 @@ func (block *Block) recordEvent(txHash byteutils.Hash, event *Event) error {
 // FetchEvents fetch events by txHash.
 func (block *Block) FetchEvents(txHash byteutils.Hash) ([]*Event, error) {
 	events := []*Event{}
-	iter, err := block.eventsTrie.Iterator(txHash)
-	if err != nil && err != storage.ErrKeyNotFound {
-		return nil, err
-	}
-	if err != storage.ErrKeyNotFound {
-		exist, err := iter.Next()
-		if err != nil {
-			return nil, err
-		}
-		for exist {
-			event := new(Event)
-			err = json.Unmarshal(iter.Value(), event)
-			if err != nil {
-				return nil, err
-			}
-			events = append(events, event)
-			exist, err = iter.Next()
-			if err != nil {
-				return nil, err
-			}
+	err := block.eventsTrie.ScanPrefix(txHash, func(key []byte, value []byte) error {
+		event := new(Event)
+		if err := json.Unmarshal(value, event); err != nil {
+			return err
 		}
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return events, nil
 }
 
 func (block *Block) recordMintCnt() error {
-	key := append(byteutils.FromInt64(block.Timestamp()/DynastyInterval), block.miner.Bytes()...)
+	dynastyID, err := block.DynastyID(block.Timestamp())
+	if err != nil {
+		return err
+	}
+	key := append(byteutils.FromInt64(dynastyID), block.miner.Bytes()...)
 	bytes, err := block.dposContext.mintCntTrie.Get(key)
 	if err != nil && err != storage.ErrKeyNotFound {
 		return err
@@ -812,19 +969,29 @@ func (block *Block) recordMintCnt() error {
 		return err
 	}
 	logging.VLog().WithFields(logrus.Fields{
-		"dynasty": block.Timestamp() / DynastyInterval,
+		"dynasty": dynastyID,
 		"miner":   block.miner.String(),
 		"count":   cnt,
 	}).Info("Recorded the block minted by the miner in the dynasty.")
 	return nil
 }
 
+// Reward returns the reward of the coinbase of the block in the reward schedule of genesis.
+func (block *Block) Reward() *util.Uint128 {
+	if block.rewards == nil {
+		return DefaultRewardSchedule.Reward(block.height)
+	}
+	return block.rewards.Reward(block.height)
+}
+
 func (block *Block) rewardCoinbase() {
 	coinbaseAddr := block.header.coinbase.address
 	coinbaseAcc := block.accState.GetOrCreateUserAccount(coinbaseAddr)
-	coinbaseAcc.AddBalance(BlockReward)
+	reward := block.Reward()
+	coinbaseAcc.AddBalance(reward)
 	logging.VLog().WithFields(logrus.Fields{
 		"coinbase": coinbaseAddr.Hex(),
+		"reward":   reward.String(),
 		"balance":  coinbaseAcc.Balance().Int64(),
 	}).Info("Rewarded the coinbase.")
 }
@@ -847,8 +1014,8 @@ func (block *Block) GetTransaction(hash byteutils.Hash) (*Transaction, error) {
 	return tx, nil
 }
 
-func (block *Block) acceptTransaction(tx *Transaction) error {
-	// record tx
+// putTransaction records the tx in the txs trie.
+func putTransaction(txsTrie *trie.BatchTrie, tx *Transaction) error {
 	pbTx, err := tx.ToProto()
 	if err != nil {
 		return err
@@ -857,7 +1024,13 @@ func (block *Block) acceptTransaction(tx *Transaction) error {
 	if err != nil {
 		return err
 	}
-	if _, err := block.txsTrie.Put(tx.hash, txBytes); err != nil {
+	_, err = txsTrie.Put(tx.hash, txBytes)
+	return err
+}
+
+func (block *Block) acceptTransaction(tx *Transaction) error {
+	// record tx
+	if err := putTransaction(block.txsTrie, tx); err != nil {
 		return err
 	}
 	// incre nonce
@@ -872,6 +1045,11 @@ func (block *Block) checkTransaction(tx *Transaction) (giveback bool, err error)
 		return false, ErrDuplicatedTransaction
 	}
 
+	// check the tx can be packed at the block
+	if tx.Expired(block.height, block.header.timestamp) {
+		return false, ErrTransactionExpired
+	}
+
 	// check nonce
 	fromAcc := block.accState.GetOrCreateUserAccount(tx.from.address)
 	if tx.nonce < fromAcc.Nonce()+1 {
@@ -887,7 +1065,12 @@ func (block *Block) executeTransaction(tx *Transaction) (giveback bool, err erro
 		return giveback, err
 	}
 
-	if _, err := tx.VerifyExecution(block); err != nil {
+	if giveback, err := block.checkGas(tx); err != nil {
+		return giveback, err
+	}
+
+	gasUsed, err := tx.VerifyExecution(block)
+	if err != nil {
 		return false, err
 	}
 
@@ -895,6 +1078,18 @@ func (block *Block) executeTransaction(tx *Transaction) (giveback bool, err erro
 		return false, err
 	}
 
+	receipt, err := block.newReceipt(tx, gasUsed)
+	if err != nil {
+		return false, err
+	}
+	if err := block.recordReceipt(receipt); err != nil {
+		return false, err
+	}
+	if err := block.bloomEvents(tx, receipt); err != nil {
+		return false, err
+	}
+	block.consumeGas(gasUsed)
+
 	return false, nil
 }
 
@@ -906,7 +1101,17 @@ func HashBlock(block *Block) byteutils.Hash {
 	hasher.Write(block.StateRoot())
 	hasher.Write(block.TxsRoot())
 	hasher.Write(block.EventsRoot())
+	if block.afterHeaderFork() {
+		hasher.Write(block.ReceiptsRoot())
+	}
 	hasher.Write(block.DposContextHash())
+	if block.afterHeaderFork() {
+		gasLimit, _ := block.GasLimit().ToFixedSizeByteSlice()
+		hasher.Write(gasLimit)
+		gasUsed, _ := block.GasUsed().ToFixedSizeByteSlice()
+		hasher.Write(gasUsed)
+		hasher.Write(block.header.eventBloom)
+	}
 	hasher.Write(byteutils.FromUint64(block.header.nonce))
 	hasher.Write(block.header.coinbase.address)
 	hasher.Write(byteutils.FromInt64(block.header.timestamp))
@@ -933,27 +1138,37 @@ func LoadBlockFromStorage(hash byteutils.Hash, storage storage.Storage, txPool *
 	if err = block.FromProto(pbBlock); err != nil {
 		return nil, err
 	}
-	block.accState, err = state.NewAccountState(block.StateRoot(), storage)
-	if err != nil {
-		return nil, err
+	block.txPool = txPool
+	block.storage = storage
+	block.sealed = true
+	block.eventEmitter = eventEmitter
+	if block.StatesPruned() {
+		// only the header and transactions are left.
+		return block, nil
 	}
-	block.txsTrie, err = trie.NewBatchTrie(block.TxsRoot(), storage)
-	if err != nil {
+	if err := block.loadStates(); err != nil {
 		return nil, err
 	}
-	block.eventsTrie, err = trie.NewBatchTrie(block.EventsRoot(), storage)
-	if err != nil {
-		return nil, err
+	return block, nil
+}
+
+// loadStates opens the tries at the roots in header.
+func (block *Block) loadStates() error {
+	var err error
+	if block.accState, err = state.NewAccountState(block.StateRoot(), block.storage); err != nil {
+		return err
 	}
-	if block.dposContext, err = NewDposContext(storage); err != nil {
-		return nil, err
+	if block.txsTrie, err = trie.NewBatchTrie(block.TxsRoot(), block.storage); err != nil {
+		return err
 	}
-	if block.dposContext.FromProto(block.DposContext()) != nil {
-		return nil, err
+	if block.eventsTrie, err = trie.NewBatchTrie(block.EventsRoot(), block.storage); err != nil {
+		return err
 	}
-	block.txPool = txPool
-	block.storage = storage
-	block.sealed = true
-	block.eventEmitter = eventEmitter
-	return block, nil
+	if block.receiptsTrie, err = trie.NewBatchTrie(block.ReceiptsRoot(), block.storage); err != nil {
+		return err
+	}
+	if block.dposContext, err = NewDposContext(block.storage); err != nil {
+		return err
+	}
+	return block.dposContext.FromProto(block.DposContext())
 }
@@ -0,0 +1,154 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+var (
+	// MinBlockGasLimit is the gas limit of genesis block, and the lowest gas limit of a block.
+	// value: 4 * TransactionMaxGas, a block always holds the transactions of the max gas.
+	MinBlockGasLimit = util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionMaxGas.Int, util.NewUint128FromInt(4).Int))
+
+	// MaxBlockGasLimit is the highest gas limit of a block.
+	// value: 64 * MinBlockGasLimit
+	MaxBlockGasLimit = util.NewUint128FromBigInt(util.NewUint128().Mul(MinBlockGasLimit.Int, util.NewUint128FromInt(64).Int))
+
+	// BlockGasLimitBoundDivisor bounds the change of gas limit from the parent, at most 1/1024 of it.
+	BlockGasLimitBoundDivisor = util.NewUint128FromInt(1024)
+)
+
+// Errors of gas
+var (
+	ErrInvalidBlockGasLimit = errors.New("invalid block gas limit")
+	ErrInvalidBlockGasUsed  = errors.New("invalid block gas used")
+	ErrInvalidGasPrice      = errors.New("invalid transaction gas price")
+	ErrInvalidGasLimit      = errors.New("invalid transaction gas limit")
+	ErrExceedBlockGasLimit  = errors.New("transaction gas limit exceeds the gas left in block")
+)
+
+// NextBlockGasLimit returns the gas limit of the child block of parent.
+// The limit rises by 1/1024 if the parent used more than 2/3 of its limit,
+// and falls by 1/1024 if less than 1/3, in the range of MinBlockGasLimit and MaxBlockGasLimit.
+func NextBlockGasLimit(parent *Block) *util.Uint128 {
+	limit := parent.GasLimit()
+	if limit.Sign() == 0 {
+		// the parent is created before the gas limit of blocks.
+		return MinBlockGasLimit
+	}
+	used := util.NewUint128().Mul(parent.GasUsed().Int, util.NewUint128FromInt(3).Int)
+	delta := util.NewUint128().Div(limit.Int, BlockGasLimitBoundDivisor.Int)
+
+	next := util.NewUint128FromBigInt(util.NewUint128().Set(limit.Int))
+	if used.Cmp(util.NewUint128().Mul(limit.Int, util.NewUint128FromInt(2).Int)) > 0 {
+		next.Add(next.Int, delta)
+	} else if used.Cmp(limit.Int) < 0 {
+		next.Sub(next.Int, delta)
+	}
+
+	if next.Cmp(MinBlockGasLimit.Int) < 0 {
+		return MinBlockGasLimit
+	}
+	if next.Cmp(MaxBlockGasLimit.Int) > 0 {
+		return MaxBlockGasLimit
+	}
+	return next
+}
+
+// GasLimit returns the gas limit of the block.
+func (block *Block) GasLimit() *util.Uint128 {
+	if block.header.gasLimit == nil {
+		return util.NewUint128()
+	}
+	return block.header.gasLimit
+}
+
+// GasUsed returns the gas used by the transactions in the block.
+func (block *Block) GasUsed() *util.Uint128 {
+	if block.header.gasUsed == nil {
+		return util.NewUint128()
+	}
+	return block.header.gasUsed
+}
+
+// consumeGas adds the gas used by a transaction executed in the block.
+func (block *Block) consumeGas(gas *util.Uint128) {
+	if block.gasUsed == nil {
+		block.gasUsed = util.NewUint128()
+	}
+	block.gasUsed = util.NewUint128FromBigInt(util.NewUint128().Add(block.gasUsed.Int, gas.Int))
+}
+
+// gasLeft returns the gas left for the transactions to execute.
+func (block *Block) gasLeft() *util.Uint128 {
+	if block.gasUsed == nil {
+		return block.GasLimit()
+	}
+	left := util.NewUint128().Sub(block.GasLimit().Int, block.gasUsed.Int)
+	if left.Sign() < 0 {
+		return util.NewUint128()
+	}
+	return util.NewUint128FromBigInt(left)
+}
+
+// verifyGasLimit checks the gas limit of the block follows the adjustment from the parent.
+func (block *Block) verifyGasLimit(parent *Block) error {
+	if !block.afterHeaderFork() {
+		return nil
+	}
+	if block.GasLimit().Cmp(NextBlockGasLimit(parent).Int) != 0 {
+		return ErrInvalidBlockGasLimit
+	}
+	return nil
+}
+
+// checkGas checks the gas price and limit of the transaction, and the limit fits in the gas left in block
+// after the header fork.
+func (block *Block) checkGas(tx *Transaction) (giveback bool, err error) {
+	if tx.gasPrice.Sign() <= 0 || tx.gasPrice.Cmp(TransactionMaxGasPrice.Int) > 0 {
+		return false, ErrInvalidGasPrice
+	}
+	if tx.gasLimit.Sign() <= 0 || tx.gasLimit.Cmp(TransactionMaxGas.Int) > 0 {
+		return false, ErrInvalidGasLimit
+	}
+	if block.afterHeaderFork() && tx.gasLimit.Cmp(block.gasLeft().Int) > 0 {
+		// the transaction may fit in the next block.
+		return true, ErrExceedBlockGasLimit
+	}
+	return false, nil
+}
+
+// gasBytes returns the fixed size bytes of gas, empty if nil.
+func gasBytes(gas *util.Uint128) ([]byte, error) {
+	if gas == nil {
+		return nil, nil
+	}
+	return gas.ToFixedSizeByteSlice()
+}
+
+// gasFromBytes returns the gas of fixed size bytes, nil if empty.
+func gasFromBytes(data []byte) (*util.Uint128, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return util.NewUint128FromFixedSizeByteSlice(data)
+}
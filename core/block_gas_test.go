@@ -0,0 +1,158 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextBlockGasLimit(t *testing.T) {
+	gas := func(limit, used *util.Uint128) *Block {
+		return &Block{header: &BlockHeader{gasLimit: limit, gasUsed: used}}
+	}
+	fraction := func(u *util.Uint128, mul, div int64) *util.Uint128 {
+		v := util.NewUint128().Mul(u.Int, util.NewUint128FromInt(mul).Int)
+		return util.NewUint128FromBigInt(v.Div(v, util.NewUint128FromInt(div).Int))
+	}
+	limit := fraction(MinBlockGasLimit, 2, 1)
+	delta := fraction(limit, 1, 1024)
+
+	tests := []struct {
+		name   string
+		parent *Block
+		want   *util.Uint128
+	}{
+		{"before gas limit", gas(nil, nil), MinBlockGasLimit},
+		{"full", gas(limit, limit), util.NewUint128FromBigInt(util.NewUint128().Add(limit.Int, delta.Int))},
+		{"half", gas(limit, fraction(limit, 1, 2)), limit},
+		{"empty", gas(limit, util.NewUint128()), util.NewUint128FromBigInt(util.NewUint128().Sub(limit.Int, delta.Int))},
+		{"min", gas(MinBlockGasLimit, util.NewUint128()), MinBlockGasLimit},
+		{"max", gas(MaxBlockGasLimit, MaxBlockGasLimit), MaxBlockGasLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want.String(), NextBlockGasLimit(tt.parent).String())
+		})
+	}
+}
+
+func TestBlock_CollectTransactionsByGasPrice(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	to := &Address{[]byte("01234567890123456789012345")}
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionMaxGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	var froms []*Address
+	var signatures []keystore.Signature
+	bc.tailBlock.begin()
+	for i := 0; i < 2; i++ {
+		priv := secp256k1.GeneratePrivateKey()
+		pubdata, _ := priv.PublicKey().Encoded()
+		from, _ := NewAddressFromPublicKey(pubdata)
+		signature, _ := crypto.NewSignature(keystore.SECP256K1)
+		signature.InitSign(priv)
+		bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+		froms = append(froms, from)
+		signatures = append(signatures, signature)
+	}
+	bc.tailBlock.header.stateRoot = bc.tailBlock.accState.RootHash()
+	bc.tailBlock.commit()
+	bc.storeBlockToStorage(bc.tailBlock)
+
+	highPrice := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2).Int))
+	txs := []*Transaction{
+		NewTransaction(bc.ChainID(), froms[0], to, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000)),
+		NewTransaction(bc.ChainID(), froms[1], to, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, []byte("nas"), highPrice, util.NewUint128FromInt(200000)),
+		NewTransaction(bc.ChainID(), froms[0], to, util.NewUint128FromInt(1), 2, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000)),
+	}
+	for i, tx := range txs {
+		assert.Nil(t, tx.Sign(signatures[i%2]))
+		assert.Nil(t, bc.txPool.Push(tx))
+	}
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	coinbase := &Address{validators[1]}
+	block, err := NewBlock(bc.ChainID(), coinbase, bc.tailBlock)
+	assert.Nil(t, err)
+	assert.Equal(t, NextBlockGasLimit(bc.tailBlock), block.GasLimit())
+
+	// the gas left holds the gas limit of two transactions, the one of the highest price is packed first.
+	block.header.gasLimit = util.NewUint128FromInt(230000)
+	block.header.timestamp = BlockInterval
+	block.CollectTransactions(len(txs))
+	assert.Equal(t, 2, len(block.transactions))
+	assert.Equal(t, txs[1].Hash(), block.transactions[0].Hash())
+	assert.Equal(t, txs[0].Hash(), block.transactions[1].Hash())
+	assert.NotNil(t, bc.txPool.GetTransaction(txs[2].Hash()))
+
+	block.SetMiner(coinbase)
+	assert.Nil(t, block.Seal())
+	gasUsed := util.NewUint128().Add(txs[0].GasCountOfTxBase().Int, txs[1].GasCountOfTxBase().Int)
+	assert.Equal(t, gasUsed.String(), block.GasUsed().String())
+
+	// the gas limit not following the parent is rejected.
+	received, err := mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+	assert.Equal(t, ErrInvalidBlockGasLimit, received.VerifyExecution(bc.tailBlock, c))
+
+	// the gas used not matching the execution is rejected.
+	block.header.gasLimit = NextBlockGasLimit(bc.tailBlock)
+	block.header.gasUsed = util.NewUint128()
+	received, err = mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+	received.SetMiner(coinbase)
+	assert.Equal(t, ErrInvalidBlockGasUsed, received.VerifyExecution(bc.tailBlock, c))
+}
+
+func TestBlock_CheckGas(t *testing.T) {
+	block := &Block{header: &BlockHeader{gasLimit: util.NewUint128FromInt(300000)}}
+	from, to := mockAddress(), mockAddress()
+	gasLimit := util.NewUint128FromInt(200000)
+	maxPricePlus1 := util.NewUint128FromBigInt(util.NewUint128().Add(TransactionMaxGasPrice.Int, util.NewUint128FromInt(1).Int))
+	maxGasPlus1 := util.NewUint128FromBigInt(util.NewUint128().Add(TransactionMaxGas.Int, util.NewUint128FromInt(1).Int))
+
+	tx := NewTransaction(0, from, to, util.NewUint128(), 1, TxPayloadBinaryType, nil, TransactionGasPrice, gasLimit)
+	giveback, err := block.checkGas(tx)
+	assert.False(t, giveback)
+	assert.Nil(t, err)
+
+	tx = NewTransaction(0, from, to, util.NewUint128(), 1, TxPayloadBinaryType, nil, maxPricePlus1, gasLimit)
+	_, err = block.checkGas(tx)
+	assert.Equal(t, ErrInvalidGasPrice, err)
+
+	tx = NewTransaction(0, from, to, util.NewUint128(), 1, TxPayloadBinaryType, nil, TransactionGasPrice, maxGasPlus1)
+	_, err = block.checkGas(tx)
+	assert.Equal(t, ErrInvalidGasLimit, err)
+
+	block.consumeGas(gasLimit)
+	tx = NewTransaction(0, from, to, util.NewUint128(), 1, TxPayloadBinaryType, nil, TransactionGasPrice, gasLimit)
+	giveback, err = block.checkGas(tx)
+	assert.True(t, giveback)
+	assert.Equal(t, ErrExceedBlockGasLimit, err)
+}
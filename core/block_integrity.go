@@ -0,0 +1,356 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// IntegrityCheckInterval is the interval between the rounds of the integrity checker.
+	IntegrityCheckInterval = 10 * time.Minute
+
+	// IntegrityCheckBatch is the count of heights checked in a round.
+	IntegrityCheckBatch = 1024
+
+	// Quarantined Key in storage, the hashes of the corrupted blocks waiting to be re-fetched.
+	Quarantined = "blockchain_quarantined"
+
+	// quarantinePrefix is the prefix of the keys of the corrupted blocks moved out.
+	quarantinePrefix = "blockchain_quarantine_"
+)
+
+// ErrBlockCorrupted is returned if a stored block mismatches its hash.
+var ErrBlockCorrupted = errors.New("stored block is corrupted")
+
+var (
+	corruptedBlocksMeter = metrics.GetOrRegisterMeter("neb.block.corrupted", nil)
+	repairedBlocksMeter  = metrics.GetOrRegisterMeter("neb.block.repaired", nil)
+)
+
+// IntegrityReport is the result of an integrity check of the stored blocks.
+type IntegrityReport struct {
+	// Checked is the count of the blocks checked.
+	Checked int
+	// Quarantined is the hashes of the corrupted blocks found, they are moved out and re-fetched from peers.
+	Quarantined []byteutils.Hash
+	// BrokenLinks is the heights of the blocks not linked to the canonical block below.
+	BrokenLinks []uint64
+}
+
+// IntegrityChecker re-hashes the stored blocks of canonical chain in background, a batch of heights
+// in a round from the genesis to the tail, and re-fetches the corrupted ones from peers.
+type IntegrityChecker struct {
+	mu     sync.Mutex
+	bc     *BlockChain
+	quitCh chan int
+
+	// next is the height checked in the next round.
+	next uint64
+}
+
+func newIntegrityChecker(bc *BlockChain) *IntegrityChecker {
+	return &IntegrityChecker{
+		bc:     bc,
+		quitCh: make(chan int, 1),
+		next:   2,
+	}
+}
+
+// Start start the integrity checker.
+func (ic *IntegrityChecker) Start() {
+	go ic.loop()
+}
+
+// Stop stop the integrity checker.
+func (ic *IntegrityChecker) Stop() {
+	ic.quitCh <- 0
+}
+
+func (ic *IntegrityChecker) loop() {
+	logging.CLog().Info("Launched IntegrityChecker.")
+	ticker := time.NewTicker(IntegrityCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ic.quitCh:
+			logging.CLog().Info("Shutdowned IntegrityChecker.")
+			return
+		case <-ticker.C:
+			ic.checkRound()
+		}
+	}
+}
+
+// checkRound checks the next batch of heights, it starts over from the genesis after the tail.
+func (ic *IntegrityChecker) checkRound() {
+	from := ic.next
+	to := from + IntegrityCheckBatch - 1
+	if tail := ic.bc.TailBlock().Height(); to >= tail {
+		to = tail
+		ic.next = 2
+	} else {
+		ic.next = to + 1
+	}
+	report, err := ic.bc.CheckIntegrity(from, to)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"from": from,
+			"to":   to,
+			"err":  err,
+		}).Error("Failed to check the integrity of blocks.")
+		return
+	}
+	if len(report.Quarantined) > 0 || len(report.BrokenLinks) > 0 {
+		logging.CLog().WithFields(logrus.Fields{
+			"from":        from,
+			"to":          to,
+			"quarantined": len(report.Quarantined),
+			"brokenLinks": report.BrokenLinks,
+		}).Warn("Found corrupted blocks in storage.")
+	}
+}
+
+// CheckIntegrity re-hashes the stored blocks of canonical chain from fromHeight to toHeight against
+// their keys, and verifies they are linked to the blocks below. toHeight is the tail if 0. The corrupted
+// blocks are quarantined, and re-fetched from peers if the chain is in network.
+func (bc *BlockChain) CheckIntegrity(fromHeight, toHeight uint64) (*IntegrityReport, error) {
+	if tail := bc.TailBlock().Height(); toHeight == 0 || toHeight > tail {
+		toHeight = tail
+	}
+	if fromHeight < 2 {
+		// the genesis is built from the config.
+		fromHeight = 2
+	}
+
+	ic := bc.integrity
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	quarantined, err := ic.quarantined()
+	if err != nil {
+		return nil, err
+	}
+	report := new(IntegrityReport)
+	parent := bc.canonicalHash(fromHeight - 1)
+	for height := fromHeight; height <= toHeight; height++ {
+		hash := bc.canonicalHash(height)
+		if hash == nil {
+			// the index is repaired on start.
+			parent = nil
+			continue
+		}
+		report.Checked++
+		if _, ok := quarantined[hash.Hex()]; ok {
+			parent = hash
+			continue
+		}
+		value, err := bc.storage.Get(hash)
+		if err != nil && err != storage.ErrKeyNotFound {
+			return nil, err
+		}
+		var block *Block
+		if err == nil {
+			block, err = bc.verifyStoredBlock(hash, value)
+		}
+		if err != nil {
+			if err := ic.quarantine(hash, value); err != nil {
+				return nil, err
+			}
+			report.Quarantined = append(report.Quarantined, hash)
+		} else if block.Height() != height || (parent != nil && !block.ParentHash().Equals(parent)) {
+			report.BrokenLinks = append(report.BrokenLinks, height)
+		}
+		parent = hash
+	}
+	if err := ic.requestQuarantined(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// verifyStoredBlock returns the block stored at the hash, if its content hashes to it.
+func (bc *BlockChain) verifyStoredBlock(hash byteutils.Hash, value []byte) (*Block, error) {
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(value, pbBlock); err != nil {
+		return nil, ErrBlockCorrupted
+	}
+	block := new(Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, ErrBlockCorrupted
+	}
+	if block.header.chainID != bc.chainID || !block.Hash().Equals(hash) || !HashBlock(block).Equals(hash) {
+		return nil, ErrBlockCorrupted
+	}
+	for _, err := range VerifyTransactions(bc.chainID, block.transactions) {
+		if err != nil {
+			return nil, ErrBlockCorrupted
+		}
+	}
+	return block, nil
+}
+
+// quarantined returns the hashes of the blocks quarantined, the caller holds the lock.
+func (ic *IntegrityChecker) quarantined() (map[byteutils.HexHash]byteutils.Hash, error) {
+	data, err := ic.bc.storage.Get([]byte(Quarantined))
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	hashes := make(map[byteutils.HexHash]byteutils.Hash)
+	for i := 0; i+BlockHashLength <= len(data); i += BlockHashLength {
+		hash := byteutils.Hash(data[i : i+BlockHashLength])
+		hashes[hash.Hex()] = hash
+	}
+	return hashes, nil
+}
+
+func (ic *IntegrityChecker) saveQuarantined(hashes map[byteutils.HexHash]byteutils.Hash) error {
+	if len(hashes) == 0 {
+		return ic.bc.storage.Del([]byte(Quarantined))
+	}
+	var data []byte
+	for _, hash := range hashes {
+		data = append(data, hash...)
+	}
+	return ic.bc.storage.Put([]byte(Quarantined), data)
+}
+
+// quarantine moves the corrupted block out of its key, it's read as missing till re-fetched.
+// The caller holds the lock.
+func (ic *IntegrityChecker) quarantine(hash byteutils.Hash, value []byte) error {
+	bc := ic.bc
+	if value != nil {
+		if err := bc.storage.Put(append([]byte(quarantinePrefix), hash...), value); err != nil {
+			return err
+		}
+	}
+	if err := bc.storage.Del(hash); err != nil {
+		return err
+	}
+	bc.cachedBlocks.Remove(hash.Hex())
+
+	hashes, err := ic.quarantined()
+	if err != nil {
+		return err
+	}
+	hashes[hash.Hex()] = hash
+	if err := ic.saveQuarantined(hashes); err != nil {
+		return err
+	}
+	corruptedBlocksMeter.Mark(1)
+	logging.CLog().WithFields(logrus.Fields{
+		"hash":    hash.Hex(),
+		"missing": value == nil,
+	}).Warn("Quarantined a corrupted block.")
+	return nil
+}
+
+// requestQuarantined asks the peers for the blocks quarantined, the caller holds the lock.
+func (ic *IntegrityChecker) requestQuarantined() error {
+	pool := ic.bc.bkPool
+	if pool == nil || pool.nm == nil {
+		return nil
+	}
+	hashes, err := ic.quarantined()
+	if err != nil {
+		return err
+	}
+	// the ids differ in rounds, or the requests are dropped by peers as the messages known.
+	id := uint64(time.Now().UnixNano())
+	req := &corepb.GetBlocksByHashList{Id: id}
+	for _, hash := range hashes {
+		req.Hashes = append(req.Hashes, hash)
+		if len(req.Hashes) == MaxBlocksPerRequest {
+			pool.nm.Broadcast(MessageTypeGetBlocksByHash, &blocksRequest{req})
+			id++
+			req = &corepb.GetBlocksByHashList{Id: id}
+		}
+	}
+	if len(req.Hashes) > 0 {
+		pool.nm.Broadcast(MessageTypeGetBlocksByHash, &blocksRequest{req})
+	}
+	return nil
+}
+
+// repair restores the block quarantined from the block re-fetched, it returns false if the block
+// isn't quarantined.
+func (ic *IntegrityChecker) repair(pbBlock *corepb.Block) (bool, error) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	hashes, err := ic.quarantined()
+	if err != nil {
+		return false, err
+	}
+	hash := byteutils.Hash(pbBlock.Header.Hash)
+	if _, ok := hashes[hash.Hex()]; !ok {
+		return false, nil
+	}
+	value, err := proto.Marshal(pbBlock)
+	if err != nil {
+		return true, err
+	}
+	if _, err := ic.bc.verifyStoredBlock(hash, value); err != nil {
+		return true, err
+	}
+	if err := ic.bc.storage.Put(hash, value); err != nil {
+		return true, err
+	}
+	if err := ic.bc.storage.Del(append([]byte(quarantinePrefix), hash...)); err != nil {
+		return true, err
+	}
+	delete(hashes, hash.Hex())
+	if err := ic.saveQuarantined(hashes); err != nil {
+		return true, err
+	}
+	repairedBlocksMeter.Mark(1)
+	logging.CLog().WithFields(logrus.Fields{
+		"hash": hash.Hex(),
+	}).Info("Repaired a corrupted block from peers.")
+	return true, nil
+}
+
+// blocksRequest is a request of blocks by hash list broadcasted to the peers.
+type blocksRequest struct {
+	req *corepb.GetBlocksByHashList
+}
+
+// ToProto converts the request to proto message.
+func (r *blocksRequest) ToProto() (proto.Message, error) {
+	return r.req, nil
+}
+
+// FromProto converts proto message to the request.
+func (r *blocksRequest) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.GetBlocksByHashList); ok {
+		r.req = msg
+		return nil
+	}
+	return errors.New("Protobuf message cannot be converted into GetBlocksByHashList")
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_CheckIntegrity(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	blocks := []*Block{bc.GenesisBlock()}
+	for i := 0; i < 3; i++ {
+		blocks = append(blocks, mintSignedBlock(t, bc, mockAddress()))
+	}
+
+	report, err := bc.CheckIntegrity(0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, 0, len(report.Quarantined))
+	assert.Equal(t, 0, len(report.BrokenLinks))
+
+	// flip a byte of the stored block.
+	corrupted := blocks[2]
+	value, err := neb.storage.Get(corrupted.Hash())
+	assert.Nil(t, err)
+	pbBlock := new(corepb.Block)
+	assert.Nil(t, proto.Unmarshal(value, pbBlock))
+	damaged := append([]byte{}, value...)
+	damaged[len(damaged)-1] ^= 0xff
+	assert.Nil(t, neb.storage.Put(corrupted.Hash(), damaged))
+
+	report, err = bc.CheckIntegrity(0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, corrupted.Hash(), report.Quarantined[0])
+	_, err = neb.storage.Get(corrupted.Hash())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	assert.Nil(t, bc.GetBlock(corrupted.Hash()))
+
+	// quarantined once.
+	report, err = bc.CheckIntegrity(0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(report.Quarantined))
+
+	// another block is not accepted for it.
+	other, _ := blocks[3].ToProto()
+	ok, err := bc.integrity.repair(other.(*corepb.Block))
+	assert.False(t, ok)
+	assert.Nil(t, err)
+	forged := proto.Clone(pbBlock).(*corepb.Block)
+	forged.Header.Nonce++
+	ok, err = bc.integrity.repair(forged)
+	assert.True(t, ok)
+	assert.Equal(t, ErrBlockCorrupted, err)
+
+	// repaired by the block from peers.
+	ok, err = bc.integrity.repair(pbBlock)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, corrupted.Hash(), bc.GetBlock(corrupted.Hash()).Hash())
+	_, err = neb.storage.Get([]byte(Quarantined))
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
@@ -44,6 +44,7 @@ const (
 var (
 	duplicatedBlockCounter = metrics.GetOrRegisterCounter("neb.block.duplicated", nil)
 	invalidBlockCounter    = metrics.GetOrRegisterCounter("neb.block.invalid", nil)
+	doubleSignCounter      = metrics.GetOrRegisterCounter("neb.block.doublesign", nil)
 	BlockExecutedTimer     = metrics.GetOrRegisterTimer("neb.block.executed", nil)
 	TxExecutedTimer        = metrics.GetOrRegisterTimer("neb.tx.executed", nil)
 )
@@ -55,14 +56,25 @@ type BlockPool struct {
 	receiveBlockMessageCh         chan net.Message
 	receiveDownloadBlockMessageCh chan net.Message
 	receivedLinkedBlockCh         chan *Block
+	receiveCompactMessageCh       chan net.Message
 	quitCh                        chan int
 
 	bc    *BlockChain
 	cache *lru.Cache
 	slot  *lru.Cache
 
+	// the hash of block signed by a miner at a slot, recently received.
+	signed *lru.Cache
+
 	nm p2p.Manager
 	mu sync.RWMutex
+
+	compactRelay     bool
+	pendingCompact   *lru.Cache
+	compactRequestID uint64
+
+	future  futureBlocks
+	orphans map[byteutils.HexHash]*orphan
 }
 
 type linkedBlock struct {
@@ -82,7 +94,9 @@ func NewBlockPool(size int) (*BlockPool, error) {
 		receiveBlockMessageCh:         make(chan net.Message, size),
 		receiveDownloadBlockMessageCh: make(chan net.Message, size),
 		receivedLinkedBlockCh:         make(chan *Block, size),
+		receiveCompactMessageCh:       make(chan net.Message, size),
 		quitCh:                        make(chan int, 1),
+		orphans:                       make(map[byteutils.HexHash]*orphan),
 	}
 	var err error
 	bp.cache, err = lru.New(size)
@@ -93,6 +107,14 @@ func NewBlockPool(size int) (*BlockPool, error) {
 	if err != nil {
 		return nil, err
 	}
+	bp.signed, err = lru.New(MaxSignedSlots)
+	if err != nil {
+		return nil, err
+	}
+	bp.pendingCompact, err = lru.New(MaxPendingCompactBlocks)
+	if err != nil {
+		return nil, err
+	}
 	return bp, nil
 }
 
@@ -106,6 +128,11 @@ func (pool *BlockPool) RegisterInNetwork(nm p2p.Manager) {
 	nm.Register(net.NewSubscriber(pool, pool.receiveBlockMessageCh, MessageTypeNewBlock))
 	nm.Register(net.NewSubscriber(pool, pool.receiveBlockMessageCh, MessageTypeDownloadedBlockReply))
 	nm.Register(net.NewSubscriber(pool, pool.receiveDownloadBlockMessageCh, MessageTypeDownloadedBlock))
+	nm.Register(net.NewSubscriber(pool, pool.receiveCompactMessageCh, MessageTypeCompactBlock, MessageTypeGetTxs, MessageTypeTxs, MessageTypeBlocksReply))
+	nm.RegisterValidator(MessageTypeNewBlock, ValidateBlockMessage(pool.bc.ChainID()))
+	nm.RegisterValidator(MessageTypeCompactBlock, ValidateCompactBlockMessage(pool.bc.ChainID()))
+	nm.RegisterValidator(MessageTypeTxs, ValidateTxsMessage(pool.bc.ChainID()))
+	nm.RegisterValidator(MessageTypeDownloadedBlockReply, ValidateBlockMessage(pool.bc.ChainID()))
 	pool.nm = nm
 }
 
@@ -137,6 +164,9 @@ func (pool *BlockPool) handleBlock(msg net.Message) {
 		return
 	}
 
+	trace := net.TraceOf(msg)
+	defer trace.Finish("blockpool.handled")
+
 	block := new(Block)
 	pbblock := new(corepb.Block)
 	if err := proto.Unmarshal(msg.Data().([]byte), pbblock); err != nil {
@@ -156,7 +186,21 @@ func (pool *BlockPool) handleBlock(msg net.Message) {
 		return
 	}
 
+	trace.Mark("blockpool.decoded")
+
 	diff := time.Now().Unix() - block.Timestamp()
+	if msg.MessageType() == MessageTypeNewBlock && diff < -AcceptedNetWorkDelay {
+		// hold the block slightly ahead of local time until its time.
+		if err := pool.future.add(msg.MessageFrom(), block, -diff-AcceptedNetWorkDelay); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": block,
+				"diff":  diff,
+				"limit": MaxFutureBlockDrift,
+				"err":   err,
+			}).Warn("Failed to accept a future block.")
+		}
+		return
+	}
 	if msg.MessageType() == MessageTypeNewBlock && int64(math.Abs(float64(diff))) > AcceptedNetWorkDelay {
 		logging.VLog().WithFields(logrus.Fields{
 			"block": block,
@@ -170,6 +214,7 @@ func (pool *BlockPool) handleBlock(msg net.Message) {
 	logging.VLog().WithFields(logrus.Fields{
 		"block": block,
 		"type":  msg.MessageType(),
+		"trace": trace.ID(),
 	}).Info("Received a new block.")
 
 	if err := pool.PushAndRelay(msg.MessageFrom(), block); err != nil {
@@ -258,15 +303,22 @@ func (pool *BlockPool) handleDownloadedBlock(msg net.Message) {
 
 func (pool *BlockPool) loop() {
 	logging.CLog().Info("Launched BlockPool.")
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-pool.quitCh:
 			logging.CLog().Info("Shutdowned BlockPool.")
 			return
+		case now := <-ticker.C:
+			pool.processFutureBlocks(now.Unix())
+			pool.maintainOrphans(now.Unix())
 		case msg := <-pool.receiveBlockMessageCh:
 			pool.handleBlock(msg)
 		case msg := <-pool.receiveDownloadBlockMessageCh:
 			pool.handleDownloadedBlock(msg)
+		case msg := <-pool.receiveCompactMessageCh:
+			pool.handleCompactMessage(msg)
 		}
 	}
 }
@@ -312,7 +364,7 @@ func (pool *BlockPool) PushAndRelay(sender string, block *Block) error {
 	if err := pool.push(sender, block); err != nil {
 		return err
 	}
-	pool.nm.Relay(MessageTypeNewBlock, block)
+	pool.distribute(block, true)
 	return nil
 }
 
@@ -325,10 +377,11 @@ func (pool *BlockPool) PushAndBroadcast(block *Block) error {
 	if err != nil {
 		return nil
 	}
+	pool.bc.markMinedBlock(block.Hash())
 	if err := pool.push(NoSender, block); err != nil {
 		return err
 	}
-	pool.nm.Broadcast(MessageTypeNewBlock, block)
+	pool.distribute(block, false)
 	return nil
 }
 
@@ -369,6 +422,10 @@ func (pool *BlockPool) push(sender string, block *Block) error {
 		invalidBlockCounter.Inc(1)
 		return err
 	}
+	if err := pool.bc.verifyCheckpoint(block); err != nil {
+		invalidBlockCounter.Inc(1)
+		return err
+	}
 
 	logging.VLog().WithFields(logrus.Fields{
 		"block": block,
@@ -380,6 +437,11 @@ func (pool *BlockPool) push(sender string, block *Block) error {
 	var plb *linkedBlock
 	lb := newLinkedBlock(block, pool)
 
+	if err := pool.checkDoubleSign(lb.block); err != nil {
+		invalidBlockCounter.Inc(1)
+		return err
+	}
+	// one block of a slot per rank, the timestamp of a backup's block carries the delay of its rank.
 	if exist := pool.slot.Contains(lb.block.Timestamp()); exist {
 		invalidBlockCounter.Inc(1)
 		return ErrDoubleBlockMinted
@@ -394,6 +456,7 @@ func (pool *BlockPool) push(sender string, block *Block) error {
 		if c.parentHash.Equals(lb.hash) {
 			// found child block and continue.
 			c.LinkParent(lb)
+			pool.linkOrphan(c.hash)
 		}
 	}
 
@@ -426,17 +489,19 @@ func (pool *BlockPool) push(sender string, block *Block) error {
 			return ErrMissingParentBlock
 		}
 		// do sync if there are so many empty slots.
-		if lb.block.Timestamp()-bc.TailBlock().Timestamp() > BlockInterval*DynastySize {
+		limit := BlockInterval * int64(bc.TailBlock().DposParams().DynastySize)
+		if lb.block.Timestamp()-bc.TailBlock().Timestamp() > limit {
 
 			logging.CLog().WithFields(logrus.Fields{
 				"tail":    bc.tailBlock,
 				"offline": strconv.Itoa(int(lb.block.Timestamp()-bc.TailBlock().Timestamp())) + "s",
-				"limit":   strconv.Itoa(int(BlockInterval*DynastySize)) + "s",
+				"limit":   strconv.Itoa(int(limit)) + "s",
 			}).Warn("offline too long, restart sync from others.")
 
 			bc.Neb().StartSync()
 			return nil
 		}
+		pool.addOrphan(lb, sender, time.Now().Unix())
 		if err := pool.download(sender, lb.block); err != nil {
 			return err
 		}
@@ -445,19 +510,26 @@ func (pool *BlockPool) push(sender string, block *Block) error {
 
 	// found in BlockChain, then we can verify the state root, and tell the Consensus all the tails.
 	// performance depth-first search to verify state root, and get all tails.
-	allBlocks, tailBlocks, err := lb.travelToLinkAndReturnAllValidBlocks(parentBlock)
-	if err != nil {
-		return err
-	}
+	// the states of the blocks are committed with them at once.
+	err := bc.commitAtomically(func() error {
+		allBlocks, tailBlocks, err := lb.travelToLinkAndReturnAllValidBlocks(parentBlock)
+		if err != nil {
+			return err
+		}
 
-	if err := bc.putVerifiedNewBlocks(parentBlock, allBlocks, tailBlocks); err != nil {
-		return err
-	}
+		if err := bc.putVerifiedNewBlocks(parentBlock, allBlocks, tailBlocks); err != nil {
+			return err
+		}
 
-	// remove allBlocks from cache.
-	for _, v := range allBlocks {
-		cache.Remove(v.Hash().Hex())
-		pool.bc.storeBlockToStorage(v)
+		// remove allBlocks from cache.
+		for _, v := range allBlocks {
+			cache.Remove(v.Hash().Hex())
+			pool.bc.storeBlockToStorage(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// notify consensus to handle new block.
@@ -495,6 +567,9 @@ func (lb *linkedBlock) travelToLinkAndReturnAllValidBlocks(parentBlock *Block) (
 		}).Error("Failed to link the block with its parent.")
 		return nil, nil, err
 	}
+	if err := lb.pool.bc.verifyDynastyChanges(lb.block); err != nil {
+		return nil, nil, err
+	}
 
 	if err := lb.block.VerifyExecution(parentBlock, lb.pool.bc.ConsensusHandler()); err != nil {
 		logging.VLog().WithFields(logrus.Fields{
@@ -19,6 +19,7 @@
 package core
 
 import (
+	"io"
 	"testing"
 
 	"github.com/nebulasio/go-nebulas/core/pb"
@@ -64,8 +65,9 @@ func (n MockNetManager) Node() *p2p.Node { return nil }
 func (n MockNetManager) Sync(net.Serializable) error            { return nil }
 func (n MockNetManager) SendSyncReply(string, net.Serializable) {}
 
-func (n MockNetManager) Register(...*net.Subscriber)   {}
-func (n MockNetManager) Deregister(...*net.Subscriber) {}
+func (n MockNetManager) Register(...*net.Subscriber)             {}
+func (n MockNetManager) Deregister(...*net.Subscriber)           {}
+func (n MockNetManager) RegisterValidator(string, net.Validator) {}
 
 func (n MockNetManager) Broadcast(name string, msg net.Serializable) {}
 func (n MockNetManager) Relay(name string, msg net.Serializable)     {}
@@ -78,6 +80,17 @@ func (n MockNetManager) BroadcastNetworkID([]byte) {}
 
 func (n MockNetManager) BuildData([]byte, string) []byte { return nil }
 
+func (n MockNetManager) ExportPeers(io.Writer) error        { return nil }
+func (n MockNetManager) ImportPeers(io.Reader) (int, error) { return 0, nil }
+
+func (n MockNetManager) AddBootNode(string) error        { return nil }
+func (n MockNetManager) RemoveBootNode(string) error     { return nil }
+func (n MockNetManager) BootNodes() []p2p.BootNodeStatus { return nil }
+
+func (n MockNetManager) Peers() []*p2p.PeerRecord            { return nil }
+func (n MockNetManager) BanPeer(string, time.Duration) error { return nil }
+func (n MockNetManager) UnbanPeer(string) error              { return nil }
+
 func TestBlockPool(t *testing.T) {
 	received = []byte{}
 
@@ -0,0 +1,237 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/p2p"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// limits of block requests served to a peer.
+const (
+	MaxBlocksPerRequest      = 64
+	MaxBlocksReplySize       = 4 * 1024 * 1024
+	MaxBlockRequestsPerRound = 16
+	BlockRequestRound        = time.Second
+)
+
+var (
+	blockRequestsServed  = metrics.GetOrRegisterMeter("neb.blockserver.served", nil)
+	blockRequestsLimited = metrics.GetOrRegisterMeter("neb.blockserver.limited", nil)
+)
+
+// BlockServer serves batches of blocks requested by peers, by hash list or by height range.
+type BlockServer struct {
+	receiveMessageCh chan net.Message
+	quitCh           chan int
+
+	bc      *BlockChain
+	nm      p2p.Manager
+	limiter *requestLimiter
+}
+
+// NewBlockServer return new #BlockServer instance.
+func NewBlockServer(size int) *BlockServer {
+	return &BlockServer{
+		receiveMessageCh: make(chan net.Message, size),
+		quitCh:           make(chan int, 1),
+		limiter:          newRequestLimiter(MaxBlockRequestsPerRound, BlockRequestRound),
+	}
+}
+
+func (server *BlockServer) setBlockChain(bc *BlockChain) {
+	server.bc = bc
+}
+
+// RegisterInNetwork register message subscriber in network.
+func (server *BlockServer) RegisterInNetwork(nm p2p.Manager) {
+	nm.Register(net.NewSubscriber(server, server.receiveMessageCh, MessageTypeGetBlocksByHash))
+	nm.Register(net.NewSubscriber(server, server.receiveMessageCh, MessageTypeGetBlocksByHeight))
+	server.nm = nm
+}
+
+// Start start block server.
+func (server *BlockServer) Start() {
+	go server.loop()
+}
+
+// Stop stop block server.
+func (server *BlockServer) Stop() {
+	server.quitCh <- 0
+}
+
+func (server *BlockServer) loop() {
+	logging.CLog().Info("Launched BlockServer.")
+	for {
+		select {
+		case <-server.quitCh:
+			logging.CLog().Info("Shutdowned BlockServer.")
+			return
+		case msg := <-server.receiveMessageCh:
+			server.handleRequest(msg)
+		}
+	}
+}
+
+func (server *BlockServer) handleRequest(msg net.Message) {
+	if !server.limiter.Allow(msg.MessageFrom()) {
+		blockRequestsLimited.Mark(1)
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"from":    msg.MessageFrom(),
+		}).Warn("Too many block requests from the peer, ignore it.")
+		return
+	}
+
+	trace := net.TraceOf(msg)
+	defer trace.Finish("blockserver.handled")
+
+	var (
+		id     uint64
+		blocks []*Block
+	)
+	switch msg.MessageType() {
+	case MessageTypeGetBlocksByHash:
+		req := new(corepb.GetBlocksByHashList)
+		if err := proto.Unmarshal(msg.Data().([]byte), req); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"msgType": msg.MessageType(),
+				"msg":     msg,
+				"err":     err,
+			}).Error("Failed to unmarshal data.")
+			return
+		}
+		id = req.Id
+		blocks = server.bc.GetBlocksByHashList(req.Hashes)
+	case MessageTypeGetBlocksByHeight:
+		req := new(corepb.GetBlocksByHeightRange)
+		if err := proto.Unmarshal(msg.Data().([]byte), req); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"msgType": msg.MessageType(),
+				"msg":     msg,
+				"err":     err,
+			}).Error("Failed to unmarshal data.")
+			return
+		}
+		id = req.Id
+		blocks = server.bc.GetBlocksByHeightRange(req.From, req.Count)
+	default:
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+		}).Warn("Received unregistered message.")
+		return
+	}
+
+	reply, err := buildBlocksReply(id, blocks)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"err":     err,
+		}).Error("Failed to build blocks reply.")
+		return
+	}
+	data, err := proto.Marshal(reply)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"err":     err,
+		}).Error("Failed to marshal blocks reply.")
+		return
+	}
+	server.nm.SendMsg(MessageTypeBlocksReply, data, msg.MessageFrom())
+	blockRequestsServed.Mark(1)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"msgType": msg.MessageType(),
+		"from":    msg.MessageFrom(),
+		"count":   len(reply.Blocks),
+	}).Debug("Responsed to the blocks request.")
+}
+
+// buildBlocksReply converts the blocks in order until the reply reaches MaxBlocksReplySize.
+func buildBlocksReply(id uint64, blocks []*Block) (*corepb.BlocksReply, error) {
+	reply := &corepb.BlocksReply{Id: id}
+	size := 0
+	for _, block := range blocks {
+		pbBlock, err := block.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		pb := pbBlock.(*corepb.Block)
+		size += proto.Size(pb)
+		if size > MaxBlocksReplySize && len(reply.Blocks) > 0 {
+			break
+		}
+		reply.Blocks = append(reply.Blocks, pb)
+	}
+	return reply, nil
+}
+
+// requestLimiter limits the count of requests from each peer in a round.
+type requestLimiter struct {
+	mu    sync.Mutex
+	limit int
+	round time.Duration
+	peers *lru.Cache
+	now   func() time.Time
+}
+
+type requestRound struct {
+	start time.Time
+	count int
+}
+
+func newRequestLimiter(limit int, round time.Duration) *requestLimiter {
+	peers, _ := lru.New(1024)
+	return &requestLimiter{
+		limit: limit,
+		round: round,
+		peers: peers,
+		now:   time.Now,
+	}
+}
+
+// Allow returns if a request from the peer can be served.
+func (l *requestLimiter) Allow(peer string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	v, ok := l.peers.Get(peer)
+	if !ok || now.Sub(v.(*requestRound).start) >= l.round {
+		l.peers.Add(peer, &requestRound{start: now, count: 1})
+		return true
+	}
+	r := v.(*requestRound)
+	if r.count >= l.limit {
+		return false
+	}
+	r.count++
+	return true
+}
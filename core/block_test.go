@@ -105,6 +105,7 @@ func TestBlock(t *testing.T) {
 						1,
 						util.NewUint128(),
 						util.NewUint128(),
+						0,
 						uint8(keystore.SECP256K1),
 						nil,
 					},
@@ -119,6 +120,7 @@ func TestBlock(t *testing.T) {
 						1,
 						util.NewUint128(),
 						util.NewUint128(),
+						0,
 						uint8(keystore.SECP256K1),
 						nil,
 					},
@@ -484,6 +486,9 @@ func TestGivebackInvalidTx(t *testing.T) {
 	assert.Nil(t, err)
 	signature.InitSign(key.(keystore.PrivateKey))
 	tx.Sign(signature)
+	bc.tailBlock.begin()
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(tx.MinBalanceRequired())
+	bc.tailBlock.commit()
 	assert.Nil(t, bc.txPool.Push(tx))
 	assert.Equal(t, len(bc.txPool.all), 1)
 	block, err := bc.NewBlock(from)
@@ -492,6 +497,35 @@ func TestGivebackInvalidTx(t *testing.T) {
 	assert.Equal(t, len(bc.txPool.all), 1)
 }
 
+func TestBlock_ExpiredTransaction(t *testing.T) {
+	bc, err := NewBlockChain(testNeb())
+	assert.Nil(t, err)
+	from := mockAddress()
+	fundAccounts(bc, from)
+	tx := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), 1, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+	tx.SetValidUntil(uint64(time.Now().Unix() + 60))
+	key, err := keystore.DefaultKS.GetUnlocked(from.String())
+	assert.Nil(t, err)
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	signature.InitSign(key.(keystore.PrivateKey))
+	assert.Nil(t, tx.Sign(signature))
+	assert.Nil(t, bc.txPool.Push(tx))
+
+	// the tx expired at the block is dropped rather than given back.
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.header.timestamp = int64(tx.ValidUntil()) + 1
+	block.CollectTransactions(1)
+	assert.Equal(t, 0, len(block.transactions))
+	assert.Equal(t, 0, len(bc.txPool.all))
+
+	block.header.timestamp = int64(tx.ValidUntil())
+	giveback, err := block.executeTransaction(tx)
+	assert.False(t, giveback)
+	assert.Nil(t, err)
+}
+
 func TestRecordEvent(t *testing.T) {
 	bc, err := NewBlockChain(testNeb())
 	assert.Nil(t, err)
@@ -41,19 +41,37 @@ type BlockChain struct {
 	genesis *corepb.Genesis
 
 	genesisBlock *Block
+	rewards      *RewardSchedule
+	dpos         *DposSchedule
 	tailBlock    *Block
+	lib          *Block
 
 	bkPool           *BlockPool
 	txPool           *TransactionPool
+	bkServer         *BlockServer
 	consensusHandler Consensus
 
 	cachedBlocks       *lru.Cache
+	cachedHeaders      *lru.Cache
 	detachedTailBlocks *lru.Cache
+	staleCandidates    *lru.Cache
+	minedBlocks        *lru.Cache
+	cachedMiners       *lru.Cache
+	verifiedBlocks     *lru.Cache
 
 	storage storage.Storage
-	neb     Neblet
+	// index is the namespace of the indexes of canonical chain in storage.
+	index storage.Storage
+	neb   Neblet
 
 	eventEmitter *EventEmitter
+	forkMonitor  forkMonitor
+	pruner       pruner
+	checkpoints  checkpointManager
+	dynastyHooks dynastyHookManager
+	committer    blockCommitter
+	integrity    *IntegrityChecker
+	eventFilters *EventFilters
 }
 
 const (
@@ -92,13 +110,37 @@ func NewBlockChain(neb Neblet) (*BlockChain, error) {
 		genesis:      neb.Genesis(),
 		bkPool:       blockPool,
 		txPool:       txPool,
+		bkServer:     NewBlockServer(1024),
 		storage:      neb.Storage(),
 		neb:          neb,
 		eventEmitter: neb.EventEmitter(),
 	}
 
+	SetAddressNetwork(NetworkOfChain(bc.chainID))
+	SetHeaderForkHeight(bc.chainID, bc.genesis.Meta.HeaderForkHeight)
+	SetParticipationForkHeight(bc.chainID, bc.genesis.Meta.ParticipationForkHeight)
+
+	if err := bc.setupCommitter(); err != nil {
+		return nil, err
+	}
+	bc.index = storage.NewNamespaceStorage(bc.storage, storage.NamespaceTxIndex)
+
 	bc.cachedBlocks, _ = lru.New(1024)
+	bc.cachedHeaders, _ = lru.New(4096)
 	bc.detachedTailBlocks, _ = lru.New(64)
+	bc.staleCandidates, _ = lru.New(1024)
+	bc.minedBlocks, _ = lru.New(1024)
+	bc.cachedMiners, _ = lru.New(1024)
+	bc.verifiedBlocks, _ = lru.New(4096)
+
+	bc.rewards, err = NewRewardSchedule(bc.genesis)
+	if err != nil {
+		return nil, err
+	}
+	bc.dpos, err = NewDposSchedule(bc.genesis)
+	if err != nil {
+		return nil, err
+	}
 
 	bc.genesisBlock, err = bc.loadGenesisFromStorage()
 	if err != nil {
@@ -109,6 +151,7 @@ func NewBlockChain(neb Neblet) (*BlockChain, error) {
 		return nil, err
 	}
 	logging.CLog().WithFields(logrus.Fields{
+		"digest":                 GenesisDigest(bc.genesisBlock),
 		"meta.chainid":           genesisConf.Meta.ChainId,
 		"consensus.dpos.dynasty": genesisConf.Consensus.Dpos.Dynasty,
 		"token.distribution":     genesisConf.TokenDistribution,
@@ -122,8 +165,26 @@ func NewBlockChain(neb Neblet) (*BlockChain, error) {
 		"block": bc.tailBlock,
 	}).Info("Tail Block.")
 
+	bc.lib, err = bc.loadIrreversibleFromStorage()
+	if err != nil {
+		return nil, err
+	}
+
 	bc.bkPool.setBlockChain(bc)
 	bc.txPool.setBlockChain(bc)
+	bc.bkServer.setBlockChain(bc)
+	bc.integrity = newIntegrityChecker(bc)
+	bc.eventFilters = newEventFilters(bc)
+
+	if err := bc.repairChainIndex(); err != nil {
+		return nil, err
+	}
+
+	for _, cp := range Checkpoints[bc.chainID] {
+		if err := bc.AddCheckpoint(cp); err != nil {
+			return nil, err
+		}
+	}
 
 	return bc, nil
 }
@@ -143,6 +204,11 @@ func (bc *BlockChain) Neb() Neblet {
 	return bc.neb
 }
 
+// GenesisDigest returns the digest of the genesis block, peers of other digests are on other chains.
+func (bc *BlockChain) GenesisDigest() byteutils.Hash {
+	return GenesisDigest(bc.genesisBlock)
+}
+
 // GenesisBlock return the genesis block.
 func (bc *BlockChain) GenesisBlock() *Block {
 	return bc.genesisBlock
@@ -161,13 +227,33 @@ func (bc *BlockChain) EventEmitter() *EventEmitter {
 // SetTailBlock set tail block.
 func (bc *BlockChain) SetTailBlock(newTail *Block) error {
 	oldTail := bc.tailBlock
-	bc.tailBlock = newTail
-	bc.storeTailToStorage(bc.tailBlock)
-	// giveBack txs in reverted blocks to tx pool
-	ancestor, err := bc.FindCommonAncestorWithTail(oldTail)
+	var ancestor *Block
+	// the tail is committed with the indexes of the canonical chain at once.
+	err := bc.commitAtomically(func() error {
+		var err error
+		bc.tailBlock = newTail
+		bc.storeTailToStorage(bc.tailBlock)
+		// giveBack txs in reverted blocks to tx pool
+		ancestor, err = bc.FindCommonAncestorWithTail(oldTail)
+		if err != nil {
+			return err
+		}
+		if err := bc.verifyReorg(ancestor, oldTail); err != nil {
+			// keep the old tail, and the fork is never chosen again.
+			bc.tailBlock = oldTail
+			bc.storeTailToStorage(oldTail)
+			bc.detachedTailBlocks.Remove(newTail.Hash().Hex())
+			return err
+		}
+		bc.storeLocalCheckpoint(newTail)
+		bc.updateIndexes(ancestor, oldTail, newTail)
+		bc.updateIrreversibleBlock(newTail)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
+	bc.txPool.onNewTail(newTail)
 	if ancestor.Hash().Equals(oldTail.Hash()) {
 		// oldTail and newTail is on same chain, no reverted blocks
 		// when tail change, add metrics
@@ -180,8 +266,14 @@ func (bc *BlockChain) SetTailBlock(newTail *Block) error {
 				blocktailHashGauge.Update(hash)
 			}
 		}
+		bc.triggerNewTail(ancestor, newTail)
+		bc.autoPrune(newTail)
 		return nil
 	}
+	reorg, err := bc.newReorg(ancestor, oldTail, newTail)
+	if err != nil {
+		return err
+	}
 	reverted := oldTail
 	var revertTimes int64
 	for revertTimes = 0; !reverted.Hash().Equals(ancestor.Hash()); {
@@ -196,9 +288,46 @@ func (bc *BlockChain) SetTailBlock(newTail *Block) error {
 		blockRevertTimesGauge.Update(revertTimes)
 		blockRevertMeter.Mark(1)
 	}
+	bc.onReorg(reorg)
+	bc.triggerNewTail(ancestor, newTail)
+	bc.autoPrune(newTail)
 	return nil
 }
 
+// SetTailBlockFromSnapshot sets a block whose states are fetched from other nodes as tail,
+// the blocks before it are unknown. The tries referred by the block must be complete in storage.
+func (bc *BlockChain) SetTailBlockFromSnapshot(pbBlock *corepb.Block) (*Block, error) {
+	block := new(Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	if !HashBlock(block).Equals(block.Hash()) {
+		return nil, ErrInvalidBlockHash
+	}
+	if err := bc.storeBlockToStorage(block); err != nil {
+		return nil, err
+	}
+	// load the block to attach its states.
+	tail, err := bc.loadBlockFromStorage(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	bc.cachedBlocks.Add(tail.Hash().Hex(), tail)
+	bc.detachedTailBlocks.Add(tail.Hash().Hex(), tail)
+	bc.tailBlock = tail
+	bc.storeTailToStorage(tail)
+	if err := bc.indexCanonicalBlock(tail); err != nil {
+		return nil, err
+	}
+	blockHeightGauge.Update(int64(tail.Height()))
+
+	logging.CLog().WithFields(logrus.Fields{
+		"tail": tail,
+	}).Info("Set the tail from snapshot.")
+	return tail, nil
+}
+
 func hashToInt64(hash string) (int64, error) {
 	rs := []rune(hash)
 	h := string(rs[len(hash)-4 : len(hash)])
@@ -216,34 +345,38 @@ func hashToInt64(hash string) (int64, error) {
 
 // FindCommonAncestorWithTail return the block's common ancestor with current tail
 func (bc *BlockChain) FindCommonAncestorWithTail(block *Block) (*Block, error) {
-	target := bc.GetBlock(block.Hash())
+	target := bc.GetBlockHeader(block.Hash())
 	if target == nil {
-		target = bc.GetBlock(block.ParentHash())
+		target = bc.GetBlockHeader(block.ParentHash())
 	}
 	if target == nil {
 		return nil, ErrMissingParentBlock
 	}
-	tail := bc.TailBlock()
+	// walk by headers, only the ancestor is loaded.
+	tail := bc.TailBlock().ChainHeader()
 	for tail.Height() > target.Height() {
-		tail = bc.GetBlock(tail.header.parentHash)
+		tail = bc.GetBlockHeader(tail.ParentHash())
 		if tail == nil {
 			return nil, ErrMissingParentBlock
 		}
 	}
 	for tail.Height() < target.Height() {
-		target = bc.GetBlock(target.header.parentHash)
+		target = bc.GetBlockHeader(target.ParentHash())
 		if target == nil {
 			return nil, ErrMissingParentBlock
 		}
 	}
 	for !tail.Hash().Equals(target.Hash()) {
-		tail = bc.GetBlock(tail.header.parentHash)
-		target = bc.GetBlock(target.header.parentHash)
+		tail = bc.GetBlockHeader(tail.ParentHash())
+		target = bc.GetBlockHeader(target.ParentHash())
 		if tail == nil || target == nil {
 			return nil, ErrMissingParentBlock
 		}
 	}
-	return target, nil
+	if ancestor := bc.GetBlock(target.Hash()); ancestor != nil {
+		return ancestor, nil
+	}
+	return nil, ErrMissingParentBlock
 }
 
 // FetchDescendantInCanonicalChain return the subsequent blocks of the block
@@ -282,6 +415,16 @@ func (bc *BlockChain) TransactionPool() *TransactionPool {
 	return bc.txPool
 }
 
+// BlockServer return block server.
+func (bc *BlockChain) BlockServer() *BlockServer {
+	return bc.bkServer
+}
+
+// IntegrityChecker return integrity checker.
+func (bc *BlockChain) IntegrityChecker() *IntegrityChecker {
+	return bc.integrity
+}
+
 // SetConsensusHandler set consensus handler.
 func (bc *BlockChain) SetConsensusHandler(handler Consensus) {
 	bc.consensusHandler = handler
@@ -309,6 +452,7 @@ func (bc *BlockChain) putVerifiedNewBlocks(parent *Block, allBlocks, tailBlocks
 		if err := bc.storeBlockToStorage(v); err != nil {
 			return err
 		}
+		bc.addStaleCandidate(v)
 
 		logging.CLog().WithFields(logrus.Fields{
 			"block": v,
@@ -346,7 +490,7 @@ func (bc *BlockChain) GetBlock(hash byteutils.Hash) *Block {
 	// TODO: get block from local storage.
 	v, _ := bc.cachedBlocks.Get(hash.Hex())
 	if v == nil {
-		block, err := LoadBlockFromStorage(hash, bc.storage, bc.txPool, bc.eventEmitter)
+		block, err := bc.loadBlockFromStorage(hash)
 		if err != nil {
 			return nil
 		}
@@ -357,8 +501,72 @@ func (bc *BlockChain) GetBlock(hash byteutils.Hash) *Block {
 	return block
 }
 
+// GetBlocksByHashList return the blocks of given hashes, unknown hashes are skipped.
+// At most MaxBlocksPerRequest hashes are looked up.
+func (bc *BlockChain) GetBlocksByHashList(hashes [][]byte) []*Block {
+	if len(hashes) > MaxBlocksPerRequest {
+		hashes = hashes[:MaxBlocksPerRequest]
+	}
+	var blocks []*Block
+	for _, hash := range hashes {
+		if block := bc.GetBlock(hash); block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// GetBlocksByHeightRange return at most count blocks in canonical chain from the height, in ascending order.
+// At most MaxBlocksPerRequest blocks are returned.
+func (bc *BlockChain) GetBlocksByHeightRange(from uint64, count uint64) []*Block {
+	if count > MaxBlocksPerRequest {
+		count = MaxBlocksPerRequest
+	}
+	return bc.GetCanonicalBlocks(from, count)
+}
+
+// GetCanonicalBlocks return at most count blocks in canonical chain from the height, in ascending order.
+func (bc *BlockChain) GetCanonicalBlocks(from uint64, count uint64) []*Block {
+	tail := bc.TailBlock()
+	if count == 0 || from > tail.Height() {
+		return nil
+	}
+	to := from + count - 1
+	if to > tail.Height() {
+		to = tail.Height()
+	}
+
+	blocks := make([]*Block, to-from+1)
+	// the blocks are looked up by the height index, or walked down from the tail if any is missing.
+	indexed := true
+	for i := range blocks {
+		if blocks[i] = bc.GetBlockByHeight(from + uint64(i)); blocks[i] == nil {
+			indexed = false
+			break
+		}
+	}
+	if indexed {
+		return blocks
+	}
+	for block := tail; block != nil && block.Height() >= from; block = bc.GetBlock(block.ParentHash()) {
+		if block.Height() <= to {
+			blocks[block.Height()-from] = block
+		}
+		if CheckGenesisBlock(block) {
+			break
+		}
+	}
+	if blocks[0] == nil {
+		return nil
+	}
+	return blocks
+}
+
 // GetTransaction return transaction of given hash from local storage.
 func (bc *BlockChain) GetTransaction(hash byteutils.Hash) *Transaction {
+	if tx, _, err := bc.GetTransactionByHash(hash); err == nil {
+		return tx
+	}
 	// TODO: get transaction err handle.
 	tx, err := bc.tailBlock.GetTransaction(hash)
 	if err != nil {
@@ -397,24 +605,20 @@ func (bc *BlockChain) GasPrice() *util.Uint128 {
 	return gasPrice
 }
 
-// EstimateGas returns the transaction gas cost
+// EstimateGas returns the least gas limit the transaction succeeds with, see EstimateGasLimit.
 func (bc *BlockChain) EstimateGas(tx *Transaction) (*util.Uint128, error) {
-	// update gas to max for estimate
-	tx.gasLimit = TransactionMaxGas
-
-	bc.tailBlock.accState.BeginBatch()
-	fromAcc := bc.tailBlock.accState.GetOrCreateUserAccount(tx.from.address)
-	fromAcc.AddBalance(tx.MinBalanceRequired())
-	fromAcc.AddBalance(tx.value)
-	defer bc.tailBlock.accState.RollBack()
-	return tx.VerifyExecution(bc.tailBlock)
+	estimate, err := bc.EstimateGasLimit(tx)
+	if err != nil {
+		return util.NewUint128(), err
+	}
+	return estimate.Gas, nil
 }
 
 func (bc *BlockChain) getAncestorHash(number int) (byteutils.Hash, error) {
-	block := bc.tailBlock
+	block := bc.tailBlock.ChainHeader()
 	for i := 0; i < number; i++ {
-		if !CheckGenesisBlock(block) {
-			block = bc.GetBlock(block.ParentHash())
+		if !block.Hash().Equals(GenesisHash) {
+			block = bc.GetBlockHeader(block.ParentHash())
 			if block == nil {
 				return nil, ErrMissingParentBlock
 			}
@@ -440,6 +644,13 @@ func (bc *BlockChain) Dump(count int) string {
 }
 
 func (bc *BlockChain) storeBlockToStorage(block *Block) error {
+	// the states of a block are retained once.
+	_, err := bc.storage.Get(block.Hash())
+	if err != nil && err != storage.ErrKeyNotFound {
+		return err
+	}
+	stored := err == nil
+
 	pbBlock, err := block.ToProto()
 	if err != nil {
 		return err
@@ -452,7 +663,13 @@ func (bc *BlockChain) storeBlockToStorage(block *Block) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	if err := storeBlockHeader(block, bc.storage); err != nil {
+		return err
+	}
+	if stored {
+		return nil
+	}
+	return bc.retainStates(block)
 }
 
 func (bc *BlockChain) storeTailToStorage(block *Block) {
@@ -476,11 +693,23 @@ func (bc *BlockChain) loadTailFromStorage() (*Block, error) {
 		return genesis, nil
 	}
 
-	return LoadBlockFromStorage(hash, bc.storage, bc.txPool, bc.eventEmitter)
+	return bc.loadBlockFromStorage(hash)
+}
+
+// loadBlockFromStorage loads the block of the chain, in the reward and dpos schedules of the chain.
+func (bc *BlockChain) loadBlockFromStorage(hash byteutils.Hash) (*Block, error) {
+	block, err := LoadBlockFromStorage(hash, bc.storage, bc.txPool, bc.eventEmitter)
+	if err != nil {
+		return nil, err
+	}
+	block.rewards = bc.rewards
+	block.dpos = bc.dpos
+	block.verifiedBlocks = bc.verifiedBlocks
+	return block, nil
 }
 
 func (bc *BlockChain) loadGenesisFromStorage() (*Block, error) {
-	genesis, err := LoadBlockFromStorage(GenesisHash, bc.storage, bc.txPool, bc.eventEmitter)
+	genesis, err := bc.loadBlockFromStorage(GenesisHash)
 	if err != nil {
 		genesis, err = NewGenesisBlock(bc.genesis, bc)
 		if err != nil {
@@ -489,7 +718,20 @@ func (bc *BlockChain) loadGenesisFromStorage() (*Block, error) {
 		if err := bc.storeBlockToStorage(genesis); err != nil {
 			return nil, err
 		}
+		return genesis, nil
+	}
 
+	// the genesis conf is only loaded at first start, it must not change later.
+	digest, err := GenesisConfDigest(bc.genesis)
+	if err != nil {
+		return nil, err
+	}
+	if !digest.Equals(GenesisDigest(genesis)) {
+		logging.CLog().WithFields(logrus.Fields{
+			"conf":    digest,
+			"storage": GenesisDigest(genesis),
+		}).Error("Genesis conf does not match the genesis block in storage.")
+		return nil, ErrGenesisNotMatch
 	}
 	return genesis, nil
 }
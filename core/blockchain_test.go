@@ -74,7 +74,6 @@ func TestBlockChain_FindCommonAncestorWithTail(t *testing.T) {
 	tx3.timestamp = tx3.timestamp + 1
 	tx3.Sign(signature)
 	bc.txPool.Push(tx1)
-	bc.txPool.Push(tx2)
 	bc.txPool.Push(tx3)
 
 	coinbase11 := &Address{[]byte("012345678901234567890011")}
@@ -95,6 +94,8 @@ func TestBlockChain_FindCommonAncestorWithTail(t *testing.T) {
 	block11.CollectTransactions(1)
 	block11.SetMiner(coinbase11)
 	block11.Seal()
+	// tx2 of the same nonce as tx1 packed by block11 is accepted for the other fork.
+	bc.txPool.Push(tx2)
 	block12.CollectTransactions(1)
 	block12.SetMiner(coinbase12)
 	block12.Seal()
@@ -221,6 +222,45 @@ func TestBlockChain_FetchDescendantInCanonicalChain(t *testing.T) {
 	assert.Nil(t, err0)
 }
 
+func TestBlockChain_GetBlocksByRequest(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := &Address{[]byte("012345678901234567890000")}
+
+	var blocks []*Block
+	for i := 0; i < 6; i++ {
+		block, _ := bc.NewBlock(coinbase)
+		block.header.timestamp = BlockInterval * int64(i+1)
+		blocks = append(blocks, block)
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		block.Seal()
+		bc.BlockPool().Push(block)
+		bc.SetTailBlock(block)
+	}
+
+	byHash := bc.GetBlocksByHashList([][]byte{blocks[1].Hash(), []byte("unknown"), blocks[4].Hash()})
+	assert.Equal(t, 2, len(byHash))
+	assert.Equal(t, blocks[1].Hash(), byHash[0].Hash())
+	assert.Equal(t, blocks[4].Hash(), byHash[1].Hash())
+
+	from := blocks[2].Height()
+	byHeight := bc.GetBlocksByHeightRange(from, 3)
+	assert.Equal(t, 3, len(byHeight))
+	for i, block := range byHeight {
+		assert.Equal(t, blocks[i+2].Hash(), block.Hash())
+	}
+	assert.Equal(t, 2, len(bc.GetBlocksByHeightRange(blocks[4].Height(), 10)))
+	assert.Equal(t, 7, len(bc.GetBlocksByHeightRange(bc.genesisBlock.Height(), MaxBlocksPerRequest+1)))
+	assert.Nil(t, bc.GetBlocksByHeightRange(blocks[5].Height()+1, 10))
+	assert.Nil(t, bc.GetBlocksByHeightRange(from, 0))
+
+	canonical := bc.GetCanonicalBlocks(from, 10)
+	assert.Equal(t, 4, len(canonical))
+	assert.Equal(t, blocks[5].Hash(), canonical[3].Hash())
+}
+
 func TestBlockChain_EstimateGas(t *testing.T) {
 	priv := secp256k1.GeneratePrivateKey()
 	pubdata, _ := priv.PublicKey().Encoded()
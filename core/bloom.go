@@ -0,0 +1,138 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+)
+
+const (
+	// BloomLength is the length of the event bloom in bytes, 2048 bits.
+	BloomLength = 256
+
+	// bloomHashes is the count of bits set for an item.
+	bloomHashes = 3
+)
+
+// Errors of bloom
+var (
+	ErrInvalidBloomLength     = errors.New("invalid event bloom length")
+	ErrInvalidBlockEventBloom = errors.New("invalid block event bloom")
+)
+
+// Bloom is the bloom filter of the topics of events and the addresses of contracts emitting them in a block.
+// It never misses an item added, but may match an item never added.
+type Bloom [BloomLength]byte
+
+// BloomFromBytes returns the bloom of bytes.
+func BloomFromBytes(data []byte) (Bloom, error) {
+	var b Bloom
+	if len(data) != BloomLength {
+		return b, ErrInvalidBloomLength
+	}
+	copy(b[:], data)
+	return b, nil
+}
+
+// bloomBits returns the bits of an item, each in 11 bits of its hash.
+func bloomBits(data []byte) [bloomHashes]uint {
+	var bits [bloomHashes]uint
+	h := hash.Sha3256(data)
+	for i := range bits {
+		bits[i] = (uint(h[2*i])<<8 | uint(h[2*i+1])) % (BloomLength * 8)
+	}
+	return bits
+}
+
+// Add adds an item to the bloom.
+func (b *Bloom) Add(data []byte) {
+	for _, bit := range bloomBits(data) {
+		b[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test returns if the item may have been added to the bloom.
+func (b *Bloom) Test(data []byte) bool {
+	for _, bit := range bloomBits(data) {
+		if b[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns the bytes of the bloom.
+func (b *Bloom) Bytes() []byte {
+	data := make([]byte, BloomLength)
+	copy(data, b[:])
+	return data
+}
+
+// Matches returns if the bloom may have an event of any of the topics, emitted by any of the contracts.
+// Empty topics or addresses match any.
+func (b *Bloom) Matches(topics []string, addresses []*Address) bool {
+	return b.matchesAny(len(topics), func(i int) []byte { return []byte(topics[i]) }) &&
+		b.matchesAny(len(addresses), func(i int) []byte { return addresses[i].Bytes() })
+}
+
+func (b *Bloom) matchesAny(n int, item func(int) []byte) bool {
+	if n == 0 {
+		return true
+	}
+	for i := 0; i < n; i++ {
+		if b.Test(item(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// EventBloom returns the bloom of the events in the block, false if the block has no bloom in header,
+// created before the event bloom of blocks.
+func (block *Block) EventBloom() (Bloom, bool) {
+	b, err := BloomFromBytes(block.header.eventBloom)
+	return b, err == nil
+}
+
+// MayHaveEvents returns if the block may have an event of any of the topics, emitted by any of the contracts.
+// The blocks without bloom always may have them. The blocks not matching are skipped in filtering events.
+func (block *Block) MayHaveEvents(topics []string, addresses []*Address) bool {
+	b, ok := block.EventBloom()
+	if !ok {
+		return true
+	}
+	return b.Matches(topics, addresses)
+}
+
+// bloomEvents adds the topics of events emitted by the transaction, and the contract emitting them to the bloom.
+func (block *Block) bloomEvents(tx *Transaction, receipt *Receipt) error {
+	events, err := block.FetchEvents(tx.hash)
+	if err != nil || len(events) == 0 {
+		return err
+	}
+	for _, e := range events {
+		block.eventBloom.Add([]byte(e.Topic))
+	}
+	if contract := eventContract(tx, receipt); contract != nil {
+		block.eventBloom.Add(contract.Bytes())
+	}
+	return nil
+}
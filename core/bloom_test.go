@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloom(t *testing.T) {
+	var b Bloom
+	contract := mockAddress()
+	assert.False(t, b.Test([]byte(TopicExecuteTxSuccess)))
+	assert.True(t, b.Matches(nil, nil))
+	assert.False(t, b.Matches([]string{TopicExecuteTxSuccess}, nil))
+
+	b.Add([]byte(TopicExecuteTxSuccess))
+	b.Add(contract.Bytes())
+	assert.True(t, b.Test([]byte(TopicExecuteTxSuccess)))
+	assert.True(t, b.Test(contract.Bytes()))
+	assert.True(t, b.Matches([]string{TopicExecuteTxFailed, TopicExecuteTxSuccess}, nil))
+	assert.True(t, b.Matches([]string{TopicExecuteTxSuccess}, []*Address{contract}))
+	assert.True(t, b.Matches(nil, []*Address{mockAddress(), contract}))
+
+	loaded, err := BloomFromBytes(b.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, b, loaded)
+	_, err = BloomFromBytes(b.Bytes()[1:])
+	assert.Equal(t, ErrInvalidBloomLength, err)
+}
+
+func TestBlock_EventBloom(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase, to := mockAddress(), mockAddress()
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	bc.tailBlock.header.stateRoot = bc.tailBlock.accState.RootHash()
+	bc.tailBlock.commit()
+	bc.storeBlockToStorage(bc.tailBlock)
+
+	tx := NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+	assert.Nil(t, tx.Sign(signature))
+	assert.Nil(t, bc.txPool.Push(tx))
+
+	block, err := NewBlock(bc.ChainID(), coinbase, bc.tailBlock)
+	assert.Nil(t, err)
+	block.header.timestamp = BlockInterval
+	block.CollectTransactions(1)
+	block.SetMiner(coinbase)
+	assert.Nil(t, block.Seal())
+	assert.Equal(t, 1, len(block.transactions))
+
+	bloom, ok := block.EventBloom()
+	assert.True(t, ok)
+	assert.True(t, bloom.Test([]byte(TopicExecuteTxSuccess)))
+	assert.True(t, block.MayHaveEvents([]string{TopicExecuteTxSuccess}, nil))
+	assert.False(t, block.MayHaveEvents([]string{TopicChainReorg}, nil))
+
+	// the bloom is verified in execution, and covered by the block hash.
+	received, err := mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+	received.SetMiner(coinbase)
+	assert.Nil(t, received.VerifyExecution(bc.tailBlock, c))
+
+	received, err = mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	var fake Bloom
+	received.header.eventBloom = fake.Bytes()
+	assert.NotEqual(t, received.Hash(), HashBlock(received))
+	assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+	received.SetMiner(coinbase)
+	assert.Equal(t, ErrInvalidBlockEventBloom, received.VerifyExecution(bc.tailBlock, c))
+
+	// the blocks without bloom always may have the events.
+	received.header.eventBloom = nil
+	_, ok = received.EventBloom()
+	assert.False(t, ok)
+	assert.True(t, received.MayHaveEvents([]string{TopicChainReorg}, nil))
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	blockCommitTimer = metrics.GetOrRegisterTimer("neb.block.commit", nil)
+)
+
+// blockCommitter buffers the writes of the chain in a commit, to write them at once.
+type blockCommitter struct {
+	mu      sync.Mutex
+	storage *storage.BatchStorage
+}
+
+// setupCommitter rolls back the writes of a commit interrupted by a crash, and puts the storage of the
+// chain in a batch storage.
+func (bc *BlockChain) setupCommitter() error {
+	count, err := storage.RecoverJournal(bc.storage)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		logging.CLog().WithFields(logrus.Fields{
+			"entries": count,
+		}).Warn("Rolled back the blocks partially committed before crash.")
+	}
+	bc.committer.storage = storage.NewBatchStorage(bc.storage)
+	bc.storage = bc.committer.storage
+	return nil
+}
+
+// commitAtomically runs fn with the writes of the chain to storage buffered, and writes them at once
+// after it, so a crash never leaves a block partially committed. The writes are flushed even if fn fails,
+// as they were written one by one before.
+func (bc *BlockChain) commitAtomically(fn func() error) error {
+	if bc.committer.storage == nil {
+		return fn()
+	}
+	bc.committer.mu.Lock()
+	defer bc.committer.mu.Unlock()
+
+	start := time.Now()
+	bc.committer.storage.EnableBatch()
+	err := fn()
+	if e := bc.committer.storage.Flush(); e != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": e,
+		}).Error("Failed to commit the writes of blocks.")
+		if err == nil {
+			err = e
+		}
+	}
+	blockCommitTimer.UpdateSince(start)
+	return err
+}
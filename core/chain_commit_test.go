@@ -0,0 +1,68 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_CommitAtomically(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+
+	err := bc.commitAtomically(func() error {
+		assert.Nil(t, bc.storage.Put([]byte("key"), []byte("value")))
+		_, err := neb.storage.Get([]byte("key"))
+		assert.Equal(t, storage.ErrKeyNotFound, err)
+		return ErrMissingParentBlock
+	})
+	assert.Equal(t, ErrMissingParentBlock, err)
+	value, err := neb.storage.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestBlockChain_RecoverPartialCommit(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	genesis := bc.GenesisBlock()
+	block := mintSignedBlock(t, bc, mockAddress())
+
+	// the tail was written before crash, the journal rolls it back.
+	journal, err := json.Marshal([]map[string]interface{}{
+		{"key": byteutils.Hex([]byte(Tail)), "value": genesis.Hash().String(), "exists": true},
+		{"key": block.Hash().String(), "exists": false},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, neb.storage.Put(storage.JournalKey, journal))
+
+	bc, err = NewBlockChain(neb)
+	assert.Nil(t, err)
+	assert.Equal(t, genesis.Hash(), bc.TailBlock().Hash())
+	assert.Nil(t, bc.GetBlock(block.Hash()))
+	_, err = neb.storage.Get(storage.JournalKey)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
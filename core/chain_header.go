@@ -0,0 +1,177 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// blockHeaderPrefix is the prefix of the keys of the headers of blocks stored, without the transactions,
+// in the flat keyspace before the namespaces. The headers are stored in the headers namespace now.
+const blockHeaderPrefix = "header_"
+
+func blockHeaderKey(hash byteutils.Hash) []byte {
+	return append([]byte(blockHeaderPrefix), hash...)
+}
+
+// ChainHeader is the header of a stored block with its height, loaded without deserializing the
+// transactions and reconstructing the tries. The walks along the chain go by headers, and load
+// the block body on first access by Block.
+type ChainHeader struct {
+	header *BlockHeader
+	height uint64
+
+	once  sync.Once
+	load  func() (*Block, error)
+	block *Block
+	err   error
+}
+
+// Hash returns the hash of block.
+func (h *ChainHeader) Hash() byteutils.Hash {
+	return h.header.hash
+}
+
+// ParentHash returns the hash of parent block.
+func (h *ChainHeader) ParentHash() byteutils.Hash {
+	return h.header.parentHash
+}
+
+// Height returns the height of block.
+func (h *ChainHeader) Height() uint64 {
+	return h.height
+}
+
+// Timestamp returns the timestamp of block.
+func (h *ChainHeader) Timestamp() int64 {
+	return h.header.timestamp
+}
+
+// Coinbase returns the coinbase of block.
+func (h *ChainHeader) Coinbase() *Address {
+	return h.header.coinbase
+}
+
+// Header returns the header of block.
+func (h *ChainHeader) Header() *BlockHeader {
+	return h.header
+}
+
+func (h *ChainHeader) String() string {
+	return fmt.Sprintf("{\"height\":%d, \"hash\":\"%s\", \"parentHash\":\"%s\", \"timestamp\": %d}",
+		h.height,
+		byteutils.Hex(h.header.hash),
+		byteutils.Hex(h.header.parentHash),
+		h.header.timestamp,
+	)
+}
+
+// Block loads the full block on first access.
+func (h *ChainHeader) Block() (*Block, error) {
+	h.once.Do(func() {
+		if h.block == nil {
+			h.block, h.err = h.load()
+		}
+	})
+	return h.block, h.err
+}
+
+// ChainHeader returns the header of the block, whose body is the block.
+func (block *Block) ChainHeader() *ChainHeader {
+	return &ChainHeader{header: block.header, height: block.height, block: block}
+}
+
+// storeBlockHeader stores the header of the block apart from the block, to be loaded alone.
+func storeBlockHeader(block *Block, stor storage.Storage) error {
+	header, err := block.header.ToProto()
+	if err != nil {
+		return err
+	}
+	value, err := proto.Marshal(&corepb.Block{
+		Header: header.(*corepb.BlockHeader),
+		Height: block.height,
+	})
+	if err != nil {
+		return err
+	}
+	return storage.NewNamespaceStorage(stor, storage.NamespaceHeaders).Put(block.Hash(), value)
+}
+
+// LoadBlockHeaderFromStorage returns the header of a block from storage, the body is loaded from storage on first access.
+// The header of a block stored before the headers are stored apart is taken from the block, still skipping the tries.
+func LoadBlockHeaderFromStorage(hash byteutils.Hash, stor storage.Storage, txPool *TransactionPool, eventEmitter *EventEmitter) (*ChainHeader, error) {
+	value, err := storage.NewNamespaceStorage(stor, storage.NamespaceHeaders).Get(hash)
+	if err == storage.ErrKeyNotFound {
+		value, err = stor.Get(blockHeaderKey(hash))
+	}
+	if err == storage.ErrKeyNotFound {
+		value, err = stor.Get(hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(value, pbBlock); err != nil {
+		return nil, err
+	}
+	header := new(BlockHeader)
+	if err := header.FromProto(pbBlock.Header); err != nil {
+		return nil, err
+	}
+	return &ChainHeader{
+		header: header,
+		height: pbBlock.Height,
+		load: func() (*Block, error) {
+			return LoadBlockFromStorage(hash, stor, txPool, eventEmitter)
+		},
+	}, nil
+}
+
+// GetBlockHeader returns the header of block of given hash, nil if not found.
+func (bc *BlockChain) GetBlockHeader(hash byteutils.Hash) *ChainHeader {
+	if v, ok := bc.cachedBlocks.Get(hash.Hex()); ok {
+		return v.(*Block).ChainHeader()
+	}
+	if v, ok := bc.cachedHeaders.Get(hash.Hex()); ok {
+		return v.(*ChainHeader)
+	}
+	h, err := LoadBlockHeaderFromStorage(hash, bc.storage, bc.txPool, bc.eventEmitter)
+	if err != nil {
+		return nil
+	}
+	load := h.load
+	h.load = func() (*Block, error) {
+		block, err := load()
+		if err != nil {
+			return nil, err
+		}
+		block.rewards = bc.rewards
+		block.dpos = bc.dpos
+		block.verifiedBlocks = bc.verifiedBlocks
+		return block, nil
+	}
+	bc.cachedHeaders.Add(hash.Hex(), h)
+	return h
+}
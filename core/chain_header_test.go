@@ -0,0 +1,98 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_GetBlockHeader(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	var timestamp int64
+	mint := func(parent *Block) *Block {
+		timestamp += BlockInterval
+		coinbase := &Address{validators[int(timestamp/BlockInterval)%len(validators)]}
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		block = bc.GetBlock(block.Hash())
+		assert.Nil(t, bc.SetTailBlock(block))
+		return block
+	}
+	block2 := mint(bc.tailBlock)
+	block3 := mint(block2)
+
+	// the header is loaded alone, and the body on first access.
+	h, err := LoadBlockHeaderFromStorage(block3.Hash(), bc.storage, bc.txPool, bc.eventEmitter)
+	assert.Nil(t, err)
+	assert.Equal(t, block3.Hash(), h.Hash())
+	assert.Equal(t, block2.Hash(), h.ParentHash())
+	assert.Equal(t, uint64(3), h.Height())
+	assert.Equal(t, block3.Timestamp(), h.Timestamp())
+	assert.Nil(t, h.block)
+	block, err := h.Block()
+	assert.Nil(t, err)
+	assert.Equal(t, block3.Hash(), block.Hash())
+	assert.Equal(t, block3.StateRoot(), block.StateRoot())
+	loaded, _ := h.Block()
+	assert.True(t, block == loaded)
+
+	// the header stored in the flat keyspace before the namespaces is loaded.
+	headers := storage.NewNamespaceStorage(bc.storage, storage.NamespaceHeaders)
+	value, err := headers.Get(block2.Hash())
+	assert.Nil(t, err)
+	assert.Nil(t, headers.Del(block2.Hash()))
+	assert.Nil(t, bc.storage.Put(blockHeaderKey(block2.Hash()), value))
+	h, err = LoadBlockHeaderFromStorage(block2.Hash(), bc.storage, bc.txPool, bc.eventEmitter)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), h.Height())
+
+	// the header of a block stored before the headers are stored apart is taken from the block.
+	assert.Nil(t, bc.storage.Del(blockHeaderKey(block2.Hash())))
+	h, err = LoadBlockHeaderFromStorage(block2.Hash(), bc.storage, bc.txPool, bc.eventEmitter)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), h.Height())
+	assert.Equal(t, bc.genesisBlock.Hash(), h.ParentHash())
+
+	_, err = LoadBlockHeaderFromStorage(mockAddress().Bytes(), bc.storage, bc.txPool, bc.eventEmitter)
+	assert.NotNil(t, err)
+
+	// the chain walks by headers.
+	bc.cachedBlocks.Purge()
+	assert.Equal(t, block2.Hash(), bc.GetBlockHeader(block2.Hash()).Hash())
+	assert.Nil(t, bc.GetBlockHeader(mockAddress().Bytes()))
+	ancestor, err := bc.FindCommonAncestorWithTail(block2)
+	assert.Nil(t, err)
+	assert.Equal(t, block2.Hash(), ancestor.Hash())
+	assert.Equal(t, bc.rewards, ancestor.rewards)
+	hash, err := bc.getAncestorHash(5)
+	assert.Nil(t, err)
+	assert.Equal(t, bc.genesisBlock.Hash(), hash)
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// heightIndexPrefix is the prefix of the keys of the canonical block hashes by height.
+	heightIndexPrefix = "height_"
+
+	// txIndexPrefix is the prefix of the keys of the canonical block hash and index of transactions by hash.
+	txIndexPrefix = "tx_"
+)
+
+// Errors of chain index
+var (
+	ErrTransactionNotFound = errors.New("cannot find the transaction in canonical chain")
+)
+
+func heightIndexKey(height uint64) []byte {
+	return append([]byte(heightIndexPrefix), byteutils.FromUint64(height)...)
+}
+
+func txIndexKey(hash byteutils.Hash) []byte {
+	return append([]byte(txIndexPrefix), hash...)
+}
+
+// canonicalHash returns the hash of the canonical block at the height, nil if unknown.
+func (bc *BlockChain) canonicalHash(height uint64) byteutils.Hash {
+	hash, err := bc.index.Get(heightIndexKey(height))
+	if err != nil {
+		return nil
+	}
+	return hash
+}
+
+// GetBlockByHeight returns the block at the height in canonical chain, nil if not found.
+func (bc *BlockChain) GetBlockByHeight(height uint64) *Block {
+	if height > bc.TailBlock().Height() {
+		return nil
+	}
+	hash := bc.canonicalHash(height)
+	if hash == nil {
+		return nil
+	}
+	return bc.GetBlock(hash)
+}
+
+// GetTransactionByHash returns the transaction in canonical chain and the block packing it.
+func (bc *BlockChain) GetTransactionByHash(hash byteutils.Hash) (*Transaction, *Block, error) {
+	data, err := bc.index.Get(txIndexKey(hash))
+	if err == storage.ErrKeyNotFound {
+		return nil, nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) != BlockHashLength+4 {
+		return nil, nil, ErrTransactionNotFound
+	}
+	block := bc.GetBlock(data[:BlockHashLength])
+	// the index of a block reverted may be left if the node crashed during the reorg.
+	if block == nil || !block.Hash().Equals(bc.canonicalHash(block.Height())) {
+		return nil, nil, ErrTransactionNotFound
+	}
+	index := int(byteutils.Uint32(data[BlockHashLength:]))
+	if index >= len(block.transactions) || !block.transactions[index].Hash().Equals(hash) {
+		return nil, nil, ErrTransactionNotFound
+	}
+	return block.transactions[index], block, nil
+}
+
+// canonicalChange returns the blocks reverted from oldTail down to ancestor,
+// and the blocks applied from ancestor up to newTail in ascending order.
+func (bc *BlockChain) canonicalChange(ancestor, oldTail, newTail *Block) ([]*Block, []*Block, error) {
+	var reverted, applied []*Block
+	for block := oldTail; !block.Hash().Equals(ancestor.Hash()); {
+		reverted = append(reverted, block)
+		if block = bc.GetBlock(block.ParentHash()); block == nil {
+			return nil, nil, ErrMissingParentBlock
+		}
+	}
+	for block := newTail; !block.Hash().Equals(ancestor.Hash()); {
+		applied = append([]*Block{block}, applied...)
+		if block = bc.GetBlock(block.ParentHash()); block == nil {
+			return nil, nil, ErrMissingParentBlock
+		}
+	}
+	return reverted, applied, nil
+}
+
+// updateIndexes maintains the indexes of canonical chain when the tail switches from oldTail to newTail.
+func (bc *BlockChain) updateIndexes(ancestor, oldTail, newTail *Block) {
+	reverted, applied, err := bc.canonicalChange(ancestor, oldTail, newTail)
+	if err == nil {
+		err = bc.indexCanonicalBlocks(reverted, applied)
+	}
+	if err == nil {
+		err = bc.indexAddressTransactions(reverted, applied)
+	}
+	if err == nil {
+		err = bc.indexEvents(reverted, applied)
+	}
+	if err == nil {
+		err = bc.recordStaleBlocks(reverted, newTail)
+	}
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"ancestor": ancestor,
+			"oldTail":  oldTail,
+			"newTail":  newTail,
+			"err":      err,
+		}).Error("Failed to update the indexes of canonical chain.")
+	}
+}
+
+func (bc *BlockChain) indexCanonicalBlocks(reverted, applied []*Block) error {
+	for _, block := range reverted {
+		for _, tx := range block.transactions {
+			if err := bc.index.Del(txIndexKey(tx.Hash())); err != nil {
+				return err
+			}
+		}
+		if err := bc.index.Del(heightIndexKey(block.Height())); err != nil {
+			return err
+		}
+	}
+	for _, block := range applied {
+		if err := bc.indexCanonicalBlock(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bc *BlockChain) indexCanonicalBlock(block *Block) error {
+	for i, tx := range block.transactions {
+		value := append(append([]byte{}, block.Hash()...), byteutils.FromUint32(uint32(i))...)
+		if err := bc.index.Put(txIndexKey(tx.Hash()), value); err != nil {
+			return err
+		}
+	}
+	return bc.index.Put(heightIndexKey(block.Height()), block.Hash())
+}
+
+// repairChainIndex indexes the canonical blocks not indexed yet, walking down from the tail.
+// The index is behind the tail if the node crashed before updating it, or was built by an old version
+// in the flat keyspace before the namespaces.
+func (bc *BlockChain) repairChainIndex() error {
+	repaired := 0
+	for block := bc.tailBlock; block != nil && !block.Hash().Equals(bc.canonicalHash(block.Height())); {
+		if err := bc.indexCanonicalBlock(block); err != nil {
+			return err
+		}
+		repaired++
+		if CheckGenesisBlock(block) {
+			break
+		}
+		block = bc.GetBlock(block.ParentHash())
+	}
+	if repaired > 0 {
+		logging.CLog().WithFields(logrus.Fields{
+			"tail":     bc.tailBlock,
+			"repaired": repaired,
+		}).Info("Repaired the index of canonical chain.")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_ChainIndex(t *testing.T) {
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	to := &Address{[]byte("01234567890123456789012345")}
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+
+	// the chain is reloaded later, the account is funded in genesis conf.
+	neb := testNeb()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	neb.genesis.TokenDistribution = append(neb.genesis.TokenDistribution, &corepb.GenesisTokenDistribution{
+		Address: from.String(),
+		Value:   balance.String(),
+	})
+	bc, _ := NewBlockChain(neb)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	var timestamp int64
+	mint := func(parent *Block, txs ...*Transaction) *Block {
+		for _, tx := range txs {
+			assert.Nil(t, tx.Sign(signature))
+			assert.Nil(t, bc.txPool.Push(tx))
+		}
+		timestamp += BlockInterval
+		coinbase := &Address{validators[int(timestamp/BlockInterval)%len(validators)]}
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(len(txs))
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		block = bc.GetBlock(block.Hash())
+		assert.Nil(t, bc.SetTailBlock(block))
+		return block
+	}
+	transfer := func(nonce uint64) *Transaction {
+		return NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), nonce, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+	}
+
+	/*
+		genesis -- 2 -- 3
+		             \_ 3' -- 4'
+	*/
+	tx1, tx2 := transfer(1), transfer(2)
+	block2 := mint(bc.tailBlock, tx1)
+	block3 := mint(block2, tx2)
+	assert.Equal(t, bc.genesisBlock.Hash(), bc.GetBlockByHeight(1).Hash())
+	assert.Equal(t, block3.Hash(), bc.GetBlockByHeight(3).Hash())
+	assert.Nil(t, bc.GetBlockByHeight(4))
+	tx, block, err := bc.GetTransactionByHash(tx2.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, tx2.Hash(), tx.Hash())
+	assert.Equal(t, block3.Hash(), block.Hash())
+
+	// the blocks and transactions reverted are removed from the index.
+	fork := mint(block2)
+	bc.txPool.Pop()
+	fork = mint(fork)
+	assert.Equal(t, fork.Hash(), bc.GetBlockByHeight(4).Hash())
+	assert.NotEqual(t, block3.Hash(), bc.GetBlockByHeight(3).Hash())
+	_, _, err = bc.GetTransactionByHash(tx2.Hash())
+	assert.Equal(t, ErrTransactionNotFound, err)
+	_, block, err = bc.GetTransactionByHash(tx1.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, block2.Hash(), block.Hash())
+	blocks := bc.GetCanonicalBlocks(2, 3)
+	assert.Equal(t, 3, len(blocks))
+	assert.Equal(t, fork.Hash(), blocks[2].Hash())
+
+	// the index missing is rebuilt when the chain is loaded.
+	assert.Nil(t, bc.index.Del(heightIndexKey(3)))
+	assert.Nil(t, bc.index.Del(heightIndexKey(4)))
+	reloaded, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	assert.Equal(t, fork.Hash(), reloaded.GetBlockByHeight(4).Hash())
+	assert.Equal(t, fork.ParentHash(), reloaded.GetBlockByHeight(3).Hash())
+}
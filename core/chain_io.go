@@ -0,0 +1,245 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Chain dump format: the header of magic, version, chain id, from and to heights,
+// followed by the blocks in ascending order, each is the length of data and the protobuf encoded block.
+const (
+	ChainDumpMagic   = "NEBCHAIN"
+	ChainDumpVersion = uint32(1)
+
+	// MaxDumpBlockSize is the max size of a block in dump.
+	MaxDumpBlockSize = 32 * 1024 * 1024
+
+	// chainDumpProgress is the count of blocks between the progress reports.
+	chainDumpProgress = 1000
+)
+
+// Errors of chain dump
+var (
+	ErrInvalidExportRange   = errors.New("invalid height range to export")
+	ErrInvalidChainDump     = errors.New("invalid chain dump")
+	ErrChainDumpMismatch    = errors.New("chain dump is of another chain")
+	ErrChainDumpUnsupported = errors.New("unsupported version of chain dump")
+)
+
+// Export writes the canonical blocks from fromHeight to toHeight into w, toHeight is the tail if 0.
+func (bc *BlockChain) Export(w io.Writer, fromHeight, toHeight uint64) error {
+	tail := bc.TailBlock()
+	if toHeight == 0 {
+		toHeight = tail.Height()
+	}
+	if fromHeight == 0 || fromHeight > toHeight || toHeight > tail.Height() {
+		return ErrInvalidExportRange
+	}
+
+	// collect the hashes in canonical chain first, the blocks are loaded one by one.
+	total := toHeight - fromHeight + 1
+	hashes := make([]byteutils.Hash, total)
+	for block := tail; block.Height() >= fromHeight; {
+		if block.Height() <= toHeight {
+			hashes[block.Height()-fromHeight] = block.Hash()
+		}
+		if CheckGenesisBlock(block) {
+			break
+		}
+		if block = bc.GetBlock(block.ParentHash()); block == nil {
+			return ErrMissingParentBlock
+		}
+	}
+
+	header := []byte(ChainDumpMagic)
+	header = append(header, byteutils.FromUint32(ChainDumpVersion)...)
+	header = append(header, byteutils.FromUint32(bc.chainID)...)
+	header = append(header, byteutils.FromUint64(fromHeight)...)
+	header = append(header, byteutils.FromUint64(toHeight)...)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	for i, hash := range hashes {
+		block := bc.GetBlock(hash)
+		if block == nil {
+			return ErrMissingParentBlock
+		}
+		pbBlock, err := block.ToProto()
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(pbBlock)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(byteutils.FromUint32(uint32(len(data)))); err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		if done := uint64(i + 1); done%chainDumpProgress == 0 || done == total {
+			logging.CLog().WithFields(logrus.Fields{
+				"height":   block.Height(),
+				"exported": done,
+				"total":    total,
+			}).Info("Exporting blocks.")
+		}
+	}
+	return nil
+}
+
+// Import reads the blocks exported by Export from r, and puts them on chain after verification.
+// The blocks already on chain are skipped, the tail is updated if the blocks extend it.
+func (bc *BlockChain) Import(r io.Reader) error {
+	header := make([]byte, len(ChainDumpMagic)+24)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return ErrInvalidChainDump
+	}
+	if !bytes.Equal(header[:len(ChainDumpMagic)], []byte(ChainDumpMagic)) {
+		return ErrInvalidChainDump
+	}
+	header = header[len(ChainDumpMagic):]
+	if byteutils.Uint32(header[:4]) != ChainDumpVersion {
+		return ErrChainDumpUnsupported
+	}
+	if byteutils.Uint32(header[4:8]) != bc.chainID {
+		return ErrChainDumpMismatch
+	}
+	fromHeight, toHeight := byteutils.Uint64(header[8:16]), byteutils.Uint64(header[16:24])
+	if fromHeight == 0 || fromHeight > toHeight {
+		return ErrInvalidChainDump
+	}
+
+	total := toHeight - fromHeight + 1
+	imported := 0
+	for done := uint64(1); done <= total; done++ {
+		block, err := readDumpBlock(r)
+		if err != nil {
+			return err
+		}
+		if block.Height() != fromHeight+done-1 {
+			return ErrInvalidChainDump
+		}
+		ok, err := bc.importBlock(block)
+		if err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"block": block,
+				"err":   err,
+			}).Error("Failed to import the block.")
+			return err
+		}
+		if ok {
+			imported++
+		}
+		if done%chainDumpProgress == 0 || done == total {
+			logging.CLog().WithFields(logrus.Fields{
+				"height":   block.Height(),
+				"imported": imported,
+				"read":     done,
+				"total":    total,
+			}).Info("Importing blocks.")
+		}
+	}
+	return nil
+}
+
+func readDumpBlock(r io.Reader) (*Block, error) {
+	size := make([]byte, 4)
+	if _, err := io.ReadFull(r, size); err != nil {
+		return nil, ErrInvalidChainDump
+	}
+	n := byteutils.Uint32(size)
+	if n == 0 || n > MaxDumpBlockSize {
+		return nil, ErrInvalidChainDump
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, ErrInvalidChainDump
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(data, pbBlock); err != nil {
+		return nil, ErrInvalidChainDump
+	}
+	block := new(Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, ErrInvalidChainDump
+	}
+	return block, nil
+}
+
+// importBlock verifies the block and puts it on chain, returns false if the block is on chain already.
+func (bc *BlockChain) importBlock(block *Block) (bool, error) {
+	pool := bc.bkPool
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if CheckGenesisBlock(block) {
+		if !block.Hash().Equals(bc.genesisBlock.Hash()) {
+			return false, ErrInvalidChainDump
+		}
+		return false, nil
+	}
+	if bc.GetBlock(block.Hash()) != nil {
+		return false, nil
+	}
+
+	if err := block.VerifyIntegrity(bc.chainID, bc.ConsensusHandler()); err != nil {
+		return false, err
+	}
+	if err := bc.verifyCheckpoint(block); err != nil {
+		return false, err
+	}
+	parent := bc.GetBlock(block.ParentHash())
+	if parent == nil {
+		return false, ErrMissingParentBlock
+	}
+	// the states of the block are committed with it at once.
+	err := bc.commitAtomically(func() error {
+		if err := block.LinkParentBlock(parent); err != nil {
+			return err
+		}
+		if err := bc.verifyDynastyChanges(block); err != nil {
+			return err
+		}
+		if err := block.VerifyExecution(parent, bc.ConsensusHandler()); err != nil {
+			return err
+		}
+		return bc.putVerifiedNewBlocks(parent, []*Block{block}, []*Block{block})
+	})
+	if err != nil {
+		return false, err
+	}
+	if parent.Hash().Equals(bc.TailBlock().Hash()) {
+		if err := bc.SetTailBlock(block); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
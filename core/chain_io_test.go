@@ -0,0 +1,83 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_ExportImport(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	blocks := []*Block{bc.genesisBlock}
+	for i := 0; i < 5; i++ {
+		coinbase := &Address{[]byte(fmt.Sprintf("0123456789012345678900%02d", i))}
+		block, err := NewBlock(bc.ChainID(), coinbase, blocks[len(blocks)-1])
+		assert.Nil(t, err)
+		block.header.timestamp = BlockInterval * int64(i+1)
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		assert.Nil(t, bc.SetTailBlock(block))
+		blocks = append(blocks, block)
+	}
+
+	buf := new(bytes.Buffer)
+	assert.Equal(t, ErrInvalidExportRange, bc.Export(buf, 0, 3))
+	assert.Equal(t, ErrInvalidExportRange, bc.Export(buf, 4, 3))
+	assert.Equal(t, ErrInvalidExportRange, bc.Export(buf, 1, 7))
+	assert.Nil(t, bc.Export(buf, 1, 0))
+	dump := append([]byte{}, buf.Bytes()...)
+
+	another, _ := NewBlockChain(testNeb())
+	another.SetConsensusHandler(c)
+	assert.Nil(t, another.Import(bytes.NewReader(dump)))
+	assert.Equal(t, bc.TailBlock().Hash(), another.TailBlock().Hash())
+	for _, block := range blocks {
+		imported := another.GetBlock(block.Hash())
+		assert.NotNil(t, imported)
+		assert.Equal(t, block.StateRoot(), imported.StateRoot())
+	}
+	// importing again skips the blocks on chain.
+	assert.Nil(t, another.Import(bytes.NewReader(dump)))
+
+	// a part of chain is imported if its parent is on chain.
+	buf.Reset()
+	assert.Nil(t, bc.Export(buf, 3, 4))
+	partial, _ := NewBlockChain(testNeb())
+	partial.SetConsensusHandler(c)
+	assert.Equal(t, ErrMissingParentBlock, partial.Import(bytes.NewReader(buf.Bytes())))
+
+	// the dumps truncated, tampered or of other chains are rejected.
+	assert.Equal(t, ErrInvalidChainDump, partial.Import(bytes.NewReader(dump[:len(dump)-1])))
+	assert.Equal(t, ErrInvalidChainDump, partial.Import(bytes.NewReader([]byte("NEBULAS"))))
+	mismatch := append([]byte{}, dump...)
+	mismatch[len(ChainDumpMagic)+7]++
+	assert.Equal(t, ErrChainDumpMismatch, partial.Import(bytes.NewReader(mismatch)))
+	tampered := append([]byte{}, dump...)
+	tampered[len(tampered)-10]++
+	assert.NotNil(t, partial.Import(bytes.NewReader(tampered)))
+}
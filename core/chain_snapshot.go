@@ -0,0 +1,144 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// chainSnapshotPrefix is the prefix of the keys of the blocks whose states are pinned by snapshots.
+const chainSnapshotPrefix = "chain_snapshot_"
+
+// Errors of chain snapshots
+var (
+	ErrSnapshotNotFound = errors.New("cannot find the snapshot in canonical chain")
+)
+
+// SnapshotID identifies a canonical block whose states are kept, the chain can be reverted to it.
+type SnapshotID struct {
+	Height uint64
+	Hash   byteutils.Hash
+}
+
+// String returns the snapshot id in format of height:hash.
+func (id SnapshotID) String() string {
+	return strconv.FormatUint(id.Height, 10) + ":" + id.Hash.String()
+}
+
+func chainSnapshotKey(hash byteutils.Hash) []byte {
+	return append([]byte(chainSnapshotPrefix), hash...)
+}
+
+// Snapshot pins the states of the canonical block at height, they are never pruned,
+// so the chain can be reverted to it by RevertTo.
+func (bc *BlockChain) Snapshot(height uint64) (SnapshotID, error) {
+	block := bc.GetBlockByHeight(height)
+	if block == nil {
+		return SnapshotID{}, ErrSnapshotNotFound
+	}
+	if block.StatesPruned() {
+		return SnapshotID{}, ErrBlockStatePruned
+	}
+	if err := bc.pinStates(block); err != nil {
+		return SnapshotID{}, err
+	}
+
+	id := SnapshotID{Height: block.Height(), Hash: block.Hash()}
+	logging.CLog().WithFields(logrus.Fields{
+		"snapshot": id,
+	}).Info("Took a snapshot of the chain.")
+	return id, nil
+}
+
+// pinStates references the states of the block once more than retainStates, once for all snapshots of it.
+func (bc *BlockChain) pinStates(block *Block) error {
+	header, err := block.header.ToProto()
+	if err != nil {
+		return err
+	}
+	bc.pruner.mu.Lock()
+	defer bc.pruner.mu.Unlock()
+
+	key := chainSnapshotKey(block.Hash())
+	if _, err := bc.storage.Get(key); err != storage.ErrKeyNotFound {
+		return err
+	}
+	if err := bc.retainRoots(header.(*corepb.BlockHeader)); err != nil {
+		return err
+	}
+	return bc.storage.Put(key, byteutils.FromUint64(block.Height()))
+}
+
+// RevertTo rewinds the canonical chain to the block of the snapshot. The transactions of the blocks
+// reverted are returned to the tx pool, the indexes are unwound and TopicChainReorg is emitted,
+// as a reorg to the snapshot. The blocks reverted are dropped from the tails of fork choice,
+// they stay in storage and are chosen again only if extended by new blocks.
+// It fails if a checkpoint is above the snapshot.
+func (bc *BlockChain) RevertTo(id SnapshotID) error {
+	// no new block is put on chain during the revert.
+	pool := bc.bkPool
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	tail := bc.TailBlock()
+	if id.Height > tail.Height() || !id.Hash.Equals(bc.canonicalHash(id.Height)) {
+		return ErrSnapshotNotFound
+	}
+	if id.Height == tail.Height() {
+		return nil
+	}
+	target := bc.GetBlock(id.Hash)
+	if target == nil {
+		return ErrSnapshotNotFound
+	}
+	if target.StatesPruned() {
+		return ErrBlockStatePruned
+	}
+	if err := bc.SetTailBlock(target); err != nil {
+		return err
+	}
+	bc.dropDescendantTails(target)
+
+	logging.CLog().WithFields(logrus.Fields{
+		"snapshot": id,
+		"oldTail":  tail,
+	}).Warn("Reverted the chain to the snapshot.")
+	return nil
+}
+
+// dropDescendantTails removes the tails descending from the block from fork choice, the block becomes a tail.
+func (bc *BlockChain) dropDescendantTails(block *Block) {
+	for _, tail := range bc.DetachedTailBlocks() {
+		ancestor := tail.ChainHeader()
+		for ancestor != nil && ancestor.Height() > block.Height() {
+			ancestor = bc.GetBlockHeader(ancestor.ParentHash())
+		}
+		if ancestor != nil && ancestor.Hash().Equals(block.Hash()) {
+			bc.detachedTailBlocks.Remove(tail.Hash().Hex())
+		}
+	}
+	bc.detachedTailBlocks.Add(block.Hash().Hex(), block)
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_RevertTo(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	to := &Address{[]byte("01234567890123456789012345")}
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	bc.tailBlock.header.stateRoot = bc.tailBlock.accState.RootHash()
+	bc.tailBlock.commit()
+	bc.storeBlockToStorage(bc.tailBlock)
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	var timestamp int64
+	mint := func(parent *Block, txs ...*Transaction) *Block {
+		for _, tx := range txs {
+			assert.Nil(t, tx.Sign(signature))
+			assert.Nil(t, bc.txPool.Push(tx))
+		}
+		timestamp += BlockInterval
+		coinbase := &Address{validators[int(timestamp/BlockInterval)%len(validators)]}
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(len(txs))
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		block = bc.GetBlock(block.Hash())
+		assert.Nil(t, bc.SetTailBlock(block))
+		return block
+	}
+	transfer := func(nonce uint64) *Transaction {
+		return NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), nonce, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+	}
+
+	tx1, tx2 := transfer(1), transfer(2)
+	block2 := mint(bc.tailBlock, tx1)
+	_, err = bc.Snapshot(3)
+	assert.Equal(t, ErrSnapshotNotFound, err)
+	id, err := bc.Snapshot(2)
+	assert.Nil(t, err)
+	assert.Equal(t, SnapshotID{Height: 2, Hash: block2.Hash()}, id)
+	block3 := mint(block2, tx2)
+	assert.Nil(t, bc.txPool.GetTransaction(tx2.Hash()))
+
+	assert.Nil(t, bc.RevertTo(id))
+	assert.Equal(t, block2.Hash(), bc.TailBlock().Hash())
+	assert.Nil(t, bc.GetBlockByHeight(3))
+	_, _, err = bc.GetTransactionByHash(tx2.Hash())
+	assert.Equal(t, ErrTransactionNotFound, err)
+	assert.NotNil(t, bc.txPool.GetTransaction(tx2.Hash()))
+	history := bc.ReorgHistory()
+	assert.Equal(t, []string{block3.Hash().String()}, history[len(history)-1].Reverted)
+	for _, tail := range bc.DetachedTailBlocks() {
+		assert.NotEqual(t, block3.Hash(), tail.Hash())
+	}
+	assert.Nil(t, bc.RevertTo(id))
+
+	// the snapshot of the block left the canonical chain is stale.
+	assert.Equal(t, ErrSnapshotNotFound, bc.RevertTo(SnapshotID{Height: 3, Hash: block3.Hash()}))
+	fork := mint(block2)
+	assert.Equal(t, ErrSnapshotNotFound, bc.RevertTo(SnapshotID{Height: 3, Hash: block3.Hash()}))
+	assert.Equal(t, fork.Hash(), bc.GetBlockByHeight(3).Hash())
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LocalCheckpoint Key in storage, the latest block verified locally and deep enough in canonical chain.
+	LocalCheckpoint = "blockchain_checkpoint"
+
+	// LocalCheckpointInterval is the interval of heights the local checkpoints are stored at.
+	LocalCheckpointInterval = 1024
+
+	// LocalCheckpointDepth is how far a local checkpoint is behind the tail, the recent blocks may be reverted.
+	LocalCheckpointDepth = MinPruneRetention
+)
+
+// Errors of checkpoints
+var (
+	ErrInvalidCheckpoint    = errors.New("invalid checkpoint, should be height:hash")
+	ErrCheckpointMismatch   = errors.New("block conflicts with the checkpoint at its height")
+	ErrReorgBelowCheckpoint = errors.New("cannot revert the blocks below a checkpoint")
+)
+
+// Checkpoint is a block trusted to be in canonical chain, the chain never reorganizes below it.
+type Checkpoint struct {
+	Height uint64
+	Hash   byteutils.Hash
+}
+
+// Checkpoints are the hard-coded checkpoints of the released chains, by chain id.
+var Checkpoints = map[uint32][]*Checkpoint{}
+
+// ParseCheckpoint parses a checkpoint in format of height:hash, the hash is in hex.
+func ParseCheckpoint(s string) (*Checkpoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, ErrInvalidCheckpoint
+	}
+	height, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || height == 0 {
+		return nil, ErrInvalidCheckpoint
+	}
+	hash, err := byteutils.FromHex(parts[1])
+	if err != nil || len(hash) != BlockHashLength {
+		return nil, ErrInvalidCheckpoint
+	}
+	return &Checkpoint{Height: height, Hash: hash}, nil
+}
+
+// String returns the checkpoint in format of height:hash.
+func (cp *Checkpoint) String() string {
+	return strconv.FormatUint(cp.Height, 10) + ":" + cp.Hash.String()
+}
+
+// checkpointManager keeps the hard-coded and configured checkpoints.
+type checkpointManager struct {
+	mu          sync.RWMutex
+	checkpoints map[uint64]byteutils.Hash
+}
+
+// AddCheckpoint adds a checkpoint, the blocks conflicting with it are rejected.
+// It fails if the canonical chain conflicts with the checkpoint already.
+func (bc *BlockChain) AddCheckpoint(cp *Checkpoint) error {
+	if blocks := bc.GetCanonicalBlocks(cp.Height, 1); len(blocks) > 0 && !blocks[0].Hash().Equals(cp.Hash) {
+		return ErrCheckpointMismatch
+	}
+	bc.checkpoints.mu.Lock()
+	defer bc.checkpoints.mu.Unlock()
+	if bc.checkpoints.checkpoints == nil {
+		bc.checkpoints.checkpoints = make(map[uint64]byteutils.Hash)
+	}
+	if hash, ok := bc.checkpoints.checkpoints[cp.Height]; ok && !hash.Equals(cp.Hash) {
+		return ErrCheckpointMismatch
+	}
+	bc.checkpoints.checkpoints[cp.Height] = cp.Hash
+
+	logging.CLog().WithFields(logrus.Fields{
+		"checkpoint": cp,
+	}).Info("Added a checkpoint.")
+	return nil
+}
+
+// Checkpoints returns the hard-coded and configured checkpoints, and the local one, in ascending order.
+func (bc *BlockChain) Checkpoints() []*Checkpoint {
+	bc.checkpoints.mu.RLock()
+	checkpoints := make(map[uint64]byteutils.Hash)
+	for height, hash := range bc.checkpoints.checkpoints {
+		checkpoints[height] = hash
+	}
+	bc.checkpoints.mu.RUnlock()
+
+	if local := bc.LocalCheckpoint(); local != nil {
+		if _, ok := checkpoints[local.Height]; !ok {
+			checkpoints[local.Height] = local.Hash
+		}
+	}
+	var ret []*Checkpoint
+	for height, hash := range checkpoints {
+		ret = append(ret, &Checkpoint{Height: height, Hash: hash})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Height < ret[j].Height })
+	return ret
+}
+
+// LatestCheckpoint returns the highest checkpoint, nil if none.
+func (bc *BlockChain) LatestCheckpoint() *Checkpoint {
+	checkpoints := bc.Checkpoints()
+	if len(checkpoints) == 0 {
+		return nil
+	}
+	return checkpoints[len(checkpoints)-1]
+}
+
+// LocalCheckpoint returns the latest block verified locally and deep enough in canonical chain, nil if none.
+func (bc *BlockChain) LocalCheckpoint() *Checkpoint {
+	data, err := bc.storage.Get([]byte(LocalCheckpoint))
+	if err != nil || len(data) != 8+BlockHashLength {
+		return nil
+	}
+	return &Checkpoint{Height: byteutils.Uint64(data[:8]), Hash: data[8:]}
+}
+
+// verifyCheckpoint checks the block doesn't conflict with the checkpoint at its height.
+func (bc *BlockChain) verifyCheckpoint(block *Block) error {
+	bc.checkpoints.mu.RLock()
+	defer bc.checkpoints.mu.RUnlock()
+	if hash, ok := bc.checkpoints.checkpoints[block.Height()]; ok && !hash.Equals(block.Hash()) {
+		return ErrCheckpointMismatch
+	}
+	return nil
+}
+
+// verifyReorg checks the blocks reverted from oldTail down to ancestor contain no checkpoint,
+// nor the latest irreversible block.
+func (bc *BlockChain) verifyReorg(ancestor, oldTail *Block) error {
+	if bc.lib != nil && bc.lib.Height() > ancestor.Height() {
+		return ErrReorgBelowIrreversible
+	}
+	for _, cp := range bc.Checkpoints() {
+		if cp.Height > ancestor.Height() && cp.Height <= oldTail.Height() {
+			return ErrReorgBelowCheckpoint
+		}
+	}
+	return nil
+}
+
+// storeLocalCheckpoint records the canonical block LocalCheckpointDepth behind the tail,
+// every LocalCheckpointInterval heights.
+func (bc *BlockChain) storeLocalCheckpoint(tail *Block) {
+	if tail.Height() <= LocalCheckpointDepth {
+		return
+	}
+	height := tail.Height() - LocalCheckpointDepth
+	if height%LocalCheckpointInterval != 0 {
+		return
+	}
+	if local := bc.LocalCheckpoint(); local != nil && local.Height >= height {
+		return
+	}
+	block := tail.ChainHeader()
+	for block != nil && block.Height() > height {
+		block = bc.GetBlockHeader(block.ParentHash())
+	}
+	if block == nil {
+		return
+	}
+	data := append(byteutils.FromUint64(height), block.Hash()...)
+	if err := bc.storage.Put([]byte(LocalCheckpoint), data); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Error("Failed to store the local checkpoint.")
+		return
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"block": block,
+	}).Info("Stored the local checkpoint.")
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckpoint(t *testing.T) {
+	hash := "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+	cp, err := ParseCheckpoint("1024:" + hash)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1024), cp.Height)
+	assert.Equal(t, hash, cp.Hash.String())
+	assert.Equal(t, "1024:"+hash, cp.String())
+
+	for _, v := range []string{"", "1024", "0:" + hash, "x:" + hash, "1024:abcd", "1024:" + hash + ":1"} {
+		_, err := ParseCheckpoint(v)
+		assert.Equal(t, ErrInvalidCheckpoint, err, v)
+	}
+}
+
+func TestBlockChain_Checkpoint(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	var timestamp int64
+	mint := func(parent *Block) *Block {
+		timestamp += BlockInterval
+		coinbase := &Address{[]byte(fmt.Sprintf("0123456789012345678900%02d", timestamp))}
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		return block
+	}
+	/*
+		genesis -- 2 -- 3 -- 4
+		       \_ 2'    \_ 4'
+	*/
+	blocks := []*Block{bc.genesisBlock}
+	for i := 0; i < 3; i++ {
+		block := mint(blocks[len(blocks)-1])
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		assert.Nil(t, bc.SetTailBlock(block))
+		blocks = append(blocks, block)
+	}
+
+	assert.Equal(t, ErrCheckpointMismatch, bc.AddCheckpoint(&Checkpoint{Height: 3, Hash: blocks[1].Hash()}))
+	assert.Nil(t, bc.AddCheckpoint(&Checkpoint{Height: 3, Hash: blocks[2].Hash()}))
+	assert.Equal(t, ErrCheckpointMismatch, bc.AddCheckpoint(&Checkpoint{Height: 3, Hash: blocks[1].Hash()}))
+	assert.Equal(t, blocks[2].Hash(), bc.LatestCheckpoint().Hash)
+
+	// the blocks conflicting with checkpoints are rejected.
+	fork := mint(blocks[1])
+	assert.Equal(t, ErrCheckpointMismatch, bc.BlockPool().Push(BlockFromNetwork(fork)))
+
+	// the chain never reorganizes below the checkpoint.
+	fork = mint(blocks[0])
+	assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(fork)))
+	assert.Equal(t, ErrReorgBelowCheckpoint, bc.SetTailBlock(bc.GetBlock(fork.Hash())))
+	assert.Equal(t, blocks[3].Hash(), bc.TailBlock().Hash())
+
+	fork = mint(blocks[2])
+	assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(fork)))
+	assert.Nil(t, bc.SetTailBlock(bc.GetBlock(fork.Hash())))
+	assert.Equal(t, fork.Hash(), bc.TailBlock().Hash())
+
+	// the local checkpoint is listed with the others.
+	assert.Nil(t, bc.LocalCheckpoint())
+	assert.Nil(t, bc.storage.Put([]byte(LocalCheckpoint), append(byteutils.FromUint64(2), blocks[1].Hash()...)))
+	checkpoints := bc.Checkpoints()
+	assert.Equal(t, 2, len(checkpoints))
+	assert.Equal(t, blocks[1].Hash(), checkpoints[0].Hash)
+	assert.Equal(t, blocks[2].Hash(), checkpoints[1].Hash)
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/core/codec"
+	"github.com/nebulasio/go-nebulas/core/pb"
+)
+
+// EncodeBlock serializes the block by the codec.
+func EncodeBlock(block *Block, c codec.Codec) ([]byte, error) {
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return c.EncodeBlock(pbBlock.(*corepb.Block))
+}
+
+// DecodeBlock deserializes a block by the codec, the block is neither verified nor linked to its parent.
+func DecodeBlock(data []byte, c codec.Codec) (*Block, error) {
+	pbBlock, err := c.DecodeBlock(data)
+	if err != nil {
+		return nil, err
+	}
+	block := new(Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// EncodeTransaction serializes the transaction by the codec.
+func EncodeTransaction(tx *Transaction, c codec.Codec) ([]byte, error) {
+	pbTx, err := tx.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return c.EncodeTransaction(pbTx.(*corepb.Transaction))
+}
+
+// DecodeTransaction deserializes a transaction by the codec, the transaction is not verified.
+func DecodeTransaction(data []byte, c codec.Codec) (*Transaction, error) {
+	pbTx, err := c.DecodeTransaction(data)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(Transaction)
+	if err := tx.FromProto(pbTx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Package codec serializes blocks and transactions in corepb by a codec chosen by callers:
+//   - "protobuf", the encoding on the wire and in storage;
+//   - "json", the documented JSON representation of package core/pbjson, with hex hashes and decimal amounts;
+//   - "rlp", the recursive length prefix encoding of the fields in order of their protobuf numbers, see RLP.
+//
+// Every codec decodes what it encodes to the same message.
+package codec
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/pbjson"
+)
+
+// The names of codecs.
+const (
+	Protobuf = "protobuf"
+	JSON     = "json"
+	RLP      = "rlp"
+)
+
+// Errors
+var (
+	ErrUnknownCodec = errors.New("codec: unknown codec")
+)
+
+// Codec serializes blocks and transactions.
+type Codec interface {
+	// Name returns the name of codec.
+	Name() string
+
+	EncodeBlock(block *corepb.Block) ([]byte, error)
+	DecodeBlock(data []byte) (*corepb.Block, error)
+
+	EncodeTransaction(tx *corepb.Transaction) ([]byte, error)
+	DecodeTransaction(data []byte) (*corepb.Transaction, error)
+}
+
+var codecs = map[string]Codec{
+	Protobuf: protobufCodec{},
+	JSON:     jsonCodec{},
+	RLP:      rlpCodec{},
+}
+
+// Get returns the codec of the name.
+func Get(name string) (Codec, error) {
+	c, ok := codecs[name]
+	if !ok {
+		return nil, ErrUnknownCodec
+	}
+	return c, nil
+}
+
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string { return Protobuf }
+
+func (protobufCodec) EncodeBlock(block *corepb.Block) ([]byte, error) {
+	return proto.Marshal(block)
+}
+
+func (protobufCodec) DecodeBlock(data []byte) (*corepb.Block, error) {
+	block := new(corepb.Block)
+	if err := proto.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (protobufCodec) EncodeTransaction(tx *corepb.Transaction) ([]byte, error) {
+	return proto.Marshal(tx)
+}
+
+func (protobufCodec) DecodeTransaction(data []byte) (*corepb.Transaction, error) {
+	tx := new(corepb.Transaction)
+	if err := proto.Unmarshal(data, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return JSON }
+
+func (jsonCodec) EncodeBlock(block *corepb.Block) ([]byte, error) {
+	return pbjson.MarshalBlock(block)
+}
+
+func (jsonCodec) DecodeBlock(data []byte) (*corepb.Block, error) {
+	return pbjson.UnmarshalBlock(data)
+}
+
+func (jsonCodec) EncodeTransaction(tx *corepb.Transaction) ([]byte, error) {
+	return pbjson.MarshalTransaction(tx)
+}
+
+func (jsonCodec) DecodeTransaction(data []byte) (*corepb.Transaction, error) {
+	return pbjson.UnmarshalTransaction(data)
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package codec
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockAmount(v int64) []byte {
+	data, _ := util.NewUint128FromInt(v).ToFixedSizeByteSlice()
+	return data
+}
+
+func mockBlock() *corepb.Block {
+	tx := &corepb.Transaction{
+		Hash:       []byte{0x01, 0x02},
+		From:       []byte{0x0a, 0x0b},
+		To:         []byte{0x0c, 0x0d},
+		Value:      mockAmount(1000000),
+		Nonce:      3,
+		Timestamp:  1510000000,
+		Data:       &corepb.Data{Type: "binary", Payload: []byte("data")},
+		ChainId:    100,
+		GasPrice:   mockAmount(1),
+		GasLimit:   mockAmount(20000),
+		Alg:        1,
+		Sign:       []byte{0xff},
+		ValidUntil: 1510003600,
+	}
+	return &corepb.Block{
+		Header: &corepb.BlockHeader{
+			Hash:       []byte{0x11},
+			ParentHash: []byte{0x12},
+			Nonce:      1,
+			Coinbase:   []byte{0x13},
+			Timestamp:  1510000001,
+			ChainId:    100,
+			StateRoot:  []byte{0x14},
+			TxsRoot:    []byte{0x15},
+			EventsRoot: []byte{0x16},
+			DposContext: &corepb.DposContext{
+				DynastyRoot: []byte{0x17},
+				VoteRoot:    []byte{0x18},
+			},
+			GasUsed: mockAmount(20000),
+		},
+		Transactions: []*corepb.Transaction{tx, &corepb.Transaction{Nonce: 4, Timestamp: -1}},
+		Height:       7,
+	}
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	for _, name := range []string{Protobuf, JSON, RLP} {
+		t.Run(name, func(t *testing.T) {
+			c, err := Get(name)
+			assert.Nil(t, err)
+			assert.Equal(t, name, c.Name())
+
+			block := mockBlock()
+			data, err := c.EncodeBlock(block)
+			assert.Nil(t, err)
+			decoded, err := c.DecodeBlock(data)
+			assert.Nil(t, err)
+			assert.Equal(t, block, decoded)
+
+			tx := block.Transactions[0]
+			data, err = c.EncodeTransaction(tx)
+			assert.Nil(t, err)
+			decodedTx, err := c.DecodeTransaction(data)
+			assert.Nil(t, err)
+			assert.Equal(t, tx, decodedTx)
+
+			// the encoding is stable.
+			again, err := c.EncodeTransaction(decodedTx)
+			assert.Nil(t, err)
+			assert.Equal(t, data, again)
+		})
+	}
+
+	_, err := Get("xml")
+	assert.Equal(t, ErrUnknownCodec, err)
+}
+
+func TestRLP_Invalid(t *testing.T) {
+	c, _ := Get(RLP)
+	data, _ := rlp.Encode([]interface{}{[]byte{0x01}})
+	_, err := c.DecodeTransaction(data)
+	assert.Equal(t, rlp.ErrExpectedList, err)
+	_, err = c.DecodeBlock(data)
+	assert.Equal(t, rlp.ErrExpectedList, err)
+
+	item := txItem(mockBlock().Transactions[0])
+	item[4] = []byte{0x00, 0x01}
+	data, _ = rlp.Encode(item)
+	_, err = c.DecodeTransaction(data)
+	assert.Equal(t, rlp.ErrNonCanonical, err)
+
+	item = txItem(mockBlock().Transactions[0])
+	item[7] = rlp.Uint(1 << 32)
+	data, _ = rlp.Encode(item)
+	_, err = c.DecodeTransaction(data)
+	assert.Equal(t, rlp.ErrUintOverflow, err)
+}
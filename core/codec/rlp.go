@@ -0,0 +1,304 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package codec
+
+import (
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util/rlp"
+)
+
+// rlpCodec encodes a message as the list of its fields in order of protobuf numbers.
+// Bytes and strings are byte strings, amounts are the 16 bytes of uint128 as in protobuf.
+// Unsigned integers are rlp integers, int64 timestamps are the integers of their two's complement.
+// A message field is the list of its fields, or the empty list if absent. Repeated fields are lists.
+//
+//	Transaction: [hash, from, to, value, nonce, timestamp, [type, payload], chain_id, gas_price, gas_limit, alg, sign,
+//	             valid_until]
+//	DposContext: [dynasty_root, next_dynasty_root, delegate_root, candidate_root, vote_root, mint_cnt_root]
+//	BlockHeader: [hash, parent_hash, nonce, coinbase, timestamp, chain_id, alg, sign, state_root, txs_root,
+//	              events_root, DposContext, receipts_root, gas_limit, gas_used, event_bloom]
+//	Block:       [BlockHeader, [Transaction...], height]
+type rlpCodec struct{}
+
+func (rlpCodec) Name() string { return RLP }
+
+func (rlpCodec) EncodeBlock(block *corepb.Block) ([]byte, error) {
+	return rlp.Encode(blockItem(block))
+}
+
+func (rlpCodec) DecodeBlock(data []byte) (*corepb.Block, error) {
+	item, err := rlp.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return blockFromItem(item)
+}
+
+func (rlpCodec) EncodeTransaction(tx *corepb.Transaction) ([]byte, error) {
+	return rlp.Encode(txItem(tx))
+}
+
+func (rlpCodec) DecodeTransaction(data []byte) (*corepb.Transaction, error) {
+	item, err := rlp.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return txFromItem(item)
+}
+
+func bytesItem(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	return b
+}
+
+func txItem(tx *corepb.Transaction) []interface{} {
+	data := []interface{}{}
+	if tx.Data != nil {
+		data = []interface{}{[]byte(tx.Data.Type), bytesItem(tx.Data.Payload)}
+	}
+	return []interface{}{
+		bytesItem(tx.Hash),
+		bytesItem(tx.From),
+		bytesItem(tx.To),
+		bytesItem(tx.Value),
+		rlp.Uint(tx.Nonce),
+		rlp.Uint(uint64(tx.Timestamp)),
+		data,
+		rlp.Uint(uint64(tx.ChainId)),
+		bytesItem(tx.GasPrice),
+		bytesItem(tx.GasLimit),
+		rlp.Uint(uint64(tx.Alg)),
+		bytesItem(tx.Sign),
+		rlp.Uint(tx.ValidUntil),
+	}
+}
+
+func dposContextItem(ctx *corepb.DposContext) []interface{} {
+	if ctx == nil {
+		return []interface{}{}
+	}
+	return []interface{}{
+		bytesItem(ctx.DynastyRoot),
+		bytesItem(ctx.NextDynastyRoot),
+		bytesItem(ctx.DelegateRoot),
+		bytesItem(ctx.CandidateRoot),
+		bytesItem(ctx.VoteRoot),
+		bytesItem(ctx.MintCntRoot),
+	}
+}
+
+func headerItem(h *corepb.BlockHeader) []interface{} {
+	if h == nil {
+		return []interface{}{}
+	}
+	return []interface{}{
+		bytesItem(h.Hash),
+		bytesItem(h.ParentHash),
+		rlp.Uint(h.Nonce),
+		bytesItem(h.Coinbase),
+		rlp.Uint(uint64(h.Timestamp)),
+		rlp.Uint(uint64(h.ChainId)),
+		rlp.Uint(uint64(h.Alg)),
+		bytesItem(h.Sign),
+		bytesItem(h.StateRoot),
+		bytesItem(h.TxsRoot),
+		bytesItem(h.EventsRoot),
+		dposContextItem(h.DposContext),
+		bytesItem(h.ReceiptsRoot),
+		bytesItem(h.GasLimit),
+		bytesItem(h.GasUsed),
+		bytesItem(h.EventBloom),
+	}
+}
+
+func blockItem(block *corepb.Block) []interface{} {
+	txs := make([]interface{}, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		txs[i] = txItem(tx)
+	}
+	return []interface{}{headerItem(block.Header), txs, rlp.Uint(block.Height)}
+}
+
+// fields decodes the fields of a message, and collects the first error.
+type fields struct {
+	items []interface{}
+	err   error
+}
+
+// message returns the fields of a message, nil if the message is absent.
+func message(item interface{}, size int) (*fields, error) {
+	if l, ok := item.([]interface{}); ok && len(l) == 0 {
+		return nil, nil
+	}
+	items, err := rlp.List(item, size)
+	if err != nil {
+		return nil, err
+	}
+	return &fields{items: items}, nil
+}
+
+func (f *fields) bytes(i int) []byte {
+	if f.err != nil {
+		return nil
+	}
+	b, err := rlp.String(f.items[i])
+	if err != nil {
+		f.err = err
+		return nil
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+func (f *fields) uint(i int) uint64 {
+	b := f.bytes(i)
+	if f.err != nil {
+		return 0
+	}
+	v, err := rlp.ToUint(b)
+	if err != nil {
+		f.err = err
+	}
+	return v
+}
+
+func (f *fields) uint32(i int) uint32 {
+	v := f.uint(i)
+	if v > 1<<32-1 && f.err == nil {
+		f.err = rlp.ErrUintOverflow
+	}
+	return uint32(v)
+}
+
+func txFromItem(item interface{}) (*corepb.Transaction, error) {
+	f, err := message(item, 13)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, rlp.ErrExpectedList
+	}
+	tx := &corepb.Transaction{
+		Hash:       f.bytes(0),
+		From:       f.bytes(1),
+		To:         f.bytes(2),
+		Value:      f.bytes(3),
+		Nonce:      f.uint(4),
+		Timestamp:  int64(f.uint(5)),
+		ChainId:    f.uint32(7),
+		GasPrice:   f.bytes(8),
+		GasLimit:   f.bytes(9),
+		Alg:        f.uint32(10),
+		Sign:       f.bytes(11),
+		ValidUntil: f.uint(12),
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	data, err := message(f.items[6], 2)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		tx.Data = &corepb.Data{Type: string(data.bytes(0)), Payload: data.bytes(1)}
+		if data.err != nil {
+			return nil, data.err
+		}
+	}
+	return tx, nil
+}
+
+func headerFromItem(item interface{}) (*corepb.BlockHeader, error) {
+	f, err := message(item, 16)
+	if f == nil || err != nil {
+		return nil, err
+	}
+	h := &corepb.BlockHeader{
+		Hash:         f.bytes(0),
+		ParentHash:   f.bytes(1),
+		Nonce:        f.uint(2),
+		Coinbase:     f.bytes(3),
+		Timestamp:    int64(f.uint(4)),
+		ChainId:      f.uint32(5),
+		Alg:          f.uint32(6),
+		Sign:         f.bytes(7),
+		StateRoot:    f.bytes(8),
+		TxsRoot:      f.bytes(9),
+		EventsRoot:   f.bytes(10),
+		ReceiptsRoot: f.bytes(12),
+		GasLimit:     f.bytes(13),
+		GasUsed:      f.bytes(14),
+		EventBloom:   f.bytes(15),
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	ctx, err := message(f.items[11], 6)
+	if err != nil {
+		return nil, err
+	}
+	if ctx != nil {
+		h.DposContext = &corepb.DposContext{
+			DynastyRoot:     ctx.bytes(0),
+			NextDynastyRoot: ctx.bytes(1),
+			DelegateRoot:    ctx.bytes(2),
+			CandidateRoot:   ctx.bytes(3),
+			VoteRoot:        ctx.bytes(4),
+			MintCntRoot:     ctx.bytes(5),
+		}
+		if ctx.err != nil {
+			return nil, ctx.err
+		}
+	}
+	return h, nil
+}
+
+func blockFromItem(item interface{}) (*corepb.Block, error) {
+	f, err := message(item, 3)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, rlp.ErrExpectedList
+	}
+	header, err := headerFromItem(f.items[0])
+	if err != nil {
+		return nil, err
+	}
+	block := &corepb.Block{Header: header, Height: f.uint(2)}
+	if f.err != nil {
+		return nil, f.err
+	}
+	txs, ok := f.items[1].([]interface{})
+	if !ok {
+		return nil, rlp.ErrExpectedList
+	}
+	for _, v := range txs {
+		tx, err := txFromItem(v)
+		if err != nil {
+			return nil, err
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+	return block, nil
+}
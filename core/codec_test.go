@@ -0,0 +1,58 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeBlock(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := mockAddress()
+	txs := mockSignedTransactions(bc.ChainID(), 2)
+
+	block, err := NewBlock(bc.ChainID(), coinbase, bc.tailBlock)
+	assert.Nil(t, err)
+	block.transactions = txs
+	block.header.timestamp = BlockInterval
+	block.header.hash = HashBlock(block)
+
+	for _, name := range []string{codec.Protobuf, codec.JSON, codec.RLP} {
+		cc, _ := codec.Get(name)
+		data, err := EncodeBlock(block, cc)
+		assert.Nil(t, err)
+		decoded, err := DecodeBlock(data, cc)
+		assert.Nil(t, err)
+		assert.Equal(t, block.Hash(), decoded.Hash())
+		assert.Equal(t, block.Hash(), HashBlock(decoded))
+		assert.Equal(t, 2, len(decoded.transactions))
+
+		data, err = EncodeTransaction(txs[1], cc)
+		assert.Nil(t, err)
+		tx, err := DecodeTransaction(data, cc)
+		assert.Nil(t, err)
+		assert.Nil(t, tx.VerifyIntegrity(bc.ChainID()))
+		assert.Equal(t, txs[1].Hash(), tx.Hash())
+	}
+}
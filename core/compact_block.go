@@ -0,0 +1,328 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxPendingCompactBlocks is the count of compact blocks waiting for their missing transactions.
+const MaxPendingCompactBlocks = 64
+
+// Errors of compact block relay
+var (
+	ErrInvalidCompactBlock = errors.New("invalid compact block")
+	ErrBlockNotFound       = errors.New("block not found")
+)
+
+var (
+	compactBlockReconstructed = metrics.GetOrRegisterMeter("neb.block.compact.reconstructed", nil)
+	compactBlockMissingTx     = metrics.GetOrRegisterMeter("neb.block.compact.missing", nil)
+	compactBlockFallback      = metrics.GetOrRegisterMeter("neb.block.compact.fallback", nil)
+)
+
+// compactBlock announces a block by its header and transaction hashes,
+// receivers reconstruct the block with the transactions in their pools.
+type compactBlock struct {
+	header *corepb.LightHeader
+}
+
+// ToProto converts the compact block to proto message.
+func (c *compactBlock) ToProto() (proto.Message, error) {
+	return c.header, nil
+}
+
+// FromProto converts proto message to the compact block.
+func (c *compactBlock) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.LightHeader); ok {
+		c.header = msg
+		return nil
+	}
+	return errors.New("Protobuf message cannot be converted into CompactBlock")
+}
+
+// pendingCompact is a compact block waiting for the transactions missing in pool from its sender.
+type pendingCompact struct {
+	header  *corepb.LightHeader
+	txs     []*corepb.Transaction
+	missing []uint32
+	sender  string
+	// fallback is the id of the full block request, 0 if not requested.
+	fallback uint64
+}
+
+// SetCompactRelay sets whether the blocks are relayed as compact blocks.
+func (pool *BlockPool) SetCompactRelay(enabled bool) {
+	pool.compactRelay = enabled
+}
+
+// distribute broadcasts or relays the block, as a compact block if enabled.
+func (pool *BlockPool) distribute(block *Block, relay bool) {
+	if !pool.compactRelay {
+		if relay {
+			pool.nm.Relay(MessageTypeNewBlock, block)
+		} else {
+			pool.nm.Broadcast(MessageTypeNewBlock, block)
+		}
+		return
+	}
+	header, err := block.LightHeader()
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Error("Failed to build compact block.")
+		return
+	}
+	if relay {
+		pool.nm.Relay(MessageTypeCompactBlock, &compactBlock{header})
+	} else {
+		pool.nm.Broadcast(MessageTypeCompactBlock, &compactBlock{header})
+	}
+}
+
+func (pool *BlockPool) handleCompactMessage(msg net.Message) {
+	var err error
+	switch msg.MessageType() {
+	case MessageTypeCompactBlock:
+		err = pool.handleCompactBlock(msg)
+	case MessageTypeGetTxs:
+		err = pool.handleGetTxs(msg)
+	case MessageTypeTxs:
+		err = pool.handleTxs(msg)
+	case MessageTypeBlocksReply:
+		err = pool.handleBlocksReply(msg)
+	}
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"from":    msg.MessageFrom(),
+			"err":     err,
+		}).Debug("Failed to handle compact block message.")
+	}
+}
+
+func (pool *BlockPool) knownBlock(hash byteutils.Hash) bool {
+	return pool.cache.Contains(hash.Hex()) || pool.bc.GetBlock(hash) != nil
+}
+
+// findBlock returns the block in pool or chain.
+func (pool *BlockPool) findBlock(hash byteutils.Hash) *Block {
+	if v, ok := pool.cache.Get(hash.Hex()); ok {
+		return v.(*linkedBlock).block
+	}
+	return pool.bc.GetBlock(hash)
+}
+
+// handleCompactBlock reconstructs the block with the transactions in pool, and asks the sender for the missing ones.
+func (pool *BlockPool) handleCompactBlock(msg net.Message) error {
+	header := new(corepb.LightHeader)
+	if err := proto.Unmarshal(msg.Data().([]byte), header); err != nil {
+		return err
+	}
+	if err := VerifyLightHeader(header); err != nil {
+		return err
+	}
+	hash := byteutils.Hash(header.Header.Hash)
+	if pool.knownBlock(hash) || pool.pendingCompact.Contains(hash.Hex()) {
+		return nil
+	}
+	if diff := time.Now().Unix() - header.Header.Timestamp; int64(math.Abs(float64(diff))) > AcceptedNetWorkDelay {
+		return ErrInvalidCompactBlock
+	}
+	return pool.acceptCompactBlock(msg.MessageFrom(), header)
+}
+
+func (pool *BlockPool) acceptCompactBlock(sender string, header *corepb.LightHeader) error {
+	hash := byteutils.Hash(header.Header.Hash)
+	pending := &pendingCompact{
+		header: header,
+		txs:    make([]*corepb.Transaction, len(header.TxHashes)),
+		sender: sender,
+	}
+	for i, h := range header.TxHashes {
+		tx := pool.bc.txPool.GetTransaction(h)
+		if tx == nil {
+			pending.missing = append(pending.missing, uint32(i))
+			continue
+		}
+		pbTx, err := tx.ToProto()
+		if err != nil {
+			return err
+		}
+		pending.txs[i] = pbTx.(*corepb.Transaction)
+	}
+	if len(pending.missing) == 0 {
+		return pool.reconstruct(pending)
+	}
+
+	compactBlockMissingTx.Mark(int64(len(pending.missing)))
+	pool.pendingCompact.Add(hash.Hex(), pending)
+	data, err := proto.Marshal(&corepb.GetTxs{BlockHash: hash, Indexes: pending.missing})
+	if err != nil {
+		return err
+	}
+	return pool.nm.SendMsg(MessageTypeGetTxs, data, pending.sender)
+}
+
+// handleGetTxs replies the transactions at the indexes of a block.
+func (pool *BlockPool) handleGetTxs(msg net.Message) error {
+	req := new(corepb.GetTxs)
+	if err := proto.Unmarshal(msg.Data().([]byte), req); err != nil {
+		return err
+	}
+	block := pool.findBlock(req.BlockHash)
+	if block == nil {
+		return ErrBlockNotFound
+	}
+	reply := &corepb.Txs{BlockHash: req.BlockHash}
+	for _, index := range req.Indexes {
+		if int(index) >= len(block.transactions) {
+			return ErrInvalidCompactBlock
+		}
+		pbTx, err := block.transactions[index].ToProto()
+		if err != nil {
+			return err
+		}
+		reply.Transactions = append(reply.Transactions, pbTx.(*corepb.Transaction))
+	}
+	data, err := proto.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	return pool.nm.SendMsg(MessageTypeTxs, data, msg.MessageFrom())
+}
+
+// handleTxs fills the missing transactions of a pending compact block, the full block is requested
+// if the reply doesn't match.
+func (pool *BlockPool) handleTxs(msg net.Message) error {
+	reply := new(corepb.Txs)
+	if err := proto.Unmarshal(msg.Data().([]byte), reply); err != nil {
+		return err
+	}
+	v, ok := pool.pendingCompact.Get(byteutils.Hash(reply.BlockHash).Hex())
+	if !ok {
+		return nil
+	}
+	pending := v.(*pendingCompact)
+	if pending.sender != msg.MessageFrom() || pending.fallback != 0 {
+		return nil
+	}
+	if len(reply.Transactions) != len(pending.missing) {
+		return pool.requestFullBlock(pending)
+	}
+	for i, index := range pending.missing {
+		pbTx := reply.Transactions[i]
+		tx := new(Transaction)
+		if err := tx.FromProto(pbTx); err != nil || !byteutils.Equal(tx.hash, pending.header.TxHashes[index]) ||
+			tx.VerifyIntegrity(pool.bc.chainID) != nil {
+			return pool.requestFullBlock(pending)
+		}
+		pending.txs[index] = pbTx
+	}
+	pool.pendingCompact.Remove(byteutils.Hash(reply.BlockHash).Hex())
+	return pool.reconstruct(pending)
+}
+
+// reconstruct pushes the block rebuilt from the compact block and transactions.
+func (pool *BlockPool) reconstruct(pending *pendingCompact) error {
+	block := new(Block)
+	if err := block.FromProto(&corepb.Block{
+		Header:       pending.header.Header,
+		Transactions: pending.txs,
+		Height:       pending.header.Height,
+	}); err != nil {
+		return err
+	}
+	compactBlockReconstructed.Mark(1)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"block": block,
+		"from":  pending.sender,
+	}).Info("Reconstructed a compact block.")
+	return pool.PushAndRelay(pending.sender, block)
+}
+
+// requestFullBlock asks the sender of compact block for the full block.
+func (pool *BlockPool) requestFullBlock(pending *pendingCompact) error {
+	compactBlockFallback.Mark(1)
+	pool.compactRequestID++
+	pending.fallback = pool.compactRequestID
+	data, err := proto.Marshal(&corepb.GetBlocksByHashList{
+		Id:     pending.fallback,
+		Hashes: [][]byte{pending.header.Header.Hash},
+	})
+	if err != nil {
+		return err
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"hash": byteutils.Hex(pending.header.Header.Hash),
+		"from": pending.sender,
+	}).Warn("Failed to reconstruct a compact block, request the full block.")
+	return pool.nm.SendMsg(MessageTypeGetBlocksByHash, data, pending.sender)
+}
+
+// handleBlocksReply pushes the full block requested for a compact block.
+func (pool *BlockPool) handleBlocksReply(msg net.Message) error {
+	reply := new(corepb.BlocksReply)
+	if err := proto.Unmarshal(msg.Data().([]byte), reply); err != nil {
+		return err
+	}
+	for _, pbBlock := range reply.Blocks {
+		if pbBlock.Header == nil {
+			continue
+		}
+		// the blocks re-fetched for the corrupted ones.
+		if ok, err := pool.bc.integrity.repair(pbBlock); ok {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		key := byteutils.Hash(pbBlock.Header.Hash).Hex()
+		v, ok := pool.pendingCompact.Get(key)
+		if !ok {
+			continue
+		}
+		pending := v.(*pendingCompact)
+		if pending.fallback != reply.Id || pending.sender != msg.MessageFrom() {
+			continue
+		}
+		pool.pendingCompact.Remove(key)
+
+		block := new(Block)
+		if err := block.FromProto(pbBlock); err != nil {
+			return err
+		}
+		if err := pool.PushAndRelay(pending.sender, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type sentMessage struct {
+	name string
+	data []byte
+}
+
+type mockCompactNetManager struct {
+	MockNetManager
+	sent []sentMessage
+}
+
+func (n *mockCompactNetManager) SendMsg(name string, msg []byte, target string) error {
+	n.sent = append(n.sent, sentMessage{name, msg})
+	return nil
+}
+
+func (n *mockCompactNetManager) last() sentMessage {
+	return n.sent[len(n.sent)-1]
+}
+
+func TestBlockPool_CompactBlock(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+	nm := new(mockCompactNetManager)
+	bc.bkPool.RegisterInNetwork(nm)
+	bc.SetConsensusHandler(&MockConsensus{neb.storage})
+	pool := bc.bkPool
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	bc.tailBlock.header.stateRoot = bc.tailBlock.accState.RootHash()
+	bc.tailBlock.commit()
+	bc.storeBlockToStorage(bc.tailBlock)
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	mint := func(parent *Block, validator []byte, nonce uint64) *Block {
+		tx := NewTransaction(bc.ChainID(), from, from, util.NewUint128FromInt(1), nonce, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+		assert.Nil(t, tx.Sign(signature))
+		assert.Nil(t, bc.txPool.Push(tx))
+		addr := &Address{validator}
+		block, err := NewBlock(bc.ChainID(), addr, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = parent.header.timestamp + BlockInterval
+		block.CollectTransactions(1)
+		block.SetMiner(addr)
+		assert.Nil(t, block.Seal())
+		assert.Equal(t, 1, len(block.transactions))
+		return block
+	}
+	compact := func(block *Block) *corepb.LightHeader {
+		header, err := block.LightHeader()
+		assert.Nil(t, err)
+		return header
+	}
+
+	// the transaction of block was popped from pool, so it's requested from the sender.
+	block1 := mint(bc.tailBlock, validators[1], 1)
+	// the compact block announced long ago is rejected.
+	assert.Equal(t, ErrInvalidCompactBlock, pool.handleCompactBlock(mockMessage(t, MessageTypeCompactBlock, compact(block1))))
+	assert.Nil(t, pool.acceptCompactBlock("peer", compact(block1)))
+	assert.True(t, pool.pendingCompact.Contains(block1.Hash().Hex()))
+	assert.Equal(t, MessageTypeGetTxs, nm.last().name)
+	req := new(corepb.GetTxs)
+	assert.Nil(t, proto.Unmarshal(nm.last().data, req))
+	assert.Equal(t, []uint32{0}, req.Indexes)
+
+	pbTx, _ := block1.transactions[0].ToProto()
+	assert.Nil(t, pool.handleTxs(mockMessage(t, MessageTypeTxs, &corepb.Txs{
+		BlockHash:    block1.Hash(),
+		Transactions: []*corepb.Transaction{pbTx.(*corepb.Transaction)},
+	})))
+	assert.False(t, pool.pendingCompact.Contains(block1.Hash().Hex()))
+	assert.NotNil(t, bc.GetBlock(block1.Hash()))
+
+	// the peers asking for the transactions are served.
+	assert.Nil(t, pool.handleGetTxs(mockMessage(t, MessageTypeGetTxs, req)))
+	assert.Equal(t, MessageTypeTxs, nm.last().name)
+	reply := new(corepb.Txs)
+	assert.Nil(t, proto.Unmarshal(nm.last().data, reply))
+	assert.Equal(t, []byte(block1.transactions[0].Hash()), reply.Transactions[0].Hash)
+	req.Indexes = []uint32{1}
+	assert.Equal(t, ErrInvalidCompactBlock, pool.handleGetTxs(mockMessage(t, MessageTypeGetTxs, req)))
+
+	// the full block is requested if the transactions replied don't match.
+	block2 := mint(block1, validators[2], 2)
+	assert.Nil(t, pool.acceptCompactBlock("peer", compact(block2)))
+	assert.Nil(t, pool.handleTxs(mockMessage(t, MessageTypeTxs, &corepb.Txs{
+		BlockHash:    block2.Hash(),
+		Transactions: []*corepb.Transaction{pbTx.(*corepb.Transaction)},
+	})))
+	assert.Equal(t, MessageTypeGetBlocksByHash, nm.last().name)
+	fallback := new(corepb.GetBlocksByHashList)
+	assert.Nil(t, proto.Unmarshal(nm.last().data, fallback))
+
+	pbBlock, _ := block2.ToProto()
+	assert.Nil(t, pool.handleBlocksReply(mockMessage(t, MessageTypeBlocksReply, &corepb.BlocksReply{
+		Id:     fallback.Id,
+		Blocks: []*corepb.Block{pbBlock.(*corepb.Block)},
+	})))
+	assert.NotNil(t, bc.GetBlock(block2.Hash()))
+}
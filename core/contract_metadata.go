@@ -0,0 +1,79 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+)
+
+// MaxContractMetadataLength is the max length of the metadata of a contract in bytes.
+const MaxContractMetadataLength = 16 * 1024
+
+// the reserved account whose storage trie is the registry of the metadata of contracts, so the metadata
+// is verified in the state as any other account.
+var contractRegistryAddress, _ = NewContractAddressFromHash(hash.Sha3256([]byte("nebulas.contract.registry")))
+
+func contractMetadataKey(contract *Address) []byte {
+	return trie.HashDomains("contract", "metadata", contract.String())
+}
+
+// checkContractMetadata returns nil if the metadata is empty or a JSON object in MaxContractMetadataLength.
+func checkContractMetadata(metadata string) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+	if len(metadata) > MaxContractMetadataLength {
+		return ErrInvalidContractMetadata
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(metadata), &object); err != nil || object == nil {
+		return ErrInvalidContractMetadata
+	}
+	return nil
+}
+
+// setContractMetadata registers the metadata of contract, replacing the previous.
+func setContractMetadata(accState state.AccountState, contract *Address, metadata string) error {
+	registry := accState.GetOrCreateUserAccount(contractRegistryAddress.Bytes())
+	return registry.Put(contractMetadataKey(contract), []byte(metadata))
+}
+
+// GetContractMetadata returns the metadata the contract is deployed or last upgraded with in the states of the block.
+func (block *Block) GetContractMetadata(contract *Address) (string, error) {
+	registry, err := block.accState.GetContractAccount(contractRegistryAddress.Bytes())
+	if err == state.ErrAccountNotFound {
+		return "", ErrContractMetadataNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	metadata, err := registry.Get(contractMetadataKey(contract))
+	if err == storage.ErrKeyNotFound {
+		return "", ErrContractMetadataNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(metadata), nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckContractMetadata(t *testing.T) {
+	tests := []struct {
+		metadata string
+		err      error
+	}{
+		{"", nil},
+		{`{"functions":[{"name":"transfer","args":[{"name":"to","type":"address"}]}]}`, nil},
+		{`{}`, nil},
+		{`null`, ErrInvalidContractMetadata},
+		{`[]`, ErrInvalidContractMetadata},
+		{`{"name":"token"} {}`, ErrInvalidContractMetadata},
+		{`{"name":"` + strings.Repeat("a", MaxContractMetadataLength) + `"}`, ErrInvalidContractMetadata},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.err, checkContractMetadata(tt.metadata), tt.metadata)
+	}
+
+	payload := NewDeployPayload(upgradeTestSource, "js", "")
+	payload.Metadata = "abi"
+	data, _ := payload.ToBytes()
+	_, err := LoadDeployPayload(data)
+	assert.Equal(t, ErrInvalidContractMetadata, err)
+}
+
+func TestBlock_GetContractMetadata(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.begin()
+
+	owner := mockAddress()
+	block.accState.GetOrCreateUserAccount(owner.Bytes()).AddBalance(util.NewUint128FromInt(1000000000000))
+	nonce := uint64(0)
+	execute := func(to *Address, payloadType string, payload []byte) *Transaction {
+		nonce++
+		tx := NewTransaction(bc.ChainID(), owner, to, util.NewUint128(), nonce, payloadType, payload, TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.hash, _ = HashTransaction(tx)
+		_, err := block.executeTransaction(tx)
+		assert.Nil(t, err)
+		events, _ := block.FetchEvents(tx.hash)
+		assert.Equal(t, TopicExecuteTxSuccess, events[len(events)-1].Topic)
+		return tx
+	}
+
+	_, err = block.GetContractMetadata(mockAddress())
+	assert.Equal(t, ErrContractMetadataNotFound, err)
+
+	metadata := `{"functions":[{"name":"init","args":[]}]}`
+	deploy := NewDeployPayload(upgradeTestSource, "js", "")
+	deploy.Upgradable, deploy.Metadata = true, metadata
+	data, _ := deploy.ToBytes()
+	contract, _ := execute(owner, TxPayloadDeployType, data).GenerateContractAddress()
+	value, err := block.GetContractMetadata(contract)
+	assert.Nil(t, err)
+	assert.Equal(t, metadata, value)
+
+	// the metadata is kept by an upgrade without it, and replaced by one with it.
+	data, _ = NewUpgradePayload(upgradeTestSource, "js").ToBytes()
+	execute(contract, TxPayloadUpgradeType, data)
+	value, _ = block.GetContractMetadata(contract)
+	assert.Equal(t, metadata, value)
+
+	upgraded := `{"functions":[{"name":"init","args":[]},{"name":"name","args":[]}]}`
+	upgrade := NewUpgradePayload(upgradeTestSource, "js")
+	upgrade.Metadata = upgraded
+	data, _ = upgrade.ToBytes()
+	execute(contract, TxPayloadUpgradeType, data)
+	value, _ = block.GetContractMetadata(contract)
+	assert.Equal(t, upgraded, value)
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxSignedSlots is the count of recent (miner, slot) records kept to detect double signing.
+const MaxSignedSlots = 1024
+
+// Errors of double signing
+var (
+	ErrDoubleSign                = errors.New("block double signed by the miner at the slot")
+	ErrInvalidDoubleSignEvidence = errors.New("invalid double sign evidence")
+)
+
+// signedSlotKey is the key of the block signed by miner at the slot.
+func signedSlotKey(miner *Address, slot int64) string {
+	return miner.String() + ":" + strconv.FormatInt(slot, 10)
+}
+
+// signer recovers the address signed the block hash.
+func signer(header *corepb.BlockHeader) (*Address, error) {
+	signature, err := crypto.NewSignature(keystore.Algorithm(header.Alg))
+	if err != nil {
+		return nil, err
+	}
+	pub, err := signature.RecoverPublic(header.Hash, header.Sign)
+	if err != nil {
+		return nil, err
+	}
+	pubdata, err := pub.Encoded()
+	if err != nil {
+		return nil, err
+	}
+	return NewAddressFromPublicKey(pubdata)
+}
+
+// NewDoubleSignEvidence returns the evidence of two blocks signed by the same miner at the same slot.
+func NewDoubleSignEvidence(first *Block, second *Block) (*corepb.DoubleSignEvidence, error) {
+	a, err := first.LightHeader()
+	if err != nil {
+		return nil, err
+	}
+	b, err := second.LightHeader()
+	if err != nil {
+		return nil, err
+	}
+	evidence := &corepb.DoubleSignEvidence{First: a, Second: b}
+	if _, err := VerifyDoubleSignEvidence(first.ChainID(), evidence); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+// VerifyDoubleSignEvidence checks the two headers of evidence are different blocks of the chain
+// at the same slot, and signed by the same miner, which is returned.
+func VerifyDoubleSignEvidence(chainID uint32, evidence *corepb.DoubleSignEvidence) (*Address, error) {
+	if evidence.First == nil || evidence.Second == nil {
+		return nil, ErrInvalidDoubleSignEvidence
+	}
+	if err := VerifyLightHeader(evidence.First); err != nil {
+		return nil, err
+	}
+	if err := VerifyLightHeader(evidence.Second); err != nil {
+		return nil, err
+	}
+	a, b := evidence.First.Header, evidence.Second.Header
+	if a.ChainId != chainID || b.ChainId != chainID {
+		return nil, ErrInvalidChainID
+	}
+	if a.Timestamp != b.Timestamp || byteutils.Equal(a.Hash, b.Hash) {
+		return nil, ErrInvalidDoubleSignEvidence
+	}
+	miner, err := signer(a)
+	if err != nil {
+		return nil, err
+	}
+	other, err := signer(b)
+	if err != nil {
+		return nil, err
+	}
+	if !miner.Equals(other) {
+		return nil, ErrInvalidDoubleSignEvidence
+	}
+	return miner, nil
+}
+
+// checkDoubleSign records the block signed by its miner at the slot, and reports the evidence
+// if the miner has signed another block at the slot.
+func (pool *BlockPool) checkDoubleSign(block *Block) error {
+	miner := block.Miner()
+	if miner == nil {
+		// the proposer is unknown if the block is too far from tail, recover it from the sign.
+		header, err := block.header.ToProto()
+		if err != nil {
+			return err
+		}
+		if miner, err = signer(header.(*corepb.BlockHeader)); err != nil {
+			return err
+		}
+	}
+	key := signedSlotKey(miner, block.Timestamp())
+	v, ok := pool.signed.Get(key)
+	if !ok {
+		pool.signed.Add(key, block.Hash())
+		return nil
+	}
+	if v.(byteutils.Hash).Equals(block.Hash()) {
+		return nil
+	}
+
+	doubleSignCounter.Inc(1)
+	logging.VLog().WithFields(logrus.Fields{
+		"miner": miner.String(),
+		"slot":  block.Timestamp(),
+		"first": v.(byteutils.Hash).Hex(),
+		"block": block,
+	}).Warn("Found a block double signed by the miner.")
+
+	if first := pool.findBlock(v.(byteutils.Hash)); first != nil {
+		evidence, err := NewDoubleSignEvidence(first, block)
+		if err != nil {
+			return err
+		}
+		pool.bc.reportDoubleSign(miner, evidence)
+	}
+	return ErrDoubleSign
+}
+
+// reportDoubleSign notifies the subscribers of TopicDoubleSign with the evidence in hex,
+// which can be sent in a slash transaction.
+func (bc *BlockChain) reportDoubleSign(miner *Address, evidence *corepb.DoubleSignEvidence) {
+	if bc.eventEmitter == nil {
+		return
+	}
+	bytes, err := proto.Marshal(evidence)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"miner":    miner.String(),
+		"slot":     evidence.First.Header.Timestamp,
+		"evidence": byteutils.Hex(bytes),
+	})
+	if err != nil {
+		return
+	}
+	bc.eventEmitter.Trigger(&Event{
+		Topic: TopicDoubleSign,
+		Data:  string(data),
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockSignedBlock returns a block at the slot signed by the miner.
+func mockSignedBlock(t *testing.T, bc *BlockChain, miner *Address, slot int64) *Block {
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.header.timestamp = slot
+	block.CollectTransactions(0)
+	block.SetMiner(miner)
+	assert.Nil(t, block.Seal())
+	key, _ := keystore.DefaultKS.GetUnlocked(miner.String())
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(key.(keystore.PrivateKey))
+	assert.Nil(t, block.Sign(signature))
+	return block
+}
+
+func TestDoubleSignEvidence(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	miner := mockAddress()
+
+	a := mockSignedBlock(t, bc, miner, BlockInterval)
+	b := mockSignedBlock(t, bc, miner, BlockInterval)
+	evidence, err := NewDoubleSignEvidence(a, b)
+	assert.Nil(t, err)
+	got, err := VerifyDoubleSignEvidence(bc.ChainID(), evidence)
+	assert.Nil(t, err)
+	assert.Equal(t, miner, got)
+	_, err = VerifyDoubleSignEvidence(bc.ChainID()+1, evidence)
+	assert.Equal(t, ErrInvalidChainID, err)
+
+	// the same block, blocks at different slots or by different miners are no evidence.
+	_, err = NewDoubleSignEvidence(a, a)
+	assert.Equal(t, ErrInvalidDoubleSignEvidence, err)
+	_, err = NewDoubleSignEvidence(a, mockSignedBlock(t, bc, miner, 2*BlockInterval))
+	assert.Equal(t, ErrInvalidDoubleSignEvidence, err)
+	_, err = NewDoubleSignEvidence(a, mockSignedBlock(t, bc, mockAddress(), BlockInterval))
+	assert.Equal(t, ErrInvalidDoubleSignEvidence, err)
+
+	// the header tampered to another slot.
+	tampered := proto.Clone(evidence).(*corepb.DoubleSignEvidence)
+	tampered.Second.Header.Timestamp++
+	_, err = VerifyDoubleSignEvidence(bc.ChainID(), tampered)
+	assert.Equal(t, ErrInvalidBlockHash, err)
+}
+
+func TestBlockPool_DoubleSign(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	pool := bc.BlockPool()
+	miner := mockAddress()
+
+	a := mockSignedBlock(t, bc, miner, BlockInterval)
+	b := mockSignedBlock(t, bc, miner, BlockInterval)
+	assert.Nil(t, pool.checkDoubleSign(a))
+	assert.Nil(t, pool.checkDoubleSign(a))
+	assert.Equal(t, ErrDoubleSign, pool.checkDoubleSign(b))
+	assert.Nil(t, pool.checkDoubleSign(mockSignedBlock(t, bc, mockAddress(), BlockInterval)))
+	assert.Nil(t, pool.checkDoubleSign(mockSignedBlock(t, bc, miner, 2*BlockInterval)))
+}
+
+func TestSlashPayload_Execute(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	miner, poor, reporter := mockAddress(), mockAddress(), mockAddress()
+
+	evidence, err := NewDoubleSignEvidence(mockSignedBlock(t, bc, miner, BlockInterval), mockSignedBlock(t, bc, miner, BlockInterval))
+	assert.Nil(t, err)
+	payload, err := NewSlashPayload(evidence)
+	assert.Nil(t, err)
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	got, err := LoadSlashPayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, got)
+
+	evidence, err = NewDoubleSignEvidence(mockSignedBlock(t, bc, poor, BlockInterval), mockSignedBlock(t, bc, poor, BlockInterval))
+	assert.Nil(t, err)
+	payload, err = NewSlashPayload(evidence)
+	assert.Nil(t, err)
+	poorData, err := payload.ToBytes()
+	assert.Nil(t, err)
+
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.header.timestamp = 2 * BlockInterval
+	block.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(MinSlashBond.Int, util.NewUint128FromInt(20).Int))
+	block.accState.GetOrCreateUserAccount(miner.Bytes()).AddBalance(balance)
+	block.accState.GetOrCreateUserAccount(poor.Bytes()).AddBalance(util.NewUint128FromInt(1000000))
+	block.accState.GetOrCreateUserAccount(reporter.Bytes()).AddBalance(util.NewUint128FromInt(1000000000000))
+
+	nonce := uint64(0)
+	execute := func(data []byte) []string {
+		nonce++
+		tx := NewTransaction(bc.ChainID(), reporter, reporter, util.NewUint128(), nonce, TxPayloadSlashType, data, TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.hash, _ = HashTransaction(tx)
+		_, err := block.executeTransaction(tx)
+		assert.Nil(t, err)
+		events, err := block.FetchEvents(tx.hash)
+		assert.Nil(t, err)
+		var topics []string
+		for _, e := range events {
+			topics = append(topics, e.Topic)
+		}
+		return topics
+	}
+
+	// the miner isn't a validator of the dynasty.
+	assert.Equal(t, []string{TopicExecuteTxFailed}, execute(data))
+
+	validator := miner.Bytes()
+	_, err = block.dposContext.dynastyTrie.Put(validator, validator)
+	assert.Nil(t, err)
+	_, err = block.dposContext.candidateTrie.Put(validator, validator)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{TopicValidatorSlashed, TopicExecuteTxSuccess}, execute(data))
+	left := util.NewUint128().Mul(MinSlashBond.Int, util.NewUint128FromInt(18).Int)
+	assert.Equal(t, left.String(), block.accState.GetOrCreateUserAccount(validator).Balance().String())
+	_, err = block.dposContext.dynastyTrie.Get(validator)
+	assert.NotNil(t, err)
+	_, err = block.dposContext.candidateTrie.Get(validator)
+	assert.NotNil(t, err)
+
+	// the validator is slashed once.
+	assert.Equal(t, []string{TopicExecuteTxFailed}, execute(data))
+
+	// the bond of a validator is at least MinSlashBond, all its balance if less.
+	_, err = block.dposContext.dynastyTrie.Put(poor.Bytes(), poor.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{TopicValidatorSlashed, TopicExecuteTxSuccess}, execute(poorData))
+	assert.Equal(t, "0", block.accState.GetOrCreateUserAccount(poor.Bytes()).Balance().String())
+
+	// the evidence of the last dynasty is stale.
+	_, err = block.dposContext.dynastyTrie.Put(validator, validator)
+	assert.Nil(t, err)
+	block.header.timestamp = DynastyInterval + BlockInterval
+	assert.Equal(t, []string{TopicExecuteTxFailed}, execute(data))
+}
@@ -214,6 +214,16 @@ type DynastyContext struct {
 	MintCntTrie     *trie.BatchTrie
 	Accounts        state.AccountState
 	Storage         storage.Storage
+	Params          *DposParams
+
+	// the participations of validators in the dynasties kicked out.
+	Participations []*ValidatorParticipation
+
+	// the changes of the dynasties ended.
+	Changes []*DynastyChange
+
+	// countOffline is true if the context is of a block after the participation fork.
+	countOffline bool
 }
 
 func (dc *DynastyContext) tallyVotes() (map[string]*util.Uint128, error) {
@@ -462,34 +472,23 @@ func (dc *DynastyContext) kickoutDynasty(dynastyID int64) error {
 	}
 	for exist {
 		validator := iter.Value()
-		key := append(byteutils.FromInt64(dynastyID), validator...)
-		bytes, err := dc.MintCntTrie.Get(key)
-		if err != nil && err != storage.ErrKeyNotFound {
-			return err
-		}
-		if err != storage.ErrKeyNotFound {
-			cnt := byteutils.Int64(bytes)
-			if cnt >= DynastyInterval/BlockInterval/DynastySize/2 {
-				exist, err = iter.Next()
-				if err != nil {
-					return err
-				}
-				continue
-			}
-		}
-		isActiveBootstrapValidator, err := checkActiveBootstrapValidator(validator, dc.Storage, dc.CandidateTrie)
+		participation, err := dc.participate(dynastyID, validator)
 		if err != nil {
 			return err
 		}
-		if isActiveBootstrapValidator {
-			addr, err := AddressParseFromBytes(validator)
+		dc.Participations = append(dc.Participations, participation)
+		if participation.Offline() {
+			isActiveBootstrapValidator, err := checkActiveBootstrapValidator(validator, dc.Storage, dc.CandidateTrie)
 			if err != nil {
 				return err
 			}
-			logging.VLog().Info("Protect active bootstrap candidate: ", addr)
-		} else {
-			if err := dc.kickoutCandidate(validator); err != nil {
-				return err
+			if isActiveBootstrapValidator && (!dc.countOffline || participation.OfflineDynasties < MaxOfflineDynasties) {
+				logging.VLog().Info("Protect active bootstrap candidate: ", participation.Address)
+			} else {
+				if err := dc.kickoutCandidate(validator); err != nil {
+					return err
+				}
+				participation.Excluded = true
 			}
 		}
 		exist, err = iter.Next()
@@ -513,6 +512,7 @@ func (dc *DynastyContext) electNextDynastyOnBaseDynasty(baseDynastyID int64, nex
 		baseDynastyID = nextDynastyID - 1
 	}
 	for i := baseDynastyID; i < nextDynastyID; i++ {
+		participations := len(dc.Participations)
 		// collect candidates
 		if !baseGenesis {
 			err := dc.kickoutDynasty(i)
@@ -528,13 +528,13 @@ func (dc *DynastyContext) electNextDynastyOnBaseDynasty(baseDynastyID int64, nex
 		if err != nil {
 			return err
 		}
-		if len(candidates) < SafeSize {
+		if len(candidates) < dc.Params.SafeSize() {
 			return ErrTooFewCandidates
 		}
 		// Top 20 are selected directly
 		newDynasty := []string{}
 		nextDynastyTrie, err := trie.NewBatchTrie(nil, dc.Storage)
-		directSelected := DynastySize - 1
+		directSelected := dc.Params.DynastySize - 1
 		for i := 0; i < directSelected && i < len(candidates); i++ {
 			delegatee := candidates[i].Address.Bytes()
 			_, err := nextDynastyTrie.Put(delegatee, delegatee)
@@ -549,7 +549,7 @@ func (dc *DynastyContext) electNextDynastyOnBaseDynasty(baseDynastyID int64, nex
 			hasher.Write(byteutils.FromInt64(nextDynastyID))
 			hasher.Write(dc.Accounts.RootHash())
 			result := int(hasher.Sum32()) % (len(candidates) - directSelected)
-			offset := result + DynastySize - 1
+			offset := result + directSelected
 			delegatee := candidates[offset].Address.Bytes()
 			_, err = nextDynastyTrie.Put(delegatee, delegatee)
 			if err != nil {
@@ -557,6 +557,12 @@ func (dc *DynastyContext) electNextDynastyOnBaseDynasty(baseDynastyID int64, nex
 			}
 			newDynasty = append(newDynasty, candidates[offset].Address.String())
 		}
+		change, err := dc.newDynastyChange(i+1, candidates, dc.Participations[participations:])
+		if err != nil {
+			return err
+		}
+		change.Elected = newDynasty
+		dc.Changes = append(dc.Changes, change)
 		dc.DynastyTrie = dc.NextDynastyTrie
 		dc.NextDynastyTrie = nextDynastyTrie
 
@@ -604,6 +610,8 @@ func (block *Block) LoadDynastyContext(context *DynastyContext) error {
 		mintCntTrie:     mintCntTrie,
 		storage:         block.storage,
 	}
+	block.participations = context.Participations
+	block.dynastyChanges = context.Changes
 	return nil
 }
 
@@ -629,7 +637,12 @@ func GenesisDynastyContext(storage storage.Storage, conf *corepb.Genesis) (*Dyna
 	if err != nil {
 		return nil, err
 	}
-	if len(conf.Consensus.Dpos.Dynasty) < SafeSize {
+	schedule, err := NewDposSchedule(conf)
+	if err != nil {
+		return nil, err
+	}
+	params := schedule.Params(1)
+	if len(conf.Consensus.Dpos.Dynasty) < params.SafeSize() {
 		return nil, ErrInitialDynastyNotEnough
 	}
 	for i := 0; i < len(conf.Consensus.Dpos.Dynasty); i++ {
@@ -639,7 +652,7 @@ func GenesisDynastyContext(storage storage.Storage, conf *corepb.Genesis) (*Dyna
 			return nil, err
 		}
 		v := member.Bytes()
-		if i < DynastySize {
+		if i < params.DynastySize {
 			if _, err = dynasty.Put(v, v); err != nil {
 				return nil, err
 			}
@@ -667,30 +680,19 @@ func GenesisDynastyContext(storage storage.Storage, conf *corepb.Genesis) (*Dyna
 		CandidateTrie:   candidate,
 		MintCntTrie:     mint,
 		VoteTrie:        vote,
+		Params:          params,
 	}, nil
 }
 
-// FindProposer for now in given dynasty
-func FindProposer(now int64, dynasty *trie.BatchTrie) (proposer byteutils.Hash, err error) {
-	offset := now % DynastyInterval
-	if offset%BlockInterval != 0 {
-		return nil, ErrNotBlockForgTime
-	}
-	offset /= BlockInterval
-	offset %= DynastySize
-	delegatees, err := TraverseDynasty(dynasty)
+// NextDynastyContext when some seconds elapsed
+func (block *Block) NextDynastyContext(elapsedSecond int64) (*DynastyContext, error) {
+	// a backup proposes only if its slot is empty.
+	timestamp := block.header.timestamp + elapsedSecond
+	rank, err := ProposerRank(timestamp)
 	if err != nil {
 		return nil, err
 	}
-	if int(offset) < len(delegatees) {
-		proposer = delegatees[offset]
-	}
-	return proposer, nil
-}
-
-// NextDynastyContext when some seconds elapsed
-func (block *Block) NextDynastyContext(elapsedSecond int64) (*DynastyContext, error) {
-	if elapsedSecond%BlockInterval != 0 {
+	if rank > 0 && timestamp-rank*BackupProposerDelay <= block.header.timestamp {
 		return nil, ErrNotBlockForgTime
 	}
 
@@ -720,7 +722,7 @@ func (block *Block) NextDynastyContext(elapsedSecond int64) (*DynastyContext, er
 	}
 
 	context := &DynastyContext{
-		TimeStamp:       block.header.timestamp + elapsedSecond,
+		TimeStamp:       timestamp,
 		DynastyTrie:     dynastyTrie,
 		NextDynastyTrie: nextDynastyTrie,
 		DelegateTrie:    delegateTrie,
@@ -729,11 +731,33 @@ func (block *Block) NextDynastyContext(elapsedSecond int64) (*DynastyContext, er
 		MintCntTrie:     mintCntTrie,
 		Accounts:        block.accState,
 		Storage:         block.storage,
+		Params:          block.nextDposParams(),
+		countOffline:    block.height+1 >= ParticipationForkHeight(block.header.chainID),
 	}
 
-	baseDynastyID := block.header.timestamp / DynastyInterval
-	newDynastyID := context.TimeStamp / DynastyInterval
-	if baseDynastyID < newDynastyID {
+	baseParams := block.DposParams()
+	base, err := dynastyBaseOf(context.MintCntTrie)
+	if err != nil {
+		return nil, err
+	}
+	baseDynastyID := baseParams.DynastyID(base, block.header.timestamp)
+	if !baseParams.Equals(context.Params) {
+		// the dynasties of the fork are counted from the one after the last of the old params.
+		base = context.Params.forkDynastyBase(baseDynastyID, context.TimeStamp)
+		if err := putDynastyBase(context.MintCntTrie, base); err != nil {
+			return nil, err
+		}
+	}
+	newDynastyID := context.Params.DynastyID(base, context.TimeStamp)
+	if !baseParams.Equals(context.Params) {
+		// the dynasty and the next are elected again by the new params at the fork, without kickout
+		// since the mint counts are of the old.
+		for i := 0; i < 2; i++ {
+			if err = context.electNextDynastyOnBaseDynasty(newDynastyID-1, newDynastyID, true); err != nil {
+				return nil, err
+			}
+		}
+	} else if baseDynastyID < newDynastyID {
 		if baseDynastyID+1 < newDynastyID {
 			// do not kickout genesis dynasty
 			err = context.electNextDynastyOnBaseDynasty(baseDynastyID, newDynastyID-1, baseDynastyID == 0)
@@ -748,7 +772,7 @@ func (block *Block) NextDynastyContext(elapsedSecond int64) (*DynastyContext, er
 		}
 	}
 
-	context.Proposer, err = FindProposer(context.TimeStamp, context.DynastyTrie)
+	context.Proposer, err = context.Params.FindProposer(context.TimeStamp, context.DynastyTrie)
 	if err != nil {
 		return nil, err
 	}
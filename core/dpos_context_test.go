@@ -229,6 +229,79 @@ func TestKickoutDynastyActuallyKickoutCandidates(t *testing.T) {
 	assert.Equal(t, len(candidates), len(neb.Genesis().Consensus.Dpos.Dynasty)-1)
 }
 
+func TestKickoutDynastyParticipations(t *testing.T) {
+	chain, _ := NewBlockChain(testNeb())
+	dc, err := chain.TailBlock().NextDynastyContext(0)
+	assert.Nil(t, err)
+	validators, err := TraverseDynasty(dc.DynastyTrie)
+	assert.Nil(t, err)
+	online, flaky, offline := validators[0], validators[1], validators[2]
+	mint := func(dynastyID int64, validator byteutils.Hash, count int64) {
+		_, err := dc.MintCntTrie.Put(append(byteutils.FromInt64(dynastyID), validator...), byteutils.FromInt64(count))
+		assert.Nil(t, err)
+	}
+	participation := func(validator byteutils.Hash) *ValidatorParticipation {
+		for _, p := range dc.Participations {
+			if p.Address == byteutils.Hex(validator) {
+				return p
+			}
+		}
+		return nil
+	}
+
+	// the bootstrap validators offline are protected till MaxOfflineDynasties.
+	for i := int64(1); i <= MaxOfflineDynasties; i++ {
+		mint(i, online, dc.Params.SlotsPerValidator())
+		if i == 2 {
+			mint(i, flaky, dc.Params.SlotsPerValidator())
+		}
+		dc.Participations = nil
+		assert.Nil(t, dc.kickoutDynasty(i))
+		assert.Equal(t, len(validators), len(dc.Participations))
+		assert.Equal(t, int64(100), participation(online).Rate)
+		assert.False(t, participation(online).Offline())
+		assert.Equal(t, int64(0), participation(offline).Rate)
+		assert.True(t, participation(offline).Offline())
+		assert.Equal(t, i, participation(offline).OfflineDynasties)
+		assert.Equal(t, i == MaxOfflineDynasties, participation(offline).Excluded)
+	}
+	assert.Equal(t, int64(1), participation(flaky).OfflineDynasties)
+	assert.False(t, participation(flaky).Excluded)
+
+	_, err = dc.CandidateTrie.Get(online)
+	assert.Nil(t, err)
+	_, err = dc.CandidateTrie.Get(flaky)
+	assert.Nil(t, err)
+	_, err = dc.CandidateTrie.Get(offline)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
+
+func TestKickoutDynastyBeforeParticipationFork(t *testing.T) {
+	chain, _ := NewBlockChain(testNeb())
+	SetParticipationForkHeight(chain.ChainID(), 100)
+	defer SetParticipationForkHeight(chain.ChainID(), 0)
+	dc, err := chain.TailBlock().NextDynastyContext(0)
+	assert.Nil(t, err)
+	validators, err := TraverseDynasty(dc.DynastyTrie)
+	assert.Nil(t, err)
+	root := dc.MintCntTrie.RootHash()
+
+	// the offline dynasties are not counted, and the bootstrap validators are protected as ever.
+	for i := int64(1); i <= MaxOfflineDynasties+1; i++ {
+		dc.Participations = nil
+		assert.Nil(t, dc.kickoutDynasty(i))
+		for _, p := range dc.Participations {
+			assert.True(t, p.Offline())
+			assert.Equal(t, int64(0), p.OfflineDynasties)
+			assert.False(t, p.Excluded)
+		}
+	}
+	assert.Equal(t, root, dc.MintCntTrie.RootHash())
+	candidates, err := TraverseDynasty(dc.CandidateTrie)
+	assert.Nil(t, err)
+	assert.True(t, len(candidates) >= len(validators))
+}
+
 func TestCheckActiveBootstrapValidators(t *testing.T) {
 	stor, err := storage.NewMemoryStorage()
 	assert.Nil(t, err)
@@ -0,0 +1,303 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"math"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"golang.org/x/crypto/sha3"
+)
+
+// Errors of dpos params
+var (
+	ErrInvalidDposParams  = errors.New("invalid dpos params in genesis, dynasty interval must be a multiple of block interval times dynasty size")
+	ErrInvalidDposFork    = errors.New("dpos forks in genesis must be in ascending heights after genesis")
+	ErrInvalidDynastyBase = errors.New("invalid dynasty base in mint count trie")
+)
+
+// Backup proposers
+const (
+	// MaxBackupProposers is the count of validators following the proposer in the dynasty,
+	// who may propose in turn if the ones before them miss the slot.
+	MaxBackupProposers = 2
+
+	// BackupProposerDelay is the seconds in the slot a backup proposer waits for each one before it,
+	// and stamps its block with, a block of the slot is not minted after MaxBackupProposers*BackupProposerDelay seconds.
+	BackupProposerDelay = int64(2)
+)
+
+// MinSlashBond is the least bond of a double signing validator, 100 NAS.
+var MinSlashBond = util.NewUint128FromBigInt(util.NewUint128().Mul(util.NewUint128FromInt(100).Int,
+	util.NewUint128().Exp(util.NewUint128FromInt(10).Int, util.NewUint128FromInt(18).Int, nil)))
+
+// DposParams is the size and interval of dynasties from the height,
+// and the least bond taken from a validator slashed.
+type DposParams struct {
+	Height          uint64
+	DynastyInterval int64
+	DynastySize     int
+	MinSlashBond    *util.Uint128
+}
+
+// DposSchedule is the dpos params by height, recorded in genesis conf. The params of a
+// height are of the last fork at or below it, and the dynasties are elected again by
+// the new params at the first block of a fork.
+type DposSchedule struct {
+	Forks []*DposParams
+}
+
+// DefaultDposParams is the dynasty of DynastySize validators in every DynastyInterval.
+var DefaultDposParams = &DposParams{
+	Height:          1,
+	DynastyInterval: DynastyInterval,
+	DynastySize:     DynastySize,
+	MinSlashBond:    MinSlashBond,
+}
+
+// DefaultDposSchedule is DefaultDposParams for ever.
+var DefaultDposSchedule = &DposSchedule{
+	Forks: []*DposParams{DefaultDposParams},
+}
+
+func newDposParams(height uint64, interval int64, size uint32, base *DposParams) (*DposParams, error) {
+	params := &DposParams{
+		Height:          height,
+		DynastyInterval: base.DynastyInterval,
+		DynastySize:     base.DynastySize,
+		MinSlashBond:    base.MinSlashBond,
+	}
+	if interval != 0 {
+		params.DynastyInterval = interval
+	}
+	if size != 0 {
+		params.DynastySize = int(size)
+	}
+	if params.DynastyInterval <= 0 || params.DynastyInterval%(BlockInterval*int64(params.DynastySize)) != 0 {
+		return nil, ErrInvalidDposParams
+	}
+	return params, nil
+}
+
+// NewDposSchedule returns the dpos schedule in genesis conf, DefaultDposSchedule if not configured.
+func NewDposSchedule(conf *corepb.Genesis) (*DposSchedule, error) {
+	if conf.Consensus == nil || conf.Consensus.Dpos == nil ||
+		(conf.Consensus.Dpos.DynastyInterval == 0 && conf.Consensus.Dpos.DynastySize == 0 && len(conf.Consensus.Dpos.Forks) == 0) {
+		return DefaultDposSchedule, nil
+	}
+	pbDpos := conf.Consensus.Dpos
+
+	initial, err := newDposParams(1, pbDpos.DynastyInterval, pbDpos.DynastySize, DefaultDposParams)
+	if err != nil {
+		return nil, err
+	}
+	schedule := &DposSchedule{Forks: []*DposParams{initial}}
+	for _, v := range pbDpos.Forks {
+		last := schedule.Forks[len(schedule.Forks)-1]
+		if v.Height <= last.Height {
+			return nil, ErrInvalidDposFork
+		}
+		params, err := newDposParams(v.Height, v.DynastyInterval, v.DynastySize, last)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Forks = append(schedule.Forks, params)
+	}
+	return schedule, nil
+}
+
+// Params returns the dpos params of the block at height.
+func (s *DposSchedule) Params(height uint64) *DposParams {
+	params := s.Forks[0]
+	for _, v := range s.Forks {
+		if v.Height > height {
+			break
+		}
+		params = v
+	}
+	return params
+}
+
+// Hash returns the hash of the schedule, nil for DefaultDposSchedule.
+func (s *DposSchedule) Hash() byteutils.Hash {
+	if s == DefaultDposSchedule {
+		return nil
+	}
+	hasher := sha3.New256()
+	for _, v := range s.Forks {
+		hasher.Write(byteutils.FromUint64(v.Height))
+		hasher.Write(byteutils.FromInt64(v.DynastyInterval))
+		hasher.Write(byteutils.FromInt64(int64(v.DynastySize)))
+	}
+	return hasher.Sum(nil)
+}
+
+// SafeSize is the min count of candidates to elect a dynasty.
+func (p *DposParams) SafeSize() int {
+	return p.DynastySize/3 + 1
+}
+
+// SlotsPerValidator is the count of slots of a validator in a dynasty.
+func (p *DposParams) SlotsPerValidator() int64 {
+	return p.DynastyInterval / BlockInterval / int64(p.DynastySize)
+}
+
+// DynastyBase is the id and the start of the first dynasty of a dpos fork, the dynasty ids of
+// the fork are counted from it so that they keep increasing across the forks whatever the intervals.
+// The dynasties before the first fork are counted from the zero base.
+type DynastyBase struct {
+	ID    int64
+	Start int64
+}
+
+// dynastyBaseKey is the key of the dynasty base of the last fork in mint count trie, as the mint count
+// of the zero address in dynasty math.MinInt64, which never starts. It's put at the first block of a
+// fork only, so the trie of a chain without forks is not changed.
+var dynastyBaseKey = append(byteutils.FromInt64(math.MinInt64), make([]byte, AddressLength)...)
+
+// DynastyID returns the id of the dynasty at the timestamp, counted from the base. The timestamps
+// before the start are of ids before the base.
+func (p *DposParams) DynastyID(base *DynastyBase, timestamp int64) int64 {
+	elapsed := timestamp - base.Start
+	id := elapsed / p.DynastyInterval
+	if elapsed < 0 && elapsed%p.DynastyInterval != 0 {
+		id--
+	}
+	return base.ID + id
+}
+
+// forkDynastyBase returns the base of the fork starting at the timestamp after the dynasty lastID,
+// the start is aligned to the interval as the slots are.
+func (p *DposParams) forkDynastyBase(lastID, timestamp int64) *DynastyBase {
+	return &DynastyBase{ID: lastID + 1, Start: timestamp - timestamp%p.DynastyInterval}
+}
+
+// dynastyBaseOf returns the dynasty base in the mint count trie, the zero base if not put.
+func dynastyBaseOf(mintCntTrie *trie.BatchTrie) (*DynastyBase, error) {
+	bytes, err := mintCntTrie.Get(dynastyBaseKey)
+	if err == storage.ErrKeyNotFound {
+		return &DynastyBase{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) != 16 {
+		return nil, ErrInvalidDynastyBase
+	}
+	return &DynastyBase{ID: byteutils.Int64(bytes[:8]), Start: byteutils.Int64(bytes[8:])}, nil
+}
+
+// putDynastyBase puts the dynasty base into the mint count trie.
+func putDynastyBase(mintCntTrie *trie.BatchTrie, base *DynastyBase) error {
+	_, err := mintCntTrie.Put(dynastyBaseKey, append(byteutils.FromInt64(base.ID), byteutils.FromInt64(base.Start)...))
+	return err
+}
+
+// DynastyID returns the id of the dynasty at the timestamp by the dpos params of the block.
+func (block *Block) DynastyID(timestamp int64) (int64, error) {
+	base, err := dynastyBaseOf(block.dposContext.mintCntTrie)
+	if err != nil {
+		return 0, err
+	}
+	return block.DposParams().DynastyID(base, timestamp), nil
+}
+
+// ProposerRank returns the rank of the proposer minting the block at the timestamp, the proposer
+// of the slot stamps its block at the slot and a backup of rank i BackupProposerDelay*i seconds later.
+func ProposerRank(timestamp int64) (int64, error) {
+	delay := timestamp % BlockInterval
+	if delay%BackupProposerDelay != 0 || delay/BackupProposerDelay > MaxBackupProposers {
+		return 0, ErrNotBlockForgTime
+	}
+	return delay / BackupProposerDelay, nil
+}
+
+// FindProposer for now in given dynasty, the proposer of the slot or the backup of the rank now is in.
+func (p *DposParams) FindProposer(now int64, dynasty *trie.BatchTrie) (proposer byteutils.Hash, err error) {
+	rank, err := ProposerRank(now)
+	if err != nil {
+		return nil, err
+	}
+	proposers, err := p.FindProposers(now, dynasty)
+	if err != nil {
+		return nil, err
+	}
+	if int(rank) >= len(proposers) {
+		return nil, ErrNotBlockForgTime
+	}
+	return proposers[rank], nil
+}
+
+// FindProposers for now in given dynasty, the proposer of the slot now is in followed by its backups,
+// the next validators in order. The ones of empty slots are nil.
+func (p *DposParams) FindProposers(now int64, dynasty *trie.BatchTrie) ([]byteutils.Hash, error) {
+	rank, err := ProposerRank(now)
+	if err != nil {
+		return nil, err
+	}
+	offset := (now - rank*BackupProposerDelay) % p.DynastyInterval
+	offset /= BlockInterval
+	offset %= int64(p.DynastySize)
+	delegatees, err := TraverseDynasty(dynasty)
+	if err != nil {
+		return nil, err
+	}
+	count := MaxBackupProposers + 1
+	if count > p.DynastySize {
+		count = p.DynastySize
+	}
+	proposers := make([]byteutils.Hash, count)
+	for i := range proposers {
+		if index := (int(offset) + i) % p.DynastySize; index < len(delegatees) {
+			proposers[i] = delegatees[index]
+		}
+	}
+	return proposers, nil
+}
+
+// Equals returns true if the params elect the same dynasties.
+func (p *DposParams) Equals(other *DposParams) bool {
+	return p.DynastyInterval == other.DynastyInterval && p.DynastySize == other.DynastySize
+}
+
+// DposParams returns the dpos params of the block.
+func (block *Block) DposParams() *DposParams {
+	if block.dpos == nil {
+		return DefaultDposSchedule.Params(block.height)
+	}
+	return block.dpos.Params(block.height)
+}
+
+// nextDposParams returns the dpos params of the child of the block.
+func (block *Block) nextDposParams() *DposParams {
+	if block.dpos == nil {
+		return DefaultDposSchedule.Params(block.height + 1)
+	}
+	return block.dpos.Params(block.height + 1)
+}
+
+// DposParams returns the dpos params of the block at height.
+func (bc *BlockChain) DposParams(height uint64) *DposParams {
+	return bc.dpos.Params(height)
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDposSchedule_Params(t *testing.T) {
+	forks := &corepb.GenesisConsensusDpos{
+		DynastyInterval: 120,
+		Forks: []*corepb.GenesisDposFork{
+			&corepb.GenesisDposFork{Height: 100, DynastySize: 3},
+			&corepb.GenesisDposFork{Height: 200, DynastyInterval: 60},
+		},
+	}
+	tests := []struct {
+		name     string
+		dpos     *corepb.GenesisConsensusDpos
+		height   uint64
+		interval int64
+		size     int
+	}{
+		{"default", &corepb.GenesisConsensusDpos{}, 1000000, DynastyInterval, DynastySize},
+		{"genesis", forks, 1, 120, DynastySize},
+		{"before fork", forks, 99, 120, DynastySize},
+		{"fork", forks, 100, 120, 3},
+		{"last fork", forks, 1000, 60, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := MockGenesisConf()
+			tt.dpos.Dynasty = MockDynasty
+			conf.Consensus.Dpos = tt.dpos
+			schedule, err := NewDposSchedule(conf)
+			assert.Nil(t, err)
+			params := schedule.Params(tt.height)
+			assert.Equal(t, tt.interval, params.DynastyInterval)
+			assert.Equal(t, tt.size, params.DynastySize)
+		})
+	}
+}
+
+func TestNewDposSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		dpos *corepb.GenesisConsensusDpos
+		err  error
+	}{
+		{"negative interval", &corepb.GenesisConsensusDpos{DynastyInterval: -60}, ErrInvalidDposParams},
+		{"uneven slots", &corepb.GenesisConsensusDpos{DynastyInterval: 50}, ErrInvalidDposParams},
+		{"fork at genesis", &corepb.GenesisConsensusDpos{Forks: []*corepb.GenesisDposFork{&corepb.GenesisDposFork{Height: 1, DynastySize: 3}}}, ErrInvalidDposFork},
+		{"invalid fork", &corepb.GenesisConsensusDpos{Forks: []*corepb.GenesisDposFork{&corepb.GenesisDposFork{Height: 10, DynastySize: 7}}}, ErrInvalidDposParams},
+		{"descending forks", &corepb.GenesisConsensusDpos{Forks: []*corepb.GenesisDposFork{
+			&corepb.GenesisDposFork{Height: 20, DynastySize: 3},
+			&corepb.GenesisDposFork{Height: 10, DynastySize: 2},
+		}}, ErrInvalidDposFork},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := MockGenesisConf()
+			tt.dpos.Dynasty = MockDynasty
+			conf.Consensus.Dpos = tt.dpos
+			_, err := NewDposSchedule(conf)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestBlock_NextDynastyContextAtFork(t *testing.T) {
+	neb := testNeb()
+	neb.genesis.Consensus.Dpos.Forks = []*corepb.GenesisDposFork{&corepb.GenesisDposFork{Height: 3, DynastySize: 3}}
+	chain, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	genesis := chain.GenesisBlock()
+
+	context, err := genesis.NextDynastyContext(BlockInterval)
+	assert.Nil(t, err)
+	assert.Equal(t, DynastySize, context.Params.DynastySize)
+	validators, _ := TraverseDynasty(context.DynastyTrie)
+	assert.Equal(t, DynastySize, len(validators))
+
+	block, err := NewBlock(chain.ChainID(), &Address{validators[1]}, genesis)
+	assert.Nil(t, err)
+	block.LoadDynastyContext(context)
+	assert.Equal(t, uint64(2), block.Height())
+
+	// the dynasty and the next are elected again by the new size at the fork.
+	context, err = block.NextDynastyContext(BlockInterval)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, context.Params.DynastySize)
+	validators, _ = TraverseDynasty(context.DynastyTrie)
+	assert.Equal(t, 3, len(validators))
+	next, _ := TraverseDynasty(context.NextDynastyTrie)
+	assert.Equal(t, validators, next)
+	proposer, err := context.Params.FindProposer(context.TimeStamp, context.DynastyTrie)
+	assert.Nil(t, err)
+	assert.Equal(t, context.Proposer, proposer)
+
+	// the digest of genesis commits to the dpos schedule.
+	digest, err := GenesisConfDigest(neb.genesis)
+	assert.Nil(t, err)
+	defaultDigest, err := GenesisConfDigest(MockGenesisConf())
+	assert.Nil(t, err)
+	assert.NotEqual(t, defaultDigest, digest)
+	assert.Equal(t, HashBlock(chain.GenesisBlock()), defaultDigest)
+}
+
+func TestBlock_DynastyIDAcrossForks(t *testing.T) {
+	neb := testNeb()
+	neb.genesis.Consensus.Dpos.Forks = []*corepb.GenesisDposFork{&corepb.GenesisDposFork{Height: 3, DynastyInterval: 4 * DynastyInterval}}
+	chain, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	genesis := chain.GenesisBlock()
+
+	context, err := genesis.NextDynastyContext(5*DynastyInterval + BlockInterval)
+	assert.Nil(t, err)
+	block, err := NewBlock(chain.ChainID(), &Address{context.Proposer}, genesis)
+	assert.Nil(t, err)
+	block.LoadDynastyContext(context)
+	dynastyID, err := block.DynastyID(block.Timestamp())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), dynastyID)
+
+	// the longer dynasties of the fork are counted from the one after the last before it,
+	// the ids don't go back to the timestamp divided by the new interval.
+	context, err = block.NextDynastyContext(BlockInterval)
+	assert.Nil(t, err)
+	fork, err := NewBlock(chain.ChainID(), &Address{context.Proposer}, block)
+	assert.Nil(t, err)
+	fork.LoadDynastyContext(context)
+	assert.Equal(t, uint64(3), fork.Height())
+	start := fork.Timestamp() - fork.Timestamp()%(4*DynastyInterval)
+	tests := []struct {
+		timestamp int64
+		id        int64
+	}{
+		{fork.Timestamp(), 6},
+		{start + 4*DynastyInterval - BlockInterval, 6},
+		{start + 4*DynastyInterval, 7},
+		{start + 9*DynastyInterval, 8},
+		{start - BlockInterval, 5},
+	}
+	for _, tt := range tests {
+		dynastyID, err := fork.DynastyID(tt.timestamp)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.id, dynastyID, tt.timestamp)
+	}
+
+	// the base is kept by the blocks after the fork.
+	context, err = fork.NextDynastyContext(4 * DynastyInterval)
+	assert.Nil(t, err)
+	next, err := NewBlock(chain.ChainID(), &Address{context.Proposer}, fork)
+	assert.Nil(t, err)
+	next.LoadDynastyContext(context)
+	dynastyID, err = next.DynastyID(next.Timestamp())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), dynastyID)
+}
+
+func TestDposParams_FindProposers(t *testing.T) {
+	neb := testNeb()
+	chain, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	context, err := chain.GenesisBlock().NextDynastyContext(BlockInterval)
+	assert.Nil(t, err)
+	validators, err := TraverseDynasty(context.DynastyTrie)
+	assert.Nil(t, err)
+
+	params := context.Params
+	proposers, err := params.FindProposers(BlockInterval, context.DynastyTrie)
+	assert.Nil(t, err)
+	assert.Equal(t, MaxBackupProposers+1, len(proposers))
+	assert.Equal(t, validators[1:MaxBackupProposers+2], proposers)
+	assert.Equal(t, context.Proposer, proposers[0])
+
+	// the backups of the last slot in a round are the first validators.
+	proposers, err = params.FindProposers(int64(DynastySize-1)*BlockInterval, context.DynastyTrie)
+	assert.Nil(t, err)
+	assert.Equal(t, validators[DynastySize-1], proposers[0])
+	assert.Equal(t, validators[:MaxBackupProposers], proposers[1:])
+
+	// the proposer of a timestamp is the backup of the rank its delay in the slot is of.
+	for rank := int64(0); rank <= MaxBackupProposers; rank++ {
+		now := BlockInterval + rank*BackupProposerDelay
+		r, err := ProposerRank(now)
+		assert.Nil(t, err)
+		assert.Equal(t, rank, r)
+		proposers, err := params.FindProposers(now, context.DynastyTrie)
+		assert.Nil(t, err)
+		assert.Equal(t, validators[1:MaxBackupProposers+2], proposers)
+		proposer, err := params.FindProposer(now, context.DynastyTrie)
+		assert.Nil(t, err)
+		assert.Equal(t, validators[1+rank], proposer)
+	}
+
+	_, err = params.FindProposers(BlockInterval+1, context.DynastyTrie)
+	assert.Equal(t, ErrNotBlockForgTime, err)
+	_, err = params.FindProposer(BlockInterval+BackupProposerDelay+1, context.DynastyTrie)
+	assert.Equal(t, ErrNotBlockForgTime, err)
+}
+
+func TestBlock_NextDynastyContextOfBackup(t *testing.T) {
+	neb := testNeb()
+	chain, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	genesis := chain.GenesisBlock()
+
+	context, err := genesis.NextDynastyContext(BlockInterval + BackupProposerDelay)
+	assert.Nil(t, err)
+	assert.Equal(t, BlockInterval+BackupProposerDelay, context.TimeStamp)
+	proposer, err := context.Params.FindProposer(BlockInterval+BackupProposerDelay, context.DynastyTrie)
+	assert.Nil(t, err)
+	assert.Equal(t, proposer, context.Proposer)
+
+	_, err = genesis.NextDynastyContext(BlockInterval + 1)
+	assert.Equal(t, ErrNotBlockForgTime, err)
+
+	// a backup never proposes in the slot of the parent.
+	block, err := NewBlock(chain.ChainID(), mockAddress(), genesis)
+	assert.Nil(t, err)
+	context, err = genesis.NextDynastyContext(BlockInterval)
+	assert.Nil(t, err)
+	assert.Nil(t, block.LoadDynastyContext(context))
+	_, err = block.NextDynastyContext(BackupProposerDelay)
+	assert.Equal(t, ErrNotBlockForgTime, err)
+	_, err = block.NextDynastyContext(BlockInterval + BackupProposerDelay)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Errors of dynasty changes
+var (
+	ErrDynastyChangeVetoed = errors.New("dynasty change vetoed by a governance hook")
+)
+
+// DynastyChange is the transition at the boundary of a dynasty, the outgoing validators are replaced
+// by the incoming ones elected a dynasty before, and the next dynasty is elected by the votes.
+type DynastyChange struct {
+	DynastyID int64    `json:"dynasty_id"`
+	Outgoing  []string `json:"outgoing"`
+	Incoming  []string `json:"incoming"`
+	Elected   []string `json:"elected"`
+
+	// Votes is the votes of the candidates tallied in the election, in decimal.
+	Votes map[string]string `json:"votes"`
+
+	// Kicked is the validators kicked out from the candidates for offline.
+	Kicked []string `json:"kicked"`
+}
+
+// DynastyHook is notified of the dynasty changes of the blocks before they are accepted, external
+// governance modules log or veto the transitions by it. A block with a vetoed change is rejected by
+// the node, so a hook vetoing a change elected by the chain takes the node off the chain. It may be
+// notified of a change more than once, by the blocks of the forks.
+type DynastyHook interface {
+	OnDynastyChange(change *DynastyChange) error
+}
+
+// dynastyHookManager keeps the registered dynasty hooks.
+type dynastyHookManager struct {
+	mu    sync.RWMutex
+	hooks []DynastyHook
+}
+
+// RegisterDynastyHook registers the hook to be notified of dynasty changes.
+func (bc *BlockChain) RegisterDynastyHook(hook DynastyHook) {
+	bc.dynastyHooks.mu.Lock()
+	defer bc.dynastyHooks.mu.Unlock()
+	bc.dynastyHooks.hooks = append(bc.dynastyHooks.hooks, hook)
+}
+
+// verifyDynastyChanges notifies the hooks of the dynasty changes of the block, it fails if any vetoes.
+func (bc *BlockChain) verifyDynastyChanges(block *Block) error {
+	if len(block.dynastyChanges) == 0 {
+		return nil
+	}
+	bc.dynastyHooks.mu.RLock()
+	defer bc.dynastyHooks.mu.RUnlock()
+	for _, change := range block.dynastyChanges {
+		for _, hook := range bc.dynastyHooks.hooks {
+			if err := hook.OnDynastyChange(change); err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"block":   block,
+					"dynasty": change.DynastyID,
+					"err":     err,
+				}).Warn("Dynasty change vetoed.")
+				return ErrDynastyChangeVetoed
+			}
+		}
+	}
+	return nil
+}
+
+// addressesOf returns the addresses of the members of the dynasty.
+func addressesOf(dynasty *trie.BatchTrie) ([]string, error) {
+	members, err := TraverseDynasty(dynasty)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, len(members))
+	for i, v := range members {
+		addr, err := AddressParseFromBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = addr.String()
+	}
+	return addrs, nil
+}
+
+// newDynastyChange returns the change of the dynasty to the next of the context, with the votes of the candidates
+// and the validators kicked out in the participations.
+func (dc *DynastyContext) newDynastyChange(dynastyID int64, candidates Candidates, participations []*ValidatorParticipation) (*DynastyChange, error) {
+	outgoing, err := addressesOf(dc.DynastyTrie)
+	if err != nil {
+		return nil, err
+	}
+	incoming, err := addressesOf(dc.NextDynastyTrie)
+	if err != nil {
+		return nil, err
+	}
+	change := &DynastyChange{
+		DynastyID: dynastyID,
+		Outgoing:  outgoing,
+		Incoming:  incoming,
+		Votes:     make(map[string]string),
+		Kicked:    []string{},
+	}
+	for _, v := range candidates {
+		change.Votes[v.Address.String()] = v.Votes.String()
+	}
+	for _, v := range participations {
+		if v.Excluded {
+			change.Kicked = append(change.Kicked, v.Address)
+		}
+	}
+	return change, nil
+}
+
+// triggerDynastyChanges notifies the subscribers of TopicDynastyChange with the dynasty changes of the block.
+func (block *Block) triggerDynastyChanges() {
+	for _, change := range block.dynastyChanges {
+		data, err := json.Marshal(change)
+		if err != nil {
+			continue
+		}
+		block.eventEmitter.Trigger(&Event{
+			Topic: TopicDynastyChange,
+			Data:  string(data),
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDynastyHook struct {
+	changes []*DynastyChange
+	veto    error
+}
+
+func (h *mockDynastyHook) OnDynastyChange(change *DynastyChange) error {
+	h.changes = append(h.changes, change)
+	return h.veto
+}
+
+func TestDynastyContext_Changes(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	genesis := bc.GenesisBlock()
+
+	context, err := genesis.NextDynastyContext(BlockInterval)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(context.Changes))
+
+	context, err = genesis.NextDynastyContext(DynastyInterval)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(context.Changes))
+	change := context.Changes[0]
+	assert.Equal(t, int64(1), change.DynastyID)
+	validators, err := addressesOf(genesis.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	assert.Equal(t, validators, change.Outgoing)
+	assert.Equal(t, validators, change.Incoming)
+	assert.Equal(t, DynastySize, len(change.Elected))
+	for _, v := range change.Elected {
+		assert.Contains(t, change.Votes, v)
+	}
+	assert.Equal(t, 0, len(change.Kicked))
+}
+
+func TestBlockChain_VetoDynastyChange(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	logger, vetoer := new(mockDynastyHook), &mockDynastyHook{veto: errors.New("vetoed")}
+	bc.RegisterDynastyHook(logger)
+	bc.RegisterDynastyHook(vetoer)
+
+	// the blocks in a dynasty are not notified.
+	mintSignedBlock(t, bc, mockAddress())
+	assert.Equal(t, 0, len(logger.changes))
+
+	tail := bc.TailBlock()
+	miner := mockAddress()
+	context, err := tail.NextDynastyContext(DynastyInterval - tail.Timestamp())
+	assert.Nil(t, err)
+	block, err := NewBlock(bc.ChainID(), miner, tail)
+	assert.Nil(t, err)
+	assert.Nil(t, block.LoadDynastyContext(context))
+	block.CollectTransactions(0)
+	block.SetMiner(miner)
+	assert.Nil(t, block.Seal())
+	key, _ := keystore.DefaultKS.GetUnlocked(miner.String())
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(key.(keystore.PrivateKey))
+	assert.Nil(t, block.Sign(signature))
+
+	assert.Equal(t, ErrDynastyChangeVetoed, bc.BlockPool().Push(BlockFromNetwork(block)))
+	assert.Equal(t, 1, len(logger.changes))
+	assert.Equal(t, 1, len(vetoer.changes))
+	assert.Nil(t, bc.GetBlock(block.Hash()))
+
+	vetoer.veto = nil
+	bc.BlockPool().cache.Remove(block.Hash().Hex())
+	bc.BlockPool().slot.Remove(block.Timestamp())
+	assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+	assert.NotNil(t, bc.GetBlock(block.Hash()))
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sort"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+)
+
+// ValidatorSnapshot is a validator of the dynasty, and the count of blocks it minted in the dynasty.
+type ValidatorSnapshot struct {
+	Address   string
+	MintCount int64
+}
+
+// CandidateSnapshot is a candidate and the votes tallied from the balances of its delegators.
+type CandidateSnapshot struct {
+	Address string
+	Votes   string
+}
+
+// DynastySnapshot is the dpos state of a block: the active validators, and the candidates with their votes.
+type DynastySnapshot struct {
+	Height     uint64
+	DynastyID  int64
+	Validators []*ValidatorSnapshot
+	Candidates []*CandidateSnapshot
+}
+
+// Snapshot returns the validators of the dynasty and their mint counts in it, and the candidates
+// with the votes tallied on accounts, sorted by votes.
+func (dc *DposContext) Snapshot(accounts state.AccountState, dynastyID int64) (*DynastySnapshot, error) {
+	snapshot := &DynastySnapshot{DynastyID: dynastyID}
+
+	validators, err := TraverseDynasty(dc.dynastyTrie)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range validators {
+		addr, err := AddressParseFromBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		count, err := dc.mintCount(dynastyID, addr)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Validators = append(snapshot.Validators, &ValidatorSnapshot{Address: addr.String(), MintCount: count})
+	}
+
+	context := &DynastyContext{
+		DelegateTrie:  dc.delegateTrie,
+		CandidateTrie: dc.candidateTrie,
+		Accounts:      accounts,
+	}
+	votes, err := context.tallyVotes()
+	if err != nil {
+		return nil, err
+	}
+	candidates := Candidates{}
+	for k, v := range votes {
+		addr, err := AddressParse(k)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &Candidate{Address: addr, Votes: v})
+	}
+	sort.Sort(candidates)
+	for _, v := range candidates {
+		snapshot.Candidates = append(snapshot.Candidates, &CandidateSnapshot{Address: v.Address.String(), Votes: v.Votes.String()})
+	}
+	return snapshot, nil
+}
+
+// mintCount returns the count of blocks minted by the validator in the dynasty.
+func (dc *DposContext) mintCount(dynastyID int64, validator *Address) (int64, error) {
+	return mintCountOf(dc.mintCntTrie, dynastyID, validator.Bytes())
+}
+
+// DynastyAtHeight returns the dynasty snapshot of the block at height in canonical chain.
+func (bc *BlockChain) DynastyAtHeight(height uint64) (*DynastySnapshot, error) {
+	block := bc.GetBlockByHeight(height)
+	if block == nil {
+		return nil, ErrBlockNotFound
+	}
+	dynastyID, err := block.DynastyID(block.Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := block.dposContext.Snapshot(block.accState, dynastyID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Height = block.Height()
+	return snapshot, nil
+}
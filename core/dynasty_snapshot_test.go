@@ -0,0 +1,78 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_DynastyAtHeight(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	genesis, err := bc.DynastyAtHeight(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), genesis.Height)
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	assert.Equal(t, len(validators), len(genesis.Validators))
+	_, err = bc.DynastyAtHeight(100)
+	assert.Equal(t, ErrBlockNotFound, err)
+
+	miner, err := AddressParse(genesis.Validators[0].Address)
+	assert.Nil(t, err)
+	block, err := bc.NewBlock(miner)
+	assert.Nil(t, err)
+	a, b, c, d := mockAddress(), mockAddress(), mockAddress(), mockAddress()
+	block.begin()
+	for _, candidate := range []*Address{a, d} {
+		_, err = block.dposContext.candidateTrie.Put(candidate.Bytes(), candidate.Bytes())
+		assert.Nil(t, err)
+	}
+	for i, delegator := range []*Address{b, c} {
+		_, err = block.dposContext.delegateTrie.Put(append(a.Bytes(), delegator.Bytes()...), delegator.Bytes())
+		assert.Nil(t, err)
+		block.accState.GetOrCreateUserAccount(delegator.Bytes()).AddBalance(util.NewUint128FromInt(int64(10 * (i + 1))))
+	}
+	block.commit()
+	block.SetMiner(miner)
+	assert.Nil(t, block.Seal())
+
+	snapshot, err := block.dposContext.Snapshot(block.accState, block.Timestamp()/DynastyInterval)
+	assert.Nil(t, err)
+	assert.Equal(t, block.Timestamp()/DynastyInterval, snapshot.DynastyID)
+	assert.Equal(t, len(validators), len(snapshot.Validators))
+	for _, v := range snapshot.Validators {
+		if v.Address == miner.String() {
+			assert.Equal(t, int64(1), v.MintCount)
+		} else {
+			assert.Equal(t, int64(0), v.MintCount)
+		}
+	}
+	// the candidates are sorted by votes.
+	assert.Equal(t, len(genesis.Candidates)+2, len(snapshot.Candidates))
+	index := make(map[string]int)
+	for i, v := range snapshot.Candidates {
+		index[v.Address] = i
+	}
+	assert.Equal(t, "30", snapshot.Candidates[index[a.String()]].Votes)
+	assert.Equal(t, "0", snapshot.Candidates[index[d.String()]].Votes)
+	assert.True(t, index[a.String()] < index[d.String()])
+}
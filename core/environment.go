@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// ExpectedV8Version is the version of v8 engine the contracts are executed by, all validators must link the same one.
+const ExpectedV8Version = "6.2.414.40"
+
+// expectedFloatDigest is the digest of the float probes on a strict IEEE 754 runtime.
+const expectedFloatDigest = "79bfe68debdbf75130b851074dcf494eb007d40a2a0b23eb957a9b63abe4f71a"
+
+// the operands of float probes, they are variables to keep the compiler from folding the expressions.
+var floatProbeOperands = []float64{0.1, 3.0, 1e308, 5e-324, 1.0 / 3.0, math.Pi}
+
+// ExecutionEnvironment is the properties of the host which may change the results of execution.
+// Validators in different environments may disagree on the state of the same block.
+type ExecutionEnvironment struct {
+	GoVersion   string `json:"go_version"`
+	OS          string `json:"os"`
+	Arch        string `json:"arch"`
+	V8Version   string `json:"v8_version"`
+	TimeZone    string `json:"time_zone"`
+	ZoneOffset  int    `json:"zone_offset"`
+	FloatDigest string `json:"float_digest"`
+}
+
+// CurrentExecutionEnvironment returns the execution environment of this node.
+func CurrentExecutionEnvironment() *ExecutionEnvironment {
+	zone, offset := time.Now().Zone()
+	return &ExecutionEnvironment{
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		V8Version:   nvm.V8Version(),
+		TimeZone:    zone,
+		ZoneOffset:  offset,
+		FloatDigest: floatDigest(),
+	}
+}
+
+// Fingerprint returns the hash of the properties which must be the same on all validators.
+// The go version, os and arch are reported but not included, they are allowed to differ.
+func (env *ExecutionEnvironment) Fingerprint() string {
+	data := fmt.Sprintf("v8=%s;float=%s;offset=%d", env.V8Version, env.FloatDigest, env.ZoneOffset)
+	return byteutils.Hex(hash.Sha3256([]byte(data)))
+}
+
+// Check returns the problems of the environment which may break the determinism of execution.
+func (env *ExecutionEnvironment) Check() []string {
+	var problems []string
+	if env.V8Version != ExpectedV8Version {
+		problems = append(problems, fmt.Sprintf("v8 engine version is %s, expected %s", env.V8Version, ExpectedV8Version))
+	}
+	if env.FloatDigest != expectedFloatDigest {
+		problems = append(problems, fmt.Sprintf("float arithmetic of %s/%s is not strict IEEE 754", env.OS, env.Arch))
+	}
+	if env.ZoneOffset != 0 {
+		problems = append(problems, fmt.Sprintf("local time zone %s is not UTC", env.TimeZone))
+	}
+	return problems
+}
+
+// floatDigest runs the float operations whose results vary with the fused multiply-add,
+// the rounding mode and the math implementations of a platform, and returns the digest of their bits.
+func floatDigest() string {
+	v := floatProbeOperands
+	results := []float64{
+		v[0]*v[1] - 0.3,
+		v[4]*v[1] - 1,
+		v[2] * 10,
+		v[3] / 2,
+		math.Sqrt(v[0]),
+		math.Exp(v[4]),
+		math.Log(v[5]),
+		math.Pow(v[5], v[4]),
+		math.Sin(v[2]),
+		math.Atan2(v[0], v[4]),
+	}
+	var data []byte
+	for _, r := range results {
+		data = append(data, byteutils.FromUint64(math.Float64bits(r))...)
+	}
+	return byteutils.Hex(hash.Sha3256(data))
+}
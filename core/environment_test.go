@@ -0,0 +1,63 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionEnvironment_Check(t *testing.T) {
+	env := CurrentExecutionEnvironment()
+	assert.Equal(t, expectedFloatDigest, env.FloatDigest)
+	assert.Equal(t, floatDigest(), env.FloatDigest)
+
+	good := &ExecutionEnvironment{
+		GoVersion:   "go1.9",
+		OS:          "linux",
+		Arch:        "amd64",
+		V8Version:   ExpectedV8Version,
+		TimeZone:    "UTC",
+		FloatDigest: expectedFloatDigest,
+	}
+	assert.Empty(t, good.Check())
+
+	tests := []struct {
+		name   string
+		modify func(env *ExecutionEnvironment)
+	}{
+		{"v8 version", func(env *ExecutionEnvironment) { env.V8Version = "5.8.283.38" }},
+		{"float", func(env *ExecutionEnvironment) { env.FloatDigest = "00" }},
+		{"time zone", func(env *ExecutionEnvironment) { env.TimeZone, env.ZoneOffset = "CST", 8*3600 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := *good
+			tt.modify(&bad)
+			assert.Equal(t, 1, len(bad.Check()))
+			assert.NotEqual(t, good.Fingerprint(), bad.Fingerprint())
+		})
+	}
+
+	// the go version, os and arch are allowed to differ.
+	other := *good
+	other.GoVersion, other.OS, other.Arch = "go1.10", "darwin", "arm64"
+	assert.Equal(t, good.Fingerprint(), other.Fingerprint())
+}
@@ -19,9 +19,12 @@
 package core
 
 import (
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
 	"github.com/sirupsen/logrus"
 )
 
@@ -36,12 +39,60 @@ const (
 	// TopicCallSmartContract the topic of call a smart contract.
 	TopicCallSmartContract = "chain.callSmartContract"
 
+	// TopicUpgradeSmartContract the topic of upgrade a smart contract.
+	TopicUpgradeSmartContract = "chain.upgradeSmartContract"
+
+	// TopicContractUpgraded the topic of the code of a contract replaced, with the hashes of the old and new code.
+	TopicContractUpgraded = "chain.contractUpgraded"
+
+	// TopicOracle the topic of feed the oracle data.
+	TopicOracle = "chain.oracle"
+
+	// TopicOracleDataFed the topic of the oracle data of a key recorded, with the feeder and the height.
+	TopicOracleDataFed = "chain.oracleDataFed"
+
 	// TopicDelegate the topic of delegate.
 	TopicDelegate = "chain.delegate"
 
 	// TopicCandidate the topic of candidate.
 	TopicCandidate = "chain.candidate"
 
+	// TopicMultisig the topic of a multisig operation.
+	TopicMultisig = "chain.multisig"
+
+	// TopicMultisigCreated the topic of create a multisig account.
+	TopicMultisigCreated = "chain.multisigCreated"
+
+	// TopicMultisigProposed the topic of propose a transfer from a multisig account.
+	TopicMultisigProposed = "chain.multisigProposed"
+
+	// TopicMultisigSigned the topic of sign a multisig proposal, by the proposer or a co-signer.
+	TopicMultisigSigned = "chain.multisigSigned"
+
+	// TopicMultisigExecuted the topic of execute a multisig proposal signed by enough owners.
+	TopicMultisigExecuted = "chain.multisigExecuted"
+
+	// TopicBatchTransfer the topic of a batch transfer.
+	TopicBatchTransfer = "chain.batchTransfer"
+
+	// TopicBatchTransferOutput the topic of an output transferred in a batch transfer.
+	TopicBatchTransferOutput = "chain.batchTransferOutput"
+
+	// TopicSlash the topic of a slash transaction.
+	TopicSlash = "chain.slash"
+
+	// TopicValidatorSlashed the topic of slash a validator double signed, with the penalty burnt and rewarded.
+	TopicValidatorSlashed = "chain.validatorSlashed"
+
+	// TopicDoubleSign the topic of receive two blocks signed by the same miner at the same slot, with the evidence.
+	TopicDoubleSign = "chain.doubleSign"
+
+	// TopicValidatorOffline the topic of a validator missed more than MaxMissedSlotsPercent of its slots in a dynasty.
+	TopicValidatorOffline = "chain.validatorOffline"
+
+	// TopicDynastyChange the topic of a dynasty ends, with the outgoing and incoming validators, the votes and the kicked.
+	TopicDynastyChange = "chain.dynastyChange"
+
 	// TopicLinkBlock the topic of link a block.
 	TopicLinkBlock = "chain.linkBlock"
 
@@ -50,6 +101,27 @@ const (
 
 	// TopicExecuteTxSuccess the topic of execute a transaction success.
 	TopicExecuteTxSuccess = "chain.executeTxSuccess"
+
+	// TopicNewIrreversibleBlock the topic of a block becomes irreversible, with the block.
+	TopicNewIrreversibleBlock = "chain.newIrreversibleBlock"
+
+	// TopicChainReorg the topic of switch the canonical chain to another fork.
+	TopicChainReorg = "chain.reorg"
+
+	// TopicNewTail the topic of set a block as the tail of canonical chain, with the block.
+	TopicNewTail = "chain.newTail"
+
+	// TopicSyncStarted the topic of start to sync with peers.
+	TopicSyncStarted = "chain.syncStarted"
+
+	// TopicSyncFinished the topic of finish syncing with peers.
+	TopicSyncFinished = "chain.syncFinished"
+
+	// TopicPendingTransaction the topic of admit a transaction into pool, with the transaction in JSON.
+	TopicPendingTransaction = "chain.pendingTransaction"
+
+	// TopicTransactionReplaced the topic of replace a transaction in pool by one of the same nonce and a higher price.
+	TopicTransactionReplaced = "chain.transactionReplaced"
 )
 
 // Event event structure.
@@ -58,21 +130,43 @@ type Event struct {
 	Data  string
 }
 
+const (
+	// DefaultSubscriberQueueSize is the count of events queued for a subscriber, the oldest are dropped if it's full.
+	DefaultSubscriberQueueSize = 1024
+
+	// topicWildcard matches a segment of topics in pattern subscriptions.
+	topicWildcard = "*"
+)
+
+// metrics of event emitter
+var (
+	eventDroppedMeter    = metrics.GetOrRegisterMeter("neb.event.dropped", nil)
+	slowSubscribersGauge = metrics.GetOrRegisterGauge("neb.event.slow_subscribers", nil)
+	slowSubscribersCount int64
+)
+
 // EventEmitter provide event functionality for Nebulas.
+// A topic of subscription is either a topic, or a pattern of topics separated by ".", in which "*" matches
+// a segment, or all the rest segments at the end, e.g. "chain.*" matches "chain.linkBlock" and "chain.contract.foo".
+// The events are queued for each subscriber, so a slow subscriber never blocks the emitter and the others.
 type EventEmitter struct {
-	eventSubs *sync.Map
-	eventCh   chan *Event
-	quitCh    chan int
-	size      int
+	eventSubs   *sync.Map
+	patternSubs *sync.Map
+	eventCh     chan *Event
+	quitCh      chan int
+	size        int
+	queueSize   int
 }
 
 // NewEventEmitter return new EventEmitter.
 func NewEventEmitter(size int) *EventEmitter {
 	return &EventEmitter{
-		eventSubs: new(sync.Map),
-		eventCh:   make(chan *Event, size),
-		quitCh:    make(chan int, 1),
-		size:      size,
+		eventSubs:   new(sync.Map),
+		patternSubs: new(sync.Map),
+		eventCh:     make(chan *Event, size),
+		quitCh:      make(chan int, 1),
+		size:        size,
+		queueSize:   DefaultSubscriberQueueSize,
 	}
 }
 
@@ -103,29 +197,72 @@ func (emitter *EventEmitter) Trigger(e *Event) {
 	emitter.eventCh <- e
 }
 
+func isTopicPattern(topic string) bool {
+	for _, v := range strings.Split(topic, ".") {
+		if v == topicWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTopic returns if the topic matches the pattern.
+func matchTopic(pattern, topic string) bool {
+	ps, ts := strings.Split(pattern, "."), strings.Split(topic, ".")
+	for i, p := range ps {
+		if i == len(ts) {
+			return false
+		}
+		if p == topicWildcard {
+			if i == len(ps)-1 {
+				return true
+			}
+			continue
+		}
+		if p != ts[i] {
+			return false
+		}
+	}
+	return len(ps) == len(ts)
+}
+
 // Register register event chan.
 func (emitter *EventEmitter) Register(topic string, ch chan *Event) error {
+	subs := emitter.eventSubs
+	if isTopicPattern(topic) {
+		subs = emitter.patternSubs
+	}
 
-	v, ok := emitter.eventSubs.Load(topic)
+	v, ok := subs.Load(topic)
 	if !ok {
-		v, _ = emitter.eventSubs.LoadOrStore(topic, new(sync.Map))
+		v, _ = subs.LoadOrStore(topic, new(sync.Map))
 	}
 
 	m, _ := v.(*sync.Map)
-	m.Store(ch, true)
+	sub := newSubscriber(topic, ch, emitter.queueSize)
+	if _, loaded := m.LoadOrStore(ch, sub); !loaded {
+		go sub.loop()
+	}
 
 	return nil
 }
 
 // Deregister deregister event chan.
 func (emitter *EventEmitter) Deregister(topic string, ch chan *Event) error {
+	subs := emitter.eventSubs
+	if isTopicPattern(topic) {
+		subs = emitter.patternSubs
+	}
 
-	v, ok := emitter.eventSubs.Load(topic)
+	v, ok := subs.Load(topic)
 	if !ok {
 		return nil
 	}
 	m, _ := v.(*sync.Map)
-	m.Delete(ch)
+	if sub, ok := m.Load(ch); ok {
+		m.Delete(ch)
+		sub.(*subscriber).stop()
+	}
 
 	return nil
 }
@@ -139,18 +276,122 @@ func (emitter *EventEmitter) loop() {
 			logging.CLog().Info("ShutDowned EventEmitter.")
 			return
 		case e := <-emitter.eventCh:
+			emitter.dispatch(e)
+		}
+	}
+}
 
-			topic := e.Topic
-			v, ok := emitter.eventSubs.Load(topic)
-			if !ok {
-				continue
-			}
+func (emitter *EventEmitter) dispatch(e *Event) {
+	notify := func(key, value interface{}) bool {
+		value.(*subscriber).push(e)
+		return true
+	}
+	if v, ok := emitter.eventSubs.Load(e.Topic); ok {
+		v.(*sync.Map).Range(notify)
+	}
+	emitter.patternSubs.Range(func(key, value interface{}) bool {
+		if matchTopic(key.(string), e.Topic) {
+			value.(*sync.Map).Range(notify)
+		}
+		return true
+	})
+}
 
-			m, _ := v.(*sync.Map)
-			m.Range(func(key, value interface{}) bool {
-				key.(chan *Event) <- e
-				return true
-			})
+// subscriber queues the events for the chan of a subscription, and delivers them in order.
+type subscriber struct {
+	topic string
+	ch    chan *Event
+
+	mu      sync.Mutex
+	queue   []*Event
+	size    int
+	dropped int64
+	slow    bool
+
+	notifyCh chan struct{}
+	quitCh   chan struct{}
+}
+
+func newSubscriber(topic string, ch chan *Event, size int) *subscriber {
+	return &subscriber{
+		topic:    topic,
+		ch:       ch,
+		size:     size,
+		notifyCh: make(chan struct{}, 1),
+		quitCh:   make(chan struct{}),
+	}
+}
+
+// push queues the event, the oldest one is dropped if the queue is full.
+func (sub *subscriber) push(e *Event) {
+	sub.mu.Lock()
+	if len(sub.queue) >= sub.size {
+		sub.queue = sub.queue[1:]
+		sub.dropped++
+		eventDroppedMeter.Mark(1)
+		if !sub.slow {
+			// a subscriber is slow since it drops events, until its queue is drained.
+			sub.slow = true
+			slowSubscribersGauge.Update(atomic.AddInt64(&slowSubscribersCount, 1))
+			logging.VLog().WithFields(logrus.Fields{
+				"topic": sub.topic,
+				"size":  sub.size,
+			}).Warn("Slow event subscriber, dropped the oldest events.")
+		}
+	}
+	sub.queue = append(sub.queue, e)
+	sub.mu.Unlock()
+
+	select {
+	case sub.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the oldest event queued, nil if empty.
+func (sub *subscriber) pop() *Event {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if len(sub.queue) == 0 {
+		sub.recover()
+		return nil
+	}
+	e := sub.queue[0]
+	sub.queue[0] = nil
+	sub.queue = sub.queue[1:]
+	return e
+}
+
+// recover marks the slow subscriber normal, the caller holds the lock.
+func (sub *subscriber) recover() {
+	if sub.slow {
+		sub.slow = false
+		slowSubscribersGauge.Update(atomic.AddInt64(&slowSubscribersCount, -1))
+	}
+}
+
+func (sub *subscriber) stop() {
+	close(sub.quitCh)
+}
+
+func (sub *subscriber) loop() {
+	defer func() {
+		sub.mu.Lock()
+		sub.recover()
+		sub.mu.Unlock()
+	}()
+	for {
+		select {
+		case <-sub.quitCh:
+			return
+		case <-sub.notifyCh:
+		}
+		for e := sub.pop(); e != nil; e = sub.pop() {
+			select {
+			case sub.ch <- e:
+			case <-sub.quitCh:
+				return
+			}
 		}
 	}
 }
@@ -0,0 +1,318 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// EventFilterTimeout is the time a filter is kept without being polled.
+	EventFilterTimeout = 5 * time.Minute
+
+	// MaxEventFilters is the max count of filters installed.
+	MaxEventFilters = 1024
+
+	// MaxFilterTopics is the max count of topics of a filter.
+	MaxFilterTopics = 16
+
+	// MaxFilterChanges is the count of events kept for a filter between polls, the oldest are dropped.
+	MaxFilterChanges = 1024
+)
+
+// Errors of event filters
+var (
+	ErrEventFilterNotFound = errors.New("event filter not found")
+	ErrTooManyEventFilters = errors.New("too many event filters")
+	ErrInvalidEventFilter  = errors.New("invalid event filter, 1 to 16 topics and an ascending height range required")
+)
+
+// EventFilterCriteria is the events a filter matches, those of the topics, emitted by the contract if
+// it's not nil, between the heights. FromHeight is from 1 if 0, ToHeight is unbounded if 0.
+type EventFilterCriteria struct {
+	Topics     []string
+	Contract   *Address
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+func (c *EventFilterCriteria) matchHeight(height uint64) bool {
+	return height >= c.FromHeight && (c.ToHeight == 0 || height <= c.ToHeight)
+}
+
+// eventFilter keeps the position in the event store of each topic, the events stored after it are
+// the changes of the filter.
+type eventFilter struct {
+	criteria *EventFilterCriteria
+	lists    [][]byte
+	cursors  []uint64
+	changes  []*StoredEvent
+	lastPoll time.Time
+}
+
+// newTailEvent is the data of TopicNewTail.
+type newTailEvent struct {
+	Height         uint64 `json:"height"`
+	Hash           string `json:"hash"`
+	AncestorHeight uint64 `json:"ancestor_height"`
+}
+
+// triggerNewTail notifies the subscribers of TopicNewTail, with the common ancestor of the old tail.
+func (bc *BlockChain) triggerNewTail(ancestor, tail *Block) {
+	if bc.eventEmitter == nil {
+		return
+	}
+	data, err := json.Marshal(&newTailEvent{
+		Height:         tail.Height(),
+		Hash:           tail.Hash().String(),
+		AncestorHeight: ancestor.Height(),
+	})
+	if err != nil {
+		return
+	}
+	bc.eventEmitter.Trigger(&Event{Topic: TopicNewTail, Data: string(data)})
+}
+
+// EventFilters installs the filters on events, their history is read from the event store, and their
+// changes are collected from the store once the tail of canonical chain is set, so the events of blocks
+// reverted are never reported and those applied again are reported again.
+type EventFilters struct {
+	mu      sync.Mutex
+	bc      *BlockChain
+	filters map[string]*eventFilter
+	eventCh chan *Event
+	quitCh  chan int
+	now     func() time.Time
+}
+
+func newEventFilters(bc *BlockChain) *EventFilters {
+	return &EventFilters{
+		bc:      bc,
+		filters: make(map[string]*eventFilter),
+		eventCh: make(chan *Event, 128),
+		quitCh:  make(chan int, 1),
+		now:     time.Now,
+	}
+}
+
+// EventFilters returns the event filters of the chain.
+func (bc *BlockChain) EventFilters() *EventFilters {
+	return bc.eventFilters
+}
+
+// Start start the event filters.
+func (f *EventFilters) Start() {
+	if f.bc.eventEmitter != nil {
+		f.bc.eventEmitter.Register(TopicNewTail, f.eventCh)
+	}
+	go f.loop()
+}
+
+// Stop stop the event filters.
+func (f *EventFilters) Stop() {
+	if f.bc.eventEmitter != nil {
+		f.bc.eventEmitter.Deregister(TopicNewTail, f.eventCh)
+	}
+	f.quitCh <- 0
+}
+
+func (f *EventFilters) loop() {
+	logging.CLog().Info("Launched EventFilters.")
+	ticker := time.NewTicker(EventFilterTimeout / 5)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.quitCh:
+			logging.CLog().Info("Shutdowned EventFilters.")
+			return
+		case <-ticker.C:
+			f.expire()
+		case e := <-f.eventCh:
+			tail := new(newTailEvent)
+			if err := json.Unmarshal([]byte(e.Data), tail); err != nil {
+				continue
+			}
+			f.onNewTail(tail.AncestorHeight)
+		}
+	}
+}
+
+// Install installs a filter of the criteria, it returns the id of the filter.
+func (f *EventFilters) Install(criteria *EventFilterCriteria) (string, error) {
+	if len(criteria.Topics) == 0 || len(criteria.Topics) > MaxFilterTopics {
+		return "", ErrInvalidEventFilter
+	}
+	if criteria.FromHeight == 0 {
+		criteria.FromHeight = 1
+	}
+	if criteria.ToHeight > 0 && criteria.ToHeight < criteria.FromHeight {
+		return "", ErrInvalidEventFilter
+	}
+
+	filter := &eventFilter{criteria: criteria, lastPoll: f.now()}
+	for _, topic := range criteria.Topics {
+		list := eventTopicKey(topic)
+		if criteria.Contract != nil {
+			list = eventContractKey(criteria.Contract.Bytes(), topic)
+		}
+		filter.lists = append(filter.lists, list)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.filters) >= MaxEventFilters {
+		return "", ErrTooManyEventFilters
+	}
+	// the changes are the events stored after the filter is installed.
+	for _, list := range filter.lists {
+		filter.cursors = append(filter.cursors, f.bc.storedEventsCount(list))
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	f.filters[byteutils.Hex(id)] = filter
+	return byteutils.Hex(id), nil
+}
+
+// Uninstall removes the filter.
+func (f *EventFilters) Uninstall(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.filters[id]; !ok {
+		return ErrEventFilterNotFound
+	}
+	delete(f.filters, id)
+	return nil
+}
+
+// Logs returns the events of the filter in the event store, oldest first, at most MaxEventsPerQuery.
+func (f *EventFilters) Logs(id string) ([]*StoredEvent, error) {
+	f.mu.Lock()
+	filter, ok := f.filters[id]
+	if ok {
+		filter.lastPoll = f.now()
+	}
+	f.mu.Unlock()
+	if !ok {
+		return nil, ErrEventFilterNotFound
+	}
+
+	criteria := filter.criteria
+	to := criteria.ToHeight
+	if to == 0 {
+		to = f.bc.TailBlock().Height()
+	}
+	var events []*StoredEvent
+	for _, list := range filter.lists {
+		found, err := f.bc.fetchEvents(list, criteria.FromHeight, to, MaxEventsPerQuery)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, found...)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Height < events[j].Height
+	})
+	if len(events) > MaxEventsPerQuery {
+		events = events[:MaxEventsPerQuery]
+	}
+	return events, nil
+}
+
+// Changes returns the events of the filter stored since the last poll, oldest first.
+func (f *EventFilters) Changes(id string) ([]*StoredEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	filter, ok := f.filters[id]
+	if !ok {
+		return nil, ErrEventFilterNotFound
+	}
+	changes := filter.changes
+	filter.changes = nil
+	filter.lastPoll = f.now()
+	return changes, nil
+}
+
+// onNewTail collects the changes of the filters. The cursors are moved back above the common ancestor
+// of the old tail first, the events above it were reverted or stored again.
+func (f *EventFilters) onNewTail(ancestorHeight uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id, filter := range f.filters {
+		if err := f.collect(filter, ancestorHeight); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"filter": id,
+				"err":    err,
+			}).Error("Failed to collect the changes of event filter.")
+		}
+	}
+}
+
+func (f *EventFilters) collect(filter *eventFilter, ancestorHeight uint64) error {
+	var changes []*StoredEvent
+	for i, list := range filter.lists {
+		first, err := f.bc.firstStoredEvent(list, ancestorHeight+1)
+		if err != nil {
+			return err
+		}
+		if first < filter.cursors[i] {
+			filter.cursors[i] = first
+		}
+		count := f.bc.storedEventsCount(list)
+		for ; filter.cursors[i] < count; filter.cursors[i]++ {
+			record, err := f.bc.storedEvent(list, filter.cursors[i])
+			if err != nil {
+				return err
+			}
+			if filter.criteria.matchHeight(record.Height) {
+				changes = append(changes, record.storedEvent())
+			}
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Height < changes[j].Height
+	})
+	filter.changes = append(filter.changes, changes...)
+	if len(filter.changes) > MaxFilterChanges {
+		filter.changes = filter.changes[len(filter.changes)-MaxFilterChanges:]
+	}
+	return nil
+}
+
+// expire uninstalls the filters not polled in EventFilterTimeout.
+func (f *EventFilters) expire() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+	for id, filter := range f.filters {
+		if now.Sub(filter.lastPoll) > EventFilterTimeout {
+			delete(f.filters, id)
+		}
+	}
+}
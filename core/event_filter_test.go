@@ -0,0 +1,118 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilters(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+	filters := bc.EventFilters()
+	contract := mockAddress()
+
+	// store appends an event of the contract to the store as storeEvents.
+	store := func(height uint64, topic string, tx string) {
+		record := &eventRecord{Height: height, TxHash: byteutils.Hash(tx), Contract: contract.Bytes(), Topic: topic}
+		data, err := json.Marshal(record)
+		assert.Nil(t, err)
+		for _, list := range [][]byte{eventTopicKey(topic), eventContractKey(contract.Bytes(), topic)} {
+			count := bc.storedEventsCount(list)
+			assert.Nil(t, bc.storage.Put(eventKey(list, count), data))
+			assert.Nil(t, bc.storage.Put(list, byteutils.FromUint64(count+1)))
+		}
+	}
+	// unwind removes the last event of the topic as unindexEvents.
+	unwind := func(topic string) {
+		for _, list := range [][]byte{eventTopicKey(topic), eventContractKey(contract.Bytes(), topic)} {
+			count := bc.storedEventsCount(list)
+			assert.Nil(t, bc.storage.Del(eventKey(list, count-1)))
+			assert.Nil(t, bc.storage.Put(list, byteutils.FromUint64(count-1)))
+		}
+	}
+	txs := func(events []*StoredEvent) []string {
+		var hashes []string
+		for _, v := range events {
+			hashes = append(hashes, string(v.TxHash))
+		}
+		return hashes
+	}
+
+	_, err := filters.Install(&EventFilterCriteria{})
+	assert.Equal(t, ErrInvalidEventFilter, err)
+	_, err = filters.Install(&EventFilterCriteria{Topics: []string{"a"}, FromHeight: 5, ToHeight: 4})
+	assert.Equal(t, ErrInvalidEventFilter, err)
+
+	store(2, "a", "tx1")
+	id, err := filters.Install(&EventFilterCriteria{Topics: []string{"a", "b"}, Contract: contract, ToHeight: 10})
+	assert.Nil(t, err)
+	other, err := filters.Install(&EventFilterCriteria{Topics: []string{"b"}, FromHeight: 4})
+	assert.Nil(t, err)
+
+	// the history is in logs, the changes are the events stored after the install.
+	logs, err := filters.Logs(id)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tx1"}, txs(logs))
+	store(3, "b", "tx2")
+	store(3, "a", "tx3")
+	filters.onNewTail(2)
+	changes, err := filters.Changes(id)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"tx2", "tx3"}, txs(changes))
+	changes, err = filters.Changes(other)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(changes))
+	changes, err = filters.Changes(id)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(changes))
+
+	// the events above the ancestor of a reorg are reported again.
+	unwind("a")
+	unwind("b")
+	store(3, "b", "tx2")
+	store(4, "b", "tx4")
+	filters.onNewTail(2)
+	changes, err = filters.Changes(id)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tx2", "tx4"}, txs(changes))
+	changes, err = filters.Changes(other)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tx4"}, txs(changes))
+	logs, err = filters.Logs(id)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tx1", "tx2", "tx4"}, txs(logs))
+
+	// the filters not polled expire.
+	assert.Nil(t, filters.Uninstall(other))
+	assert.Equal(t, ErrEventFilterNotFound, filters.Uninstall(other))
+	now := time.Now()
+	filters.now = func() time.Time { return now }
+	_, err = filters.Changes(id)
+	assert.Nil(t, err)
+	now = now.Add(EventFilterTimeout + time.Second)
+	filters.expire()
+	_, err = filters.Changes(id)
+	assert.Equal(t, ErrEventFilterNotFound, err)
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+const (
+	// MaxEventsPerQuery is the max count of events returned by a query of topic.
+	MaxEventsPerQuery = 100
+
+	// eventTopicPrefix is the prefix of the keys of the events by topic, the count of events of a topic
+	// is stored at the prefix with the hash of topic, and the events at the positions appended.
+	eventTopicPrefix = "event_topic_"
+
+	// eventContractPrefix is the prefix of the keys of the events by contract and topic, laid out as eventTopicPrefix.
+	eventContractPrefix = "event_contract_"
+)
+
+// StoredEvent is an event in canonical chain, with the transaction and contract emitting it.
+type StoredEvent struct {
+	Height   uint64
+	TxHash   byteutils.Hash
+	Contract *Address
+	Event    *Event
+}
+
+// eventRecord is the value of an event in the event store.
+type eventRecord struct {
+	Height   uint64         `json:"height"`
+	TxHash   byteutils.Hash `json:"tx_hash"`
+	Contract []byte         `json:"contract,omitempty"`
+	Topic    string         `json:"topic"`
+	Data     string         `json:"data"`
+}
+
+func eventTopicKey(topic string) []byte {
+	return append([]byte(eventTopicPrefix), hash.Sha3256([]byte(topic))...)
+}
+
+func eventContractKey(contract []byte, topic string) []byte {
+	key := append([]byte(eventContractPrefix), contract...)
+	return append(key, hash.Sha3256([]byte(topic))...)
+}
+
+func eventKey(list []byte, index uint64) []byte {
+	return append(append([]byte{}, list...), byteutils.FromUint64(index)...)
+}
+
+// eventContract returns the contract emitting the events of the transaction, nil if none.
+func eventContract(tx *Transaction, receipt *Receipt) *Address {
+	if receipt != nil && receipt.ContractAddress() != nil {
+		return receipt.ContractAddress()
+	}
+	if tx.Type() == TxPayloadCallType {
+		return tx.to
+	}
+	return nil
+}
+
+// eventTopicContract returns the contract triggering the event of topic, nil if it's not a contract event.
+// The events of the contracts called by others are recorded under the transaction calling them.
+func eventTopicContract(topic string) *Address {
+	if !strings.HasPrefix(topic, nvm.EventNameSpaceContract+".") {
+		return nil
+	}
+	segments := strings.SplitN(topic[len(nvm.EventNameSpaceContract)+1:], ".", 2)
+	if len(segments) != 2 {
+		return nil
+	}
+	addr, err := AddressParse(segments[0])
+	if err != nil {
+		return nil
+	}
+	return addr
+}
+
+// FetchEventsByTopic returns the events of the topic in canonical chain from height from to height to,
+// oldest first. At most MaxEventsPerQuery events are returned, query again from the height of the
+// last one to page them. The events are stored when their blocks become canonical, those before
+// the store is built or before a snapshot fast synced are not found.
+func (bc *BlockChain) FetchEventsByTopic(topic string, from, to, limit uint64) ([]*StoredEvent, error) {
+	return bc.fetchEvents(eventTopicKey(topic), from, to, limit)
+}
+
+// FetchContractEventsByTopic returns the events of the topic emitted by the contract, as FetchEventsByTopic.
+func (bc *BlockChain) FetchContractEventsByTopic(contract *Address, topic string, from, to, limit uint64) ([]*StoredEvent, error) {
+	return bc.fetchEvents(eventContractKey(contract.Bytes(), topic), from, to, limit)
+}
+
+func (bc *BlockChain) fetchEvents(list []byte, from, to, limit uint64) ([]*StoredEvent, error) {
+	if limit > MaxEventsPerQuery {
+		limit = MaxEventsPerQuery
+	}
+	count := bc.storedEventsCount(list)
+
+	// the events are stored in ascending heights, search the first one from the height.
+	start, err := bc.firstStoredEvent(list, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*StoredEvent
+	for index := start; index < count && uint64(len(events)) < limit; index++ {
+		record, err := bc.storedEvent(list, index)
+		if err != nil {
+			return nil, err
+		}
+		if record.Height > to {
+			break
+		}
+		events = append(events, record.storedEvent())
+	}
+	return events, nil
+}
+
+func (record *eventRecord) storedEvent() *StoredEvent {
+	event := &StoredEvent{
+		Height: record.Height,
+		TxHash: record.TxHash,
+		Event:  &Event{Topic: record.Topic, Data: record.Data},
+	}
+	if len(record.Contract) > 0 {
+		event.Contract = &Address{record.Contract}
+	}
+	return event
+}
+
+// firstStoredEvent returns the index of the first event in the list at or above the height.
+func (bc *BlockChain) firstStoredEvent(list []byte, height uint64) (uint64, error) {
+	var err error
+	index := sort.Search(int(bc.storedEventsCount(list)), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var record *eventRecord
+		record, err = bc.storedEvent(list, uint64(i))
+		return err != nil || record.Height >= height
+	})
+	return uint64(index), err
+}
+
+func (bc *BlockChain) storedEventsCount(list []byte) uint64 {
+	data, err := bc.storage.Get(list)
+	if err != nil {
+		return 0
+	}
+	return byteutils.Uint64(data)
+}
+
+func (bc *BlockChain) storedEvent(list []byte, index uint64) (*eventRecord, error) {
+	data, err := bc.storage.Get(eventKey(list, index))
+	if err != nil {
+		return nil, err
+	}
+	record := new(eventRecord)
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// indexEvents unwinds the events of the blocks reverted, and stores those of the blocks applied.
+func (bc *BlockChain) indexEvents(reverted, applied []*Block) error {
+	for _, block := range reverted {
+		for i := len(block.transactions) - 1; i >= 0; i-- {
+			if err := bc.unindexEvents(block, block.transactions[i]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, block := range applied {
+		for _, tx := range block.transactions {
+			if err := bc.storeEvents(block, tx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// eventLists returns the records of the events of the transaction, and the lists each is stored in.
+func eventLists(block *Block, tx *Transaction) ([]*eventRecord, [][][]byte, error) {
+	events, err := block.FetchEvents(tx.Hash())
+	if err != nil || len(events) == 0 {
+		return nil, nil, err
+	}
+	receipt, err := block.GetReceipt(tx.Hash())
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, nil, err
+	}
+	contract := eventContract(tx, receipt)
+
+	records := make([]*eventRecord, len(events))
+	lists := make([][][]byte, len(events))
+	for i, e := range events {
+		records[i] = &eventRecord{Height: block.Height(), TxHash: tx.Hash(), Topic: e.Topic, Data: e.Data}
+		lists[i] = [][]byte{eventTopicKey(e.Topic)}
+		emitter := contract
+		if c := eventTopicContract(e.Topic); c != nil {
+			emitter = c
+		}
+		if emitter != nil {
+			records[i].Contract = emitter.Bytes()
+			lists[i] = append(lists[i], eventContractKey(emitter.Bytes(), e.Topic))
+		}
+	}
+	return records, lists, nil
+}
+
+func (bc *BlockChain) storeEvents(block *Block, tx *Transaction) error {
+	records, lists, err := eventLists(block, tx)
+	if err != nil {
+		return err
+	}
+	for i, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		for _, list := range lists[i] {
+			count := bc.storedEventsCount(list)
+			if err := bc.storage.Put(eventKey(list, count), data); err != nil {
+				return err
+			}
+			if err := bc.storage.Put(list, byteutils.FromUint64(count+1)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unindexEvents removes the events of the transaction stored last.
+func (bc *BlockChain) unindexEvents(block *Block, tx *Transaction) error {
+	_, lists, err := eventLists(block, tx)
+	if err != nil {
+		return err
+	}
+	for i := len(lists) - 1; i >= 0; i-- {
+		for _, list := range lists[i] {
+			count := bc.storedEventsCount(list)
+			if count == 0 {
+				continue
+			}
+			record, err := bc.storedEvent(list, count-1)
+			if err != nil && err != storage.ErrKeyNotFound {
+				return err
+			}
+			if record == nil || record.Height != block.Height() || !record.TxHash.Equals(tx.Hash()) {
+				// the event wasn't stored.
+				continue
+			}
+			if err := bc.storage.Del(eventKey(list, count-1)); err != nil {
+				return err
+			}
+			if count == 1 {
+				err = bc.storage.Del(list)
+			} else {
+				err = bc.storage.Put(list, byteutils.FromUint64(count-1))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
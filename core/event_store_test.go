@@ -0,0 +1,178 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_FetchEventsByTopic(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	to := mockAddress()
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	bc.tailBlock.header.stateRoot = bc.tailBlock.accState.RootHash()
+	bc.tailBlock.commit()
+	bc.storeBlockToStorage(bc.tailBlock)
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	var timestamp int64
+	mint := func(parent *Block, txs ...*Transaction) *Block {
+		for _, tx := range txs {
+			assert.Nil(t, tx.Sign(signature))
+			assert.Nil(t, bc.txPool.Push(tx))
+		}
+		timestamp += BlockInterval
+		coinbase := &Address{validators[int(timestamp/BlockInterval)%len(validators)]}
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(len(txs))
+		assert.Equal(t, len(txs), len(block.transactions))
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		block = bc.GetBlock(block.Hash())
+		assert.Nil(t, bc.SetTailBlock(block))
+		return block
+	}
+	transfer := func(nonce uint64) *Transaction {
+		return NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), nonce, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+	}
+	call := func(nonce uint64) *Transaction {
+		return NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(0), nonce, TxPayloadCallType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+	}
+
+	/*
+		genesis -- 2 -- 3
+		             \_ 3' -- 4'
+	*/
+	tx1, tx2, tx3 := transfer(1), call(2), transfer(2)
+	block2 := mint(bc.tailBlock, tx1)
+	mint(block2, tx2)
+
+	events, err := bc.FetchEventsByTopic(TopicExecuteTxSuccess, 0, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, uint64(2), events[0].Height)
+	assert.Equal(t, tx1.Hash(), events[0].TxHash)
+	assert.Nil(t, events[0].Contract)
+	assert.Equal(t, TopicExecuteTxSuccess, events[0].Event.Topic)
+
+	events, err = bc.FetchEventsByTopic(TopicExecuteTxFailed, 0, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, uint64(3), events[0].Height)
+	assert.Equal(t, to.Bytes(), events[0].Contract.Bytes())
+	events, err = bc.FetchContractEventsByTopic(to, TopicExecuteTxFailed, 0, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, tx2.Hash(), events[0].TxHash)
+	events, err = bc.FetchContractEventsByTopic(mockAddress(), TopicExecuteTxFailed, 0, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	// the events of the blocks reverted are unwound.
+	block3 := mint(block2)
+	bc.txPool.Pop()
+	mint(block3, tx3)
+	events, err = bc.FetchEventsByTopic(TopicExecuteTxFailed, 0, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+	events, err = bc.FetchContractEventsByTopic(to, TopicExecuteTxFailed, 0, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	events, err = bc.FetchEventsByTopic(TopicExecuteTxSuccess, 0, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, tx1.Hash(), events[0].TxHash)
+	assert.Equal(t, tx3.Hash(), events[1].TxHash)
+	assert.Equal(t, uint64(4), events[1].Height)
+
+	// the events are queried in the range of heights, and limited.
+	events, err = bc.FetchEventsByTopic(TopicExecuteTxSuccess, 3, 10, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, tx3.Hash(), events[0].TxHash)
+	events, err = bc.FetchEventsByTopic(TopicExecuteTxSuccess, 0, 3, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, tx1.Hash(), events[0].TxHash)
+	events, err = bc.FetchEventsByTopic(TopicExecuteTxSuccess, 0, 10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, tx1.Hash(), events[0].TxHash)
+}
+
+func TestEventTopicContract(t *testing.T) {
+	addr := mockAddress()
+	tests := []struct {
+		topic    string
+		contract *Address
+	}{
+		{nvm.ContractEventTopic(addr.String(), "transfer"), addr},
+		{nvm.ContractEventTopic(addr.String(), "transfer.done"), addr},
+		{nvm.EventNameSpaceContract + "." + addr.String(), nil},
+		{nvm.ContractEventTopic("nas", "transfer"), nil},
+		{TopicExecuteTxSuccess, nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.contract, eventTopicContract(tt.topic), tt.topic)
+	}
+}
+
+func TestEventLists_CalledContract(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+
+	from, caller, callee := mockAddress(), mockAddress(), mockAddress()
+	tx := NewTransaction(bc.ChainID(), from, caller, util.NewUint128(), 1, TxPayloadCallType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+	tx.hash, _ = HashTransaction(tx)
+	topic := nvm.ContractEventTopic(callee.String(), "transfer")
+	assert.Nil(t, block.RecordEvent(tx.hash, topic, "{}"))
+	assert.Nil(t, block.RecordEvent(tx.hash, TopicExecuteTxSuccess, "{}"))
+
+	// the event of the called contract is stored as its own, the others as of the contract of tx.
+	records, lists, err := eventLists(block, tx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, callee.Bytes(), records[0].Contract)
+	assert.Equal(t, [][]byte{eventTopicKey(topic), eventContractKey(callee.Bytes(), topic)}, lists[0])
+	assert.Equal(t, caller.Bytes(), records[1].Contract)
+}
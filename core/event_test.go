@@ -194,3 +194,90 @@ func TestEventEmitterDeregister(t *testing.T) {
 	ch := make(chan *Event, 1)
 	assert.Nil(t, emitter.Deregister("wow", ch))
 }
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		match   bool
+	}{
+		{"chain.*", "chain.linkBlock", true},
+		{"chain.*", "chain.contract.foo", true},
+		{"chain.*", "chain", false},
+		{"chain.*", "node.linkBlock", false},
+		{"chain.*.foo", "chain.contract.foo", true},
+		{"chain.*.foo", "chain.contract.bar", false},
+		{"chain.*.foo", "chain.contract.foo.bar", false},
+		{"*", "chain", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, matchTopic(tt.pattern, tt.topic), tt.pattern+" "+tt.topic)
+	}
+}
+
+func TestEventEmitterPattern(t *testing.T) {
+	emitter := NewEventEmitter(1024)
+	emitter.Start()
+	defer emitter.Stop()
+
+	ch := register(emitter, "chain.*")
+	emitter.Trigger(&Event{Topic: "node.topic.11"})
+	emitter.Trigger(&Event{Topic: TopicLinkBlock})
+	emitter.Trigger(&Event{Topic: "chain.contract.foo"})
+
+	for _, topic := range []string{TopicLinkBlock, "chain.contract.foo"} {
+		select {
+		case e := <-ch:
+			assert.Equal(t, topic, e.Topic)
+		case <-time.After(time.Second):
+			t.Fatal("missing event " + topic)
+		}
+	}
+
+	assert.Nil(t, emitter.Deregister("chain.*", ch))
+	emitter.Trigger(&Event{Topic: TopicLinkBlock})
+	select {
+	case e := <-ch:
+		t.Fatal("unexpected event " + e.Topic)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestEventEmitterSlowSubscriber(t *testing.T) {
+	emitter := NewEventEmitter(1024)
+	emitter.Start()
+	defer emitter.Stop()
+
+	// the stuck subscriber never blocks the others.
+	emitter.queueSize = 4
+	stuck := make(chan *Event)
+	emitter.Register(TopicLinkBlock, stuck)
+	emitter.queueSize = DefaultSubscriberQueueSize
+	ch := register(emitter, TopicLinkBlock)
+
+	for i := 0; i < 10; i++ {
+		emitter.Trigger(&Event{Topic: TopicLinkBlock, Data: fmt.Sprintf("%d", i)})
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case e := <-ch:
+			assert.Equal(t, fmt.Sprintf("%d", i), e.Data)
+		case <-time.After(time.Second):
+			t.Fatal("blocked by the stuck subscriber")
+		}
+	}
+
+	// the stuck subscriber gets at most the event it was stuck on, and the newest ones.
+	received := []string{}
+	for done := false; !done; {
+		select {
+		case e := <-stuck:
+			received = append(received, e.Data)
+		case <-time.After(time.Millisecond * 200):
+			done = true
+		}
+	}
+	assert.True(t, len(received) >= 4 && len(received) <= 5)
+	assert.Equal(t, []string{"6", "7", "8", "9"}, received[len(received)-4:])
+	assert.Nil(t, emitter.Deregister(TopicLinkBlock, stuck))
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+)
+
+// Codes of the failure reasons of transaction execution
+const (
+	ExecutionErrOutOfGas            = "out_of_gas"
+	ExecutionErrInsufficientBalance = "insufficient_balance"
+	ExecutionErrInvalidPayload      = "invalid_payload"
+	ExecutionErrReverted            = "reverted"
+	ExecutionErrFailed              = "failed"
+)
+
+// ExecutionError is the structured reason of a transaction failed in execution,
+// recorded in the TopicExecuteTxFailed event and the receipt of the transaction.
+type ExecutionError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// NewExecutionError returns the execution error of code caused by err.
+func NewExecutionError(code string, err error) *ExecutionError {
+	e := &ExecutionError{Code: code}
+	if err != nil {
+		e.Message = err.Error()
+	}
+	return e
+}
+
+func (e *ExecutionError) Error() string {
+	if e.Message == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Message
+}
+
+// toExecutionError classifies the error failing a transaction.
+func toExecutionError(err error) *ExecutionError {
+	if e, ok := err.(*ExecutionError); ok {
+		return e
+	}
+	switch err {
+	case ErrOutOfGasLimit, nvm.ErrInsufficientGas:
+		return NewExecutionError(ExecutionErrOutOfGas, err)
+	case ErrInsufficientBalance:
+		return NewExecutionError(ExecutionErrInsufficientBalance, err)
+	}
+	return NewExecutionError(ExecutionErrFailed, err)
+}
+
+// contractExecutionError returns the error of the contract execution in engine,
+// with the message thrown by the contract if it reverted.
+func contractExecutionError(engine *nvm.V8Engine, err error) error {
+	if err != nvm.ErrExecutionFailed {
+		return err
+	}
+	if msg := engine.Exception(); msg != "" {
+		return &ExecutionError{Code: ExecutionErrReverted, Message: msg}
+	}
+	return err
+}
+
+// executionErrorOf returns the failure reason recorded in the data of a TopicExecuteTxFailed event.
+func executionErrorOf(event *Event) *ExecutionError {
+	if event.Topic != TopicExecuteTxFailed {
+		return nil
+	}
+	var data struct {
+		Reason *ExecutionError `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+		return nil
+	}
+	return data.Reason
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToExecutionError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{ErrOutOfGasLimit, ExecutionErrOutOfGas},
+		{nvm.ErrInsufficientGas, ExecutionErrOutOfGas},
+		{ErrInsufficientBalance, ExecutionErrInsufficientBalance},
+		{&ExecutionError{Code: ExecutionErrReverted, Message: "Error: paused"}, ExecutionErrReverted},
+		{errors.New("unknown"), ExecutionErrFailed},
+	}
+	for _, tt := range tests {
+		e := toExecutionError(tt.err)
+		assert.Equal(t, tt.code, e.Code)
+	}
+	assert.Equal(t, "reverted: Error: paused", toExecutionError(tests[3].err).Error())
+	assert.Equal(t, "out_of_gas", (&ExecutionError{Code: ExecutionErrOutOfGas}).Error())
+}
+
+func TestTransaction_ExecutionError(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.begin()
+
+	from := mockAddress()
+	block.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(util.NewUint128FromInt(1000000000000))
+	nonce := uint64(0)
+	failure := func(payloadType string, data []byte, gasLimit int64) *ExecutionError {
+		nonce++
+		tx := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), nonce, payloadType, data, TransactionGasPrice, util.NewUint128FromInt(gasLimit))
+		tx.hash, _ = HashTransaction(tx)
+		_, err := block.executeTransaction(tx)
+		assert.Nil(t, err)
+		events, err := block.FetchEvents(tx.hash)
+		assert.Nil(t, err)
+		assert.Equal(t, TopicExecuteTxFailed, events[len(events)-1].Topic)
+		receipt, err := block.GetReceipt(tx.hash)
+		assert.Nil(t, err)
+		assert.Equal(t, ReceiptStatusFailed, receipt.Status())
+		assert.Equal(t, executionErrorOf(events[len(events)-1]), receipt.Failure())
+		return receipt.Failure()
+	}
+
+	assert.Equal(t, ExecutionErrInvalidPayload, failure(TxPayloadMultisigType, []byte("nas"), 200000).Code)
+	assert.Equal(t, ExecutionErrOutOfGas, failure(TxPayloadMultisigType, []byte(`{"Action":"create"}`), 30000).Code)
+	e := failure(TxPayloadMultisigType, []byte(`{"Action":"create"}`), 200000)
+	assert.Equal(t, ExecutionErrFailed, e.Code)
+	assert.Equal(t, ErrInvalidMultisigOwners.Error(), e.Message)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LIB Key in storage, the hash of the latest irreversible block.
+	LIB = "blockchain_lib"
+)
+
+// Errors of finality
+var (
+	ErrReorgBelowIrreversible = errors.New("cannot revert the blocks below the latest irreversible block")
+)
+
+var (
+	irreversibleHeightGauge = metrics.GetOrRegisterGauge("neb.block.lib", nil)
+)
+
+// IrreversibleSize is the count of validators in a dynasty of the size to build on a block
+// before it's irreversible, more than 2/3 of them.
+func IrreversibleSize(dynastySize int) int {
+	return dynastySize*2/3 + 1
+}
+
+// LatestIrreversibleBlock returns the latest irreversible block, the chain never reorganizes below it.
+func (bc *BlockChain) LatestIrreversibleBlock() *Block {
+	return bc.lib
+}
+
+// minerOf returns the miner signed the block, nil if the signature is unknown.
+func (bc *BlockChain) minerOf(block *ChainHeader) *Address {
+	if v, ok := bc.cachedMiners.Get(block.Hash().Hex()); ok {
+		return v.(*Address)
+	}
+	header, err := block.Header().ToProto()
+	if err != nil {
+		return nil
+	}
+	miner, err := signer(header.(*corepb.BlockHeader))
+	if err != nil {
+		return nil
+	}
+	bc.cachedMiners.Add(block.Hash().Hex(), miner)
+	return miner
+}
+
+// findIrreversibleBlock returns the latest block built on by IrreversibleSize of the dynasty in canonical
+// chain to the tail, nil if none after the current one in the blocks of a dynasty behind the tail.
+func (bc *BlockChain) findIrreversibleBlock(tail *Block) *ChainHeader {
+	params := tail.DposParams()
+	size := IrreversibleSize(params.DynastySize)
+	miners := make(map[string]bool)
+	block := tail.ChainHeader()
+	for i := int64(0); i < params.DynastyInterval/BlockInterval && block != nil && block.Height() > bc.lib.Height(); i++ {
+		if len(miners) >= size {
+			return block
+		}
+		if miner := bc.minerOf(block); miner != nil {
+			miners[miner.String()] = true
+		}
+		block = bc.GetBlockHeader(block.ParentHash())
+	}
+	return nil
+}
+
+// updateIrreversibleBlock moves the latest irreversible block forward along the new tail.
+func (bc *BlockChain) updateIrreversibleBlock(tail *Block) {
+	header := bc.findIrreversibleBlock(tail)
+	if header == nil {
+		return
+	}
+	lib, err := header.Block()
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": header,
+			"err":   err,
+		}).Error("Failed to load the latest irreversible block.")
+		return
+	}
+	if err := bc.storage.Put([]byte(LIB), lib.Hash()); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": lib,
+			"err":   err,
+		}).Error("Failed to store the latest irreversible block.")
+		return
+	}
+	bc.lib = lib
+	irreversibleHeightGauge.Update(int64(lib.Height()))
+	bc.eventEmitter.Trigger(&Event{
+		Topic: TopicNewIrreversibleBlock,
+		Data:  lib.String(),
+	})
+	logging.VLog().WithFields(logrus.Fields{
+		"block": lib,
+		"tail":  tail,
+	}).Debug("New irreversible block.")
+}
+
+func (bc *BlockChain) loadIrreversibleFromStorage() (*Block, error) {
+	hash, err := bc.storage.Get([]byte(LIB))
+	if err == storage.ErrKeyNotFound {
+		return bc.genesisBlock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bc.loadBlockFromStorage(hash)
+}
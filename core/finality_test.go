@@ -0,0 +1,88 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func mintSignedBlock(t *testing.T, bc *BlockChain, miner *Address) *Block {
+	tail := bc.TailBlock()
+	context, err := tail.NextDynastyContext(BlockInterval)
+	assert.Nil(t, err)
+	block, err := NewBlock(bc.ChainID(), miner, tail)
+	assert.Nil(t, err)
+	assert.Nil(t, block.LoadDynastyContext(context))
+	block.CollectTransactions(0)
+	block.SetMiner(miner)
+	assert.Nil(t, block.Seal())
+	key, _ := keystore.DefaultKS.GetUnlocked(miner.String())
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(key.(keystore.PrivateKey))
+	assert.Nil(t, block.Sign(signature))
+	assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+	assert.Nil(t, bc.SetTailBlock(bc.GetBlock(block.Hash())))
+	return block
+}
+
+func TestBlockChain_LatestIrreversibleBlock(t *testing.T) {
+	neb := testNeb()
+	bc, _ := NewBlockChain(neb)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	assert.Equal(t, bc.GenesisBlock().Hash(), bc.LatestIrreversibleBlock().Hash())
+
+	size := IrreversibleSize(DynastySize)
+	blocks := []*Block{bc.GenesisBlock()}
+	for i := 1; i <= size; i++ {
+		blocks = append(blocks, mintSignedBlock(t, bc, mockAddress()))
+	}
+	// genesis is built on by the miners of all blocks, the first one by the others.
+	assert.Equal(t, bc.GenesisBlock().Hash(), bc.LatestIrreversibleBlock().Hash())
+
+	// the blocks by the same miner count once.
+	miner := mockAddress()
+	mintSignedBlock(t, bc, miner)
+	assert.Equal(t, blocks[1].Hash(), bc.LatestIrreversibleBlock().Hash())
+	block := mintSignedBlock(t, bc, miner)
+	assert.Equal(t, blocks[1].Hash(), bc.LatestIrreversibleBlock().Hash())
+
+	// the chain never reorganizes below the latest irreversible block.
+	coinbase := mockAddress()
+	fork, err := NewBlock(bc.ChainID(), coinbase, bc.GenesisBlock())
+	assert.Nil(t, err)
+	context, err := bc.GenesisBlock().NextDynastyContext(BlockInterval * 100)
+	assert.Nil(t, err)
+	assert.Nil(t, fork.LoadDynastyContext(context))
+	fork.CollectTransactions(0)
+	fork.SetMiner(coinbase)
+	assert.Nil(t, fork.Seal())
+	assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(fork)))
+	assert.Equal(t, ErrReorgBelowIrreversible, bc.SetTailBlock(bc.GetBlock(fork.Hash())))
+	assert.Equal(t, block.Hash(), bc.TailBlock().Hash())
+
+	// it's restored from storage.
+	bc, err = NewBlockChain(neb)
+	assert.Nil(t, err)
+	assert.Equal(t, blocks[1].Hash(), bc.LatestIrreversibleBlock().Hash())
+}
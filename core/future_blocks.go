@@ -0,0 +1,126 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// Future block constants
+const (
+	// MaxFutureBlockDrift is the max seconds a block is ahead of local time to be held until its time,
+	// the blocks further ahead are rejected.
+	MaxFutureBlockDrift = 3 * BlockInterval
+
+	// MaxFutureBlocks is the max count of blocks held, the furthest ahead is dropped if full.
+	MaxFutureBlocks = 64
+)
+
+// Errors of future blocks
+var (
+	ErrFutureBlockTooFar = errors.New("block timestamp is too far in the future")
+)
+
+var (
+	futureBlockDriftGauge = metrics.GetOrRegisterGauge("neb.block.future.drift", nil)
+	futureBlockMeter      = metrics.GetOrRegisterMeter("neb.block.future", nil)
+)
+
+type futureBlock struct {
+	sender string
+	block  *Block
+}
+
+// futureBlocks holds the blocks slightly ahead of local time in ascending timestamps,
+// they are pushed into block pool when their timestamps become acceptable.
+type futureBlocks struct {
+	mu     sync.Mutex
+	blocks []*futureBlock
+}
+
+// add holds the block, which is drift seconds ahead of the accepted time.
+func (f *futureBlocks) add(sender string, block *Block, drift int64) error {
+	if drift > MaxFutureBlockDrift {
+		return ErrFutureBlockTooFar
+	}
+	// blocks keep coming ahead of time if the local clock is behind.
+	futureBlockDriftGauge.Update(drift)
+	futureBlockMeter.Mark(1)
+	logging.VLog().WithFields(logrus.Fields{
+		"block": block,
+		"drift": drift,
+	}).Warn("Held a block ahead of local time, the local clock may be behind, check NTP.")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, v := range f.blocks {
+		if v.block.Hash().Equals(block.Hash()) {
+			return nil
+		}
+	}
+	i := sort.Search(len(f.blocks), func(i int) bool {
+		return f.blocks[i].block.Timestamp() > block.Timestamp()
+	})
+	f.blocks = append(f.blocks, nil)
+	copy(f.blocks[i+1:], f.blocks[i:])
+	f.blocks[i] = &futureBlock{sender: sender, block: block}
+	if len(f.blocks) > MaxFutureBlocks {
+		f.blocks = f.blocks[:MaxFutureBlocks]
+	}
+	return nil
+}
+
+// due pops the blocks acceptable at the time now.
+func (f *futureBlocks) due(now int64) []*futureBlock {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	i := sort.Search(len(f.blocks), func(i int) bool {
+		return f.blocks[i].block.Timestamp()-AcceptedNetWorkDelay > now
+	})
+	due := f.blocks[:i]
+	f.blocks = f.blocks[i:]
+	return due
+}
+
+func (f *futureBlocks) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.blocks)
+}
+
+// processFutureBlocks pushes the blocks held, whose timestamps are acceptable at the time now.
+func (pool *BlockPool) processFutureBlocks(now int64) {
+	for _, v := range pool.future.due(now) {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": v.block,
+		}).Info("Processing a block held until its time.")
+		if err := pool.PushAndRelay(v.sender, v.block); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": v.block,
+				"err":   err,
+			}).Error("Failed to push a block into block pool.")
+		}
+	}
+}
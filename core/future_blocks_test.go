@@ -0,0 +1,120 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/net/messages"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFutureBlocks(t *testing.T) {
+	var f futureBlocks
+	mock := func(timestamp int64) *Block {
+		return &Block{header: &BlockHeader{
+			hash:      byteutils.FromInt64(timestamp),
+			timestamp: timestamp,
+			coinbase:  &Address{[]byte("012345678901234567890001")},
+		}}
+	}
+
+	assert.Equal(t, ErrFutureBlockTooFar, f.add("a", mock(100), MaxFutureBlockDrift+1))
+	for _, ts := range []int64{103, 101, 102, 101} {
+		assert.Nil(t, f.add("a", mock(ts), 1))
+	}
+	assert.Equal(t, 3, f.len())
+
+	due := f.due(101 - AcceptedNetWorkDelay)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, int64(101), due[0].block.Timestamp())
+	assert.Equal(t, 2, f.len())
+	assert.Equal(t, 0, len(f.due(0)))
+
+	// the furthest ahead are dropped if full.
+	for i := 0; i < MaxFutureBlocks; i++ {
+		assert.Nil(t, f.add("a", mock(int64(200+i)), 1))
+	}
+	assert.Equal(t, MaxFutureBlocks, f.len())
+	due = f.due(1000)
+	assert.Equal(t, int64(102), due[0].block.Timestamp())
+	assert.Equal(t, int64(200+MaxFutureBlocks-3), due[len(due)-1].block.Timestamp())
+}
+
+func TestHandleBlock_Future(t *testing.T) {
+	neb := testNeb()
+	bc, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	var n MockNetManager
+	bc.bkPool.RegisterInNetwork(n)
+	cons := &MockConsensus{neb.storage}
+	bc.SetConsensusHandler(cons)
+	from := mockAddress()
+	key, err := keystore.DefaultKS.GetUnlocked(from.String())
+	assert.Nil(t, err)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(key.(keystore.PrivateKey))
+
+	mint := func(timestamp int64) *Block {
+		block, err := bc.NewBlock(from)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.SetMiner(from)
+		block.Seal()
+		block.Sign(signature)
+		return block
+	}
+	handle := func(timestamp int64) *Block {
+		block := mint(timestamp)
+		pbMsg, err := block.ToProto()
+		assert.Nil(t, err)
+		data, err := proto.Marshal(pbMsg)
+		assert.Nil(t, err)
+		bc.bkPool.handleBlock(messages.NewBaseMessage(MessageTypeNewBlock, "from", data))
+		return block
+	}
+
+	// too far ahead.
+	now := time.Now().Unix()
+	block := handle(now + AcceptedNetWorkDelay + MaxFutureBlockDrift + 10)
+	assert.Nil(t, bc.GetBlock(block.Hash()))
+	assert.Equal(t, 0, bc.bkPool.future.len())
+
+	// held until its time.
+	block = handle(now + AcceptedNetWorkDelay + 5)
+	assert.Nil(t, bc.GetBlock(block.Hash()))
+	assert.Equal(t, 1, bc.bkPool.future.len())
+	bc.bkPool.processFutureBlocks(now)
+	assert.Equal(t, 1, bc.bkPool.future.len())
+	bc.bkPool.processFutureBlocks(now + 5)
+	assert.Equal(t, 0, bc.bkPool.future.len())
+
+	// pushed into block pool at its time.
+	block = mint(BlockInterval)
+	assert.Nil(t, bc.bkPool.future.add("from", block, 1))
+	bc.bkPool.processFutureBlocks(0)
+	assert.Nil(t, bc.GetBlock(block.Hash()))
+	bc.bkPool.processFutureBlocks(BlockInterval)
+	assert.NotNil(t, bc.GetBlock(block.Hash()))
+}
@@ -19,16 +19,22 @@
 package core
 
 import (
+	"bytes"
+	"errors"
 	"io/ioutil"
+	"path/filepath"
 
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/proto"
 	"github.com/nebulasio/go-nebulas/common/trie"
 	"github.com/nebulasio/go-nebulas/core/pb"
 	"github.com/nebulasio/go-nebulas/core/state"
 	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/sha3"
 )
 
 // Genesis Block Hash
@@ -37,21 +43,45 @@ var (
 	GenesisTimestamp = int64(0)
 )
 
-// LoadGenesisConf load genesis conf for file
+// Errors of genesis
+var (
+	ErrInvalidGenesisGasLimit = errors.New("invalid gas limit of genesis block")
+	ErrGenesisNotMatch        = errors.New("genesis conf does not match the genesis block in storage")
+)
+
+// LoadGenesisConf load genesis conf for file, in json if the file name ends with .json, or else in protobuf text.
 func LoadGenesisConf(filePath string) (*corepb.Genesis, error) {
 	b, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
-	content := string(b)
 
 	genesis := new(corepb.Genesis)
-	if err := proto.UnmarshalText(content, genesis); err != nil {
+	if filepath.Ext(filePath) == ".json" {
+		if err := jsonpb.Unmarshal(bytes.NewReader(b), genesis); err != nil {
+			return nil, err
+		}
+		return genesis, nil
+	}
+	if err := proto.UnmarshalText(string(b), genesis); err != nil {
 		return nil, err
 	}
 	return genesis, nil
 }
 
+// genesisGasLimit returns the gas limit of genesis block in conf.
+func genesisGasLimit(conf *corepb.Genesis) (*util.Uint128, error) {
+	if conf.Consensus == nil || len(conf.Consensus.GasLimit) == 0 {
+		return MinBlockGasLimit, nil
+	}
+	// an invalid number is parsed as 0, below the min gas limit.
+	gasLimit := util.NewUint128FromString(conf.Consensus.GasLimit)
+	if gasLimit.Cmp(MinBlockGasLimit.Int) < 0 || gasLimit.Cmp(MaxBlockGasLimit.Int) > 0 {
+		return nil, ErrInvalidGenesisGasLimit
+	}
+	return gasLimit, nil
+}
+
 // NewGenesisBlock create genesis @Block from file.
 func NewGenesisBlock(conf *corepb.Genesis, chain *BlockChain) (*Block, error) {
 	accState, err := state.NewAccountState(nil, chain.storage)
@@ -66,28 +96,51 @@ func NewGenesisBlock(conf *corepb.Genesis, chain *BlockChain) (*Block, error) {
 	if err != nil {
 		return nil, err
 	}
+	receiptsTrie, err := trie.NewBatchTrie(nil, chain.storage)
+	if err != nil {
+		return nil, err
+	}
 	dposContext, err := NewDposContext(chain.storage)
 	if err != nil {
 		return nil, err
 	}
+	gasLimit, err := genesisGasLimit(conf)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := NewRewardSchedule(conf)
+	if err != nil {
+		return nil, err
+	}
+	dpos, err := NewDposSchedule(conf)
+	if err != nil {
+		return nil, err
+	}
 	coinbase := &Address{make([]byte, AddressLength)}
 	genesisBlock := &Block{
 		header: &BlockHeader{
 			chainID:     conf.Meta.ChainId,
 			parentHash:  GenesisHash,
 			dposContext: &corepb.DposContext{},
+			gasLimit:    gasLimit,
 			coinbase:    coinbase,
 			timestamp:   GenesisTimestamp,
 			nonce:       0,
 		},
-		accState:    accState,
-		txsTrie:     txsTrie,
-		eventsTrie:  eventsTrie,
-		dposContext: dposContext,
-		txPool:      chain.txPool,
-		storage:     chain.storage,
-		height:      1,
-		sealed:      false,
+		accState:     accState,
+		txsTrie:      txsTrie,
+		eventsTrie:   eventsTrie,
+		receiptsTrie: receiptsTrie,
+		dposContext:  dposContext,
+		txPool:       chain.txPool,
+		gasUsed:      util.NewUint128(),
+		storage:      chain.storage,
+		rewards:      rewards,
+		dpos:         dpos,
+		height:       1,
+		sealed:       false,
+
+		verifiedBlocks: chain.verifiedBlocks,
 	}
 
 	context, err := GenesisDynastyContext(chain.storage, conf)
@@ -111,6 +164,13 @@ func NewGenesisBlock(conf *corepb.Genesis, chain *BlockChain) (*Block, error) {
 		acc := genesisBlock.accState.GetOrCreateUserAccount(addr.address)
 		acc.AddBalance(util.NewUint128FromString(v.Value))
 	}
+	if err := setupOracleFeeders(genesisBlock.accState, conf); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"oracle": conf.Oracle,
+			"err":    err,
+		}).Error("Existed invalid address in genesis oracle feeders.")
+		return nil, err
+	}
 	genesisBlock.commit()
 
 	if err := genesisBlock.Seal(); err != nil {
@@ -125,6 +185,40 @@ func NewGenesisBlock(conf *corepb.Genesis, chain *BlockChain) (*Block, error) {
 	return genesisBlock, nil
 }
 
+// GenesisDigest returns the digest of the genesis block, committing to the chain id, the initial dynasty,
+// the token distribution, the oracle feeders, the gas limit, the reward schedule and the dpos schedule, while
+// the hash of genesis block is always GenesisHash. Nodes started by the same genesis conf have the same digest.
+func GenesisDigest(genesis *Block) byteutils.Hash {
+	hash := HashBlock(genesis)
+	defaultRewards := genesis.rewards == nil || genesis.rewards == DefaultRewardSchedule
+	defaultDpos := genesis.dpos == nil || genesis.dpos == DefaultDposSchedule
+	if defaultRewards && defaultDpos {
+		return hash
+	}
+	hasher := sha3.New256()
+	hasher.Write(hash)
+	if !defaultRewards {
+		hasher.Write(genesis.rewards.Hash())
+	}
+	if !defaultDpos {
+		hasher.Write(genesis.dpos.Hash())
+	}
+	return hasher.Sum(nil)
+}
+
+// GenesisConfDigest returns the digest of the genesis block created by the conf, in a memory storage.
+func GenesisConfDigest(conf *corepb.Genesis) (byteutils.Hash, error) {
+	stor, err := storage.NewMemoryStorage()
+	if err != nil {
+		return nil, err
+	}
+	genesis, err := NewGenesisBlock(conf, &BlockChain{storage: stor})
+	if err != nil {
+		return nil, err
+	}
+	return GenesisDigest(genesis), nil
+}
+
 // CheckGenesisBlock if a block is a genesis block
 func CheckGenesisBlock(block *Block) bool {
 	if block == nil {
@@ -154,7 +248,7 @@ func DumpGenesis(stor storage.Storage) (*corepb.Genesis, error) {
 	accounts, err := genesis.accState.Accounts()
 	for _, v := range accounts {
 		balance := v.Balance()
-		if v.Address().Equals(genesis.Coinbase().Bytes()) {
+		if v.Address().Equals(genesis.Coinbase().Bytes()) || v.Address().Equals(oracleAddress.Bytes()) {
 			continue
 		}
 		distribution = append(distribution, &corepb.GenesisTokenDistribution{
@@ -162,11 +256,20 @@ func DumpGenesis(stor storage.Storage) (*corepb.Genesis, error) {
 			Value:   balance.String(),
 		})
 	}
+	consensus := &corepb.GenesisConsensus{
+		Dpos: &corepb.GenesisConsensusDpos{Dynasty: bootstrap},
+	}
+	if genesis.GasLimit().Cmp(MinBlockGasLimit.Int) != 0 {
+		consensus.GasLimit = genesis.GasLimit().String()
+	}
+	oracle, err := dumpOracleFeeders(genesis.accState)
+	if err != nil {
+		return nil, err
+	}
 	return &corepb.Genesis{
-		Meta: &corepb.GenesisMeta{ChainId: genesis.ChainID()},
-		Consensus: &corepb.GenesisConsensus{
-			Dpos: &corepb.GenesisConsensusDpos{Dynasty: bootstrap},
-		},
+		Meta:              &corepb.GenesisMeta{ChainId: genesis.ChainID()},
+		Consensus:         consensus,
 		TokenDistribution: distribution,
+		Oracle:            oracle,
 	}, nil
 }
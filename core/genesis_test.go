@@ -19,6 +19,9 @@
 package core
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -151,3 +154,74 @@ func TestNewGenesisBlock(t *testing.T) {
 	assert.Equal(t, dumpConf.Consensus.Dpos.Dynasty, conf.Consensus.Dpos.Dynasty)
 	assert.Equal(t, dumpConf.TokenDistribution, conf.TokenDistribution)
 }
+
+func TestLoadGenesisConf_JSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "genesis.json")
+	data := `{
+		"meta": {"chain_id": 100},
+		"consensus": {"dpos": {"dynasty": ["1a263547d167c74cf4b8f9166cfa244de0481c514a45aa2c"]}, "gas_limit": "9000000"},
+		"token_distribution": [{"address": "2fe3f9f51f9a05dd5f7c5329127f7c917917149b4e16b0b8", "value": "100"}]
+	}`
+	assert.Nil(t, ioutil.WriteFile(path, []byte(data), 0644))
+
+	conf, err := LoadGenesisConf(path)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(100), conf.Meta.ChainId)
+	assert.Equal(t, MockDynasty[:1], conf.Consensus.Dpos.Dynasty)
+	assert.Equal(t, "9000000", conf.Consensus.GasLimit)
+	assert.Equal(t, "100", conf.TokenDistribution[0].Value)
+}
+
+func TestGenesisConfDigest(t *testing.T) {
+	conf := MockGenesisConf()
+	digest, err := GenesisConfDigest(conf)
+	assert.Nil(t, err)
+	same, err := GenesisConfDigest(MockGenesisConf())
+	assert.Nil(t, err)
+	assert.Equal(t, digest, same)
+
+	// the token distribution, the chain id and the gas limit are hashed into the genesis block.
+	conf.TokenDistribution[0].Value = "1"
+	other, err := GenesisConfDigest(conf)
+	assert.Nil(t, err)
+	assert.NotEqual(t, digest, other)
+
+	conf = MockGenesisConf()
+	conf.Meta.ChainId = 101
+	other, err = GenesisConfDigest(conf)
+	assert.Nil(t, err)
+	assert.NotEqual(t, digest, other)
+
+	conf = MockGenesisConf()
+	conf.Consensus.GasLimit = MaxBlockGasLimit.String()
+	other, err = GenesisConfDigest(conf)
+	assert.Nil(t, err)
+	assert.NotEqual(t, digest, other)
+
+	conf.Consensus.GasLimit = "1"
+	_, err = GenesisConfDigest(conf)
+	assert.Equal(t, ErrInvalidGenesisGasLimit, err)
+}
+
+func TestBlockChain_GenesisNotMatch(t *testing.T) {
+	neb := testNeb()
+	neb.genesis.Consensus.GasLimit = MaxBlockGasLimit.String()
+	bc, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	assert.Equal(t, MaxBlockGasLimit, bc.GenesisBlock().GasLimit())
+	digest, err := GenesisConfDigest(neb.genesis)
+	assert.Nil(t, err)
+	assert.Equal(t, digest, bc.GenesisDigest())
+
+	dumpConf, err := DumpGenesis(neb.storage)
+	assert.Nil(t, err)
+	assert.Equal(t, neb.genesis.Consensus.GasLimit, dumpConf.Consensus.GasLimit)
+
+	// the genesis conf changed after the first start is rejected.
+	neb.genesis = MockGenesisConf()
+	_, err = NewBlockChain(neb)
+	assert.Equal(t, ErrGenesisNotMatch, err)
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import "sync"
+
+// headerForkHeights are the heights from which the block hash commits to the receipts root, the gas and
+// the event bloom of header, by chain id. The blocks below are hashed and verified without them, and the
+// chains not set commit to them from genesis.
+var headerForkHeights sync.Map
+
+// SetHeaderForkHeight sets the height from which the blocks of the chain commit to the receipts root,
+// the gas and the event bloom of header.
+func SetHeaderForkHeight(chainID uint32, height uint64) {
+	headerForkHeights.Store(chainID, height)
+}
+
+// HeaderForkHeight returns the height from which the blocks of the chain commit to the receipts root,
+// the gas and the event bloom of header.
+func HeaderForkHeight(chainID uint32) uint64 {
+	if v, ok := headerForkHeights.Load(chainID); ok {
+		return v.(uint64)
+	}
+	return 0
+}
+
+// afterHeaderFork returns true if the block commits to the receipts root, the gas and the event bloom.
+func (block *Block) afterHeaderFork() bool {
+	return block.height >= HeaderForkHeight(block.header.chainID)
+}
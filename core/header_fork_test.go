@@ -0,0 +1,80 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlock_HeaderFork(t *testing.T) {
+	defaultDigest, err := GenesisConfDigest(MockGenesisConf())
+	assert.Nil(t, err)
+
+	neb := testNeb()
+	neb.genesis.Meta.HeaderForkHeight = 3
+	bc, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	defer SetHeaderForkHeight(bc.ChainID(), 0)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	assert.Equal(t, uint64(3), HeaderForkHeight(bc.ChainID()))
+	assert.NotEqual(t, defaultDigest, bc.GenesisDigest())
+
+	mint := func(parent *Block, timestamp int64) *Block {
+		block, err := NewBlock(bc.ChainID(), mockAddress(), parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.SetMiner(block.Coinbase())
+		assert.Nil(t, block.Seal())
+		return block
+	}
+
+	// the blocks before the fork are hashed and verified without the new fields, as the nodes before it.
+	block := mint(bc.tailBlock, BlockInterval)
+	assert.Equal(t, uint64(2), block.Height())
+	assert.Nil(t, block.header.gasLimit)
+	assert.Nil(t, block.header.gasUsed)
+	assert.Nil(t, block.header.receiptsRoot)
+	assert.Nil(t, block.header.eventBloom)
+	hash := HashBlock(block)
+	block.header.gasUsed = util.NewUint128FromInt(1)
+	var bloom Bloom
+	block.header.eventBloom = bloom.Bytes()
+	assert.Equal(t, hash, HashBlock(block))
+	block.header.gasUsed, block.header.eventBloom = nil, nil
+
+	received, err := mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+	received.SetMiner(block.Coinbase())
+	assert.Nil(t, received.VerifyExecution(bc.tailBlock, c))
+	assert.Nil(t, bc.storeBlockToStorage(received))
+
+	// the blocks from the fork commit to them.
+	fork := mint(received, BlockInterval*2)
+	assert.Equal(t, uint64(3), fork.Height())
+	assert.Equal(t, MinBlockGasLimit, fork.GasLimit())
+	assert.NotNil(t, fork.header.eventBloom)
+	hash = HashBlock(fork)
+	fork.header.gasUsed = util.NewUint128FromInt(1)
+	assert.NotEqual(t, hash, HashBlock(fork))
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Proof kinds, the tries proved against the roots in header.
+const (
+	ProofAccount     = "account"
+	ProofTransaction = "transaction"
+	ProofEvent       = "event"
+	ProofReceipt     = "receipt"
+)
+
+// Errors of light client
+var (
+	ErrUnknownProofKind    = errors.New("unknown proof kind")
+	ErrInvalidLightHeader  = errors.New("invalid light header")
+	ErrLightHeaderNotChain = errors.New("light header doesn't link to the tail of header chain")
+)
+
+// keys of header chain in storage
+var (
+	lightTailKey      = []byte("light_tail")
+	lightHeaderPrefix = []byte("light_header_")
+	lightHeightPrefix = []byte("light_height_")
+)
+
+// LightHeader returns the header of block with its transaction hashes.
+func (block *Block) LightHeader() (*corepb.LightHeader, error) {
+	header, err := block.header.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	h := &corepb.LightHeader{
+		Header: header.(*corepb.BlockHeader),
+		Height: block.height,
+	}
+	for _, tx := range block.transactions {
+		h.TxHashes = append(h.TxHashes, tx.hash)
+	}
+	return h, nil
+}
+
+// VerifyLightHeader checks the hash of header is computed from its content and transaction hashes.
+func VerifyLightHeader(h *corepb.LightHeader) error {
+	if h.Header == nil || h.Header.DposContext == nil {
+		return ErrInvalidLightHeader
+	}
+	header := new(BlockHeader)
+	if err := header.FromProto(h.Header); err != nil {
+		return err
+	}
+	block := &Block{header: header, height: h.Height}
+	for _, hash := range h.TxHashes {
+		block.transactions = append(block.transactions, &Transaction{hash: hash})
+	}
+	if !HashBlock(block).Equals(header.hash) {
+		return ErrInvalidBlockHash
+	}
+	return nil
+}
+
+func proofRoot(header *corepb.BlockHeader, kind string) ([]byte, error) {
+	switch kind {
+	case ProofAccount:
+		return header.StateRoot, nil
+	case ProofTransaction:
+		return header.TxsRoot, nil
+	case ProofEvent:
+		return header.EventsRoot, nil
+	case ProofReceipt:
+		return header.ReceiptsRoot, nil
+	}
+	return nil, ErrUnknownProofKind
+}
+
+// Prove returns the value of key in the trie of kind, and its merkle proof against the root in header.
+// The key of account is the address, of transaction and receipt is the transaction hash, and of event
+// is the transaction hash followed by the index of event from 1 in 8 bytes.
+func (block *Block) Prove(kind string, key []byte) ([]byte, trie.MerkleProof, error) {
+	header, err := block.header.ToProto()
+	if err != nil {
+		return nil, nil, err
+	}
+	root, err := proofRoot(header.(*corepb.BlockHeader), kind)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := trie.NewTrie(root, block.storage)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := t.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := t.Prove(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, proof, nil
+}
+
+// ProofRoot returns the root of the trie of kind in the block, the proofs of the kind are against it.
+func (block *Block) ProofRoot(kind string) (byteutils.Hash, error) {
+	header, err := block.header.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proofRoot(header.(*corepb.BlockHeader), kind)
+}
+
+// VerifyProof checks the value of key in the trie of kind is proved against the root in header.
+func VerifyProof(header *corepb.BlockHeader, kind string, key []byte, value []byte, proof trie.MerkleProof) error {
+	root, err := proofRoot(header, kind)
+	if err != nil {
+		return err
+	}
+	return proof.Verify(root, key, value)
+}
+
+// EventProofKey returns the key of the index-th event of a transaction in events trie, index starts from 1.
+func EventProofKey(txHash byteutils.Hash, index int64) []byte {
+	return append(append([]byte{}, txHash...), byteutils.FromInt64(index)...)
+}
+
+// HeaderChain keeps the verified headers of canonical chain for light clients, which don't store blocks.
+// Headers are appended in order after the tail, starting from genesis.
+type HeaderChain struct {
+	mu      sync.RWMutex
+	storage storage.Storage
+	tail    *corepb.LightHeader
+}
+
+// NewHeaderChain loads the header chain from storage, or starts it from genesis.
+func NewHeaderChain(genesis *Block, stor storage.Storage) (*HeaderChain, error) {
+	hc := &HeaderChain{storage: stor}
+	hash, err := stor.Get(lightTailKey)
+	if err == storage.ErrKeyNotFound {
+		tail, err := genesis.LightHeader()
+		if err != nil {
+			return nil, err
+		}
+		if err := hc.store(tail); err != nil {
+			return nil, err
+		}
+		hc.tail = tail
+		return hc, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if hc.tail, err = hc.GetHeader(hash); err != nil {
+		return nil, err
+	}
+	return hc, nil
+}
+
+// Tail returns the latest header.
+func (hc *HeaderChain) Tail() *corepb.LightHeader {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.tail
+}
+
+// GetHeader returns the header of hash.
+func (hc *HeaderChain) GetHeader(hash byteutils.Hash) (*corepb.LightHeader, error) {
+	data, err := hc.storage.Get(append(append([]byte{}, lightHeaderPrefix...), hash...))
+	if err != nil {
+		return nil, err
+	}
+	h := new(corepb.LightHeader)
+	if err := proto.Unmarshal(data, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// GetHeaderByHeight returns the header at height.
+func (hc *HeaderChain) GetHeaderByHeight(height uint64) (*corepb.LightHeader, error) {
+	hash, err := hc.storage.Get(append(append([]byte{}, lightHeightPrefix...), byteutils.FromUint64(height)...))
+	if err != nil {
+		return nil, err
+	}
+	return hc.GetHeader(hash)
+}
+
+// Append verifies and appends the continuous headers after the tail.
+func (hc *HeaderChain) Append(headers []*corepb.LightHeader) error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	for _, h := range headers {
+		if err := VerifyLightHeader(h); err != nil {
+			return err
+		}
+		if h.Height != hc.tail.Height+1 || !byteutils.Equal(h.Header.ParentHash, hc.tail.Header.Hash) {
+			return ErrLightHeaderNotChain
+		}
+		if h.Header.ChainId != hc.tail.Header.ChainId {
+			return ErrInvalidChainID
+		}
+		if err := hc.store(h); err != nil {
+			return err
+		}
+		hc.tail = h
+	}
+	if len(headers) > 0 {
+		logging.VLog().WithFields(logrus.Fields{
+			"height": hc.tail.Height,
+			"hash":   byteutils.Hex(hc.tail.Header.Hash),
+		}).Info("Appended light headers.")
+	}
+	return nil
+}
+
+// Rewind sets the header at height as tail, the headers after it are replaced by later appends.
+func (hc *HeaderChain) Rewind(height uint64) error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if height >= hc.tail.Height {
+		return nil
+	}
+	tail, err := hc.GetHeaderByHeight(height)
+	if err != nil {
+		return err
+	}
+	if err := hc.storage.Put(lightTailKey, tail.Header.Hash); err != nil {
+		return err
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"from": hc.tail.Height,
+		"to":   height,
+	}).Warn("Rewound light headers.")
+	hc.tail = tail
+	return nil
+}
+
+func (hc *HeaderChain) store(h *corepb.LightHeader) error {
+	data, err := proto.Marshal(h)
+	if err != nil {
+		return err
+	}
+	if err := hc.storage.Put(append(append([]byte{}, lightHeaderPrefix...), h.Header.Hash...), data); err != nil {
+		return err
+	}
+	if err := hc.storage.Put(append(append([]byte{}, lightHeightPrefix...), byteutils.FromUint64(h.Height)...), h.Header.Hash); err != nil {
+		return err
+	}
+	return hc.storage.Put(lightTailKey, h.Header.Hash)
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderChain(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := &Address{[]byte("012345678901234567890000")}
+
+	var headers []*corepb.LightHeader
+	var blocks []*Block
+	for i := 0; i < 3; i++ {
+		block, _ := bc.NewBlock(coinbase)
+		block.header.timestamp = BlockInterval * int64(i+1)
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		block.Seal()
+		bc.BlockPool().Push(block)
+		bc.SetTailBlock(block)
+		h, err := block.LightHeader()
+		assert.Nil(t, err)
+		assert.Nil(t, VerifyLightHeader(h))
+		headers = append(headers, h)
+		blocks = append(blocks, block)
+	}
+
+	stor, _ := storage.NewMemoryStorage()
+	hc, err := NewHeaderChain(bc.GenesisBlock(), stor)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), hc.Tail().Height)
+
+	assert.Equal(t, ErrLightHeaderNotChain, hc.Append(headers[1:]))
+	tampered := proto.Clone(headers[0]).(*corepb.LightHeader)
+	tampered.Header.Timestamp++
+	assert.Equal(t, ErrInvalidBlockHash, hc.Append([]*corepb.LightHeader{tampered}))
+
+	assert.Nil(t, hc.Append(headers))
+	assert.Equal(t, blocks[2].Height(), hc.Tail().Height)
+
+	// reloaded from storage.
+	hc, err = NewHeaderChain(bc.GenesisBlock(), stor)
+	assert.Nil(t, err)
+	assert.Equal(t, blocks[2].Height(), hc.Tail().Height)
+	h, err := hc.GetHeaderByHeight(blocks[1].Height())
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(blocks[1].Hash()), h.Header.Hash)
+
+	assert.Nil(t, hc.Rewind(blocks[0].Height()))
+	assert.Equal(t, blocks[0].Height(), hc.Tail().Height)
+	assert.Nil(t, hc.Append(headers[1:]))
+	assert.Equal(t, blocks[2].Height(), hc.Tail().Height)
+}
+
+func TestBlock_Prove(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := &Address{[]byte("012345678901234567890000")}
+
+	block, _ := bc.NewBlock(coinbase)
+	block.header.timestamp = BlockInterval
+	block.CollectTransactions(0)
+	block.SetMiner(coinbase)
+	block.Seal()
+	h, _ := block.LightHeader()
+
+	value, proof, err := block.Prove(ProofAccount, coinbase.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyProof(h.Header, ProofAccount, coinbase.Bytes(), value, proof))
+	acc := new(corepb.Account)
+	assert.Nil(t, proto.Unmarshal(value, acc))
+	assert.Equal(t, coinbase.Bytes(), acc.Address)
+
+	// the proof doesn't hold in other tries or for other values.
+	assert.Equal(t, trie.ErrInvalidProof, VerifyProof(h.Header, ProofTransaction, coinbase.Bytes(), value, proof))
+	assert.Equal(t, trie.ErrInvalidProof, VerifyProof(h.Header, ProofAccount, coinbase.Bytes(), []byte("fake"), proof))
+	assert.Equal(t, ErrUnknownProofKind, VerifyProof(h.Header, "unknown", coinbase.Bytes(), value, proof))
+
+	// the serialized proof is verified against the root alone.
+	root, err := block.ProofRoot(ProofAccount)
+	assert.Nil(t, err)
+	assert.Equal(t, block.StateRoot(), root)
+	serialized, err := trie.NewProof(proof)
+	assert.Nil(t, err)
+	proved, err := trie.VerifyProof(root, coinbase.Bytes(), serialized)
+	assert.Nil(t, err)
+	assert.Equal(t, value, proved)
+
+	_, _, err = block.Prove(ProofTransaction, []byte("tx"))
+	assert.NotNil(t, err)
+}
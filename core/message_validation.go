@@ -0,0 +1,187 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/net"
+)
+
+// caps of the messages checked before dispatch.
+const (
+	MaxTransactionMessageSize = 128 * 1024
+	MaxBlockMessageSize       = 4 * 1024 * 1024
+)
+
+// Errors of message validation
+var (
+	ErrMessageTooLarge       = errors.New("message too large")
+	ErrInvalidMessageData    = errors.New("invalid message data")
+	ErrInvalidSignatureShape = errors.New("invalid signature shape")
+	ErrInvalidTxNonce        = errors.New("invalid transaction nonce")
+)
+
+// validTransactionMessage checks the cheap invariants of a transaction in message, which don't need the state.
+func validTransactionMessage(tx *corepb.Transaction, chainID uint32) error {
+	if tx.ChainId != chainID {
+		return ErrInvalidChainID
+	}
+	if len(tx.Hash) != BlockHashLength {
+		return ErrInvalidTransactionHash
+	}
+	if _, err := AddressParseFromBytes(tx.From); err != nil {
+		return err
+	}
+	if _, err := AddressParseFromBytes(tx.To); err != nil {
+		return err
+	}
+	// the nonce of account starts from 0, so its first transaction is 1.
+	if tx.Nonce == 0 {
+		return ErrInvalidTxNonce
+	}
+	if !validSignatureShape(tx.Alg, tx.Sign) {
+		return ErrInvalidSignatureShape
+	}
+	return nil
+}
+
+// validSignatureShape checks the signature is of a supported algorithm and in its length.
+func validSignatureShape(alg uint32, sign []byte) bool {
+	length, err := crypto.SignatureLength(keystore.Algorithm(alg))
+	return err == nil && len(sign) == length
+}
+
+// ValidateTransactionMessage returns the validator of transaction messages of the chain.
+func ValidateTransactionMessage(chainID uint32) net.Validator {
+	return func(msg net.Message) error {
+		data, ok := msg.Data().([]byte)
+		if !ok {
+			return ErrInvalidMessageData
+		}
+		if len(data) > MaxTransactionMessageSize {
+			return ErrMessageTooLarge
+		}
+		tx := new(corepb.Transaction)
+		if err := proto.Unmarshal(data, tx); err != nil {
+			return err
+		}
+		return validTransactionMessage(tx, chainID)
+	}
+}
+
+// validHeaderMessage checks the cheap invariants of a block header in message.
+// Blocks may be unsigned by some consensus, the signature is checked only if present.
+func validHeaderMessage(header *corepb.BlockHeader, height uint64, chainID uint32) error {
+	if header == nil || header.DposContext == nil {
+		return ErrInvalidMessageData
+	}
+	if header.ChainId != chainID {
+		return ErrInvalidChainID
+	}
+	if len(header.Hash) != BlockHashLength || len(header.ParentHash) != BlockHashLength {
+		return ErrInvalidBlockHash
+	}
+	if height == 0 {
+		return ErrInvalidMessageData
+	}
+	if (header.Alg != 0 || len(header.Sign) > 0) && !validSignatureShape(header.Alg, header.Sign) {
+		return ErrInvalidSignatureShape
+	}
+	return nil
+}
+
+// ValidateBlockMessage returns the validator of block messages of the chain.
+func ValidateBlockMessage(chainID uint32) net.Validator {
+	return func(msg net.Message) error {
+		data, ok := msg.Data().([]byte)
+		if !ok {
+			return ErrInvalidMessageData
+		}
+		if len(data) > MaxBlockMessageSize {
+			return ErrMessageTooLarge
+		}
+		block := new(corepb.Block)
+		if err := proto.Unmarshal(data, block); err != nil {
+			return err
+		}
+		if err := validHeaderMessage(block.Header, block.Height, chainID); err != nil {
+			return err
+		}
+		for _, tx := range block.Transactions {
+			if err := validTransactionMessage(tx, chainID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateCompactBlockMessage returns the validator of compact block messages of the chain.
+func ValidateCompactBlockMessage(chainID uint32) net.Validator {
+	return func(msg net.Message) error {
+		data, ok := msg.Data().([]byte)
+		if !ok {
+			return ErrInvalidMessageData
+		}
+		if len(data) > MaxBlockMessageSize {
+			return ErrMessageTooLarge
+		}
+		header := new(corepb.LightHeader)
+		if err := proto.Unmarshal(data, header); err != nil {
+			return err
+		}
+		if err := validHeaderMessage(header.Header, header.Height, chainID); err != nil {
+			return err
+		}
+		for _, hash := range header.TxHashes {
+			if len(hash) != BlockHashLength {
+				return ErrInvalidTransactionHash
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateTxsMessage returns the validator of the messages replying the transactions of a block.
+func ValidateTxsMessage(chainID uint32) net.Validator {
+	return func(msg net.Message) error {
+		data, ok := msg.Data().([]byte)
+		if !ok {
+			return ErrInvalidMessageData
+		}
+		if len(data) > MaxBlockMessageSize {
+			return ErrMessageTooLarge
+		}
+		reply := new(corepb.Txs)
+		if err := proto.Unmarshal(data, reply); err != nil {
+			return err
+		}
+		for _, tx := range reply.Transactions {
+			if err := validTransactionMessage(tx, chainID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
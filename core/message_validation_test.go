@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/net/messages"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSignedTransaction(t *testing.T, chainID uint32, nonce uint64) *corepb.Transaction {
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+
+	tx := NewTransaction(chainID, from, from, util.NewUint128FromInt(1), nonce, TxPayloadBinaryType, nil, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, tx.Sign(signature))
+	pbTx, err := tx.ToProto()
+	assert.Nil(t, err)
+	return pbTx.(*corepb.Transaction)
+}
+
+func mockMessage(t *testing.T, msgType string, pbMsg proto.Message) *messages.BaseMessage {
+	data, err := proto.Marshal(pbMsg)
+	assert.Nil(t, err)
+	return messages.NewBaseMessage(msgType, "peer", data).(*messages.BaseMessage)
+}
+
+func TestValidateTransactionMessage(t *testing.T) {
+	validate := ValidateTransactionMessage(100)
+
+	tx := mockSignedTransaction(t, 100, 1)
+	assert.Nil(t, validate(mockMessage(t, MessageTypeNewTx, tx)))
+
+	assert.Equal(t, ErrInvalidChainID, validate(mockMessage(t, MessageTypeNewTx, mockSignedTransaction(t, 101, 1))))
+	assert.Equal(t, ErrInvalidTxNonce, validate(mockMessage(t, MessageTypeNewTx, mockSignedTransaction(t, 100, 0))))
+
+	tampered := proto.Clone(tx).(*corepb.Transaction)
+	tampered.Sign = tampered.Sign[1:]
+	assert.Equal(t, ErrInvalidSignatureShape, validate(mockMessage(t, MessageTypeNewTx, tampered)))
+
+	tampered = proto.Clone(tx).(*corepb.Transaction)
+	tampered.To = []byte("fake address")
+	assert.Equal(t, ErrInvalidAddress, validate(mockMessage(t, MessageTypeNewTx, tampered)))
+
+	assert.Equal(t, ErrMessageTooLarge, validate(messages.NewBaseMessage(MessageTypeNewTx, "peer", make([]byte, MaxTransactionMessageSize+1))))
+	assert.NotNil(t, validate(messages.NewBaseMessage(MessageTypeNewTx, "peer", []byte("garbage"))))
+}
+
+func TestValidateBlockMessage(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := &Address{[]byte("012345678901234567890000")}
+	validate := ValidateBlockMessage(bc.ChainID())
+
+	block, _ := bc.NewBlock(coinbase)
+	block.header.timestamp = BlockInterval
+	block.CollectTransactions(0)
+	block.SetMiner(coinbase)
+	block.Seal()
+	pbBlock, err := block.ToProto()
+	assert.Nil(t, err)
+	assert.Nil(t, validate(mockMessage(t, MessageTypeNewBlock, pbBlock)))
+
+	tampered := proto.Clone(pbBlock).(*corepb.Block)
+	tampered.Header.ChainId++
+	assert.Equal(t, ErrInvalidChainID, validate(mockMessage(t, MessageTypeNewBlock, tampered)))
+
+	tampered = proto.Clone(pbBlock).(*corepb.Block)
+	tampered.Header.Sign = []byte("sign")
+	assert.Equal(t, ErrInvalidSignatureShape, validate(mockMessage(t, MessageTypeNewBlock, tampered)))
+
+	tampered = proto.Clone(pbBlock).(*corepb.Block)
+	tampered.Transactions = append(tampered.Transactions, mockSignedTransaction(t, bc.ChainID(), 0))
+	assert.Equal(t, ErrInvalidTxNonce, validate(mockMessage(t, MessageTypeNewBlock, tampered)))
+}
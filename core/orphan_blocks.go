@@ -0,0 +1,127 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// Orphan block constants
+const (
+	// MaxOrphanBlocks is the max count of orphans held, the oldest is dropped with its descendants if full.
+	MaxOrphanBlocks = 256
+
+	// OrphanBlockTTL is the seconds an orphan is held waiting for its parent.
+	OrphanBlockTTL = int64(60)
+
+	// OrphanRequestInterval is the seconds between the requests of the parent of an orphan.
+	OrphanRequestInterval = BlockInterval
+)
+
+var (
+	orphanBlocksGauge  = metrics.GetOrRegisterGauge("neb.block.orphans", nil)
+	orphanExpiredMeter = metrics.GetOrRegisterMeter("neb.block.orphans.expired", nil)
+)
+
+// orphan is a block whose parent is neither in the chain nor in the pool, it's the root of the
+// blocks linked to it in the pool. The parent is requested from the peer announcing it.
+type orphan struct {
+	lb        *linkedBlock
+	sender    string
+	received  int64
+	requested int64
+}
+
+// addOrphan holds the block until its parent arrives, the caller holds the lock of pool.
+func (pool *BlockPool) addOrphan(lb *linkedBlock, sender string, now int64) {
+	if _, ok := pool.orphans[lb.hash.Hex()]; ok {
+		return
+	}
+	if len(pool.orphans) >= MaxOrphanBlocks {
+		var oldest *orphan
+		for _, o := range pool.orphans {
+			if oldest == nil || o.received < oldest.received {
+				oldest = o
+			}
+		}
+		pool.dropOrphan(oldest)
+	}
+	pool.orphans[lb.hash.Hex()] = &orphan{lb: lb, sender: sender, received: now, requested: now}
+	orphanBlocksGauge.Update(int64(len(pool.orphans)))
+}
+
+// linkOrphan removes the orphan whose parent arrived, the caller holds the lock of pool.
+func (pool *BlockPool) linkOrphan(hash byteutils.Hash) {
+	if _, ok := pool.orphans[hash.Hex()]; ok {
+		delete(pool.orphans, hash.Hex())
+		orphanBlocksGauge.Update(int64(len(pool.orphans)))
+	}
+}
+
+// dropOrphan removes the orphan and its descendants from the pool, the caller holds the lock of pool.
+func (pool *BlockPool) dropOrphan(o *orphan) {
+	var drop func(lb *linkedBlock)
+	drop = func(lb *linkedBlock) {
+		pool.cache.Remove(lb.hash.Hex())
+		for _, c := range lb.childBlocks {
+			drop(c)
+		}
+	}
+	drop(o.lb)
+	delete(pool.orphans, o.lb.hash.Hex())
+	orphanBlocksGauge.Update(int64(len(pool.orphans)))
+
+	logging.VLog().WithFields(logrus.Fields{
+		"block":  o.lb.block,
+		"sender": o.sender,
+	}).Info("Dropped an orphan block.")
+}
+
+// OrphanCount returns the count of orphans in pool.
+func (pool *BlockPool) OrphanCount() int {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return len(pool.orphans)
+}
+
+// maintainOrphans drops the orphans expired, and requests again the parents of the others.
+func (pool *BlockPool) maintainOrphans(now int64) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	for _, o := range pool.orphans {
+		if now-o.received > OrphanBlockTTL || !pool.cache.Contains(o.lb.hash.Hex()) {
+			orphanExpiredMeter.Mark(1)
+			pool.dropOrphan(o)
+			continue
+		}
+		if now-o.requested >= OrphanRequestInterval {
+			o.requested = now
+			if err := pool.download(o.sender, o.lb.block); err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"block": o.lb.block,
+					"err":   err,
+				}).Debug("Failed to request the parent of an orphan block.")
+			}
+		}
+	}
+}
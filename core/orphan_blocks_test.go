@@ -0,0 +1,135 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrphanBlocks(t *testing.T) {
+	received = []byte{}
+
+	neb := testNeb()
+	bc, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	var n MockNetManager
+	bc.bkPool.RegisterInNetwork(n)
+	cons := &MockConsensus{neb.storage}
+	bc.SetConsensusHandler(cons)
+	pool := bc.bkPool
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+
+	blocks := []*Block{}
+	parent := bc.tailBlock
+	for i := 0; i < 4; i++ {
+		addr := &Address{validators[i+1]}
+		block, err := NewBlock(bc.ChainID(), addr, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = parent.header.timestamp + BlockInterval
+		block.SetMiner(addr)
+		assert.Nil(t, block.Seal())
+		blocks = append(blocks, block)
+		parent = block
+	}
+	downloadMsg := func(block *Block) []byte {
+		bytes, _ := proto.Marshal(&corepb.DownloadBlock{Hash: block.Hash(), Sign: block.Signature()})
+		return bytes
+	}
+
+	// the parent of an orphan is requested from the sender.
+	assert.Equal(t, ErrInvalidBlockCannotFindParentInLocal, pool.push("fake", blocks[3]))
+	assert.Equal(t, 1, pool.OrphanCount())
+	assert.Equal(t, downloadMsg(blocks[3]), received)
+
+	// requested again after the interval.
+	received = []byte{}
+	pool.maintainOrphans(pool.orphans[blocks[3].Hash().Hex()].received)
+	assert.Equal(t, []byte{}, received)
+	pool.maintainOrphans(pool.orphans[blocks[3].Hash().Hex()].received + OrphanRequestInterval)
+	assert.Equal(t, downloadMsg(blocks[3]), received)
+
+	// the orphan linked to its parent arrived is no longer an orphan.
+	assert.Equal(t, ErrInvalidBlockCannotFindParentInLocal, pool.push("fake", blocks[2]))
+	assert.Equal(t, 1, pool.OrphanCount())
+	assert.Equal(t, downloadMsg(blocks[2]), received)
+	assert.NotNil(t, pool.orphans[blocks[2].Hash().Hex()])
+
+	assert.Equal(t, ErrInvalidBlockCannotFindParentInLocal, pool.push("fake", blocks[1]))
+	assert.Equal(t, 1, pool.OrphanCount())
+	assert.NotNil(t, pool.orphans[blocks[1].Hash().Hex()])
+
+	// linked to the chain when the missing parent arrives.
+	assert.Nil(t, pool.push("fake", blocks[0]))
+	assert.Equal(t, 0, pool.OrphanCount())
+	assert.Equal(t, 0, pool.cache.Len())
+	for _, block := range blocks {
+		assert.NotNil(t, bc.GetBlock(block.Hash()))
+	}
+}
+
+func TestOrphanBlocksExpiredAndFull(t *testing.T) {
+	neb := testNeb()
+	bc, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	var n MockNetManager
+	bc.bkPool.RegisterInNetwork(n)
+	cons := &MockConsensus{neb.storage}
+	bc.SetConsensusHandler(cons)
+	pool := bc.bkPool
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	addr := &Address{validators[0]}
+	mock := func(i int) *linkedBlock {
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		block.header.timestamp = int64(i+2) * BlockInterval
+		block.header.parentHash = HashBlock(block)
+		block.SetMiner(addr)
+		assert.Nil(t, block.Seal())
+		lb := newLinkedBlock(block, pool)
+		pool.cache.Add(lb.hash.Hex(), lb)
+		return lb
+	}
+
+	// the oldest is dropped with its descendants if full.
+	first := mock(0)
+	child := mock(1)
+	child.LinkParent(first)
+	pool.addOrphan(first, "fake", 0)
+	for i := 1; i <= MaxOrphanBlocks; i++ {
+		pool.addOrphan(mock(i+1), "fake", int64(i))
+	}
+	assert.Equal(t, MaxOrphanBlocks, pool.OrphanCount())
+	assert.Nil(t, pool.orphans[first.hash.Hex()])
+	assert.False(t, pool.cache.Contains(first.hash.Hex()))
+	assert.False(t, pool.cache.Contains(child.hash.Hex()))
+
+	// expired orphans are dropped.
+	pool.maintainOrphans(OrphanBlockTTL + 10)
+	assert.Equal(t, MaxOrphanBlocks-10+1, pool.OrphanCount())
+	pool.maintainOrphans(OrphanBlockTTL + MaxOrphanBlocks + 1)
+	assert.Equal(t, 0, pool.OrphanCount())
+}
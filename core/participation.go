@@ -0,0 +1,160 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Validator participation
+const (
+	// MaxMissedSlotsPercent is the percent of its slots a validator can miss in a dynasty, it's offline if missed more.
+	MaxMissedSlotsPercent = 50
+
+	// MaxOfflineDynasties is the count of consecutive dynasties an active bootstrap validator can be offline,
+	// it's kicked out as others then.
+	MaxOfflineDynasties = 3
+)
+
+// ValidatorParticipation is the blocks minted by a validator in its slots of a dynasty.
+type ValidatorParticipation struct {
+	DynastyID int64  `json:"dynasty_id"`
+	Address   string `json:"address"`
+	Minted    int64  `json:"minted"`
+	Expected  int64  `json:"expected"`
+
+	// Rate is the percent of slots minted.
+	Rate int64 `json:"rate"`
+
+	// OfflineDynasties is the count of consecutive dynasties the validator is offline till this one.
+	OfflineDynasties int64 `json:"offline_dynasties"`
+
+	// Excluded is true if the validator is kicked out from the candidates of the next elections.
+	Excluded bool `json:"excluded"`
+}
+
+// Offline returns true if the validator missed more than MaxMissedSlotsPercent of its slots.
+func (p *ValidatorParticipation) Offline() bool {
+	return p.Minted < p.Expected*(100-MaxMissedSlotsPercent)/100
+}
+
+// participationForkHeights are the heights from which the consecutive dynasties a validator is offline
+// are counted, by chain id. The active bootstrap validators are always protected below them, and the
+// chains not set count them from genesis.
+var participationForkHeights sync.Map
+
+// SetParticipationForkHeight sets the height from which the consecutive dynasties a validator of the
+// chain is offline are counted.
+func SetParticipationForkHeight(chainID uint32, height uint64) {
+	participationForkHeights.Store(chainID, height)
+}
+
+// ParticipationForkHeight returns the height from which the consecutive dynasties a validator of the
+// chain is offline are counted.
+func ParticipationForkHeight(chainID uint32) uint64 {
+	if v, ok := participationForkHeights.Load(chainID); ok {
+		return v.(uint64)
+	}
+	return 0
+}
+
+// offlineKey is the key of the count of consecutive dynasties the validator is offline in mint count trie,
+// as the mint count of the validator in dynasty -1, which never starts. It's only put from the
+// participation fork height, so the trie of the blocks below is not changed.
+func offlineKey(validator byteutils.Hash) []byte {
+	return append(byteutils.FromInt64(-1), validator...)
+}
+
+// mintCountOf returns the count of blocks minted by the validator in the dynasty.
+func mintCountOf(mintCntTrie *trie.BatchTrie, dynastyID int64, validator byteutils.Hash) (int64, error) {
+	bytes, err := mintCntTrie.Get(append(byteutils.FromInt64(dynastyID), validator...))
+	if err == storage.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return byteutils.Int64(bytes), nil
+}
+
+// participate returns the participation of the validator in the dynasty, and counts the consecutive
+// dynasties it's offline after the participation fork.
+func (dc *DynastyContext) participate(dynastyID int64, validator byteutils.Hash) (*ValidatorParticipation, error) {
+	addr, err := AddressParseFromBytes(validator)
+	if err != nil {
+		return nil, err
+	}
+	minted, err := mintCountOf(dc.MintCntTrie, dynastyID, validator)
+	if err != nil {
+		return nil, err
+	}
+	p := &ValidatorParticipation{
+		DynastyID: dynastyID,
+		Address:   addr.String(),
+		Minted:    minted,
+		Expected:  dc.Params.SlotsPerValidator(),
+		Rate:      minted * 100 / dc.Params.SlotsPerValidator(),
+	}
+
+	if !dc.countOffline {
+		return p, nil
+	}
+	key := offlineKey(validator)
+	if !p.Offline() {
+		if _, err := dc.MintCntTrie.Del(key); err != nil && err != storage.ErrKeyNotFound {
+			return nil, err
+		}
+		return p, nil
+	}
+	bytes, err := dc.MintCntTrie.Get(key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	if err == nil {
+		p.OfflineDynasties = byteutils.Int64(bytes)
+	}
+	p.OfflineDynasties++
+	if _, err := dc.MintCntTrie.Put(key, byteutils.FromInt64(p.OfflineDynasties)); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// triggerParticipations notifies the subscribers of TopicValidatorOffline with the validators offline
+// in the dynasties ended by the block.
+func (block *Block) triggerParticipations() {
+	for _, p := range block.participations {
+		if !p.Offline() {
+			continue
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			continue
+		}
+		block.eventEmitter.Trigger(&Event{
+			Topic: TopicValidatorOffline,
+			Data:  string(data),
+		})
+	}
+}
@@ -13,10 +13,18 @@ It has these top-level messages:
 	Transaction
 	DposContext
 	BlockHeader
+	Receipt
 	Block
+	LightHeader
+	DoubleSignEvidence
 	NetBlocks
 	NetBlock
+	GetTxs
+	Txs
 	DownloadBlock
+	GetBlocksByHashList
+	GetBlocksByHeightRange
+	BlocksReply
 */
 package corepb
 
@@ -108,18 +116,19 @@ func (m *Data) GetPayload() []byte {
 }
 
 type Transaction struct {
-	Hash      []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	From      []byte `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
-	To        []byte `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
-	Value     []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	Nonce     uint64 `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	Timestamp int64  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Data      *Data  `protobuf:"bytes,7,opt,name=data" json:"data,omitempty"`
-	ChainId   uint32 `protobuf:"varint,8,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	GasPrice  []byte `protobuf:"bytes,9,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
-	GasLimit  []byte `protobuf:"bytes,10,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
-	Alg       uint32 `protobuf:"varint,11,opt,name=alg,proto3" json:"alg,omitempty"`
-	Sign      []byte `protobuf:"bytes,12,opt,name=sign,proto3" json:"sign,omitempty"`
+	Hash       []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	From       []byte `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To         []byte `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Value      []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Nonce      uint64 `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Timestamp  int64  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Data       *Data  `protobuf:"bytes,7,opt,name=data" json:"data,omitempty"`
+	ChainId    uint32 `protobuf:"varint,8,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	GasPrice   []byte `protobuf:"bytes,9,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasLimit   []byte `protobuf:"bytes,10,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	Alg        uint32 `protobuf:"varint,11,opt,name=alg,proto3" json:"alg,omitempty"`
+	Sign       []byte `protobuf:"bytes,12,opt,name=sign,proto3" json:"sign,omitempty"`
+	ValidUntil uint64 `protobuf:"varint,13,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
 }
 
 func (m *Transaction) Reset()                    { *m = Transaction{} }
@@ -211,6 +220,13 @@ func (m *Transaction) GetSign() []byte {
 	return nil
 }
 
+func (m *Transaction) GetValidUntil() uint64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
 type DposContext struct {
 	DynastyRoot     []byte `protobuf:"bytes,1,opt,name=dynasty_root,json=dynastyRoot,proto3" json:"dynasty_root,omitempty"`
 	NextDynastyRoot []byte `protobuf:"bytes,2,opt,name=next_dynasty_root,json=nextDynastyRoot,proto3" json:"next_dynasty_root,omitempty"`
@@ -268,18 +284,22 @@ func (m *DposContext) GetMintCntRoot() []byte {
 }
 
 type BlockHeader struct {
-	Hash        []byte       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	ParentHash  []byte       `protobuf:"bytes,2,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
-	Nonce       uint64       `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	Coinbase    []byte       `protobuf:"bytes,4,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
-	Timestamp   int64        `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	ChainId     uint32       `protobuf:"varint,6,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Alg         uint32       `protobuf:"varint,7,opt,name=alg,proto3" json:"alg,omitempty"`
-	Sign        []byte       `protobuf:"bytes,8,opt,name=sign,proto3" json:"sign,omitempty"`
-	StateRoot   []byte       `protobuf:"bytes,9,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
-	TxsRoot     []byte       `protobuf:"bytes,10,opt,name=txs_root,json=txsRoot,proto3" json:"txs_root,omitempty"`
-	EventsRoot  []byte       `protobuf:"bytes,11,opt,name=events_root,json=eventsRoot,proto3" json:"events_root,omitempty"`
-	DposContext *DposContext `protobuf:"bytes,12,opt,name=dpos_context,json=dposContext" json:"dpos_context,omitempty"`
+	Hash         []byte       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	ParentHash   []byte       `protobuf:"bytes,2,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Nonce        uint64       `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Coinbase     []byte       `protobuf:"bytes,4,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	Timestamp    int64        `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ChainId      uint32       `protobuf:"varint,6,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Alg          uint32       `protobuf:"varint,7,opt,name=alg,proto3" json:"alg,omitempty"`
+	Sign         []byte       `protobuf:"bytes,8,opt,name=sign,proto3" json:"sign,omitempty"`
+	StateRoot    []byte       `protobuf:"bytes,9,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	TxsRoot      []byte       `protobuf:"bytes,10,opt,name=txs_root,json=txsRoot,proto3" json:"txs_root,omitempty"`
+	EventsRoot   []byte       `protobuf:"bytes,11,opt,name=events_root,json=eventsRoot,proto3" json:"events_root,omitempty"`
+	DposContext  *DposContext `protobuf:"bytes,12,opt,name=dpos_context,json=dposContext" json:"dpos_context,omitempty"`
+	ReceiptsRoot []byte       `protobuf:"bytes,13,opt,name=receipts_root,json=receiptsRoot,proto3" json:"receipts_root,omitempty"`
+	GasLimit     []byte       `protobuf:"bytes,14,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasUsed      []byte       `protobuf:"bytes,15,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	EventBloom   []byte       `protobuf:"bytes,16,opt,name=event_bloom,json=eventBloom,proto3" json:"event_bloom,omitempty"`
 }
 
 func (m *BlockHeader) Reset()                    { *m = BlockHeader{} }
@@ -371,6 +391,108 @@ func (m *BlockHeader) GetDposContext() *DposContext {
 	return nil
 }
 
+func (m *BlockHeader) GetReceiptsRoot() []byte {
+	if m != nil {
+		return m.ReceiptsRoot
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetGasLimit() []byte {
+	if m != nil {
+		return m.GasLimit
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetGasUsed() []byte {
+	if m != nil {
+		return m.GasUsed
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetEventBloom() []byte {
+	if m != nil {
+		return m.EventBloom
+	}
+	return nil
+}
+
+type Receipt struct {
+	TxHash          []byte   `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Status          uint32   `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	GasUsed         []byte   `protobuf:"bytes,3,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	ContractAddress []byte   `protobuf:"bytes,4,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	EventHashes     [][]byte `protobuf:"bytes,5,rep,name=event_hashes,json=eventHashes" json:"event_hashes,omitempty"`
+	// gas_used * gas_price, charged from the sender and credited to the coinbase, even if the tx failed.
+	Fee []byte `protobuf:"bytes,6,opt,name=fee,proto3" json:"fee,omitempty"`
+	// the reason of the failed tx, empty if succeeded.
+	ErrorCode    string `protobuf:"bytes,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage string `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *Receipt) Reset()                    { *m = Receipt{} }
+func (m *Receipt) String() string            { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()               {}
+func (*Receipt) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{5} }
+
+func (m *Receipt) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *Receipt) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *Receipt) GetGasUsed() []byte {
+	if m != nil {
+		return m.GasUsed
+	}
+	return nil
+}
+
+func (m *Receipt) GetContractAddress() []byte {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return nil
+}
+
+func (m *Receipt) GetEventHashes() [][]byte {
+	if m != nil {
+		return m.EventHashes
+	}
+	return nil
+}
+
+func (m *Receipt) GetFee() []byte {
+	if m != nil {
+		return m.Fee
+	}
+	return nil
+}
+
+func (m *Receipt) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+func (m *Receipt) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
 type Block struct {
 	Header       *BlockHeader   `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Transactions []*Transaction `protobuf:"bytes,2,rep,name=transactions" json:"transactions,omitempty"`
@@ -380,7 +502,7 @@ type Block struct {
 func (m *Block) Reset()                    { *m = Block{} }
 func (m *Block) String() string            { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()               {}
-func (*Block) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{5} }
+func (*Block) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{6} }
 
 func (m *Block) GetHeader() *BlockHeader {
 	if m != nil {
@@ -403,6 +525,64 @@ func (m *Block) GetHeight() uint64 {
 	return 0
 }
 
+// LightHeader is the header with the transaction hashes of a block, enough to verify the block hash.
+type LightHeader struct {
+	Header   *BlockHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	TxHashes [][]byte     `protobuf:"bytes,2,rep,name=tx_hashes,json=txHashes" json:"tx_hashes,omitempty"`
+	Height   uint64       `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *LightHeader) Reset()                    { *m = LightHeader{} }
+func (m *LightHeader) String() string            { return proto.CompactTextString(m) }
+func (*LightHeader) ProtoMessage()               {}
+func (*LightHeader) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{7} }
+
+func (m *LightHeader) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *LightHeader) GetTxHashes() [][]byte {
+	if m != nil {
+		return m.TxHashes
+	}
+	return nil
+}
+
+func (m *LightHeader) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// DoubleSignEvidence is two different blocks signed by the same miner at the same slot.
+type DoubleSignEvidence struct {
+	First  *LightHeader `protobuf:"bytes,1,opt,name=first" json:"first,omitempty"`
+	Second *LightHeader `protobuf:"bytes,2,opt,name=second" json:"second,omitempty"`
+}
+
+func (m *DoubleSignEvidence) Reset()                    { *m = DoubleSignEvidence{} }
+func (m *DoubleSignEvidence) String() string            { return proto.CompactTextString(m) }
+func (*DoubleSignEvidence) ProtoMessage()               {}
+func (*DoubleSignEvidence) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{8} }
+
+func (m *DoubleSignEvidence) GetFirst() *LightHeader {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *DoubleSignEvidence) GetSecond() *LightHeader {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
 type NetBlocks struct {
 	From   string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
 	Batch  uint64   `protobuf:"varint,2,opt,name=batch,proto3" json:"batch,omitempty"`
@@ -412,7 +592,7 @@ type NetBlocks struct {
 func (m *NetBlocks) Reset()                    { *m = NetBlocks{} }
 func (m *NetBlocks) String() string            { return proto.CompactTextString(m) }
 func (*NetBlocks) ProtoMessage()               {}
-func (*NetBlocks) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{6} }
+func (*NetBlocks) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{9} }
 
 func (m *NetBlocks) GetFrom() string {
 	if m != nil {
@@ -444,7 +624,7 @@ type NetBlock struct {
 func (m *NetBlock) Reset()                    { *m = NetBlock{} }
 func (m *NetBlock) String() string            { return proto.CompactTextString(m) }
 func (*NetBlock) ProtoMessage()               {}
-func (*NetBlock) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{7} }
+func (*NetBlock) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{10} }
 
 func (m *NetBlock) GetFrom() string {
 	if m != nil {
@@ -467,6 +647,55 @@ func (m *NetBlock) GetBlock() *Block {
 	return nil
 }
 
+type GetTxs struct {
+	BlockHash []byte `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// indexes of the transactions in block.
+	Indexes []uint32 `protobuf:"varint,2,rep,packed,name=indexes" json:"indexes,omitempty"`
+}
+
+func (m *GetTxs) Reset()                    { *m = GetTxs{} }
+func (m *GetTxs) String() string            { return proto.CompactTextString(m) }
+func (*GetTxs) ProtoMessage()               {}
+func (*GetTxs) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{11} }
+
+func (m *GetTxs) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *GetTxs) GetIndexes() []uint32 {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+type Txs struct {
+	BlockHash    []byte         `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Transactions []*Transaction `protobuf:"bytes,2,rep,name=transactions" json:"transactions,omitempty"`
+}
+
+func (m *Txs) Reset()                    { *m = Txs{} }
+func (m *Txs) String() string            { return proto.CompactTextString(m) }
+func (*Txs) ProtoMessage()               {}
+func (*Txs) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{12} }
+
+func (m *Txs) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Txs) GetTransactions() []*Transaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
 type DownloadBlock struct {
 	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Sign []byte `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`
@@ -475,7 +704,7 @@ type DownloadBlock struct {
 func (m *DownloadBlock) Reset()                    { *m = DownloadBlock{} }
 func (m *DownloadBlock) String() string            { return proto.CompactTextString(m) }
 func (*DownloadBlock) ProtoMessage()               {}
-func (*DownloadBlock) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{8} }
+func (*DownloadBlock) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{13} }
 
 func (m *DownloadBlock) GetHash() []byte {
 	if m != nil {
@@ -491,66 +720,176 @@ func (m *DownloadBlock) GetSign() []byte {
 	return nil
 }
 
+type GetBlocksByHashList struct {
+	Id     uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hashes [][]byte `protobuf:"bytes,2,rep,name=hashes" json:"hashes,omitempty"`
+}
+
+func (m *GetBlocksByHashList) Reset()                    { *m = GetBlocksByHashList{} }
+func (m *GetBlocksByHashList) String() string            { return proto.CompactTextString(m) }
+func (*GetBlocksByHashList) ProtoMessage()               {}
+func (*GetBlocksByHashList) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{14} }
+
+func (m *GetBlocksByHashList) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetBlocksByHashList) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type GetBlocksByHeightRange struct {
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From  uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *GetBlocksByHeightRange) Reset()                    { *m = GetBlocksByHeightRange{} }
+func (m *GetBlocksByHeightRange) String() string            { return proto.CompactTextString(m) }
+func (*GetBlocksByHeightRange) ProtoMessage()               {}
+func (*GetBlocksByHeightRange) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{15} }
+
+func (m *GetBlocksByHeightRange) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetBlocksByHeightRange) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GetBlocksByHeightRange) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type BlocksReply struct {
+	Id     uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Blocks []*Block `protobuf:"bytes,2,rep,name=blocks" json:"blocks,omitempty"`
+}
+
+func (m *BlocksReply) Reset()                    { *m = BlocksReply{} }
+func (m *BlocksReply) String() string            { return proto.CompactTextString(m) }
+func (*BlocksReply) ProtoMessage()               {}
+func (*BlocksReply) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{16} }
+
+func (m *BlocksReply) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *BlocksReply) GetBlocks() []*Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Account)(nil), "corepb.Account")
 	proto.RegisterType((*Data)(nil), "corepb.Data")
 	proto.RegisterType((*Transaction)(nil), "corepb.Transaction")
 	proto.RegisterType((*DposContext)(nil), "corepb.DposContext")
 	proto.RegisterType((*BlockHeader)(nil), "corepb.BlockHeader")
+	proto.RegisterType((*Receipt)(nil), "corepb.Receipt")
 	proto.RegisterType((*Block)(nil), "corepb.Block")
+	proto.RegisterType((*LightHeader)(nil), "corepb.LightHeader")
+	proto.RegisterType((*DoubleSignEvidence)(nil), "corepb.DoubleSignEvidence")
 	proto.RegisterType((*NetBlocks)(nil), "corepb.NetBlocks")
 	proto.RegisterType((*NetBlock)(nil), "corepb.NetBlock")
+	proto.RegisterType((*GetTxs)(nil), "corepb.GetTxs")
+	proto.RegisterType((*Txs)(nil), "corepb.Txs")
 	proto.RegisterType((*DownloadBlock)(nil), "corepb.DownloadBlock")
+	proto.RegisterType((*GetBlocksByHashList)(nil), "corepb.GetBlocksByHashList")
+	proto.RegisterType((*GetBlocksByHeightRange)(nil), "corepb.GetBlocksByHeightRange")
+	proto.RegisterType((*BlocksReply)(nil), "corepb.BlocksReply")
 }
 
 func init() { proto.RegisterFile("block.proto", fileDescriptorBlock) }
 
 var fileDescriptorBlock = []byte{
-	// 722 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x6e, 0xd3, 0x4a,
-	0x10, 0x96, 0x93, 0x38, 0x3f, 0x63, 0xa7, 0xe7, 0x9c, 0x3d, 0x15, 0x72, 0xf9, 0x51, 0x83, 0xab,
-	0x4a, 0x11, 0x48, 0xbd, 0x28, 0x88, 0x5e, 0x43, 0x73, 0x51, 0x24, 0x84, 0x2a, 0x8b, 0x1b, 0x24,
-	0x24, 0x6b, 0x63, 0x2f, 0x89, 0x85, 0xb3, 0x6b, 0x79, 0xa7, 0x25, 0x79, 0x00, 0x1e, 0x80, 0xf7,
-	0xe0, 0xb9, 0x90, 0x78, 0x0b, 0xb4, 0xb3, 0x1b, 0xc7, 0xa1, 0xbd, 0xe1, 0x6e, 0xe7, 0xfb, 0x66,
-	0x77, 0x67, 0xbe, 0xf9, 0x76, 0x21, 0x98, 0x97, 0x2a, 0xfb, 0x72, 0x56, 0xd5, 0x0a, 0x15, 0xeb,
-	0x67, 0xaa, 0x16, 0xd5, 0x3c, 0xfe, 0xee, 0xc1, 0xe0, 0x75, 0x96, 0xa9, 0x1b, 0x89, 0x2c, 0x82,
-	0x01, 0xcf, 0xf3, 0x5a, 0x68, 0x1d, 0x79, 0x13, 0x6f, 0x1a, 0x26, 0xdb, 0xd0, 0x30, 0x73, 0x5e,
-	0x72, 0x99, 0x89, 0xa8, 0x63, 0x19, 0x17, 0xb2, 0x43, 0xf0, 0xa5, 0x32, 0x78, 0x77, 0xe2, 0x4d,
-	0x7b, 0x89, 0x0d, 0xd8, 0x23, 0x18, 0xdd, 0xf2, 0x5a, 0xa7, 0x4b, 0xae, 0x97, 0x51, 0x8f, 0x76,
-	0x0c, 0x0d, 0x70, 0xc5, 0xf5, 0x92, 0x1d, 0x43, 0x30, 0x2f, 0x6a, 0x5c, 0xa6, 0x55, 0xc9, 0x33,
-	0x11, 0xf9, 0x44, 0x03, 0x41, 0xd7, 0x06, 0x89, 0x5f, 0x42, 0x6f, 0xc6, 0x91, 0x33, 0x06, 0x3d,
-	0xdc, 0x54, 0x82, 0x8a, 0x19, 0x25, 0xb4, 0x36, 0x95, 0x54, 0x7c, 0x53, 0x2a, 0x9e, 0x6f, 0x2b,
-	0x71, 0x61, 0xfc, 0xa3, 0x03, 0xc1, 0x87, 0x9a, 0x4b, 0xcd, 0x33, 0x2c, 0x94, 0x34, 0xbb, 0xe9,
-	0x7a, 0xdb, 0x0a, 0xad, 0x0d, 0xf6, 0xb9, 0x56, 0x2b, 0xb7, 0x95, 0xd6, 0xec, 0x00, 0x3a, 0xa8,
-	0xa8, 0xfc, 0x30, 0xe9, 0xa0, 0x32, 0x1d, 0xdd, 0xf2, 0xf2, 0x46, 0xb8, 0xba, 0x6d, 0xb0, 0xeb,
-	0xd3, 0x6f, 0xf7, 0xf9, 0x18, 0x46, 0x58, 0xac, 0x84, 0x46, 0xbe, 0xaa, 0xa2, 0xfe, 0xc4, 0x9b,
-	0x76, 0x93, 0x1d, 0xc0, 0x26, 0xd0, 0xcb, 0x39, 0xf2, 0x68, 0x30, 0xf1, 0xa6, 0xc1, 0x79, 0x78,
-	0x66, 0x25, 0x3f, 0x33, 0xbd, 0x25, 0xc4, 0xb0, 0x23, 0x18, 0x66, 0x4b, 0x5e, 0xc8, 0xb4, 0xc8,
-	0xa3, 0xe1, 0xc4, 0x9b, 0x8e, 0x93, 0x01, 0xc5, 0x6f, 0x73, 0x23, 0xe1, 0x82, 0xeb, 0xb4, 0xaa,
-	0x8b, 0x4c, 0x44, 0x23, 0x2b, 0xe1, 0x82, 0xeb, 0x6b, 0x13, 0x6f, 0xc9, 0xb2, 0x58, 0x15, 0x18,
-	0x41, 0x43, 0xbe, 0x33, 0x31, 0xfb, 0x17, 0xba, 0xbc, 0x5c, 0x44, 0x01, 0x9d, 0x67, 0x96, 0xa6,
-	0x6d, 0x5d, 0x2c, 0x64, 0x14, 0xda, 0xb6, 0xcd, 0x3a, 0xfe, 0xe5, 0x41, 0x30, 0xab, 0x94, 0xbe,
-	0x54, 0x12, 0xc5, 0x1a, 0xd9, 0x53, 0x08, 0xf3, 0x8d, 0xe4, 0x1a, 0x37, 0x69, 0xad, 0x14, 0x3a,
-	0xd9, 0x02, 0x87, 0x25, 0x4a, 0x21, 0x7b, 0x06, 0xff, 0x49, 0xb1, 0xc6, 0x74, 0x2f, 0xcf, 0x4a,
-	0xf9, 0x8f, 0x21, 0x66, 0xad, 0xdc, 0x13, 0x18, 0xe7, 0xa2, 0x14, 0x0b, 0x8e, 0xc2, 0xe6, 0x59,
-	0x81, 0xc3, 0x2d, 0x48, 0x49, 0xa7, 0x70, 0x90, 0x71, 0x99, 0x17, 0x79, 0x93, 0x65, 0x35, 0x1f,
-	0x37, 0x28, 0xa5, 0x19, 0x37, 0xa9, 0x6d, 0x86, 0xef, 0xdc, 0xa4, 0x1c, 0x19, 0xc3, 0x78, 0x55,
-	0x48, 0x4c, 0x33, 0x89, 0x36, 0xa1, 0x6f, 0x0b, 0x37, 0xe0, 0xa5, 0x44, 0x93, 0x13, 0xff, 0xec,
-	0x40, 0xf0, 0xc6, 0x98, 0xff, 0x4a, 0xf0, 0x5c, 0xd4, 0xf7, 0x5a, 0xe3, 0x18, 0x82, 0x8a, 0xd7,
-	0x42, 0xa2, 0x35, 0xad, 0x6d, 0x0b, 0x2c, 0x44, 0xb6, 0xbd, 0xdf, 0xe9, 0x0f, 0x61, 0x98, 0xa9,
-	0x42, 0xce, 0xb9, 0xde, 0x1a, 0xa6, 0x89, 0xf7, 0xdd, 0xe1, 0xff, 0xe9, 0x8e, 0xf6, 0xec, 0xfb,
-	0xfb, 0xb3, 0x77, 0x13, 0x1c, 0xdc, 0x9d, 0xe0, 0x70, 0x37, 0x41, 0xf6, 0x04, 0x40, 0x63, 0xa3,
-	0x9c, 0xb5, 0xc8, 0x88, 0x10, 0x12, 0xe6, 0x08, 0x86, 0xb8, 0xd6, 0x96, 0xb4, 0x16, 0x19, 0xe0,
-	0x5a, 0x13, 0x75, 0x0c, 0x81, 0xb8, 0x15, 0x12, 0x1d, 0x1b, 0xd8, 0x5e, 0x2d, 0x44, 0x09, 0xaf,
-	0x20, 0xcc, 0x2b, 0xa5, 0xd3, 0xcc, 0x9a, 0x83, 0x8c, 0x13, 0x9c, 0xff, 0xdf, 0x38, 0x78, 0xe7,
-	0x9b, 0x24, 0xc8, 0x77, 0x41, 0xfc, 0xcd, 0x03, 0x9f, 0x84, 0x66, 0xcf, 0xa1, 0xbf, 0x24, 0xb1,
-	0x49, 0xe4, 0xd6, 0xde, 0xd6, 0x1c, 0x12, 0x97, 0xc2, 0x2e, 0x20, 0xc4, 0xdd, 0xcb, 0xd5, 0x51,
-	0x67, 0xd2, 0x6d, 0x6f, 0x69, 0xbd, 0xea, 0x64, 0x2f, 0x91, 0x3d, 0x30, 0xb7, 0x14, 0x8b, 0x25,
-	0xba, 0xa1, 0xb8, 0x28, 0xfe, 0x04, 0xa3, 0xf7, 0x02, 0xe9, 0x2a, 0xdd, 0x3c, 0x7a, 0xf7, 0x8d,
-	0xd0, 0xa3, 0x3f, 0x04, 0x7f, 0xce, 0x31, 0xb3, 0x73, 0xee, 0x25, 0x36, 0x60, 0xa7, 0xd0, 0xa7,
-	0x3f, 0x52, 0x47, 0x5d, 0xaa, 0x60, 0xbc, 0x57, 0x74, 0xe2, 0xc8, 0xf8, 0x23, 0x0c, 0xb7, 0xa7,
-	0xff, 0xc5, 0xe1, 0x27, 0xe0, 0xd3, 0x7e, 0x2a, 0xf5, 0xce, 0xd9, 0x96, 0x8b, 0x2f, 0x60, 0x3c,
-	0x53, 0x5f, 0xa5, 0xf9, 0xd0, 0x9a, 0xf3, 0xef, 0xfb, 0xc5, 0xc8, 0x0c, 0x9d, 0x9d, 0x19, 0xe6,
-	0x7d, 0xfa, 0xd6, 0x5f, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xd7, 0x27, 0xd4, 0x2d, 0xe5, 0x05,
-	0x00, 0x00,
+	// 1078 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x6e, 0x23, 0xc5,
+	0x13, 0x96, 0xff, 0x7b, 0x6a, 0xec, 0x24, 0xbf, 0xd9, 0x9f, 0x96, 0x09, 0x0b, 0x8a, 0x99, 0xd5,
+	0x4a, 0x59, 0x90, 0x72, 0x58, 0x10, 0x7b, 0xe2, 0x90, 0xc4, 0x68, 0x83, 0x14, 0xd0, 0xaa, 0xd9,
+	0x3d, 0x20, 0x81, 0x46, 0xed, 0x99, 0x8e, 0xdd, 0x62, 0xdc, 0x3d, 0x9a, 0x6e, 0x07, 0xfb, 0x01,
+	0x78, 0x00, 0x1e, 0x8c, 0xb7, 0xe0, 0xc2, 0x23, 0x70, 0x43, 0x55, 0xdd, 0x63, 0x8f, 0x93, 0x2c,
+	0x62, 0xb9, 0x75, 0x7d, 0x55, 0xdd, 0x5d, 0x55, 0xdf, 0xd7, 0x7f, 0x20, 0x9c, 0x15, 0x3a, 0xfb,
+	0xf9, 0xac, 0xac, 0xb4, 0xd5, 0x51, 0x3f, 0xd3, 0x95, 0x28, 0x67, 0xc9, 0x6f, 0x2d, 0x18, 0x9c,
+	0x67, 0x99, 0x5e, 0x29, 0x1b, 0xc5, 0x30, 0xe0, 0x79, 0x5e, 0x09, 0x63, 0xe2, 0xd6, 0xa4, 0x75,
+	0x3a, 0x62, 0xb5, 0x89, 0x9e, 0x19, 0x2f, 0xb8, 0xca, 0x44, 0xdc, 0x76, 0x1e, 0x6f, 0x46, 0xff,
+	0x87, 0x9e, 0xd2, 0x88, 0x77, 0x26, 0xad, 0xd3, 0x2e, 0x73, 0x46, 0xf4, 0x04, 0x82, 0x5b, 0x5e,
+	0x99, 0x74, 0xc1, 0xcd, 0x22, 0xee, 0xd2, 0x8c, 0x21, 0x02, 0x57, 0xdc, 0x2c, 0xa2, 0x13, 0x08,
+	0x67, 0xb2, 0xb2, 0x8b, 0xb4, 0x2c, 0x78, 0x26, 0xe2, 0x1e, 0xb9, 0x81, 0xa0, 0xd7, 0x88, 0x24,
+	0x5f, 0x40, 0x77, 0xca, 0x2d, 0x8f, 0x22, 0xe8, 0xda, 0x4d, 0x29, 0x28, 0x99, 0x80, 0xd1, 0x18,
+	0x33, 0x29, 0xf9, 0xa6, 0xd0, 0x3c, 0xaf, 0x33, 0xf1, 0x66, 0xf2, 0x7b, 0x1b, 0xc2, 0x37, 0x15,
+	0x57, 0x86, 0x67, 0x56, 0x6a, 0x85, 0xb3, 0x69, 0x7b, 0x57, 0x0a, 0x8d, 0x11, 0xbb, 0xa9, 0xf4,
+	0xd2, 0x4f, 0xa5, 0x71, 0x74, 0x00, 0x6d, 0xab, 0x29, 0xfd, 0x11, 0x6b, 0x5b, 0x8d, 0x15, 0xdd,
+	0xf2, 0x62, 0x25, 0x7c, 0xde, 0xce, 0xd8, 0xd5, 0xd9, 0x6b, 0xd6, 0xf9, 0x11, 0x04, 0x56, 0x2e,
+	0x85, 0xb1, 0x7c, 0x59, 0xc6, 0xfd, 0x49, 0xeb, 0xb4, 0xc3, 0x76, 0x40, 0x34, 0x81, 0x6e, 0xce,
+	0x2d, 0x8f, 0x07, 0x93, 0xd6, 0x69, 0xf8, 0x62, 0x74, 0xe6, 0x5a, 0x7e, 0x86, 0xb5, 0x31, 0xf2,
+	0x44, 0xc7, 0x30, 0xcc, 0x16, 0x5c, 0xaa, 0x54, 0xe6, 0xf1, 0x70, 0xd2, 0x3a, 0x1d, 0xb3, 0x01,
+	0xd9, 0xdf, 0xe4, 0xd8, 0xc2, 0x39, 0x37, 0x69, 0x59, 0xc9, 0x4c, 0xc4, 0x81, 0x6b, 0xe1, 0x9c,
+	0x9b, 0xd7, 0x68, 0xd7, 0xce, 0x42, 0x2e, 0xa5, 0x8d, 0x61, 0xeb, 0xbc, 0x46, 0x3b, 0x3a, 0x82,
+	0x0e, 0x2f, 0xe6, 0x71, 0x48, 0xeb, 0xe1, 0x10, 0xcb, 0x36, 0x72, 0xae, 0xe2, 0x91, 0x2b, 0x1b,
+	0xc7, 0xc8, 0xc2, 0x2d, 0x2f, 0x64, 0x9e, 0xae, 0x94, 0x95, 0x45, 0x3c, 0xa6, 0xb2, 0x80, 0xa0,
+	0xb7, 0x88, 0x24, 0x7f, 0xb6, 0x20, 0x9c, 0x96, 0xda, 0x5c, 0x6a, 0x65, 0xc5, 0xda, 0x46, 0x9f,
+	0xc0, 0x28, 0xdf, 0x28, 0x6e, 0xec, 0x26, 0xad, 0xb4, 0xb6, 0xbe, 0xaf, 0xa1, 0xc7, 0x98, 0xd6,
+	0x36, 0xfa, 0x14, 0xfe, 0xa7, 0xc4, 0xda, 0xa6, 0x7b, 0x71, 0xae, 0xd7, 0x87, 0xe8, 0x98, 0x36,
+	0x62, 0x9f, 0xc2, 0x38, 0x17, 0x85, 0x98, 0x73, 0x2b, 0x5c, 0x9c, 0x63, 0x60, 0x54, 0x83, 0x14,
+	0xf4, 0x0c, 0x0e, 0x32, 0xae, 0x72, 0x99, 0x6f, 0xa3, 0x1c, 0x29, 0xe3, 0x2d, 0x4a, 0x61, 0x28,
+	0x37, 0x5d, 0x47, 0xf4, 0xbc, 0xdc, 0xb4, 0x77, 0x26, 0x30, 0x5e, 0x4a, 0x65, 0xd3, 0x4c, 0x59,
+	0x17, 0xd0, 0x77, 0x89, 0x23, 0x78, 0xa9, 0x2c, 0xc6, 0x24, 0x7f, 0x74, 0x20, 0xbc, 0xc0, 0xd3,
+	0x71, 0x25, 0x78, 0x2e, 0xaa, 0x07, 0xb5, 0x73, 0x02, 0x61, 0xc9, 0x2b, 0xa1, 0xac, 0x53, 0xb5,
+	0x2b, 0x0b, 0x1c, 0x44, 0xba, 0x7e, 0xf8, 0x28, 0x7c, 0x08, 0xc3, 0x4c, 0x4b, 0x35, 0xe3, 0xa6,
+	0x56, 0xd4, 0xd6, 0xde, 0x97, 0x4f, 0xef, 0xae, 0x7c, 0x9a, 0xe2, 0xe8, 0xef, 0x8b, 0xc3, 0x53,
+	0x3c, 0xb8, 0x4f, 0xf1, 0xb0, 0x41, 0xf1, 0xc7, 0x00, 0xc6, 0x6e, 0x3b, 0xe7, 0x34, 0x14, 0x10,
+	0x42, 0x8d, 0x39, 0x86, 0xa1, 0x5d, 0x1b, 0xe7, 0x74, 0x1a, 0x1a, 0xd8, 0xb5, 0x21, 0xd7, 0x09,
+	0x84, 0xe2, 0x56, 0x28, 0xeb, 0xbd, 0xa1, 0xab, 0xd5, 0x41, 0x14, 0xf0, 0x25, 0x8c, 0xf2, 0x52,
+	0x9b, 0x34, 0x73, 0xe2, 0x20, 0x65, 0x85, 0x2f, 0x1e, 0x6d, 0x25, 0xbe, 0xd3, 0x0d, 0x0b, 0xf3,
+	0x9d, 0x81, 0xac, 0x57, 0x22, 0x13, 0xb2, 0xac, 0x97, 0x1e, 0x3b, 0xd6, 0x6b, 0xb0, 0xa6, 0x73,
+	0xa7, 0xee, 0x83, 0x3b, 0xea, 0x3e, 0x06, 0x1c, 0xa7, 0x2b, 0x23, 0xf2, 0xf8, 0xd0, 0x65, 0x3d,
+	0xe7, 0xe6, 0xad, 0x11, 0xf9, 0x36, 0xeb, 0x74, 0x56, 0x68, 0xbd, 0x8c, 0x8f, 0x1a, 0x59, 0x5f,
+	0x20, 0x92, 0xfc, 0xd5, 0x82, 0x01, 0x73, 0x3b, 0x45, 0x1f, 0xc0, 0xc0, 0xae, 0xd3, 0x06, 0xcb,
+	0x7d, 0xbb, 0x26, 0x1a, 0x1f, 0x43, 0x1f, 0x7b, 0xb4, 0x32, 0x44, 0xf1, 0x98, 0x79, 0x6b, 0x6f,
+	0xe3, 0xce, 0xfe, 0xc6, 0xcf, 0xe1, 0x08, 0x1b, 0x51, 0xf1, 0xcc, 0xa6, 0xf5, 0x0d, 0xea, 0xb8,
+	0x3e, 0xac, 0xf1, 0x73, 0x07, 0xe3, 0x29, 0x72, 0x39, 0xe2, 0xce, 0xc2, 0xc4, 0xbd, 0x49, 0x07,
+	0xc5, 0x48, 0xd8, 0x15, 0x41, 0x48, 0xee, 0x8d, 0x10, 0x5e, 0xa6, 0x38, 0x44, 0x22, 0x45, 0x55,
+	0xe9, 0x2a, 0xcd, 0x74, 0x2e, 0x88, 0xf5, 0x80, 0x05, 0x84, 0x5c, 0xea, 0x5c, 0x60, 0x53, 0x9d,
+	0x7b, 0x29, 0x8c, 0xe1, 0x73, 0x41, 0x22, 0x08, 0xd8, 0x88, 0xc0, 0x6f, 0x1d, 0x96, 0xfc, 0xda,
+	0x82, 0x1e, 0x49, 0x3c, 0xfa, 0x0c, 0xfa, 0x0b, 0x92, 0x79, 0xdc, 0xda, 0x67, 0xad, 0x71, 0x02,
+	0x98, 0x0f, 0x89, 0x5e, 0xc2, 0xc8, 0xee, 0x2e, 0x55, 0xec, 0x49, 0xa7, 0x39, 0xa5, 0x71, 0xe1,
+	0xb2, 0xbd, 0x40, 0x6c, 0xe3, 0x42, 0xc8, 0xf9, 0xc2, 0xfa, 0xe3, 0xe0, 0xad, 0x44, 0x43, 0x78,
+	0x8d, 0x03, 0x7f, 0xd2, 0xde, 0x2b, 0x99, 0x27, 0x10, 0x78, 0xce, 0x84, 0xcb, 0x64, 0xc4, 0x86,
+	0x8e, 0x35, 0xf1, 0xee, 0x0d, 0x0b, 0x88, 0xa6, 0x7a, 0x35, 0x2b, 0xc4, 0xf7, 0x72, 0xae, 0xbe,
+	0xbe, 0x95, 0xb9, 0xc0, 0x63, 0xf9, 0x1c, 0x7a, 0x37, 0xb2, 0x32, 0xf6, 0xee, 0xb6, 0x8d, 0xdc,
+	0x98, 0x8b, 0xc0, 0x14, 0x8d, 0xc8, 0xb4, 0x72, 0x2f, 0xce, 0x3b, 0x62, 0x7d, 0x48, 0xf2, 0x23,
+	0x04, 0xdf, 0x09, 0x4b, 0xc9, 0x9b, 0xed, 0x73, 0xe3, 0x1f, 0x30, 0x1c, 0xe3, 0x2d, 0x31, 0xe3,
+	0x36, 0x73, 0x17, 0x48, 0x97, 0x39, 0x23, 0x7a, 0x06, 0x7d, 0x7a, 0x9d, 0x4d, 0xdc, 0xa1, 0x06,
+	0x8f, 0xf7, 0xda, 0xc0, 0xbc, 0x33, 0xf9, 0x01, 0x86, 0xf5, 0xea, 0xef, 0xb1, 0xf8, 0x53, 0xe8,
+	0xd1, 0x7c, 0x6a, 0xcc, 0xbd, 0xb5, 0x9d, 0x2f, 0x39, 0x87, 0xfe, 0x2b, 0x61, 0xdf, 0xac, 0x0d,
+	0xaa, 0x8d, 0xa0, 0xe6, 0xe1, 0x08, 0x08, 0xa1, 0xf3, 0x11, 0xc3, 0x40, 0xaa, 0x5c, 0xac, 0x3d,
+	0x05, 0x63, 0x56, 0x9b, 0xc9, 0x4f, 0xd0, 0xf9, 0x17, 0xf3, 0xff, 0xab, 0xa2, 0x92, 0x97, 0x30,
+	0x9e, 0xea, 0x5f, 0x14, 0x3e, 0xf6, 0xdb, 0x0e, 0x3c, 0xf4, 0xc2, 0xd3, 0x3d, 0xd8, 0xde, 0xdd,
+	0x83, 0xc9, 0x57, 0xf0, 0xe8, 0x55, 0xcd, 0xc9, 0xc5, 0x06, 0x93, 0xb8, 0x96, 0xc6, 0xe2, 0xc3,
+	0x2f, 0x73, 0x9a, 0xdc, 0x65, 0x6d, 0x99, 0x93, 0x80, 0x9a, 0xd2, 0xf2, 0x56, 0xc2, 0xe0, 0x71,
+	0x73, 0x3a, 0xa9, 0x8a, 0x71, 0x35, 0x17, 0xf7, 0x56, 0x68, 0x7e, 0x2f, 0xba, 0x3b, 0x4a, 0xe8,
+	0x77, 0x55, 0xbf, 0x0a, 0x64, 0x24, 0x53, 0xff, 0xde, 0x18, 0x26, 0xca, 0x62, 0x73, 0x6f, 0xa1,
+	0x9d, 0x1c, 0xda, 0xff, 0x20, 0x87, 0x59, 0x9f, 0xfe, 0x72, 0x9f, 0xff, 0x3d, 0x00, 0x8e, 0x86,
+	0x57, 0xe4, 0xda, 0x09, 0x00, 0x00,
 }
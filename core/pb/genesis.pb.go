@@ -11,8 +11,12 @@ It has these top-level messages:
 	Genesis
 	GenesisMeta
 	GenesisConsensus
+	GenesisReward
+	GenesisRewardStep
 	GenesisConsensusDpos
+	GenesisDposFork
 	GenesisTokenDistribution
+	GenesisOracle
 */
 package corepb
 
@@ -39,6 +43,8 @@ type Genesis struct {
 	// genesis token distribution address
 	// map<string, string> token_distribution = 3;
 	TokenDistribution []*GenesisTokenDistribution `protobuf:"bytes,3,rep,name=token_distribution,json=tokenDistribution" json:"token_distribution,omitempty"`
+	// genesis oracle config
+	Oracle *GenesisOracle `protobuf:"bytes,4,opt,name=oracle" json:"oracle,omitempty"`
 }
 
 func (m *Genesis) Reset()                    { *m = Genesis{} }
@@ -67,9 +73,22 @@ func (m *Genesis) GetTokenDistribution() []*GenesisTokenDistribution {
 	return nil
 }
 
+func (m *Genesis) GetOracle() *GenesisOracle {
+	if m != nil {
+		return m.Oracle
+	}
+	return nil
+}
+
 type GenesisMeta struct {
 	// ChainID.
 	ChainId uint32 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// the height from which the block hash commits to the receipts root, gas and event bloom of header,
+	// from genesis if 0. The chains started before them set it above their tail to upgrade.
+	HeaderForkHeight uint64 `protobuf:"varint,2,opt,name=header_fork_height,json=headerForkHeight,proto3" json:"header_fork_height,omitempty"`
+	// the height from which the consecutive dynasties a validator is offline are counted in the mint count
+	// trie, and active bootstrap validators are kicked out after MaxOfflineDynasties, from genesis if 0.
+	ParticipationForkHeight uint64 `protobuf:"varint,3,opt,name=participation_fork_height,json=participationForkHeight,proto3" json:"participation_fork_height,omitempty"`
 }
 
 func (m *GenesisMeta) Reset()                    { *m = GenesisMeta{} }
@@ -84,9 +103,27 @@ func (m *GenesisMeta) GetChainId() uint32 {
 	return 0
 }
 
+func (m *GenesisMeta) GetHeaderForkHeight() uint64 {
+	if m != nil {
+		return m.HeaderForkHeight
+	}
+	return 0
+}
+
+func (m *GenesisMeta) GetParticipationForkHeight() uint64 {
+	if m != nil {
+		return m.ParticipationForkHeight
+	}
+	return 0
+}
+
 type GenesisConsensus struct {
 	// ChainID.
 	Dpos *GenesisConsensusDpos `protobuf:"bytes,1,opt,name=dpos" json:"dpos,omitempty"`
+	// gas limit of genesis block, MinBlockGasLimit if empty.
+	GasLimit string `protobuf:"bytes,2,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	// reward schedule of coinbase, BlockReward for ever if empty.
+	Reward *GenesisReward `protobuf:"bytes,3,opt,name=reward" json:"reward,omitempty"`
 }
 
 func (m *GenesisConsensus) Reset()                    { *m = GenesisConsensus{} }
@@ -101,15 +138,111 @@ func (m *GenesisConsensus) GetDpos() *GenesisConsensusDpos {
 	return nil
 }
 
+func (m *GenesisConsensus) GetGasLimit() string {
+	if m != nil {
+		return m.GasLimit
+	}
+	return ""
+}
+
+func (m *GenesisConsensus) GetReward() *GenesisReward {
+	if m != nil {
+		return m.Reward
+	}
+	return nil
+}
+
+type GenesisReward struct {
+	// reward of the blocks after genesis, BlockReward if empty.
+	Initial string `protobuf:"bytes,1,opt,name=initial,proto3" json:"initial,omitempty"`
+	// the reward steps to the value from the height.
+	Steps []*GenesisRewardStep `protobuf:"bytes,2,rep,name=steps" json:"steps,omitempty"`
+	// the reward decays every decay_interval blocks from the last step, never if 0.
+	DecayInterval uint64 `protobuf:"varint,3,opt,name=decay_interval,json=decayInterval,proto3" json:"decay_interval,omitempty"`
+	// the reward decays to decay_numerator/decay_denominator of it each interval, e.g. 1/2 for halving.
+	DecayNumerator   uint64 `protobuf:"varint,4,opt,name=decay_numerator,json=decayNumerator,proto3" json:"decay_numerator,omitempty"`
+	DecayDenominator uint64 `protobuf:"varint,5,opt,name=decay_denominator,json=decayDenominator,proto3" json:"decay_denominator,omitempty"`
+}
+
+func (m *GenesisReward) Reset()                    { *m = GenesisReward{} }
+func (m *GenesisReward) String() string            { return proto.CompactTextString(m) }
+func (*GenesisReward) ProtoMessage()               {}
+func (*GenesisReward) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{3} }
+
+func (m *GenesisReward) GetInitial() string {
+	if m != nil {
+		return m.Initial
+	}
+	return ""
+}
+
+func (m *GenesisReward) GetSteps() []*GenesisRewardStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *GenesisReward) GetDecayInterval() uint64 {
+	if m != nil {
+		return m.DecayInterval
+	}
+	return 0
+}
+
+func (m *GenesisReward) GetDecayNumerator() uint64 {
+	if m != nil {
+		return m.DecayNumerator
+	}
+	return 0
+}
+
+func (m *GenesisReward) GetDecayDenominator() uint64 {
+	if m != nil {
+		return m.DecayDenominator
+	}
+	return 0
+}
+
+type GenesisRewardStep struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Value  string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *GenesisRewardStep) Reset()                    { *m = GenesisRewardStep{} }
+func (m *GenesisRewardStep) String() string            { return proto.CompactTextString(m) }
+func (*GenesisRewardStep) ProtoMessage()               {}
+func (*GenesisRewardStep) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{4} }
+
+func (m *GenesisRewardStep) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GenesisRewardStep) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 type GenesisConsensusDpos struct {
 	// dpos genesis dynasty address
 	Dynasty []string `protobuf:"bytes,1,rep,name=dynasty" json:"dynasty,omitempty"`
+	// seconds of a dynasty, DynastyInterval if 0.
+	DynastyInterval int64 `protobuf:"varint,2,opt,name=dynasty_interval,json=dynastyInterval,proto3" json:"dynasty_interval,omitempty"`
+	// count of validators in a dynasty, DynastySize if 0.
+	DynastySize uint32 `protobuf:"varint,3,opt,name=dynasty_size,json=dynastySize,proto3" json:"dynasty_size,omitempty"`
+	// the dpos params changed from the heights.
+	Forks []*GenesisDposFork `protobuf:"bytes,4,rep,name=forks" json:"forks,omitempty"`
 }
 
 func (m *GenesisConsensusDpos) Reset()                    { *m = GenesisConsensusDpos{} }
 func (m *GenesisConsensusDpos) String() string            { return proto.CompactTextString(m) }
 func (*GenesisConsensusDpos) ProtoMessage()               {}
-func (*GenesisConsensusDpos) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{3} }
+func (*GenesisConsensusDpos) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{5} }
 
 func (m *GenesisConsensusDpos) GetDynasty() []string {
 	if m != nil {
@@ -118,6 +251,61 @@ func (m *GenesisConsensusDpos) GetDynasty() []string {
 	return nil
 }
 
+func (m *GenesisConsensusDpos) GetDynastyInterval() int64 {
+	if m != nil {
+		return m.DynastyInterval
+	}
+	return 0
+}
+
+func (m *GenesisConsensusDpos) GetDynastySize() uint32 {
+	if m != nil {
+		return m.DynastySize
+	}
+	return 0
+}
+
+func (m *GenesisConsensusDpos) GetForks() []*GenesisDposFork {
+	if m != nil {
+		return m.Forks
+	}
+	return nil
+}
+
+type GenesisDposFork struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// the new seconds of a dynasty, unchanged if 0.
+	DynastyInterval int64 `protobuf:"varint,2,opt,name=dynasty_interval,json=dynastyInterval,proto3" json:"dynasty_interval,omitempty"`
+	// the new count of validators in a dynasty, unchanged if 0.
+	DynastySize uint32 `protobuf:"varint,3,opt,name=dynasty_size,json=dynastySize,proto3" json:"dynasty_size,omitempty"`
+}
+
+func (m *GenesisDposFork) Reset()                    { *m = GenesisDposFork{} }
+func (m *GenesisDposFork) String() string            { return proto.CompactTextString(m) }
+func (*GenesisDposFork) ProtoMessage()               {}
+func (*GenesisDposFork) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{6} }
+
+func (m *GenesisDposFork) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GenesisDposFork) GetDynastyInterval() int64 {
+	if m != nil {
+		return m.DynastyInterval
+	}
+	return 0
+}
+
+func (m *GenesisDposFork) GetDynastySize() uint32 {
+	if m != nil {
+		return m.DynastySize
+	}
+	return 0
+}
+
 type GenesisTokenDistribution struct {
 	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Value   string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -126,7 +314,7 @@ type GenesisTokenDistribution struct {
 func (m *GenesisTokenDistribution) Reset()                    { *m = GenesisTokenDistribution{} }
 func (m *GenesisTokenDistribution) String() string            { return proto.CompactTextString(m) }
 func (*GenesisTokenDistribution) ProtoMessage()               {}
-func (*GenesisTokenDistribution) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{4} }
+func (*GenesisTokenDistribution) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{7} }
 
 func (m *GenesisTokenDistribution) GetAddress() string {
 	if m != nil {
@@ -142,33 +330,73 @@ func (m *GenesisTokenDistribution) GetValue() string {
 	return ""
 }
 
+type GenesisOracle struct {
+	// the addresses allowed to feed the oracle data read by contracts.
+	Feeders []string `protobuf:"bytes,1,rep,name=feeders" json:"feeders,omitempty"`
+}
+
+func (m *GenesisOracle) Reset()                    { *m = GenesisOracle{} }
+func (m *GenesisOracle) String() string            { return proto.CompactTextString(m) }
+func (*GenesisOracle) ProtoMessage()               {}
+func (*GenesisOracle) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{8} }
+
+func (m *GenesisOracle) GetFeeders() []string {
+	if m != nil {
+		return m.Feeders
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Genesis)(nil), "corepb.Genesis")
 	proto.RegisterType((*GenesisMeta)(nil), "corepb.GenesisMeta")
 	proto.RegisterType((*GenesisConsensus)(nil), "corepb.GenesisConsensus")
+	proto.RegisterType((*GenesisReward)(nil), "corepb.GenesisReward")
+	proto.RegisterType((*GenesisRewardStep)(nil), "corepb.GenesisRewardStep")
 	proto.RegisterType((*GenesisConsensusDpos)(nil), "corepb.GenesisConsensusDpos")
+	proto.RegisterType((*GenesisDposFork)(nil), "corepb.GenesisDposFork")
 	proto.RegisterType((*GenesisTokenDistribution)(nil), "corepb.GenesisTokenDistribution")
+	proto.RegisterType((*GenesisOracle)(nil), "corepb.GenesisOracle")
 }
 
 func init() { proto.RegisterFile("genesis.proto", fileDescriptorGenesis) }
 
 var fileDescriptorGenesis = []byte{
-	// 264 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x51, 0x4b, 0xc3, 0x30,
-	0x14, 0x85, 0xa9, 0x9d, 0xab, 0xbd, 0x65, 0xa0, 0x71, 0x0f, 0x11, 0x7c, 0x28, 0x79, 0xb1, 0x4f,
-	0x65, 0x4c, 0xf0, 0x0f, 0x58, 0x10, 0x05, 0x11, 0x82, 0xef, 0x23, 0x6d, 0x2e, 0x1a, 0xd4, 0xa4,
-	0xf4, 0xa6, 0xc2, 0x7e, 0x9b, 0x7f, 0x4e, 0x96, 0xae, 0x38, 0x8a, 0x7b, 0x3c, 0x39, 0x5f, 0x2e,
-	0xe7, 0xdc, 0x0b, 0x8b, 0x37, 0xb4, 0x48, 0x86, 0xca, 0xb6, 0x73, 0xde, 0xb1, 0x79, 0xe3, 0x3a,
-	0x6c, 0x6b, 0xf1, 0x13, 0x41, 0xf2, 0x30, 0x38, 0xec, 0x06, 0x66, 0x5f, 0xe8, 0x15, 0x8f, 0xf2,
-	0xa8, 0xc8, 0xd6, 0x97, 0xe5, 0x80, 0x94, 0x7b, 0xfb, 0x19, 0xbd, 0x92, 0x01, 0x60, 0x77, 0x90,
-	0x36, 0xce, 0x12, 0x5a, 0xea, 0x89, 0x9f, 0x04, 0x9a, 0x4f, 0xe8, 0xfb, 0xd1, 0x97, 0x7f, 0x28,
-	0x7b, 0x01, 0xe6, 0xdd, 0x07, 0xda, 0x8d, 0x36, 0xe4, 0x3b, 0x53, 0xf7, 0xde, 0x38, 0xcb, 0xe3,
-	0x3c, 0x2e, 0xb2, 0x75, 0x3e, 0x19, 0xf0, 0xba, 0x03, 0xab, 0x03, 0x4e, 0x5e, 0xf8, 0xe9, 0x93,
-	0x28, 0x20, 0x3b, 0x48, 0xc7, 0xae, 0xe0, 0xac, 0x79, 0x57, 0xc6, 0x6e, 0x8c, 0x0e, 0x25, 0x16,
-	0x32, 0x09, 0xfa, 0x51, 0x8b, 0x0a, 0xce, 0xa7, 0xc9, 0xd8, 0x0a, 0x66, 0xba, 0x75, 0xb4, 0xef,
-	0x7b, 0x7d, 0xac, 0x41, 0xd5, 0x3a, 0x92, 0x81, 0x14, 0x2b, 0x58, 0xfe, 0xe7, 0x32, 0x0e, 0x89,
-	0xde, 0x5a, 0x45, 0x7e, 0xcb, 0xa3, 0x3c, 0x2e, 0x52, 0x39, 0x4a, 0xf1, 0x04, 0xfc, 0x58, 0xa1,
-	0xdd, 0x2f, 0xa5, 0x75, 0x87, 0x34, 0x44, 0x48, 0xe5, 0x28, 0xd9, 0x12, 0x4e, 0xbf, 0xd5, 0x67,
-	0x8f, 0x61, 0xb9, 0xa9, 0x1c, 0x44, 0x3d, 0x0f, 0xa7, 0xbb, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff,
-	0xbe, 0xf4, 0x0e, 0x3f, 0xcb, 0x01, 0x00, 0x00,
+	// 567 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xc1, 0x6e, 0x13, 0x31,
+	0x10, 0x86, 0xb5, 0xec, 0x26, 0x6d, 0x26, 0x2c, 0x6d, 0x4d, 0xa1, 0x5b, 0xc1, 0x21, 0xac, 0x84,
+	0xda, 0x0a, 0x5a, 0x50, 0x91, 0x38, 0x70, 0x43, 0x54, 0x40, 0x11, 0x50, 0xc9, 0xe5, 0xbe, 0x72,
+	0xd7, 0xd3, 0xc4, 0x6a, 0x62, 0xaf, 0x6c, 0xa7, 0x55, 0xfb, 0x14, 0x88, 0xf7, 0xe0, 0x79, 0x78,
+	0x07, 0x9e, 0x02, 0xd9, 0xeb, 0x25, 0xcd, 0x92, 0xdc, 0xb8, 0x65, 0xe6, 0xff, 0x66, 0x34, 0xf3,
+	0x8f, 0x37, 0x90, 0x0e, 0x51, 0xa2, 0x11, 0xe6, 0xa0, 0xd2, 0xca, 0x2a, 0xd2, 0x2d, 0x95, 0xc6,
+	0xea, 0x2c, 0xff, 0x1d, 0xc1, 0xca, 0x87, 0x5a, 0x21, 0x3b, 0x90, 0x4c, 0xd0, 0xb2, 0x2c, 0x1a,
+	0x44, 0xbb, 0xfd, 0xc3, 0xfb, 0x07, 0x35, 0x72, 0x10, 0xe4, 0x2f, 0x68, 0x19, 0xf5, 0x00, 0x79,
+	0x0d, 0xbd, 0x52, 0x49, 0x83, 0xd2, 0x4c, 0x4d, 0x76, 0xc7, 0xd3, 0x59, 0x8b, 0x7e, 0xd7, 0xe8,
+	0x74, 0x86, 0x92, 0x13, 0x20, 0x56, 0x5d, 0xa0, 0x2c, 0xb8, 0x30, 0x56, 0x8b, 0xb3, 0xa9, 0x15,
+	0x4a, 0x66, 0xf1, 0x20, 0xde, 0xed, 0x1f, 0x0e, 0x5a, 0x0d, 0xbe, 0x39, 0xf0, 0xe8, 0x16, 0x47,
+	0x37, 0x6c, 0x3b, 0x45, 0xf6, 0xa1, 0xab, 0x34, 0x2b, 0xc7, 0x98, 0x25, 0x7e, 0x8a, 0x07, 0xad,
+	0x26, 0x27, 0x5e, 0xa4, 0x01, 0xca, 0x7f, 0x44, 0xd0, 0xbf, 0xb5, 0x0d, 0xd9, 0x86, 0xd5, 0x72,
+	0xc4, 0x84, 0x2c, 0x04, 0xf7, 0x4b, 0xa7, 0x74, 0xc5, 0xc7, 0xc7, 0x9c, 0x3c, 0x07, 0x32, 0x42,
+	0xc6, 0x51, 0x17, 0xe7, 0x4a, 0x5f, 0x14, 0x23, 0x14, 0xc3, 0x91, 0xf5, 0xbb, 0x26, 0x74, 0xbd,
+	0x56, 0xde, 0x2b, 0x7d, 0xf1, 0xd1, 0xe7, 0xc9, 0x1b, 0xd8, 0xae, 0x98, 0xb6, 0xa2, 0x14, 0x15,
+	0x73, 0x83, 0xcd, 0x15, 0xc5, 0xbe, 0x68, 0x6b, 0x0e, 0x98, 0xd5, 0xe6, 0xdf, 0x23, 0x58, 0x6f,
+	0x9b, 0x46, 0x5e, 0x42, 0xc2, 0x2b, 0x65, 0xc2, 0x29, 0x1e, 0x2f, 0x33, 0xf7, 0xa8, 0x52, 0x86,
+	0x7a, 0x92, 0x3c, 0x82, 0xde, 0x90, 0x99, 0x62, 0x2c, 0x26, 0xa2, 0x9e, 0xb3, 0x47, 0x57, 0x87,
+	0xcc, 0x7c, 0x76, 0xb1, 0xf3, 0x49, 0xe3, 0x15, 0xd3, 0x3c, 0x8b, 0x17, 0xfa, 0x44, 0xbd, 0x48,
+	0x03, 0x94, 0xff, 0x8a, 0x20, 0x9d, 0x53, 0x48, 0x06, 0x2b, 0x42, 0x0a, 0x2b, 0xd8, 0xd8, 0x8f,
+	0xd4, 0xa3, 0x4d, 0x48, 0x5e, 0x40, 0xc7, 0x58, 0xac, 0xdc, 0x3b, 0x70, 0x67, 0xdc, 0x5e, 0xd8,
+	0xf9, 0xd4, 0x62, 0x45, 0x6b, 0x8e, 0x3c, 0x85, 0x7b, 0x1c, 0x4b, 0x76, 0x5d, 0x08, 0x69, 0x51,
+	0x5f, 0xb2, 0x71, 0x30, 0x28, 0xf5, 0xd9, 0xe3, 0x90, 0x24, 0x3b, 0xb0, 0x56, 0x63, 0x72, 0x3a,
+	0x41, 0xcd, 0xac, 0xd2, 0xfe, 0xc6, 0x09, 0xad, 0xab, 0xbf, 0x36, 0x59, 0xf2, 0x0c, 0x36, 0x6a,
+	0x90, 0xa3, 0x54, 0x13, 0x21, 0x3d, 0xda, 0xa9, 0x0f, 0xe5, 0x85, 0xa3, 0x59, 0x3e, 0x7f, 0x0b,
+	0x1b, 0xff, 0x0c, 0x46, 0x1e, 0x42, 0x37, 0x9c, 0x2a, 0xf2, 0x65, 0x21, 0x22, 0x9b, 0xd0, 0xb9,
+	0x64, 0xe3, 0x29, 0x06, 0x3b, 0xeb, 0x20, 0xff, 0x19, 0xc1, 0xe6, 0xa2, 0x3b, 0x38, 0x8f, 0xf8,
+	0xb5, 0x64, 0xc6, 0x5e, 0x67, 0xd1, 0x20, 0x76, 0x1e, 0x85, 0x90, 0xec, 0xc1, 0x7a, 0xf8, 0x39,
+	0x5b, 0xda, 0xf5, 0x8c, 0xe9, 0x5a, 0xc8, 0xff, 0x5d, 0xfb, 0x09, 0xdc, 0x6d, 0x50, 0x23, 0x6e,
+	0xd0, 0x7b, 0x93, 0xd2, 0x7e, 0xc8, 0x9d, 0x8a, 0x1b, 0x24, 0xfb, 0xd0, 0x71, 0xcf, 0xcb, 0x64,
+	0x89, 0x77, 0x7c, 0xab, 0xe5, 0xb8, 0x9b, 0xc5, 0x3d, 0x2f, 0x5a, 0x53, 0xf9, 0x15, 0xac, 0xb5,
+	0x94, 0xa5, 0x0b, 0xff, 0xd7, 0x39, 0xf3, 0x4f, 0x90, 0x2d, 0xfb, 0x96, 0x9d, 0x57, 0x8c, 0x73,
+	0x8d, 0xc6, 0x34, 0xef, 0x29, 0x84, 0x4b, 0x4c, 0xdf, 0x83, 0x74, 0xee, 0x93, 0x76, 0x0d, 0xce,
+	0x11, 0x39, 0x6a, 0xd3, 0x98, 0x1d, 0xc2, 0xb3, 0xae, 0xff, 0x83, 0x7b, 0xf5, 0x67, 0x00, 0xb6,
+	0x4b, 0xb8, 0xe1, 0xf1, 0x04, 0x00, 0x00,
 }
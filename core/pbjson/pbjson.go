@@ -0,0 +1,477 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Package pbjson converts the chain data in corepb between protobuf and a documented JSON representation,
+// so indexers and SDKs share the field mappings of the node.
+//
+// The JSON representation of version 1:
+//   - hashes, addresses, signatures and other bytes are lowercase hex strings without prefix;
+//   - balances, values, gas prices and gas limits are decimal strings of uint128;
+//   - empty bytes and amounts are omitted;
+//   - Block and Transaction carry the "version" of representation, the transactions in a block carry it too.
+//
+// Fields are only appended in a version, a change of the existing mappings bumps the version.
+package pbjson
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Version is the version of JSON representation produced.
+const Version = 1
+
+// The types of data converted by ProtoToJSON and JSONToProto.
+const (
+	TypeBlock       = "block"
+	TypeTransaction = "transaction"
+	TypeAccount     = "account"
+)
+
+// Errors
+var (
+	ErrUnsupportedVersion = errors.New("pbjson: unsupported version of JSON representation")
+	ErrInvalidAmount      = errors.New("pbjson: invalid uint128 amount")
+	ErrUnknownType        = errors.New("pbjson: unknown type of data")
+)
+
+// Account is the JSON representation of corepb.Account.
+type Account struct {
+	Address    string `json:"address,omitempty"`
+	Balance    string `json:"balance,omitempty"`
+	Nonce      uint64 `json:"nonce"`
+	VarsHash   string `json:"vars_hash,omitempty"`
+	BirthPlace string `json:"birth_place,omitempty"`
+}
+
+// Data is the JSON representation of corepb.Data, the payload is hex of the raw bytes whatever the type is.
+type Data struct {
+	Type    string `json:"type"`
+	Payload string `json:"payload,omitempty"`
+}
+
+// Transaction is the JSON representation of corepb.Transaction.
+type Transaction struct {
+	Version   int    `json:"version"`
+	Hash      string `json:"hash,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Nonce     uint64 `json:"nonce"`
+	Timestamp int64  `json:"timestamp"`
+	Data      *Data  `json:"data,omitempty"`
+	ChainID   uint32 `json:"chain_id"`
+	GasPrice  string `json:"gas_price,omitempty"`
+	GasLimit  string `json:"gas_limit,omitempty"`
+	Alg       uint32 `json:"alg"`
+	Sign      string `json:"sign,omitempty"`
+
+	ValidUntil uint64 `json:"valid_until,omitempty"`
+}
+
+// DposContext is the JSON representation of corepb.DposContext.
+type DposContext struct {
+	DynastyRoot     string `json:"dynasty_root,omitempty"`
+	NextDynastyRoot string `json:"next_dynasty_root,omitempty"`
+	DelegateRoot    string `json:"delegate_root,omitempty"`
+	CandidateRoot   string `json:"candidate_root,omitempty"`
+	VoteRoot        string `json:"vote_root,omitempty"`
+	MintCntRoot     string `json:"mint_cnt_root,omitempty"`
+}
+
+// BlockHeader is the JSON representation of corepb.BlockHeader.
+type BlockHeader struct {
+	Hash         string       `json:"hash,omitempty"`
+	ParentHash   string       `json:"parent_hash,omitempty"`
+	Nonce        uint64       `json:"nonce"`
+	Coinbase     string       `json:"coinbase,omitempty"`
+	Timestamp    int64        `json:"timestamp"`
+	ChainID      uint32       `json:"chain_id"`
+	Alg          uint32       `json:"alg"`
+	Sign         string       `json:"sign,omitempty"`
+	StateRoot    string       `json:"state_root,omitempty"`
+	TxsRoot      string       `json:"txs_root,omitempty"`
+	EventsRoot   string       `json:"events_root,omitempty"`
+	ReceiptsRoot string       `json:"receipts_root,omitempty"`
+	DposContext  *DposContext `json:"dpos_context,omitempty"`
+	GasLimit     string       `json:"gas_limit,omitempty"`
+	GasUsed      string       `json:"gas_used,omitempty"`
+	EventBloom   string       `json:"event_bloom,omitempty"`
+}
+
+// Block is the JSON representation of corepb.Block.
+type Block struct {
+	Version      int            `json:"version"`
+	Header       *BlockHeader   `json:"header,omitempty"`
+	Transactions []*Transaction `json:"transactions"`
+	Height       uint64         `json:"height"`
+}
+
+// hex encoding of bytes, empty bytes are omitted.
+func toHex(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	return byteutils.Hex(data)
+}
+
+func fromHex(s string) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return byteutils.FromHex(s)
+}
+
+func toAmount(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	amount, err := util.NewUint128FromFixedSizeByteSlice(data)
+	if err != nil {
+		return "", err
+	}
+	return amount.String(), nil
+}
+
+func fromAmount(s string) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	amount, ok := util.NewUint128().FromString(s)
+	if !ok {
+		return nil, ErrInvalidAmount
+	}
+	return amount.ToFixedSizeByteSlice()
+}
+
+// decoder collects the first error of the conversions from JSON.
+type decoder struct {
+	err error
+}
+
+func (d *decoder) hex(s string) []byte {
+	data, err := fromHex(s)
+	if d.err == nil {
+		d.err = err
+	}
+	return data
+}
+
+func (d *decoder) amount(s string) []byte {
+	data, err := fromAmount(s)
+	if d.err == nil {
+		d.err = err
+	}
+	return data
+}
+
+// FromAccount converts an account to JSON representation.
+func FromAccount(pb *corepb.Account) (*Account, error) {
+	balance, err := toAmount(pb.Balance)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		Address:    toHex(pb.Address),
+		Balance:    balance,
+		Nonce:      pb.Nonce,
+		VarsHash:   toHex(pb.VarsHash),
+		BirthPlace: toHex(pb.BirthPlace),
+	}, nil
+}
+
+// ToProto converts the account to protobuf.
+func (a *Account) ToProto() (*corepb.Account, error) {
+	d := new(decoder)
+	pb := &corepb.Account{
+		Address:    d.hex(a.Address),
+		Balance:    d.amount(a.Balance),
+		Nonce:      a.Nonce,
+		VarsHash:   d.hex(a.VarsHash),
+		BirthPlace: d.hex(a.BirthPlace),
+	}
+	return pb, d.err
+}
+
+// FromTransaction converts a transaction to JSON representation.
+func FromTransaction(pb *corepb.Transaction) (*Transaction, error) {
+	tx := &Transaction{
+		Version:    Version,
+		Hash:       toHex(pb.Hash),
+		From:       toHex(pb.From),
+		To:         toHex(pb.To),
+		Nonce:      pb.Nonce,
+		Timestamp:  pb.Timestamp,
+		ChainID:    pb.ChainId,
+		Alg:        pb.Alg,
+		Sign:       toHex(pb.Sign),
+		ValidUntil: pb.ValidUntil,
+	}
+	var err error
+	if tx.Value, err = toAmount(pb.Value); err != nil {
+		return nil, err
+	}
+	if tx.GasPrice, err = toAmount(pb.GasPrice); err != nil {
+		return nil, err
+	}
+	if tx.GasLimit, err = toAmount(pb.GasLimit); err != nil {
+		return nil, err
+	}
+	if pb.Data != nil {
+		tx.Data = &Data{Type: pb.Data.Type, Payload: toHex(pb.Data.Payload)}
+	}
+	return tx, nil
+}
+
+// ToProto converts the transaction to protobuf.
+func (tx *Transaction) ToProto() (*corepb.Transaction, error) {
+	if tx.Version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+	d := new(decoder)
+	pb := &corepb.Transaction{
+		Hash:       d.hex(tx.Hash),
+		From:       d.hex(tx.From),
+		To:         d.hex(tx.To),
+		Value:      d.amount(tx.Value),
+		Nonce:      tx.Nonce,
+		Timestamp:  tx.Timestamp,
+		ChainId:    tx.ChainID,
+		GasPrice:   d.amount(tx.GasPrice),
+		GasLimit:   d.amount(tx.GasLimit),
+		Alg:        tx.Alg,
+		Sign:       d.hex(tx.Sign),
+		ValidUntil: tx.ValidUntil,
+	}
+	if tx.Data != nil {
+		pb.Data = &corepb.Data{Type: tx.Data.Type, Payload: d.hex(tx.Data.Payload)}
+	}
+	return pb, d.err
+}
+
+// FromBlockHeader converts a block header to JSON representation.
+func FromBlockHeader(pb *corepb.BlockHeader) (*BlockHeader, error) {
+	header := &BlockHeader{
+		Hash:         toHex(pb.Hash),
+		ParentHash:   toHex(pb.ParentHash),
+		Nonce:        pb.Nonce,
+		Coinbase:     toHex(pb.Coinbase),
+		Timestamp:    pb.Timestamp,
+		ChainID:      pb.ChainId,
+		Alg:          pb.Alg,
+		Sign:         toHex(pb.Sign),
+		StateRoot:    toHex(pb.StateRoot),
+		TxsRoot:      toHex(pb.TxsRoot),
+		EventsRoot:   toHex(pb.EventsRoot),
+		ReceiptsRoot: toHex(pb.ReceiptsRoot),
+		EventBloom:   toHex(pb.EventBloom),
+	}
+	if ctx := pb.DposContext; ctx != nil {
+		header.DposContext = &DposContext{
+			DynastyRoot:     toHex(ctx.DynastyRoot),
+			NextDynastyRoot: toHex(ctx.NextDynastyRoot),
+			DelegateRoot:    toHex(ctx.DelegateRoot),
+			CandidateRoot:   toHex(ctx.CandidateRoot),
+			VoteRoot:        toHex(ctx.VoteRoot),
+			MintCntRoot:     toHex(ctx.MintCntRoot),
+		}
+	}
+	var err error
+	if header.GasLimit, err = toAmount(pb.GasLimit); err != nil {
+		return nil, err
+	}
+	if header.GasUsed, err = toAmount(pb.GasUsed); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// ToProto converts the block header to protobuf.
+func (h *BlockHeader) ToProto() (*corepb.BlockHeader, error) {
+	d := new(decoder)
+	pb := &corepb.BlockHeader{
+		Hash:         d.hex(h.Hash),
+		ParentHash:   d.hex(h.ParentHash),
+		Nonce:        h.Nonce,
+		Coinbase:     d.hex(h.Coinbase),
+		Timestamp:    h.Timestamp,
+		ChainId:      h.ChainID,
+		Alg:          h.Alg,
+		Sign:         d.hex(h.Sign),
+		StateRoot:    d.hex(h.StateRoot),
+		TxsRoot:      d.hex(h.TxsRoot),
+		EventsRoot:   d.hex(h.EventsRoot),
+		ReceiptsRoot: d.hex(h.ReceiptsRoot),
+		GasLimit:     d.amount(h.GasLimit),
+		GasUsed:      d.amount(h.GasUsed),
+		EventBloom:   d.hex(h.EventBloom),
+	}
+	if ctx := h.DposContext; ctx != nil {
+		pb.DposContext = &corepb.DposContext{
+			DynastyRoot:     d.hex(ctx.DynastyRoot),
+			NextDynastyRoot: d.hex(ctx.NextDynastyRoot),
+			DelegateRoot:    d.hex(ctx.DelegateRoot),
+			CandidateRoot:   d.hex(ctx.CandidateRoot),
+			VoteRoot:        d.hex(ctx.VoteRoot),
+			MintCntRoot:     d.hex(ctx.MintCntRoot),
+		}
+	}
+	return pb, d.err
+}
+
+// FromBlock converts a block to JSON representation.
+func FromBlock(pb *corepb.Block) (*Block, error) {
+	block := &Block{
+		Version:      Version,
+		Height:       pb.Height,
+		Transactions: make([]*Transaction, 0, len(pb.Transactions)),
+	}
+	if pb.Header != nil {
+		header, err := FromBlockHeader(pb.Header)
+		if err != nil {
+			return nil, err
+		}
+		block.Header = header
+	}
+	for _, v := range pb.Transactions {
+		tx, err := FromTransaction(v)
+		if err != nil {
+			return nil, err
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+	return block, nil
+}
+
+// ToProto converts the block to protobuf.
+func (b *Block) ToProto() (*corepb.Block, error) {
+	if b.Version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+	pb := &corepb.Block{Height: b.Height}
+	if b.Header != nil {
+		header, err := b.Header.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		pb.Header = header
+	}
+	for _, v := range b.Transactions {
+		tx, err := v.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		pb.Transactions = append(pb.Transactions, tx)
+	}
+	return pb, nil
+}
+
+// MarshalBlock returns the JSON of a block.
+func MarshalBlock(pb *corepb.Block) ([]byte, error) {
+	block, err := FromBlock(pb)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(block)
+}
+
+// UnmarshalBlock parses the JSON of a block.
+func UnmarshalBlock(data []byte) (*corepb.Block, error) {
+	block := new(Block)
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	return block.ToProto()
+}
+
+// MarshalTransaction returns the JSON of a transaction.
+func MarshalTransaction(pb *corepb.Transaction) ([]byte, error) {
+	tx, err := FromTransaction(pb)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tx)
+}
+
+// UnmarshalTransaction parses the JSON of a transaction.
+func UnmarshalTransaction(data []byte) (*corepb.Transaction, error) {
+	tx := new(Transaction)
+	if err := json.Unmarshal(data, tx); err != nil {
+		return nil, err
+	}
+	return tx.ToProto()
+}
+
+// ProtoToJSON converts the protobuf bytes of the type of data to JSON.
+func ProtoToJSON(typ string, data []byte) ([]byte, error) {
+	switch typ {
+	case TypeBlock:
+		pb := new(corepb.Block)
+		if err := proto.Unmarshal(data, pb); err != nil {
+			return nil, err
+		}
+		return MarshalBlock(pb)
+	case TypeTransaction:
+		pb := new(corepb.Transaction)
+		if err := proto.Unmarshal(data, pb); err != nil {
+			return nil, err
+		}
+		return MarshalTransaction(pb)
+	case TypeAccount:
+		pb := new(corepb.Account)
+		if err := proto.Unmarshal(data, pb); err != nil {
+			return nil, err
+		}
+		acc, err := FromAccount(pb)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(acc)
+	}
+	return nil, ErrUnknownType
+}
+
+// JSONToProto converts the JSON of the type of data to protobuf bytes.
+func JSONToProto(typ string, data []byte) ([]byte, error) {
+	var (
+		pb  proto.Message
+		err error
+	)
+	switch typ {
+	case TypeBlock:
+		pb, err = UnmarshalBlock(data)
+	case TypeTransaction:
+		pb, err = UnmarshalTransaction(data)
+	case TypeAccount:
+		acc := new(Account)
+		if err = json.Unmarshal(data, acc); err == nil {
+			pb, err = acc.ToProto()
+		}
+	default:
+		return nil, ErrUnknownType
+	}
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package pbjson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockAmount(v int64) []byte {
+	data, _ := util.NewUint128FromInt(v).ToFixedSizeByteSlice()
+	return data
+}
+
+func mockBlock() *corepb.Block {
+	tx := &corepb.Transaction{
+		Hash:       []byte{0x01, 0x02},
+		From:       []byte{0x0a, 0x0b},
+		To:         []byte{0x0c, 0x0d},
+		Value:      mockAmount(1000000),
+		Nonce:      3,
+		Timestamp:  1510000000,
+		Data:       &corepb.Data{Type: "binary", Payload: []byte("data")},
+		ChainId:    100,
+		GasPrice:   mockAmount(1),
+		GasLimit:   mockAmount(20000),
+		Alg:        1,
+		Sign:       []byte{0xff},
+		ValidUntil: 1510003600,
+	}
+	return &corepb.Block{
+		Header: &corepb.BlockHeader{
+			Hash:       []byte{0x11},
+			ParentHash: []byte{0x12},
+			Nonce:      1,
+			Coinbase:   []byte{0x13},
+			Timestamp:  1510000001,
+			ChainId:    100,
+			StateRoot:  []byte{0x14},
+			TxsRoot:    []byte{0x15},
+			EventsRoot: []byte{0x16},
+			DposContext: &corepb.DposContext{
+				DynastyRoot: []byte{0x17},
+				VoteRoot:    []byte{0x18},
+			},
+		},
+		Transactions: []*corepb.Transaction{tx},
+		Height:       7,
+	}
+}
+
+func TestBlock_RoundTrip(t *testing.T) {
+	pb := mockBlock()
+	data, err := MarshalBlock(pb)
+	assert.Nil(t, err)
+
+	var fields map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, float64(Version), fields["version"])
+	header := fields["header"].(map[string]interface{})
+	assert.Equal(t, "11", header["hash"])
+	assert.Nil(t, header["sign"])
+	tx := fields["transactions"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "1000000", tx["value"])
+	assert.Equal(t, "20000", tx["gas_limit"])
+	assert.Equal(t, "0a0b", tx["from"])
+	assert.Equal(t, "64617461", tx["data"].(map[string]interface{})["payload"])
+	assert.Equal(t, float64(1510003600), tx["valid_until"])
+
+	decoded, err := UnmarshalBlock(data)
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(pb, decoded))
+}
+
+func TestConvert(t *testing.T) {
+	block := mockBlock()
+	acc := &corepb.Account{Address: []byte{0x01}, Balance: mockAmount(5), Nonce: 2}
+	tests := []struct {
+		typ string
+		pb  proto.Message
+	}{
+		{TypeBlock, block},
+		{TypeTransaction, block.Transactions[0]},
+		{TypeAccount, acc},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			data, err := proto.Marshal(tt.pb)
+			assert.Nil(t, err)
+			js, err := ProtoToJSON(tt.typ, data)
+			assert.Nil(t, err)
+			back, err := JSONToProto(tt.typ, js)
+			assert.Nil(t, err)
+			assert.Equal(t, data, back)
+		})
+	}
+
+	_, err := ProtoToJSON("receipt", nil)
+	assert.Equal(t, ErrUnknownType, err)
+	_, err = JSONToProto("receipt", []byte("{}"))
+	assert.Equal(t, ErrUnknownType, err)
+}
+
+func TestTransaction_Invalid(t *testing.T) {
+	_, err := UnmarshalTransaction([]byte(`{"version":2}`))
+	assert.Equal(t, ErrUnsupportedVersion, err)
+	_, err = UnmarshalTransaction([]byte(`{"version":1,"value":"abc"}`))
+	assert.Equal(t, ErrInvalidAmount, err)
+	_, err = UnmarshalTransaction([]byte(`{"version":1,"hash":"xyz"}`))
+	assert.NotNil(t, err)
+	_, err = UnmarshalBlock([]byte(`{"version":1,"transactions":[{"version":0}]}`))
+	assert.Equal(t, ErrUnsupportedVersion, err)
+}
@@ -0,0 +1,381 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MinPruneRetention is the least count of recent block states kept, the blocks near the tail may be reverted.
+	MinPruneRetention = 128
+
+	// PrunedHeight Key in storage, the states of blocks below it are pruned except the checkpoints.
+	PrunedHeight = "blockchain_pruned"
+
+	// blockStatesPrefix is the prefix of the keys of the blocks retaining states at a height.
+	blockStatesPrefix = "blockchain_states_"
+
+	// DefaultFullNodeRetention is the count of recent block states kept by a full node if not configured.
+	DefaultFullNodeRetention = 1024
+
+	// CompactAfterPrunedNodes is the count of trie nodes pruned between the automatic compactions of storage.
+	CompactAfterPrunedNodes = 100000
+)
+
+// Node modes, an archive node keeps all the historical states, a full node keeps the recent ones only.
+const (
+	ArchiveNode = "archive"
+	FullNode    = "full"
+)
+
+// Errors of pruning
+var (
+	ErrPruneTooRecent   = errors.New("cannot prune the states of blocks near the tail")
+	ErrBlockStatePruned = errors.New("the states of block were pruned")
+	ErrUnknownNodeMode  = errors.New("unknown node mode")
+	ErrPruneArchiveNode = errors.New("cannot prune the states of an archive node")
+	ErrStatesPruned     = errors.New("cannot run as archive node, the states were pruned")
+)
+
+var (
+	prunedHeightGauge = metrics.GetOrRegisterGauge("neb.block.pruned.height", nil)
+	prunedNodesMeter  = metrics.GetOrRegisterMeter("neb.block.pruned.nodes", nil)
+	compactionTimer   = metrics.GetOrRegisterTimer("neb.storage.compaction", nil)
+)
+
+// pruner releases the states of historical blocks, keeping the recent ones and the checkpoints.
+type pruner struct {
+	mu sync.Mutex
+	rc *trie.RefCounter
+	// retention is the count of recent block states kept, pruning is disabled if 0.
+	retention uint64
+	// checkpointInterval keeps the states of blocks at the heights of multiples of it, none if 0.
+	checkpointInterval uint64
+	// uncompacted is the count of nodes pruned since the last compaction.
+	uncompacted int
+	compacting  bool
+}
+
+// SetPruning enables to prune the states of blocks automatically when the tail grows,
+// keeping the recent retention blocks and the blocks every checkpointInterval heights.
+func (bc *BlockChain) SetPruning(retention, checkpointInterval uint64) {
+	if retention > 0 && retention < MinPruneRetention {
+		retention = MinPruneRetention
+	}
+	bc.pruner.mu.Lock()
+	defer bc.pruner.mu.Unlock()
+	bc.pruner.retention = retention
+	bc.pruner.checkpointInterval = checkpointInterval
+}
+
+// SetNodeMode sets the node as an archive node keeping all states, or a full node keeping
+// the states of the recent retention blocks and the checkpoints, DefaultFullNodeRetention if 0.
+// The node is an archive node if mode is empty.
+func (bc *BlockChain) SetNodeMode(mode string, retention, checkpointInterval uint64) error {
+	switch mode {
+	case "", ArchiveNode:
+		if bc.PrunedHeight() > 0 {
+			return ErrStatesPruned
+		}
+		bc.SetPruning(0, 0)
+	case FullNode:
+		if retention == 0 {
+			retention = DefaultFullNodeRetention
+		}
+		bc.SetPruning(retention, checkpointInterval)
+	default:
+		return ErrUnknownNodeMode
+	}
+	logging.CLog().WithFields(logrus.Fields{
+		"mode":       bc.NodeMode(),
+		"retention":  retention,
+		"checkpoint": checkpointInterval,
+	}).Info("Set the node mode.")
+	return nil
+}
+
+// NodeMode returns the mode of node, ArchiveNode or FullNode.
+func (bc *BlockChain) NodeMode() string {
+	bc.pruner.mu.Lock()
+	defer bc.pruner.mu.Unlock()
+	if bc.pruner.retention > 0 {
+		return FullNode
+	}
+	return ArchiveNode
+}
+
+// PrunedHeight returns the height below which the states of blocks are pruned, except the checkpoints.
+func (bc *BlockChain) PrunedHeight() uint64 {
+	bc.pruner.mu.Lock()
+	defer bc.pruner.mu.Unlock()
+	return bc.prunedHeight()
+}
+
+func (bc *BlockChain) prunedHeight() uint64 {
+	data, err := bc.storage.Get([]byte(PrunedHeight))
+	if err != nil {
+		return 0
+	}
+	return byteutils.Uint64(data)
+}
+
+// accountVariables returns the root of the variables trie of an account in state trie.
+func accountVariables(value []byte) [][]byte {
+	acc := new(corepb.Account)
+	if err := proto.Unmarshal(value, acc); err != nil {
+		return nil
+	}
+	return [][]byte{acc.VarsHash}
+}
+
+// stateRoots returns the roots of the tries referred by the header, with the callbacks of their leaves.
+func stateRoots(header *corepb.BlockHeader) ([][]byte, []trie.LeafCallback) {
+	roots := [][]byte{header.StateRoot, header.TxsRoot, header.EventsRoot, header.ReceiptsRoot}
+	callbacks := []trie.LeafCallback{accountVariables, nil, nil, nil}
+	if ctx := header.DposContext; ctx != nil {
+		for _, root := range [][]byte{ctx.DynastyRoot, ctx.NextDynastyRoot, ctx.DelegateRoot,
+			ctx.CandidateRoot, ctx.VoteRoot, ctx.MintCntRoot} {
+			roots = append(roots, root)
+			callbacks = append(callbacks, nil)
+		}
+	}
+	return roots, callbacks
+}
+
+// refCounter returns the reference counter of trie nodes in the storage of chain, the caller holds the lock.
+func (bc *BlockChain) refCounter() *trie.RefCounter {
+	if bc.pruner.rc == nil {
+		bc.pruner.rc = trie.NewRefCounter(bc.storage)
+	}
+	return bc.pruner.rc
+}
+
+func blockStatesKey(height uint64) []byte {
+	return append([]byte(blockStatesPrefix), byteutils.FromUint64(height)...)
+}
+
+// retainStates references the states of a block stored, they are kept until the height is pruned.
+func (bc *BlockChain) retainStates(block *Block) error {
+	header, err := block.header.ToProto()
+	if err != nil {
+		return err
+	}
+	bc.pruner.mu.Lock()
+	defer bc.pruner.mu.Unlock()
+
+	if err := bc.retainRoots(header.(*corepb.BlockHeader)); err != nil {
+		return err
+	}
+	key := blockStatesKey(block.Height())
+	hashes, err := bc.storage.Get(key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return err
+	}
+	return bc.storage.Put(key, append(hashes, block.Hash()...))
+}
+
+// retainRoots references the tries referred by the header, the caller holds the lock.
+func (bc *BlockChain) retainRoots(header *corepb.BlockHeader) error {
+	roots, callbacks := stateRoots(header)
+	for i, root := range roots {
+		if err := bc.refCounter().Retain(root, callbacks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune releases the states of the blocks below targetHeight, including the blocks on forks,
+// the genesis and the checkpoints are kept. The recent MinPruneRetention blocks can't be pruned,
+// nor the states of an archive node.
+// It's safe to prune while the chain is running, the states of the blocks pruned can't be read anymore.
+func (bc *BlockChain) Prune(targetHeight uint64) error {
+	bc.pruner.mu.Lock()
+	defer bc.pruner.mu.Unlock()
+
+	if bc.pruner.retention == 0 {
+		return ErrPruneArchiveNode
+	}
+	tail := bc.TailBlock()
+	if targetHeight+MinPruneRetention > tail.Height() {
+		return ErrPruneTooRecent
+	}
+	return bc.prune(targetHeight)
+}
+
+func (bc *BlockChain) prune(targetHeight uint64) error {
+	pruned := bc.prunedHeight()
+	if pruned < 2 {
+		// the genesis at height 1 is always kept.
+		pruned = 2
+	}
+	deleted := 0
+	for height := pruned; height < targetHeight; height++ {
+		if bc.pruner.checkpointInterval == 0 || height%bc.pruner.checkpointInterval != 0 {
+			n, err := bc.pruneHeight(height)
+			if err != nil {
+				return err
+			}
+			deleted += n
+		}
+		if err := bc.storage.Put([]byte(PrunedHeight), byteutils.FromUint64(height+1)); err != nil {
+			return err
+		}
+	}
+
+	if deleted > 0 {
+		prunedNodesMeter.Mark(int64(deleted))
+		bc.pruner.uncompacted += deleted
+		if bc.pruner.uncompacted >= CompactAfterPrunedNodes && !bc.pruner.compacting {
+			bc.pruner.uncompacted = 0
+			bc.pruner.compacting = true
+			go bc.autoCompact()
+		}
+	}
+	prunedHeightGauge.Update(int64(targetHeight))
+	logging.VLog().WithFields(logrus.Fields{
+		"from":    pruned,
+		"to":      targetHeight,
+		"deleted": deleted,
+	}).Info("Pruned the states of blocks.")
+	return nil
+}
+
+// pruneHeight releases the states of the blocks at the height.
+func (bc *BlockChain) pruneHeight(height uint64) (int, error) {
+	key := blockStatesKey(height)
+	hashes, err := bc.storage.Get(key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return 0, err
+	}
+	deleted := 0
+	for i := 0; i+BlockHashLength <= len(hashes); i += BlockHashLength {
+		n, err := bc.releaseStates(hashes[i : i+BlockHashLength])
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+		if err := bc.forgetVerified(hashes[i : i+BlockHashLength]); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, bc.storage.Del(key)
+}
+
+func (bc *BlockChain) releaseStates(hash byteutils.Hash) (int, error) {
+	value, err := bc.storage.Get(hash)
+	if err == storage.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(value, pbBlock); err != nil {
+		return 0, err
+	}
+	if pbBlock.Header == nil {
+		return 0, nil
+	}
+	deleted := 0
+	roots, callbacks := stateRoots(pbBlock.Header)
+	for i, root := range roots {
+		n, err := bc.refCounter().Release(root, callbacks[i])
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
+
+// autoPrune prunes the states out of the retention when the tail grows.
+func (bc *BlockChain) autoPrune(tail *Block) {
+	bc.pruner.mu.Lock()
+	defer bc.pruner.mu.Unlock()
+
+	retention := bc.pruner.retention
+	if retention == 0 || tail.Height() <= retention {
+		return
+	}
+	target := tail.Height() - retention
+	if target <= bc.prunedHeight() {
+		return
+	}
+	if err := bc.prune(target); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"target": target,
+			"err":    err,
+		}).Error("Failed to prune the states of blocks.")
+	}
+}
+
+// autoCompact compacts the storage to reclaim the space of the nodes pruned, the trie nodes are
+// spread over all the keys so it compacts them all.
+func (bc *BlockChain) autoCompact() {
+	defer func() {
+		bc.pruner.mu.Lock()
+		bc.pruner.compacting = false
+		bc.pruner.mu.Unlock()
+	}()
+	if err := bc.CompactStorage(nil); err != nil && err != storage.ErrCompactionUnsupported {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Error("Failed to compact the storage after pruning.")
+	}
+}
+
+// CompactStorage compacts the storage of chain in the range, all if nil.
+func (bc *BlockChain) CompactStorage(r *storage.Range) error {
+	start := time.Now()
+	if err := storage.Compact(bc.storage, r); err != nil {
+		return err
+	}
+	compactionTimer.UpdateSince(start)
+	logging.CLog().WithFields(logrus.Fields{
+		"elapsed": time.Since(start),
+	}).Info("Compacted the storage.")
+	return nil
+}
+
+// StorageStats returns the statistics of the storage of chain with the sizes of the prefixes.
+func (bc *BlockChain) StorageStats(prefixes [][]byte) (*storage.Stats, error) {
+	return storage.GetStats(bc.storage, prefixes)
+}
+
+// StatesPruned returns true if the states of block were pruned.
+func (block *Block) StatesPruned() bool {
+	if len(block.StateRoot()) == 0 || block.storage == nil {
+		return false
+	}
+	_, err := block.storage.Get(block.StateRoot())
+	return err == storage.ErrKeyNotFound
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_Prune(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	assert.Equal(t, ArchiveNode, bc.NodeMode())
+	assert.Equal(t, ErrPruneArchiveNode, bc.Prune(2))
+	assert.Equal(t, ErrUnknownNodeMode, bc.SetNodeMode("light", 0, 0))
+	assert.Nil(t, bc.SetNodeMode(FullNode, 0, 4))
+	assert.Equal(t, FullNode, bc.NodeMode())
+
+	var coinbases []*Address
+	mint := func(parent *Block, timestamp int64) *Block {
+		coinbase := &Address{[]byte(fmt.Sprintf("0123456789012345678900%02d", len(coinbases)))}
+		coinbases = append(coinbases, coinbase)
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		return block
+	}
+	/*
+		genesis -- 2 -- 3 -- 4 -- 5 -- 6
+		             \_ 3'
+	*/
+	blocks := []*Block{bc.genesisBlock}
+	for i := 0; i < 5; i++ {
+		block := mint(blocks[len(blocks)-1], BlockInterval*int64(i+1))
+		assert.Nil(t, bc.SetTailBlock(block))
+		blocks = append(blocks, block)
+	}
+	fork := mint(blocks[1], BlockInterval*10)
+	assert.Equal(t, uint64(3), fork.Height())
+
+	// complete returns true if all the nodes of the states of block are in storage.
+	complete := func(block *Block) bool {
+		header, _ := block.header.ToProto()
+		roots, callbacks := stateRoots(header.(*corepb.BlockHeader))
+		for i, root := range roots {
+			s := trie.NewSync(bc.storage, callbacks[i])
+			assert.Nil(t, s.AddRoot(root))
+			if s.Pending() > 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	assert.Equal(t, ErrPruneTooRecent, bc.Prune(5))
+	assert.Nil(t, bc.prune(5))
+	assert.Equal(t, uint64(5), bc.PrunedHeight())
+
+	// the states below the height are pruned except the genesis and checkpoints.
+	for _, block := range []*Block{blocks[1], blocks[2], fork} {
+		assert.True(t, block.StatesPruned())
+		_, err := bc.storage.Get(verifiedBlockKey(block.Hash()))
+		assert.Equal(t, storage.ErrKeyNotFound, err)
+		loaded, err := LoadBlockFromStorage(block.Hash(), bc.storage, bc.txPool, bc.eventEmitter)
+		assert.Nil(t, err)
+		assert.Equal(t, block.Hash(), loaded.Hash())
+		assert.True(t, loaded.StatesPruned())
+		_, err = NewBlock(bc.ChainID(), coinbases[0], loaded)
+		assert.Equal(t, ErrBlockStatePruned, err)
+	}
+	for _, block := range []*Block{blocks[0], blocks[3], blocks[4], blocks[5]} {
+		assert.False(t, block.StatesPruned())
+		if block != blocks[0] {
+			_, err := bc.storage.Get(verifiedBlockKey(block.Hash()))
+			assert.Nil(t, err)
+		}
+		loaded, err := LoadBlockFromStorage(block.Hash(), bc.storage, bc.txPool, bc.eventEmitter)
+		assert.Nil(t, err)
+		assert.Equal(t, block.StateRoot(), loaded.accState.RootHash())
+	}
+
+	// the states shared with the kept blocks are intact.
+	for _, block := range []*Block{blocks[0], blocks[3], blocks[4], blocks[5]} {
+		assert.True(t, complete(block))
+	}
+	assert.False(t, complete(blocks[2]))
+	block := mint(bc.TailBlock(), BlockInterval*11)
+	assert.Nil(t, bc.SetTailBlock(block))
+
+	// pruning again continues from the height pruned.
+	assert.Nil(t, bc.prune(6))
+	assert.False(t, blocks[3].StatesPruned())
+	assert.True(t, blocks[4].StatesPruned())
+	assert.True(t, complete(blocks[5]))
+	assert.True(t, complete(block))
+	assert.Equal(t, uint64(6), bc.PrunedHeight())
+
+	// the states pruned can't be served by an archive node.
+	assert.Equal(t, ErrStatesPruned, bc.SetNodeMode(ArchiveNode, 0, 0))
+}
+
+func TestBlockChain_CompactStorage(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	stats, err := bc.StorageStats([][]byte{[]byte(Tail), []byte("missing")})
+	assert.Nil(t, err)
+	assert.True(t, stats.Size > 0)
+	assert.True(t, stats.PrefixSizes[Tail] > 0)
+	assert.Equal(t, int64(0), stats.PrefixSizes["missing"])
+	assert.Nil(t, bc.CompactStorage(nil))
+
+	// another compaction after pruning may run after one is done.
+	bc.pruner.compacting = true
+	bc.autoCompact()
+	assert.False(t, bc.pruner.compacting)
+}
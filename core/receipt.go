@@ -0,0 +1,213 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Status of transaction execution
+const (
+	ReceiptStatusFailed  uint32 = 0
+	ReceiptStatusSuccess uint32 = 1
+)
+
+// Receipt is the execution result of a transaction in block.
+type Receipt struct {
+	txHash          byteutils.Hash
+	status          uint32
+	gasUsed         *util.Uint128
+	fee             *util.Uint128
+	contractAddress *Address
+	eventHashes     []byteutils.Hash
+	failure         *ExecutionError
+}
+
+// ToProto converts domain Receipt into proto Receipt
+func (r *Receipt) ToProto() (proto.Message, error) {
+	gasUsed, err := r.gasUsed.ToFixedSizeByteSlice()
+	if err != nil {
+		return nil, err
+	}
+	fee, err := gasBytes(r.fee)
+	if err != nil {
+		return nil, err
+	}
+	var contractAddress []byte
+	if r.contractAddress != nil {
+		contractAddress = r.contractAddress.address
+	}
+	eventHashes := make([][]byte, len(r.eventHashes))
+	for i, v := range r.eventHashes {
+		eventHashes[i] = v
+	}
+	pbReceipt := &corepb.Receipt{
+		TxHash:          r.txHash,
+		Status:          r.status,
+		GasUsed:         gasUsed,
+		Fee:             fee,
+		ContractAddress: contractAddress,
+		EventHashes:     eventHashes,
+	}
+	if r.failure != nil {
+		pbReceipt.ErrorCode = r.failure.Code
+		pbReceipt.ErrorMessage = r.failure.Message
+	}
+	return pbReceipt, nil
+}
+
+// FromProto converts proto Receipt into domain Receipt
+func (r *Receipt) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.Receipt); ok {
+		gasUsed, err := util.NewUint128FromFixedSizeByteSlice(msg.GasUsed)
+		if err != nil {
+			return err
+		}
+		fee, err := gasFromBytes(msg.Fee)
+		if err != nil {
+			return err
+		}
+		r.txHash = msg.TxHash
+		r.status = msg.Status
+		r.gasUsed = gasUsed
+		r.fee = fee
+		r.contractAddress = nil
+		if len(msg.ContractAddress) > 0 {
+			r.contractAddress = &Address{msg.ContractAddress}
+		}
+		r.eventHashes = make([]byteutils.Hash, len(msg.EventHashes))
+		for i, v := range msg.EventHashes {
+			r.eventHashes[i] = v
+		}
+		r.failure = nil
+		if len(msg.ErrorCode) > 0 {
+			r.failure = &ExecutionError{Code: msg.ErrorCode, Message: msg.ErrorMessage}
+		}
+		return nil
+	}
+	return errors.New("Protobuf message cannot be converted into Receipt")
+}
+
+// TxHash return the hash of transaction.
+func (r *Receipt) TxHash() byteutils.Hash {
+	return r.txHash
+}
+
+// Status return the execution status of transaction.
+func (r *Receipt) Status() uint32 {
+	return r.status
+}
+
+// GasUsed return the gas used by transaction.
+func (r *Receipt) GasUsed() *util.Uint128 {
+	return r.gasUsed
+}
+
+// Fee return the fee paid by the sender to the coinbase, gas used * gas price.
+func (r *Receipt) Fee() *util.Uint128 {
+	if r.fee == nil {
+		return util.NewUint128()
+	}
+	return r.fee
+}
+
+// ContractAddress return the address of contract deployed by transaction, nil if none.
+func (r *Receipt) ContractAddress() *Address {
+	return r.contractAddress
+}
+
+// EventHashes return the hashes of events emitted by transaction.
+func (r *Receipt) EventHashes() []byteutils.Hash {
+	return r.eventHashes
+}
+
+// Failure return the reason of the failed transaction, nil if it succeeded.
+func (r *Receipt) Failure() *ExecutionError {
+	return r.failure
+}
+
+// newReceipt builds the receipt of tx executed in block from the events it emitted.
+func (block *Block) newReceipt(tx *Transaction, gasUsed *util.Uint128) (*Receipt, error) {
+	events, err := block.FetchEvents(tx.hash)
+	if err != nil {
+		return nil, err
+	}
+	r := &Receipt{
+		txHash:  tx.hash,
+		status:  ReceiptStatusFailed,
+		gasUsed: gasUsed,
+		fee:     tx.Fee(gasUsed),
+	}
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		r.eventHashes = append(r.eventHashes, hash.Sha3256(data))
+	}
+	if len(events) > 0 {
+		r.failure = executionErrorOf(events[len(events)-1])
+	}
+	if len(events) > 0 && events[len(events)-1].Topic == TopicExecuteTxSuccess {
+		r.status = ReceiptStatusSuccess
+		if tx.Type() == TxPayloadDeployType {
+			if r.contractAddress, err = tx.GenerateContractAddress(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, nil
+}
+
+func (block *Block) recordReceipt(r *Receipt) error {
+	pbReceipt, err := r.ToProto()
+	if err != nil {
+		return err
+	}
+	bytes, err := proto.Marshal(pbReceipt)
+	if err != nil {
+		return err
+	}
+	_, err = block.receiptsTrie.Put(r.txHash, bytes)
+	return err
+}
+
+// GetReceipt returns the receipt of transaction executed in the block.
+func (block *Block) GetReceipt(txHash byteutils.Hash) (*Receipt, error) {
+	bytes, err := block.receiptsTrie.Get(txHash)
+	if err != nil {
+		return nil, err
+	}
+	pbReceipt := new(corepb.Receipt)
+	if err := proto.Unmarshal(bytes, pbReceipt); err != nil {
+		return nil, err
+	}
+	r := new(Receipt)
+	if err := r.FromProto(pbReceipt); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceipt_Proto(t *testing.T) {
+	r := &Receipt{
+		txHash:          []byte("tx"),
+		status:          ReceiptStatusSuccess,
+		gasUsed:         util.NewUint128FromInt(20000),
+		fee:             util.NewUint128FromInt(20000000000),
+		contractAddress: &Address{[]byte("012345678901234567890000")},
+		eventHashes:     []byteutils.Hash{[]byte("e1"), []byte("e2")},
+	}
+	pbReceipt, err := r.ToProto()
+	assert.Nil(t, err)
+	r2 := new(Receipt)
+	assert.Nil(t, r2.FromProto(pbReceipt))
+	assert.Equal(t, r, r2)
+
+	// the receipt of failed transaction has no contract address.
+	r.contractAddress = nil
+	pbReceipt, err = r.ToProto()
+	assert.Nil(t, err)
+	assert.Nil(t, r2.FromProto(pbReceipt))
+	assert.Nil(t, r2.ContractAddress())
+
+	// the receipt of failed transaction carries the reason.
+	r.failure = &ExecutionError{Code: ExecutionErrReverted, Message: "Error: not owner"}
+	pbReceipt, err = r.ToProto()
+	assert.Nil(t, err)
+	assert.Nil(t, r2.FromProto(pbReceipt))
+	assert.Equal(t, r.failure, r2.Failure())
+
+	// the receipt recorded before fees has no fee.
+	pbReceipt.(*corepb.Receipt).Fee = nil
+	assert.Nil(t, r2.FromProto(pbReceipt))
+	assert.Equal(t, util.NewUint128(), r2.Fee())
+
+	assert.NotNil(t, r2.FromProto(&corepb.Block{}))
+}
+
+func TestBlock_GetReceipt(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := &Address{[]byte("012345678901234567890000")}
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int)
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(util.NewUint128FromBigInt(balance))
+	bc.tailBlock.commit()
+
+	tx1 := NewTransaction(bc.ChainID(), from, coinbase, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+	assert.Nil(t, tx1.Sign(signature))
+	// the value and fees are covered at admission, but exceed the balance after tx1 paid, the transaction is packed but failed.
+	tx2 := NewTransaction(bc.ChainID(), from, coinbase, util.NewUint128(), 2, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+	tx2.gasLimit = tx2.GasCountOfTxBase()
+	tx2.value = util.NewUint128FromBigInt(util.NewUint128().Sub(balance, tx2.MinBalanceRequired().Int))
+	assert.Nil(t, tx2.Sign(signature))
+	assert.Nil(t, bc.txPool.Push(tx1))
+	assert.Nil(t, bc.txPool.Push(tx2))
+
+	block, _ := bc.NewBlock(coinbase)
+	block.header.timestamp = BlockInterval
+	block.CollectTransactions(2)
+	block.SetMiner(coinbase)
+	assert.Nil(t, block.Seal())
+	assert.Equal(t, 2, len(block.transactions))
+	assert.Equal(t, byteutils.Hash(block.receiptsTrie.RootHash()), block.ReceiptsRoot())
+
+	r1, err := block.GetReceipt(tx1.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, tx1.Hash(), r1.TxHash())
+	assert.Equal(t, ReceiptStatusSuccess, r1.Status())
+	assert.Nil(t, r1.Failure())
+	assert.Equal(t, tx1.GasCountOfTxBase(), r1.GasUsed())
+	assert.Nil(t, r1.ContractAddress())
+	events, err := block.FetchEvents(tx1.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, len(events), len(r1.EventHashes()))
+
+	r2, err := block.GetReceipt(tx2.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, ReceiptStatusFailed, r2.Status())
+	assert.Equal(t, NewExecutionError(ExecutionErrInsufficientBalance, ErrInsufficientBalance), r2.Failure())
+
+	_, err = block.GetReceipt([]byte("unknown"))
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+
+	// the receipt is proved against the receipts root in header.
+	h, _ := block.LightHeader()
+	value, proof, err := block.Prove(ProofReceipt, tx1.Hash())
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyProof(h.Header, ProofReceipt, tx1.Hash(), value, proof))
+	pbReceipt := new(corepb.Receipt)
+	assert.Nil(t, proto.Unmarshal(value, pbReceipt))
+	assert.Equal(t, ReceiptStatusSuccess, pbReceipt.Status)
+
+	// the receipts are covered by the block hash.
+	header := block.header.receiptsRoot
+	block.header.receiptsRoot = []byte("fake")
+	assert.NotEqual(t, block.Hash(), HashBlock(block))
+	block.header.receiptsRoot = header
+	assert.Equal(t, block.Hash(), HashBlock(block))
+}
+
+func TestBlock_TransactionFee(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase, to := mockAddress(), mockAddress()
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	bc.tailBlock.commit()
+
+	login, _ := NewCandidatePayload(LoginAction).ToBytes()
+	txs := []*Transaction{
+		NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000)),
+		// the value exceeds the balance after the former paid.
+		NewTransaction(bc.ChainID(), from, to, util.NewUint128(), 2, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000)),
+		// the payload is invalid.
+		NewTransaction(bc.ChainID(), from, to, util.NewUint128(), 3, TxPayloadCallType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000)),
+		NewTransaction(bc.ChainID(), from, from, util.NewUint128(), 4, TxPayloadCandidateType, login, TransactionGasPrice, util.NewUint128FromInt(200000)),
+	}
+	// the gas limit doesn't cover the base gas of payload, all the gas limit is charged.
+	txs[3].gasLimit = util.NewUint128FromBigInt(util.NewUint128().Add(txs[3].GasCountOfTxBase().Int, util.NewUint128FromInt(1).Int))
+	// the value and fees are covered at admission.
+	txs[1].gasLimit = txs[1].GasCountOfTxBase()
+	txs[1].value = util.NewUint128FromBigInt(util.NewUint128().Sub(balance.Int, txs[1].MinBalanceRequired().Int))
+	for _, tx := range txs {
+		assert.Nil(t, tx.Sign(signature))
+		assert.Nil(t, bc.txPool.Push(tx))
+	}
+
+	block, _ := bc.NewBlock(coinbase)
+	block.header.timestamp = BlockInterval
+	block.CollectTransactions(len(txs))
+	block.SetMiner(coinbase)
+	assert.Nil(t, block.Seal())
+	assert.Equal(t, len(txs), len(block.transactions))
+
+	fees := util.NewUint128()
+	for i, tx := range txs {
+		r, err := block.GetReceipt(tx.Hash())
+		assert.Nil(t, err)
+		if i == 0 {
+			assert.Equal(t, ReceiptStatusSuccess, r.Status())
+		} else {
+			assert.Equal(t, ReceiptStatusFailed, r.Status())
+		}
+		gasUsed := tx.GasCountOfTxBase()
+		if i == 3 {
+			gasUsed = tx.gasLimit
+		}
+		assert.Equal(t, gasUsed.String(), r.GasUsed().String())
+		assert.Equal(t, tx.Fee(gasUsed).String(), r.Fee().String())
+		fees.Add(fees.Int, r.Fee().Int)
+	}
+
+	// the fees are charged from the sender even if the transactions failed, and credited to the coinbase.
+	left := util.NewUint128().Sub(balance.Int, fees.Int)
+	left.Sub(left, txs[0].value.Int)
+	assert.Equal(t, left.String(), block.GetBalance(from.address).String())
+	assert.Equal(t, txs[0].value.String(), block.GetBalance(to.address).String())
+	earned := util.NewUint128().Add(block.Reward().Int, fees.Int)
+	assert.Equal(t, earned.String(), block.GetBalance(coinbase.address).String())
+}
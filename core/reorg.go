@@ -0,0 +1,125 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxReorgHistory is the count of recent reorgs kept for operators.
+const MaxReorgHistory = 64
+
+// Reorg is a switch of the canonical chain to the tail on another fork.
+type Reorg struct {
+	Time           int64  `json:"time"`
+	Ancestor       string `json:"ancestor"`
+	AncestorHeight uint64 `json:"ancestor_height"`
+	OldTail        string `json:"old_tail"`
+	NewTail        string `json:"new_tail"`
+	// Reverted are the hashes of blocks left the canonical chain, from the old tail down to the ancestor.
+	Reverted []string `json:"reverted"`
+	// Applied are the hashes of blocks joined the canonical chain, from the ancestor up to the new tail.
+	Applied []string `json:"applied"`
+}
+
+// forkMonitor keeps the recent reorgs of the chain.
+type forkMonitor struct {
+	mu      sync.Mutex
+	history []*Reorg
+}
+
+func (m *forkMonitor) add(r *Reorg) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history = append(m.history, r)
+	if len(m.history) > MaxReorgHistory {
+		m.history = m.history[len(m.history)-MaxReorgHistory:]
+	}
+}
+
+func (m *forkMonitor) list() []*Reorg {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	history := make([]*Reorg, len(m.history))
+	copy(history, m.history)
+	return history
+}
+
+// ReorgHistory returns the recent reorgs of the canonical chain, the latest is the last.
+func (bc *BlockChain) ReorgHistory() []*Reorg {
+	return bc.forkMonitor.list()
+}
+
+// newReorg collects the blocks reverted and applied when the tail switches from oldTail to newTail.
+func (bc *BlockChain) newReorg(ancestor, oldTail, newTail *Block) (*Reorg, error) {
+	r := &Reorg{
+		Time:           time.Now().Unix(),
+		Ancestor:       ancestor.Hash().String(),
+		AncestorHeight: ancestor.Height(),
+		OldTail:        oldTail.Hash().String(),
+		NewTail:        newTail.Hash().String(),
+	}
+	for block := oldTail.ChainHeader(); !block.Hash().Equals(ancestor.Hash()); {
+		r.Reverted = append(r.Reverted, block.Hash().String())
+		if block = bc.GetBlockHeader(block.ParentHash()); block == nil {
+			return nil, ErrMissingParentBlock
+		}
+	}
+	var applied []string
+	for block := newTail.ChainHeader(); !block.Hash().Equals(ancestor.Hash()); {
+		applied = append(applied, block.Hash().String())
+		if block = bc.GetBlockHeader(block.ParentHash()); block == nil {
+			return nil, ErrMissingParentBlock
+		}
+	}
+	for i := len(applied) - 1; i >= 0; i-- {
+		r.Applied = append(r.Applied, applied[i])
+	}
+	return r, nil
+}
+
+// onReorg records the reorg and notifies the subscribers of TopicChainReorg.
+func (bc *BlockChain) onReorg(r *Reorg) {
+	bc.forkMonitor.add(r)
+
+	logging.CLog().WithFields(logrus.Fields{
+		"ancestor": r.Ancestor,
+		"oldTail":  r.OldTail,
+		"newTail":  r.NewTail,
+		"reverted": len(r.Reverted),
+		"applied":  len(r.Applied),
+	}).Warn("Canonical chain reorganized.")
+
+	if bc.eventEmitter == nil {
+		return
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	bc.eventEmitter.Trigger(&Event{
+		Topic: TopicChainReorg,
+		Data:  string(data),
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_ReorgHistory(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	eventCh := make(chan *Event, 1)
+	bc.eventEmitter.Register(TopicChainReorg, eventCh)
+	bc.eventEmitter.Start()
+	defer bc.eventEmitter.Stop()
+
+	mint := func(coinbase *Address, timestamp int64) *Block {
+		block, _ := bc.NewBlock(coinbase)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		return block
+	}
+	/*
+		genesis -- 1 -- 11 -- 111
+		             \_ 12
+	*/
+	block1 := mint(&Address{[]byte("012345678901234567890001")}, BlockInterval)
+	assert.Nil(t, bc.SetTailBlock(block1))
+	block11 := mint(&Address{[]byte("012345678901234567890011")}, BlockInterval*2)
+	block12 := mint(&Address{[]byte("012345678901234567890012")}, BlockInterval*3)
+	assert.Nil(t, bc.SetTailBlock(block11))
+	block111 := mint(&Address{[]byte("012345678901234567890111")}, BlockInterval*4)
+	assert.Nil(t, bc.SetTailBlock(block111))
+	assert.Equal(t, 0, len(bc.ReorgHistory()))
+
+	assert.Nil(t, bc.SetTailBlock(block12))
+	history := bc.ReorgHistory()
+	assert.Equal(t, 1, len(history))
+	reorg := history[0]
+	assert.Equal(t, block1.Hash().String(), reorg.Ancestor)
+	assert.Equal(t, block1.Height(), reorg.AncestorHeight)
+	assert.Equal(t, block111.Hash().String(), reorg.OldTail)
+	assert.Equal(t, block12.Hash().String(), reorg.NewTail)
+	assert.Equal(t, []string{block111.Hash().String(), block11.Hash().String()}, reorg.Reverted)
+	assert.Equal(t, []string{block12.Hash().String()}, reorg.Applied)
+
+	event := <-eventCh
+	notified := new(Reorg)
+	assert.Nil(t, json.Unmarshal([]byte(event.Data), notified))
+	assert.Equal(t, reorg, notified)
+
+	assert.Nil(t, bc.SetTailBlock(block111))
+	history = bc.ReorgHistory()
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, []string{block11.Hash().String(), block111.Hash().String()}, history[1].Applied)
+}
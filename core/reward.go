@@ -0,0 +1,152 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"golang.org/x/crypto/sha3"
+)
+
+// Errors of reward schedule
+var (
+	ErrInvalidRewardValue = errors.New("invalid reward value in genesis")
+	ErrInvalidRewardStep  = errors.New("reward steps in genesis must be in ascending heights after genesis")
+	ErrInvalidRewardDecay = errors.New("invalid reward decay in genesis, numerator must not exceed denominator")
+)
+
+// RewardStep sets the reward from the height.
+type RewardStep struct {
+	Height uint64
+	Reward *util.Uint128
+}
+
+// RewardSchedule is the reward of coinbase by height, recorded in genesis conf.
+// The reward steps to the value of the last step at or below the height, and decays
+// every DecayInterval blocks from the step by DecayNumerator/DecayDenominator.
+// All in integers, every validator gets the same reward of a height.
+type RewardSchedule struct {
+	Steps            []*RewardStep
+	DecayInterval    uint64
+	DecayNumerator   uint64
+	DecayDenominator uint64
+}
+
+// DefaultRewardSchedule is BlockReward for ever.
+var DefaultRewardSchedule = &RewardSchedule{
+	Steps: []*RewardStep{&RewardStep{Height: 2, Reward: BlockReward}},
+}
+
+func parseReward(value string) (*util.Uint128, error) {
+	v, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, ErrInvalidRewardValue
+	}
+	reward := util.NewUint128FromBigInt(v)
+	if err := reward.Validate(); err != nil {
+		return nil, ErrInvalidRewardValue
+	}
+	return reward, nil
+}
+
+// NewRewardSchedule returns the reward schedule in genesis conf, DefaultRewardSchedule if not configured.
+func NewRewardSchedule(conf *corepb.Genesis) (*RewardSchedule, error) {
+	if conf.Consensus == nil || conf.Consensus.Reward == nil {
+		return DefaultRewardSchedule, nil
+	}
+	pbReward := conf.Consensus.Reward
+
+	initial := BlockReward
+	if len(pbReward.Initial) > 0 {
+		v, err := parseReward(pbReward.Initial)
+		if err != nil {
+			return nil, err
+		}
+		initial = v
+	}
+	schedule := &RewardSchedule{
+		Steps:            []*RewardStep{&RewardStep{Height: 2, Reward: initial}},
+		DecayInterval:    pbReward.DecayInterval,
+		DecayNumerator:   pbReward.DecayNumerator,
+		DecayDenominator: pbReward.DecayDenominator,
+	}
+	for _, v := range pbReward.Steps {
+		if v.Height <= schedule.Steps[len(schedule.Steps)-1].Height {
+			return nil, ErrInvalidRewardStep
+		}
+		reward, err := parseReward(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Steps = append(schedule.Steps, &RewardStep{Height: v.Height, Reward: reward})
+	}
+	if schedule.DecayInterval > 0 &&
+		(schedule.DecayDenominator == 0 || schedule.DecayNumerator > schedule.DecayDenominator) {
+		return nil, ErrInvalidRewardDecay
+	}
+	return schedule, nil
+}
+
+// Reward returns the reward of coinbase of the block at height.
+func (s *RewardSchedule) Reward(height uint64) *util.Uint128 {
+	var step *RewardStep
+	for _, v := range s.Steps {
+		if v.Height > height {
+			break
+		}
+		step = v
+	}
+	if step == nil {
+		// genesis is not rewarded.
+		return util.NewUint128()
+	}
+	if s.DecayInterval == 0 || s.DecayNumerator == s.DecayDenominator {
+		return step.Reward
+	}
+
+	reward := new(big.Int).Set(step.Reward.Int)
+	numerator := new(big.Int).SetUint64(s.DecayNumerator)
+	denominator := new(big.Int).SetUint64(s.DecayDenominator)
+	for n := (height - step.Height) / s.DecayInterval; n > 0 && reward.Sign() > 0; n-- {
+		reward.Mul(reward, numerator)
+		reward.Div(reward, denominator)
+	}
+	return util.NewUint128FromBigInt(reward)
+}
+
+// Hash returns the hash of the schedule, nil for DefaultRewardSchedule.
+func (s *RewardSchedule) Hash() byteutils.Hash {
+	if s == DefaultRewardSchedule {
+		return nil
+	}
+	hasher := sha3.New256()
+	for _, v := range s.Steps {
+		hasher.Write(byteutils.FromUint64(v.Height))
+		reward, _ := v.Reward.ToFixedSizeByteSlice()
+		hasher.Write(reward)
+	}
+	hasher.Write(byteutils.FromUint64(s.DecayInterval))
+	hasher.Write(byteutils.FromUint64(s.DecayNumerator))
+	hasher.Write(byteutils.FromUint64(s.DecayDenominator))
+	return hasher.Sum(nil)
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardSchedule_Reward(t *testing.T) {
+	halving := &corepb.GenesisReward{
+		Initial:          "1000",
+		DecayInterval:    10,
+		DecayNumerator:   1,
+		DecayDenominator: 2,
+	}
+	steps := &corepb.GenesisReward{
+		Initial: "1000",
+		Steps: []*corepb.GenesisRewardStep{
+			&corepb.GenesisRewardStep{Height: 100, Value: "500"},
+			&corepb.GenesisRewardStep{Height: 200, Value: "0"},
+		},
+	}
+	tests := []struct {
+		name   string
+		reward *corepb.GenesisReward
+		height uint64
+		want   *util.Uint128
+	}{
+		{"genesis", nil, 1, util.NewUint128()},
+		{"default", nil, 1000000, BlockReward},
+		{"initial", &corepb.GenesisReward{}, 2, BlockReward},
+		{"before halving", halving, 11, util.NewUint128FromInt(1000)},
+		{"first halving", halving, 12, util.NewUint128FromInt(500)},
+		{"third halving", halving, 32, util.NewUint128FromInt(125)},
+		{"halved to zero", halving, 1000000, util.NewUint128()},
+		{"before step", steps, 99, util.NewUint128FromInt(1000)},
+		{"step", steps, 100, util.NewUint128FromInt(500)},
+		{"last step", steps, 1000, util.NewUint128()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := MockGenesisConf()
+			conf.Consensus.Reward = tt.reward
+			schedule, err := NewRewardSchedule(conf)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want.String(), schedule.Reward(tt.height).String())
+		})
+	}
+}
+
+func TestNewRewardSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		reward *corepb.GenesisReward
+		err    error
+	}{
+		{"invalid initial", &corepb.GenesisReward{Initial: "nas"}, ErrInvalidRewardValue},
+		{"negative step", &corepb.GenesisReward{Steps: []*corepb.GenesisRewardStep{&corepb.GenesisRewardStep{Height: 10, Value: "-1"}}}, ErrInvalidRewardValue},
+		{"step at genesis", &corepb.GenesisReward{Steps: []*corepb.GenesisRewardStep{&corepb.GenesisRewardStep{Height: 1, Value: "1"}}}, ErrInvalidRewardStep},
+		{"descending steps", &corepb.GenesisReward{Steps: []*corepb.GenesisRewardStep{
+			&corepb.GenesisRewardStep{Height: 20, Value: "1"},
+			&corepb.GenesisRewardStep{Height: 10, Value: "1"},
+		}}, ErrInvalidRewardStep},
+		{"zero denominator", &corepb.GenesisReward{DecayInterval: 10, DecayNumerator: 1}, ErrInvalidRewardDecay},
+		{"growth", &corepb.GenesisReward{DecayInterval: 10, DecayNumerator: 3, DecayDenominator: 2}, ErrInvalidRewardDecay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := MockGenesisConf()
+			conf.Consensus.Reward = tt.reward
+			_, err := NewRewardSchedule(conf)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestBlockChain_RewardSchedule(t *testing.T) {
+	neb := testNeb()
+	neb.genesis.Consensus.Reward = &corepb.GenesisReward{
+		Initial: "1000",
+		Steps:   []*corepb.GenesisRewardStep{&corepb.GenesisRewardStep{Height: 3, Value: "500"}},
+	}
+	bc, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+
+	// the reward schedule is hashed into the genesis digest.
+	defaultDigest, err := GenesisConfDigest(MockGenesisConf())
+	assert.Nil(t, err)
+	assert.NotEqual(t, defaultDigest, bc.GenesisDigest())
+
+	validators, err := TraverseDynasty(bc.tailBlock.dposContext.dynastyTrie)
+	assert.Nil(t, err)
+	parent := bc.tailBlock
+	for i, want := range []int64{1000, 500} {
+		coinbase := &Address{validators[i+2]}
+		block, err := NewBlock(bc.ChainID(), coinbase, parent)
+		assert.Nil(t, err)
+		assert.Equal(t, want, block.Reward().Int64())
+		assert.Equal(t, want, block.GetBalance(coinbase.address).Int64())
+
+		block.header.timestamp = BlockInterval * int64(i+1)
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		received, err := mockBlockFromNetwork(block)
+		assert.Nil(t, err)
+		assert.Nil(t, received.LinkParentBlock(parent))
+		received.SetMiner(coinbase)
+		assert.Nil(t, received.VerifyExecution(parent, c))
+		parent = received
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+// MaxGasEstimateSteps is the max count of simulations in the binary search of a gas estimate.
+const MaxGasEstimateSteps = 64
+
+// Simulation is the result of a transaction executed in a sandbox, nothing is committed.
+type Simulation struct {
+	// Receipt tells the execution status and the gas used, the estimate of gas of the transaction.
+	Receipt *Receipt
+	Events  []*Event
+}
+
+// GasEstimate is the least gas limit a transaction succeeds with on the states of the tail.
+type GasEstimate struct {
+	// Gas is the least gas limit, or the gas used at the max limit if the transaction never succeeds.
+	Gas *util.Uint128
+	// Failure is the reason of the transaction failed at the max limit, nil if it succeeds.
+	Failure *ExecutionError
+}
+
+// sandbox returns a copy of the block on the states it committed, in a batch never committed,
+// so the changes made on it don't affect the block.
+func (block *Block) sandbox() (*Block, error) {
+	if block.accState == nil {
+		return nil, ErrBlockStatePruned
+	}
+	sandbox := &Block{
+		header:  block.header,
+		sealed:  true,
+		height:  block.height,
+		txPool:  block.txPool,
+		miner:   block.miner,
+		storage: block.storage,
+	}
+	var err error
+	if sandbox.accState, err = state.NewAccountState(block.StateRoot(), block.storage); err != nil {
+		return nil, err
+	}
+	if sandbox.txsTrie, err = trie.NewBatchTrie(block.TxsRoot(), block.storage); err != nil {
+		return nil, err
+	}
+	if sandbox.eventsTrie, err = trie.NewBatchTrie(block.EventsRoot(), block.storage); err != nil {
+		return nil, err
+	}
+	if sandbox.receiptsTrie, err = trie.NewBatchTrie(block.ReceiptsRoot(), block.storage); err != nil {
+		return nil, err
+	}
+	if sandbox.dposContext, err = block.dposContext.Clone(); err != nil {
+		return nil, err
+	}
+	sandbox.begin()
+	return sandbox, nil
+}
+
+// SimulateTransaction executes the transaction on the states of the block without committing,
+// and returns its receipt and events. The nonce and signature of the transaction aren't checked,
+// so it serves the calls of contracts and the estimate of gas.
+func (block *Block) SimulateTransaction(tx *Transaction) (*Simulation, error) {
+	if block.txsTrie == nil {
+		return nil, ErrBlockStatePruned
+	}
+	if proof, _ := block.txsTrie.Prove(tx.hash); proof != nil {
+		return nil, ErrDuplicatedTransaction
+	}
+	sandbox, err := block.sandbox()
+	if err != nil {
+		return nil, err
+	}
+	return sandbox.simulate(tx)
+}
+
+func (block *Block) simulate(tx *Transaction) (*Simulation, error) {
+	gasUsed, err := tx.VerifyExecution(block)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := block.newReceipt(tx, gasUsed)
+	if err != nil {
+		return nil, err
+	}
+	events, err := block.FetchEvents(tx.hash)
+	if err != nil {
+		return nil, err
+	}
+	return &Simulation{Receipt: receipt, Events: events}, nil
+}
+
+// simulateWithGasLimit simulates the transaction of the gas limit on the tail, the sender is given the
+// balance it needs, so only the execution decides the result.
+func simulateWithGasLimit(tail *Block, tx *Transaction, gasLimit *util.Uint128) (*Receipt, error) {
+	tx.gasLimit = gasLimit
+	sandbox, err := tail.sandbox()
+	if err != nil {
+		return nil, err
+	}
+	fromAcc := sandbox.accState.GetOrCreateUserAccount(tx.from.address)
+	fromAcc.AddBalance(tx.MinBalanceRequired())
+	fromAcc.AddBalance(tx.value)
+	simulation, err := sandbox.simulate(tx)
+	if err != nil {
+		return nil, err
+	}
+	return simulation.Receipt, nil
+}
+
+// EstimateGasLimit binary searches the least gas limit the transaction succeeds with, by simulating it on
+// the tail. The transaction fails at any limit if it fails at TransactionMaxGas, the reason is returned then.
+func (bc *BlockChain) EstimateGasLimit(tx *Transaction) (*GasEstimate, error) {
+	tail := bc.TailBlock()
+	gasLimit := tx.gasLimit
+	defer func() { tx.gasLimit = gasLimit }()
+
+	receipt, err := simulateWithGasLimit(tail, tx, TransactionMaxGas)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status() != ReceiptStatusSuccess {
+		return &GasEstimate{Gas: receipt.GasUsed(), Failure: receipt.Failure()}, nil
+	}
+
+	// the gas used is enough mostly, otherwise the least limit is above it.
+	used := receipt.GasUsed()
+	if receipt, err = simulateWithGasLimit(tail, tx, used); err != nil {
+		return nil, err
+	}
+	if receipt.Status() == ReceiptStatusSuccess {
+		return &GasEstimate{Gas: used}, nil
+	}
+	lo, hi := new(big.Int).Set(used.Int), new(big.Int).Set(TransactionMaxGas.Int)
+	one := big.NewInt(1)
+	for i := 0; i < MaxGasEstimateSteps && new(big.Int).Sub(hi, lo).Cmp(one) > 0; i++ {
+		mid := new(big.Int).Rsh(new(big.Int).Add(lo, hi), 1)
+		if receipt, err = simulateWithGasLimit(tail, tx, util.NewUint128FromBigInt(mid)); err != nil {
+			return nil, err
+		}
+		if receipt.Status() == ReceiptStatusSuccess {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return &GasEstimate{Gas: util.NewUint128FromBigInt(hi)}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlock_SimulateTransaction(t *testing.T) {
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	to := &Address{[]byte("01234567890123456789012345")}
+
+	bc, _ := NewBlockChain(testNeb())
+	tail := bc.tailBlock
+	tx := NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+	_, err := tail.SimulateTransaction(tx)
+	assert.Equal(t, ErrInsufficientBalance, err)
+
+	tail.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	tail.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	tail.header.stateRoot = tail.accState.RootHash()
+	tail.commit()
+
+	simulation, err := tail.SimulateTransaction(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, ReceiptStatusSuccess, simulation.Receipt.Status())
+	assert.Equal(t, tx.GasCountOfTxBase(), simulation.Receipt.GasUsed())
+	assert.Equal(t, 1, len(simulation.Events))
+	assert.Equal(t, TopicExecuteTxSuccess, simulation.Events[0].Topic)
+
+	// nothing is committed to the block.
+	assert.Equal(t, tail.header.stateRoot, tail.accState.RootHash())
+	assert.Equal(t, balance, tail.accState.GetOrCreateUserAccount(from.Bytes()).Balance())
+	assert.Equal(t, "0", tail.accState.GetOrCreateUserAccount(to.Bytes()).Balance().String())
+	events, err := tail.FetchEvents(tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	gas, err := bc.EstimateGas(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, simulation.Receipt.GasUsed(), gas)
+}
+
+func TestBlockChain_EstimateGasLimit(t *testing.T) {
+	from, _ := NewAddressFromPublicKey([]byte("estimate"))
+	to := &Address{[]byte("01234567890123456789012345")}
+
+	bc, _ := NewBlockChain(testNeb())
+	gasLimit := util.NewUint128FromInt(200000)
+	tx := NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, gasLimit)
+
+	// the sender needs no balance to estimate.
+	estimate, err := bc.EstimateGasLimit(tx)
+	assert.Nil(t, err)
+	assert.Nil(t, estimate.Failure)
+	assert.Equal(t, tx.GasCountOfTxBase(), estimate.Gas)
+	assert.Equal(t, gasLimit, tx.GasLimit())
+
+	payload, _ := NewCallPayload("missing", "").ToBytes()
+	tx = NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), 1, TxPayloadCallType, payload, TransactionGasPrice, gasLimit)
+	estimate, err = bc.EstimateGasLimit(tx)
+	assert.Nil(t, err)
+	assert.NotNil(t, estimate.Failure)
+	assert.Equal(t, gasLimit, tx.GasLimit())
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxStaleBlocksPerQuery is the max count of stale blocks returned by a query.
+	MaxStaleBlocksPerQuery = 100
+
+	// sideBlockPrefix is the prefix of the keys of the stale blocks by hash, in the side chain namespace.
+	sideBlockPrefix = "side_block_"
+
+	// sideHeightPrefix is the prefix of the keys of the stale blocks by height, the count of stale blocks
+	// at a height is stored at the prefix with the height, and the hashes at the positions appended.
+	sideHeightPrefix = "side_height_"
+)
+
+// Errors of stale blocks
+var (
+	ErrInvalidHeightRange = errors.New("invalid height range")
+)
+
+var (
+	staleReceivedMeter = metrics.GetOrRegisterMeter("neb.block.stale.received", nil)
+	staleMinedMeter    = metrics.GetOrRegisterMeter("neb.block.stale.mined", nil)
+)
+
+// StaleBlock is a block accepted by the node but left out of the canonical chain,
+// either never chosen by fork choice or reverted by a reorg.
+type StaleBlock struct {
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parent_hash"`
+	Height     uint64 `json:"height"`
+	Timestamp  int64  `json:"timestamp"`
+	Coinbase   string `json:"coinbase"`
+	// Mined is true if the block was mined by this node, or else received from others.
+	Mined bool `json:"mined"`
+	// StaleTime is the local time the block was found stale.
+	StaleTime int64 `json:"stale_time"`
+}
+
+func sideBlockKey(hash byteutils.Hash) []byte {
+	return append([]byte(sideBlockPrefix), hash...)
+}
+
+func sideHeightCountKey(height uint64) []byte {
+	return append([]byte(sideHeightPrefix), byteutils.FromUint64(height)...)
+}
+
+func sideHeightKey(height, index uint64) []byte {
+	return append(sideHeightCountKey(height), byteutils.FromUint64(index)...)
+}
+
+// markMinedBlock remembers the block is mined by this node.
+func (bc *BlockChain) markMinedBlock(hash byteutils.Hash) {
+	if bc.minedBlocks != nil {
+		bc.minedBlocks.Add(hash.Hex(), true)
+	}
+}
+
+// addStaleCandidate watches the block accepted, until the canonical chain reaches its height.
+func (bc *BlockChain) addStaleCandidate(block *Block) {
+	if bc.staleCandidates != nil {
+		bc.staleCandidates.Add(block.Hash().Hex(), block.ChainHeader())
+	}
+}
+
+// recordStaleBlocks persists the blocks reverted, and the blocks watched not in canonical chain
+// when the canonical chain reaches their heights.
+func (bc *BlockChain) recordStaleBlocks(reverted []*Block, newTail *Block) error {
+	for _, block := range reverted {
+		if err := bc.storeStaleBlock(block.ChainHeader()); err != nil {
+			return err
+		}
+	}
+	if bc.staleCandidates == nil {
+		return nil
+	}
+	for _, k := range bc.staleCandidates.Keys() {
+		v, ok := bc.staleCandidates.Peek(k)
+		if !ok {
+			continue
+		}
+		header := v.(*ChainHeader)
+		if header.Height() > newTail.Height() {
+			continue
+		}
+		bc.staleCandidates.Remove(k)
+		if header.Hash().Equals(bc.canonicalHash(header.Height())) {
+			continue
+		}
+		if err := bc.storeStaleBlock(header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bc *BlockChain) storeStaleBlock(header *ChainHeader) error {
+	if _, err := bc.storage.Get(sideBlockKey(header.Hash())); err == nil {
+		// a block reverted again after a reorg back to its fork.
+		return nil
+	}
+	mined := false
+	if bc.minedBlocks != nil {
+		mined = bc.minedBlocks.Contains(header.Hash().Hex())
+	}
+	stale := &StaleBlock{
+		Hash:       header.Hash().String(),
+		ParentHash: header.ParentHash().String(),
+		Height:     header.Height(),
+		Timestamp:  header.Timestamp(),
+		Coinbase:   header.Coinbase().String(),
+		Mined:      mined,
+		StaleTime:  time.Now().Unix(),
+	}
+	data, err := json.Marshal(stale)
+	if err != nil {
+		return err
+	}
+	if err := bc.storage.Put(sideBlockKey(header.Hash()), data); err != nil {
+		return err
+	}
+	count := bc.staleBlockCount(header.Height())
+	if err := bc.storage.Put(sideHeightKey(header.Height(), count), header.Hash()); err != nil {
+		return err
+	}
+	if err := bc.storage.Put(sideHeightCountKey(header.Height()), byteutils.FromUint64(count+1)); err != nil {
+		return err
+	}
+
+	if mined {
+		staleMinedMeter.Mark(1)
+	} else {
+		staleReceivedMeter.Mark(1)
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"block": header,
+		"mined": mined,
+	}).Info("Found a stale block.")
+	return nil
+}
+
+func (bc *BlockChain) staleBlockCount(height uint64) uint64 {
+	data, err := bc.storage.Get(sideHeightCountKey(height))
+	if err != nil {
+		return 0
+	}
+	return byteutils.Uint64(data)
+}
+
+// GetStaleBlocks returns the stale blocks from height from to height to inclusive, in ascending heights.
+// A stale block back in canonical chain after a reorg is skipped. At most MaxStaleBlocksPerQuery blocks are returned.
+func (bc *BlockChain) GetStaleBlocks(from, to uint64) ([]*StaleBlock, error) {
+	if from > to {
+		return nil, ErrInvalidHeightRange
+	}
+	var blocks []*StaleBlock
+	for height := from; height <= to && len(blocks) < MaxStaleBlocksPerQuery; height++ {
+		count := bc.staleBlockCount(height)
+		for i := uint64(0); i < count && len(blocks) < MaxStaleBlocksPerQuery; i++ {
+			hash, err := bc.storage.Get(sideHeightKey(height, i))
+			if err != nil {
+				return nil, err
+			}
+			if bc.canonicalHash(height).Equals(hash) {
+				continue
+			}
+			data, err := bc.storage.Get(sideBlockKey(hash))
+			if err == storage.ErrKeyNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			stale := new(StaleBlock)
+			if err := json.Unmarshal(data, stale); err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, stale)
+		}
+		if height == to {
+			// avoid the overflow at the max height.
+			break
+		}
+	}
+	return blocks, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockChain_GetStaleBlocks(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	var n MockNetManager
+	bc.bkPool.RegisterInNetwork(n)
+
+	mint := func(coinbase *Address, timestamp int64, mined bool) *Block {
+		block, _ := bc.NewBlock(coinbase)
+		block.header.timestamp = timestamp
+		block.CollectTransactions(0)
+		block.SetMiner(coinbase)
+		assert.Nil(t, block.Seal())
+		if mined {
+			assert.Nil(t, bc.BlockPool().PushAndBroadcast(BlockFromNetwork(block)))
+		} else {
+			assert.Nil(t, bc.BlockPool().Push(BlockFromNetwork(block)))
+		}
+		return block
+	}
+	hashes := func(blocks []*StaleBlock) []string {
+		var ret []string
+		for _, v := range blocks {
+			ret = append(ret, v.Hash)
+		}
+		return ret
+	}
+	/*
+		genesis -- 1 -- 11 -- 111
+		             \_ 12
+	*/
+	block1 := mint(&Address{[]byte("012345678901234567890001")}, BlockInterval, false)
+	assert.Nil(t, bc.SetTailBlock(block1))
+	block11 := mint(&Address{[]byte("012345678901234567890011")}, BlockInterval*2, true)
+	block12 := mint(&Address{[]byte("012345678901234567890012")}, BlockInterval*3, false)
+	assert.Nil(t, bc.SetTailBlock(block11))
+
+	// never chosen by fork choice.
+	stale, err := bc.GetStaleBlocks(0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{block12.Hash().String()}, hashes(stale))
+	assert.False(t, stale[0].Mined)
+	assert.Equal(t, block12.Height(), stale[0].Height)
+	assert.Equal(t, block12.ParentHash().String(), stale[0].ParentHash)
+
+	block111 := mint(&Address{[]byte("012345678901234567890111")}, BlockInterval*4, false)
+	assert.Nil(t, bc.SetTailBlock(block111))
+
+	// reverted by a reorg, and the one back in canonical chain is skipped.
+	assert.Nil(t, bc.SetTailBlock(block12))
+	stale, err = bc.GetStaleBlocks(0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{block11.Hash().String(), block111.Hash().String()}, hashes(stale))
+	assert.True(t, stale[0].Mined)
+	assert.False(t, stale[1].Mined)
+
+	stale, err = bc.GetStaleBlocks(block111.Height(), block111.Height())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{block111.Hash().String()}, hashes(stale))
+
+	assert.Nil(t, bc.SetTailBlock(block111))
+	stale, err = bc.GetStaleBlocks(0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{block12.Hash().String()}, hashes(stale))
+
+	_, err = bc.GetStaleBlocks(10, 0)
+	assert.Equal(t, ErrInvalidHeightRange, err)
+}
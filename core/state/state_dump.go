@@ -0,0 +1,243 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// State dump format: the header of magic, version and the length of root followed by the root,
+// then the entries, each is the kind, the length of data and the data. An account entry is the
+// protobuf encoded account in the state trie, followed by the variable entries of its storage, each
+// is the length of key, the key and the value. The accounts and variables are in ascending order of
+// keys, so a state has only one dump. The dump ends with an end entry of no data.
+const (
+	StateDumpMagic   = "NEBSTATE"
+	StateDumpVersion = uint32(1)
+
+	// MaxStateDumpEntrySize is the max size of an entry in dump.
+	MaxStateDumpEntrySize = 32 * 1024 * 1024
+
+	// stateDumpProgress is the count of accounts between the progress reports.
+	stateDumpProgress = 10000
+)
+
+// kinds of the entries in state dump
+const (
+	dumpEnd byte = iota
+	dumpAccount
+	dumpVariable
+)
+
+// Errors of state dump
+var (
+	ErrInvalidStateDump     = errors.New("invalid state dump")
+	ErrStateDumpUnsupported = errors.New("unsupported version of state dump")
+	ErrStateDumpMismatch    = errors.New("root of imported state mismatches the dump")
+)
+
+// DumpToWriter writes the accounts at the root of state with their storage into w, in the canonical
+// order of keys. The tries are scanned as it goes, never loaded at once.
+func (as *accountState) DumpToWriter(w io.Writer) error {
+	root := as.RootHash()
+	header := []byte(StateDumpMagic)
+	header = append(header, byteutils.FromUint32(StateDumpVersion)...)
+	header = append(header, byteutils.FromUint32(uint32(len(root)))...)
+	header = append(header, root...)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	count := 0
+	err := as.stateTrie.ScanPrefix(nil, func(key []byte, value []byte) error {
+		if err := writeDumpEntry(w, dumpAccount, value); err != nil {
+			return err
+		}
+		acc := new(account)
+		if err := acc.FromBytes(value, as.storage); err != nil {
+			return err
+		}
+		err := acc.variables.ScanPrefix(nil, func(key []byte, value []byte) error {
+			data := append(byteutils.FromUint32(uint32(len(key))), key...)
+			return writeDumpEntry(w, dumpVariable, append(data, value...))
+		})
+		if err != nil {
+			return err
+		}
+		if count++; count%stateDumpProgress == 0 {
+			logging.CLog().WithFields(logrus.Fields{
+				"root":     byteutils.Hex(root),
+				"exported": count,
+			}).Info("Exporting accounts.")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logging.CLog().WithFields(logrus.Fields{
+		"root":     byteutils.Hex(root),
+		"exported": count,
+	}).Info("Exported the state.")
+	return writeDumpEntry(w, dumpEnd, nil)
+}
+
+// ImportAccountState rebuilds the state dumped by DumpToWriter from r into storage, it verifies the
+// storage root of every account and the root of state against the dump.
+func ImportAccountState(r io.Reader, storage storage.Storage) (AccountState, error) {
+	header := make([]byte, len(StateDumpMagic)+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrInvalidStateDump
+	}
+	if !bytes.Equal(header[:len(StateDumpMagic)], []byte(StateDumpMagic)) {
+		return nil, ErrInvalidStateDump
+	}
+	header = header[len(StateDumpMagic):]
+	if byteutils.Uint32(header[:4]) != StateDumpVersion {
+		return nil, ErrStateDumpUnsupported
+	}
+	n := byteutils.Uint32(header[4:8])
+	if n > MaxStateDumpEntrySize {
+		return nil, ErrInvalidStateDump
+	}
+	root := make([]byte, n)
+	if _, err := io.ReadFull(r, root); err != nil {
+		return nil, ErrInvalidStateDump
+	}
+
+	stateTrie, err := trie.NewBatchTrie(nil, storage)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		pbAcc   *corepb.Account
+		accData []byte
+		vars    *trie.BatchTrie
+		lastAcc []byte
+		lastVar []byte
+		count   int
+	)
+	// putAccount puts the account read after its storage is rebuilt.
+	putAccount := func() error {
+		if pbAcc == nil {
+			return nil
+		}
+		if !bytes.Equal(vars.RootHash(), pbAcc.VarsHash) {
+			logging.VLog().WithFields(logrus.Fields{
+				"address": byteutils.Hex(pbAcc.Address),
+			}).Debug("Storage root of account mismatches the dump.")
+			return ErrStateDumpMismatch
+		}
+		_, err := stateTrie.Put(pbAcc.Address, accData)
+		return err
+	}
+	for {
+		kind, data, err := readDumpEntry(r)
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case dumpAccount:
+			if err := putAccount(); err != nil {
+				return nil, err
+			}
+			pbAcc, accData = new(corepb.Account), data
+			if err := proto.Unmarshal(data, pbAcc); err != nil {
+				return nil, ErrInvalidStateDump
+			}
+			if lastAcc != nil && bytes.Compare(pbAcc.Address, lastAcc) <= 0 {
+				return nil, ErrInvalidStateDump
+			}
+			if vars, err = trie.NewBatchTrie(nil, storage); err != nil {
+				return nil, err
+			}
+			lastAcc, lastVar = pbAcc.Address, nil
+			if count++; count%stateDumpProgress == 0 {
+				logging.CLog().WithFields(logrus.Fields{
+					"root":     byteutils.Hex(root),
+					"imported": count,
+				}).Info("Importing accounts.")
+			}
+		case dumpVariable:
+			if pbAcc == nil || len(data) < 4 {
+				return nil, ErrInvalidStateDump
+			}
+			n := uint64(byteutils.Uint32(data[:4]))
+			if n > uint64(len(data)-4) {
+				return nil, ErrInvalidStateDump
+			}
+			key, value := data[4:4+n], data[4+n:]
+			if lastVar != nil && bytes.Compare(key, lastVar) <= 0 {
+				return nil, ErrInvalidStateDump
+			}
+			if _, err := vars.Put(key, value); err != nil {
+				return nil, err
+			}
+			lastVar = key
+		case dumpEnd:
+			if err := putAccount(); err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(stateTrie.RootHash(), root) {
+				return nil, ErrStateDumpMismatch
+			}
+			logging.CLog().WithFields(logrus.Fields{
+				"root":     byteutils.Hex(root),
+				"imported": count,
+			}).Info("Imported the state.")
+			return NewAccountState(stateTrie.RootHash(), storage)
+		default:
+			return nil, ErrInvalidStateDump
+		}
+	}
+}
+
+func writeDumpEntry(w io.Writer, kind byte, data []byte) error {
+	if _, err := w.Write(append([]byte{kind}, byteutils.FromUint32(uint32(len(data)))...)); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readDumpEntry(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, ErrInvalidStateDump
+	}
+	n := byteutils.Uint32(header[1:])
+	if n > MaxStateDumpEntrySize {
+		return 0, nil, ErrInvalidStateDump
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, ErrInvalidStateDump
+	}
+	return header[0], data, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockDumpState(t *testing.T) AccountState {
+	stor, _ := storage.NewMemoryStorage()
+	as, _ := NewAccountState(nil, stor)
+	as.BeginBatch()
+	acc1 := as.GetOrCreateUserAccount([]byte("1ccAddr"))
+	acc1.AddBalance(util.NewUint128FromInt(16))
+	acc1.IncrNonce()
+	contract, err := as.CreateContractAccount([]byte("2ccAddr"), []byte("birth"))
+	assert.Nil(t, err)
+	assert.Nil(t, contract.Put([]byte("bar"), []byte("value1")))
+	assert.Nil(t, contract.Put([]byte("var"), []byte("value0")))
+	as.Commit()
+	return as
+}
+
+func TestAccountState_DumpToWriter(t *testing.T) {
+	as := mockDumpState(t)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, as.DumpToWriter(buf))
+	dump := buf.Bytes()
+
+	// the dump is canonical.
+	again := new(bytes.Buffer)
+	assert.Nil(t, as.DumpToWriter(again))
+	assert.Equal(t, dump, again.Bytes())
+
+	stor, _ := storage.NewMemoryStorage()
+	imported, err := ImportAccountState(bytes.NewReader(dump), stor)
+	assert.Nil(t, err)
+	assert.Equal(t, as.RootHash(), imported.RootHash())
+	acc, err := imported.GetContractAccount([]byte("2ccAddr"))
+	assert.Nil(t, err)
+	value, err := acc.Get([]byte("var"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value0"), value)
+	acc = imported.GetOrCreateUserAccount([]byte("1ccAddr"))
+	assert.Equal(t, util.NewUint128FromInt(16), acc.Balance())
+	assert.Equal(t, uint64(1), acc.Nonce())
+
+	// an empty state.
+	empty, _ := NewAccountState(nil, stor)
+	buf.Reset()
+	assert.Nil(t, empty.DumpToWriter(buf))
+	imported, err = ImportAccountState(buf, stor)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(imported.RootHash()))
+}
+
+func TestImportAccountState_Invalid(t *testing.T) {
+	as := mockDumpState(t)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, as.DumpToWriter(buf))
+	dump := buf.Bytes()
+
+	tests := []struct {
+		name string
+		dump []byte
+		err  error
+	}{
+		{"magic", append([]byte("NEBCHAIN"), dump[8:]...), ErrInvalidStateDump},
+		{"version", append(append([]byte{}, dump[:8]...), append([]byte{0, 0, 0, 2}, dump[12:]...)...), ErrStateDumpUnsupported},
+		{"truncated", dump[:len(dump)-1], ErrInvalidStateDump},
+		{"tampered value", bytes.Replace(dump, []byte("value1"), []byte("value2"), 1), ErrStateDumpMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stor, _ := storage.NewMemoryStorage()
+			_, err := ImportAccountState(bytes.NewReader(tt.dump), stor)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -70,3 +70,36 @@ func TestAccountState(t *testing.T) {
 	as.RollBack()
 	assert.Equal(t, as.RootHash(), asClone.RootHash())
 }
+
+func TestAccountState_ContractStorage(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	as, _ := NewAccountState(nil, stor)
+	as.BeginBatch()
+	contract, _ := as.CreateContractAccount([]byte("contract"), []byte("birth"))
+	as.Commit()
+	root := as.RootHash()
+
+	// the storage of contract is rooted in the account, so in the root of state.
+	as.BeginBatch()
+	contract, _ = as.GetContractAccount([]byte("contract"))
+	assert.Nil(t, contract.Put([]byte("key"), []byte("value")))
+	as.Commit()
+	assert.NotNil(t, contract.VarsHash())
+	assert.NotEqual(t, root, as.RootHash())
+
+	loaded, _ := NewAccountState(as.RootHash(), stor)
+	acc, err := loaded.GetContractAccount([]byte("contract"))
+	assert.Nil(t, err)
+	value, err := acc.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, contract.VarsHash(), acc.VarsHash())
+
+	as.BeginBatch()
+	contract, _ = as.GetContractAccount([]byte("contract"))
+	assert.Nil(t, contract.Del([]byte("key")))
+	as.Commit()
+	_, err = contract.Get([]byte("key"))
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	assert.Equal(t, root, as.RootHash())
+}
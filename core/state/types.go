@@ -19,6 +19,8 @@
 package state
 
 import (
+	"io"
+
 	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/byteutils"
@@ -64,6 +66,7 @@ type AccountState interface {
 	RollBack()
 
 	Clone() (AccountState, error)
+	DumpToWriter(w io.Writer) error
 
 	GetOrCreateUserAccount(addr []byte) Account
 	GetContractAccount(addr []byte) (Account, error)
@@ -31,6 +31,7 @@ import (
 	"github.com/nebulasio/go-nebulas/crypto"
 	"github.com/nebulasio/go-nebulas/crypto/hash"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/nf/nvm"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/nebulasio/go-nebulas/util/logging"
@@ -60,6 +61,14 @@ var (
 	DelegateBaseGasCount = util.NewUint128FromInt(20000)
 	// CandidateBaseGasCount is base gas count of candidate transaction
 	CandidateBaseGasCount = util.NewUint128FromInt(20000)
+	// MultisigBaseGasCount is base gas count of multisig transaction
+	MultisigBaseGasCount = util.NewUint128FromInt(20000)
+	// BatchTransferBaseGasCount is base gas count of batch transfer transaction
+	BatchTransferBaseGasCount = util.NewUint128FromInt(20000)
+	// BatchTransferOutputGasCount is gas count of each output of batch transfer transaction
+	BatchTransferOutputGasCount = util.NewUint128FromInt(5000)
+	// SlashBaseGasCount is base gas count of slash transaction
+	SlashBaseGasCount = util.NewUint128FromInt(20000)
 	// ZeroGasCount is zero gas count
 	ZeroGasCount = util.NewUint128()
 
@@ -67,6 +76,10 @@ var (
 	executeTxErrCounter = metrics.GetOrRegisterCounter("tx_execute_err", nil)
 )
 
+// ValidUntilHeightThreshold is the bound of the valid until of a tx, below which it's a block height,
+// otherwise a unix timestamp.
+const ValidUntilHeightThreshold = 500000000
+
 // Transaction type is used to handle all transaction data.
 type Transaction struct {
 	hash      byteutils.Hash
@@ -80,6 +93,9 @@ type Transaction struct {
 	gasPrice  *util.Uint128
 	gasLimit  *util.Uint128
 
+	// the last height or timestamp the tx can be packed at, 0 if it never expires.
+	validUntil uint64
+
 	// Signature
 	alg  uint8          // algorithm
 	sign byteutils.Hash // Signature values
@@ -140,18 +156,19 @@ func (tx *Transaction) ToProto() (proto.Message, error) {
 		return nil, err
 	}
 	return &corepb.Transaction{
-		Hash:      tx.hash,
-		From:      tx.from.address,
-		To:        tx.to.address,
-		Value:     value,
-		Nonce:     tx.nonce,
-		Timestamp: tx.timestamp,
-		Data:      tx.data,
-		ChainId:   tx.chainID,
-		GasPrice:  gasPrice,
-		GasLimit:  gasLimit,
-		Alg:       uint32(tx.alg),
-		Sign:      tx.sign,
+		Hash:       tx.hash,
+		From:       tx.from.address,
+		To:         tx.to.address,
+		Value:      value,
+		Nonce:      tx.nonce,
+		Timestamp:  tx.timestamp,
+		Data:       tx.data,
+		ChainId:    tx.chainID,
+		GasPrice:   gasPrice,
+		GasLimit:   gasLimit,
+		Alg:        uint32(tx.alg),
+		Sign:       tx.sign,
+		ValidUntil: tx.validUntil,
 	}, nil
 }
 
@@ -182,6 +199,7 @@ func (tx *Transaction) FromProto(msg proto.Message) error {
 		tx.gasLimit = gasLimit
 		tx.alg = uint8(msg.Alg)
 		tx.sign = msg.Sign
+		tx.validUntil = msg.ValidUntil
 		return nil
 	}
 	return errors.New("Protobug Message cannot be converted into Transaction")
@@ -231,6 +249,28 @@ func (tx *Transaction) Hash() byteutils.Hash {
 	return tx.hash
 }
 
+// ValidUntil returns the last height or timestamp the tx can be packed at, 0 if it never expires.
+func (tx *Transaction) ValidUntil() uint64 {
+	return tx.validUntil
+}
+
+// SetValidUntil sets the last height or timestamp the tx can be packed at, before hashed and signed.
+// The values below ValidUntilHeightThreshold are heights, others are unix timestamps in seconds.
+func (tx *Transaction) SetValidUntil(validUntil uint64) {
+	tx.validUntil = validUntil
+}
+
+// Expired returns whether the tx can't be packed in the block of height and timestamp.
+func (tx *Transaction) Expired(height uint64, timestamp int64) bool {
+	if tx.validUntil == 0 {
+		return false
+	}
+	if tx.validUntil < ValidUntilHeightThreshold {
+		return height > tx.validUntil
+	}
+	return timestamp < 0 || uint64(timestamp) > tx.validUntil
+}
+
 // GasPrice returns gasPrice
 func (tx *Transaction) GasPrice() *util.Uint128 {
 	return tx.gasPrice
@@ -290,6 +330,16 @@ func (tx *Transaction) LoadPayload() (TxPayload, error) {
 		payload, err = LoadCandidatePayload(tx.data.Payload)
 	case TxPayloadDelegateType:
 		payload, err = LoadDelegatePayload(tx.data.Payload)
+	case TxPayloadMultisigType:
+		payload, err = LoadMultisigPayload(tx.data.Payload)
+	case TxPayloadBatchTransferType:
+		payload, err = LoadBatchTransferPayload(tx.data.Payload)
+	case TxPayloadSlashType:
+		payload, err = LoadSlashPayload(tx.data.Payload)
+	case TxPayloadUpgradeType:
+		payload, err = LoadUpgradePayload(tx.data.Payload)
+	case TxPayloadOracleType:
+		payload, err = LoadOraclePayload(tx.data.Payload)
 	default:
 		err = ErrInvalidTxPayloadType
 	}
@@ -324,7 +374,7 @@ func (tx *Transaction) VerifyExecution(block *Block) (*util.Uint128, error) {
 		executeTxErrCounter.Inc(1)
 
 		tx.gasConsumption(fromAcc, coinbaseAcc, gasUsed)
-		tx.triggerEvent(TopicExecuteTxFailed, block, err)
+		tx.triggerEvent(TopicExecuteTxFailed, block, NewExecutionError(ExecutionErrInvalidPayload, err))
 		return gasUsed, nil
 	}
 
@@ -345,7 +395,7 @@ func (tx *Transaction) VerifyExecution(block *Block) (*util.Uint128, error) {
 		executeTxErrCounter.Inc(1)
 
 		tx.gasConsumption(fromAcc, coinbaseAcc, tx.gasLimit)
-		tx.triggerEvent(TopicExecuteTxFailed, block, err)
+		tx.triggerEvent(TopicExecuteTxFailed, block, ErrOutOfGasLimit)
 		return tx.gasLimit, nil
 	}
 
@@ -353,12 +403,15 @@ func (tx *Transaction) VerifyExecution(block *Block) (*util.Uint128, error) {
 	gasExecution, err := payload.Execute(ctx)
 	if err != nil {
 		ctx.RollBack()
-	} else {
-		ctx.Commit()
+	} else if err := ctx.Commit(); err != nil {
+		return util.NewUint128(), err
 	}
 
-	// gas = tx.GasCountOfTxBase() +  gasExecution
+	// gas = tx.GasCountOfTxBase() +  gasExecution, an execution out of gas costs the whole limit.
 	gas := util.NewUint128FromBigInt(util.NewUint128().Add(gasUsed.Int, gasExecution.Int))
+	if gas.Cmp(tx.gasLimit.Int) > 0 || err == nvm.ErrInsufficientGas {
+		gas = tx.gasLimit
+	}
 
 	logging.VLog().WithFields(logrus.Fields{
 		"tx":           tx,
@@ -406,10 +459,17 @@ func (tx *Transaction) VerifyExecution(block *Block) (*util.Uint128, error) {
 	return gas, nil
 }
 
+// Fee returns the fee of the gas used by the transaction, in its gas price.
+func (tx *Transaction) Fee(gasUsed *util.Uint128) *util.Uint128 {
+	return util.NewUint128FromBigInt(util.NewUint128().Mul(tx.GasPrice().Int, gasUsed.Int))
+}
+
+// gasConsumption charges the fee of the gas used from the sender and credits it to the coinbase,
+// whether the transaction succeeded or not.
 func (tx *Transaction) gasConsumption(from, coinbase state.Account, gas *util.Uint128) {
-	gasCost := util.NewUint128().Mul(tx.GasPrice().Int, gas.Int)
-	from.SubBalance(util.NewUint128FromBigInt(gasCost))
-	coinbase.AddBalance(util.NewUint128FromBigInt(gasCost))
+	fee := tx.Fee(gas)
+	from.SubBalance(fee)
+	coinbase.AddBalance(fee)
 }
 
 func (tx *Transaction) triggerEvent(topic string, block *Block, err error) {
@@ -418,12 +478,14 @@ func (tx *Transaction) triggerEvent(topic string, block *Block, err error) {
 	if err != nil {
 		var (
 			txErrEvent struct {
-				Transaction proto.Message `json:"transaction"`
-				Error       error         `json:"error"`
+				Transaction proto.Message   `json:"transaction"`
+				Error       string          `json:"error"`
+				Reason      *ExecutionError `json:"reason"`
 			}
 		)
 		txErrEvent.Transaction = pbTx
-		txErrEvent.Error = err
+		txErrEvent.Error = err.Error()
+		txErrEvent.Reason = toExecutionError(err)
 		txData, _ = json.Marshal(txErrEvent)
 	} else {
 		txData, _ = json.Marshal(pbTx)
@@ -475,6 +537,9 @@ func (tx *Transaction) VerifyIntegrity(chainID uint32) error {
 }
 
 func (tx *Transaction) verifySign() error {
+	if tx.signVerifiedBefore() {
+		return nil
+	}
 	signature, err := crypto.NewSignature(keystore.Algorithm(tx.alg))
 	if err != nil {
 		return err
@@ -498,6 +563,7 @@ func (tx *Transaction) verifySign() error {
 		}).Error("Failed to verify tx's sign.")
 		return ErrInvalidTransactionSigner
 	}
+	tx.markSignVerified()
 	return nil
 }
 
@@ -524,7 +590,7 @@ func HashTransaction(tx *Transaction) (byteutils.Hash, error) {
 	if err != nil {
 		return nil, err
 	}
-	return hash.Sha3256(
+	args := [][]byte{
 		tx.from.address,
 		tx.to.address,
 		value,
@@ -534,5 +600,10 @@ func HashTransaction(tx *Transaction) (byteutils.Hash, error) {
 		byteutils.FromUint32(tx.chainID),
 		gasPrice,
 		gasLimit,
-	), nil
+	}
+	// the hashes of the txs never expiring are kept as before.
+	if tx.validUntil > 0 {
+		args = append(args, byteutils.FromUint64(tx.validUntil))
+	}
+	return hash.Sha3256(args...), nil
 }
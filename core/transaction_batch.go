@@ -0,0 +1,126 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// TransactionBatch is the transactions broadcast in a message of MessageTypeNewTxs,
+// in a corepb.Txs without block hash.
+type TransactionBatch []*Transaction
+
+// ToProto converts domain TransactionBatch into proto Txs
+func (batch TransactionBatch) ToProto() (proto.Message, error) {
+	msg := new(corepb.Txs)
+	for _, tx := range batch {
+		pbTx, err := tx.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		msg.Transactions = append(msg.Transactions, pbTx.(*corepb.Transaction))
+	}
+	return msg, nil
+}
+
+// FromProto converts proto Txs into domain TransactionBatch
+func (batch *TransactionBatch) FromProto(msg proto.Message) error {
+	txs, ok := msg.(*corepb.Txs)
+	if !ok {
+		return errors.New("Protobuf Message cannot be converted into TransactionBatch")
+	}
+	*batch = nil
+	for _, pbTx := range txs.Transactions {
+		tx := new(Transaction)
+		if err := tx.FromProto(pbTx); err != nil {
+			return err
+		}
+		*batch = append(*batch, tx)
+	}
+	return nil
+}
+
+// PushBatch verifies the transactions in parallel and pushes them into pool under a single lock,
+// then broadcasts the ones admitted in aggregated messages.
+// It returns the error of each transaction in order, nil for the admitted ones.
+func (pool *TransactionPool) PushBatch(txs []*Transaction) []error {
+	errs := VerifyTransactions(pool.bc.chainID, txs)
+
+	var admitted TransactionBatch
+	pool.mu.Lock()
+	for i, tx := range txs {
+		if errs[i] != nil {
+			invalidTxCounter.Inc(1)
+			continue
+		}
+		if errs[i] = pool.admit(tx); errs[i] != nil {
+			continue
+		}
+		if errs[i] = pool.insert(tx); errs[i] != nil {
+			continue
+		}
+		admitted = append(admitted, tx)
+	}
+	pool.mu.Unlock()
+
+	if pool.nm != nil {
+		for _, batch := range splitBatch(admitted, MaxBlockMessageSize) {
+			pool.nm.Broadcast(MessageTypeNewTxs, batch)
+		}
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"count":    len(txs),
+		"admitted": len(admitted),
+	}).Debug("Pushed a batch of txs into tx pool.")
+	return errs
+}
+
+// splitBatch splits the transactions into batches whose messages are not larger than size.
+func splitBatch(txs TransactionBatch, size int) []*TransactionBatch {
+	var (
+		batches []*TransactionBatch
+		batch   TransactionBatch
+		total   int
+	)
+	for _, tx := range txs {
+		pbTx, err := tx.ToProto()
+		if err != nil {
+			continue
+		}
+		// the length prefix and tag of the repeated field are counted roughly.
+		n := proto.Size(pbTx) + 8
+		if len(batch) > 0 && total+n > size {
+			full := batch
+			batches = append(batches, &full)
+			batch, total = nil, 0
+		}
+		batch = append(batch, tx)
+		total += n
+	}
+	if len(batch) > 0 {
+		batches = append(batches, &batch)
+	}
+	return batches
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionPool_PushBatch(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+
+	txs := mockSignedTransactions(bc.ChainID(), 4)
+	fundAccounts(bc, txs[0].from)
+	txs = append(txs, txs[1], mockSignedTransactions(bc.ChainID(), 1)[0])
+	txs[2].nonce++
+
+	errs := txPool.PushBatch(txs)
+	assert.Equal(t, len(txs), len(errs))
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.Equal(t, ErrInvalidTransactionHash, errs[2])
+	assert.Nil(t, errs[3])
+	assert.Equal(t, ErrDuplicatedTransaction, errs[4])
+	// the sender of the last is not funded.
+	assert.Equal(t, ErrInsufficientBalance, errs[5])
+	assert.Equal(t, 3, len(txPool.all))
+	assert.Equal(t, 2, txPool.PendingCount())
+	assert.Equal(t, 1, txPool.QueuedCount())
+}
+
+func TestTransactionBatch_Message(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var n MockNetManager
+	txPool := bc.txPool
+	txPool.RegisterInNetwork(n)
+	txs := mockSignedTransactions(bc.ChainID(), 3)
+	fundAccounts(bc, txs[0].from)
+
+	// the batches are split by the size of message.
+	pbTx, _ := txs[0].ToProto()
+	size := proto.Size(pbTx) + 8
+	batches := splitBatch(txs, 2*size)
+	assert.Equal(t, 2, len(batches))
+	assert.Equal(t, 2, len(*batches[0]))
+	assert.Equal(t, 1, len(*batches[1]))
+	assert.Equal(t, 1, len(splitBatch(txs, 3*size)))
+
+	pbMsg, err := TransactionBatch(txs).ToProto()
+	assert.Nil(t, err)
+	data, err := proto.Marshal(pbMsg)
+	assert.Nil(t, err)
+	msg := messages.NewBaseMessage(MessageTypeNewTxs, "peer", data)
+	assert.Nil(t, ValidateTxsMessage(bc.ChainID())(msg))
+
+	decoded := txPool.decodeTxMessage(msg)
+	assert.Equal(t, len(txs), len(decoded))
+	for i, tx := range decoded {
+		assert.Equal(t, txs[i].Hash(), tx.Hash())
+	}
+	txPool.handleTxMessages([]net.Message{msg})
+	assert.Equal(t, len(txs), len(txPool.all))
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxBatchTransferOutputs is the max count of the outputs of a batch transfer.
+const MaxBatchTransferOutputs = 1024
+
+// BatchTransferOutput is a recipient and the amount it receives in a batch transfer.
+type BatchTransferOutput struct {
+	To    string
+	Value string
+}
+
+// BatchTransferPayload carry the outputs transferred from the sender in a tx.
+// The outputs are executed atomically, all of them or none, each recorded in an event.
+type BatchTransferPayload struct {
+	Outputs []*BatchTransferOutput
+}
+
+// LoadBatchTransferPayload from bytes
+func LoadBatchTransferPayload(bytes []byte) (*BatchTransferPayload, error) {
+	payload := &BatchTransferPayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// NewBatchTransferPayload with outputs
+func NewBatchTransferPayload(outputs []*BatchTransferOutput) *BatchTransferPayload {
+	return &BatchTransferPayload{
+		Outputs: outputs,
+	}
+}
+
+// ToBytes serialize payload
+func (payload *BatchTransferPayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count, grown with the count of outputs.
+func (payload *BatchTransferPayload) BaseGasCount() *util.Uint128 {
+	gas := new(big.Int).Mul(BatchTransferOutputGasCount.Int, big.NewInt(int64(len(payload.Outputs))))
+	return util.NewUint128FromBigInt(gas.Add(gas, BatchTransferBaseGasCount.Int))
+}
+
+// Execute the batch transfer payload in tx
+func (payload *BatchTransferPayload) Execute(ctx *PayloadContext) (*util.Uint128, error) {
+	if len(payload.Outputs) == 0 || len(payload.Outputs) > MaxBatchTransferOutputs {
+		return ZeroGasCount, ErrInvalidBatchTransferOutputs
+	}
+
+	// all the outputs are checked before any transferred.
+	addrs := make([]*Address, len(payload.Outputs))
+	values := make([]*util.Uint128, len(payload.Outputs))
+	total := util.NewUint128()
+	for i, output := range payload.Outputs {
+		addr, err := AddressParse(output.To)
+		if err != nil {
+			return ZeroGasCount, err
+		}
+		value, ok := util.NewUint128().FromString(output.Value)
+		if !ok || value.Validate() != nil {
+			return ZeroGasCount, ErrInvalidBatchTransferValue
+		}
+		addrs[i], values[i] = addr, value
+		total.Add(total.Int, value.Int)
+	}
+	if total.Validate() != nil {
+		return ZeroGasCount, ErrInvalidBatchTransferValue
+	}
+
+	// the balance left covers the value and fees of the tx.
+	from := ctx.accState.GetOrCreateUserAccount(ctx.tx.from.address)
+	required := new(big.Int).Add(total.Int, ctx.tx.value.Int)
+	required.Add(required, ctx.tx.MinBalanceRequired().Int)
+	if from.Balance().Cmp(required) < 0 {
+		return ZeroGasCount, ErrInsufficientBalance
+	}
+
+	events := make([]*Event, len(payload.Outputs))
+	for i := range payload.Outputs {
+		data, err := json.Marshal(map[string]interface{}{
+			"index": i,
+			"from":  ctx.tx.from.String(),
+			"to":    addrs[i].String(),
+			"value": values[i].String(),
+		})
+		if err != nil {
+			return ZeroGasCount, err
+		}
+		events[i] = &Event{Topic: TopicBatchTransferOutput, Data: string(data)}
+	}
+
+	if err := from.SubBalance(total); err != nil {
+		return ZeroGasCount, ErrInsufficientBalance
+	}
+	for i, addr := range addrs {
+		ctx.accState.GetOrCreateUserAccount(addr.Bytes()).AddBalance(values[i])
+	}
+	for _, event := range events {
+		if err := ctx.block.recordEvent(ctx.tx.hash, event); err != nil {
+			return ZeroGasCount, err
+		}
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tx":      ctx.tx,
+		"outputs": len(payload.Outputs),
+		"total":   total.String(),
+	}).Debug("Executed a batch transfer.")
+	return ZeroGasCount, nil
+}
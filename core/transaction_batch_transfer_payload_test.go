@@ -0,0 +1,92 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBatchTransferPayload(t *testing.T) {
+	payload := NewBatchTransferPayload([]*BatchTransferOutput{
+		{To: "1a263547d167c74cf4b8f9166cfa244de0481c514a45aa2c", Value: "10"},
+		{To: "2fe3f9f51f9a05dd5f7c5329127f7c917917149b4e16b0b8", Value: "20"},
+	})
+	bytes, err := payload.ToBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Outputs":[{"To":"1a263547d167c74cf4b8f9166cfa244de0481c514a45aa2c","Value":"10"},{"To":"2fe3f9f51f9a05dd5f7c5329127f7c917917149b4e16b0b8","Value":"20"}]}`, string(bytes))
+	got, err := LoadBatchTransferPayload(bytes)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, got)
+	assert.Equal(t, "30000", got.BaseGasCount().String())
+
+	_, err = LoadBatchTransferPayload([]byte("nas"))
+	assert.NotNil(t, err)
+}
+
+func TestBatchTransferPayload_Execute(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.begin()
+
+	from, a, b := mockAddress(), mockAddress(), mockAddress()
+	block.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(util.NewUint128FromInt(1000000000000))
+	nonce := uint64(0)
+	execute := func(gasLimit int64, outputs ...*BatchTransferOutput) []string {
+		data, err := NewBatchTransferPayload(outputs).ToBytes()
+		assert.Nil(t, err)
+		nonce++
+		tx := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), nonce, TxPayloadBatchTransferType, data, TransactionGasPrice, util.NewUint128FromInt(gasLimit))
+		tx.hash, _ = HashTransaction(tx)
+		_, err = block.executeTransaction(tx)
+		assert.Nil(t, err)
+		events, err := block.FetchEvents(tx.hash)
+		assert.Nil(t, err)
+		var topics []string
+		for _, e := range events {
+			topics = append(topics, e.Topic)
+		}
+		return topics
+	}
+	balance := func(addr *Address) string {
+		return block.accState.GetOrCreateUserAccount(addr.Bytes()).Balance().String()
+	}
+
+	topics := execute(200000, &BatchTransferOutput{To: a.String(), Value: "10"}, &BatchTransferOutput{To: b.String(), Value: "20"}, &BatchTransferOutput{To: a.String(), Value: "5"})
+	assert.Equal(t, []string{TopicBatchTransferOutput, TopicBatchTransferOutput, TopicBatchTransferOutput, TopicExecuteTxSuccess}, topics)
+	assert.Equal(t, "15", balance(a))
+	assert.Equal(t, "20", balance(b))
+
+	// the outputs are transferred all or none.
+	failures := [][]*BatchTransferOutput{
+		nil,
+		{{To: a.String(), Value: "10"}, {To: "nas", Value: "10"}},
+		{{To: a.String(), Value: "10"}, {To: b.String(), Value: "-1"}},
+		{{To: a.String(), Value: "10"}, {To: b.String(), Value: "900000000000"}},
+	}
+	for _, outputs := range failures {
+		assert.Equal(t, []string{TopicExecuteTxFailed}, execute(200000, outputs...))
+	}
+	assert.Equal(t, []string{TopicExecuteTxFailed}, execute(40000, &BatchTransferOutput{To: a.String(), Value: "10"}))
+	assert.Equal(t, "15", balance(a))
+	assert.Equal(t, "20", balance(b))
+}
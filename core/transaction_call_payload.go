@@ -21,6 +21,7 @@ package core
 import (
 	"encoding/json"
 
+	"github.com/nebulasio/go-nebulas/core/state"
 	"github.com/nebulasio/go-nebulas/nf/nvm"
 	"github.com/nebulasio/go-nebulas/util"
 )
@@ -72,25 +73,49 @@ func (payload *CallPayload) Execute(context *PayloadContext) (*util.Uint128, err
 	engine.SetExecutionLimits(context.tx.PayloadGasLimit(payload).Uint64(), nvm.DefaultLimitsOfTotalMemorySize)
 
 	err = engine.Call(deployPayload.Source, deployPayload.SourceType, payload.Function, payload.Args)
-	return util.NewUint128FromInt(int64(engine.ExecutionInstructions())), err
+	return util.NewUint128FromInt(int64(engine.ExecutionInstructions())), contractExecutionError(engine, err)
 }
 
 func generateCallContext(ctx *PayloadContext) (*nvm.Context, *DeployPayload, error) {
-
-	contract, err := ctx.accState.GetContractAccount(ctx.tx.to.Bytes())
+	contract, birthTx, deploy, err := loadContract(ctx.block, ctx.accState, ctx.tx.to.Bytes())
 	if err != nil {
 		return nil, nil, err
 	}
-	birthTx, err := ctx.block.GetTransaction(contract.BirthPlace())
+	owner := ctx.accState.GetOrCreateUserAccount(birthTx.from.Bytes())
+
+	nvmctx := nvm.NewContext(ctx.contractBlock(), convertNvmTx(ctx.tx), owner, contract, ctx.accState)
+	return nvmctx, deploy, nil
+}
+
+// loadContract returns the contract account at addr, with the transaction deploying it and the current
+// code, which is of the latest upgrade if any.
+func loadContract(block *Block, accState state.AccountState, addr []byte) (state.Account, *Transaction, *DeployPayload, error) {
+	contract, err := accState.GetContractAccount(addr)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	owner := ctx.accState.GetOrCreateUserAccount(birthTx.from.Bytes())
-	deploy, err := LoadDeployPayload(birthTx.data.Payload)
+	birthTx, err := block.GetTransaction(contract.BirthPlace())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-
-	nvmctx := nvm.NewContext(ctx.block, convertNvmTx(ctx.tx), owner, contract, ctx.accState)
-	return nvmctx, deploy, nil
+	code, err := contractCodeOf(contract)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if code.Version == 0 {
+		deploy, err := LoadDeployPayload(birthTx.data.Payload)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return contract, birthTx, deploy, nil
+	}
+	upgradeTx, err := block.GetTransaction(code.TxHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	upgrade, err := LoadUpgradePayload(upgradeTx.data.Payload)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return contract, birthTx, &DeployPayload{Source: upgrade.Source, SourceType: upgrade.SourceType}, nil
 }
@@ -30,6 +30,12 @@ type DeployPayload struct {
 	SourceType string
 	Source     string
 	Args       string
+
+	// Upgradable allows the owner to replace the code later, keeping the storage.
+	Upgradable bool `json:",omitempty"`
+
+	// Metadata is the JSON object of the ABI and metadata of the contract, registered for the wallets.
+	Metadata string `json:",omitempty"`
 }
 
 // LoadDeployPayload from bytes
@@ -38,6 +44,9 @@ func LoadDeployPayload(bytes []byte) (*DeployPayload, error) {
 	if err := json.Unmarshal(bytes, payload); err != nil {
 		return nil, err
 	}
+	if err := checkContractMetadata(payload.Metadata); err != nil {
+		return nil, err
+	}
 	return payload, nil
 }
 
@@ -74,7 +83,15 @@ func (payload *DeployPayload) Execute(ctx *PayloadContext) (*util.Uint128, error
 
 	// Deploy and Init.
 	err = engine.DeployAndInit(payload.Source, payload.SourceType, payload.Args)
-	return util.NewUint128FromInt(int64(engine.ExecutionInstructions())), err
+	gas := util.NewUint128FromInt(int64(engine.ExecutionInstructions()))
+	if err != nil || len(payload.Metadata) == 0 {
+		return gas, contractExecutionError(engine, err)
+	}
+	addr, err := ctx.tx.GenerateContractAddress()
+	if err != nil {
+		return gas, err
+	}
+	return gas, setContractMetadata(ctx.accState, addr, payload.Metadata)
 }
 
 func generateDeployContext(ctx *PayloadContext) (*nvm.Context, error) {
@@ -87,7 +104,7 @@ func generateDeployContext(ctx *PayloadContext) (*nvm.Context, error) {
 	if err != nil {
 		return nil, err
 	}
-	nvmctx := nvm.NewContext(ctx.block, convertNvmTx(ctx.tx), owner, contract, ctx.accState)
+	nvmctx := nvm.NewContext(ctx.contractBlock(), convertNvmTx(ctx.tx), owner, contract, ctx.accState)
 	return nvmctx, nil
 }
 
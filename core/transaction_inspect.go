@@ -0,0 +1,97 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sort"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+var droppedTxCounter = metrics.GetOrRegisterCounter("txpool_dropped", nil)
+
+// SenderSummary summarizes the txs of a sender in pool.
+type SenderSummary struct {
+	Address  *Address
+	Count    int
+	Queued   int
+	MinNonce uint64
+	MaxNonce uint64
+
+	// the sum of the values of the txs.
+	TotalValue *util.Uint128
+
+	// the sum of the max fees of the txs, gasPrice * gasLimit each.
+	TotalFees *util.Uint128
+}
+
+// Inspect returns the summaries of the senders in pool, in order of address.
+func (pool *TransactionPool) Inspect() []*SenderSummary {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	summaries := make([]*SenderSummary, 0, len(pool.nonces))
+	for sender, txs := range pool.nonces {
+		summary := &SenderSummary{
+			Count:      len(txs),
+			Queued:     len(pool.queued[sender]),
+			TotalValue: util.NewUint128(),
+			TotalFees:  util.NewUint128(),
+		}
+		for _, tx := range txs {
+			if summary.Address == nil || tx.nonce < summary.MinNonce {
+				summary.MinNonce = tx.nonce
+			}
+			if summary.Address == nil || tx.nonce > summary.MaxNonce {
+				summary.MaxNonce = tx.nonce
+			}
+			summary.Address = tx.from
+			summary.TotalValue.Add(summary.TotalValue.Int, tx.value.Int)
+			summary.TotalFees.Add(summary.TotalFees.Int, tx.MinBalanceRequired().Int)
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Address.String() < summaries[j].Address.String()
+	})
+	return summaries
+}
+
+// Drop evicts the tx of the hash from pool, for operators to remove a stuck tx manually.
+// The following txs of the sender are kept, they wait for a new tx of the nonce dropped.
+func (pool *TransactionPool) Drop(hash byteutils.Hash) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	tx := pool.all[hash.Hex()]
+	if tx == nil {
+		return ErrTransactionNotInPool
+	}
+	pool.detach(tx)
+	droppedTxCounter.Inc(1)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tx": tx,
+	}).Info("Dropped a tx from tx pool.")
+	return nil
+}
@@ -0,0 +1,355 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Multisig Actions
+const (
+	MultisigCreateAction  = "create"
+	MultisigProposeAction = "propose"
+	MultisigSignAction    = "sign"
+)
+
+// MaxMultisigOwners is the max count of the owners of a multisig account.
+const MaxMultisigOwners = 16
+
+// the keys in the storage of a multisig account.
+var multisigConfigKey = []byte("multisig")
+
+func multisigProposalKey(id byteutils.Hash) []byte {
+	return append([]byte("proposal_"), id...)
+}
+
+// MultisigPayload carry the operations of M-of-N multisig accounts:
+//   - "create" creates a multisig account of Owners, Threshold of them sign a transfer,
+//     the address is generated by the sender and nonce as a contract;
+//   - "propose" proposes a transfer of Value from the multisig Account to To, signed by the proposer;
+//   - "sign" co-signs the Proposal, the hash of the proposing tx. The transfer is executed
+//     once signed by Threshold owners.
+type MultisigPayload struct {
+	Action    string
+	Owners    []string `json:",omitempty"`
+	Threshold uint32   `json:",omitempty"`
+	Account   string   `json:",omitempty"`
+	To        string   `json:",omitempty"`
+	Value     string   `json:",omitempty"`
+	Proposal  string   `json:",omitempty"`
+
+	// the events of the stages passed, recorded if the execution succeeds.
+	events []*Event
+}
+
+// MultisigConfig is the owners and threshold of a multisig account.
+type MultisigConfig struct {
+	Owners    []string
+	Threshold uint32
+}
+
+// MultisigProposal is a transfer proposed from a multisig account.
+type MultisigProposal struct {
+	To       string
+	Value    string
+	Signers  []string
+	Executed bool
+}
+
+// LoadMultisigPayload from bytes
+func LoadMultisigPayload(bytes []byte) (*MultisigPayload, error) {
+	payload := &MultisigPayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// NewMultisigCreatePayload creates a multisig account of the owners.
+func NewMultisigCreatePayload(owners []string, threshold uint32) *MultisigPayload {
+	return &MultisigPayload{
+		Action:    MultisigCreateAction,
+		Owners:    owners,
+		Threshold: threshold,
+	}
+}
+
+// NewMultisigProposePayload proposes a transfer from the multisig account.
+func NewMultisigProposePayload(account, to, value string) *MultisigPayload {
+	return &MultisigPayload{
+		Action:  MultisigProposeAction,
+		Account: account,
+		To:      to,
+		Value:   value,
+	}
+}
+
+// NewMultisigSignPayload co-signs the proposal of the multisig account.
+func NewMultisigSignPayload(account, proposal string) *MultisigPayload {
+	return &MultisigPayload{
+		Action:   MultisigSignAction,
+		Account:  account,
+		Proposal: proposal,
+	}
+}
+
+// ToBytes serialize payload
+func (payload *MultisigPayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *MultisigPayload) BaseGasCount() *util.Uint128 {
+	return MultisigBaseGasCount
+}
+
+// Execute the multisig payload in tx
+func (payload *MultisigPayload) Execute(ctx *PayloadContext) (*util.Uint128, error) {
+	var err error
+	switch payload.Action {
+	case MultisigCreateAction:
+		err = payload.create(ctx)
+	case MultisigProposeAction:
+		err = payload.propose(ctx)
+	case MultisigSignAction:
+		err = payload.sign(ctx)
+	default:
+		err = ErrInvalidMultisigPayloadAction
+	}
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	for _, event := range payload.events {
+		if err := ctx.block.recordEvent(ctx.tx.hash, event); err != nil {
+			return ZeroGasCount, err
+		}
+	}
+	return ZeroGasCount, nil
+}
+
+func (payload *MultisigPayload) create(ctx *PayloadContext) error {
+	if len(payload.Owners) == 0 || len(payload.Owners) > MaxMultisigOwners {
+		return ErrInvalidMultisigOwners
+	}
+	owners := make(map[string]bool)
+	config := &MultisigConfig{Threshold: payload.Threshold}
+	for _, v := range payload.Owners {
+		owner, err := AddressParse(v)
+		if err != nil {
+			return err
+		}
+		if owners[owner.String()] {
+			return ErrInvalidMultisigOwners
+		}
+		owners[owner.String()] = true
+		config.Owners = append(config.Owners, owner.String())
+	}
+	if payload.Threshold == 0 || int(payload.Threshold) > len(config.Owners) {
+		return ErrInvalidMultisigThreshold
+	}
+
+	addr, err := ctx.tx.GenerateContractAddress()
+	if err != nil {
+		return err
+	}
+	acc, err := ctx.accState.CreateContractAccount(addr.Bytes(), ctx.tx.Hash())
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := acc.Put(multisigConfigKey, data); err != nil {
+		return err
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tx":        ctx.tx,
+		"account":   addr.String(),
+		"owners":    config.Owners,
+		"threshold": config.Threshold,
+	}).Info("Created a multisig account.")
+	return payload.addEvent(TopicMultisigCreated, map[string]interface{}{
+		"account":   addr.String(),
+		"owners":    config.Owners,
+		"threshold": config.Threshold,
+	})
+}
+
+func (payload *MultisigPayload) propose(ctx *PayloadContext) error {
+	acc, config, err := loadMultisigAccount(ctx, payload.Account)
+	if err != nil {
+		return err
+	}
+	if !config.isOwner(ctx.tx.from) {
+		return ErrNotMultisigOwner
+	}
+	to, err := AddressParse(payload.To)
+	if err != nil {
+		return err
+	}
+	value, ok := util.NewUint128().FromString(payload.Value)
+	if !ok || value.Validate() != nil {
+		return ErrInvalidMultisigValue
+	}
+
+	proposal := &MultisigProposal{
+		To:      to.String(),
+		Value:   value.String(),
+		Signers: []string{ctx.tx.from.String()},
+	}
+	if err := payload.addEvent(TopicMultisigProposed, map[string]interface{}{
+		"account":  payload.Account,
+		"proposal": ctx.tx.hash.String(),
+		"to":       proposal.To,
+		"value":    proposal.Value,
+	}); err != nil {
+		return err
+	}
+	return payload.collect(ctx, acc, config, ctx.tx.hash, proposal)
+}
+
+func (payload *MultisigPayload) sign(ctx *PayloadContext) error {
+	acc, config, err := loadMultisigAccount(ctx, payload.Account)
+	if err != nil {
+		return err
+	}
+	if !config.isOwner(ctx.tx.from) {
+		return ErrNotMultisigOwner
+	}
+	id, err := byteutils.FromHex(payload.Proposal)
+	if err != nil {
+		return err
+	}
+	data, err := acc.Get(multisigProposalKey(id))
+	if err == storage.ErrKeyNotFound {
+		return ErrMultisigProposalNotFound
+	}
+	if err != nil {
+		return err
+	}
+	proposal := new(MultisigProposal)
+	if err := json.Unmarshal(data, proposal); err != nil {
+		return err
+	}
+	if proposal.Executed {
+		return ErrMultisigProposalExecuted
+	}
+	for _, v := range proposal.Signers {
+		if v == ctx.tx.from.String() {
+			return ErrMultisigAlreadySigned
+		}
+	}
+	proposal.Signers = append(proposal.Signers, ctx.tx.from.String())
+	return payload.collect(ctx, acc, config, id, proposal)
+}
+
+// collect saves the signatures of the proposal, and executes the transfer if signed enough.
+func (payload *MultisigPayload) collect(ctx *PayloadContext, acc state.Account, config *MultisigConfig, id byteutils.Hash, proposal *MultisigProposal) error {
+	if err := payload.addEvent(TopicMultisigSigned, map[string]interface{}{
+		"account":  payload.Account,
+		"proposal": id.String(),
+		"signer":   ctx.tx.from.String(),
+		"signers":  len(proposal.Signers),
+	}); err != nil {
+		return err
+	}
+
+	if len(proposal.Signers) >= int(config.Threshold) {
+		to, err := AddressParse(proposal.To)
+		if err != nil {
+			return err
+		}
+		value := util.NewUint128FromString(proposal.Value)
+		if err := acc.SubBalance(value); err != nil {
+			return ErrInsufficientBalance
+		}
+		ctx.accState.GetOrCreateUserAccount(to.Bytes()).AddBalance(value)
+		proposal.Executed = true
+
+		logging.VLog().WithFields(logrus.Fields{
+			"tx":       ctx.tx,
+			"account":  payload.Account,
+			"proposal": id.String(),
+			"to":       proposal.To,
+			"value":    proposal.Value,
+		}).Info("Executed a multisig proposal.")
+		if err := payload.addEvent(TopicMultisigExecuted, map[string]interface{}{
+			"account":  payload.Account,
+			"proposal": id.String(),
+			"to":       proposal.To,
+			"value":    proposal.Value,
+		}); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+	return acc.Put(multisigProposalKey(id), data)
+}
+
+func (payload *MultisigPayload) addEvent(topic string, v map[string]interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	payload.events = append(payload.events, &Event{Topic: topic, Data: string(data)})
+	return nil
+}
+
+// loadMultisigAccount returns the multisig account of the address and its config.
+func loadMultisigAccount(ctx *PayloadContext, address string) (state.Account, *MultisigConfig, error) {
+	addr, err := AddressParse(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	acc, err := ctx.accState.GetContractAccount(addr.Bytes())
+	if err != nil {
+		return nil, nil, ErrMultisigAccountNotFound
+	}
+	data, err := acc.Get(multisigConfigKey)
+	if err != nil {
+		return nil, nil, ErrMultisigAccountNotFound
+	}
+	config := new(MultisigConfig)
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, nil, err
+	}
+	return acc, config, nil
+}
+
+func (config *MultisigConfig) isOwner(addr *Address) bool {
+	for _, v := range config.Owners {
+		if v == addr.String() {
+			return true
+		}
+	}
+	return false
+}
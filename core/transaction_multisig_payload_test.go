@@ -0,0 +1,117 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMultisigPayload(t *testing.T) {
+	payload := NewMultisigCreatePayload([]string{"1a263547d167c74cf4b8f9166cfa244de0481c514a45aa2c"}, 1)
+	bytes, err := payload.ToBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Action":"create","Owners":["1a263547d167c74cf4b8f9166cfa244de0481c514a45aa2c"],"Threshold":1}`, string(bytes))
+	got, err := LoadMultisigPayload(bytes)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, got)
+
+	_, err = LoadMultisigPayload([]byte("nas"))
+	assert.NotNil(t, err)
+}
+
+func TestMultisigPayload_Execute(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.begin()
+
+	a, b, c, d := mockAddress(), mockAddress(), mockAddress(), mockAddress()
+	for _, addr := range []*Address{a, b, c, d} {
+		block.accState.GetOrCreateUserAccount(addr.Bytes()).AddBalance(util.NewUint128FromInt(1000000000000))
+	}
+	nonces := make(map[string]uint64)
+	execute := func(from *Address, payload *MultisigPayload) (*Transaction, []string) {
+		data, err := payload.ToBytes()
+		assert.Nil(t, err)
+		nonces[from.String()]++
+		tx := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), nonces[from.String()], TxPayloadMultisigType, data, TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.hash, _ = HashTransaction(tx)
+		_, err = block.executeTransaction(tx)
+		assert.Nil(t, err)
+		events, err := block.FetchEvents(tx.hash)
+		assert.Nil(t, err)
+		var topics []string
+		for _, e := range events {
+			topics = append(topics, e.Topic)
+		}
+		return tx, topics
+	}
+	balance := func(addr *Address) string {
+		return block.accState.GetOrCreateUserAccount(addr.Bytes()).Balance().String()
+	}
+
+	// 2 of 3 owners sign a transfer.
+	_, topics := execute(a, NewMultisigCreatePayload([]string{a.String(), b.String(), a.String()}, 2))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+	_, topics = execute(a, NewMultisigCreatePayload([]string{a.String(), b.String(), c.String()}, 4))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+	tx, topics := execute(a, NewMultisigCreatePayload([]string{a.String(), b.String(), c.String()}, 2))
+	assert.Equal(t, []string{TopicMultisigCreated, TopicExecuteTxSuccess}, topics)
+	account, err := tx.GenerateContractAddress()
+	assert.Nil(t, err)
+	block.accState.GetOrCreateUserAccount(account.Bytes()).AddBalance(util.NewUint128FromInt(100))
+
+	proposal, topics := execute(b, NewMultisigProposePayload(account.String(), d.String(), "60"))
+	assert.Equal(t, []string{TopicMultisigProposed, TopicMultisigSigned, TopicExecuteTxSuccess}, topics)
+	assert.Equal(t, "100", balance(account))
+
+	// the proposer, the non-owner and the unknown proposals fail.
+	_, topics = execute(b, NewMultisigSignPayload(account.String(), proposal.hash.String()))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+	_, topics = execute(d, NewMultisigSignPayload(account.String(), proposal.hash.String()))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+	_, topics = execute(c, NewMultisigSignPayload(account.String(), tx.hash.String()))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+	_, topics = execute(c, NewMultisigSignPayload(a.String(), proposal.hash.String()))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+
+	before := balance(d)
+	_, topics = execute(c, NewMultisigSignPayload(account.String(), proposal.hash.String()))
+	assert.Equal(t, []string{TopicMultisigSigned, TopicMultisigExecuted, TopicExecuteTxSuccess}, topics)
+	assert.Equal(t, "40", balance(account))
+	received := util.NewUint128().Sub(block.accState.GetOrCreateUserAccount(d.Bytes()).Balance().Int, util.NewUint128FromString(before).Int)
+	assert.Equal(t, "60", received.String())
+
+	// the proposal is executed once.
+	_, topics = execute(a, NewMultisigSignPayload(account.String(), proposal.hash.String()))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+
+	// the proposal exceeding the balance is collected until signed enough.
+	_, topics = execute(a, NewMultisigProposePayload(account.String(), d.String(), "50"))
+	assert.Equal(t, []string{TopicMultisigProposed, TopicMultisigSigned, TopicExecuteTxSuccess}, topics)
+
+	// the transfer of a 1 of 1 account exceeding the balance fails.
+	tx, _ = execute(a, NewMultisigCreatePayload([]string{a.String()}, 1))
+	single, _ := tx.GenerateContractAddress()
+	_, topics = execute(a, NewMultisigProposePayload(single.String(), d.String(), "1"))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics)
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+const (
+	// DefaultNonceReservation is the default time a nonce handed out by NextNonce is kept from the other
+	// submitters of the account, if no tx of it comes into the pool.
+	DefaultNonceReservation = 30 * time.Second
+)
+
+var (
+	// ErrNonceNotReserved the nonce is not reserved for the account.
+	ErrNonceNotReserved = errors.New("nonce not reserved")
+)
+
+// SetNonceReservation config the time a nonce handed out by NextNonce is reserved,
+// DefaultNonceReservation is kept for 0.
+func (pool *TransactionPool) SetNonceReservation(d time.Duration) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if d > 0 {
+		pool.reservation = d
+	}
+}
+
+// NextNonce returns the next safe nonce of the address, the first one after the tail not taken by a tx in
+// pool, pending or queued, nor reserved for another submitter. The nonce is reserved until a tx of it comes
+// into the pool, ReleaseNonce, or the reservation times out, so the concurrent submitters of an account
+// never get the same nonce. It returns the deadline of the reservation too.
+func (pool *TransactionPool) NextNonce(addr *Address) (uint64, time.Time) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	sender := addr.address.Hex()
+	now := time.Now()
+	reserved := pool.reserved[sender]
+	for nonce, deadline := range reserved {
+		if !now.Before(deadline) {
+			delete(reserved, nonce)
+		}
+	}
+
+	nonce := pool.tailNonce(addr) + 1
+	for pool.nonces[sender][nonce] != nil || !reserved[nonce].IsZero() {
+		nonce++
+	}
+	if reserved == nil {
+		reserved = make(map[uint64]time.Time)
+		pool.reserved[sender] = reserved
+	}
+	deadline := now.Add(pool.reservation)
+	reserved[nonce] = deadline
+	return nonce, deadline
+}
+
+// ReleaseNonce gives back a nonce reserved by NextNonce not to be used, so it's handed out again.
+func (pool *TransactionPool) ReleaseNonce(addr *Address, nonce uint64) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if _, ok := pool.reserved[addr.address.Hex()][nonce]; !ok {
+		return ErrNonceNotReserved
+	}
+	pool.unreserve(addr.address.Hex(), nonce)
+	return nil
+}
+
+// unreserve forgets the reservation of the nonce of the sender.
+func (pool *TransactionPool) unreserve(sender byteutils.HexHash, nonce uint64) {
+	reserved := pool.reserved[sender]
+	if reserved == nil {
+		return
+	}
+	delete(reserved, nonce)
+	if len(reserved) == 0 {
+		delete(pool.reserved, sender)
+	}
+}
+
+// dropReservations forgets the reservations of the nonces included by the tail, and the ones timed out.
+func (pool *TransactionPool) dropReservations(tail *Block, now time.Time) {
+	for sender, reserved := range pool.reserved {
+		addr, err := sender.Hash()
+		if err != nil {
+			delete(pool.reserved, sender)
+			continue
+		}
+		nonce := tail.GetNonce(addr)
+		for n, deadline := range reserved {
+			if n <= nonce || !now.Before(deadline) {
+				delete(reserved, n)
+			}
+		}
+		if len(reserved) == 0 {
+			delete(pool.reserved, sender)
+		}
+	}
+}
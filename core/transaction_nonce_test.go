@@ -0,0 +1,114 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionPool_NextNonce(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	sign, _ := crypto.NewSignature(keystore.SECP256K1)
+	sign.InitSign(priv)
+	fundAccounts(bc, from)
+	newTx := func(nonce uint64) *Transaction {
+		tx := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), nonce, TxPayloadBinaryType, []byte("data"), TransactionGasPrice, util.NewUint128FromInt(200000))
+		assert.Nil(t, tx.Sign(sign))
+		return tx
+	}
+
+	// the nonces taken by the txs in pool, pending or queued, are skipped.
+	assert.Nil(t, txPool.Push(newTx(1)))
+	assert.Nil(t, txPool.Push(newTx(3)))
+	nonce, deadline := txPool.NextNonce(from)
+	assert.Equal(t, uint64(2), nonce)
+	assert.True(t, deadline.After(time.Now()))
+	nonce, _ = txPool.NextNonce(from)
+	assert.Equal(t, uint64(4), nonce)
+
+	// a tx of the reserved nonce takes the reservation, a released nonce is handed out again.
+	assert.Nil(t, txPool.Push(newTx(2)))
+	assert.Equal(t, ErrNonceNotReserved, txPool.ReleaseNonce(from, 2))
+	assert.Nil(t, txPool.ReleaseNonce(from, 4))
+	nonce, _ = txPool.NextNonce(from)
+	assert.Equal(t, uint64(4), nonce)
+
+	// the concurrent submitters never get the same nonce.
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		nonces = make(map[uint64]bool)
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, _ := txPool.NextNonce(from)
+			mu.Lock()
+			nonces[n] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 20, len(nonces))
+	for n := uint64(5); n < 25; n++ {
+		assert.True(t, nonces[n])
+	}
+
+	// the reservations time out.
+	other := mockAddress()
+	txPool.SetNonceReservation(time.Millisecond)
+	nonce, _ = txPool.NextNonce(other)
+	assert.Equal(t, uint64(1), nonce)
+	time.Sleep(2 * time.Millisecond)
+	nonce, _ = txPool.NextNonce(other)
+	assert.Equal(t, uint64(1), nonce)
+
+	// the reservations of the nonces included by the tail are dropped.
+	txPool.SetNonceReservation(time.Minute)
+	tail := bc.tailBlock
+	tail.begin()
+	acc := tail.accState.GetOrCreateUserAccount(from.Bytes())
+	for i := 0; i < 5; i++ {
+		acc.IncrNonce()
+	}
+	tail.commit()
+	time.Sleep(2 * time.Millisecond)
+	txPool.onNewTail(tail)
+	_, ok := txPool.reserved[other.address.Hex()]
+	assert.False(t, ok)
+	for n := range txPool.reserved[from.address.Hex()] {
+		assert.True(t, n > 5)
+	}
+	nonce, _ = txPool.NextNonce(from)
+	assert.Equal(t, uint64(25), nonce)
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// the limits of the oracle data fed by a transaction.
+const (
+	MaxOracleKeyLength   = 128
+	MaxOracleValueLength = 4096
+)
+
+var (
+	// the reserved account keeping the feeders and the oracle data in its storage, whose state is verified
+	// as any other account in the blocks.
+	oracleAddress, _ = NewContractAddressFromHash(hash.Sha3256([]byte("nebulas.oracle")))
+
+	oracleFeederPrefix = trie.HashDomainsPrefix("oracle", "feeder")
+)
+
+// OraclePayload feeds the value of key to the contracts, sent by a feeder whitelisted in genesis. The data
+// is signed by the feeder as the transaction.
+type OraclePayload struct {
+	Key   string
+	Value string
+}
+
+// OracleData is the latest record of the oracle data of a key, read by contracts.
+type OracleData struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Feeder    string `json:"feeder"`
+	Height    uint64 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// LoadOraclePayload from bytes
+func LoadOraclePayload(bytes []byte) (*OraclePayload, error) {
+	payload := &OraclePayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, err
+	}
+	if len(payload.Key) == 0 || len(payload.Key) > MaxOracleKeyLength || len(payload.Value) > MaxOracleValueLength {
+		return nil, ErrInvalidOraclePayload
+	}
+	return payload, nil
+}
+
+// NewOraclePayload with key and value
+func NewOraclePayload(key, value string) *OraclePayload {
+	return &OraclePayload{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// ToBytes serialize payload
+func (payload *OraclePayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *OraclePayload) BaseGasCount() *util.Uint128 {
+	return util.NewUint128()
+}
+
+// Execute the oracle payload in tx, record the data of the feeder
+func (payload *OraclePayload) Execute(ctx *PayloadContext) (*util.Uint128, error) {
+	oracle, err := ctx.accState.GetContractAccount(oracleAddress.Bytes())
+	if err == state.ErrAccountNotFound {
+		return ZeroGasCount, ErrNotOracleFeeder
+	}
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	if _, err := oracle.Get(oracleFeederKey(ctx.tx.from.Bytes())); err != nil {
+		if err == storage.ErrKeyNotFound {
+			return ZeroGasCount, ErrNotOracleFeeder
+		}
+		return ZeroGasCount, err
+	}
+
+	data, err := json.Marshal(&OracleData{
+		Key:       payload.Key,
+		Value:     payload.Value,
+		Feeder:    ctx.tx.from.String(),
+		Height:    ctx.block.Height(),
+		Timestamp: ctx.block.Timestamp(),
+	})
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	if err := oracle.Put(oracleDataKey(payload.Key), data); err != nil {
+		return ZeroGasCount, err
+	}
+	ctx.recordEvent(&Event{Topic: TopicOracleDataFed, Data: string(data)})
+	return ZeroGasCount, nil
+}
+
+func oracleFeederKey(addr byteutils.Hash) []byte {
+	return trie.HashDomains("oracle", "feeder", addr.String())
+}
+
+func oracleDataKey(key string) []byte {
+	return trie.HashDomains("oracle", "data", key)
+}
+
+// oracleData returns the latest record of the oracle data of key, storage.ErrKeyNotFound if never fed.
+func oracleData(accState state.AccountState, key string) ([]byte, error) {
+	oracle, err := accState.GetContractAccount(oracleAddress.Bytes())
+	if err == state.ErrAccountNotFound {
+		return nil, storage.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return oracle.Get(oracleDataKey(key))
+}
+
+// setupOracleFeeders whitelists the feeders in genesis conf.
+func setupOracleFeeders(accState state.AccountState, conf *corepb.Genesis) error {
+	if conf.Oracle == nil || len(conf.Oracle.Feeders) == 0 {
+		return nil
+	}
+	oracle := accState.GetOrCreateUserAccount(oracleAddress.Bytes())
+	for _, v := range conf.Oracle.Feeders {
+		addr, err := AddressParse(v)
+		if err != nil {
+			return err
+		}
+		if err := oracle.Put(oracleFeederKey(addr.Bytes()), addr.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpOracleFeeders returns the feeders whitelisted in genesis, nil if none.
+func dumpOracleFeeders(accState state.AccountState) (*corepb.GenesisOracle, error) {
+	oracle, err := accState.GetContractAccount(oracleAddress.Bytes())
+	if err == state.ErrAccountNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	iter, err := oracle.Iterator(oracleFeederPrefix)
+	if err == storage.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	conf := &corepb.GenesisOracle{}
+	exist, err := iter.Next()
+	for exist {
+		var addr *Address
+		if addr, err = AddressParseFromBytes(iter.Value()); err != nil {
+			return nil, err
+		}
+		conf.Feeders = append(conf.Feeders, addr.String())
+		exist, err = iter.Next()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadOraclePayload(t *testing.T) {
+	data, _ := NewOraclePayload("NAS/USD", "1.25").ToBytes()
+	payload, err := LoadOraclePayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, "NAS/USD", payload.Key)
+	assert.Equal(t, "1.25", payload.Value)
+
+	long := make([]byte, MaxOracleValueLength+1)
+	for _, p := range []*OraclePayload{NewOraclePayload("", "1"), NewOraclePayload("key", string(long))} {
+		data, _ := p.ToBytes()
+		_, err := LoadOraclePayload(data)
+		assert.Equal(t, ErrInvalidOraclePayload, err)
+	}
+}
+
+func TestOraclePayload_Execute(t *testing.T) {
+	feeder, other := mockAddress(), mockAddress()
+	neb := testNeb()
+	neb.genesis.Oracle = &corepb.GenesisOracle{Feeders: []string{feeder.String()}}
+	bc, err := NewBlockChain(neb)
+	assert.Nil(t, err)
+
+	dumpConf, err := DumpGenesis(neb.storage)
+	assert.Nil(t, err)
+	assert.Equal(t, neb.genesis.Oracle, dumpConf.Oracle)
+	assert.Equal(t, neb.genesis.TokenDistribution, dumpConf.TokenDistribution)
+
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.begin()
+	_, err = oracleData(block.accState, "NAS/USD")
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+
+	nonces := make(map[string]uint64)
+	execute := func(from *Address, payload *OraclePayload) *Transaction {
+		block.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(util.NewUint128FromInt(1000000000000))
+		data, _ := payload.ToBytes()
+		nonces[from.String()]++
+		tx := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), nonces[from.String()], TxPayloadOracleType, data, TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.hash, _ = HashTransaction(tx)
+		_, err := block.executeTransaction(tx)
+		assert.Nil(t, err)
+		return tx
+	}
+
+	tx := execute(other, NewOraclePayload("NAS/USD", "1.00"))
+	events, _ := block.FetchEvents(tx.hash)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, TopicExecuteTxFailed, events[0].Topic)
+	_, err = oracleData(block.accState, "NAS/USD")
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+
+	tx = execute(feeder, NewOraclePayload("NAS/USD", "1.25"))
+	events, _ = block.FetchEvents(tx.hash)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, TopicOracleDataFed, events[0].Topic)
+	assert.Equal(t, TopicExecuteTxSuccess, events[1].Topic)
+
+	// contracts read the latest record fed.
+	bytes, err := oracleData(block.accState, "NAS/USD")
+	assert.Nil(t, err)
+	assert.Equal(t, events[0].Data, string(bytes))
+	data := new(OracleData)
+	assert.Nil(t, json.Unmarshal(bytes, data))
+	assert.Equal(t, &OracleData{
+		Key:       "NAS/USD",
+		Value:     "1.25",
+		Feeder:    feeder.String(),
+		Height:    block.Height(),
+		Timestamp: block.Timestamp(),
+	}, data)
+}
@@ -18,7 +18,11 @@
 
 package core
 
-import "github.com/nebulasio/go-nebulas/core/state"
+import (
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
 
 // PayloadContext transaction payload context
 type PayloadContext struct {
@@ -27,6 +31,22 @@ type PayloadContext struct {
 
 	accState    state.AccountState
 	dposContext *DposContext
+
+	// the events of the execution, they are recorded in block once the execution is committed.
+	events []*Event
+}
+
+// contractBlock is the block seen by contracts, the events they record are kept in the context, so
+// a failed execution leaves none of them, as it leaves no state changes.
+type contractBlock struct {
+	*Block
+	ctx *PayloadContext
+}
+
+// RecordEvent keeps the event of contract until the execution is committed.
+func (block *contractBlock) RecordEvent(txHash byteutils.Hash, topic, data string) error {
+	block.ctx.recordEvent(&Event{Topic: topic, Data: data})
+	return nil
 }
 
 // NewPayloadContext returns new payloadcontxt
@@ -45,8 +65,43 @@ func (ctx *PayloadContext) Transaction() *Transaction {
 	return ctx.tx
 }
 
+// OracleData returns the latest record of the oracle data of key in the states, for the reads from contracts.
+func (block *contractBlock) OracleData(accState state.AccountState, key string) ([]byte, error) {
+	return oracleData(accState, key)
+}
+
+// LoadContract loads the contract at address in the states, for the calls from contracts.
+func (block *contractBlock) LoadContract(accState state.AccountState, address string) (*nvm.Contract, error) {
+	addr, err := AddressParse(address)
+	if err != nil {
+		return nil, err
+	}
+	contract, birthTx, deploy, err := loadContract(block.Block, accState, addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &nvm.Contract{
+		Address:    addr.String(),
+		Account:    contract,
+		Owner:      birthTx.from.Bytes(),
+		Source:     deploy.Source,
+		SourceType: deploy.SourceType,
+	}, nil
+}
+
+// recordEvent keeps the event of the execution, it is recorded in the block once committed.
+func (ctx *PayloadContext) recordEvent(event *Event) {
+	ctx.events = append(ctx.events, event)
+}
+
+// contractBlock returns the block for the contract runtime.
+func (ctx *PayloadContext) contractBlock() *contractBlock {
+	return &contractBlock{Block: ctx.block, ctx: ctx}
+}
+
 // BeginBatch begin a batch task
 func (ctx *PayloadContext) BeginBatch() (err error) {
+	ctx.events = nil
 	ctx.accState, err = ctx.block.accState.Clone()
 	if err != nil {
 		return err
@@ -58,13 +113,20 @@ func (ctx *PayloadContext) BeginBatch() (err error) {
 	return nil
 }
 
-// Commit a batch task
-func (ctx *PayloadContext) Commit() {
+// Commit a batch task, the events recorded by contracts go into the block.
+func (ctx *PayloadContext) Commit() error {
 	ctx.block.accState = ctx.accState
 	ctx.block.dposContext = ctx.dposContext
+	for _, event := range ctx.events {
+		if err := ctx.block.recordEvent(ctx.tx.hash, event); err != nil {
+			return err
+		}
+	}
+	ctx.events = nil
+	return nil
 }
 
-// RollBack a batch task
+// RollBack a batch task, the events recorded by contracts are dropped.
 func (ctx *PayloadContext) RollBack() {
-	// do nothing
+	ctx.events = nil
 }
@@ -447,3 +447,31 @@ func TestPayload_Execute(t *testing.T) {
 
 	block.accState.Commit()
 }
+
+func TestPayloadContext_ContractEvents(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.begin()
+
+	from := mockAddress()
+	execute := func(nonce uint64, succeeded bool) []*Event {
+		tx := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), nonce, TxPayloadCallType, []byte("nas"), TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.hash, _ = HashTransaction(tx)
+		ctx := NewPayloadContext(block, tx)
+		assert.Nil(t, ctx.BeginBatch())
+		assert.Nil(t, ctx.contractBlock().RecordEvent(tx.hash, "chain.contract.topic", "data"))
+		if succeeded {
+			assert.Nil(t, ctx.Commit())
+		} else {
+			ctx.RollBack()
+		}
+		events, err := block.FetchEvents(tx.hash)
+		assert.Nil(t, err)
+		return events
+	}
+
+	// the events of contract are recorded only if the execution succeeds.
+	assert.Equal(t, []*Event{{Topic: "chain.contract.topic", Data: "data"}}, execute(1, true))
+	assert.Equal(t, 0, len(execute(2, false)))
+}
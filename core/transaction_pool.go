@@ -19,11 +19,15 @@
 package core
 
 import (
+	"encoding/json"
+	"math/big"
 	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/nebulasio/go-nebulas/common/pdeque"
 	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/pbjson"
 	"github.com/nebulasio/go-nebulas/net"
 	"github.com/nebulasio/go-nebulas/net/p2p"
 	"github.com/nebulasio/go-nebulas/util"
@@ -33,14 +37,44 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// MaxTxVerifyBatch is the max count of the transactions received verified in a batch.
+	MaxTxVerifyBatch = 256
+
+	// DefaultTxSenderLimit is the default max count of the transactions of a sender in pool.
+	DefaultTxSenderLimit = 256
+
+	// DefaultTxLifetime is the default time a transaction stays in pool before expired.
+	DefaultTxLifetime = 3 * time.Hour
+
+	// TxExpireInterval is the interval to drop the expired transactions.
+	TxExpireInterval = time.Minute
+
+	// DefaultTxPriceBump is the default min percentage of the gas price raised to replace a tx of the same nonce.
+	DefaultTxPriceBump = 10
+
+	// MaxTxDataPayloadLength is the max length of the data payload of a transaction admitted into pool.
+	MaxTxDataPayloadLength = 128 * 1024
+)
+
 var (
 	invalidTxCounter       = metrics.GetOrRegisterCounter("txpool_invalid", nil)
 	duplicateTxCounter     = metrics.GetOrRegisterCounter("txpool_duplicate", nil)
 	belowGasPriceTxCounter = metrics.GetOrRegisterCounter("txpool_below_gas_price", nil)
 	outOfGasLimitTxCounter = metrics.GetOrRegisterCounter("txpool_out_of_gas_limit", nil)
+	senderLimitTxCounter   = metrics.GetOrRegisterCounter("txpool_sender_limit", nil)
+	largeDataTxCounter     = metrics.GetOrRegisterCounter("txpool_large_data", nil)
+	insufficientTxCounter  = metrics.GetOrRegisterCounter("txpool_insufficient_balance", nil)
+	evictedTxCounter       = metrics.GetOrRegisterCounter("txpool_evicted", nil)
+	expiredTxCounter       = metrics.GetOrRegisterCounter("txpool_expired", nil)
+	replacedTxCounter      = metrics.GetOrRegisterCounter("txpool_replaced", nil)
+	txPoolSizeGauge        = metrics.GetOrRegisterGauge("txpool_size", nil)
+	txPoolQueuedGauge      = metrics.GetOrRegisterGauge("txpool_queued", nil)
 )
 
-// TransactionPool cache txs, is thread safe
+// TransactionPool cache txs, is thread safe.
+// The pending txs in cache are executable in order of nonce after the tail, the txs of future nonces
+// are queued by sender until the nonce gaps before them are filled.
 type TransactionPool struct {
 	receivedMessageCh chan net.Message
 	quitCh            chan int
@@ -50,6 +84,19 @@ type TransactionPool struct {
 	all   map[byteutils.HexHash]*Transaction
 	bc    *BlockChain
 
+	queued       map[byteutils.HexHash]map[uint64]*Transaction
+	pendingNonce map[byteutils.HexHash]uint64
+	nonces       map[byteutils.HexHash]map[uint64]*Transaction
+	priceBump    int
+
+	reserved    map[byteutils.HexHash]map[uint64]time.Time
+	reservation time.Duration
+
+	senderLimit int
+	lifetime    time.Duration
+	received    map[byteutils.HexHash]time.Time
+	senders     map[byteutils.HexHash]int
+
 	nm p2p.Manager
 	mu sync.RWMutex
 
@@ -57,6 +104,7 @@ type TransactionPool struct {
 	gasLimit *util.Uint128 // the maximum gasLimit.
 }
 
+// less returns true if txa is packed before txb, the min is popped first and the max is dropped first.
 func less(a interface{}, b interface{}) bool {
 	txa := a.(*Transaction)
 	txb := b.(*Transaction)
@@ -64,8 +112,8 @@ func less(a interface{}, b interface{}) bool {
 		return txa.Nonce() < txb.Nonce()
 	}
 	if txa.gasPrice.Cmp(txb.gasPrice.Int) != 0 {
-		// txa.gasPrice < txb.gasPrice
-		return txa.GasPrice().Cmp(txb.GasPrice().Int) == -1
+		// txa.gasPrice > txb.gasPrice
+		return txa.GasPrice().Cmp(txb.GasPrice().Int) == 1
 	}
 	// txa.gasLimit < txb.gasLimit
 	return txa.GasLimit().Cmp(txb.GasLimit().Int) == -1
@@ -79,12 +127,55 @@ func NewTransactionPool(size int) (*TransactionPool, error) {
 		size:              size,
 		cache:             pdeque.NewPriorityDeque(less),
 		all:               make(map[byteutils.HexHash]*Transaction),
+		senderLimit:       DefaultTxSenderLimit,
+		lifetime:          DefaultTxLifetime,
+		received:          make(map[byteutils.HexHash]time.Time),
+		senders:           make(map[byteutils.HexHash]int),
+		queued:            make(map[byteutils.HexHash]map[uint64]*Transaction),
+		pendingNonce:      make(map[byteutils.HexHash]uint64),
+		nonces:            make(map[byteutils.HexHash]map[uint64]*Transaction),
+		priceBump:         DefaultTxPriceBump,
+		reserved:          make(map[byteutils.HexHash]map[uint64]time.Time),
+		reservation:       DefaultNonceReservation,
 		gasPrice:          TransactionGasPrice,
 		gasLimit:          TransactionMaxGas,
 	}
 	return txPool, nil
 }
 
+// SetEvictionConfig config the max count of transactions in pool, the max count of transactions of a sender,
+// and the time a transaction stays in pool before expired. The defaults are kept for the zero values.
+// The cheapest transactions are evicted when the pool is full.
+func (pool *TransactionPool) SetEvictionConfig(size, senderLimit int, lifetime time.Duration) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if size > 0 {
+		pool.size = size
+	}
+	if senderLimit > 0 {
+		pool.senderLimit = senderLimit
+	}
+	if lifetime > 0 {
+		pool.lifetime = lifetime
+	}
+	for len(pool.all) > pool.size {
+		evictedTxCounter.Inc(1)
+		pool.evict()
+	}
+}
+
+// SetPriceBump config the min percentage of the gas price raised to replace a tx of the same nonce,
+// DefaultTxPriceBump is kept for 0.
+func (pool *TransactionPool) SetPriceBump(percent int) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if percent > 0 {
+		pool.priceBump = percent
+	}
+}
+
 // SetGasConfig config the lowest gasPrice and the maximum gasLimit.
 func (pool *TransactionPool) SetGasConfig(gasPrice, gasLimit *util.Uint128) {
 	if gasPrice == nil || gasPrice.Cmp(util.NewUint128().Int) <= 0 {
@@ -101,7 +192,9 @@ func (pool *TransactionPool) SetGasConfig(gasPrice, gasLimit *util.Uint128) {
 
 // RegisterInNetwork register message subscriber in network.
 func (pool *TransactionPool) RegisterInNetwork(nm p2p.Manager) {
-	nm.Register(net.NewSubscriber(pool, pool.receivedMessageCh, MessageTypeNewTx))
+	nm.Register(net.NewSubscriber(pool, pool.receivedMessageCh, MessageTypeNewTx, MessageTypeNewTxs))
+	nm.RegisterValidator(MessageTypeNewTx, ValidateTransactionMessage(pool.bc.ChainID()))
+	nm.RegisterValidator(MessageTypeNewTxs, ValidateTxsMessage(pool.bc.ChainID()))
 	pool.nm = nm
 }
 
@@ -132,57 +225,122 @@ func (pool *TransactionPool) loop() {
 		"size": pool.size,
 	}).Info("Launched TransactionPool.")
 
+	ticker := time.NewTicker(TxExpireInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
+		case now := <-ticker.C:
+			pool.expire(now)
 		case <-pool.quitCh:
 			logging.CLog().WithFields(logrus.Fields{
 				"size": pool.size,
 			}).Info("Shutdowned TransactionPool.")
 			return
 		case msg := <-pool.receivedMessageCh:
-			if msg.MessageType() != MessageTypeNewTx {
-				logging.VLog().WithFields(logrus.Fields{
-					"messageType": msg.MessageType(),
-					"message":     msg,
-					"err":         "not new tx msg",
-				}).Warn("Received unregistered message.")
-				continue
+			msgs := []net.Message{msg}
+			// drain the messages queued, their transactions are verified in a batch.
+			for drained := false; !drained && len(msgs) < MaxTxVerifyBatch; {
+				select {
+				case msg := <-pool.receivedMessageCh:
+					msgs = append(msgs, msg)
+				default:
+					drained = true
+				}
 			}
+			pool.handleTxMessages(msgs)
+		}
+	}
+}
 
-			tx := new(Transaction)
-			pbTx := new(corepb.Transaction)
-			if err := proto.Unmarshal(msg.Data().([]byte), pbTx); err != nil {
-				logging.VLog().WithFields(logrus.Fields{
-					"msgType": msg.MessageType(),
-					"msg":     msg,
-					"err":     err,
-				}).Error("Failed to unmarshal data.")
-				continue
-			}
-			if err := tx.FromProto(pbTx); err != nil {
-				logging.VLog().WithFields(logrus.Fields{
-					"msgType": msg.MessageType(),
-					"msg":     msg,
-					"err":     err,
-				}).Error("Failed to recover a tx from proto data.")
-				continue
-			}
+func (pool *TransactionPool) decodeTxMessage(msg net.Message) []*Transaction {
+	var (
+		pbMsg proto.Message
+		txs   net.Serializable
+	)
+	switch msg.MessageType() {
+	case MessageTypeNewTx:
+		pbMsg, txs = new(corepb.Transaction), new(Transaction)
+	case MessageTypeNewTxs:
+		pbMsg, txs = new(corepb.Txs), new(TransactionBatch)
+	default:
+		logging.VLog().WithFields(logrus.Fields{
+			"messageType": msg.MessageType(),
+			"message":     msg,
+			"err":         "not new tx msg",
+		}).Warn("Received unregistered message.")
+		return nil
+	}
 
+	if err := proto.Unmarshal(msg.Data().([]byte), pbMsg); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+			"err":     err,
+		}).Error("Failed to unmarshal data.")
+		return nil
+	}
+	if err := txs.FromProto(pbMsg); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+			"err":     err,
+		}).Error("Failed to recover a tx from proto data.")
+		return nil
+	}
+	if tx, ok := txs.(*Transaction); ok {
+		return []*Transaction{tx}
+	}
+	return *txs.(*TransactionBatch)
+}
+
+// handleTxMessages verifies the new transactions received in parallel, and pushes the valid ones.
+func (pool *TransactionPool) handleTxMessages(msgs []net.Message) {
+	var (
+		txs    []*Transaction
+		traces []*net.Trace
+	)
+	for _, msg := range msgs {
+		trace := net.TraceOf(msg)
+		traces = append(traces, trace)
+		for _, tx := range pool.decodeTxMessage(msg) {
 			logging.VLog().WithFields(logrus.Fields{
-				"tx":   tx,
-				"type": msg.MessageType(),
+				"tx":    tx,
+				"type":  msg.MessageType(),
+				"trace": trace.ID(),
 			}).Info("Received a new tx.")
 
-			if err := pool.PushAndRelay(tx); err != nil {
-				logging.VLog().WithFields(logrus.Fields{
-					"func":        "TxPool.loop",
-					"messageType": msg.MessageType(),
-					"transaction": tx,
-					"err":         err,
-				}).Error("Failed to push a tx into tx pool.")
+			// the transactions known are dropped before the verification.
+			if pool.GetTransaction(tx.hash) != nil {
+				duplicateTxCounter.Inc(1)
 				continue
 			}
+			txs = append(txs, tx)
+		}
+		trace.Mark("txpool.decoded")
+	}
+
+	errs := VerifyTransactions(pool.bc.chainID, txs)
+	for i, tx := range txs {
+		err := errs[i]
+		if err != nil {
+			invalidTxCounter.Inc(1)
+		} else {
+			err = pool.pushVerified(tx)
+		}
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"func":        "TxPool.loop",
+				"messageType": MessageTypeNewTx,
+				"transaction": tx,
+				"err":         err,
+			}).Error("Failed to push a tx into tx pool.")
+			continue
 		}
+		pool.nm.Relay(MessageTypeNewTx, tx)
+	}
+	for _, trace := range traces {
+		trace.Finish("txpool.handled")
 	}
 }
 
@@ -212,6 +370,32 @@ func (pool *TransactionPool) PushAndBroadcast(tx *Transaction) error {
 }
 
 func (pool *TransactionPool) push(tx *Transaction) error {
+	if err := pool.admit(tx); err != nil {
+		return err
+	}
+
+	// verify hash & sign of tx
+	if err := tx.VerifyIntegrity(pool.bc.chainID); err != nil {
+		invalidTxCounter.Inc(1)
+		return err
+	}
+
+	return pool.insert(tx)
+}
+
+// pushVerified pushes a tx whose integrity is verified already.
+func (pool *TransactionPool) pushVerified(tx *Transaction) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if err := pool.admit(tx); err != nil {
+		return err
+	}
+	return pool.insert(tx)
+}
+
+// admit checks the tx is new, its gas and data meet the pool config, it can be packed after tail,
+// and the balance of the sender on tail covers its value and fees.
+func (pool *TransactionPool) admit(tx *Transaction) error {
 	// verify non-dup tx
 	if _, ok := pool.all[tx.hash.Hex()]; ok {
 		duplicateTxCounter.Inc(1)
@@ -227,24 +411,199 @@ func (pool *TransactionPool) push(tx *Transaction) error {
 		outOfGasLimitTxCounter.Inc(1)
 		return ErrOutOfGasLimit
 	}
-
-	// verify hash & sign of tx
-	if err := tx.VerifyIntegrity(pool.bc.chainID); err != nil {
-		invalidTxCounter.Inc(1)
-		return err
+	if tx.DataLen() > MaxTxDataPayloadLength {
+		largeDataTxCounter.Inc(1)
+		return ErrTxDataPayloadTooLarge
 	}
+	tail := pool.bc.TailBlock()
+	if tx.Expired(tail.height+1, time.Now().Unix()) {
+		expiredTxCounter.Inc(1)
+		return ErrTransactionExpired
+	}
+	cost := new(big.Int).Add(tx.value.Int, tx.MinBalanceRequired().Int)
+	if tail.GetBalance(tx.from.address).Cmp(cost) < 0 {
+		insufficientTxCounter.Inc(1)
+		return ErrInsufficientBalance
+	}
+	if old := pool.nonces[tx.from.address.Hex()][tx.nonce]; old != nil {
+		// the replacement needs a price raised by priceBump percent at least.
+		price := new(big.Int).Mul(old.gasPrice.Int, big.NewInt(int64(100+pool.priceBump)))
+		if new(big.Int).Mul(tx.gasPrice.Int, big.NewInt(100)).Cmp(price) < 0 || tx.gasPrice.Cmp(old.gasPrice.Int) <= 0 {
+			return ErrUnderpricedReplacement
+		}
+		return nil
+	}
+	if pool.senders[tx.from.address.Hex()] >= pool.senderLimit {
+		senderLimitTxCounter.Inc(1)
+		return ErrTooManySenderTransactions
+	}
+	return nil
+}
 
-	// cache the verified tx
-	pool.cache.Insert(tx)
+func (pool *TransactionPool) insert(tx *Transaction) error {
+	sender := tx.from.address.Hex()
+	if old := pool.nonces[sender][tx.nonce]; old != nil {
+		pool.replace(old, tx)
+	}
 	pool.all[tx.hash.Hex()] = tx
+	pool.received[tx.hash.Hex()] = time.Now()
+	pool.senders[sender]++
+	if pool.nonces[sender] == nil {
+		pool.nonces[sender] = make(map[uint64]*Transaction)
+	}
+	pool.nonces[sender][tx.nonce] = tx
+	pool.unreserve(sender, tx.nonce)
+	if tx.nonce > pool.nextNonce(tx.from) {
+		pool.enqueue(tx)
+	} else {
+		// cache the verified tx
+		pool.cache.Insert(tx)
+		if tx.nonce == pool.nextNonce(tx.from) {
+			pool.pendingNonce[tx.from.address.Hex()] = tx.nonce
+		}
+		pool.promote(tx.from)
+	}
 	// delete tx with lowest priority if cache is full
-	if pool.cache.Len() > pool.size {
-		tx := pool.cache.PopMax().(*Transaction)
-		delete(pool.all, tx.hash.Hex())
+	if len(pool.all) > pool.size {
+		evicted := pool.evict()
+		if evicted == tx {
+			return ErrTxPoolFull
+		}
+		evictedTxCounter.Inc(1)
+
+		logging.VLog().WithFields(logrus.Fields{
+			"tx":   evicted,
+			"size": pool.size,
+		}).Debug("Evicted the cheapest tx from full tx pool.")
 	}
+	pool.updateGauges()
+	pool.triggerPending(tx)
 	return nil
 }
 
+// triggerPending notifies the subscribers of TopicPendingTransaction.
+func (pool *TransactionPool) triggerPending(tx *Transaction) {
+	if pool.bc == nil || pool.bc.eventEmitter == nil {
+		return
+	}
+	pbTx, err := tx.ToProto()
+	if err != nil {
+		return
+	}
+	data, err := pbjson.MarshalTransaction(pbTx.(*corepb.Transaction))
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"tx":  tx,
+			"err": err,
+		}).Error("Failed to marshal the pending tx.")
+		return
+	}
+	pool.bc.eventEmitter.Trigger(&Event{
+		Topic: TopicPendingTransaction,
+		Data:  string(data),
+	})
+}
+
+// evict drops a queued tx, the last of a sender with the lowest price, or the cheapest pending tx if none queued.
+func (pool *TransactionPool) evict() *Transaction {
+	evicted := pool.lastQueued()
+	if evicted != nil {
+		pool.dequeue(evicted)
+	} else {
+		evicted = pool.cache.PopMax().(*Transaction)
+	}
+	pool.remove(evicted)
+	return evicted
+}
+
+func (pool *TransactionPool) updateGauges() {
+	txPoolSizeGauge.Update(int64(len(pool.all)))
+	txPoolQueuedGauge.Update(int64(len(pool.all) - pool.cache.Len()))
+}
+
+// replace drops the old tx replaced by the new one of the same nonce, and notifies the subscribers.
+func (pool *TransactionPool) replace(old, tx *Transaction) {
+	pool.detach(old)
+	replacedTxCounter.Inc(1)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"old": old,
+		"new": tx,
+	}).Info("Replaced a tx in tx pool.")
+
+	if pool.bc == nil || pool.bc.eventEmitter == nil {
+		return
+	}
+	data, err := json.Marshal(map[string]string{
+		"old": old.hash.String(),
+		"new": tx.hash.String(),
+	})
+	if err != nil {
+		return
+	}
+	pool.bc.eventEmitter.Trigger(&Event{
+		Topic: TopicTransactionReplaced,
+		Data:  string(data),
+	})
+}
+
+// detach removes the tx from cache or queue, and forgets it.
+func (pool *TransactionPool) detach(tx *Transaction) {
+	if queued := pool.queued[tx.from.address.Hex()]; queued != nil && queued[tx.nonce] == tx {
+		pool.dequeue(tx)
+	} else {
+		pool.cache.RemoveIf(func(ele interface{}) bool { return ele.(*Transaction) == tx })
+	}
+	pool.remove(tx)
+}
+
+// remove forgets the tx removed from cache or queue.
+func (pool *TransactionPool) remove(tx *Transaction) {
+	delete(pool.all, tx.hash.Hex())
+	delete(pool.received, tx.hash.Hex())
+	sender := tx.from.address.Hex()
+	if pool.nonces[sender][tx.nonce] == tx {
+		delete(pool.nonces[sender], tx.nonce)
+		if len(pool.nonces[sender]) == 0 {
+			delete(pool.nonces, sender)
+		}
+	}
+	if pool.senders[sender] <= 1 {
+		delete(pool.senders, sender)
+	} else {
+		pool.senders[sender]--
+	}
+	pool.updateGauges()
+}
+
+// expire drops the transactions staying in pool longer than the lifetime,
+// or can't be packed after tail any more.
+func (pool *TransactionPool) expire(now time.Time) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.dropExpired(pool.bc.TailBlock().height+1, now)
+}
+
+func (pool *TransactionPool) dropExpired(height uint64, now time.Time) {
+	expired := func(tx *Transaction) bool {
+		return now.Sub(pool.received[tx.hash.Hex()]) > pool.lifetime || tx.Expired(height, now.Unix())
+	}
+	removed := pool.cache.RemoveIf(func(ele interface{}) bool {
+		return expired(ele.(*Transaction))
+	})
+	removed = append(removed, pool.dequeueIf(expired)...)
+	for _, v := range removed {
+		pool.remove(v.(*Transaction))
+	}
+	if len(removed) > 0 {
+		expiredTxCounter.Inc(int64(len(removed)))
+		logging.VLog().WithFields(logrus.Fields{
+			"expired": len(removed),
+			"size":    pool.cache.Len(),
+		}).Info("Dropped the expired txs from tx pool.")
+	}
+}
+
 // Pop a transaction from pool
 func (pool *TransactionPool) Pop() *Transaction {
 	pool.mu.Lock()
@@ -255,15 +614,22 @@ func (pool *TransactionPool) Pop() *Transaction {
 func (pool *TransactionPool) pop() *Transaction {
 	if pool.cache.Len() > 0 {
 		tx := pool.cache.PopMin().(*Transaction)
-		delete(pool.all, tx.hash.Hex())
+		pool.remove(tx)
 		return tx
 	}
 	return nil
 }
 
-// Empty return if the pool is empty
+// Empty return if the pool has no pending tx
 func (pool *TransactionPool) Empty() bool {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 	return pool.cache.Len() == 0
 }
+
+// GetTransaction returns the transaction of hash in pool, nil if not found.
+func (pool *TransactionPool) GetTransaction(hash byteutils.Hash) *Transaction {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return pool.all[hash.Hex()]
+}
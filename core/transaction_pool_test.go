@@ -19,10 +19,12 @@
 package core
 
 import (
+	"encoding/json"
 	"testing"
 
 	"time"
 
+	"github.com/nebulasio/go-nebulas/core/pbjson"
 	"github.com/nebulasio/go-nebulas/crypto"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
 	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
@@ -30,6 +32,16 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// fundAccounts credits the accounts on tail, for their txs admitted into pool.
+func fundAccounts(bc *BlockChain, addrs ...*Address) {
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(100000000).Int))
+	bc.tailBlock.begin()
+	for _, addr := range addrs {
+		bc.tailBlock.accState.GetOrCreateUserAccount(addr.Bytes()).AddBalance(balance)
+	}
+	bc.tailBlock.commit()
+}
+
 func TestTransactionPool(t *testing.T) {
 	ks := keystore.DefaultKS
 	priv1 := secp256k1.GeneratePrivateKey()
@@ -54,6 +66,7 @@ func TestTransactionPool(t *testing.T) {
 	txPool, _ := NewTransactionPool(3)
 	bc, _ := NewBlockChain(testNeb())
 	txPool.setBlockChain(bc)
+	fundAccounts(bc, from, other)
 
 	txs := []*Transaction{
 		NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 10, TxPayloadBinaryType, []byte("datadata"), TransactionGasPrice, util.NewUint128FromInt(200000)),
@@ -63,8 +76,8 @@ func TestTransactionPool(t *testing.T) {
 		NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 2, TxPayloadBinaryType, []byte("da"), TransactionGasPrice, util.NewUint128FromInt(200000)),
 		NewTransaction(bc.ChainID()+1, from, &Address{[]byte("to")}, util.NewUint128(), 0, TxPayloadBinaryType, []byte("da"), TransactionGasPrice, util.NewUint128FromInt(200000)),
 
-		NewTransaction(bc.ChainID(), other, &Address{[]byte("to")}, util.NewUint128(), 1, TxPayloadBinaryType, []byte("data"), TransactionGasPrice, util.NewUint128FromInt(200000)),
-		NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 1, TxPayloadBinaryType, []byte("datadata"), heighPrice, util.NewUint128FromInt(200000)),
+		NewTransaction(bc.ChainID(), other, &Address{[]byte("to")}, util.NewUint128(), 1, TxPayloadBinaryType, []byte("data"), TransactionGasPrice, util.NewUint128FromInt(100000)),
+		NewTransaction(bc.ChainID(), other, &Address{[]byte("to")}, util.NewUint128(), 2, TxPayloadBinaryType, []byte("datadata"), heighPrice, util.NewUint128FromInt(300000)),
 	}
 
 	assert.Nil(t, txs[0].Sign(signature1))
@@ -80,18 +93,21 @@ func TestTransactionPool(t *testing.T) {
 	// put tx with different chainID, should fail
 	assert.Nil(t, txs[4].Sign(signature1))
 	assert.NotNil(t, txPool.Push(txs[4]))
-	// put one new, replace txs[1]
+	// txs[0] of a future nonce is queued.
 	assert.Equal(t, len(txPool.all), 3)
-	assert.Equal(t, txPool.cache.Len(), 3)
-	assert.Nil(t, txs[6].Sign(signature1))
+	assert.Equal(t, txPool.cache.Len(), 2)
+	assert.Equal(t, txPool.QueuedCount(), 1)
+	// put one new, drop txs[0] queued
+	assert.Nil(t, txs[6].Sign(signature2))
 	assert.Nil(t, txPool.Push(txs[6]))
 	assert.Equal(t, txPool.cache.Len(), 3)
 	assert.Equal(t, len(txPool.all), 3)
-	// get from: other, nonce: 1, data: "da"
+	assert.Nil(t, txPool.GetTransaction(txs[0].hash))
+	// get the highest price first, from: other, nonce: 1, data: "datadata"
 	tx1 := txPool.Pop()
-	assert.Equal(t, txs[2].from.address, tx1.from.address)
-	assert.Equal(t, txs[2].nonce, tx1.nonce)
-	assert.Equal(t, txs[2].data, tx1.data)
+	assert.Equal(t, txs[1].from.address, tx1.from.address)
+	assert.Equal(t, txs[1].nonce, tx1.nonce)
+	assert.Equal(t, txs[1].data, tx1.data)
 	// put one new
 	assert.Equal(t, len(txPool.all), 2)
 	assert.Equal(t, txPool.cache.Len(), 2)
@@ -99,7 +115,7 @@ func TestTransactionPool(t *testing.T) {
 	assert.Nil(t, txPool.Push(txs[5]))
 	assert.Equal(t, len(txPool.all), 3)
 	assert.Equal(t, txPool.cache.Len(), 3)
-	// get 2 txs, txs[5], txs[0]
+	// get 2 txs, txs[5] before txs[6] of the same sender, txs[6] before txs[2] of a lower price
 	tx21 := txPool.Pop()
 	tx22 := txPool.Pop()
 	assert.Equal(t, txs[5].from.address, tx21.from.address)
@@ -155,3 +171,355 @@ func TestPushTxs(t *testing.T) {
 	assert.Equal(t, txPool.push(txs[0]), ErrBelowGasPrice)
 	assert.Equal(t, txPool.push(txs[1]), ErrOutOfGasLimit)
 }
+
+func TestTransactionPool_Admission(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	newTx := func(value *util.Uint128, data []byte) *Transaction {
+		tx := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, value, 1, TxPayloadBinaryType, data, TransactionGasPrice, util.NewUint128FromInt(200000))
+		assert.Nil(t, tx.Sign(signature))
+		return tx
+	}
+
+	// the balance doesn't cover the fees.
+	tx := newTx(util.NewUint128(), nil)
+	assert.Equal(t, ErrInsufficientBalance, txPool.Push(tx))
+	bc.tailBlock.begin()
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(tx.MinBalanceRequired())
+	bc.tailBlock.commit()
+
+	// the balance doesn't cover the value and fees.
+	assert.Equal(t, ErrInsufficientBalance, txPool.Push(newTx(util.NewUint128FromInt(1), nil)))
+	assert.Equal(t, ErrTxDataPayloadTooLarge, txPool.Push(newTx(util.NewUint128(), make([]byte, MaxTxDataPayloadLength+1))))
+
+	// the signature verified at admission isn't recovered again.
+	assert.False(t, tx.signVerifiedBefore())
+	assert.Nil(t, txPool.Push(tx))
+	assert.True(t, tx.signVerifiedBefore())
+	assert.Equal(t, 1, len(txPool.all))
+}
+
+func TestTransactionPool_ValidUntil(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+	height := bc.TailBlock().Height()
+	now := time.Now()
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	fundAccounts(bc, from)
+	newTx := func(nonce, validUntil uint64) *Transaction {
+		tx := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), nonce, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.SetValidUntil(validUntil)
+		assert.Nil(t, tx.Sign(signature))
+		return tx
+	}
+
+	// the hash covers the valid until only if set.
+	tx := newTx(1, 0)
+	hash := tx.Hash()
+	tx.SetValidUntil(height + 1)
+	tx.hash, _ = HashTransaction(tx)
+	assert.NotEqual(t, hash, tx.Hash())
+
+	// the valid until is a height below the threshold, otherwise a timestamp.
+	assert.False(t, tx.Expired(height+1, 0))
+	assert.True(t, tx.Expired(height+2, 0))
+	tx.SetValidUntil(uint64(now.Unix()))
+	assert.False(t, tx.Expired(ValidUntilHeightThreshold, now.Unix()))
+	assert.True(t, tx.Expired(0, now.Unix()+1))
+
+	// the txs can't be packed after tail are refused.
+	assert.Equal(t, ErrTransactionExpired, txPool.Push(newTx(1, height)))
+	assert.Equal(t, ErrTransactionExpired, txPool.Push(newTx(1, uint64(now.Unix()-1))))
+	byHeight := newTx(1, height+1)
+	byTime := newTx(2, uint64(now.Unix()+60))
+	assert.Nil(t, txPool.Push(byHeight))
+	assert.Nil(t, txPool.Push(byTime))
+	assert.Nil(t, txPool.Push(newTx(3, 0)))
+
+	// the txs expired after tail are dropped.
+	txPool.mu.Lock()
+	txPool.dropExpired(height+2, now)
+	txPool.mu.Unlock()
+	assert.Nil(t, txPool.GetTransaction(byHeight.Hash()))
+	assert.Equal(t, 2, len(txPool.all))
+	txPool.expire(now.Add(time.Minute + time.Second))
+	assert.Nil(t, txPool.GetTransaction(byTime.Hash()))
+	assert.Equal(t, 1, len(txPool.all))
+}
+
+func TestTransactionPool_Eviction(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool, _ := NewTransactionPool(3)
+	txPool.setBlockChain(bc)
+	txPool.SetEvictionConfig(0, 2, time.Minute)
+	assert.Equal(t, 3, txPool.size)
+	assert.Equal(t, 2, txPool.senderLimit)
+
+	signer := func() (*Address, keystore.Signature) {
+		priv := secp256k1.GeneratePrivateKey()
+		pubdata, _ := priv.PublicKey().Encoded()
+		addr, _ := NewAddressFromPublicKey(pubdata)
+		signature, _ := crypto.NewSignature(keystore.SECP256K1)
+		signature.InitSign(priv)
+		return addr, signature
+	}
+	newTx := func(from *Address, signature keystore.Signature, nonce uint64, price int64) *Transaction {
+		gasPrice := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(price).Int))
+		tx := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), nonce, TxPayloadBinaryType, []byte("data"), gasPrice, util.NewUint128FromInt(200000))
+		assert.Nil(t, tx.Sign(signature))
+		return tx
+	}
+	from1, sign1 := signer()
+	from2, sign2 := signer()
+	from3, sign3 := signer()
+	fundAccounts(bc, from1, from2, from3)
+
+	// at most 2 txs of a sender.
+	expired := newTx(from1, sign1, 1, 2)
+	assert.Nil(t, txPool.Push(expired))
+	assert.Nil(t, txPool.Push(newTx(from1, sign1, 2, 2)))
+	assert.Equal(t, ErrTooManySenderTransactions, txPool.Push(newTx(from1, sign1, 3, 2)))
+	cheap := newTx(from2, sign2, 1, 1)
+	assert.Nil(t, txPool.Push(cheap))
+
+	// the cheapest is evicted if full, and a tx cheaper than all is refused.
+	assert.Equal(t, ErrTxPoolFull, txPool.Push(newTx(from3, sign3, 1, 1)))
+	assert.Equal(t, 3, txPool.cache.Len())
+	assert.Nil(t, txPool.Push(newTx(from3, sign3, 1, 3)))
+	assert.Equal(t, 3, txPool.cache.Len())
+	assert.Nil(t, txPool.GetTransaction(cheap.Hash()))
+	assert.Equal(t, 0, txPool.senders[from2.address.Hex()])
+
+	// the txs staying longer than lifetime are expired.
+	txPool.received[expired.Hash().Hex()] = time.Now().Add(-time.Hour)
+	txPool.expire(time.Now())
+	assert.Equal(t, 2, txPool.cache.Len())
+	assert.Equal(t, 2, len(txPool.all))
+	assert.Equal(t, 1, txPool.senders[from1.address.Hex()])
+	txPool.expire(time.Now().Add(time.Hour))
+	assert.True(t, txPool.Empty())
+	assert.Equal(t, 0, len(txPool.senders))
+	assert.Equal(t, 0, len(txPool.received))
+
+	// the pool shrinks to the size configured.
+	assert.Nil(t, txPool.Push(newTx(from1, sign1, 1, 1)))
+	assert.Nil(t, txPool.Push(newTx(from2, sign2, 1, 2)))
+	txPool.SetEvictionConfig(1, 0, 0)
+	assert.Equal(t, 1, txPool.cache.Len())
+	assert.Equal(t, from2.address, txPool.Pop().from.address)
+}
+
+func TestTransactionPool_NonceGap(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+
+	signer := func() (*Address, keystore.Signature) {
+		priv := secp256k1.GeneratePrivateKey()
+		pubdata, _ := priv.PublicKey().Encoded()
+		addr, _ := NewAddressFromPublicKey(pubdata)
+		signature, _ := crypto.NewSignature(keystore.SECP256K1)
+		signature.InitSign(priv)
+		return addr, signature
+	}
+	newTx := func(from *Address, signature keystore.Signature, nonce uint64) *Transaction {
+		tx := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), nonce, TxPayloadBinaryType, []byte("data"), TransactionGasPrice, util.NewUint128FromInt(200000))
+		assert.Nil(t, tx.Sign(signature))
+		return tx
+	}
+	from, sign := signer()
+	fundAccounts(bc, from)
+
+	// the txs of future nonces are queued until the gap is filled.
+	assert.Nil(t, txPool.Push(newTx(from, sign, 3)))
+	assert.Nil(t, txPool.Push(newTx(from, sign, 2)))
+	other := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 2, TxPayloadBinaryType, []byte("other"), TransactionGasPrice, util.NewUint128FromInt(200000))
+	assert.Nil(t, other.Sign(sign))
+	assert.Equal(t, ErrUnderpricedReplacement, txPool.Push(other))
+	assert.Equal(t, 2, txPool.QueuedCount())
+	assert.True(t, txPool.Empty())
+	assert.Nil(t, txPool.Push(newTx(from, sign, 1)))
+	assert.Equal(t, 0, txPool.QueuedCount())
+	assert.Equal(t, 3, txPool.PendingCount())
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		assert.Equal(t, nonce, txPool.Pop().Nonce())
+	}
+
+	// the txs after the popped ones are pending.
+	tx4 := newTx(from, sign, 4)
+	assert.Nil(t, txPool.Push(tx4))
+	assert.Equal(t, 1, txPool.PendingCount())
+
+	// a tx requeued for the gap before it waits for the tail.
+	assert.Equal(t, tx4, txPool.Pop())
+	assert.Nil(t, txPool.Requeue(tx4))
+	assert.Equal(t, 1, txPool.QueuedCount())
+	assert.True(t, txPool.Empty())
+
+	tail := bc.tailBlock
+	tail.begin()
+	acc := tail.accState.GetOrCreateUserAccount(from.Bytes())
+	for i := 0; i < 3; i++ {
+		acc.IncrNonce()
+	}
+	tail.commit()
+	txPool.onNewTail(tail)
+	assert.Equal(t, 0, txPool.QueuedCount())
+	assert.Equal(t, tx4, txPool.Pop())
+
+	// the queued txs included by the tail are dropped.
+	assert.Nil(t, txPool.Push(newTx(from, sign, 6)))
+	tail.begin()
+	acc = tail.accState.GetOrCreateUserAccount(from.Bytes())
+	for i := 0; i < 3; i++ {
+		acc.IncrNonce()
+	}
+	tail.commit()
+	txPool.onNewTail(tail)
+	assert.Equal(t, 0, txPool.QueuedCount())
+	assert.Nil(t, txPool.Pop())
+	assert.Equal(t, 0, len(txPool.all))
+}
+
+func TestTransactionPool_Replace(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+	txPool.SetPriceBump(50)
+	eventCh := make(chan *Event, 1)
+	bc.eventEmitter.Register(TopicTransactionReplaced, eventCh)
+	bc.eventEmitter.Start()
+	defer bc.eventEmitter.Stop()
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	fundAccounts(bc, from)
+	value := int64(0)
+	newTx := func(nonce uint64, price int64) *Transaction {
+		value++
+		gasPrice := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(price).Int))
+		tx := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128FromInt(value), nonce, TxPayloadBinaryType, []byte("data"), gasPrice, util.NewUint128FromInt(200000))
+		assert.Nil(t, tx.Sign(signature))
+		return tx
+	}
+
+	// the pending tx is replaced by one of a price raised enough.
+	old := newTx(1, 2)
+	assert.Nil(t, txPool.Push(old))
+	assert.Equal(t, ErrUnderpricedReplacement, txPool.Push(newTx(1, 2)))
+	assert.Equal(t, ErrUnderpricedReplacement, txPool.Push(newTx(1, 1)))
+	replacement := newTx(1, 3)
+	assert.Nil(t, txPool.Push(replacement))
+	assert.Nil(t, txPool.GetTransaction(old.Hash()))
+	assert.Equal(t, 1, txPool.PendingCount())
+	assert.Equal(t, 1, len(txPool.all))
+
+	event := <-eventCh
+	data := make(map[string]string)
+	assert.Nil(t, json.Unmarshal([]byte(event.Data), &data))
+	assert.Equal(t, old.Hash().String(), data["old"])
+	assert.Equal(t, replacement.Hash().String(), data["new"])
+
+	// so is the queued one.
+	queued := newTx(3, 2)
+	assert.Nil(t, txPool.Push(queued))
+	assert.Nil(t, txPool.Push(newTx(3, 4)))
+	assert.Nil(t, txPool.GetTransaction(queued.Hash()))
+	assert.Equal(t, 1, txPool.QueuedCount())
+	<-eventCh
+
+	assert.Equal(t, replacement, txPool.Pop())
+	assert.Nil(t, txPool.Pop())
+}
+
+func TestTransactionPool_Pending(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+	eventCh := make(chan *Event, 4)
+	bc.eventEmitter.Register(TopicPendingTransaction, eventCh)
+	bc.eventEmitter.Start()
+	defer bc.eventEmitter.Stop()
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	fundAccounts(bc, from)
+	newTx := func(nonce uint64) *Transaction {
+		tx := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), nonce, TxPayloadBinaryType, []byte("data"), TransactionGasPrice, util.NewUint128FromInt(200000))
+		assert.Nil(t, tx.Sign(signature))
+		return tx
+	}
+
+	tx3 := newTx(3)
+	tx1 := newTx(1)
+	assert.Nil(t, txPool.Push(tx3))
+	assert.Nil(t, txPool.Push(tx1))
+	assert.Equal(t, []*Transaction{tx1, tx3}, txPool.Pending(from))
+	assert.Equal(t, 0, len(txPool.Pending(&Address{[]byte("to")})))
+
+	pending, queued := txPool.Content()
+	assert.Equal(t, []*Transaction{tx1}, pending[from.address.Hex()])
+	assert.Equal(t, []*Transaction{tx3}, queued[from.address.Hex()])
+
+	// the txs admitted are notified in JSON.
+	for _, tx := range []*Transaction{tx3, tx1} {
+		event := <-eventCh
+		pbTx, err := pbjson.UnmarshalTransaction([]byte(event.Data))
+		assert.Nil(t, err)
+		notified := new(Transaction)
+		assert.Nil(t, notified.FromProto(pbTx))
+		assert.Equal(t, tx.Hash(), notified.Hash())
+		assert.Equal(t, tx.Nonce(), notified.Nonce())
+	}
+}
+
+func TestTransactionPool_InspectAndDrop(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	txPool := bc.txPool
+	txs := mockSignedTransactions(bc.ChainID(), 4)
+	other := mockSignedTransactions(bc.ChainID(), 1)[0]
+	fundAccounts(bc, txs[0].from, other.from)
+	for _, tx := range []*Transaction{txs[0], txs[1], txs[3], other} {
+		assert.Nil(t, txPool.Push(tx))
+	}
+
+	summaries := txPool.Inspect()
+	assert.Equal(t, 2, len(summaries))
+	summary := summaries[0]
+	if !summary.Address.Equals(txs[0].from) {
+		summary = summaries[1]
+	}
+	assert.Equal(t, 3, summary.Count)
+	assert.Equal(t, 1, summary.Queued)
+	assert.Equal(t, uint64(1), summary.MinNonce)
+	assert.Equal(t, uint64(4), summary.MaxNonce)
+	assert.Equal(t, "3", summary.TotalValue.String())
+	fees := util.NewUint128().Mul(txs[0].MinBalanceRequired().Int, util.NewUint128FromInt(3).Int)
+	assert.Equal(t, fees.String(), summary.TotalFees.String())
+
+	// the pending and queued txs are dropped.
+	assert.Nil(t, txPool.Drop(txs[1].Hash()))
+	assert.Nil(t, txPool.Drop(txs[3].Hash()))
+	assert.Equal(t, ErrTransactionNotInPool, txPool.Drop(txs[3].Hash()))
+	assert.Equal(t, 2, txPool.PendingCount())
+	assert.Equal(t, 0, txPool.QueuedCount())
+	assert.Equal(t, []*Transaction{txs[0]}, txPool.Pending(txs[0].from))
+
+	// the nonce dropped is admitted again.
+	assert.Nil(t, txPool.Push(txs[1]))
+	assert.Equal(t, 3, txPool.PendingCount())
+}
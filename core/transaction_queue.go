@@ -0,0 +1,209 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// tailNonce returns the nonce of the sender in the tail block.
+func (pool *TransactionPool) tailNonce(from *Address) uint64 {
+	if pool.bc == nil || pool.bc.TailBlock() == nil {
+		return 0
+	}
+	return pool.bc.TailBlock().GetNonce(from.address)
+}
+
+// nextNonce returns the nonce of the next tx of the sender executable after the tail and the pending txs.
+func (pool *TransactionPool) nextNonce(from *Address) uint64 {
+	nonce := pool.tailNonce(from)
+	if pending := pool.pendingNonce[from.address.Hex()]; pending > nonce {
+		nonce = pending
+	}
+	return nonce + 1
+}
+
+func (pool *TransactionPool) enqueue(tx *Transaction) {
+	sender := tx.from.address.Hex()
+	if pool.queued[sender] == nil {
+		pool.queued[sender] = make(map[uint64]*Transaction)
+	}
+	pool.queued[sender][tx.nonce] = tx
+}
+
+func (pool *TransactionPool) dequeue(tx *Transaction) {
+	sender := tx.from.address.Hex()
+	delete(pool.queued[sender], tx.nonce)
+	if len(pool.queued[sender]) == 0 {
+		delete(pool.queued, sender)
+	}
+}
+
+// dequeueIf removes the queued txs matching remove, and returns them.
+func (pool *TransactionPool) dequeueIf(remove func(tx *Transaction) bool) []interface{} {
+	var removed []interface{}
+	for _, txs := range pool.queued {
+		for _, tx := range txs {
+			if remove(tx) {
+				pool.dequeue(tx)
+				removed = append(removed, tx)
+			}
+		}
+	}
+	return removed
+}
+
+// promote moves the queued txs of the sender following the pending ones into cache.
+func (pool *TransactionPool) promote(from *Address) {
+	sender := from.address.Hex()
+	for {
+		next := pool.nextNonce(from)
+		tx := pool.queued[sender][next]
+		if tx == nil {
+			return
+		}
+		pool.dequeue(tx)
+		pool.cache.Insert(tx)
+		pool.pendingNonce[sender] = next
+
+		logging.VLog().WithFields(logrus.Fields{
+			"tx": tx,
+		}).Debug("Promoted a queued tx to pending.")
+	}
+}
+
+// lastQueued returns the queued tx of the largest nonce of a sender, the one of the lowest price among senders.
+func (pool *TransactionPool) lastQueued() *Transaction {
+	var last *Transaction
+	for _, txs := range pool.queued {
+		var tail *Transaction
+		for _, tx := range txs {
+			if tail == nil || tx.nonce > tail.nonce {
+				tail = tx
+			}
+		}
+		if last == nil || tail.gasPrice.Cmp(last.gasPrice.Int) < 0 {
+			last = tail
+		}
+	}
+	return last
+}
+
+// Requeue puts back a tx popped but not executable for the nonce gap before it. Instead of popped again
+// for next block, it waits in queue until the gap is filled by a new tx or the tail.
+func (pool *TransactionPool) Requeue(tx *Transaction) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if err := pool.admit(tx); err != nil {
+		return err
+	}
+	// the pending txs before the gap are packed or failed, the next executable follows the tail.
+	sender := tx.from.address.Hex()
+	if pool.pendingNonce[sender] >= tx.nonce {
+		delete(pool.pendingNonce, sender)
+	}
+	return pool.insert(tx)
+}
+
+// onNewTail drops the queued txs included by the new tail, and promotes the ones following its nonces.
+// The pending nonces of senders no longer ahead of the tail are forgotten, so are the reserved ones,
+// and the txs can't be packed after the tail are expired.
+func (pool *TransactionPool) onNewTail(tail *Block) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	for sender, pending := range pool.pendingNonce {
+		addr, err := sender.Hash()
+		if err != nil || tail.GetNonce(addr) >= pending {
+			delete(pool.pendingNonce, sender)
+		}
+	}
+	for _, txs := range pool.queued {
+		var from *Address
+		for _, tx := range txs {
+			from = tx.from
+			break
+		}
+		nonce := tail.GetNonce(from.address)
+		for _, tx := range txs {
+			if tx.nonce <= nonce {
+				pool.dequeue(tx)
+				pool.remove(tx)
+			}
+		}
+		pool.promote(from)
+	}
+	pool.dropReservations(tail, time.Now())
+	pool.dropExpired(tail.height+1, time.Now())
+}
+
+// PendingCount returns the count of the txs executable in pool.
+func (pool *TransactionPool) PendingCount() int {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return pool.cache.Len()
+}
+
+// QueuedCount returns the count of the txs waiting for the nonce gaps before them in pool.
+func (pool *TransactionPool) QueuedCount() int {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return len(pool.all) - pool.cache.Len()
+}
+
+// Pending returns the txs sent by the address in pool, both executable and queued, in order of nonce.
+func (pool *TransactionPool) Pending(addr *Address) []*Transaction {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return sortByNonce(pool.nonces[addr.address.Hex()])
+}
+
+// Content returns the executable txs and the queued txs in pool by sender, each in order of nonce.
+func (pool *TransactionPool) Content() (pending, queued map[byteutils.HexHash][]*Transaction) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	pending = make(map[byteutils.HexHash][]*Transaction)
+	queued = make(map[byteutils.HexHash][]*Transaction)
+	for sender, txs := range pool.nonces {
+		for _, tx := range sortByNonce(txs) {
+			if pool.queued[sender][tx.nonce] == tx {
+				queued[sender] = append(queued[sender], tx)
+			} else {
+				pending[sender] = append(pending[sender], tx)
+			}
+		}
+	}
+	return pending, queued
+}
+
+func sortByNonce(txs map[uint64]*Transaction) []*Transaction {
+	sorted := make([]*Transaction, 0, len(txs))
+	for _, tx := range txs {
+		sorted = append(sorted, tx)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].nonce < sorted[j].nonce })
+	return sorted
+}
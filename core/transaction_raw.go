@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+)
+
+// MaxRawTransactionSize is the largest serialized transaction accepted by SendRawTransaction.
+const MaxRawTransactionSize = 128 * 1024
+
+var (
+	// ErrOfflineSignerMismatch the private key is not of the sender of the transaction.
+	ErrOfflineSignerMismatch = errors.New("private key is not of the transaction sender")
+
+	// ErrRawTransactionTooLarge the serialized transaction is larger than MaxRawTransactionSize.
+	ErrRawTransactionTooLarge = errors.New("raw transaction too large")
+)
+
+// SignTransactionOffline signs the transaction by the private key of its sender without keystore or
+// node, and returns it serialized for SendRawTransaction, to sign on an air-gapped machine.
+func SignTransactionOffline(tx *Transaction, key keystore.PrivateKey) ([]byte, error) {
+	pub, err := key.PublicKey().Encoded()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := NewAddressFromPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	if !tx.from.Equals(addr) {
+		return nil, ErrOfflineSignerMismatch
+	}
+	signature, err := crypto.NewSignature(key.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+	signature.InitSign(key)
+	if err := tx.Sign(signature); err != nil {
+		return nil, err
+	}
+	return SerializeTransaction(tx)
+}
+
+// SerializeTransaction returns the transaction in the bytes of its proto.
+func SerializeTransaction(tx *Transaction) ([]byte, error) {
+	pbTx, err := tx.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pbTx)
+}
+
+// DeserializeTransaction returns the transaction of the bytes of its proto.
+func DeserializeTransaction(data []byte) (*Transaction, error) {
+	if len(data) > MaxRawTransactionSize {
+		return nil, ErrRawTransactionTooLarge
+	}
+	pbTx := new(corepb.Transaction)
+	if err := proto.Unmarshal(data, pbTx); err != nil {
+		return nil, err
+	}
+	tx := new(Transaction)
+	if err := tx.FromProto(pbTx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SendRawTransaction deserializes a transaction signed offline, validates it and pushes it into
+// pool to be broadcast.
+func (pool *TransactionPool) SendRawTransaction(data []byte) (*Transaction, error) {
+	tx, err := DeserializeTransaction(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.PushAndBroadcast(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionPool_SendRawTransaction(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var n MockNetManager
+	bc.TransactionPool().RegisterInNetwork(n)
+	priv := secp256k1.GeneratePrivateKey()
+	pub, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pub)
+	fundAccounts(bc, from)
+
+	// signed offline by the key only.
+	tx := NewTransaction(bc.ChainID(), from, mockAddress(), util.NewUint128FromInt(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, MinGasCountPerTransaction)
+	data, err := SignTransactionOffline(tx, priv)
+	assert.Nil(t, err)
+
+	other := NewTransaction(bc.ChainID(), mockAddress(), from, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, MinGasCountPerTransaction)
+	_, err = SignTransactionOffline(other, priv)
+	assert.Equal(t, ErrOfflineSignerMismatch, err)
+
+	// a tampered one is refused.
+	tampered, err := DeserializeTransaction(data)
+	assert.Nil(t, err)
+	tampered.value = util.NewUint128FromInt(2)
+	raw, err := SerializeTransaction(tampered)
+	assert.Nil(t, err)
+	_, err = bc.TransactionPool().SendRawTransaction(raw)
+	assert.NotNil(t, err)
+	_, err = bc.TransactionPool().SendRawTransaction(make([]byte, MaxRawTransactionSize+1))
+	assert.Equal(t, ErrRawTransactionTooLarge, err)
+
+	sent, err := bc.TransactionPool().SendRawTransaction(data)
+	assert.Nil(t, err)
+	assert.Equal(t, tx.Hash(), sent.Hash())
+	assert.NotNil(t, bc.TransactionPool().GetTransaction(tx.Hash()))
+}
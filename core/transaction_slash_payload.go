@@ -0,0 +1,159 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Slashing penalty
+const (
+	// SlashPenaltyPercent is the percent of the balance of a double signing validator taken as its bond,
+	// at least the MinSlashBond of the dpos params and at most the whole balance.
+	SlashPenaltyPercent = 10
+	// SlashRewardPercent is the percent of the bond rewarded to the reporter, the rest is burnt.
+	SlashRewardPercent = 50
+)
+
+// SlashPayload carry the evidence of a validator double signed, the marshaled DoubleSignEvidence.
+// The validator is removed from the dynasty and candidates, and its bond is burnt and rewarded
+// to the reporter. The evidence is accepted in the dynasty of the slot only.
+type SlashPayload struct {
+	Evidence []byte
+}
+
+// LoadSlashPayload from bytes
+func LoadSlashPayload(bytes []byte) (*SlashPayload, error) {
+	payload := &SlashPayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// NewSlashPayload with the evidence
+func NewSlashPayload(evidence *corepb.DoubleSignEvidence) (*SlashPayload, error) {
+	bytes, err := proto.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+	return &SlashPayload{Evidence: bytes}, nil
+}
+
+// ToBytes serialize payload
+func (payload *SlashPayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *SlashPayload) BaseGasCount() *util.Uint128 {
+	return SlashBaseGasCount
+}
+
+// Execute the slash payload in tx
+func (payload *SlashPayload) Execute(ctx *PayloadContext) (*util.Uint128, error) {
+	evidence := new(corepb.DoubleSignEvidence)
+	if err := proto.Unmarshal(payload.Evidence, evidence); err != nil {
+		return ZeroGasCount, ErrInvalidDoubleSignEvidence
+	}
+	offender, err := VerifyDoubleSignEvidence(ctx.tx.chainID, evidence)
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	slot := evidence.First.Header.Timestamp
+	slotDynastyID, err := ctx.block.DynastyID(slot)
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	dynastyID, err := ctx.block.DynastyID(ctx.block.Timestamp())
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	if slotDynastyID != dynastyID {
+		return ZeroGasCount, ErrStaleDoubleSignEvidence
+	}
+	validator := offender.Bytes()
+	if _, err := ctx.dposContext.dynastyTrie.Get(validator); err != nil {
+		if err == storage.ErrKeyNotFound {
+			return ZeroGasCount, ErrNotDynastyValidator
+		}
+		return ZeroGasCount, err
+	}
+
+	// remove the validator from the dynasty, the next dynasty and the candidates.
+	if _, err := ctx.dposContext.dynastyTrie.Del(validator); err != nil {
+		return ZeroGasCount, err
+	}
+	if _, err := ctx.dposContext.nextDynastyTrie.Del(validator); err != nil && err != storage.ErrKeyNotFound {
+		return ZeroGasCount, err
+	}
+	if err := ctx.dposContext.kickoutCandidate(validator); err != nil {
+		return ZeroGasCount, err
+	}
+
+	acc := ctx.accState.GetOrCreateUserAccount(validator)
+	bond := new(big.Int).Mul(acc.Balance().Int, big.NewInt(SlashPenaltyPercent))
+	bond.Div(bond, big.NewInt(100))
+	if bond.Cmp(ctx.block.DposParams().MinSlashBond.Int) < 0 {
+		bond.Set(ctx.block.DposParams().MinSlashBond.Int)
+	}
+	if bond.Cmp(acc.Balance().Int) > 0 {
+		bond.Set(acc.Balance().Int)
+	}
+	reward := new(big.Int).Mul(bond, big.NewInt(SlashRewardPercent))
+	reward.Div(reward, big.NewInt(100))
+	burnt := new(big.Int).Sub(bond, reward)
+
+	data, err := json.Marshal(map[string]interface{}{
+		"validator": offender.String(),
+		"reporter":  ctx.tx.from.String(),
+		"slot":      slot,
+		"bond":      bond.String(),
+		"reward":    reward.String(),
+		"burnt":     burnt.String(),
+	})
+	if err != nil {
+		return ZeroGasCount, err
+	}
+
+	if err := acc.SubBalance(util.NewUint128FromBigInt(bond)); err != nil {
+		return ZeroGasCount, ErrInsufficientBalance
+	}
+	ctx.accState.GetOrCreateUserAccount(ctx.tx.from.address).AddBalance(util.NewUint128FromBigInt(reward))
+	if err := ctx.block.recordEvent(ctx.tx.hash, &Event{Topic: TopicValidatorSlashed, Data: string(data)}); err != nil {
+		return ZeroGasCount, err
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tx":        ctx.tx,
+		"validator": offender.String(),
+		"slot":      slot,
+		"bond":      bond.String(),
+		"reward":    reward.String(),
+	}).Warn("Slashed a validator double signed.")
+	return ZeroGasCount, nil
+}
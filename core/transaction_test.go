@@ -27,6 +27,7 @@ import (
 	"github.com/nebulasio/go-nebulas/core/pb"
 	"github.com/nebulasio/go-nebulas/crypto"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/stretchr/testify/assert"
@@ -363,3 +364,28 @@ func TestTransaction_VerifyExecution(t *testing.T) {
 	}
 
 }
+
+func TestTransaction_VerifyEd25519(t *testing.T) {
+	priv, err := crypto.NewPrivateKey(keystore.ED25519, nil)
+	assert.Nil(t, err)
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.ED25519)
+	signature.InitSign(priv)
+
+	tx := NewTransaction(100, from, mockAddress(), util.NewUint128FromInt(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, tx.Sign(signature))
+	assert.Equal(t, uint8(keystore.ED25519), tx.alg)
+	assert.Nil(t, tx.VerifyIntegrity(100))
+
+	pbTx, err := tx.ToProto()
+	assert.Nil(t, err)
+	assert.Nil(t, validTransactionMessage(pbTx.(*corepb.Transaction), 100))
+
+	// the signature is not taken as one of secp256k1.
+	pbTx.(*corepb.Transaction).Alg = uint32(keystore.SECP256K1)
+	assert.Equal(t, ErrInvalidSignatureShape, validTransactionMessage(pbTx.(*corepb.Transaction), 100))
+	tx.alg = uint8(keystore.SECP256K1)
+	tx.sign = tx.sign[:secp256k1.SignatureLength]
+	assert.NotNil(t, tx.verifySign())
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// the key of the code record in the storage of an upgraded contract, hashed in three domains as the keys of
+// the trie are of the same length, and the keys of contracts are in two.
+var contractCodeKey = trie.HashDomains("nebulas", "contract", "code")
+
+// UpgradePayload replaces the code of a contract deployed upgradable, sent to the contract by its owner,
+// the sender of the deploy tx. The storage of contract is kept across the versions.
+type UpgradePayload struct {
+	SourceType string
+	Source     string
+
+	// Metadata replaces the metadata of the contract if not empty.
+	Metadata string `json:",omitempty"`
+}
+
+// ContractCode is the version of the code of a contract and the hash of the upgrade tx carrying it,
+// the version of the deployed code is 0.
+type ContractCode struct {
+	Version uint64
+	TxHash  byteutils.Hash
+}
+
+// ContractUpgradedEvent is the data of a TopicContractUpgraded event.
+type ContractUpgradedEvent struct {
+	Contract    string `json:"contract"`
+	Version     uint64 `json:"version"`
+	OldCodeHash string `json:"old_code_hash"`
+	NewCodeHash string `json:"new_code_hash"`
+}
+
+// LoadUpgradePayload from bytes
+func LoadUpgradePayload(bytes []byte) (*UpgradePayload, error) {
+	payload := &UpgradePayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, err
+	}
+	if len(payload.Source) == 0 || (payload.SourceType != nvm.SourceTypeJavaScript && payload.SourceType != nvm.SourceTypeTypeScript) {
+		return nil, ErrInvalidUpgradePayload
+	}
+	if err := checkContractMetadata(payload.Metadata); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// NewUpgradePayload with source
+func NewUpgradePayload(source, sourceType string) *UpgradePayload {
+	return &UpgradePayload{
+		Source:     source,
+		SourceType: sourceType,
+	}
+}
+
+// ToBytes serialize payload
+func (payload *UpgradePayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *UpgradePayload) BaseGasCount() *util.Uint128 {
+	return util.NewUint128()
+}
+
+// Execute the upgrade payload in tx, replace the code of contract
+func (payload *UpgradePayload) Execute(ctx *PayloadContext) (*util.Uint128, error) {
+	contract, birthTx, current, err := loadContract(ctx.block, ctx.accState, ctx.tx.to.Bytes())
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	deploy, err := LoadDeployPayload(birthTx.data.Payload)
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	if !deploy.Upgradable {
+		return ZeroGasCount, ErrContractNotUpgradable
+	}
+	if !ctx.tx.from.Equals(birthTx.from) {
+		return ZeroGasCount, ErrNotContractOwner
+	}
+
+	code, err := contractCodeOf(contract)
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	code.Version++
+	code.TxHash = ctx.tx.hash
+	bytes, err := json.Marshal(code)
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	if err := contract.Put(contractCodeKey, bytes); err != nil {
+		return ZeroGasCount, err
+	}
+	if len(payload.Metadata) > 0 {
+		if err := setContractMetadata(ctx.accState, ctx.tx.to, payload.Metadata); err != nil {
+			return ZeroGasCount, err
+		}
+	}
+
+	data, err := json.Marshal(&ContractUpgradedEvent{
+		Contract:    ctx.tx.to.String(),
+		Version:     code.Version,
+		OldCodeHash: byteutils.Hex(hash.Sha3256([]byte(current.Source))),
+		NewCodeHash: byteutils.Hex(hash.Sha3256([]byte(payload.Source))),
+	})
+	if err != nil {
+		return ZeroGasCount, err
+	}
+	ctx.recordEvent(&Event{Topic: TopicContractUpgraded, Data: string(data)})
+	return ZeroGasCount, nil
+}
+
+// contractCodeOf returns the code record of contract, of version 0 if never upgraded.
+func contractCodeOf(contract state.Account) (*ContractCode, error) {
+	code := &ContractCode{}
+	bytes, err := contract.Get(contractCodeKey)
+	if err == storage.ErrKeyNotFound {
+		return code, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
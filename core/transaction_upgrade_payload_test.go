@@ -0,0 +1,113 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const upgradeTestSource = `"use strict";var Contract = function () {};Contract.prototype = {init: function () {}};module.exports = Contract;`
+
+func TestLoadUpgradePayload(t *testing.T) {
+	data, _ := NewUpgradePayload(upgradeTestSource, "js").ToBytes()
+	payload, err := LoadUpgradePayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, upgradeTestSource, payload.Source)
+
+	for _, p := range []*UpgradePayload{NewUpgradePayload("", "js"), NewUpgradePayload(upgradeTestSource, "py")} {
+		data, _ := p.ToBytes()
+		_, err := LoadUpgradePayload(data)
+		assert.Equal(t, ErrInvalidUpgradePayload, err)
+	}
+}
+
+func TestUpgradePayload_Execute(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.begin()
+
+	owner, other := mockAddress(), mockAddress()
+	for _, addr := range []*Address{owner, other} {
+		block.accState.GetOrCreateUserAccount(addr.Bytes()).AddBalance(util.NewUint128FromInt(1000000000000))
+	}
+	nonces := make(map[string]uint64)
+	execute := func(from, to *Address, payloadType string, payload []byte) *Transaction {
+		nonces[from.String()]++
+		tx := NewTransaction(bc.ChainID(), from, to, util.NewUint128(), nonces[from.String()], payloadType, payload, TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.hash, _ = HashTransaction(tx)
+		_, err := block.executeTransaction(tx)
+		assert.Nil(t, err)
+		return tx
+	}
+	topics := func(tx *Transaction) []string {
+		events, err := block.FetchEvents(tx.hash)
+		assert.Nil(t, err)
+		var topics []string
+		for _, e := range events {
+			topics = append(topics, e.Topic)
+		}
+		return topics
+	}
+	deploy := func(upgradable bool) *Address {
+		payload := NewDeployPayload(upgradeTestSource, "js", "")
+		payload.Upgradable = upgradable
+		data, _ := payload.ToBytes()
+		tx := execute(owner, owner, TxPayloadDeployType, data)
+		assert.Equal(t, []string{TopicExecuteTxSuccess}, topics(tx))
+		addr, _ := tx.GenerateContractAddress()
+		return addr
+	}
+
+	upgradable, fixed := deploy(true), deploy(false)
+	contract, _ := block.accState.GetContractAccount(upgradable.Bytes())
+	assert.Nil(t, contract.Put(trie.HashDomains("", "kept"), []byte("value")))
+
+	source := upgradeTestSource + "// v1"
+	data, _ := NewUpgradePayload(source, "js").ToBytes()
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics(execute(other, upgradable, TxPayloadUpgradeType, data)))
+	assert.Equal(t, []string{TopicExecuteTxFailed}, topics(execute(owner, fixed, TxPayloadUpgradeType, data)))
+
+	tx := execute(owner, upgradable, TxPayloadUpgradeType, data)
+	assert.Equal(t, []string{TopicContractUpgraded, TopicExecuteTxSuccess}, topics(tx))
+	events, _ := block.FetchEvents(tx.hash)
+	upgraded := new(ContractUpgradedEvent)
+	assert.Nil(t, json.Unmarshal([]byte(events[0].Data), upgraded))
+	assert.Equal(t, &ContractUpgradedEvent{
+		Contract:    upgradable.String(),
+		Version:     1,
+		OldCodeHash: byteutils.Hex(hash.Sha3256([]byte(upgradeTestSource))),
+		NewCodeHash: byteutils.Hex(hash.Sha3256([]byte(source))),
+	}, upgraded)
+
+	// the calls run the code upgraded, on the storage kept.
+	contract, _, code, err := loadContract(block, block.accState, upgradable.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, source, code.Source)
+	value, err := contract.Get(trie.HashDomains("", "kept"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// minParallelVerify is the least count of transactions verified in parallel, fewer ones are verified in place.
+const minParallelVerify = 4
+
+var (
+	// verifiedSigns caches the signatures verified recently by tx hash, the hash covers the signer,
+	// so a tx admitted into pool isn't recovered again in the verification of the block packing it.
+	verifiedSigns, _ = lru.New(40960)
+
+	verifiedSignHitMeter = metrics.GetOrRegisterMeter("neb.tx.sign.verified.hit", nil)
+)
+
+// signVerifiedBefore returns if the same signature of the tx was verified.
+func (tx *Transaction) signVerifiedBefore() bool {
+	v, ok := verifiedSigns.Get(tx.hash.Hex())
+	if !ok || !tx.sign.Equals(v.(byteutils.Hash)) {
+		return false
+	}
+	verifiedSignHitMeter.Mark(1)
+	return true
+}
+
+// markSignVerified records the signature of the tx verified.
+func (tx *Transaction) markSignVerified() {
+	verifiedSigns.Add(tx.hash.Hex(), tx.sign)
+}
+
+// VerifyWorkers is the count of workers verifying the transactions in parallel, the count of CPUs by default.
+var VerifyWorkers = runtime.NumCPU()
+
+// VerifyTransactions verifies the integrity of the transactions in parallel, the recovery of the signers
+// costs the most in the verification of a block or the transactions received.
+// It returns the error of each transaction in order, nil for the valid ones.
+func VerifyTransactions(chainID uint32, txs []*Transaction) []error {
+	errs := make([]error, len(txs))
+	workers := VerifyWorkers
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	if workers <= 1 || len(txs) < minParallelVerify {
+		for i, tx := range txs {
+			errs[i] = tx.VerifyIntegrity(chainID)
+		}
+		return errs
+	}
+
+	next := int64(-1)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(txs) {
+					return
+				}
+				errs[i] = txs[i].VerifyIntegrity(chainID)
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
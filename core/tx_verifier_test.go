@@ -0,0 +1,108 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSignedTransactions(chainID uint32, n int) []*Transaction {
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+
+	var txs []*Transaction
+	for i := 0; i < n; i++ {
+		tx := NewTransaction(chainID, from, mockAddress(), util.NewUint128FromInt(1), uint64(i+1), TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+		tx.Sign(signature)
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func TestVerifyTransactions(t *testing.T) {
+	txs := mockSignedTransactions(100, 16)
+	txs[3].nonce++
+	txs[9].from = mockAddress()
+	txs[11].sign = txs[10].sign
+
+	workers := VerifyWorkers
+	defer func() { VerifyWorkers = workers }()
+	for _, VerifyWorkers = range []int{1, 4} {
+		errs := VerifyTransactions(100, txs)
+		assert.Equal(t, len(txs), len(errs))
+		for i, err := range errs {
+			switch i {
+			case 3:
+				assert.Equal(t, ErrInvalidTransactionHash, err)
+			case 9:
+				assert.Equal(t, ErrInvalidTransactionHash, err)
+			case 11:
+				assert.Equal(t, ErrInvalidTransactionSigner, err)
+			default:
+				assert.Nil(t, err)
+			}
+		}
+		errs = VerifyTransactions(101, txs[:2])
+		assert.Equal(t, ErrInvalidChainID, errs[0])
+		assert.Equal(t, ErrInvalidChainID, errs[1])
+	}
+}
+
+func TestVerifyTransactions_SignCache(t *testing.T) {
+	txs := mockSignedTransactions(100, 2)
+	assert.False(t, txs[0].signVerifiedBefore())
+	assert.Nil(t, txs[0].VerifyIntegrity(100))
+	assert.True(t, txs[0].signVerifiedBefore())
+
+	// the cached result stands only for the same signature.
+	sign := txs[0].sign
+	txs[0].sign = txs[1].sign
+	assert.False(t, txs[0].signVerifiedBefore())
+	assert.Equal(t, ErrInvalidTransactionSigner, txs[0].VerifyIntegrity(100))
+	txs[0].sign = sign
+	assert.True(t, txs[0].signVerifiedBefore())
+}
+
+func benchmarkVerifyTransactions(b *testing.B, workers int) {
+	txs := mockSignedTransactions(100, 256)
+	defaults := VerifyWorkers
+	VerifyWorkers = workers
+	defer func() { VerifyWorkers = defaults }()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		verifiedSigns.Purge()
+		VerifyTransactions(100, txs)
+	}
+}
+
+func BenchmarkVerifyTransactions_Serial(b *testing.B) { benchmarkVerifyTransactions(b, 1) }
+
+func BenchmarkVerifyTransactions_Parallel(b *testing.B) {
+	benchmarkVerifyTransactions(b, VerifyWorkers)
+}
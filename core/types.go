@@ -24,17 +24,23 @@ import (
 	"strconv"
 
 	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
 	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/nebulasio/go-nebulas/util"
 )
 
 // Payload Types
 const (
-	TxPayloadBinaryType    = "binary"
-	TxPayloadDeployType    = "deploy"
-	TxPayloadCallType      = "call"
-	TxPayloadDelegateType  = "delegate"
-	TxPayloadCandidateType = "candidate"
+	TxPayloadBinaryType        = "binary"
+	TxPayloadDeployType        = "deploy"
+	TxPayloadCallType          = "call"
+	TxPayloadDelegateType      = "delegate"
+	TxPayloadCandidateType     = "candidate"
+	TxPayloadMultisigType      = "multisig"
+	TxPayloadBatchTransferType = "batch"
+	TxPayloadSlashType         = "slash"
+	TxPayloadUpgradeType       = "upgrade"
+	TxPayloadOracleType        = "oracle"
 )
 
 // Error Types
@@ -46,32 +52,61 @@ var (
 	ErrInsufficientBalance                 = errors.New("insufficient balance")
 	ErrBelowGasPrice                       = errors.New("below the gas price")
 	ErrOutOfGasLimit                       = errors.New("out of gas limit")
+	ErrTxPoolFull                          = errors.New("transaction pool is full, and the transaction is priced below all in pool")
+	ErrTooManySenderTransactions           = errors.New("too many transactions of the sender in transaction pool")
+	ErrTxDataPayloadTooLarge               = errors.New("transaction data payload is too large")
+	ErrTransactionExpired                  = errors.New("transaction is expired")
+	ErrTransactionNotInPool                = errors.New("transaction not found in transaction pool")
 	ErrTxExecutionFailed                   = errors.New("transaction execution failed")
 	ErrInvalidSignature                    = errors.New("invalid transaction signature")
 	ErrInvalidTransactionHash              = errors.New("invalid transaction hash")
 	ErrMissingParentBlock                  = errors.New("cannot find the block's parent block in storage")
-	ErrTooFewCandidates                    = errors.New("the size of candidates in consensus is un-safe, should be greater than or equal " + strconv.Itoa(SafeSize))
+	ErrTooFewCandidates                    = errors.New("the size of candidates in consensus is un-safe, should be greater than or equal dynasty size/3 + 1")
 	ErrNotBlockForgTime                    = errors.New("now is not time to forg block")
 	ErrInvalidBlockHash                    = errors.New("invalid block hash")
 	ErrInvalidBlockStateRoot               = errors.New("invalid block state root hash")
 	ErrInvalidBlockTxsRoot                 = errors.New("invalid block txs root hash")
 	ErrInvalidBlockEventsRoot              = errors.New("invalid block events root hash")
+	ErrInvalidBlockReceiptsRoot            = errors.New("invalid block receipts root hash")
 	ErrInvalidBlockDposContextRoot         = errors.New("invalid block dpos context root hash")
 	ErrInvalidChainID                      = errors.New("invalid transaction chainID")
 	ErrDuplicatedTransaction               = errors.New("duplicated transaction")
+	ErrUnderpricedReplacement              = errors.New("replacement transaction underpriced")
 	ErrSmallTransactionNonce               = errors.New("cannot accept a transaction with smaller nonce")
 	ErrLargeTransactionNonce               = errors.New("cannot accept a transaction with too bigger nonce")
 	ErrDuplicatedBlock                     = errors.New("duplicated block")
 	ErrDoubleBlockMinted                   = errors.New("double block minted")
 	ErrInvalidAddress                      = errors.New("address: invalid address")
 	ErrInvalidAddressDataLength            = errors.New("address: invalid address data length")
+	ErrInvalidAddressType                  = errors.New("address: invalid address type")
+	ErrAddressOfAnotherNetwork             = errors.New("address: address of another network")
 	ErrDoubleSealBlock                     = errors.New("cannot seal a block twice")
 	ErrInvalidCandidatePayloadAction       = errors.New("invalid transaction candidate payload action")
 	ErrInvalidDelegatePayloadAction        = errors.New("invalid transaction vote payload action")
+	ErrInvalidMultisigPayloadAction        = errors.New("invalid transaction multisig payload action")
+	ErrInvalidMultisigOwners               = errors.New("invalid multisig owners, should be unique and no more than " + strconv.Itoa(MaxMultisigOwners))
+	ErrInvalidMultisigThreshold            = errors.New("invalid multisig threshold, should be in [1, count of owners]")
+	ErrInvalidMultisigValue                = errors.New("invalid multisig transfer value")
+	ErrMultisigAccountNotFound             = errors.New("multisig account not found")
+	ErrNotMultisigOwner                    = errors.New("sender is not an owner of the multisig account")
+	ErrMultisigProposalNotFound            = errors.New("multisig proposal not found")
+	ErrMultisigProposalExecuted            = errors.New("multisig proposal executed already")
+	ErrMultisigAlreadySigned               = errors.New("multisig proposal signed by the sender already")
+	ErrInvalidBatchTransferOutputs         = errors.New("invalid batch transfer outputs, should be in [1, " + strconv.Itoa(MaxBatchTransferOutputs) + "]")
+	ErrInvalidBatchTransferValue           = errors.New("invalid batch transfer value")
+	ErrInvalidUpgradePayload               = errors.New("invalid contract upgrade payload, source and source type are required")
+	ErrContractNotUpgradable               = errors.New("contract is not deployed upgradable")
+	ErrNotContractOwner                    = errors.New("sender is not the owner of the contract")
+	ErrInvalidContractMetadata             = errors.New("invalid contract metadata, should be a JSON object in " + strconv.Itoa(MaxContractMetadataLength) + " bytes")
+	ErrContractMetadataNotFound            = errors.New("contract metadata not found")
+	ErrInvalidOraclePayload                = errors.New("invalid oracle payload, key should be in [1, " + strconv.Itoa(MaxOracleKeyLength) + "] bytes and value in [0, " + strconv.Itoa(MaxOracleValueLength) + "] bytes")
+	ErrNotOracleFeeder                     = errors.New("sender is not an oracle feeder whitelisted in genesis")
+	ErrStaleDoubleSignEvidence             = errors.New("double sign evidence is not of the current dynasty")
+	ErrNotDynastyValidator                 = errors.New("the miner is not a validator of the current dynasty")
 	ErrInvalidDelegateToNonCandidate       = errors.New("cannot delegate to non-candidate")
 	ErrInvalidUnDelegateFromNonDelegatee   = errors.New("cannot un-delegate from non-delegatee")
 	ErrInvalidBaseAndNextDynastyID         = errors.New("cannot kickout from baseDynastyID to nextDynastyID if nextDynastyID <= baseDynastyID")
-	ErrInitialDynastyNotEnough             = errors.New("the size of initial dynasty in genesis block is un-safe, should be greater than or equal " + strconv.Itoa(SafeSize))
+	ErrInitialDynastyNotEnough             = errors.New("the size of initial dynasty in genesis block is un-safe, should be greater than or equal dynasty size/3 + 1")
 	ErrInvalidTransactionSigner            = errors.New("transaction recover public key address not equal to from")
 	ErrNotBlockInCanonicalChain            = errors.New("cannot find the block in canonical chain")
 	ErrCloneAccountState                   = errors.New("Failed to clone account state")
@@ -83,8 +118,10 @@ var (
 	ErrCloneVoteTrie                       = errors.New("Failed to clone vote trie")
 	ErrCloneMintCntTrie                    = errors.New("Failed to clone mint count trie")
 	ErrCloneEventsState                    = errors.New("Failed to clone events state")
+	ErrCloneReceiptsState                  = errors.New("Failed to clone receipts state")
 	ErrGenerateNextDynastyContext          = errors.New("Failed to generate next dynasty context")
 	ErrLoadNextDynastyContext              = errors.New("Failed to load next dynasty context")
+	ErrInvalidAuditRange                   = errors.New("invalid height range to audit")
 )
 
 // Default gas count
@@ -105,6 +142,13 @@ const (
 	MessageTypeDownloadedBlock      = "dlblock"
 	MessageTypeDownloadedBlockReply = "dlreply"
 	MessageTypeNewTx                = "newtx"
+	MessageTypeNewTxs               = "newtxs"
+	MessageTypeGetBlocksByHash      = "getblkbyhash"
+	MessageTypeGetBlocksByHeight    = "getblkbyheight"
+	MessageTypeBlocksReply          = "blocksreply"
+	MessageTypeCompactBlock         = "cmpctblock"
+	MessageTypeGetTxs               = "gettxs"
+	MessageTypeTxs                  = "txs"
 )
 
 // Consensus interface
@@ -113,6 +157,13 @@ type Consensus interface {
 	FastVerifyBlock(block *Block) error
 }
 
+// BlockSigner signs the hashes of blocks with the key of the miner. A keystore.Signature initialized
+// with the key is one, and the key may live in a remote signer service as well.
+type BlockSigner interface {
+	Algorithm() keystore.Algorithm
+	Sign(data []byte) ([]byte, error)
+}
+
 // Less return if a < b
 func Less(a *Block, b *Block) bool {
 	hasherA := fnv.New32a()
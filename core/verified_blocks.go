@@ -0,0 +1,111 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// verifiedBlockPrefix is the prefix of the keys of the blocks executed and verified by this node,
+// the value is the state root the execution results in.
+const verifiedBlockPrefix = "verified_"
+
+var (
+	verifiedBlockHitMeter = metrics.GetOrRegisterMeter("neb.block.verified.hit", nil)
+)
+
+func verifiedBlockKey(hash byteutils.Hash) []byte {
+	return append([]byte(verifiedBlockPrefix), hash...)
+}
+
+// markVerified records that the block was executed and its states matched the header.
+func (block *Block) markVerified() {
+	if block.verifiedBlocks != nil {
+		block.verifiedBlocks.Add(block.Hash().Hex(), block.StateRoot())
+	}
+	if err := block.storage.Put(verifiedBlockKey(block.Hash()), block.StateRoot()); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Warn("Failed to mark the block verified.")
+	}
+}
+
+// verifiedBefore returns if the block was executed by this node, resulting in the state root in header,
+// whose states are not pruned since. The hash of block covers the roots, and the transactions must
+// result in the txs root of header.
+func (block *Block) verifiedBefore() bool {
+	if !HashBlock(block).Equals(block.Hash()) {
+		return false
+	}
+	var root byteutils.Hash
+	if v, ok := block.cachedVerified(); ok {
+		root = v
+	} else {
+		data, err := block.storage.Get(verifiedBlockKey(block.Hash()))
+		if err != nil {
+			return false
+		}
+		root = data
+		if block.verifiedBlocks != nil {
+			block.verifiedBlocks.Add(block.Hash().Hex(), root)
+		}
+	}
+	if !root.Equals(block.StateRoot()) || block.StatesPruned() {
+		return false
+	}
+	return block.txsRootMatched()
+}
+
+func (block *Block) cachedVerified() (byteutils.Hash, bool) {
+	if block.verifiedBlocks == nil {
+		return nil, false
+	}
+	v, ok := block.verifiedBlocks.Get(block.Hash().Hex())
+	if !ok {
+		return nil, false
+	}
+	return v.(byteutils.Hash), true
+}
+
+// txsRootMatched returns if the transactions of block on its parent result in the txs root of header.
+func (block *Block) txsRootMatched() bool {
+	if block.txsTrie == nil {
+		return false
+	}
+	txsTrie, err := block.txsTrie.Clone()
+	if err != nil {
+		return false
+	}
+	for _, tx := range block.transactions {
+		if err := putTransaction(txsTrie, tx); err != nil {
+			return false
+		}
+	}
+	return byteutils.Equal(txsTrie.RootHash(), block.TxsRoot())
+}
+
+// forgetVerified removes the marker of the block whose states are pruned.
+func (bc *BlockChain) forgetVerified(hash byteutils.Hash) error {
+	bc.verifiedBlocks.Remove(hash.Hex())
+	return bc.storage.Del(verifiedBlockKey(hash))
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlock_VerifiedBefore(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase := mockAddress()
+
+	block, err := NewBlock(bc.ChainID(), coinbase, bc.tailBlock)
+	assert.Nil(t, err)
+	block.header.timestamp = BlockInterval
+	block.SetMiner(coinbase)
+	assert.Nil(t, block.Seal())
+
+	verify := func() *Block {
+		received, err := mockBlockFromNetwork(block)
+		assert.Nil(t, err)
+		assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+		received.SetMiner(coinbase)
+		assert.Nil(t, received.VerifyExecution(bc.tailBlock, c))
+		return received
+	}
+
+	received := verify()
+	assert.True(t, received.verifiedBefore())
+	hits := verifiedBlockHitMeter.Count()
+
+	// verified again without executing, by the cache and by the marker in storage.
+	received = verify()
+	assert.Equal(t, hits+1, verifiedBlockHitMeter.Count())
+	assert.Equal(t, block.StateRoot(), received.accState.RootHash())
+	assert.Equal(t, block.dposContext.mintCntTrie.RootHash(), received.dposContext.mintCntTrie.RootHash())
+	bc.verifiedBlocks.Purge()
+	received = verify()
+	assert.Equal(t, hits+2, verifiedBlockHitMeter.Count())
+	assert.Equal(t, coinbase.Bytes(), received.Coinbase().Bytes())
+
+	// a block not matching its hash is executed.
+	received, err = mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	received.header.timestamp++
+	assert.False(t, received.verifiedBefore())
+
+	// the blocks not marked are executed.
+	assert.Nil(t, bc.storage.Del(verifiedBlockKey(block.Hash())))
+	bc.verifiedBlocks.Purge()
+	assert.False(t, block.verifiedBefore())
+}
+
+func TestBlock_VerifiedBeforeWithOtherTransactions(t *testing.T) {
+	bc, _ := NewBlockChain(testNeb())
+	var c MockConsensus
+	bc.SetConsensusHandler(c)
+	coinbase, to := mockAddress(), mockAddress()
+
+	priv := secp256k1.GeneratePrivateKey()
+	pubdata, _ := priv.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata)
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(priv)
+	bc.tailBlock.begin()
+	balance := util.NewUint128FromBigInt(util.NewUint128().Mul(TransactionGasPrice.Int, util.NewUint128FromInt(2000000).Int))
+	bc.tailBlock.accState.GetOrCreateUserAccount(from.Bytes()).AddBalance(balance)
+	bc.tailBlock.header.stateRoot = bc.tailBlock.accState.RootHash()
+	bc.tailBlock.commit()
+	bc.storeBlockToStorage(bc.tailBlock)
+
+	tx := NewTransaction(bc.ChainID(), from, to, util.NewUint128FromInt(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, util.NewUint128FromInt(200000))
+	assert.Nil(t, tx.Sign(signature))
+	assert.Nil(t, bc.txPool.Push(tx))
+
+	block, err := NewBlock(bc.ChainID(), coinbase, bc.tailBlock)
+	assert.Nil(t, err)
+	block.header.timestamp = BlockInterval
+	block.CollectTransactions(1)
+	block.SetMiner(coinbase)
+	assert.Nil(t, block.Seal())
+	assert.Equal(t, 1, len(block.transactions))
+
+	received, err := mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+	received.SetMiner(coinbase)
+	assert.Nil(t, received.VerifyExecution(bc.tailBlock, c))
+	hits := verifiedBlockHitMeter.Count()
+
+	// the same header with another transaction of the same hash is executed, and fails.
+	received, err = mockBlockFromNetwork(block)
+	assert.Nil(t, err)
+	received.transactions[0].value = util.NewUint128FromInt(2)
+	assert.Equal(t, block.Hash(), HashBlock(received))
+	assert.Nil(t, received.LinkParentBlock(bc.tailBlock))
+	received.SetMiner(coinbase)
+	assert.False(t, received.verifiedBefore())
+	assert.NotNil(t, received.VerifyExecution(bc.tailBlock, c))
+	assert.Equal(t, hits, verifiedBlockHitMeter.Count())
+}
@@ -50,6 +50,11 @@ func (c *Cipher) EncryptKey(address string, data []byte, passphrase []byte) ([]b
 	return c.encrypt.EncryptKey(address, data, passphrase)
 }
 
+// EncryptKeyV3 encrypt key in the format of Web3 Secret Storage by the kdf, scrypt or pbkdf2
+func (c *Cipher) EncryptKeyV3(address string, data []byte, passphrase []byte, kdf string) ([]byte, error) {
+	return c.encrypt.EncryptKeyV3(address, data, passphrase, kdf)
+}
+
 // Decrypt decrypts data, returning the origin data
 func (c *Cipher) Decrypt(data []byte, passphrase []byte) ([]byte, error) {
 	return c.encrypt.Decrypt(data, passphrase)
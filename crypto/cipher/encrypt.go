@@ -27,6 +27,9 @@ type Encrypt interface {
 	// EncryptKey encrypt key with address
 	EncryptKey(address string, data []byte, passphrase []byte) ([]byte, error)
 
+	// EncryptKeyV3 encrypt key in the format of Web3 Secret Storage by the kdf
+	EncryptKeyV3(address string, data []byte, passphrase []byte, kdf string) ([]byte, error)
+
 	// Decrypt decrypts data with passphrase,  returning origin data.
 	Decrypt(data []byte, passphrase []byte) ([]byte, error)
 
@@ -22,12 +22,14 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 
 	"github.com/nebulasio/go-nebulas/crypto/hash"
 	uuid "github.com/satori/go.uuid"
+	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
 )
 
@@ -47,6 +49,15 @@ const (
 	// ScryptDKLen get derived key length
 	ScryptDKLen = 32
 
+	// PBKDF2KDF name
+	PBKDF2KDF = "pbkdf2"
+
+	// StandardPBKDF2C c parameter of PBKDF2, the iterations
+	StandardPBKDF2C = 262144
+
+	// pbkdf2PRF the pseudo-random function of PBKDF2 in Web3 Secret Storage
+	pbkdf2PRF = "hmac-sha256"
+
 	// cipher the name of cipher
 	cipherName = "aes-128-ctr"
 
@@ -82,11 +93,11 @@ type cryptoJSON struct {
 	KDF          string                 `json:"kdf"`
 	KDFParams    map[string]interface{} `json:"kdfparams"`
 	MAC          string                 `json:"mac"`
-	MACHash      string                 `json:"machash"`
+	MACHash      string                 `json:"machash,omitempty"`
 }
 
 type encryptedKeyJSON struct {
-	Address string     `json:"address"`
+	Address string     `json:"address,omitempty"`
 	Crypto  cryptoJSON `json:"crypto"`
 	ID      string     `json:"id"`
 	Version int        `json:"version"`
@@ -111,6 +122,43 @@ func (s *Scrypt) EncryptKey(address string, data []byte, passphrase []byte) ([]b
 	return json.Marshal(encryptedKeyJSON)
 }
 
+// EncryptKeyV3 encrypt key in the format of Web3 Secret Storage by the kdf, scrypt or pbkdf2,
+// with the keccak256 mac read by other tooling. The address is left out if empty.
+func (s *Scrypt) EncryptKeyV3(address string, data []byte, passphrase []byte, kdf string) ([]byte, error) {
+	var (
+		crypto *cryptoJSON
+		err    error
+	)
+	switch kdf {
+	case ScryptKDF:
+		crypto, err = s.scryptEncrypt(data, passphrase, StandardScryptN, StandardScryptR, StandardScryptP)
+	case PBKDF2KDF:
+		crypto, err = s.pbkdf2Encrypt(data, passphrase, StandardPBKDF2C)
+	default:
+		return nil, ErrKDFInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	derivedKey, err := s.deriveKey(crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := hex.DecodeString(crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+	crypto.MAC = hex.EncodeToString(hash.Keccak256(derivedKey[16:32], cipherText))
+	crypto.MACHash = ""
+	encryptedKeyJSON := encryptedKeyJSON{
+		address,
+		*crypto,
+		uuid.NewV4().String(),
+		version,
+	}
+	return json.Marshal(encryptedKeyJSON)
+}
+
 // Encrypt scrypt encrypt
 func (s *Scrypt) Encrypt(data []byte, passphrase []byte) ([]byte, error) {
 	return s.ScryptEncrypt(data, passphrase, StandardScryptN, StandardScryptR, StandardScryptP)
@@ -167,6 +215,33 @@ func (s *Scrypt) scryptEncrypt(data []byte, passphrase []byte, N, r, p int) (*cr
 	return crypto, nil
 }
 
+func (s *Scrypt) pbkdf2Encrypt(data []byte, passphrase []byte, c int) (*cryptoJSON, error) {
+	salt := RandomCSPRNG(ScryptDKLen)
+	derivedKey := pbkdf2.Key(passphrase, salt, c, ScryptDKLen, sha256.New)
+	iv := RandomCSPRNG(aes.BlockSize)
+	cipherText, err := s.aesCTRXOR(derivedKey[:16], data, iv)
+	if err != nil {
+		return nil, err
+	}
+	mac := hash.Sha3256(derivedKey[16:32], cipherText)
+
+	pbkdf2ParamsJSON := make(map[string]interface{}, 4)
+	pbkdf2ParamsJSON["c"] = c
+	pbkdf2ParamsJSON["prf"] = pbkdf2PRF
+	pbkdf2ParamsJSON["dklen"] = ScryptDKLen
+	pbkdf2ParamsJSON["salt"] = hex.EncodeToString(salt)
+
+	return &cryptoJSON{
+		Cipher:       cipherName,
+		CipherText:   hex.EncodeToString(cipherText),
+		CipherParams: cipherparamsJSON{IV: hex.EncodeToString(iv)},
+		KDF:          PBKDF2KDF,
+		KDFParams:    pbkdf2ParamsJSON,
+		MAC:          hex.EncodeToString(mac),
+		MACHash:      macHash,
+	}, nil
+}
+
 func (s *Scrypt) aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
 	aesBlock, err := aes.NewCipher(key)
 	if err != nil {
@@ -220,25 +295,11 @@ func (s *Scrypt) scryptDecrypt(crypto *cryptoJSON, passphrase []byte) ([]byte, e
 		return nil, err
 	}
 
-	salt, err := hex.DecodeString(crypto.KDFParams["salt"].(string))
+	derivedKey, err := s.deriveKey(crypto, passphrase)
 	if err != nil {
 		return nil, err
 	}
 
-	dklen := ensureInt(crypto.KDFParams["dklen"])
-	var derivedKey = []byte{}
-	if crypto.KDF == ScryptKDF {
-		n := ensureInt(crypto.KDFParams["n"])
-		r := ensureInt(crypto.KDFParams["r"])
-		p := ensureInt(crypto.KDFParams["p"])
-		derivedKey, err = scrypt.Key(passphrase, salt, n, r, p, dklen)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		return nil, ErrKDFInvalid
-	}
-
 	var calculatedMAC = hash.Sha3256(derivedKey[16:32], cipherText)
 	if crypto.MACHash != macHash {
 		// compatible ethereum keystore file,
@@ -255,11 +316,47 @@ func (s *Scrypt) scryptDecrypt(crypto *cryptoJSON, passphrase []byte) ([]byte, e
 	return key, nil
 }
 
+// deriveKey derives the key from passphrase by the kdf of the crypto, scrypt or pbkdf2 with hmac-sha256.
+func (s *Scrypt) deriveKey(crypto *cryptoJSON, passphrase []byte) ([]byte, error) {
+	saltHex, ok := crypto.KDFParams["salt"].(string)
+	if !ok {
+		return nil, ErrKDFInvalid
+	}
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return nil, err
+	}
+
+	dklen := ensureInt(crypto.KDFParams["dklen"])
+	if dklen < ScryptDKLen {
+		return nil, ErrKDFInvalid
+	}
+	switch crypto.KDF {
+	case ScryptKDF:
+		n := ensureInt(crypto.KDFParams["n"])
+		r := ensureInt(crypto.KDFParams["r"])
+		p := ensureInt(crypto.KDFParams["p"])
+		return scrypt.Key(passphrase, salt, n, r, p, dklen)
+	case PBKDF2KDF:
+		if prf, _ := crypto.KDFParams["prf"].(string); prf != pbkdf2PRF {
+			return nil, ErrKDFInvalid
+		}
+		c := ensureInt(crypto.KDFParams["c"])
+		if c <= 0 {
+			return nil, ErrKDFInvalid
+		}
+		return pbkdf2.Key(passphrase, salt, c, dklen, sha256.New), nil
+	default:
+		return nil, ErrKDFInvalid
+	}
+}
+
 // because json.Unmarshal change int to float64, convert to int
 func ensureInt(x interface{}) int {
 	res, ok := x.(int)
 	if !ok {
-		res = int(x.(float64))
+		f, _ := x.(float64)
+		res = int(f)
 	}
 	return res
 }
@@ -19,6 +19,7 @@
 package cipher
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -99,3 +100,112 @@ func TestScrypt_DecryptKey(t *testing.T) {
 	}
 	//t.Logf("decrypt key :%d", d)
 }
+
+func TestScrypt_DecryptKeyV3(t *testing.T) {
+	// test vectors of Web3 Secret Storage.
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{
+			"pbkdf2",
+			`{
+    "crypto" : {
+        "cipher" : "aes-128-ctr",
+        "cipherparams" : {
+            "iv" : "6087dab2f9fdbbfaddc31a909735c1e6"
+        },
+        "ciphertext" : "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+        "kdf" : "pbkdf2",
+        "kdfparams" : {
+            "c" : 262144,
+            "dklen" : 32,
+            "prf" : "hmac-sha256",
+            "salt" : "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+        },
+        "mac" : "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+    },
+    "id" : "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+    "version" : 3
+}`,
+		},
+		{
+			"scrypt",
+			`{
+    "crypto" : {
+        "cipher" : "aes-128-ctr",
+        "cipherparams" : {
+            "iv" : "83dbcc02d8ccb40e466191a123791e0e"
+        },
+        "ciphertext" : "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+        "kdf" : "scrypt",
+        "kdfparams" : {
+            "dklen" : 32,
+            "n" : 262144,
+            "r" : 1,
+            "p" : 8,
+            "salt" : "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+        },
+        "mac" : "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+    },
+    "id" : "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+    "version" : 3
+}`,
+		},
+	}
+	want, _ := byteutils.FromHex("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	scrypt := new(Scrypt)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scrypt.DecryptKey([]byte(tt.key), []byte("testpassword"))
+			if err != nil {
+				t.Errorf("DecryptKey() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("DecryptKey() = %x, want %x", got, want)
+			}
+			if _, err := scrypt.DecryptKey([]byte(tt.key), []byte("wrong")); err != ErrDecrypt {
+				t.Errorf("DecryptKey() error = %v, want %v", err, ErrDecrypt)
+			}
+		})
+	}
+}
+
+func TestScrypt_EncryptKeyV3(t *testing.T) {
+	passphrase := []byte("passphrase")
+	data, _ := byteutils.FromHex("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	scrypt := new(Scrypt)
+	for _, kdf := range []string{ScryptKDF, PBKDF2KDF} {
+		t.Run(kdf, func(t *testing.T) {
+			keyjson, err := scrypt.EncryptKeyV3("", data, passphrase, kdf)
+			if err != nil {
+				t.Errorf("EncryptKeyV3() error = %v", err)
+				return
+			}
+			var v3 map[string]interface{}
+			if err := json.Unmarshal(keyjson, &v3); err != nil {
+				t.Errorf("Unmarshal() error = %v", err)
+				return
+			}
+			crypto := v3["crypto"].(map[string]interface{})
+			if _, ok := crypto["machash"]; ok {
+				t.Errorf("EncryptKeyV3() has machash, not read by other tooling")
+			}
+			if _, ok := v3["address"]; ok {
+				t.Errorf("EncryptKeyV3() has empty address")
+			}
+			got, err := scrypt.DecryptKey(keyjson, passphrase)
+			if err != nil {
+				t.Errorf("DecryptKey() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(data, got) {
+				t.Errorf("DecryptKey() = %x, want %x", got, data)
+			}
+		})
+	}
+	if _, err := scrypt.EncryptKeyV3("", data, passphrase, "bcrypt"); err != ErrKDFInvalid {
+		t.Errorf("EncryptKeyV3() error = %v, want %v", err, ErrKDFInvalid)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/ed25519"
 	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
 )
 
@@ -48,16 +49,47 @@ func NewPrivateKey(alg keystore.Algorithm, data []byte) (keystore.PrivateKey, er
 			return nil, err
 		}
 		return priv, nil
+	case keystore.ED25519:
+		if len(data) == 0 {
+			return ed25519.GeneratePrivateKey(), nil
+		}
+		priv := new(ed25519.PrivateKey)
+		if err := priv.Decode(data); err != nil {
+			return nil, err
+		}
+		return priv, nil
 	default:
 		return nil, ErrAlgorithmInvalid
 	}
 }
 
+// PrivateKeyAlgorithm returns the algorithm of an encoded privatekey, told by its length.
+func PrivateKeyAlgorithm(data []byte) keystore.Algorithm {
+	if len(data) == ed25519.PrivateKeySize {
+		return keystore.ED25519
+	}
+	return keystore.SECP256K1
+}
+
+// SignatureLength returns the length of the signatures of the algorithm.
+func SignatureLength(alg keystore.Algorithm) (int, error) {
+	switch alg {
+	case keystore.SECP256K1:
+		return secp256k1.SignatureLength, nil
+	case keystore.ED25519:
+		return ed25519.SignatureLength, nil
+	default:
+		return 0, ErrAlgorithmInvalid
+	}
+}
+
 // NewSignature returns a specific signature with the algorithm
 func NewSignature(alg keystore.Algorithm) (keystore.Signature, error) {
 	switch alg {
 	case keystore.SECP256K1:
 		return new(secp256k1.Signature), nil
+	case keystore.ED25519:
+		return new(ed25519.Signature), nil
 	default:
 		return nil, ErrAlgorithmInvalid
 	}
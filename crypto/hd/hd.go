@@ -0,0 +1,182 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// CoinType is the coin type of Nebulas in SLIP-44.
+const CoinType = 2718
+
+// HardenedOffset is added to the index of a hardened child.
+const HardenedOffset uint32 = 0x80000000
+
+// AccountPath is the BIP44 path of the accounts of a wallet, the address index is appended to it.
+var AccountPath = fmt.Sprintf("m/44'/%d'/0'/0", CoinType)
+
+// the key of HMAC for the master key in BIP32.
+var masterKeySeed = []byte("Bitcoin seed")
+
+// Errors of key derivation
+var (
+	ErrInvalidSeedLength = errors.New("seed must be 16 to 64 bytes")
+	ErrInvalidPath       = errors.New("invalid derivation path")
+	ErrInvalidChild      = errors.New("invalid child key, derive the next index")
+)
+
+// ExtendedKey is a private key of BIP32 with its chain code, the children are derived from it.
+type ExtendedKey struct {
+	key       []byte
+	chainCode []byte
+	depth     uint8
+	index     uint32
+}
+
+// NewMasterKey returns the master key of the seed.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrInvalidSeedLength
+	}
+	mac := hmac.New(sha512.New, masterKeySeed)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	if !validKey(sum[:32]) {
+		return nil, ErrInvalidChild
+	}
+	return &ExtendedKey{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+func validKey(key []byte) bool {
+	k := new(big.Int).SetBytes(key)
+	return k.Sign() > 0 && k.Cmp(secp256k1.S256().Params().N) < 0
+}
+
+// Child returns the child key of the index, hardened if it's not less than HardenedOffset.
+// ErrInvalidChild is returned for the rare indexes without a valid key.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var data []byte
+	if index >= HardenedOffset {
+		data = append([]byte{0}, k.key...)
+	} else {
+		data = k.compressedPublicKey()
+	}
+	data = append(data, byteutils.FromUint32(index)...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	if !validKey(sum[:32]) {
+		return nil, ErrInvalidChild
+	}
+	n := secp256k1.S256().Params().N
+	child := new(big.Int).SetBytes(sum[:32])
+	child.Add(child, new(big.Int).SetBytes(k.key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	key := make([]byte, 32)
+	b := child.Bytes()
+	copy(key[32-len(b):], b)
+	return &ExtendedKey{key: key, chainCode: sum[32:], depth: k.depth + 1, index: index}, nil
+}
+
+// compressedPublicKey returns the public key in the compressed form of SEC1.
+func (k *ExtendedKey) compressedPublicKey() []byte {
+	x, y := secp256k1.S256().ScalarBaseMult(k.key)
+	pub := make([]byte, 33)
+	pub[0] = 2 + byte(y.Bit(0))
+	b := x.Bytes()
+	copy(pub[33-len(b):], b)
+	return pub
+}
+
+// Derive returns the descendant key of the path, in the form of m/44'/2718'/0'/0/0.
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := k
+	for _, index := range indexes {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// ParsePath returns the child indexes of the path, the hardened ones are marked by ' or h.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, ErrInvalidPath
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		offset := uint32(0)
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			offset = HardenedOffset
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset {
+			return nil, ErrInvalidPath
+		}
+		indexes = append(indexes, uint32(index)+offset)
+	}
+	return indexes, nil
+}
+
+// Key returns the private key in bytes.
+func (k *ExtendedKey) Key() []byte {
+	return k.key
+}
+
+// ChainCode returns the chain code.
+func (k *ExtendedKey) ChainCode() []byte {
+	return k.chainCode
+}
+
+// Depth returns the depth from the master key.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// Index returns the index of the key in its parent.
+func (k *ExtendedKey) Index() uint32 {
+	return k.index
+}
+
+// PrivateKey returns the key as a secp256k1 private key of keystore.
+func (k *ExtendedKey) PrivateKey() (keystore.PrivateKey, error) {
+	return crypto.NewPrivateKey(keystore.SECP256K1, k.key)
+}
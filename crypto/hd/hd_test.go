@@ -0,0 +1,131 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonic(t *testing.T) {
+	// test vectors of BIP39 with the passphrase TREZOR.
+	tests := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will",
+			"f2b94508732bcbacbcc020faefecfc89feafa6649a5491b8c952cede496c214a0c7b3c392d168748f2d4a612bada0753b52a1c7ac53c1e93abd5c6320b9e95dd",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title",
+			"bc09fca1804f7e69da93c2f2028eb238c227f2e9dda30cd63699232578480a4021b146ad717fbb7e451ce9eb835f43620bf5c514db0f8add49f5d121449d3e87",
+		},
+	}
+	for _, tt := range tests {
+		entropy, _ := hex.DecodeString(tt.entropy)
+		mnemonic, err := EntropyToMnemonic(entropy)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.mnemonic, mnemonic)
+
+		got, err := MnemonicToEntropy(mnemonic)
+		assert.Nil(t, err)
+		assert.Equal(t, entropy, got)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		assert.Nil(t, err)
+		assert.Equal(t, tt.seed, hex.EncodeToString(seed))
+	}
+
+	assert.Equal(t, ErrMnemonicChecksum, ValidateMnemonic(strings.Repeat("abandon ", 12)))
+	assert.Equal(t, ErrInvalidMnemonic, ValidateMnemonic("letter advice cage absurd amount doctor acoustic avoid letter advice caged above"))
+	assert.Equal(t, ErrInvalidMnemonic, ValidateMnemonic("abandon abandon about"))
+	_, err := NewMnemonic(100)
+	assert.Equal(t, ErrInvalidEntropyLength, err)
+
+	mnemonic, err := NewMnemonic(256)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(strings.Fields(mnemonic)))
+	assert.Nil(t, ValidateMnemonic(mnemonic))
+}
+
+func TestExtendedKey_Derive(t *testing.T) {
+	// test vector 1 of BIP32.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	assert.Nil(t, err)
+	assert.Equal(t, "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", hex.EncodeToString(master.Key()))
+	assert.Equal(t, "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508", hex.EncodeToString(master.ChainCode()))
+
+	tests := []struct {
+		path      string
+		key       string
+		chainCode string
+	}{
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea", "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368", "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19"},
+		{"m/0h/1/2h", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca", "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f"},
+	}
+	for _, tt := range tests {
+		key, err := master.Derive(tt.path)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.key, hex.EncodeToString(key.Key()), tt.path)
+		assert.Equal(t, tt.chainCode, hex.EncodeToString(key.ChainCode()), tt.path)
+	}
+
+	key, err := master.Derive(AccountPath + "/0")
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(5), key.Depth())
+	assert.Equal(t, uint32(0), key.Index())
+	_, err = key.PrivateKey()
+	assert.Nil(t, err)
+
+	_, err = NewMasterKey(seed[:8])
+	assert.Equal(t, ErrInvalidSeedLength, err)
+}
+
+func TestParsePath(t *testing.T) {
+	indexes, err := ParsePath("m/44'/2718'/0'/0/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{44 + HardenedOffset, 2718 + HardenedOffset, HardenedOffset, 0, 1}, indexes)
+	indexes, err = ParsePath("m")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(indexes))
+
+	for _, path := range []string{"", "44'/0", "m/x", "m/-1", "m/2147483648"} {
+		_, err := ParsePath(path)
+		assert.Equal(t, ErrInvalidPath, err, path)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// parameters of BIP39
+const (
+	mnemonicSeedIterations = 2048
+	mnemonicSeedLen        = 64
+	mnemonicWordBits       = 11
+)
+
+// Errors of mnemonic
+var (
+	ErrInvalidEntropyLength = errors.New("entropy must be 128 to 256 bits in multiples of 32")
+	ErrInvalidMnemonic      = errors.New("invalid mnemonic")
+	ErrMnemonicChecksum     = errors.New("mnemonic checksum mismatch")
+)
+
+// NewMnemonic returns a new mnemonic of the entropy in bits, 128 for 12 words to 256 for 24 words.
+func NewMnemonic(bits int) (string, error) {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", ErrInvalidEntropyLength
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return EntropyToMnemonic(entropy)
+}
+
+// EntropyToMnemonic returns the mnemonic of the entropy, with its checksum in the last word.
+func EntropyToMnemonic(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", ErrInvalidEntropyLength
+	}
+	checksumBits := uint(bits / 32)
+	hash := sha256.Sum256(entropy)
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, checksumBits)
+	data.Or(data, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	count := (bits + int(checksumBits)) / mnemonicWordBits
+	words := make([]string, count)
+	mask := big.NewInt(1<<mnemonicWordBits - 1)
+	for i := count - 1; i >= 0; i-- {
+		words[i] = englishWords[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, mnemonicWordBits)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy returns the entropy of the mnemonic, after its words and checksum are verified.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, ErrInvalidMnemonic
+	}
+	data := new(big.Int)
+	for _, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		data.Lsh(data, mnemonicWordBits)
+		data.Or(data, big.NewInt(int64(index)))
+	}
+
+	checksumBits := uint(len(words) * mnemonicWordBits / 33)
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1)).Int64()
+	data.Rsh(data, checksumBits)
+	entropy := make([]byte, int(checksumBits)*4)
+	b := data.Bytes()
+	copy(entropy[len(entropy)-len(b):], b)
+
+	hash := sha256.Sum256(entropy)
+	if int64(hash[0]>>(8-checksumBits)) != checksum {
+		return nil, ErrMnemonicChecksum
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks the words and checksum of the mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// MnemonicToSeed returns the seed of the mnemonic protected by the passphrase, empty if none.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), mnemonicSeedIterations, mnemonicSeedLen, sha512.New), nil
+}
+
+var wordIndex = func() map[string]int {
+	index := make(map[string]int, len(englishWords))
+	for i, word := range englishWords {
+		index[word] = i
+	}
+	return index
+}()
@@ -0,0 +1,131 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+// the vectors of RFC 8032, section 7.1.
+func TestSignature_Vectors(t *testing.T) {
+	tests := []struct {
+		seed      string
+		public    string
+		message   string
+		signature string
+	}{
+		{
+			"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			"",
+			"e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			"72",
+			"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+		},
+	}
+	for _, tt := range tests {
+		seed, _ := byteutils.FromHex(tt.seed)
+		public, _ := byteutils.FromHex(tt.public)
+		message, _ := byteutils.FromHex(tt.message)
+		expected, _ := byteutils.FromHex(tt.signature)
+
+		priv := new(PrivateKey)
+		assert.Nil(t, priv.Decode(ed25519.NewKeyFromSeed(seed)))
+		pub, err := priv.PublicKey().Encoded()
+		assert.Nil(t, err)
+		assert.Equal(t, public, pub)
+
+		signer := new(Signature)
+		assert.Nil(t, signer.InitSign(priv))
+		sign, err := signer.Sign(message)
+		assert.Nil(t, err)
+		assert.Equal(t, append(public, expected...), sign)
+
+		recovered, err := new(Signature).RecoverPublic(message, sign)
+		assert.Nil(t, err)
+		data, _ := recovered.Encoded()
+		assert.Equal(t, public, data)
+	}
+}
+
+func TestPrivateKey_Decode(t *testing.T) {
+	priv := GeneratePrivateKey()
+	data, err := priv.Encoded()
+	assert.Nil(t, err)
+	assert.Equal(t, PrivateKeySize, len(data))
+
+	decoded := new(PrivateKey)
+	assert.Nil(t, decoded.Decode(data))
+	assert.Equal(t, priv.PublicKey(), decoded.PublicKey())
+
+	// the public key must be of the seed.
+	data[PrivateKeySize-1] ^= 1
+	assert.Equal(t, ErrInvalidPrivateKey, decoded.Decode(data))
+	assert.Equal(t, ErrInvalidPrivateKey, decoded.Decode(data[:32]))
+}
+
+func TestSignature_CrossAlgorithm(t *testing.T) {
+	data := hash.Sha3256([]byte("cross algorithm"))
+
+	priv := GeneratePrivateKey()
+	signer := new(Signature)
+	signer.InitSign(priv)
+	sign, err := signer.Sign(data)
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureLength, len(sign))
+
+	// a signature of another key, or of other data, is rejected.
+	other := GeneratePrivateKey()
+	forged := append([]byte(nil), sign...)
+	otherPub, _ := other.PublicKey().Encoded()
+	copy(forged, otherPub)
+	_, err = new(Signature).RecoverPublic(data, forged)
+	assert.Equal(t, ErrInvalidSignature, err)
+	_, err = new(Signature).RecoverPublic(hash.Sha3256([]byte("other")), sign)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	verifier := new(Signature)
+	verifier.InitVerify(other.PublicKey())
+	ok, err := verifier.Verify(data, sign)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	verifier.InitVerify(priv.PublicKey())
+	ok, err = verifier.Verify(data, sign)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// the signatures of secp256k1 are not taken by ed25519, nor the other way round.
+	ecdsa := secp256k1.GeneratePrivateKey()
+	ecdsaSign, err := ecdsa.Sign(data)
+	assert.Nil(t, err)
+	_, err = new(Signature).RecoverPublic(data, ecdsaSign)
+	assert.Equal(t, ErrInvalidSignature, err)
+	_, err = new(secp256k1.Signature).RecoverPublic(data, sign)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"golang.org/x/crypto/ed25519"
+)
+
+// PrivateKeySize the length of an encoded private key.
+const PrivateKeySize = ed25519.PrivateKeySize
+
+var (
+	// ErrInvalidPrivateKey invalid ed25519 private key.
+	ErrInvalidPrivateKey = errors.New("invalid ed25519 private key")
+)
+
+// PrivateKey ed25519 privatekey, encoded in 64 bytes of the seed and the public key
+type PrivateKey struct {
+	privateKey ed25519.PrivateKey
+}
+
+// GeneratePrivateKey generate a new private key
+func GeneratePrivateKey() *PrivateKey {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return &PrivateKey{privateKey: priv}
+}
+
+// Algorithm algorithm name
+func (k *PrivateKey) Algorithm() keystore.Algorithm {
+	return keystore.ED25519
+}
+
+// Encoded encoded to byte
+func (k *PrivateKey) Encoded() ([]byte, error) {
+	if len(k.privateKey) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidPrivateKey
+	}
+	data := make([]byte, ed25519.PrivateKeySize)
+	copy(data, k.privateKey)
+	return data, nil
+}
+
+// Decode decode data to key, the public key in it must be of the seed
+func (k *PrivateKey) Decode(data []byte) error {
+	if len(data) != ed25519.PrivateKeySize {
+		return ErrInvalidPrivateKey
+	}
+	priv := ed25519.NewKeyFromSeed(data[:ed25519.SeedSize])
+	for i := range priv {
+		if priv[i] != data[i] {
+			return ErrInvalidPrivateKey
+		}
+	}
+	k.privateKey = priv
+	return nil
+}
+
+// Clear clear key content
+func (k *PrivateKey) Clear() {
+	for i := range k.privateKey {
+		k.privateKey[i] = 0
+	}
+}
+
+// PublicKey returns publickey
+func (k *PrivateKey) PublicKey() keystore.PublicKey {
+	pub := make([]byte, ed25519.PublicKeySize)
+	copy(pub, k.privateKey[ed25519.SeedSize:])
+	return NewPublicKey(pub)
+}
+
+// Sign sign hash with privatekey, the signature is prefixed by the public key to be recovered
+func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	if len(k.privateKey) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidPrivateKey
+	}
+	signature := make([]byte, 0, SignatureLength)
+	signature = append(signature, k.privateKey[ed25519.SeedSize:]...)
+	return append(signature, ed25519.Sign(k.privateKey, hash)...), nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"golang.org/x/crypto/ed25519"
+)
+
+// SignatureLength the length of a signature, the public key followed by the ed25519 signature.
+const SignatureLength = ed25519.PublicKeySize + ed25519.SignatureSize
+
+var (
+	// ErrInvalidPublicKey invalid ed25519 public key.
+	ErrInvalidPublicKey = errors.New("invalid ed25519 public key")
+
+	// ErrInvalidSignature invalid ed25519 signature.
+	ErrInvalidSignature = errors.New("invalid ed25519 signature")
+)
+
+// PublicKey ed25519 publickey
+type PublicKey struct {
+	publicKey ed25519.PublicKey
+}
+
+// NewPublicKey generate PublicKey
+func NewPublicKey(pub ed25519.PublicKey) *PublicKey {
+	return &PublicKey{pub}
+}
+
+// Algorithm algorithm name
+func (k *PublicKey) Algorithm() keystore.Algorithm {
+	return keystore.ED25519
+}
+
+// Encoded encoded to byte
+func (k *PublicKey) Encoded() ([]byte, error) {
+	if len(k.publicKey) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	data := make([]byte, ed25519.PublicKeySize)
+	copy(data, k.publicKey)
+	return data, nil
+}
+
+// Decode decode data to key
+func (k *PublicKey) Decode(data []byte) error {
+	if len(data) != ed25519.PublicKeySize {
+		return ErrInvalidPublicKey
+	}
+	k.publicKey = make([]byte, ed25519.PublicKeySize)
+	copy(k.publicKey, data)
+	return nil
+}
+
+// Clear clear key content
+func (k *PublicKey) Clear() {
+	k.publicKey = nil
+}
+
+// Verify verify the signature of the hash is signed by the key
+func (k *PublicKey) Verify(hash []byte, signature []byte) (bool, error) {
+	if len(signature) != SignatureLength {
+		return false, ErrInvalidSignature
+	}
+	if !bytes.Equal(signature[:ed25519.PublicKeySize], k.publicKey) {
+		return false, nil
+	}
+	return ed25519.Verify(k.publicKey, hash, signature[ed25519.PublicKeySize:]), nil
+}
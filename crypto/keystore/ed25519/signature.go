@@ -0,0 +1,84 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"golang.org/x/crypto/ed25519"
+)
+
+// Signature signature ed25519, the public key can't be recovered from an ed25519 signature, so it's
+// carried in the signature and checked
+type Signature struct {
+	privateKey *PrivateKey
+
+	publicKey *PublicKey
+}
+
+// Algorithm ed25519 algorithm
+func (s *Signature) Algorithm() keystore.Algorithm {
+	return keystore.ED25519
+}
+
+// InitSign ed25519 init sign
+func (s *Signature) InitSign(priv keystore.PrivateKey) error {
+	s.privateKey = priv.(*PrivateKey)
+	return nil
+}
+
+// Sign ed25519 sign
+func (s *Signature) Sign(data []byte) (out []byte, err error) {
+	if s.privateKey == nil {
+		return nil, errors.New("please get private key first")
+	}
+	return s.privateKey.Sign(data)
+}
+
+// RecoverPublic returns the public key in the signature, if the signature is signed by it
+func (s *Signature) RecoverPublic(data []byte, signature []byte) (keystore.PublicKey, error) {
+	if len(signature) != SignatureLength {
+		return nil, ErrInvalidSignature
+	}
+	pub := NewPublicKey(append([]byte(nil), signature[:ed25519.PublicKeySize]...))
+	ok, err := pub.Verify(data, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+	s.publicKey = pub
+	return s.publicKey, nil
+}
+
+// InitVerify ed25519 verify init
+func (s *Signature) InitVerify(pub keystore.PublicKey) error {
+	s.publicKey = pub.(*PublicKey)
+	return nil
+}
+
+// Verify ed25519 verify
+func (s *Signature) Verify(data []byte, signature []byte) (bool, error) {
+	if s.publicKey == nil {
+		return false, errors.New("please give public key first")
+	}
+	return s.publicKey.Verify(data, signature)
+}
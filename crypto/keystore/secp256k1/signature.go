@@ -24,6 +24,9 @@ import (
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
 )
 
+// SignatureLength the length of a recoverable signature.
+const SignatureLength = 65
+
 // Signature signature ecdsa
 type Signature struct {
 	privateKey *PrivateKey
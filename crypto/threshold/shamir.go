@@ -0,0 +1,194 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+)
+
+// MaxShares is the most shares a key is split into.
+const MaxShares = 255
+
+// Errors of threshold shares
+var (
+	ErrInvalidThreshold = errors.New("threshold must be in [1, n] and n at most 255")
+	ErrInvalidShare     = errors.New("invalid key share")
+	ErrTooFewShares     = errors.New("too few key shares to combine")
+	ErrDuplicateShare   = errors.New("duplicate key share index")
+)
+
+// Share is a share of a secp256k1 private key in Shamir's secret sharing over the order of the
+// curve, any threshold of them combine into the key.
+type Share struct {
+	Index uint32
+	Value []byte
+}
+
+func order() *big.Int {
+	return secp256k1.S256().Params().N
+}
+
+// random returns a random integer in [1, N).
+func random() (*big.Int, error) {
+	for {
+		k, err := rand.Int(rand.Reader, order())
+		if err != nil {
+			return nil, err
+		}
+		if k.Sign() > 0 {
+			return k, nil
+		}
+	}
+}
+
+// polynomial returns random coefficients of degree t-1 with the constant term.
+func polynomial(constant *big.Int, t int) ([]*big.Int, error) {
+	coefficients := []*big.Int{constant}
+	for i := 1; i < t; i++ {
+		c, err := random()
+		if err != nil {
+			return nil, err
+		}
+		coefficients = append(coefficients, c)
+	}
+	return coefficients, nil
+}
+
+// evaluate returns the value of the polynomial at x, by Horner's method.
+func evaluate(coefficients []*big.Int, x uint32) *big.Int {
+	n := order()
+	bx := new(big.Int).SetUint64(uint64(x))
+	y := new(big.Int)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y.Mul(y, bx)
+		y.Add(y, coefficients[i])
+		y.Mod(y, n)
+	}
+	return y
+}
+
+func toBytes(v *big.Int) []byte {
+	b := make([]byte, 32)
+	data := v.Bytes()
+	copy(b[32-len(data):], data)
+	return b
+}
+
+func checkThreshold(t, n int) error {
+	if t < 1 || n < t || n > MaxShares {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
+// Split splits the private key into n shares of index 1 to n, any t of them combine into the key.
+func Split(key []byte, t, n int) ([]*Share, error) {
+	if err := checkThreshold(t, n); err != nil {
+		return nil, err
+	}
+	secret := new(big.Int).SetBytes(key)
+	if secret.Sign() == 0 || secret.Cmp(order()) >= 0 {
+		return nil, ErrInvalidShare
+	}
+	coefficients, err := polynomial(secret, t)
+	if err != nil {
+		return nil, err
+	}
+	shares := make([]*Share, n)
+	for i := range shares {
+		index := uint32(i + 1)
+		shares[i] = &Share{Index: index, Value: toBytes(evaluate(coefficients, index))}
+	}
+	return shares, nil
+}
+
+// Combine returns the private key of t shares by Lagrange interpolation at 0, the shares after the
+// first t are ignored. A wrong key is returned if a share is corrupted or of another split, the
+// caller checks it against the public key.
+func Combine(shares []*Share, t int) ([]byte, error) {
+	if t < 1 || len(shares) < t {
+		return nil, ErrTooFewShares
+	}
+	shares = shares[:t]
+	seen := make(map[uint32]bool)
+	for _, share := range shares {
+		if share.Index == 0 || len(share.Value) != 32 {
+			return nil, ErrInvalidShare
+		}
+		if seen[share.Index] {
+			return nil, ErrDuplicateShare
+		}
+		seen[share.Index] = true
+	}
+
+	n := order()
+	secret := new(big.Int)
+	for i, si := range shares {
+		xi := new(big.Int).SetUint64(uint64(si.Index))
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j, sj := range shares {
+			if i == j {
+				continue
+			}
+			xj := new(big.Int).SetUint64(uint64(sj.Index))
+			num.Mul(num, xj)
+			num.Mod(num, n)
+			den.Mul(den, new(big.Int).Sub(xj, xi))
+			den.Mod(den, n)
+		}
+		term := new(big.Int).SetBytes(si.Value)
+		term.Mul(term, num)
+		term.Mul(term, den.ModInverse(den, n))
+		secret.Add(secret, term)
+		secret.Mod(secret, n)
+	}
+	if secret.Sign() == 0 {
+		return nil, ErrInvalidShare
+	}
+	return toBytes(secret), nil
+}
+
+// Refresh returns new shares of the same key and indexes, adding the values of a random polynomial
+// of the constant 0. The old shares are of no use combined with the new ones, so a share leaked
+// before the refresh is worthless after it. All n shares are refreshed at once.
+func Refresh(shares []*Share, t int) ([]*Share, error) {
+	if err := checkThreshold(t, len(shares)); err != nil {
+		return nil, err
+	}
+	zero, err := polynomial(new(big.Int), t)
+	if err != nil {
+		return nil, err
+	}
+	n := order()
+	refreshed := make([]*Share, len(shares))
+	for i, share := range shares {
+		if share.Index == 0 || len(share.Value) != 32 {
+			return nil, ErrInvalidShare
+		}
+		v := new(big.Int).SetBytes(share.Value)
+		v.Add(v, evaluate(zero, share.Index))
+		v.Mod(v, n)
+		refreshed[i] = &Share{Index: share.Index, Value: toBytes(v)}
+	}
+	return refreshed, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCombine(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey().Encoded()
+	assert.Nil(t, err)
+
+	shares, err := Split(key, 3, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(shares))
+
+	// any 3 shares combine into the key.
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}} {
+		picked := []*Share{shares[subset[0]], shares[subset[1]], shares[subset[2]]}
+		got, err := Combine(picked, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, key, got)
+	}
+
+	// 2 shares are not enough, and a corrupted one gives another key.
+	_, err = Combine(shares[:2], 3)
+	assert.Equal(t, ErrTooFewShares, err)
+	got, err := Combine(shares[:2], 2)
+	assert.Nil(t, err)
+	assert.NotEqual(t, key, got)
+	_, err = Combine([]*Share{shares[0], shares[0], shares[1]}, 3)
+	assert.Equal(t, ErrDuplicateShare, err)
+
+	_, err = Split(key, 4, 3)
+	assert.Equal(t, ErrInvalidThreshold, err)
+	_, err = Split(key, 0, 3)
+	assert.Equal(t, ErrInvalidThreshold, err)
+}
+
+func TestRefresh(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey().Encoded()
+	assert.Nil(t, err)
+	shares, err := Split(key, 2, 3)
+	assert.Nil(t, err)
+
+	refreshed, err := Refresh(shares, 2)
+	assert.Nil(t, err)
+	for i := range shares {
+		assert.Equal(t, shares[i].Index, refreshed[i].Index)
+		assert.NotEqual(t, shares[i].Value, refreshed[i].Value)
+	}
+	got, err := Combine([]*Share{refreshed[2], refreshed[0]}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, key, got)
+
+	// an old share is worthless with the new ones.
+	got, err = Combine([]*Share{shares[0], refreshed[1]}, 2)
+	assert.Nil(t, err)
+	assert.NotEqual(t, key, got)
+}
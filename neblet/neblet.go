@@ -3,6 +3,7 @@ package neblet
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/nebulasio/go-nebulas/account"
 	"github.com/nebulasio/go-nebulas/consensus"
@@ -19,6 +20,7 @@ import (
 	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	m "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -27,6 +29,9 @@ var (
 
 	// ErrIncompatibleStorageSchemeVersion throws when the storage schema has been changed
 	ErrIncompatibleStorageSchemeVersion = errors.New("incompatible storage schema version, pls migrate your storage")
+
+	// ErrNondeterministicEnvironment throws when the execution environment may break the determinism of consensus.
+	ErrNondeterministicEnvironment = errors.New("execution environment may break the determinism of consensus")
 )
 
 var (
@@ -53,6 +58,8 @@ type Neblet struct {
 
 	syncManager *nsync.Manager
 
+	lightClient *nsync.LightClient
+
 	apiServer rpc.Server
 
 	managementServer rpc.Server
@@ -76,6 +83,33 @@ func New(config nebletpb.Config) (*Neblet, error) {
 	return n, nil
 }
 
+// openStorage opens the storage of datadir, encrypted if the passphrase or the kms is configured.
+func (n *Neblet) openStorage() (storage.Storage, error) {
+	db, err := storage.Open(n.config.Chain.StorageDriver, n.config.Chain.Datadir)
+	if err != nil {
+		return nil, err
+	}
+	var source storage.KeySource
+	if len(n.config.Chain.StorageKmsCommand) > 0 {
+		source = storage.KMSKey(n.config.Chain.StorageKmsCommand)
+	} else if len(n.config.Chain.StoragePassphrase) > 0 {
+		source = storage.PassphraseKey(n.config.Chain.StoragePassphrase)
+	}
+	if source == nil {
+		if err := storage.CheckNotEncrypted(db); err != nil {
+			db.Close()
+			return nil, err
+		}
+		return db, nil
+	}
+	encrypted, err := storage.NewEncryptedStorage(db, source)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return encrypted, nil
+}
+
 // Setup setup neblet
 func (n *Neblet) Setup() error {
 	var err error
@@ -84,25 +118,50 @@ func (n *Neblet) Setup() error {
 	if err != nil {
 		return err
 	}
-	n.storage, err = storage.NewDiskStorage(n.config.Chain.Datadir)
-	// storage, err := storage.NewMemoryStorage()
+	n.storage, err = n.openStorage()
 	if err != nil {
 		return err
 	}
 	if err = n.checkSchemeVersion(n.storage); err != nil {
 		return err
 	}
+	if err = n.checkExecutionEnvironment(); err != nil {
+		return err
+	}
 	n.eventEmitter = core.NewEventEmitter(1024)
 	n.blockChain, err = core.NewBlockChain(n)
 	if err != nil {
 		return err
 	}
+	// peers of another genesis are refused in handshake.
+	n.netService.Node().Config().Genesis = n.blockChain.GenesisDigest()
+	n.netService.Node().Config().LatestIrreversibleBlock = func() []byte {
+		return n.blockChain.LatestIrreversibleBlock().Hash()
+	}
 	gasPrice := util.NewUint128FromString(n.config.Chain.GasPrice)
 	gasLimit := util.NewUint128FromString(n.config.Chain.GasLimit)
 	n.blockChain.TransactionPool().SetGasConfig(gasPrice, gasLimit)
+	n.blockChain.TransactionPool().SetEvictionConfig(int(n.config.Chain.TxPoolSize),
+		int(n.config.Chain.TxPoolSenderLimit), time.Duration(n.config.Chain.TxPoolLifetime)*time.Second)
+	n.blockChain.TransactionPool().SetPriceBump(int(n.config.Chain.TxPoolPriceBump))
 
 	n.blockChain.BlockPool().RegisterInNetwork(n.netService)
+	n.blockChain.BlockPool().SetCompactRelay(n.config.Chain.CompactBlockRelay)
+	if err = n.blockChain.SetNodeMode(n.config.Chain.NodeMode, n.config.Chain.PruneRetention,
+		n.config.Chain.PruneCheckpointInterval); err != nil {
+		return err
+	}
+	for _, v := range n.config.Chain.Checkpoints {
+		cp, err := core.ParseCheckpoint(v)
+		if err != nil {
+			return err
+		}
+		if err = n.blockChain.AddCheckpoint(cp); err != nil {
+			return err
+		}
+	}
 	n.blockChain.TransactionPool().RegisterInNetwork(n.netService)
+	n.blockChain.BlockServer().RegisterInNetwork(n.netService)
 
 	n.consensus, err = dpos.NewDpos(n)
 	if err != nil {
@@ -112,6 +171,15 @@ func (n *Neblet) Setup() error {
 
 	// start sync service
 	n.syncManager = nsync.NewManager(n.blockChain, n.consensus, n.netService)
+	n.syncManager.SetFastSync(n.config.Chain.FastSync)
+
+	if n.config.Chain.LightClient {
+		headers, err := core.NewHeaderChain(n.blockChain.GenesisBlock(), n.storage)
+		if err != nil {
+			return err
+		}
+		n.lightClient = nsync.NewLightClient(headers, n.netService, n.lightServers)
+	}
 
 	n.apiServer = rpc.NewAPIServer(n)
 	return nil
@@ -143,10 +211,18 @@ func (n *Neblet) Start() error {
 
 	n.blockChain.BlockPool().Start()
 	n.blockChain.TransactionPool().Start()
+	n.blockChain.BlockServer().Start()
+	n.blockChain.IntegrityChecker().Start()
+	n.blockChain.EventFilters().Start()
 	n.eventEmitter.Start()
 
-	n.syncManager.Start()
-	n.consensus.Start()
+	// a light client syncs only headers, and never mints.
+	if n.lightClient != nil {
+		n.lightClient.Start()
+	} else {
+		n.syncManager.Start()
+		n.consensus.Start()
+	}
 
 	nebstartGauge.Update(1)
 	// TODO: error handling
@@ -160,13 +236,19 @@ func (n *Neblet) Stop() error {
 
 	logging.VLog().Info("Stopping neblet...")
 
-	if n.consensus != nil {
+	if n.lightClient != nil {
+		n.lightClient.Stop()
+		n.lightClient = nil
+	} else if n.consensus != nil {
 		n.consensus.Stop()
-		n.consensus = nil
 	}
+	n.consensus = nil
 
 	if n.blockChain != nil {
 		n.blockChain.BlockPool().Stop()
+		n.blockChain.BlockServer().Stop()
+		n.blockChain.IntegrityChecker().Stop()
+		n.blockChain.EventFilters().Stop()
 		n.blockChain = nil
 	}
 
@@ -221,11 +303,34 @@ func (n *Neblet) Storage() storage.Storage {
 	return n.storage
 }
 
+// LightClient returns the light client, nil if not in light client mode.
+func (n *Neblet) LightClient() *nsync.LightClient {
+	return n.lightClient
+}
+
+// lightServers returns the peers serving light clients.
+func (n *Neblet) lightServers() []string {
+	ns, ok := n.netService.(*p2p.NetService)
+	if !ok {
+		return nil
+	}
+	var peers []string
+	for _, pid := range ns.PeersWithServices(p2p.ServiceLightServer) {
+		peers = append(peers, pid.Pretty())
+	}
+	return peers
+}
+
 // StartSync starts sync
 func (n *Neblet) StartSync() {
 	n.syncManager.Start()
 }
 
+// SyncManager returns sync manager reference.
+func (n *Neblet) SyncManager() *nsync.Manager {
+	return n.syncManager
+}
+
 // BlockChain returns block chain reference.
 func (n *Neblet) BlockChain() *core.BlockChain {
 	return n.blockChain
@@ -261,3 +366,26 @@ func (n *Neblet) checkSchemeVersion(stor storage.Storage) error {
 	}
 	return nil
 }
+
+// checks if the execution environment is the same as the other validators'.
+func (n *Neblet) checkExecutionEnvironment() error {
+	env := core.CurrentExecutionEnvironment()
+	problems := env.Check()
+	if len(problems) == 0 {
+		logging.VLog().WithFields(logrus.Fields{
+			"fingerprint": env.Fingerprint(),
+		}).Info("Execution environment checked.")
+		return nil
+	}
+
+	for _, problem := range problems {
+		logging.CLog().WithFields(logrus.Fields{
+			"problem":     problem,
+			"fingerprint": env.Fingerprint(),
+		}).Warn("!!! Execution environment may break the determinism of consensus !!!")
+	}
+	if n.config.Chain.StrictEnvironment {
+		return ErrNondeterministicEnvironment
+	}
+	return nil
+}
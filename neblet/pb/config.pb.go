@@ -12,6 +12,8 @@ It has these top-level messages:
 	NetworkConfig
 	ChainConfig
 	RPCConfig
+	APIKeyConfig
+	QuotaConfig
 	AppConfig
 	MiscConfig
 	StatsConfig
@@ -52,7 +54,7 @@ func (x StatsConfig_ReportingModule) String() string {
 	return proto.EnumName(StatsConfig_ReportingModule_name, int32(x))
 }
 func (StatsConfig_ReportingModule) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorConfig, []int{6, 0}
+	return fileDescriptorConfig, []int{8, 0}
 }
 
 // Neblet global configurations.
@@ -127,6 +129,17 @@ type NetworkConfig struct {
 	PrivateKey string `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
 	// Network ID
 	NetworkId uint32 `protobuf:"varint,4,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	// Lower bound of the discovery interval in seconds, used when the route table is small or churning.
+	DiscoveryMinInterval uint32 `protobuf:"varint,5,opt,name=discovery_min_interval,json=discoveryMinInterval,proto3" json:"discovery_min_interval,omitempty"`
+	// Upper bound of the discovery interval in seconds, used when the route table is full and stable.
+	DiscoveryMaxInterval uint32 `protobuf:"varint,6,opt,name=discovery_max_interval,json=discoveryMaxInterval,proto3" json:"discovery_max_interval,omitempty"`
+	// Addresses advertised to other peers, in multiaddr form. If empty, the listen addresses are advertised,
+	// private ones are only advertised to private peers.
+	Advertise []string `protobuf:"bytes,7,rep,name=advertise" json:"advertise,omitempty"`
+	// Services the node provides: full, light_server, archive, relay, tx_indexer. Default is full and relay.
+	Services []string `protobuf:"bytes,8,rep,name=services" json:"services,omitempty"`
+	// Stamp inbound messages with trace IDs and record the latencies of processing stages.
+	EnableTracing bool `protobuf:"varint,9,opt,name=enable_tracing,json=enableTracing,proto3" json:"enable_tracing,omitempty"`
 }
 
 func (m *NetworkConfig) Reset()                    { *m = NetworkConfig{} }
@@ -162,6 +175,41 @@ func (m *NetworkConfig) GetNetworkId() uint32 {
 	return 0
 }
 
+func (m *NetworkConfig) GetDiscoveryMinInterval() uint32 {
+	if m != nil {
+		return m.DiscoveryMinInterval
+	}
+	return 0
+}
+
+func (m *NetworkConfig) GetDiscoveryMaxInterval() uint32 {
+	if m != nil {
+		return m.DiscoveryMaxInterval
+	}
+	return 0
+}
+
+func (m *NetworkConfig) GetAdvertise() []string {
+	if m != nil {
+		return m.Advertise
+	}
+	return nil
+}
+
+func (m *NetworkConfig) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *NetworkConfig) GetEnableTracing() bool {
+	if m != nil {
+		return m.EnableTracing
+	}
+	return false
+}
+
 type ChainConfig struct {
 	// ChainID.
 	ChainId uint32 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
@@ -181,8 +229,70 @@ type ChainConfig struct {
 	GasPrice string `protobuf:"bytes,24,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
 	// Max GasLimit.
 	GasLimit string `protobuf:"bytes,25,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
-	// Supported signature cipher list. ["ECC_SECP256K1"]
+	// Supported signature cipher list, the first one is of the new accounts. ["ECC_SECP256K1", "EDDSA_ED25519"]
 	SignatureCiphers []string `protobuf:"bytes,26,rep,name=signature_ciphers,json=signatureCiphers" json:"signature_ciphers,omitempty"`
+	// Refuse to start if the execution environment may break the determinism of consensus, only warn if false.
+	StrictEnvironment bool `protobuf:"varint,27,opt,name=strict_environment,json=strictEnvironment,proto3" json:"strict_environment,omitempty"`
+	// Fetch the states of a recent block from peers instead of replaying all blocks when far behind.
+	FastSync bool `protobuf:"varint,28,opt,name=fast_sync,json=fastSync,proto3" json:"fast_sync,omitempty"`
+	// Follow the chain and seal blocks only when the primary node of the same miner is silent.
+	Standby bool `protobuf:"varint,29,opt,name=standby,proto3" json:"standby,omitempty"`
+	// Seconds without blocks of the primary before the standby takes over, two rounds of dynasty if 0.
+	StandbySilence uint32 `protobuf:"varint,30,opt,name=standby_silence,json=standbySilence,proto3" json:"standby_silence,omitempty"`
+	// Sync only headers and query the states with proofs from the peers serving light clients.
+	LightClient bool `protobuf:"varint,31,opt,name=light_client,json=lightClient,proto3" json:"light_client,omitempty"`
+	// Announce blocks by header and transaction hashes, peers fetch only the transactions missing in their pools.
+	CompactBlockRelay bool `protobuf:"varint,32,opt,name=compact_block_relay,json=compactBlockRelay,proto3" json:"compact_block_relay,omitempty"`
+	// The count of recent block states kept by a full node, at least 128 blocks, 1024 if 0.
+	PruneRetention uint64 `protobuf:"varint,33,opt,name=prune_retention,json=pruneRetention,proto3" json:"prune_retention,omitempty"`
+	// Keep the states of the blocks at the heights of multiples of it when pruning, none if 0.
+	PruneCheckpointInterval uint64 `protobuf:"varint,34,opt,name=prune_checkpoint_interval,json=pruneCheckpointInterval,proto3" json:"prune_checkpoint_interval,omitempty"`
+	// "archive" keeps all the historical states, "full" keeps the recent states only, archive if empty.
+	NodeMode string `protobuf:"bytes,35,opt,name=node_mode,json=nodeMode,proto3" json:"node_mode,omitempty"`
+	// The trusted blocks in format of height:hash, the chain never reorganizes below them.
+	Checkpoints []string `protobuf:"bytes,36,rep,name=checkpoints" json:"checkpoints,omitempty"`
+	// The max count of transactions in pool, the cheapest are evicted if full, 4096 if 0.
+	TxPoolSize uint32 `protobuf:"varint,37,opt,name=tx_pool_size,json=txPoolSize,proto3" json:"tx_pool_size,omitempty"`
+	// The max count of transactions of a sender in pool, 256 if 0.
+	TxPoolSenderLimit uint32 `protobuf:"varint,38,opt,name=tx_pool_sender_limit,json=txPoolSenderLimit,proto3" json:"tx_pool_sender_limit,omitempty"`
+	// Seconds a transaction stays in pool before expired, 3 hours if 0.
+	TxPoolLifetime uint64 `protobuf:"varint,39,opt,name=tx_pool_lifetime,json=txPoolLifetime,proto3" json:"tx_pool_lifetime,omitempty"`
+	// The min percentage of the gas price raised to replace a transaction of the same nonce in pool, 10 if 0.
+	TxPoolPriceBump uint32 `protobuf:"varint,40,opt,name=tx_pool_price_bump,json=txPoolPriceBump,proto3" json:"tx_pool_price_bump,omitempty"`
+	// Milliseconds before its slot the miner starts to pre-build its block, collecting the transactions
+	// arriving till the slot, and the block is sealed at the slot. Pre-building is off if 0.
+	MintLeadTime uint32 `protobuf:"varint,41,opt,name=mint_lead_time,json=mintLeadTime,proto3" json:"mint_lead_time,omitempty"`
+	// Endpoints of the remote signers holding the key of the miner to sign blocks, in https://, http:// of
+	// loopback only, or unix:// of a local socket. The first is the primary, the others are standbys tried
+	// in order if it fails. The key of the miner in keystore signs blocks if empty.
+	RemoteSigners []string `protobuf:"bytes,42,rep,name=remote_signers,json=remoteSigners" json:"remote_signers,omitempty"`
+	// The storage driver of datadir, leveldb if empty. One of leveldb and memory, or badger in the builds
+	// with tag badger (go build -tags badger), or rocksdb in the builds with tag rocksdb, which links
+	// librocksdb and needs it installed with its headers (CGO_CFLAGS and CGO_LDFLAGS pointing to them).
+	StorageDriver string `protobuf:"bytes,43,opt,name=storage_driver,json=storageDriver,proto3" json:"storage_driver,omitempty"`
+	// Passphrase the key encrypting the values in storage is derived from, for the datadir on shared disks.
+	// The datadir must be new or encrypted by it. Values are kept in plaintext if both this and
+	// storage_kms_command are empty.
+	StoragePassphrase string `protobuf:"bytes,44,opt,name=storage_passphrase,json=storagePassphrase,proto3" json:"storage_passphrase,omitempty"`
+	// Command of a KMS client printing the key encrypting the values in storage in hex, it takes the
+	// place of storage_passphrase.
+	StorageKmsCommand string `protobuf:"bytes,45,opt,name=storage_kms_command,json=storageKmsCommand,proto3" json:"storage_kms_command,omitempty"`
+	// Holders of the key shares of the miner to sign blocks, any signing_threshold of them combine into
+	// its key. In file:// of a local share file, or https:// and unix:// of share holders requested like
+	// the remote signers. The remote signers, or else the key of the miner in keystore, sign blocks if
+	// too few shares are collected.
+	ThresholdSigners []string `protobuf:"bytes,46,rep,name=threshold_signers,json=thresholdSigners" json:"threshold_signers,omitempty"`
+	// The count of the key shares combined into the key of the miner.
+	SigningThreshold uint32 `protobuf:"varint,47,opt,name=signing_threshold,json=signingThreshold,proto3" json:"signing_threshold,omitempty"`
+	// Token sent to the remote signers and share holders in header "Authorization" as "Bearer <token>".
+	SignerToken string `protobuf:"bytes,48,opt,name=signer_token,json=signerToken,proto3" json:"signer_token,omitempty"`
+	// TLS files in PEM of the remote signers and share holders in https://, the CA verifying them, the
+	// system roots if empty, and the certificate and key of the node for mutual TLS, required by the holders.
+	SignerTlsCa   string `protobuf:"bytes,49,opt,name=signer_tls_ca,json=signerTlsCa,proto3" json:"signer_tls_ca,omitempty"`
+	SignerTlsCert string `protobuf:"bytes,50,opt,name=signer_tls_cert,json=signerTlsCert,proto3" json:"signer_tls_cert,omitempty"`
+	SignerTlsKey  string `protobuf:"bytes,51,opt,name=signer_tls_key,json=signerTlsKey,proto3" json:"signer_tls_key,omitempty"`
+	// Passphrase decrypting the share files in file:// of threshold_signers.
+	SharePassphrase string `protobuf:"bytes,52,opt,name=share_passphrase,json=sharePassphrase,proto3" json:"share_passphrase,omitempty"`
 }
 
 func (m *ChainConfig) Reset()                    { *m = ChainConfig{} }
@@ -260,6 +370,188 @@ func (m *ChainConfig) GetSignatureCiphers() []string {
 	return nil
 }
 
+func (m *ChainConfig) GetStrictEnvironment() bool {
+	if m != nil {
+		return m.StrictEnvironment
+	}
+	return false
+}
+
+func (m *ChainConfig) GetFastSync() bool {
+	if m != nil {
+		return m.FastSync
+	}
+	return false
+}
+
+func (m *ChainConfig) GetStandby() bool {
+	if m != nil {
+		return m.Standby
+	}
+	return false
+}
+
+func (m *ChainConfig) GetStandbySilence() uint32 {
+	if m != nil {
+		return m.StandbySilence
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetLightClient() bool {
+	if m != nil {
+		return m.LightClient
+	}
+	return false
+}
+
+func (m *ChainConfig) GetCompactBlockRelay() bool {
+	if m != nil {
+		return m.CompactBlockRelay
+	}
+	return false
+}
+
+func (m *ChainConfig) GetPruneRetention() uint64 {
+	if m != nil {
+		return m.PruneRetention
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetPruneCheckpointInterval() uint64 {
+	if m != nil {
+		return m.PruneCheckpointInterval
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetNodeMode() string {
+	if m != nil {
+		return m.NodeMode
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetCheckpoints() []string {
+	if m != nil {
+		return m.Checkpoints
+	}
+	return nil
+}
+
+func (m *ChainConfig) GetTxPoolSize() uint32 {
+	if m != nil {
+		return m.TxPoolSize
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetTxPoolSenderLimit() uint32 {
+	if m != nil {
+		return m.TxPoolSenderLimit
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetTxPoolLifetime() uint64 {
+	if m != nil {
+		return m.TxPoolLifetime
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetTxPoolPriceBump() uint32 {
+	if m != nil {
+		return m.TxPoolPriceBump
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetMintLeadTime() uint32 {
+	if m != nil {
+		return m.MintLeadTime
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetRemoteSigners() []string {
+	if m != nil {
+		return m.RemoteSigners
+	}
+	return nil
+}
+
+func (m *ChainConfig) GetStorageDriver() string {
+	if m != nil {
+		return m.StorageDriver
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetStoragePassphrase() string {
+	if m != nil {
+		return m.StoragePassphrase
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetStorageKmsCommand() string {
+	if m != nil {
+		return m.StorageKmsCommand
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetThresholdSigners() []string {
+	if m != nil {
+		return m.ThresholdSigners
+	}
+	return nil
+}
+
+func (m *ChainConfig) GetSigningThreshold() uint32 {
+	if m != nil {
+		return m.SigningThreshold
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetSignerToken() string {
+	if m != nil {
+		return m.SignerToken
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetSignerTlsCa() string {
+	if m != nil {
+		return m.SignerTlsCa
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetSignerTlsCert() string {
+	if m != nil {
+		return m.SignerTlsCert
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetSignerTlsKey() string {
+	if m != nil {
+		return m.SignerTlsKey
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetSharePassphrase() string {
+	if m != nil {
+		return m.SharePassphrase
+	}
+	return ""
+}
+
 type RPCConfig struct {
 	// RPC listen addresses.
 	RpcListen []string `protobuf:"bytes,1,rep,name=rpc_listen,json=rpcListen" json:"rpc_listen,omitempty"`
@@ -267,6 +559,22 @@ type RPCConfig struct {
 	HttpListen []string `protobuf:"bytes,2,rep,name=http_listen,json=httpListen" json:"http_listen,omitempty"`
 	// Enabled HTTP modules.["api", "admin"]
 	HttpModule []string `protobuf:"bytes,3,rep,name=http_module,json=httpModule" json:"http_module,omitempty"`
+	// API keys with their quotas, passed in header "X-Api-Key" of HTTP or metadata "x-api-key" of gRPC.
+	ApiKeys []*APIKeyConfig `protobuf:"bytes,4,rep,name=api_keys,json=apiKeys" json:"api_keys,omitempty"`
+	// Quota of the requests without an API key.
+	AnonymousQuota *QuotaConfig `protobuf:"bytes,5,opt,name=anonymous_quota,json=anonymousQuota" json:"anonymous_quota,omitempty"`
+	// Reject the requests without a valid API key.
+	RequireApiKey bool `protobuf:"varint,6,opt,name=require_api_key,json=requireApiKey,proto3" json:"require_api_key,omitempty"`
+	// TLS certificate and key files in PEM, the RPC and HTTP listeners serve TLS if both are set.
+	TlsCert string `protobuf:"bytes,7,opt,name=tls_cert,json=tlsCert,proto3" json:"tls_cert,omitempty"`
+	TlsKey  string `protobuf:"bytes,8,opt,name=tls_key,json=tlsKey,proto3" json:"tls_key,omitempty"`
+	// Token required in metadata "authorization" of gRPC, or header "Authorization" of HTTP, as "Bearer <token>".
+	AuthToken string `protobuf:"bytes,9,opt,name=auth_token,json=authToken,proto3" json:"auth_token,omitempty"`
+	// gRPC listen addresses of the admin service, it's served apart from the API on them if set, otherwise on rpc_listen.
+	AdminListen []string `protobuf:"bytes,10,rep,name=admin_listen,json=adminListen" json:"admin_listen,omitempty"`
+	// Token required in metadata "x-admin-token" of gRPC, or header "X-Admin-Token" of HTTP, by the admin service.
+	// The admin service only accepts the requests from localhost without it.
+	AdminToken string `protobuf:"bytes,11,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
 }
 
 func (m *RPCConfig) Reset()                    { *m = RPCConfig{} }
@@ -295,6 +603,139 @@ func (m *RPCConfig) GetHttpModule() []string {
 	return nil
 }
 
+func (m *RPCConfig) GetApiKeys() []*APIKeyConfig {
+	if m != nil {
+		return m.ApiKeys
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetAnonymousQuota() *QuotaConfig {
+	if m != nil {
+		return m.AnonymousQuota
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetRequireApiKey() bool {
+	if m != nil {
+		return m.RequireApiKey
+	}
+	return false
+}
+
+func (m *RPCConfig) GetTlsCert() string {
+	if m != nil {
+		return m.TlsCert
+	}
+	return ""
+}
+
+func (m *RPCConfig) GetTlsKey() string {
+	if m != nil {
+		return m.TlsKey
+	}
+	return ""
+}
+
+func (m *RPCConfig) GetAuthToken() string {
+	if m != nil {
+		return m.AuthToken
+	}
+	return ""
+}
+
+func (m *RPCConfig) GetAdminListen() []string {
+	if m != nil {
+		return m.AdminListen
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetAdminToken() string {
+	if m != nil {
+		return m.AdminToken
+	}
+	return ""
+}
+
+type APIKeyConfig struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Name of the key in logs and metrics.
+	Name  string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quota *QuotaConfig `protobuf:"bytes,3,opt,name=quota" json:"quota,omitempty"`
+}
+
+func (m *APIKeyConfig) Reset()                    { *m = APIKeyConfig{} }
+func (m *APIKeyConfig) String() string            { return proto.CompactTextString(m) }
+func (*APIKeyConfig) ProtoMessage()               {}
+func (*APIKeyConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{4} }
+
+func (m *APIKeyConfig) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *APIKeyConfig) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *APIKeyConfig) GetQuota() *QuotaConfig {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+// QuotaConfig limits the consumption of a RPC consumer, 0 for unlimited.
+type QuotaConfig struct {
+	// Requests per second, and the burst allowed above it.
+	RequestsPerSecond float64 `protobuf:"fixed64,1,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	Burst             uint32  `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	// Subscriptions open at the same time.
+	MaxSubscriptions uint32 `protobuf:"varint,3,opt,name=max_subscriptions,json=maxSubscriptions,proto3" json:"max_subscriptions,omitempty"`
+	// Events per second sent to subscriptions, the events above it are dropped.
+	EventsPerSecond float64 `protobuf:"fixed64,4,opt,name=events_per_second,json=eventsPerSecond,proto3" json:"events_per_second,omitempty"`
+}
+
+func (m *QuotaConfig) Reset()                    { *m = QuotaConfig{} }
+func (m *QuotaConfig) String() string            { return proto.CompactTextString(m) }
+func (*QuotaConfig) ProtoMessage()               {}
+func (*QuotaConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{5} }
+
+func (m *QuotaConfig) GetRequestsPerSecond() float64 {
+	if m != nil {
+		return m.RequestsPerSecond
+	}
+	return 0
+}
+
+func (m *QuotaConfig) GetBurst() uint32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
+func (m *QuotaConfig) GetMaxSubscriptions() uint32 {
+	if m != nil {
+		return m.MaxSubscriptions
+	}
+	return 0
+}
+
+func (m *QuotaConfig) GetEventsPerSecond() float64 {
+	if m != nil {
+		return m.EventsPerSecond
+	}
+	return 0
+}
+
 type AppConfig struct {
 	LogLevel          string `protobuf:"bytes,1,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
 	LogFile           string `protobuf:"bytes,2,opt,name=log_file,json=logFile,proto3" json:"log_file,omitempty"`
@@ -305,7 +746,7 @@ type AppConfig struct {
 func (m *AppConfig) Reset()                    { *m = AppConfig{} }
 func (m *AppConfig) String() string            { return proto.CompactTextString(m) }
 func (*AppConfig) ProtoMessage()               {}
-func (*AppConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{4} }
+func (*AppConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{6} }
 
 func (m *AppConfig) GetLogLevel() string {
 	if m != nil {
@@ -343,7 +784,7 @@ type MiscConfig struct {
 func (m *MiscConfig) Reset()                    { *m = MiscConfig{} }
 func (m *MiscConfig) String() string            { return proto.CompactTextString(m) }
 func (*MiscConfig) ProtoMessage()               {}
-func (*MiscConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{5} }
+func (*MiscConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{7} }
 
 func (m *MiscConfig) GetDefaultKeystoreFileCiper() string {
 	if m != nil {
@@ -363,7 +804,7 @@ type StatsConfig struct {
 func (m *StatsConfig) Reset()                    { *m = StatsConfig{} }
 func (m *StatsConfig) String() string            { return proto.CompactTextString(m) }
 func (*StatsConfig) ProtoMessage()               {}
-func (*StatsConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{6} }
+func (*StatsConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{8} }
 
 func (m *StatsConfig) GetEnableMetrics() bool {
 	if m != nil {
@@ -402,7 +843,7 @@ type InfluxdbConfig struct {
 func (m *InfluxdbConfig) Reset()                    { *m = InfluxdbConfig{} }
 func (m *InfluxdbConfig) String() string            { return proto.CompactTextString(m) }
 func (*InfluxdbConfig) ProtoMessage()               {}
-func (*InfluxdbConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{7} }
+func (*InfluxdbConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{9} }
 
 func (m *InfluxdbConfig) GetHost() string {
 	if m != nil {
@@ -444,6 +885,8 @@ func init() {
 	proto.RegisterType((*NetworkConfig)(nil), "nebletpb.NetworkConfig")
 	proto.RegisterType((*ChainConfig)(nil), "nebletpb.ChainConfig")
 	proto.RegisterType((*RPCConfig)(nil), "nebletpb.RPCConfig")
+	proto.RegisterType((*APIKeyConfig)(nil), "nebletpb.APIKeyConfig")
+	proto.RegisterType((*QuotaConfig)(nil), "nebletpb.QuotaConfig")
 	proto.RegisterType((*AppConfig)(nil), "nebletpb.AppConfig")
 	proto.RegisterType((*MiscConfig)(nil), "nebletpb.MiscConfig")
 	proto.RegisterType((*StatsConfig)(nil), "nebletpb.StatsConfig")
@@ -454,50 +897,102 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptorConfig) }
 
 var fileDescriptorConfig = []byte{
-	// 720 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x94, 0xdb, 0x6a, 0x2b, 0x37,
-	0x14, 0x86, 0x6b, 0x3b, 0x87, 0x99, 0xe5, 0xc4, 0xdb, 0x5b, 0x39, 0x29, 0x09, 0x6d, 0xc2, 0x40,
-	0xc0, 0x10, 0x30, 0x34, 0xed, 0x6d, 0x2f, 0x8a, 0xa1, 0x10, 0x92, 0x94, 0x30, 0xa5, 0xd7, 0xc3,
-	0x1c, 0x96, 0xc7, 0x22, 0xf2, 0x8c, 0x90, 0xe4, 0x1c, 0xe8, 0x33, 0xf4, 0x11, 0xfa, 0x08, 0x7d,
-	0xa4, 0xbe, 0x4b, 0x59, 0x1a, 0xcd, 0x38, 0x36, 0xfb, 0x4e, 0xeb, 0xff, 0x3f, 0x69, 0xa4, 0x75,
-	0x18, 0x38, 0xc8, 0xeb, 0x6a, 0x2e, 0xca, 0xa9, 0xd2, 0xb5, 0xad, 0x59, 0x50, 0x61, 0x26, 0xd1,
-	0xaa, 0x2c, 0xfa, 0xbb, 0x0f, 0x7b, 0x33, 0x67, 0xb1, 0x1f, 0x61, 0xbf, 0x42, 0xfb, 0x56, 0xeb,
-	0x17, 0xde, 0xbb, 0xee, 0x4d, 0x86, 0x77, 0x67, 0xd3, 0x16, 0x9b, 0xfe, 0xde, 0x18, 0x0d, 0x19,
-	0xb7, 0x1c, 0xbb, 0x85, 0xdd, 0x7c, 0x91, 0x8a, 0x8a, 0xf7, 0xdd, 0x86, 0x93, 0xf5, 0x86, 0x19,
-	0xc9, 0x1e, 0x6f, 0x18, 0x76, 0x03, 0x03, 0xad, 0x72, 0x3e, 0x70, 0xe8, 0xd1, 0x1a, 0x8d, 0x9f,
-	0x67, 0x1e, 0x24, 0x9f, 0xce, 0x34, 0x36, 0xb5, 0x86, 0x17, 0xdb, 0x67, 0xfe, 0x41, 0x72, 0x7b,
-	0xa6, 0x63, 0xd8, 0x04, 0x76, 0x96, 0xc2, 0xe4, 0x1c, 0x1d, 0x7b, 0xbc, 0x66, 0x9f, 0x84, 0xc9,
-	0x3d, 0xea, 0x08, 0xfa, 0x7a, 0xaa, 0x14, 0x9f, 0x6f, 0x7f, 0xfd, 0x57, 0xa5, 0xda, 0xaf, 0xa7,
-	0x4a, 0x45, 0x7f, 0xc1, 0xe1, 0xc6, 0x5b, 0x19, 0x83, 0x1d, 0x83, 0x58, 0xf0, 0xde, 0xf5, 0x60,
-	0x12, 0xc6, 0x6e, 0xcd, 0x4e, 0x61, 0x4f, 0x0a, 0x63, 0x91, 0xde, 0x4d, 0xaa, 0x8f, 0xd8, 0x15,
-	0x0c, 0x95, 0x16, 0xaf, 0xa9, 0xc5, 0xe4, 0x05, 0x3f, 0xdc, 0x4b, 0xc3, 0x18, 0xbc, 0xf4, 0x80,
-	0x1f, 0xec, 0x7b, 0x00, 0x9f, 0xba, 0x44, 0x14, 0x7c, 0xe7, 0xba, 0x37, 0x39, 0x8c, 0x43, 0xaf,
-	0xdc, 0x17, 0xd1, 0xbf, 0x7d, 0x18, 0x7e, 0x4a, 0x1c, 0x3b, 0x87, 0xc0, 0xa5, 0x8e, 0xe0, 0x9e,
-	0x83, 0xf7, 0x5d, 0x7c, 0x5f, 0x30, 0x0e, 0xfb, 0x25, 0x56, 0x68, 0x84, 0x71, 0xb9, 0x0f, 0xe3,
-	0x36, 0x24, 0xa7, 0x48, 0x6d, 0x5a, 0x08, 0xcd, 0x87, 0x8d, 0xe3, 0x43, 0xba, 0xf6, 0x0b, 0x7e,
-	0x90, 0x71, 0xe0, 0x0c, 0x1f, 0xb1, 0x0b, 0x08, 0xf2, 0x5a, 0x54, 0x59, 0x6a, 0x90, 0x9f, 0x38,
-	0xa7, 0x8b, 0xd9, 0x31, 0xec, 0x2e, 0x45, 0x85, 0x9a, 0x9f, 0x3a, 0xa3, 0x09, 0xd8, 0x0f, 0x00,
-	0x2a, 0x35, 0x46, 0x2d, 0x34, 0xed, 0x39, 0xf3, 0xef, 0xec, 0x14, 0x76, 0x09, 0x61, 0x99, 0x9a,
-	0x44, 0x69, 0x91, 0x23, 0xe7, 0xcd, 0x91, 0x65, 0x6a, 0x9e, 0x29, 0x6e, 0x4d, 0x29, 0x96, 0xc2,
-	0xf2, 0xf3, 0xce, 0x7c, 0xa4, 0x98, 0xdd, 0xc2, 0x57, 0x23, 0xca, 0x2a, 0xb5, 0x2b, 0x8d, 0x49,
-	0x2e, 0xd4, 0x02, 0xb5, 0xe1, 0x17, 0x2e, 0xcb, 0xe3, 0xce, 0x98, 0x35, 0x7a, 0x24, 0x21, 0xec,
-	0x9a, 0x87, 0x72, 0xab, 0x55, 0x9e, 0xf8, 0xc2, 0x34, 0xe5, 0x0a, 0xb5, 0xca, 0x1f, 0xbb, 0xda,
-	0x2c, 0xac, 0x55, 0xc9, 0x46, 0xe1, 0x80, 0xa4, 0x2d, 0x60, 0x59, 0x17, 0x2b, 0x89, 0x7c, 0xb0,
-	0x06, 0x9e, 0x9c, 0x12, 0xfd, 0xd3, 0x83, 0xb0, 0xeb, 0x16, 0x7a, 0x85, 0xac, 0xcb, 0x44, 0xe2,
-	0x2b, 0x4a, 0x57, 0x9c, 0x30, 0x0e, 0x64, 0x5d, 0x3e, 0x52, 0x4c, 0x85, 0x23, 0x73, 0x2e, 0x24,
-	0xb6, 0xe5, 0x91, 0x75, 0xf9, 0x9b, 0x90, 0xc8, 0xa6, 0x70, 0x84, 0x55, 0x9a, 0x49, 0x4c, 0x72,
-	0x9d, 0x9a, 0x45, 0xa2, 0x51, 0xd5, 0xda, 0xba, 0x5e, 0x09, 0xe2, 0xaf, 0x8d, 0x35, 0x23, 0x27,
-	0x76, 0x06, 0x9b, 0xc0, 0xf8, 0x33, 0x98, 0xac, 0xb4, 0x74, 0x8d, 0x13, 0xc6, 0xa3, 0x7c, 0x8d,
-	0xfd, 0xa9, 0x65, 0xf4, 0x00, 0xb0, 0xee, 0x7a, 0xf6, 0x0b, 0x5c, 0x16, 0x38, 0x4f, 0x57, 0xd2,
-	0x52, 0x2f, 0x1a, 0x5b, 0x6b, 0x74, 0xf7, 0xa1, 0xa4, 0xa2, 0xf6, 0x37, 0xe6, 0x1e, 0x79, 0xf0,
-	0x04, 0xdd, 0x70, 0x46, 0x7e, 0xf4, 0x5f, 0x0f, 0x86, 0x9f, 0xe6, 0x8d, 0xdd, 0xc0, 0xc8, 0x5f,
-	0x7b, 0x89, 0x56, 0x8b, 0xdc, 0xb8, 0x13, 0x82, 0xf8, 0xb0, 0x51, 0x9f, 0x1a, 0x91, 0x3d, 0xc3,
-	0xb8, 0xb9, 0xa7, 0xa8, 0xca, 0x36, 0x93, 0x94, 0xea, 0xd1, 0xdd, 0xcd, 0x37, 0xe7, 0x78, 0x1a,
-	0xb7, 0x74, 0x93, 0xe4, 0xf8, 0x8b, 0xde, 0x14, 0xd8, 0xcf, 0x10, 0x88, 0x6a, 0x2e, 0x57, 0xef,
-	0x45, 0xe6, 0xfa, 0x79, 0x78, 0xc7, 0xd7, 0x27, 0xdd, 0x7b, 0xc7, 0x4f, 0x70, 0x47, 0x46, 0x57,
-	0xf0, 0x65, 0xeb, 0x64, 0x76, 0x00, 0x41, 0x8b, 0x8f, 0xbf, 0x8b, 0xde, 0x61, 0xb4, 0xb9, 0x99,
-	0x06, 0x7d, 0x51, 0x1b, 0xeb, 0x33, 0xe3, 0xd6, 0xa4, 0xb9, 0xea, 0xf4, 0xdd, 0xf0, 0xb9, 0x35,
-	0x1b, 0x41, 0xbf, 0xc8, 0xfc, 0x6c, 0xf7, 0x8b, 0x8c, 0x98, 0x95, 0x41, 0xed, 0x8b, 0xe2, 0xd6,
-	0x34, 0x51, 0x34, 0x0d, 0x6f, 0xb5, 0x2e, 0xf8, 0x6e, 0xd3, 0x1b, 0x6d, 0x9c, 0xed, 0xb9, 0x5f,
-	0xf0, 0x4f, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff, 0xca, 0x0a, 0xb9, 0x87, 0x92, 0x05, 0x00, 0x00,
+	// 1541 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x57, 0xe1, 0x72, 0x1b, 0xb7,
+	0x11, 0x2e, 0x2d, 0xd9, 0x22, 0x41, 0x91, 0x92, 0x10, 0xc7, 0x86, 0xe3, 0x38, 0xa6, 0xd9, 0x28,
+	0x61, 0xe2, 0x46, 0xa9, 0x15, 0xff, 0xea, 0x4c, 0x3b, 0x93, 0xb0, 0xed, 0x8c, 0x46, 0x52, 0x47,
+	0x3d, 0xb9, 0xbf, 0x31, 0xe0, 0xdd, 0x8a, 0xc4, 0xe8, 0x0e, 0xb8, 0x00, 0x20, 0x23, 0xe6, 0x1d,
+	0xfa, 0x08, 0xed, 0x13, 0xf4, 0x41, 0x3a, 0x7d, 0x87, 0xbe, 0x4b, 0x67, 0x17, 0xb8, 0x23, 0xa5,
+	0xe9, 0xe4, 0xdf, 0xed, 0xf7, 0x7d, 0x58, 0x2c, 0x16, 0x0b, 0x2c, 0x8e, 0xed, 0xe7, 0xd6, 0xdc,
+	0xe8, 0xf9, 0x49, 0xed, 0x6c, 0xb0, 0xbc, 0x6b, 0x60, 0x56, 0x42, 0xa8, 0x67, 0xe3, 0xbf, 0x3f,
+	0x62, 0x4f, 0xa6, 0x44, 0xf1, 0x77, 0x6c, 0xcf, 0x40, 0xf8, 0xc9, 0xba, 0x5b, 0xd1, 0x19, 0x75,
+	0x26, 0xfd, 0xd3, 0xe7, 0x27, 0x8d, 0xec, 0xe4, 0x2f, 0x91, 0x88, 0xca, 0xac, 0xd1, 0xf1, 0xb7,
+	0xec, 0x71, 0xbe, 0x50, 0xda, 0x88, 0x47, 0x34, 0xe0, 0xe3, 0xcd, 0x80, 0x29, 0xc2, 0x49, 0x1e,
+	0x35, 0xfc, 0x98, 0xed, 0xb8, 0x3a, 0x17, 0x3b, 0x24, 0xfd, 0x68, 0x23, 0xcd, 0xae, 0xa6, 0x49,
+	0x88, 0x3c, 0xfa, 0xf4, 0x41, 0x05, 0x2f, 0x8a, 0x87, 0x3e, 0xaf, 0x11, 0x6e, 0x7c, 0x92, 0x86,
+	0x4f, 0xd8, 0x6e, 0xa5, 0x7d, 0x2e, 0x80, 0xb4, 0x4f, 0x37, 0xda, 0x4b, 0xed, 0xf3, 0x24, 0x25,
+	0x05, 0xce, 0xae, 0xea, 0x5a, 0xdc, 0x3c, 0x9c, 0xfd, 0xfb, 0xba, 0x6e, 0x66, 0x57, 0x75, 0x3d,
+	0xfe, 0xf7, 0x23, 0x36, 0xb8, 0xb7, 0x58, 0xce, 0xd9, 0xae, 0x07, 0x28, 0x44, 0x67, 0xb4, 0x33,
+	0xe9, 0x65, 0xf4, 0xcd, 0x9f, 0xb1, 0x27, 0xa5, 0xf6, 0x01, 0x70, 0xe1, 0x88, 0x26, 0x8b, 0xbf,
+	0x66, 0xfd, 0xda, 0xe9, 0x95, 0x0a, 0x20, 0x6f, 0x61, 0x4d, 0x4b, 0xed, 0x65, 0x2c, 0x41, 0xe7,
+	0xb0, 0xe6, 0xaf, 0x18, 0x4b, 0xb9, 0x93, 0xba, 0x10, 0xbb, 0xa3, 0xce, 0x64, 0x90, 0xf5, 0x12,
+	0x72, 0x56, 0xf0, 0xf7, 0xec, 0x59, 0xa1, 0x7d, 0x6e, 0x57, 0xe0, 0xd6, 0xb2, 0xd2, 0x46, 0x6a,
+	0x13, 0xc0, 0xad, 0x54, 0x29, 0x1e, 0x93, 0xf4, 0x69, 0xcb, 0x5e, 0x6a, 0x73, 0x96, 0xb8, 0x07,
+	0xa3, 0xd4, 0xdd, 0x66, 0xd4, 0x93, 0x87, 0xa3, 0xd4, 0x5d, 0x3b, 0xea, 0x53, 0xd6, 0x53, 0xc5,
+	0x0a, 0x5c, 0xd0, 0x1e, 0xc4, 0x1e, 0x2d, 0x63, 0x03, 0xf0, 0x4f, 0x58, 0xd7, 0x83, 0x5b, 0xe9,
+	0x1c, 0xbc, 0xe8, 0x12, 0xd9, 0xda, 0xfc, 0x98, 0x0d, 0xc1, 0xa8, 0x59, 0x09, 0x32, 0x38, 0x95,
+	0x6b, 0x33, 0x17, 0xbd, 0x51, 0x67, 0xd2, 0xcd, 0x06, 0x11, 0xfd, 0x10, 0xc1, 0xf1, 0x7f, 0x18,
+	0xeb, 0x6f, 0x95, 0x01, 0x7f, 0xc1, 0xba, 0x54, 0x08, 0xb8, 0xf2, 0x0e, 0x05, 0xb6, 0x47, 0xf6,
+	0x59, 0xc1, 0x05, 0xdb, 0x9b, 0x83, 0x01, 0xaf, 0x3d, 0x55, 0x52, 0x2f, 0x6b, 0x4c, 0x64, 0x0a,
+	0x15, 0x54, 0xa1, 0x9d, 0xe8, 0x47, 0x26, 0x99, 0xb8, 0x07, 0xb7, 0xb0, 0x46, 0x62, 0x9f, 0x88,
+	0x64, 0x61, 0xe4, 0xb9, 0xd5, 0x66, 0xa6, 0x3c, 0x88, 0x8f, 0x89, 0x69, 0x6d, 0xfe, 0x94, 0x3d,
+	0xae, 0xb4, 0x01, 0x27, 0x9e, 0x11, 0x11, 0x0d, 0xfe, 0x19, 0x63, 0xb5, 0xf2, 0xbe, 0x5e, 0x38,
+	0x1c, 0xf3, 0x3c, 0x6d, 0x5a, 0x8b, 0xf0, 0x97, 0xac, 0x37, 0x57, 0x5e, 0xd6, 0x4e, 0xe7, 0x20,
+	0x44, 0x74, 0x39, 0x57, 0xfe, 0x0a, 0xed, 0x86, 0x2c, 0x75, 0xa5, 0x83, 0x78, 0xd1, 0x92, 0x17,
+	0x68, 0xf3, 0xb7, 0xec, 0xc8, 0xeb, 0xb9, 0x51, 0x61, 0xe9, 0x40, 0xe6, 0xba, 0x5e, 0x80, 0xf3,
+	0xe2, 0x13, 0x4a, 0xe7, 0x61, 0x4b, 0x4c, 0x23, 0xce, 0xbf, 0x61, 0xdc, 0x07, 0xa7, 0xf3, 0x20,
+	0xc1, 0xac, 0xb4, 0xb3, 0xa6, 0x02, 0x13, 0xc4, 0x4b, 0x4a, 0xed, 0x51, 0x64, 0xfe, 0xb4, 0x21,
+	0x70, 0xe2, 0x1b, 0xe5, 0x83, 0xf4, 0x6b, 0x93, 0x8b, 0x4f, 0x49, 0xd5, 0x45, 0xe0, 0x7a, 0x6d,
+	0x72, 0x4c, 0x9b, 0x0f, 0xca, 0x14, 0xb3, 0xb5, 0x78, 0x45, 0x54, 0x63, 0xf2, 0x2f, 0xd9, 0x41,
+	0xfa, 0x94, 0x5e, 0x97, 0x60, 0x72, 0x10, 0x9f, 0xd1, 0x66, 0x0c, 0x13, 0x7c, 0x1d, 0x51, 0xfe,
+	0x86, 0xed, 0x97, 0x7a, 0xbe, 0x08, 0x32, 0x2f, 0x35, 0x06, 0xf2, 0x9a, 0xfc, 0xf4, 0x09, 0x9b,
+	0x12, 0xc4, 0x4f, 0xd8, 0x47, 0xb9, 0xad, 0x6a, 0x95, 0x07, 0x39, 0x2b, 0x6d, 0x7e, 0x2b, 0x1d,
+	0x94, 0x6a, 0x2d, 0x46, 0x31, 0xe4, 0x44, 0xfd, 0x80, 0x4c, 0x86, 0x04, 0xce, 0x5d, 0xbb, 0xa5,
+	0x01, 0xe9, 0x20, 0x80, 0x09, 0xda, 0x1a, 0xf1, 0x66, 0xd4, 0x99, 0xec, 0x66, 0x43, 0x82, 0xb3,
+	0x06, 0xe5, 0xbf, 0x63, 0x2f, 0xa2, 0x30, 0x5f, 0x40, 0x7e, 0x5b, 0x5b, 0x6d, 0xc2, 0xa6, 0xa8,
+	0xc7, 0x34, 0xe4, 0x39, 0x09, 0xa6, 0x2d, 0xdf, 0xd6, 0xf5, 0x4b, 0xd6, 0x33, 0xb6, 0x00, 0x59,
+	0xd9, 0x02, 0xc4, 0xaf, 0xe3, 0x86, 0x20, 0x70, 0x69, 0x0b, 0xe0, 0x23, 0xd6, 0xdf, 0xb8, 0xf4,
+	0xe2, 0x73, 0xda, 0x8a, 0x6d, 0x88, 0x8f, 0xd8, 0x7e, 0xb8, 0x93, 0xb5, 0xb5, 0xa5, 0xf4, 0xfa,
+	0x67, 0x10, 0xc7, 0x94, 0x1c, 0x16, 0xee, 0xae, 0xac, 0x2d, 0xaf, 0xf5, 0xcf, 0xc0, 0xbf, 0x65,
+	0x4f, 0x5b, 0x05, 0x98, 0x02, 0x5c, 0xda, 0xfc, 0x2f, 0x48, 0x79, 0x94, 0x94, 0xc4, 0xc4, 0x2a,
+	0x98, 0xb0, 0xc3, 0x66, 0x40, 0xa9, 0x6f, 0x20, 0xe8, 0x0a, 0xc4, 0x97, 0x71, 0xdd, 0x51, 0x7c,
+	0x91, 0x50, 0xfe, 0x96, 0xf1, 0x46, 0x49, 0xd5, 0x26, 0x67, 0xcb, 0xaa, 0x16, 0x13, 0x72, 0x7c,
+	0x10, 0xb5, 0x54, 0x75, 0x3f, 0x2c, 0xab, 0x9a, 0x7f, 0xce, 0x86, 0x15, 0x26, 0xa6, 0x04, 0x55,
+	0x48, 0x72, 0xfa, 0x15, 0x09, 0xf7, 0x11, 0xbd, 0x00, 0x55, 0x7c, 0x40, 0x97, 0xc7, 0x6c, 0xe8,
+	0xa0, 0xb2, 0x01, 0x24, 0x16, 0x1c, 0xd6, 0xdf, 0xd7, 0xb4, 0xe8, 0x41, 0x44, 0xaf, 0x23, 0x88,
+	0x32, 0x1f, 0xac, 0x53, 0x73, 0x90, 0x85, 0xd3, 0x2b, 0x70, 0xe2, 0x2d, 0xa5, 0x6e, 0x90, 0xd0,
+	0x3f, 0x12, 0x18, 0x6b, 0x34, 0xca, 0xb6, 0x8e, 0xcc, 0x6f, 0x48, 0x7a, 0x94, 0x98, 0xab, 0x96,
+	0xc0, 0x02, 0x69, 0xe4, 0xb7, 0x95, 0x97, 0xb9, 0xad, 0x2a, 0x65, 0x0a, 0xf1, 0xcd, 0x3d, 0xfd,
+	0x79, 0xe5, 0xa7, 0x91, 0xc0, 0xf3, 0x12, 0x16, 0x0e, 0xfc, 0xc2, 0x96, 0x45, 0x1b, 0xef, 0x49,
+	0x3c, 0x2f, 0x2d, 0xd1, 0x84, 0x9c, 0x0e, 0x97, 0x36, 0x73, 0xd9, 0x72, 0xe2, 0x5b, 0x4a, 0xc1,
+	0x61, 0x22, 0x3e, 0x34, 0x38, 0x56, 0x73, 0xf4, 0x27, 0x83, 0xbd, 0x05, 0x23, 0x7e, 0x4b, 0x21,
+	0xf4, 0x23, 0xf6, 0x01, 0x21, 0x3e, 0x66, 0x83, 0x46, 0x52, 0x7a, 0x99, 0x2b, 0xf1, 0xee, 0x9e,
+	0xa6, 0xf4, 0x53, 0xc5, 0xbf, 0x60, 0x07, 0xdb, 0x1a, 0x70, 0x41, 0x9c, 0xa6, 0x3c, 0xb5, 0x2a,
+	0x70, 0x01, 0xf7, 0x66, 0x4b, 0x87, 0xbd, 0xe0, 0x3b, 0x92, 0xed, 0xb7, 0x32, 0xec, 0x06, 0x5f,
+	0xb1, 0x43, 0xbf, 0x50, 0xee, 0x5e, 0x2e, 0xdf, 0x93, 0xee, 0x80, 0xf0, 0x4d, 0x26, 0xc7, 0xff,
+	0xdc, 0x61, 0xbd, 0xb6, 0x51, 0x62, 0x1b, 0x71, 0x75, 0x2e, 0x53, 0x0f, 0x8a, 0x9d, 0xa9, 0xe7,
+	0xea, 0xfc, 0xa2, 0x6d, 0x43, 0x8b, 0x10, 0x6a, 0x79, 0xaf, 0x47, 0x31, 0x84, 0x1e, 0x08, 0x2a,
+	0x5b, 0x2c, 0x4b, 0x10, 0x3b, 0x1b, 0xc1, 0x25, 0x21, 0xfc, 0x1d, 0xeb, 0xaa, 0x5a, 0x63, 0xe0,
+	0x5e, 0xec, 0x8e, 0x76, 0x26, 0xfd, 0xd3, 0x67, 0x5b, 0x2d, 0xf3, 0xea, 0xec, 0x1c, 0xd6, 0xcd,
+	0x5b, 0x40, 0xd5, 0xfa, 0x1c, 0xd6, 0x9e, 0xff, 0x81, 0x1d, 0x28, 0x63, 0xcd, 0xba, 0xb2, 0x4b,
+	0x2f, 0x7f, 0x5c, 0xda, 0xa0, 0xc4, 0xe3, 0x87, 0x1d, 0xfc, 0xaf, 0x08, 0xa7, 0x81, 0xc3, 0x56,
+	0x4d, 0x28, 0xa6, 0xd6, 0xc1, 0x8f, 0x4b, 0xed, 0x40, 0xa6, 0xa9, 0xa9, 0x7d, 0x75, 0xb3, 0x41,
+	0x82, 0xbf, 0xa7, 0x89, 0xb0, 0x8d, 0xb4, 0xb9, 0xdf, 0x8b, 0x2d, 0x21, 0xa4, 0xac, 0x3f, 0x67,
+	0x7b, 0x4d, 0xba, 0xbb, 0xb1, 0x27, 0x84, 0x98, 0xe8, 0x57, 0x8c, 0xa9, 0x65, 0x58, 0xa4, 0xbd,
+	0xef, 0x11, 0xd7, 0x43, 0x24, 0xee, 0xfc, 0x1b, 0xb6, 0xaf, 0x0a, 0x6c, 0xb7, 0x29, 0x61, 0x2c,
+	0x5e, 0x0b, 0x84, 0x6d, 0x32, 0x16, 0x25, 0xd1, 0x45, 0xec, 0x45, 0x8c, 0x20, 0xf2, 0x31, 0x56,
+	0x6c, 0x7f, 0x3b, 0x2f, 0xfc, 0x90, 0xed, 0x60, 0x1c, 0x1d, 0x12, 0xe2, 0x27, 0x3e, 0x24, 0x8c,
+	0xaa, 0x20, 0x75, 0x38, 0xfa, 0xc6, 0xc7, 0x4e, 0x4c, 0xd5, 0xce, 0x2f, 0xa5, 0x2a, 0x6a, 0xc6,
+	0xff, 0xea, 0xb0, 0xfe, 0x16, 0x8c, 0xa7, 0x0b, 0x53, 0x03, 0x3e, 0x78, 0x59, 0x83, 0x93, 0x1e,
+	0x72, 0x6b, 0x62, 0x6f, 0xed, 0x64, 0x47, 0x0d, 0x75, 0x05, 0xee, 0x9a, 0x08, 0xec, 0x7e, 0xb3,
+	0xa5, 0xf3, 0x81, 0x22, 0x18, 0x64, 0xd1, 0xc0, 0x63, 0x84, 0x6f, 0x06, 0xbf, 0x9c, 0xf9, 0xdc,
+	0xe9, 0x1a, 0xef, 0x5f, 0x4f, 0xe1, 0x0c, 0xb2, 0xc3, 0x4a, 0xdd, 0x5d, 0x6f, 0xe3, 0xfc, 0x6b,
+	0x76, 0x04, 0x2b, 0x30, 0xf7, 0x27, 0xdc, 0xa5, 0x09, 0x0f, 0x22, 0xd1, 0x4e, 0x37, 0xfe, 0x47,
+	0x87, 0xf5, 0xda, 0xd7, 0x15, 0x5e, 0xcb, 0xa5, 0x9d, 0xcb, 0x12, 0x56, 0x50, 0xa6, 0xac, 0x74,
+	0x4b, 0x3b, 0xbf, 0x40, 0x1b, 0xf7, 0x14, 0xc9, 0x1b, 0x5d, 0x36, 0xe9, 0xd9, 0x2b, 0xed, 0xfc,
+	0xcf, 0xba, 0xa4, 0x2b, 0x24, 0x3d, 0x36, 0x72, 0xa7, 0xfc, 0x42, 0x3a, 0xa8, 0xad, 0x0b, 0x14,
+	0x60, 0x37, 0x3b, 0x8a, 0xd4, 0x14, 0x99, 0x8c, 0x08, 0xbc, 0x6c, 0xb7, 0x85, 0x72, 0xe9, 0x4a,
+	0x0a, 0xb0, 0x97, 0x0d, 0xf3, 0x8d, 0xec, 0x6f, 0xae, 0x1c, 0x9f, 0x33, 0xb6, 0x79, 0x25, 0xf2,
+	0xdf, 0xb3, 0x97, 0x05, 0xdc, 0xa8, 0x65, 0x19, 0xa8, 0xea, 0x83, 0x75, 0x40, 0xf1, 0x60, 0xdb,
+	0x06, 0x97, 0x22, 0x16, 0x49, 0x72, 0x9e, 0x14, 0x18, 0xe1, 0x14, 0xf9, 0xf1, 0x7f, 0x3b, 0xac,
+	0xbf, 0xf5, 0x3e, 0xdd, 0x7a, 0x23, 0x55, 0x80, 0xad, 0xdb, 0x8b, 0xce, 0xf6, 0x1b, 0xe9, 0x32,
+	0x82, 0xfc, 0x8a, 0x1d, 0xc6, 0x38, 0xf1, 0x16, 0x4b, 0xa7, 0x11, 0x8f, 0xeb, 0xf0, 0xf4, 0xf8,
+	0xff, 0xbe, 0x7b, 0x4f, 0xb2, 0x46, 0x1d, 0x0f, 0x6a, 0x76, 0xe0, 0xee, 0x03, 0xfc, 0x3d, 0xeb,
+	0x6a, 0x73, 0x53, 0x2e, 0xef, 0x8a, 0x19, 0x55, 0x69, 0xff, 0x54, 0x6c, 0x3c, 0x9d, 0x25, 0x26,
+	0xd5, 0x55, 0xab, 0x1c, 0xbf, 0x66, 0x07, 0x0f, 0x3c, 0xf3, 0x7d, 0xd6, 0x6d, 0xe4, 0x87, 0xbf,
+	0x1a, 0xdf, 0xb1, 0xe1, 0xfd, 0xc1, 0x58, 0xce, 0x0b, 0xeb, 0x43, 0xca, 0x0c, 0x7d, 0x23, 0x46,
+	0xbb, 0x13, 0x0b, 0x8c, 0xbe, 0xf9, 0x90, 0x3d, 0x2a, 0x66, 0xe9, 0x29, 0xfc, 0xa8, 0x98, 0xa1,
+	0x66, 0xe9, 0xc1, 0xa5, 0x4d, 0xa1, 0x6f, 0x7c, 0xb3, 0xe1, 0x15, 0xf8, 0x93, 0x75, 0x05, 0x5d,
+	0x1a, 0xbd, 0xac, 0xb5, 0x67, 0x4f, 0xe8, 0x97, 0xe5, 0xbb, 0xff, 0x0d, 0x00, 0xa2, 0xc5, 0x25,
+	0x35, 0xc2, 0x0c, 0x00, 0x00,
 }
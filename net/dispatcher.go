@@ -33,18 +33,22 @@ var (
 )
 
 // Dispatcher a message dispatcher service.
+// The consensus messages are dispatched in a goroutine of their own, never queued behind
+// the blocks and transactions gossiped, nor blocked by their slow subscribers.
 type Dispatcher struct {
-	subscribersMap    *sync.Map
-	quitCh            chan bool
-	receivedMessageCh chan Message
+	subscribersMap     *sync.Map
+	quitCh             chan bool
+	receivedMessageCh  chan Message
+	consensusMessageCh chan Message
 }
 
 // NewDispatcher create Dispatcher instance.
 func NewDispatcher() *Dispatcher {
 	dp := &Dispatcher{
-		subscribersMap:    new(sync.Map),
-		quitCh:            make(chan bool, 10),
-		receivedMessageCh: make(chan Message, 1024),
+		subscribersMap:     new(sync.Map),
+		quitCh:             make(chan bool, 10),
+		receivedMessageCh:  make(chan Message, 1024),
+		consensusMessageCh: make(chan Message, 256),
 	}
 
 	return dp
@@ -77,37 +81,55 @@ func (dp *Dispatcher) Deregister(subscribers ...*Subscriber) {
 	}
 }
 
-// Start start message dispatch goroutine.
+// Start start message dispatch goroutines.
 func (dp *Dispatcher) Start() {
 	logging.CLog().Info("Launched Dispatcher.")
 
-	go (func() {
-		for {
-			// logging.VLog().Info("dispatcher in loop")
-			select {
-			case <-dp.quitCh:
+	go dp.loop(dp.receivedMessageCh)
+	go dp.loop(dp.consensusMessageCh)
+}
+
+func (dp *Dispatcher) loop(msgCh chan Message) {
+	for {
+		select {
+		case <-dp.quitCh:
+			if msgCh == dp.receivedMessageCh {
 				logging.CLog().Info("Shutdowned Dispatcher.")
-				return
-
-			case msg := <-dp.receivedMessageCh:
-				msgType := msg.MessageType()
-				v, _ := dp.subscribersMap.Load(msgType)
-				m, _ := v.(*sync.Map)
-				m.Range(func(key, value interface{}) bool {
-					key.(*Subscriber).msgChan <- msg
-					return true
-				})
 			}
+			return
+
+		case msg := <-msgCh:
+			dp.dispatch(msg)
 		}
-	})()
+	}
 }
 
-// Stop stop goroutine.
+func (dp *Dispatcher) dispatch(msg Message) {
+	TraceOf(msg).Mark(TraceStageDispatched)
+	msgType := msg.MessageType()
+	v, _ := dp.subscribersMap.Load(msgType)
+	m, _ := v.(*sync.Map)
+	if m == nil {
+		return
+	}
+	m.Range(func(key, value interface{}) bool {
+		key.(*Subscriber).msgChan <- msg
+		return true
+	})
+}
+
+// Stop stop goroutines.
 func (dp *Dispatcher) Stop() {
 	dp.quitCh <- true
+	dp.quitCh <- true
 }
 
 // PutMessage put new message to chan, then subscribers will be notified to process.
+// The consensus messages are put to a chan of their own.
 func (dp *Dispatcher) PutMessage(msg Message) {
+	if IsConsensusMessage(msg.MessageType()) {
+		dp.consensusMessageCh <- msg
+		return
+	}
 	dp.receivedMessageCh <- msg
 }
@@ -0,0 +1,60 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMessage struct {
+	msgType string
+}
+
+func (msg *mockMessage) MessageType() string { return msg.msgType }
+func (msg *mockMessage) Data() interface{}   { return nil }
+func (msg *mockMessage) MessageFrom() string { return "" }
+
+func TestDispatcher_ConsensusMessage(t *testing.T) {
+	dp := NewDispatcher()
+	// the subscriber of blocks is stuck, the dispatching of gossip is blocked.
+	stuck := make(chan Message)
+	consensus := make(chan Message, 1)
+	dp.Register(NewSubscriber("stuck", stuck, MessageTypeNewBlock))
+	dp.Register(NewSubscriber("consensus", consensus, MessageTypeConsensusVote))
+	dp.Start()
+	defer dp.Stop()
+
+	dp.PutMessage(&mockMessage{MessageTypeNewBlock})
+	dp.PutMessage(&mockMessage{MessageTypeNewBlock})
+	dp.PutMessage(&mockMessage{MessageTypeConsensusVote})
+	select {
+	case msg := <-consensus:
+		assert.Equal(t, MessageTypeConsensusVote, msg.MessageType())
+	case <-time.After(time.Second):
+		t.Fatal("the consensus message is blocked by gossip")
+	}
+	<-stuck
+	<-stuck
+
+	assert.True(t, IsConsensusMessage(MessageTypeConsensusPrepare))
+	assert.False(t, IsConsensusMessage(MessageTypeNewBlock))
+}
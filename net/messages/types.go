@@ -30,20 +30,24 @@ import (
 
 // BaseMessage base message
 type BaseMessage struct {
-	t    string
-	from string
-	data interface{}
+	t     string
+	from  string
+	data  interface{}
+	trace *net.Trace
 }
 
 // HelloMessage use to send hello
 type HelloMessage struct {
 	NodeID        string
 	ClientVersion string
+	Services      uint64
+	Genesis       []byte
+	LIB           []byte
 }
 
 // NewHelloMessage new hello message
-func NewHelloMessage(nodeID string, clientVersion string) *HelloMessage {
-	return &HelloMessage{NodeID: nodeID, ClientVersion: clientVersion}
+func NewHelloMessage(nodeID string, clientVersion string, services uint64, genesis []byte, lib []byte) *HelloMessage {
+	return &HelloMessage{NodeID: nodeID, ClientVersion: clientVersion, Services: services, Genesis: genesis, LIB: lib}
 }
 
 // ToProto converts domain HelloMessage to proto HelloMessage
@@ -51,6 +55,9 @@ func (h *HelloMessage) ToProto() (proto.Message, error) {
 	return &netpb.Hello{
 		NodeId:        h.NodeID,
 		ClientVersion: h.ClientVersion,
+		Services:      h.Services,
+		Genesis:       h.Genesis,
+		Lib:           h.LIB,
 	}, nil
 }
 
@@ -59,6 +66,9 @@ func (h *HelloMessage) FromProto(msg proto.Message) error {
 	if msg, ok := msg.(*netpb.Hello); ok {
 		h.NodeID = msg.NodeId
 		h.ClientVersion = msg.ClientVersion
+		h.Services = msg.Services
+		h.Genesis = msg.Genesis
+		h.LIB = msg.Lib
 		return nil
 	}
 	return errors.New("Pb Message cannot be converted into HelloMessage")
@@ -157,6 +167,11 @@ func NewBaseMessage(t string, from string, data interface{}) net.Message {
 	return &BaseMessage{t: t, from: from, data: data}
 }
 
+// NewTracedMessage new base message with a trace, see net.Trace
+func NewTracedMessage(t string, from string, data interface{}, trace *net.Trace) net.Message {
+	return &BaseMessage{t: t, from: from, data: data, trace: trace}
+}
+
 // MessageType get message type
 func (msg *BaseMessage) MessageType() string {
 	return msg.t
@@ -172,6 +187,11 @@ func (msg *BaseMessage) Data() interface{} {
 	return msg.data
 }
 
+// Trace get the trace of the message, nil if not traced
+func (msg *BaseMessage) Trace() *net.Trace {
+	return msg.trace
+}
+
 // String get the message to string
 func (msg *BaseMessage) String() string {
 	return fmt.Sprintf("BaseMessage {type:%s; data:%s}",
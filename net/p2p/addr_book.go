@@ -0,0 +1,207 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+)
+
+// AddrClass is the class of a peer's addresses, it decides how long they are kept in peerstore.
+type AddrClass int
+
+// address classes, ordered by trust.
+const (
+	// AddrClassDiscovered addresses learned from other peers' route tables, never connected.
+	AddrClassDiscovered AddrClass = iota
+	// AddrClassVerified addresses of peers who has completed a handshake with us.
+	AddrClassVerified
+	// AddrClassBoot addresses of the configured boot nodes, never purged.
+	AddrClassBoot
+)
+
+// retention rules of address classes.
+var (
+	// DiscoveredAddrTTL the ttl of addresses which are not verified.
+	DiscoveredAddrTTL = peerstore.ProviderAddrTTL
+	// VerifiedAddrRetention how long the addresses of a verified peer are kept after it goes idle,
+	// so that the node can redial it.
+	VerifiedAddrRetention = 24 * time.Hour
+)
+
+func (c AddrClass) String() string {
+	switch c {
+	case AddrClassBoot:
+		return "boot"
+	case AddrClassVerified:
+		return "verified"
+	default:
+		return "discovered"
+	}
+}
+
+type addrRecord struct {
+	class    AddrClass
+	lastSeen time.Time
+}
+
+// addrBook tracks the address class of peers and applies the retention rules to the peerstore.
+type addrBook struct {
+	mu      sync.RWMutex
+	records map[peer.ID]*addrRecord
+	now     func() time.Time
+}
+
+func newAddrBook() *addrBook {
+	return &addrBook{
+		records: make(map[peer.ID]*addrRecord),
+		now:     time.Now,
+	}
+}
+
+// Mark upgrades the class of a peer, a class is never downgraded.
+// The last seen time is refreshed unless a lower class is given.
+func (book *addrBook) Mark(pid peer.ID, class AddrClass) AddrClass {
+	book.mu.Lock()
+	defer book.mu.Unlock()
+
+	record, ok := book.records[pid]
+	if !ok {
+		record = &addrRecord{class: class}
+		book.records[pid] = record
+	}
+	if class >= record.class {
+		record.class = class
+		record.lastSeen = book.now()
+	}
+	return record.class
+}
+
+// Demote turns a boot node into a verified peer, its addresses are retained from now on.
+func (book *addrBook) Demote(pid peer.ID) {
+	book.mu.Lock()
+	defer book.mu.Unlock()
+
+	if record, ok := book.records[pid]; ok && record.class == AddrClassBoot {
+		record.class = AddrClassVerified
+		record.lastSeen = book.now()
+	}
+}
+
+// Touch refreshes the last seen time of a known peer.
+func (book *addrBook) Touch(pid peer.ID) {
+	book.mu.Lock()
+	defer book.mu.Unlock()
+
+	if record, ok := book.records[pid]; ok {
+		record.lastSeen = book.now()
+	}
+}
+
+// Class returns the class of a peer.
+func (book *addrBook) Class(pid peer.ID) AddrClass {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	if record, ok := book.records[pid]; ok {
+		return record.class
+	}
+	return AddrClassDiscovered
+}
+
+// IsBoot returns if the peer is a boot node.
+func (book *addrBook) IsBoot(pid peer.ID) bool {
+	return book.Class(pid) == AddrClassBoot
+}
+
+// ConnectedTTL returns the ttl of the peer's addresses while it is connected.
+func (book *addrBook) ConnectedTTL(pid peer.ID) time.Duration {
+	if book.Class(pid) == AddrClassDiscovered {
+		return DiscoveredAddrTTL
+	}
+	return peerstore.PermanentAddrTTL
+}
+
+// IdleTTL returns the ttl of the peer's addresses after its connection closed, 0 means drop them.
+// A discovered peer who dropped the connection is not worth to redial.
+func (book *addrBook) IdleTTL(pid peer.ID) time.Duration {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	record, ok := book.records[pid]
+	if !ok {
+		return 0
+	}
+	switch record.class {
+	case AddrClassBoot:
+		return peerstore.PermanentAddrTTL
+	case AddrClassVerified:
+		if left := VerifiedAddrRetention - book.now().Sub(record.lastSeen); left > 0 {
+			return left
+		}
+	}
+	return 0
+}
+
+// Retain returns if the addresses of a peer without connection should be kept in peerstore.
+func (book *addrBook) Retain(pid peer.ID) bool {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	record, ok := book.records[pid]
+	if !ok {
+		return false
+	}
+	idle := book.now().Sub(record.lastSeen)
+	switch record.class {
+	case AddrClassBoot:
+		return true
+	case AddrClassVerified:
+		return idle < VerifiedAddrRetention
+	default:
+		return idle < DiscoveredAddrTTL
+	}
+}
+
+// Peers returns the peers of the class.
+func (book *addrBook) Peers(class AddrClass) []peer.ID {
+	book.mu.RLock()
+	defer book.mu.RUnlock()
+
+	var peers []peer.ID
+	for pid, record := range book.records {
+		if record.class == class {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
+}
+
+// Forget drops the record of a peer who is not worth to remember, boot nodes are kept.
+func (book *addrBook) Forget(pid peer.ID) {
+	book.mu.Lock()
+	defer book.mu.Unlock()
+
+	if record, ok := book.records[pid]; ok && record.class != AddrClassBoot {
+		delete(book.records, pid)
+	}
+}
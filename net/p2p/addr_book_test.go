@@ -0,0 +1,132 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockClock struct {
+	now time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	return c.now
+}
+
+func (c *mockClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestAddrBook() (*addrBook, *mockClock) {
+	clock := &mockClock{now: time.Unix(1500000000, 0)}
+	book := newAddrBook()
+	book.now = clock.Now
+	return book, clock
+}
+
+func TestAddrBook_Mark(t *testing.T) {
+	book, _ := newTestAddrBook()
+	pid := peer.ID("peer")
+
+	assert.Equal(t, AddrClassDiscovered, book.Class(pid))
+	assert.Equal(t, AddrClassDiscovered, book.Mark(pid, AddrClassDiscovered))
+	assert.Equal(t, AddrClassVerified, book.Mark(pid, AddrClassVerified))
+	// never downgrade.
+	assert.Equal(t, AddrClassVerified, book.Mark(pid, AddrClassDiscovered))
+	assert.Equal(t, AddrClassBoot, book.Mark(pid, AddrClassBoot))
+	assert.Equal(t, AddrClassBoot, book.Mark(pid, AddrClassVerified))
+	assert.True(t, book.IsBoot(pid))
+}
+
+func TestAddrBook_ConnectedTTL(t *testing.T) {
+	book, _ := newTestAddrBook()
+
+	book.Mark(peer.ID("discovered"), AddrClassDiscovered)
+	book.Mark(peer.ID("verified"), AddrClassVerified)
+	book.Mark(peer.ID("boot"), AddrClassBoot)
+
+	assert.Equal(t, DiscoveredAddrTTL, book.ConnectedTTL(peer.ID("discovered")))
+	assert.Equal(t, DiscoveredAddrTTL, book.ConnectedTTL(peer.ID("unknown")))
+	assert.Equal(t, peerstore.PermanentAddrTTL, book.ConnectedTTL(peer.ID("verified")))
+	assert.Equal(t, peerstore.PermanentAddrTTL, book.ConnectedTTL(peer.ID("boot")))
+}
+
+func TestAddrBook_BootReconnectAfterIdle(t *testing.T) {
+	book, clock := newTestAddrBook()
+	pid := peer.ID("boot")
+	book.Mark(pid, AddrClassBoot)
+
+	clock.Add(10 * VerifiedAddrRetention)
+	assert.True(t, book.Retain(pid))
+	assert.Equal(t, peerstore.PermanentAddrTTL, book.IdleTTL(pid))
+
+	book.Forget(pid)
+	assert.True(t, book.IsBoot(pid))
+}
+
+func TestAddrBook_VerifiedReconnectAfterIdle(t *testing.T) {
+	book, clock := newTestAddrBook()
+	pid := peer.ID("verified")
+	book.Mark(pid, AddrClassVerified)
+
+	// the connection closed after a while.
+	clock.Add(time.Hour)
+	book.Touch(pid)
+	assert.Equal(t, VerifiedAddrRetention, book.IdleTTL(pid))
+
+	// idle but still retained, the node can redial it.
+	clock.Add(VerifiedAddrRetention / 2)
+	assert.True(t, book.Retain(pid))
+	assert.Equal(t, VerifiedAddrRetention/2, book.IdleTTL(pid))
+	assert.Equal(t, []peer.ID{pid}, book.Peers(AddrClassVerified))
+
+	// reconnect refreshes the retention.
+	book.Mark(pid, AddrClassVerified)
+	clock.Add(VerifiedAddrRetention - time.Second)
+	assert.True(t, book.Retain(pid))
+
+	// a discovery of a verified peer does not refresh the retention.
+	book.Mark(pid, AddrClassDiscovered)
+	clock.Add(time.Second)
+	assert.False(t, book.Retain(pid))
+	assert.Equal(t, time.Duration(0), book.IdleTTL(pid))
+
+	book.Forget(pid)
+	assert.Equal(t, AddrClassDiscovered, book.Class(pid))
+	assert.Empty(t, book.Peers(AddrClassVerified))
+}
+
+func TestAddrBook_DiscoveredIdle(t *testing.T) {
+	book, clock := newTestAddrBook()
+	pid := peer.ID("discovered")
+	book.Mark(pid, AddrClassDiscovered)
+
+	assert.True(t, book.Retain(pid))
+	assert.Equal(t, time.Duration(0), book.IdleTTL(pid))
+
+	clock.Add(DiscoveredAddrTTL)
+	assert.False(t, book.Retain(pid))
+	assert.False(t, book.Retain(peer.ID("unknown")))
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// errors
+var (
+	ErrInvalidListenAddr = errors.New("invalid listen address")
+)
+
+// private networks which are never advertised to public peers.
+var privateNetworks = parseCIDRs(
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, v := range cidrs {
+		_, ipnet, err := net.ParseCIDR(v)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets
+}
+
+// addrIP returns the ip of an /ip4 or /ip6 multiaddr, nil for other addresses.
+func addrIP(addr ma.Multiaddr) net.IP {
+	parts := strings.Split(addr.String(), "/")
+	if len(parts) < 3 || (parts[1] != "ip4" && parts[1] != "ip6") {
+		return nil
+	}
+	return net.ParseIP(parts[2])
+}
+
+// IsPublicAddr returns if the address is reachable from the public network.
+func IsPublicAddr(addr ma.Multiaddr) bool {
+	ip := addrIP(addr)
+	if ip == nil || ip.IsLoopback() || ip.IsUnspecified() {
+		return false
+	}
+	for _, ipnet := range privateNetworks {
+		if ipnet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterAddrs returns the addresses worth advertising to a peer, private ones are dropped for public peers.
+// Unspecified addresses are never advertised.
+func FilterAddrs(addrs []ma.Multiaddr, public bool) []ma.Multiaddr {
+	var filtered []ma.Multiaddr
+	for _, addr := range addrs {
+		ip := addrIP(addr)
+		if ip != nil && ip.IsUnspecified() {
+			continue
+		}
+		if public && !IsPublicAddr(addr) {
+			continue
+		}
+		filtered = append(filtered, addr)
+	}
+	return filtered
+}
+
+// ParseListenAddr parses a listen address in "ip:port" or multiaddr form,
+// returns the multiaddr and the "ip:port" to dial.
+func ParseListenAddr(v string) (ma.Multiaddr, string, error) {
+	if strings.HasPrefix(v, "/") {
+		addr, err := ma.NewMultiaddr(v)
+		if err != nil {
+			return nil, "", err
+		}
+		parts := strings.Split(addr.String(), "/")
+		if len(parts) != 5 || addrIP(addr) == nil || parts[3] != "tcp" {
+			return nil, "", ErrInvalidListenAddr
+		}
+		return addr, net.JoinHostPort(parts[2], parts[4]), nil
+	}
+
+	tcpAddr, err := net.ResolveTCPAddr("tcp", v)
+	if err != nil {
+		return nil, "", err
+	}
+	ip, family := tcpAddr.IP, "ip4"
+	if ip == nil {
+		ip = net.IPv4zero
+	} else if ip.To4() == nil {
+		family = "ip6"
+	}
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/%s/%s/tcp/%d", family, ip, tcpAddr.Port))
+	if err != nil {
+		return nil, "", err
+	}
+	return addr, v, nil
+}
+
+// ListenAddrs returns all addresses the node is listening on.
+func (ns *NetService) ListenAddrs() []ma.Multiaddr {
+	return ns.node.host.Addrs()
+}
+
+// AdvertisedAddrs returns the addresses of the node advertised to a peer.
+// The addresses pinned by config are always advertised as they are.
+func (ns *NetService) AdvertisedAddrs(public bool) []ma.Multiaddr {
+	if advertise := ns.node.config.Advertise; len(advertise) > 0 {
+		return advertise
+	}
+	return FilterAddrs(ns.ListenAddrs(), public)
+}
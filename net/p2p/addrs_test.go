@@ -0,0 +1,100 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockMultiaddrs(t *testing.T, addrs ...string) []ma.Multiaddr {
+	var result []ma.Multiaddr
+	for _, v := range addrs {
+		addr, err := ma.NewMultiaddr(v)
+		assert.Nil(t, err)
+		result = append(result, addr)
+	}
+	return result
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"/ip4/8.8.8.8/tcp/8680", true},
+		{"/ip4/127.0.0.1/tcp/8680", false},
+		{"/ip4/0.0.0.0/tcp/8680", false},
+		{"/ip4/10.1.2.3/tcp/8680", false},
+		{"/ip4/172.16.0.1/tcp/8680", false},
+		{"/ip4/172.32.0.1/tcp/8680", true},
+		{"/ip4/192.168.1.1/tcp/8680", false},
+		{"/ip6/::1/tcp/8680", false},
+		{"/ip6/fd00::1/tcp/8680", false},
+		{"/ip6/2001:db8::1/tcp/8680", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, IsPublicAddr(mockMultiaddrs(t, tt.addr)[0]))
+		})
+	}
+}
+
+func TestFilterAddrs(t *testing.T) {
+	addrs := mockMultiaddrs(t,
+		"/ip4/0.0.0.0/tcp/8680",
+		"/ip4/127.0.0.1/tcp/8680",
+		"/ip4/192.168.1.1/tcp/8680",
+		"/ip4/8.8.8.8/tcp/8680",
+	)
+
+	assert.Equal(t, addrs[1:], FilterAddrs(addrs, false))
+	assert.Equal(t, addrs[3:], FilterAddrs(addrs, true))
+	assert.Empty(t, FilterAddrs(addrs[:3], true))
+}
+
+func TestParseListenAddr(t *testing.T) {
+	tests := []struct {
+		listen   string
+		addr     string
+		hostPort string
+		err      bool
+	}{
+		{"127.0.0.1:8680", "/ip4/127.0.0.1/tcp/8680", "127.0.0.1:8680", false},
+		{":8680", "/ip4/0.0.0.0/tcp/8680", ":8680", false},
+		{"[::1]:8680", "/ip6/::1/tcp/8680", "[::1]:8680", false},
+		{"/ip4/0.0.0.0/tcp/8680", "/ip4/0.0.0.0/tcp/8680", "0.0.0.0:8680", false},
+		{"/ip6/::/tcp/8680", "/ip6/::/tcp/8680", "[::]:8680", false},
+		{"127.0.0.1", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.listen, func(t *testing.T) {
+			addr, hostPort, err := ParseListenAddr(tt.listen)
+			if tt.err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.addr, addr.String())
+			assert.Equal(t, tt.hostPort, hostPort)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// DefaultBlockDedupSize the count of recent block hashes remembered for deduplication.
+const DefaultBlockDedupSize = 1024
+
+// metrics of block propagation.
+var (
+	blockFirstSeen   = metrics.GetOrRegisterMeter("neb.net.block.first", nil)
+	blockDuplicate   = metrics.GetOrRegisterMeter("neb.net.block.duplicate", nil)
+	blockPropagation = metrics.GetOrRegisterHistogram("neb.net.block.propagation", nil, metrics.NewUniformSample(1024))
+)
+
+// blockDedup delivers only the first copy of a new block message to the dispatcher,
+// later copies from other peers only update the propagation metrics.
+type blockDedup struct {
+	mu        sync.Mutex
+	firstSeen *lru.Cache
+	now       func() time.Time
+}
+
+func newBlockDedup(size int) *blockDedup {
+	cache, _ := lru.New(size)
+	return &blockDedup{
+		firstSeen: cache,
+		now:       time.Now,
+	}
+}
+
+// Duplicate returns if the block in data has been delivered before. The copies are matched by the digest
+// of the message, never by the hash the sender claims in header, so a forged block claiming the hash of
+// another one can't shadow it before the subscribers verify the hash and sign.
+func (d *blockDedup) Duplicate(data []byte) bool {
+	return d.duplicateHash(messageDigest(net.MessageTypeNewBlock, data))
+}
+
+// DuplicateCompact returns if the compact block in data has been delivered before.
+func (d *blockDedup) DuplicateCompact(data []byte) bool {
+	return d.duplicateHash(messageDigest(net.MessageTypeCompactBlock, data))
+}
+
+func messageDigest(msgType string, data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	return hash.Sha3256([]byte(msgType), data)
+}
+
+func (d *blockDedup) duplicateHash(hash []byte) bool {
+	if len(hash) == 0 {
+		return false
+	}
+	key := byteutils.Hex(hash)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if first, ok := d.firstSeen.Get(key); ok {
+		blockDuplicate.Mark(1)
+		blockPropagation.Update(int64(now.Sub(first.(time.Time)) / time.Millisecond))
+		return true
+	}
+	d.firstSeen.Add(key, now)
+	blockFirstSeen.Mark(1)
+	return false
+}
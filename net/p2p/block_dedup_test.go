@@ -0,0 +1,72 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	corepb "github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockBlockData(t *testing.T, hash []byte) []byte {
+	data, err := proto.Marshal(&corepb.Block{Header: &corepb.BlockHeader{Hash: hash}})
+	assert.Nil(t, err)
+	return data
+}
+
+func TestBlockDedup_Duplicate(t *testing.T) {
+	dedup := newBlockDedup(2)
+	block1 := mockBlockData(t, []byte("hash1"))
+	block2 := mockBlockData(t, []byte("hash2"))
+	block3 := mockBlockData(t, []byte("hash3"))
+
+	assert.False(t, dedup.Duplicate(block1))
+	assert.True(t, dedup.Duplicate(block1))
+	assert.False(t, dedup.Duplicate(block2))
+	assert.True(t, dedup.Duplicate(block2))
+
+	// the oldest hash is evicted.
+	assert.False(t, dedup.Duplicate(block3))
+	assert.False(t, dedup.Duplicate(block1))
+
+	// a block forged with the hash of another never shadows it.
+	forged, err := proto.Marshal(&corepb.Block{Header: &corepb.BlockHeader{Hash: []byte("hash4"), Nonce: 1}})
+	assert.Nil(t, err)
+	assert.False(t, dedup.Duplicate(forged))
+	assert.False(t, dedup.Duplicate(mockBlockData(t, []byte("hash4"))))
+	assert.True(t, dedup.Duplicate(mockBlockData(t, []byte("hash4"))))
+
+	// empty data is left to the subscribers.
+	assert.False(t, dedup.Duplicate(nil))
+	assert.False(t, dedup.Duplicate(nil))
+}
+
+func TestBlockDedup_DuplicateCompact(t *testing.T) {
+	dedup := newBlockDedup(4)
+	compact, err := proto.Marshal(&corepb.LightHeader{Header: &corepb.BlockHeader{Hash: []byte("hash1")}, TxHashes: [][]byte{[]byte("tx")}})
+	assert.Nil(t, err)
+
+	assert.False(t, dedup.DuplicateCompact(compact))
+	assert.True(t, dedup.DuplicateCompact(compact))
+	// the same bytes of another message type are not a duplicate.
+	assert.False(t, dedup.Duplicate(compact))
+	assert.False(t, dedup.DuplicateCompact(nil))
+}
@@ -0,0 +1,331 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// backoff of the retries to an unreachable boot node.
+var (
+	BootNodeRetryMinInterval = 5 * time.Second
+	BootNodeRetryMaxInterval = 5 * time.Minute
+)
+
+// errors
+var (
+	ErrBootNodeExists   = errors.New("boot node already exists")
+	ErrBootNodeNotFound = errors.New("boot node not found")
+)
+
+var (
+	bootNodesTotal   = metrics.GetOrRegisterGauge("neb.net.bootnode.total", nil)
+	bootNodesHealthy = metrics.GetOrRegisterGauge("neb.net.bootnode.healthy", nil)
+	bootNodeDials    = metrics.GetOrRegisterMeter("neb.net.bootnode.dial", nil)
+	bootNodeFailures = metrics.GetOrRegisterMeter("neb.net.bootnode.failure", nil)
+)
+
+// BootNodeStatus is the health of a boot node.
+type BootNodeStatus struct {
+	Addr        string    `json:"addr"`
+	Healthy     bool      `json:"healthy"`
+	Failures    int       `json:"failures"`
+	LastSuccess time.Time `json:"last_success"`
+	LastError   string    `json:"last_error,omitempty"`
+	NextRetry   time.Time `json:"next_retry"`
+}
+
+type bootNode struct {
+	addr        ma.Multiaddr
+	id          peer.ID
+	healthy     bool
+	failures    int
+	lastSuccess time.Time
+	lastErr     error
+	nextRetry   time.Time
+}
+
+// bootNodes is the runtime list of boot nodes, it tracks their health and when to retry them.
+type bootNodes struct {
+	mu    sync.Mutex
+	nodes []*bootNode
+	now   func() time.Time
+}
+
+func newBootNodes(addrs []ma.Multiaddr) *bootNodes {
+	b := &bootNodes{now: time.Now}
+	for _, addr := range addrs {
+		if err := b.Add(addr); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"bootNode": addr,
+				"err":      err,
+			}).Error("ignore invalid boot node")
+		}
+	}
+	return b
+}
+
+// bootNodeBackoff returns the interval before the next retry after continuous failures.
+func bootNodeBackoff(failures int) time.Duration {
+	interval := BootNodeRetryMinInterval
+	for i := 1; i < failures && interval < BootNodeRetryMaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > BootNodeRetryMaxInterval {
+		interval = BootNodeRetryMaxInterval
+	}
+	return interval
+}
+
+func (b *bootNodes) find(addr ma.Multiaddr) int {
+	for i, v := range b.nodes {
+		if v.addr.String() == addr.String() {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add adds a boot node, it is due to dial at once.
+func (b *bootNodes) Add(addr ma.Multiaddr) error {
+	_, id, err := parseAddressFromMultiaddr(addr)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.find(addr) >= 0 {
+		return ErrBootNodeExists
+	}
+	b.nodes = append(b.nodes, &bootNode{addr: addr, id: id})
+	b.updateMetrics()
+	return nil
+}
+
+// Remove removes a boot node and returns its ID.
+func (b *bootNodes) Remove(addr ma.Multiaddr) (peer.ID, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := b.find(addr)
+	if i < 0 {
+		return "", ErrBootNodeNotFound
+	}
+	id := b.nodes[i].id
+	b.nodes = append(b.nodes[:i], b.nodes[i+1:]...)
+	b.updateMetrics()
+	return id, nil
+}
+
+// Len returns the count of boot nodes.
+func (b *bootNodes) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.nodes)
+}
+
+// Addrs returns the addresses of all boot nodes.
+func (b *bootNodes) Addrs() []ma.Multiaddr {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	addrs := make([]ma.Multiaddr, 0, len(b.nodes))
+	for _, v := range b.nodes {
+		addrs = append(addrs, v.addr)
+	}
+	return addrs
+}
+
+// Due returns the addresses of the unhealthy boot nodes whose retry time is reached.
+func (b *bootNodes) Due() []ma.Multiaddr {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	var addrs []ma.Multiaddr
+	for _, v := range b.nodes {
+		if !v.healthy && !now.Before(v.nextRetry) {
+			addrs = append(addrs, v.addr)
+		}
+	}
+	return addrs
+}
+
+// Report records the result of saying hello to a boot node.
+func (b *bootNodes) Report(addr ma.Multiaddr, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := b.find(addr)
+	if i < 0 {
+		return
+	}
+	v := b.nodes[i]
+	now := b.now()
+	v.lastErr = err
+	if err == nil {
+		v.healthy = true
+		v.failures = 0
+		v.lastSuccess = now
+		v.nextRetry = time.Time{}
+	} else {
+		v.healthy = false
+		v.failures++
+		v.nextRetry = now.Add(bootNodeBackoff(v.failures))
+	}
+	b.updateMetrics()
+}
+
+// Refresh marks the boot nodes disconnected since last check unhealthy, they are due to dial at once.
+func (b *bootNodes) Refresh(connected func(peer.ID) bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, v := range b.nodes {
+		if v.healthy && !connected(v.id) {
+			v.healthy = false
+			v.nextRetry = time.Time{}
+		}
+	}
+	b.updateMetrics()
+}
+
+// Status returns the health of all boot nodes.
+func (b *bootNodes) Status() []BootNodeStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := make([]BootNodeStatus, 0, len(b.nodes))
+	for _, v := range b.nodes {
+		s := BootNodeStatus{
+			Addr:        v.addr.String(),
+			Healthy:     v.healthy,
+			Failures:    v.failures,
+			LastSuccess: v.lastSuccess,
+			NextRetry:   v.nextRetry,
+		}
+		if v.lastErr != nil {
+			s.LastError = v.lastErr.Error()
+		}
+		status = append(status, s)
+	}
+	return status
+}
+
+func (b *bootNodes) updateMetrics() {
+	healthy := 0
+	for _, v := range b.nodes {
+		if v.healthy {
+			healthy++
+		}
+	}
+	bootNodesTotal.Update(int64(len(b.nodes)))
+	bootNodesHealthy.Update(int64(healthy))
+}
+
+// helloBootNodes says hello to the boot nodes in parallel, returns if any of them succeeded.
+func (ns *NetService) helloBootNodes(addrs []ma.Multiaddr) bool {
+	node := ns.node
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		success bool
+	)
+	for _, bootNode := range addrs {
+		wg.Add(1)
+		go func(bootNode ma.Multiaddr) {
+			defer wg.Done()
+			bootNodeDials.Mark(1)
+			err := ns.SayHello(bootNode)
+			node.bootNodes.Report(bootNode, err)
+			if err != nil {
+				bootNodeFailures.Mark(1)
+				logging.VLog().Error("net.start: can not say hello to trusted node.", bootNode, err)
+				return
+			}
+			logging.CLog().Info("net.start: say hello to trusted node.", bootNode)
+			mu.Lock()
+			success = true
+			mu.Unlock()
+		}(bootNode)
+	}
+	wg.Wait()
+	return success
+}
+
+// retryBootNodes redials the unreachable or disconnected boot nodes in background with backoff.
+func (ns *NetService) retryBootNodes() {
+	node := ns.node
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			node.bootNodes.Refresh(func(pid peer.ID) bool {
+				_, ok := node.stream.Load(pid.Pretty())
+				return ok || pid == node.id
+			})
+			if due := node.bootNodes.Due(); len(due) > 0 {
+				ns.helloBootNodes(due)
+			}
+		case <-ns.quitCh:
+			logging.VLog().Info("boot node retry halting")
+			return
+		}
+	}
+}
+
+// AddBootNode adds a boot node at runtime, it is dialed in background.
+func (ns *NetService) AddBootNode(addr string) error {
+	bootNode, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return err
+	}
+	return ns.node.bootNodes.Add(bootNode)
+}
+
+// RemoveBootNode removes a boot node at runtime. The connection to it is kept,
+// but its addresses are retained like a verified peer's from now on.
+func (ns *NetService) RemoveBootNode(addr string) error {
+	bootNode, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return err
+	}
+	id, err := ns.node.bootNodes.Remove(bootNode)
+	if err != nil {
+		return err
+	}
+	ns.node.addrBook.Demote(id)
+	return nil
+}
+
+// BootNodes returns the health of the boot nodes.
+func (ns *NetService) BootNodes() []BootNodeStatus {
+	return ns.node.bootNodes.Status()
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootNodeBackoff(t *testing.T) {
+	assert.Equal(t, BootNodeRetryMinInterval, bootNodeBackoff(1))
+	assert.Equal(t, 2*BootNodeRetryMinInterval, bootNodeBackoff(2))
+	assert.Equal(t, 8*BootNodeRetryMinInterval, bootNodeBackoff(4))
+	assert.Equal(t, BootNodeRetryMaxInterval, bootNodeBackoff(100))
+}
+
+func TestBootNodes(t *testing.T) {
+	clock := &mockClock{now: time.Unix(1500000000, 0)}
+	addrs := mockMultiaddrs(t,
+		"/ip4/127.0.0.1/tcp/8680/ipfs/QmPyr4ZbDmwF1nWxymTktdzspcBFPL6X1v3Q5nT7PGNtUN",
+		"/ip4/127.0.0.1/tcp/8681/ipfs/QmWxEZdpvRQP5xX8bN8jDpXT8wM9AWZ5Lxy1M1ZgVXz8Mo",
+	)
+	b := newBootNodes(addrs[:1])
+	b.now = clock.Now
+
+	assert.Equal(t, ErrBootNodeExists, b.Add(addrs[0]))
+	assert.Nil(t, b.Add(addrs[1]))
+	assert.Equal(t, addrs, b.Addrs())
+
+	// all boot nodes are due at first.
+	assert.Equal(t, addrs, b.Due())
+
+	b.Report(addrs[0], nil)
+	b.Report(addrs[1], errors.New("dial fail"))
+	assert.Empty(t, b.Due())
+
+	status := b.Status()
+	assert.True(t, status[0].Healthy)
+	assert.False(t, status[1].Healthy)
+	assert.Equal(t, 1, status[1].Failures)
+	assert.Equal(t, "dial fail", status[1].LastError)
+
+	clock.Add(BootNodeRetryMinInterval)
+	assert.Equal(t, addrs[1:], b.Due())
+
+	// a disconnected boot node is due at once.
+	b.Refresh(func(peer.ID) bool { return false })
+	assert.Equal(t, addrs, b.Due())
+
+	id, err := b.Remove(addrs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, peer.ID("QmPyr4ZbDmwF1nWxymTktdzspcBFPL6X1v3Q5nT7PGNtUN"), id)
+	_, err = b.Remove(addrs[0])
+	assert.Equal(t, ErrBootNodeNotFound, err)
+	assert.Equal(t, 1, b.Len())
+}
+
+func TestAddrBook_Demote(t *testing.T) {
+	book, clock := newTestAddrBook()
+	pid := peer.ID("boot")
+
+	book.Mark(pid, AddrClassBoot)
+	clock.Add(VerifiedAddrRetention)
+	assert.True(t, book.Retain(pid))
+
+	book.Demote(pid)
+	assert.Equal(t, AddrClassVerified, book.Class(pid))
+	assert.True(t, book.Retain(pid))
+	clock.Add(VerifiedAddrRetention)
+	assert.False(t, book.Retain(pid))
+}
@@ -19,12 +19,9 @@
 package p2p
 
 import (
-	"hash/crc32"
-
 	"github.com/gogo/protobuf/proto"
 	peer "github.com/libp2p/go-libp2p-peer"
 	"github.com/nebulasio/go-nebulas/net"
-	byteutils "github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/sirupsen/logrus"
 )
@@ -47,10 +44,11 @@ func (ns *NetService) Relay(name string, msg net.Serializable) {
 	ns.distribute(name, msg, true)
 }
 
-func (ns *NetService) nodeNotInRelayness(relayness []peer.ID, peers []peer.ID) []peer.ID {
+func (ns *NetService) peersNotKnowing(digest MessageDigest, peers []peer.ID) []peer.ID {
+	node := ns.node
 	var list []peer.ID
 	for _, p := range peers {
-		if !InArray(p, relayness) {
+		if !node.knownMsgs.Has(p, digest) {
 			list = append(list, p)
 		}
 	}
@@ -65,67 +63,67 @@ func (ns *NetService) distribute(name string, msg net.Serializable, relay bool)
 		return
 	}
 
-	var relayness []peer.ID
-	dataChecksum := crc32.ChecksumIEEE(data)
-	peers, exists := node.relayness.Get(dataChecksum)
-	if exists {
-		relayness = peers.([]peer.ID)
-	}
-	var allNode []peer.ID
+	digest := NewMessageDigest(data)
 	transfer := node.routeTable.ListPeers()
 	if relay {
-		allNode = ns.nodeNotInRelayness(relayness, node.routeTable.ListPeers())
-		transfer = allNode
+		transfer = ns.peersNotKnowing(digest, transfer)
 	}
 	logging.VLog().WithFields(logrus.Fields{
 		"msg":      msg,
 		"transfer": transfer,
 	}).Info("distribute: start distribute msg.")
 
-	ns.doMsgTransfer(transfer, relayness, dataChecksum, name, data)
+	sent := ns.doMsgTransfer(transfer, digest, name, data)
 
 	if relay {
-		ns.doRelay(allNode, relayness, dataChecksum)
+		ns.doRelay(peersExcept(node.routeTable.ListPeers(), sent), digest)
 	}
 }
 
-func (ns *NetService) doMsgTransfer(transfer []peer.ID, relayness []peer.ID, dataChecksum uint32, name string, data []byte) {
+// peersExcept returns the peers not in the excluded.
+func peersExcept(peers []peer.ID, excluded []peer.ID) []peer.ID {
+	var list []peer.ID
+	for _, p := range peers {
+		if !InArray(p, excluded) {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// doMsgTransfer sends the message to peers who don't know it, returns the peers sent to.
+func (ns *NetService) doMsgTransfer(transfer []peer.ID, digest MessageDigest, name string, data []byte) []peer.ID {
 	node := ns.node
+	var sent []peer.ID
 	for i := 0; i < len(transfer); i++ {
 		nodeID := transfer[i]
-		if InArray(nodeID, relayness) {
+		if node.knownMsgs.Has(nodeID, digest) {
 			logging.VLog().Infof("msgTransfer:  nodeID %s has already have the same message", nodeID)
 			continue
 		}
-		addrs := node.peerstore.PeerInfo(nodeID).Addrs
-		if len(addrs) == 0 || node.host.Addrs()[0].String() == addrs[0].String() {
+		if nodeID == node.id {
 			logging.VLog().Info("msgTransfer: skip self")
 			continue
 		}
-		if len(addrs) > 0 {
-			node.relayness.Add(dataChecksum, append(relayness, nodeID))
-			go ns.SendMsg(name, data, nodeID.Pretty())
+		if len(node.peerstore.PeerInfo(nodeID).Addrs) == 0 {
+			continue
 		}
+		node.knownMsgs.Add(nodeID, digest)
+		sent = append(sent, nodeID)
+		go ns.SendMsg(name, data, nodeID.Pretty())
 	}
+	return sent
 }
 
-func (ns *NetService) doRelay(nodes []peer.ID, relayness []peer.ID, dataChecksum uint32) {
+// doRelay tells the peers not sent the message its digest, so they won't relay it back. The peers sent
+// the message know it's known by the sender.
+func (ns *NetService) doRelay(nodes []peer.ID, digest MessageDigest) {
 	node := ns.node
 	for i := 0; i < len(nodes); i++ {
-		nodeID := nodes[i]
-		if InArray(nodeID, relayness) {
-			logging.VLog().Infof("distribute: relay nodeID %s has already have the same message", nodeID)
+		if nodes[i] == node.id {
 			continue
 		}
-		addrs := node.peerstore.PeerInfo(nodeID).Addrs
-		if len(addrs) == 0 || node.host.Addrs()[0].String() == addrs[0].String() {
-			logging.VLog().Info("distribute: relay skip self")
-			continue
-		}
-		if len(addrs) > 0 {
-			node.relayness.Add(dataChecksum, append(relayness, nodeID))
-			go ns.SendMsg(NewHashMsg, byteutils.FromUint32(dataChecksum), nodeID.Pretty())
-		}
+		go ns.SendMsg(NewDigestMsg, digest[:], nodes[i].Pretty())
 	}
 }
 
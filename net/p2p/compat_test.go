@@ -0,0 +1,235 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	protocol "github.com/libp2p/go-libp2p-protocol"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/messages"
+	"github.com/nebulasio/go-nebulas/net/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// compatFixture is the recording of the frames a node of a previous release put on the wire in a scenario,
+// under testdata/compat/<release>/<scenario>.json. The recordings must never be regenerated by the current code,
+// a failure means the change breaks the interop with that release.
+type compatFixture struct {
+	Description   string         `json:"description"`
+	ClientVersion string         `json:"client_version"`
+	ChainID       uint32         `json:"chain_id"`
+	Version       uint8          `json:"version"`
+	ProtocolID    string         `json:"protocol_id"`
+	Frames        []*compatFrame `json:"frames"`
+}
+
+// compatFrame is a recorded frame and the payload the old node meant to send.
+type compatFrame struct {
+	MsgName string `json:"msg_name"`
+	Frame   string `json:"frame"`
+
+	NodeID        string        `json:"node_id,omitempty"`
+	ClientVersion string        `json:"client_version,omitempty"`
+	NetworkID     uint32        `json:"network_id,omitempty"`
+	Peers         []*compatPeer `json:"peers,omitempty"`
+	BlockHash     string        `json:"block_hash,omitempty"`
+	Height        uint64        `json:"height,omitempty"`
+	LegacyDigest  bool          `json:"legacy_digest,omitempty"`
+}
+
+type compatPeer struct {
+	ID    string   `json:"id"`
+	Addrs []string `json:"addrs"`
+}
+
+func loadCompatFixtures(t *testing.T) map[string]*compatFixture {
+	files, err := filepath.Glob(filepath.Join("testdata", "compat", "*", "*.json"))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, files)
+
+	fixtures := make(map[string]*compatFixture)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		fixture := new(compatFixture)
+		assert.Nil(t, json.Unmarshal(data, fixture), file)
+		fixtures[file] = fixture
+	}
+	return fixtures
+}
+
+func mockCompatNetService(fixture *compatFixture) *NetService {
+	config := &Config{
+		ChainID:  fixture.ChainID,
+		Version:  fixture.Version,
+		Services: DefaultServices,
+	}
+	node := &Node{
+		config:   config,
+		version:  config.Version,
+		services: new(sync.Map),
+	}
+	node.knownMsgs = newKnownMessages(config.RelayCacheSize)
+	return &NetService{node: node}
+}
+
+func TestCompat_Frames(t *testing.T) {
+	for file, fixture := range loadCompatFixtures(t) {
+		for _, frame := range fixture.Frames {
+			t.Run(file+"/"+frame.MsgName, func(t *testing.T) {
+				ns := mockCompatNetService(fixture)
+				data, err := hex.DecodeString(frame.Frame)
+				assert.Nil(t, err)
+				if !assert.True(t, len(data) >= offsetThirtySix) {
+					return
+				}
+
+				// the old frames must be accepted.
+				msg, err := ns.parseMsgHeader(data[:offsetThirtySix])
+				if !assert.Nil(t, err) {
+					return
+				}
+				assert.Equal(t, frame.MsgName, msg.msgName)
+				assert.Equal(t, uint32(len(data)-offsetThirtySix), byteutils.Uint32(msg.dataLength))
+				assert.Nil(t, ns.parseMsgData(msg, data[offsetThirtySix:]))
+
+				// the frames of the same payload must be accepted by the old nodes.
+				assert.Equal(t, data, ns.buildData(msg.data, frame.MsgName))
+
+				checkCompatPayload(t, ns, fixture, frame, msg.data)
+			})
+		}
+	}
+}
+
+// negotiate returns the first protocol of the dialer the listener handles, as multistream-select does.
+func negotiate(dialer, listener []protocol.ID) (protocol.ID, bool) {
+	for _, d := range dialer {
+		for _, l := range listener {
+			if d == l {
+				return d, true
+			}
+		}
+	}
+	return "", false
+}
+
+func TestCompat_Negotiation(t *testing.T) {
+	for file, fixture := range loadCompatFixtures(t) {
+		t.Run(file, func(t *testing.T) {
+			ns := mockCompatNetService(fixture)
+			old := []protocol.ID{protocol.ID(fixture.ProtocolID)}
+			assert.NotEmpty(t, fixture.ProtocolID)
+
+			// the old nodes dial the new ones.
+			id, ok := negotiate(old, ns.node.ProtocolIDs())
+			assert.True(t, ok)
+			assert.Equal(t, old[0], id)
+
+			// the new nodes dial the old ones.
+			id, ok = negotiate(ns.node.ProtocolIDs(), old)
+			assert.True(t, ok)
+			assert.Equal(t, old[0], id)
+
+			// the new nodes prefer the protocol of their chain.
+			id, ok = negotiate(ns.node.ProtocolIDs(), ns.node.ProtocolIDs())
+			assert.True(t, ok)
+			assert.Equal(t, ChainProtocolID(fixture.ChainID), id)
+		})
+	}
+}
+
+func checkCompatPayload(t *testing.T, ns *NetService, fixture *compatFixture, frame *compatFrame, data []byte) {
+	switch frame.MsgName {
+	case HELLO, OK:
+		pb := new(netpb.Hello)
+		assert.Nil(t, proto.Unmarshal(data, pb))
+		hello := new(messages.HelloMessage)
+		assert.Nil(t, hello.FromProto(pb))
+		assert.Equal(t, frame.NodeID, hello.NodeID)
+		assert.Equal(t, frame.ClientVersion, hello.ClientVersion)
+		// the handshake is refused if the client versions differ.
+		assert.Equal(t, ClientVersion, hello.ClientVersion)
+
+		// the old nodes don't advertise services, they are considered to provide the default ones.
+		pid := peer.ID(hello.NodeID)
+		ns.node.setPeerServices(pid, ServiceFlag(hello.Services))
+		services, _ := ns.PeerServices(pid)
+		assert.Equal(t, DefaultServices, services)
+
+		// the old nodes don't advertise genesis, they are not refused.
+		assert.Empty(t, hello.Genesis)
+		genesis := []byte("genesis digest")
+		ns.node.config.Genesis = genesis
+		defer func() { ns.node.config.Genesis = nil }()
+		assert.True(t, ns.node.sameGenesis(hello.Genesis))
+		assert.False(t, ns.node.sameGenesis([]byte("another genesis")))
+
+		// new fields must be appended, the old nodes skip them.
+		current, err := messages.NewHelloMessage(hello.NodeID, ClientVersion, uint64(ns.Services()), genesis, nil).ToProto()
+		assert.Nil(t, err)
+		currentData, err := proto.Marshal(current)
+		assert.Nil(t, err)
+		assert.True(t, bytes.HasPrefix(currentData, data))
+	case NetworkID, NetworkIDReply:
+		assert.Equal(t, 4, len(data))
+		assert.Equal(t, frame.NetworkID, byteutils.Uint32(data))
+	case SyncRoute:
+		assert.Empty(t, data)
+	case SyncRouteReply:
+		pb := new(netpb.Peers)
+		assert.Nil(t, proto.Unmarshal(data, pb))
+		peers := new(messages.Peers)
+		assert.Nil(t, peers.FromProto(pb))
+		if !assert.Equal(t, len(frame.Peers), len(peers.Peers())) {
+			return
+		}
+		for i, v := range peers.Peers() {
+			assert.Equal(t, frame.Peers[i].ID, hex.EncodeToString([]byte(v.ID())))
+			assert.Equal(t, frame.Peers[i].Addrs, v.Addrs())
+		}
+	case net.MessageTypeNewBlock:
+		pb := new(corepb.Block)
+		assert.Nil(t, proto.Unmarshal(data, pb))
+		assert.Equal(t, frame.BlockHash, hex.EncodeToString(pb.Header.Hash))
+		assert.Equal(t, frame.Height, pb.Height)
+		assert.Equal(t, fixture.ChainID, pb.Header.ChainId)
+	case NewHashMsg:
+		// the legacy crc32 digests are ignored, the old nodes only miss the relay hint. The digests
+		// are sent in NewDigestMsg, which the old nodes don't handle.
+		assert.True(t, frame.LegacyDigest)
+		assert.Equal(t, 4, len(data))
+		_, ok := MessageDigestFromBytes(data)
+		assert.False(t, ok)
+		ns.handleNewHashMsg(data, peer.ID("compat"))
+	default:
+		t.Errorf("no payload check for message %s", frame.MsgName)
+	}
+}
@@ -19,9 +19,11 @@
 package p2p
 
 import (
+	"fmt"
 	"net"
 	"time"
 
+	protocol "github.com/libp2p/go-libp2p-protocol"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/util/logging"
@@ -38,10 +40,12 @@ const (
 	DefaultMaxSyncNodes          = 16
 	DefaultChainID               = 1
 	DefaultVersion               = 0
-	DefaultRelayCacheSize        = 65536
+	DefaultRelayCacheSize        = 4096
 	DefaultStreamStoreSize       = 128
 	DefaultStreamStoreExtendSize = 32
 	DefaultNetworkID             = 1
+	DefaultDiscoveryMinInterval  = 2 * time.Second
+	DefaultDiscoveryMaxInterval  = 60 * time.Second
 )
 
 // Config TODO: move to proto config.
@@ -56,10 +60,30 @@ type Config struct {
 	MaxSyncNodes          int
 	ChainID               uint32
 	Version               uint8
-	RelayCacheSize        int
+	RelayCacheSize        int // size of the known messages set of each peer
 	StreamStoreSize       int
 	StreamStoreExtendSize int
 	NetworkID             uint32
+	DiscoveryMinInterval  time.Duration
+	DiscoveryMaxInterval  time.Duration
+	Advertise             []multiaddr.Multiaddr
+	Services              ServiceFlag
+	EnableTracing         bool
+	// Genesis is the digest of the genesis block, advertised in HELLO, set after the chain is loaded.
+	Genesis []byte
+	// LatestIrreversibleBlock returns the hash of the latest irreversible block advertised in HELLO,
+	// set after the chain is loaded.
+	LatestIrreversibleBlock func() []byte
+}
+
+// LegacyProtocolID is the protocol ID of the releases before the chain namespace, 0.2.0 and older.
+// It's still handled and dialed as the fallback, the chain ID in the frame header keeps the chains apart then.
+const LegacyProtocolID = protocol.ID("/neb/" + ProtocolVersion)
+
+// ChainProtocolID returns the protocol ID namespaced by chain ID, e.g. "/neb/1/1.0.0",
+// so nodes of different chains never complete the stream negotiation.
+func ChainProtocolID(chainID uint32) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/neb/%d/%s", chainID, ProtocolVersion))
 }
 
 // Neblet interface breaks cycle import dependency.
@@ -85,7 +109,26 @@ func NewP2PConfig(n Neblet) *Config {
 		}
 	}
 
+	for _, v := range n.Config().Network.Advertise {
+		addr, err := multiaddr.NewMultiaddr(v)
+		if err != nil {
+			logging.VLog().Error("param advertise error, creating advertised address fail", err)
+			return nil
+		}
+		config.Advertise = append(config.Advertise, addr)
+	}
+
+	if names := n.Config().Network.Services; len(names) > 0 {
+		services, err := ParseServices(names)
+		if err != nil {
+			logging.VLog().Error("param services error, ", err)
+			return nil
+		}
+		config.Services = services
+	}
+
 	config.PrivateKey = n.Config().Network.PrivateKey
+	config.EnableTracing = n.Config().Network.EnableTracing
 
 	if chainID := n.Config().Chain.ChainId; chainID > 0 {
 		config.ChainID = chainID
@@ -95,6 +138,16 @@ func NewP2PConfig(n Neblet) *Config {
 		config.NetworkID = networkID
 	}
 
+	if interval := n.Config().Network.DiscoveryMinInterval; interval > 0 {
+		config.DiscoveryMinInterval = time.Duration(interval) * time.Second
+	}
+	if interval := n.Config().Network.DiscoveryMaxInterval; interval > 0 {
+		config.DiscoveryMaxInterval = time.Duration(interval) * time.Second
+	}
+	if config.DiscoveryMaxInterval < config.DiscoveryMinInterval {
+		config.DiscoveryMaxInterval = config.DiscoveryMinInterval
+	}
+
 	return config
 }
 
@@ -130,5 +183,12 @@ func DefautConfig() *Config {
 		DefaultStreamStoreSize,
 		DefaultStreamStoreExtendSize,
 		DefaultNetworkID,
+		DefaultDiscoveryMinInterval,
+		DefaultDiscoveryMaxInterval,
+		[]multiaddr.Multiaddr{},
+		DefaultServices,
+		false,
+		nil,
+		nil,
 	}
 }
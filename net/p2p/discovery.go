@@ -21,29 +21,55 @@ package p2p
 import (
 	"context"
 	"math/rand"
-	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p-peer"
-	ma "github.com/multiformats/go-multiaddr"
 	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
 )
 
+// metrics of discovery effectiveness.
+var (
+	discoveryRounds    = metrics.GetOrRegisterMeter("neb.net.discovery.rounds", nil)
+	discoveryNewPeers  = metrics.GetOrRegisterMeter("neb.net.discovery.peers.new", nil)
+	discoveryLostPeers = metrics.GetOrRegisterMeter("neb.net.discovery.peers.lost", nil)
+	discoveryInterval  = metrics.GetOrRegisterGauge("neb.net.discovery.interval", nil)
+	routeTableSize     = metrics.GetOrRegisterGauge("neb.net.routetable.size", nil)
+)
+
+// churnThreshold the ratio of changed peers in a round above which the route table is considered churning.
+const churnThreshold = 0.25
+
 /*
 discovery node can discover other node or can be discovered by another node
 and then update the routing table.
 */
 func (net *NetService) discovery(ctx context.Context) {
+	node := net.node
+	interval := node.config.DiscoveryMinInterval
+	tracker := newRouteTracker()
 
-	//FIXME  the sync routing table rate can be dynamic
-	interval := 10 * time.Second
-	ticker := time.NewTicker(interval)
 	time.Sleep(1 * time.Second)
+	tracker.update(node.routeTable.ListPeers())
 	net.syncRoutingTable()
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
+			added, removed := tracker.update(node.routeTable.ListPeers())
+			interval = nextDiscoveryInterval(interval, node.config, len(tracker.peers), added+removed)
+
+			discoveryRounds.Mark(1)
+			discoveryNewPeers.Mark(int64(added))
+			discoveryLostPeers.Mark(int64(removed))
+			discoveryInterval.Update(int64(interval / time.Millisecond))
+			routeTableSize.Update(int64(len(tracker.peers)))
+
 			net.syncRoutingTable()
+			timer.Reset(interval)
 		case <-net.quitCh:
 			logging.VLog().Info("discovery service halting")
 			return
@@ -51,6 +77,57 @@ func (net *NetService) discovery(ctx context.Context) {
 	}
 }
 
+// routeTracker remembers the peers of the route table in the last round to measure the churn.
+type routeTracker struct {
+	peers map[peer.ID]bool
+}
+
+func newRouteTracker() *routeTracker {
+	return &routeTracker{peers: make(map[peer.ID]bool)}
+}
+
+// update replaces the tracked peers, returns the count of added and removed peers.
+func (tracker *routeTracker) update(peers []peer.ID) (int, int) {
+	current := make(map[peer.ID]bool, len(peers))
+	added := 0
+	for _, p := range peers {
+		current[p] = true
+		if !tracker.peers[p] {
+			added++
+		}
+	}
+	removed := 0
+	for p := range tracker.peers {
+		if !current[p] {
+			removed++
+		}
+	}
+	tracker.peers = current
+	return added, removed
+}
+
+// nextDiscoveryInterval halves the interval when the route table is small or churning,
+// doubles it when the table is full and stable, within the configured bounds.
+func nextDiscoveryInterval(interval time.Duration, config *Config, size int, churn int) time.Duration {
+	full := config.Bucketsize
+	churning := size == 0 || float64(churn)/float64(size) > churnThreshold
+
+	switch {
+	case size < full/2 || churning:
+		interval /= 2
+	case size >= full && churn == 0:
+		interval *= 2
+	}
+
+	if interval < config.DiscoveryMinInterval {
+		interval = config.DiscoveryMinInterval
+	}
+	if interval > config.DiscoveryMaxInterval {
+		interval = config.DiscoveryMaxInterval
+	}
+	return interval
+}
+
 //sync route table
 func (net *NetService) syncRoutingTable() {
 	node := net.node
@@ -73,24 +150,31 @@ func (net *NetService) syncRoutingTable() {
 				go net.syncSingleNode(nodeID)
 			}
 		}
-	} else if nodeAccount == 0 && len(node.Config().BootNodes) > 0 { // If disconnect from the network, say hello to seed node, reconnect to the network.
-		var wg sync.WaitGroup
-		for _, bootNode := range node.config.BootNodes {
-			wg.Add(1)
-			go func(bootNode ma.Multiaddr) {
-				defer wg.Done()
-				err := net.SayHello(bootNode)
-				if err != nil {
-					logging.VLog().Error("net.start: can not say hello to trusted node.", bootNode, err)
-				}
-
-			}(bootNode)
+	} else if nodeAccount == 0 && node.bootNodes.Len() > 0 { // If disconnect from the network, say hello to seed node, reconnect to the network.
+		// redial the verified peers whose addresses are still retained.
+		for _, pid := range node.addrBook.Peers(AddrClassVerified) {
+			if node.addrBook.Retain(pid) {
+				go net.redial(pid)
+			}
 		}
-		wg.Wait()
+		net.helloBootNodes(node.bootNodes.Addrs())
 	}
 
 }
 
+// redial a verified peer after it went idle.
+func (net *NetService) redial(pid peer.ID) {
+	node := net.node
+	if err := net.Hello(pid); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"id":  pid.Pretty(),
+			"err": err,
+		}).Debug("redial the verified peer fail")
+		return
+	}
+	node.routeTable.Update(pid)
+}
+
 // sync single node routing table by peer.ID
 func (net *NetService) syncSingleNode(nodeID peer.ID) {
 	node := net.node
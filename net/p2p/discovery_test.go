@@ -0,0 +1,72 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteTracker_Update(t *testing.T) {
+	tracker := newRouteTracker()
+
+	added, removed := tracker.update([]peer.ID{"a", "b"})
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 0, removed)
+
+	added, removed = tracker.update([]peer.ID{"b", "c", "d"})
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 1, removed)
+
+	added, removed = tracker.update([]peer.ID{"b", "c", "d"})
+	assert.Equal(t, 0, added)
+	assert.Equal(t, 0, removed)
+}
+
+func TestNextDiscoveryInterval(t *testing.T) {
+	config := DefautConfig()
+	config.Bucketsize = 16
+	config.DiscoveryMinInterval = 2 * time.Second
+	config.DiscoveryMaxInterval = 32 * time.Second
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		size     int
+		churn    int
+		want     time.Duration
+	}{
+		{"empty table", 8 * time.Second, 0, 0, 4 * time.Second},
+		{"small table", 8 * time.Second, 4, 0, 4 * time.Second},
+		{"churning table", 8 * time.Second, 12, 4, 4 * time.Second},
+		{"growing table", 8 * time.Second, 12, 1, 8 * time.Second},
+		{"full stable table", 8 * time.Second, 16, 0, 16 * time.Second},
+		{"full table with churn", 8 * time.Second, 16, 2, 8 * time.Second},
+		{"lower bound", 3 * time.Second, 0, 0, 2 * time.Second},
+		{"upper bound", 20 * time.Second, 16, 0, 32 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextDiscoveryInterval(tt.interval, config, tt.size, tt.churn))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+)
+
+// MessageDigestLength the length of a message digest.
+const MessageDigestLength = 32
+
+// MessageDigest identifies a message by the sha3 hash of its data.
+type MessageDigest [MessageDigestLength]byte
+
+// NewMessageDigest returns the digest of the message data.
+func NewMessageDigest(data []byte) MessageDigest {
+	var digest MessageDigest
+	copy(digest[:], hash.Sha3256(data))
+	return digest
+}
+
+// MessageDigestFromBytes parses a digest received in NewDigestMsg.
+func MessageDigestFromBytes(data []byte) (MessageDigest, bool) {
+	var digest MessageDigest
+	if len(data) != MessageDigestLength {
+		return digest, false
+	}
+	copy(digest[:], data)
+	return digest, true
+}
+
+// knownMessages keeps a bounded set of recently known message digests for each peer,
+// a message is not relayed to a peer who already knows it.
+type knownMessages struct {
+	size  int
+	peers *sync.Map
+}
+
+func newKnownMessages(size int) *knownMessages {
+	return &knownMessages{
+		size:  size,
+		peers: new(sync.Map),
+	}
+}
+
+func (km *knownMessages) set(pid peer.ID) *lru.Cache {
+	if set, ok := km.peers.Load(pid); ok {
+		return set.(*lru.Cache)
+	}
+	set, _ := lru.New(km.size)
+	actual, _ := km.peers.LoadOrStore(pid, set)
+	return actual.(*lru.Cache)
+}
+
+// Add marks the message is known by the peer.
+func (km *knownMessages) Add(pid peer.ID, digest MessageDigest) {
+	km.set(pid).Add(digest, true)
+}
+
+// Has returns if the peer knows the message.
+func (km *knownMessages) Has(pid peer.ID, digest MessageDigest) bool {
+	set, ok := km.peers.Load(pid)
+	if !ok {
+		return false
+	}
+	return set.(*lru.Cache).Contains(digest)
+}
+
+// Remove drops the set of a peer.
+func (km *knownMessages) Remove(pid peer.ID) {
+	km.peers.Delete(pid)
+}
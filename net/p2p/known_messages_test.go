@@ -0,0 +1,66 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"testing"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageDigestFromBytes(t *testing.T) {
+	digest := NewMessageDigest([]byte("block"))
+	parsed, ok := MessageDigestFromBytes(digest[:])
+	assert.True(t, ok)
+	assert.Equal(t, digest, parsed)
+
+	_, ok = MessageDigestFromBytes([]byte{1, 2, 3, 4})
+	assert.False(t, ok)
+}
+
+func TestKnownMessages(t *testing.T) {
+	km := newKnownMessages(2)
+	a, b := peer.ID("a"), peer.ID("b")
+	msg1 := NewMessageDigest([]byte("msg1"))
+	msg2 := NewMessageDigest([]byte("msg2"))
+	msg3 := NewMessageDigest([]byte("msg3"))
+
+	km.Add(a, msg1)
+	assert.True(t, km.Has(a, msg1))
+	assert.False(t, km.Has(b, msg1))
+
+	// the set of each peer is bounded.
+	km.Add(a, msg2)
+	km.Add(a, msg3)
+	assert.False(t, km.Has(a, msg1))
+	assert.True(t, km.Has(a, msg2))
+	assert.True(t, km.Has(a, msg3))
+
+	km.Remove(a)
+	assert.False(t, km.Has(a, msg3))
+}
+
+func TestPeersExcept(t *testing.T) {
+	a, b, c := peer.ID("a"), peer.ID("b"), peer.ID("c")
+	// the digest is only relayed to the peers not sent the message.
+	assert.Equal(t, []peer.ID{a, c}, peersExcept([]peer.ID{a, b, c}, []peer.ID{b}))
+	assert.Equal(t, []peer.ID{a, b}, peersExcept([]peer.ID{a, b}, nil))
+	assert.Empty(t, peersExcept([]peer.ID{a}, []peer.ID{a}))
+}
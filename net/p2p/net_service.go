@@ -25,7 +25,6 @@ import (
 	"io"
 	"reflect"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -45,19 +44,21 @@ import (
 
 // connection state
 const (
-	ProtocolID     = "/neb/1.0.0"
-	SNC            = -1
-	SHandshaking   = 0
-	SOK            = 1
-	HELLO          = "hello"
-	OK             = "ok"
-	BYE            = "bye"
-	SyncRoute      = "syncroute"
-	SyncRouteReply = "resyncroute"
-	NewHashMsg     = "newhashmsg"
-	ClientVersion  = "0.2.0"
-	NetworkID      = "networkid"
-	NetworkIDReply = "renetworkid"
+	// ProtocolVersion the version of the wire protocol, the protocol ID is namespaced by chain ID, see ChainProtocolID.
+	ProtocolVersion = "1.0.0"
+	SNC             = -1
+	SHandshaking    = 0
+	SOK             = 1
+	HELLO           = "hello"
+	OK              = "ok"
+	BYE             = "bye"
+	SyncRoute       = "syncroute"
+	SyncRouteReply  = "resyncroute"
+	NewHashMsg      = "newhashmsg"
+	NewDigestMsg    = "newdigestmsg"
+	ClientVersion   = "0.2.0"
+	NetworkID       = "networkid"
+	NetworkIDReply  = "renetworkid"
 )
 
 // MagicNumber the protocol magic number, A constant numerical or text value used to identify protocol.
@@ -86,13 +87,16 @@ type NetService struct {
 	node       *Node
 	quitCh     chan bool
 	dispatcher *net.Dispatcher
+	blockDedup *blockDedup
+	validation *validation
 }
 
 /*
 Protocol In Nebulas, we define our own wire protocol, as the following:
 
- 0               1               2               3              (bytes)
- 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	0               1               2               3              (bytes)
+	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+
 +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 |                         Magic Number                          |
 +---------------------------------------------------------------+
@@ -129,6 +133,7 @@ type NebMessage struct {
 	header         []byte
 	data           []byte
 	reserved       []byte
+	trace          *net.Trace
 }
 
 // NewNetManager create netService
@@ -139,22 +144,25 @@ func NewNetManager(n Neblet) (*NetService, error) {
 		logging.VLog().Error("NewNetService: node create fail -> ", err)
 		return nil, err
 	}
-	ns := &NetService{node, make(chan bool), net.NewDispatcher()}
+	ns := &NetService{node, make(chan bool), net.NewDispatcher(), newBlockDedup(DefaultBlockDedupSize), nil}
+	ns.validation = newValidation(DefaultValidationWorkers, ns.PutMessage, ns.disconnect)
 	return ns, nil
 }
 
 func (ns *NetService) registerNetManager() *NetService {
 	// register streamHandler to start loop to handle stream origined from remote node.
-	ns.node.host.SetStreamHandler(ProtocolID, ns.streamHandler)
+	for _, id := range ns.node.ProtocolIDs() {
+		ns.node.host.SetStreamHandler(id, ns.streamHandler)
+	}
 	logging.VLog().Info("RegisterNetService: register netservice success")
 	return ns
 }
 
-// Addrs return peer address in string
+// Addrs return the first advertised address of the node, see AdvertisedAddrs for all of them.
 func (ns *NetService) Addrs() ma.Multiaddr {
-	len := len(ns.node.host.Addrs())
-	if len > 0 {
-		return ns.node.host.Addrs()[0]
+	addrs := ns.AdvertisedAddrs(false)
+	if len(addrs) > 0 {
+		return addrs[0]
 	}
 	return nil
 
@@ -177,6 +185,14 @@ func (ns *NetService) streamHandler(s libnet.Stream) {
 	addrs := s.Conn().RemoteMultiaddr()
 	key := pid.Pretty()
 
+	if ns.validation.Banned(pid) {
+		logging.VLog().WithFields(logrus.Fields{
+			"addrs": addrs,
+		}).Debug("Refused the banned peer.")
+		s.Close()
+		return
+	}
+
 	for {
 		select {
 		case <-ns.quitCh:
@@ -199,6 +215,9 @@ func (ns *NetService) streamHandler(s libnet.Stream) {
 					continue
 				}
 				tmpMsg, err = ns.parseMsgHeader(streamBuffer)
+				if err == nil && node.config.EnableTracing {
+					tmpMsg.trace = net.NewTrace(tmpMsg.msgName)
+				}
 				if err != nil {
 					logging.VLog().WithFields(logrus.Fields{
 						"addrs": addrs.String(),
@@ -247,12 +266,13 @@ func (ns *NetService) streamHandler(s libnet.Stream) {
 				ns.handleSyncRouteReplyMsg(msg.data, pid, s, addrs)
 			case NewHashMsg:
 				ns.handleNewHashMsg(msg.data, pid)
+			case NewDigestMsg:
+				ns.handleNewDigestMsg(msg.data, pid)
 			case NetworkID:
 				ns.handleNetworkIDMsg(msg.data, pid, s)
 			case NetworkIDReply:
 				ns.handleReNetworkIDMsg(msg.data, pid)
 			default:
-				var relayness []peer.ID
 				logging.VLog().WithFields(logrus.Fields{
 					"msgName": msg.msgName,
 					"pid":     pid.Pretty(),
@@ -273,13 +293,29 @@ func (ns *NetService) streamHandler(s libnet.Stream) {
 					ns.Bye(pid, []ma.Multiaddr{addrs}, s, key)
 					return
 				}
-				ns.PutMessage(messages.NewBaseMessage(msg.msgName, pid.Pretty(), msg.data))
-
-				peers, exists := node.relayness.Get(byteutils.Uint32(msg.dataChecksum))
-				if exists {
-					relayness = peers.([]peer.ID)
+				node.knownMsgs.Add(pid, NewMessageDigest(msg.data))
+				if !verifySyncMsg(msg.msgName, msg.data) {
+					syncMsgRejected.Mark(1)
+					logging.VLog().WithFields(logrus.Fields{
+						"msgName": msg.msgName,
+						"pid":     pid.Pretty(),
+					}).Warn("reject the invalid sync message.")
+					continue
+				}
+				// only the first copy of a block message, in full or compact, goes to the subscribers.
+				if msg.msgName == net.MessageTypeNewBlock && ns.blockDedup.Duplicate(msg.data) {
+					msg.trace.Finish("duplicated")
+					continue
+				}
+				if msg.msgName == net.MessageTypeCompactBlock && ns.blockDedup.DuplicateCompact(msg.data) {
+					msg.trace.Finish("duplicated")
+					continue
+				}
+				if node.config.EnableTracing {
+					ns.validation.Submit(messages.NewTracedMessage(msg.msgName, pid.Pretty(), msg.data, msg.trace), pid)
+				} else {
+					ns.validation.Submit(messages.NewBaseMessage(msg.msgName, pid.Pretty(), msg.data), pid)
 				}
-				node.relayness.Add(byteutils.Uint32(msg.dataChecksum), append(relayness, pid))
 			}
 
 		}
@@ -365,11 +401,23 @@ func (ns *NetService) handleHelloMsg(data []byte, pid peer.ID, s libnet.Stream,
 		"pid":           pid,
 		"addrs":         addrs.String(),
 		"ClientVersion": hello.ClientVersion,
+		"services":      ServiceFlag(hello.Services),
+		"genesis":       byteutils.Hex(hello.Genesis),
+		"lib":           byteutils.Hex(hello.LIB),
 	}).Info("receive hello message.")
 
+	if !node.sameGenesis(hello.Genesis) {
+		logging.VLog().WithFields(logrus.Fields{
+			"pid":     pid,
+			"expect":  byteutils.Hex(node.config.Genesis),
+			"genesis": byteutils.Hex(hello.Genesis),
+		}).Warn("Refused the peer of another genesis.")
+		return result
+	}
+
 	//Todo: clientVersion backwards compatible
 	if hello.NodeID == pid.String() && hello.ClientVersion == ClientVersion {
-		ok := messages.NewHelloMessage(node.id.String(), ClientVersion)
+		ok := messages.NewHelloMessage(node.id.String(), ClientVersion, uint64(node.config.Services), node.config.Genesis, node.latestIrreversibleBlock())
 		pbok, err := ok.ToProto()
 		okdata, err := proto.Marshal(pbok)
 		if err != nil {
@@ -377,11 +425,13 @@ func (ns *NetService) handleHelloMsg(data []byte, pid peer.ID, s libnet.Stream,
 			return result
 		}
 
+		node.addrBook.Mark(pid, AddrClassVerified)
 		node.peerstore.AddAddr(
 			pid,
 			addrs,
-			peerstore.PermanentAddrTTL,
+			node.addrBook.ConnectedTTL(pid),
 		)
+		node.setPeerServices(pid, ServiceFlag(hello.Services))
 
 		if err := ns.sendMsg(OK, okdata, s); err != nil {
 			logging.VLog().Error("send ok msg occurs error, ", err)
@@ -425,15 +475,26 @@ func (ns *NetService) handleOkMsg(data []byte, pid peer.ID, s libnet.Stream, add
 		return result
 	}
 
+	if !node.sameGenesis(ok.Genesis) {
+		logging.VLog().WithFields(logrus.Fields{
+			"pid":     pid,
+			"expect":  byteutils.Hex(node.config.Genesis),
+			"genesis": byteutils.Hex(ok.Genesis),
+		}).Warn("Refused the peer of another genesis.")
+		return result
+	}
+
 	if ok.NodeID == pid.String() && ok.ClientVersion == ClientVersion {
 		streamStore := NewStreamStore(key, SOK, s)
 		node.stream.Store(key, streamStore)
 		node.streamCache.Insert(streamStore)
+		node.addrBook.Mark(pid, AddrClassVerified)
 		node.peerstore.AddAddr(
 			pid,
 			addrs,
-			peerstore.PermanentAddrTTL,
+			node.addrBook.ConnectedTTL(pid),
 		)
+		node.setPeerServices(pid, ServiceFlag(ok.Services))
 		node.routeTable.Update(pid)
 
 		result = true
@@ -463,14 +524,26 @@ func (ns *NetService) handleReNetworkIDMsg(data []byte, pid peer.ID) {
 	node.networkIDCache.Add(pid.Pretty(), networkID)
 }
 
+// handleNewHashMsg ignores the crc32 checksum of a message relayed by an old node, it can't be matched
+// with the digests, the old node only misses the relay hint.
 func (ns *NetService) handleNewHashMsg(data []byte, pid peer.ID) {
-	var relayness []peer.ID
+	logging.VLog().WithFields(logrus.Fields{
+		"pid": pid.Pretty(),
+		"len": len(data),
+	}).Debug("ignore legacy message checksum.")
+}
+
+func (ns *NetService) handleNewDigestMsg(data []byte, pid peer.ID) {
 	node := ns.node
-	peers, exists := node.relayness.Get(byteutils.Uint32(data))
-	if exists {
-		relayness = peers.([]peer.ID)
+	digest, ok := MessageDigestFromBytes(data)
+	if !ok {
+		logging.VLog().WithFields(logrus.Fields{
+			"pid": pid.Pretty(),
+			"len": len(data),
+		}).Debug("receive invalid message digest.")
+		return
 	}
-	node.relayness.Add(byteutils.Uint32(data), append(relayness, pid))
+	node.knownMsgs.Add(pid, digest)
 }
 
 func (ns *NetService) handleSyncRouteMsg(data []byte, pid peer.ID, s libnet.Stream, addrs ma.Multiaddr, key string) bool {
@@ -482,9 +555,16 @@ func (ns *NetService) handleSyncRouteMsg(data []byte, pid peer.ID, s libnet.Stre
 		}
 	}()
 	peers := node.routeTable.NearestPeers(kbucket.ConvertPeerID(pid), node.config.MaxSyncNodes)
+	// don't advertise private addresses to a public peer.
+	public := IsPublicAddr(addrs)
 	var peerList []*messages.PeerInfo
 	for i := range peers {
 		peerInfo := node.peerstore.PeerInfo(peers[i])
+		if peerInfo.ID == node.id {
+			peerInfo.Addrs = ns.AdvertisedAddrs(public)
+		} else {
+			peerInfo.Addrs = FilterAddrs(peerInfo.Addrs, public)
+		}
 		if len(peerInfo.Addrs) == 0 {
 			logging.VLog().WithFields(logrus.Fields{
 				"nodeId": peerInfo.ID.Pretty(),
@@ -555,10 +635,11 @@ func (ns *NetService) handleSyncRouteReplyMsg(data []byte, pid peer.ID, s libnet
 			"addrs": addres,
 		}).Info("discover new node")
 
+		node.addrBook.Mark(id, AddrClassDiscovered)
 		node.peerstore.AddAddrs(
 			id,
 			addres,
-			peerstore.ProviderAddrTTL,
+			DiscoveredAddrTTL,
 		)
 		if err := ns.Hello(id); err != nil {
 			logging.VLog().WithFields(logrus.Fields{
@@ -603,15 +684,37 @@ func (ns *NetService) verifyHeader(nebMsg *NebMessage) bool {
 // Bye say bye to a peer, and close connection.
 func (ns *NetService) Bye(pid peer.ID, addrs []ma.Multiaddr, s libnet.Stream, key string) {
 	node := ns.node
+	// the peer was alive until now if the connection is established.
+	if _, ok := node.stream.Load(key); ok {
+		node.addrBook.Touch(pid)
+	}
 	ns.clearPeerStore(pid, addrs)
 	node.stream.Delete(key)
+	node.knownMsgs.Remove(pid)
+	node.services.Delete(pid)
 	s.Close()
 }
 
+// disconnect closes the connection with a peer if it's established.
+func (ns *NetService) disconnect(pid peer.ID) {
+	key := pid.Pretty()
+	v, ok := ns.node.stream.Load(key)
+	if !ok {
+		return
+	}
+	s := v.(*StreamStore).stream
+	ns.Bye(pid, []ma.Multiaddr{s.Conn().RemoteMultiaddr()}, s, key)
+}
+
 func (ns *NetService) clearPeerStore(pid peer.ID, addrs []ma.Multiaddr) {
 	node := ns.node
-	node.peerstore.SetAddrs(pid, addrs, 0)
-	if !InArray(pid.Pretty(), node.bootIds) {
+	// keep the addresses of boot nodes and verified peers for redial.
+	ttl := node.addrBook.IdleTTL(pid)
+	node.peerstore.SetAddrs(pid, addrs, ttl)
+	if ttl == 0 {
+		node.addrBook.Forget(pid)
+	}
+	if !node.addrBook.IsBoot(pid) {
 		node.routeTable.Remove(pid)
 	}
 }
@@ -670,17 +773,20 @@ func (ns *NetService) checkNetworkID(target string) bool {
 // Hello say hello to a peer
 func (ns *NetService) Hello(pid peer.ID) error {
 	node := ns.node
+	if ns.validation.Banned(pid) {
+		return ErrPeerBanned
+	}
 
 	stream, err := node.host.NewStream(
 		node.context,
 		pid,
-		ProtocolID,
+		node.ProtocolIDs()...,
 	)
 	if err != nil {
 		return err
 	}
 
-	hello := messages.NewHelloMessage(node.id.String(), ClientVersion)
+	hello := messages.NewHelloMessage(node.id.String(), ClientVersion, uint64(node.config.Services), node.config.Genesis, node.latestIrreversibleBlock())
 	pb, _ := hello.ToProto()
 	data, err := proto.Marshal(pb)
 	if err != nil {
@@ -749,13 +855,16 @@ func (ns *NetService) BuildData(data []byte, msgName string) []byte {
 func (ns *NetService) Start() error {
 	err := ns.start()
 	ns.dispatcher.Start()
+	ns.validation.Start()
 	return err
 }
 
 // Stop stop p2p manager.
 func (ns *NetService) Stop() {
+	ns.validation.Stop()
 	ns.dispatcher.Stop()
-	ns.quitCh <- true
+	// closed rather than sent, all the loops of the service wait on it.
+	close(ns.quitCh)
 }
 
 // Register register the subscribers.
@@ -768,6 +877,11 @@ func (ns *NetService) Deregister(subscribers ...*net.Subscriber) {
 	ns.dispatcher.Deregister(subscribers...)
 }
 
+// RegisterValidator sets the validator checking the messages of a type before they are dispatched.
+func (ns *NetService) RegisterValidator(msgType string, validator net.Validator) {
+	ns.validation.Register(msgType, validator)
+}
+
 // PutMessage put message to dispatcher.
 func (ns *NetService) PutMessage(msg net.Message) {
 	ns.dispatcher.PutMessage(msg)
@@ -787,33 +901,15 @@ func (ns *NetService) start() error {
 
 	ns.registerNetManager()
 
-	// TODO: All fail handle
-	var success bool
-	var wg sync.WaitGroup
-	for _, bootNode := range node.config.BootNodes {
-		wg.Add(1)
-		go func(bootNode ma.Multiaddr) {
-			defer wg.Done()
-			err := ns.SayHello(bootNode)
-			if err != nil {
-				logging.VLog().Error("net.start: can not say hello to trusted node.", bootNode, err)
-			} else {
-				logging.CLog().Info("net.start: say hello to trusted node.", bootNode)
-				success = true
-			}
-
-		}(bootNode)
+	// the node starts even if all boot nodes are down, they are retried in background.
+	if node.bootNodes.Len() > 0 && !ns.helloBootNodes(node.bootNodes.Addrs()) {
+		logging.CLog().Warn("net.start: say hello to bootNode fail, keep retrying in background.")
 	}
-	wg.Wait()
 
-	if success || len(node.Config().BootNodes) == 0 {
-		go ns.discovery(node.context)
-		go ns.manageStreamStore()
-		logging.CLog().Infof("net.start: node start and join to p2p network success and listening for connections on %s... ", node.config.Listen)
-	} else {
-		logging.VLog().Error("net.start: node start occurs error, say hello to bootNode fail")
-		return errors.New("net.start: node start occurs error, say hello to bootNode fail")
-	}
+	go ns.discovery(node.context)
+	go ns.manageStreamStore()
+	go ns.retryBootNodes()
+	logging.CLog().Infof("net.start: node start and join to p2p network success and listening for connections on %s... ", node.config.Listen)
 	return nil
 }
 
@@ -834,10 +930,12 @@ func (ns *NetService) manageStreamStore() {
 func (ns *NetService) cleanPeerStore() {
 	node := ns.node
 	for _, v := range node.peerstore.Peers() {
-		if _, ok := node.stream.Load(v.Pretty()); !ok {
-			if !InArray(v.Pretty(), node.bootIds) {
-				node.peerstore.ClearAddrs(v)
-			}
+		if v == node.id {
+			continue
+		}
+		if _, ok := node.stream.Load(v.Pretty()); !ok && !node.addrBook.Retain(v) {
+			node.peerstore.ClearAddrs(v)
+			node.addrBook.Forget(v)
 		}
 	}
 }
@@ -897,13 +995,14 @@ func (ns *NetService) SayHello(bootNode ma.Multiaddr) error {
 		}).Error("parse Address from trustedNode failed")
 		return err
 	}
-	node.bootIds = append(node.bootIds, bootID.Pretty())
+	// addresses of boot nodes are never purged, the node may redial them at any time.
+	node.addrBook.Mark(bootID, AddrClassBoot)
 	node.peerstore.AddAddr(
 		bootID,
 		bootAddr,
-		peerstore.ProviderAddrTTL,
+		peerstore.PermanentAddrTTL,
 	)
-	if node.host.Addrs()[0].String() != bootAddr.String() {
+	if bootID != node.id {
 		if err := ns.Hello(bootID); err != nil {
 			logging.VLog().WithFields(logrus.Fields{
 				"bootNode": bootNode,
@@ -914,10 +1013,6 @@ func (ns *NetService) SayHello(bootNode ma.Multiaddr) error {
 		logging.CLog().WithFields(logrus.Fields{
 			"bootNode": bootNode,
 		}).Info("say hello to a node success")
-		node.peerstore.AddAddr(
-			bootID,
-			bootAddr,
-			peerstore.PermanentAddrTTL)
 		// Update the routing table.
 		node.routeTable.Update(bootID)
 	}
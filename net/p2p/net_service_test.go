@@ -0,0 +1,88 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"sync"
+	"testing"
+
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
+	libnet "github.com/libp2p/go-libp2p-net"
+	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStream struct {
+	libnet.Stream
+	closed bool
+}
+
+func (s *mockStream) Close() error {
+	s.closed = true
+	return nil
+}
+
+func mockPeerID(t *testing.T, id string) peer.ID {
+	pid, err := peer.IDB58Decode(id)
+	assert.Nil(t, err)
+	return pid
+}
+
+// mockPeerNetService returns a net service of an unstarted node with an empty peerstore.
+func mockPeerNetService(t *testing.T, chainID uint32) *NetService {
+	config := &Config{
+		ChainID:    chainID,
+		Bucketsize: DefaultBucketsize,
+		Latency:    DefaultLatency,
+	}
+	node := &Node{
+		id:        mockPeerID(t, "QmPyr4ZbDmwF1nWxymTktdzspcBFPL6X1v3Q5nT7PGNtUN"),
+		config:    config,
+		peerstore: peerstore.NewPeerstore(),
+		stream:    new(sync.Map),
+		services:  new(sync.Map),
+		addrBook:  newAddrBook(),
+		knownMsgs: newKnownMessages(config.RelayCacheSize),
+	}
+	node.routeTable = kbucket.NewRoutingTable(
+		config.Bucketsize,
+		kbucket.ConvertPeerID(node.id),
+		config.Latency,
+		node.peerstore,
+	)
+	return &NetService{node: node}
+}
+
+func TestNetService_Bye(t *testing.T) {
+	ns := mockPeerNetService(t, 1)
+	node := ns.node
+	pid := mockPeerID(t, "QmWxEZdpvRQP5xX8bN8jDpXT8wM9AWZ5Lxy1M1ZgVXz8Mo")
+	s := &mockStream{}
+
+	node.stream.Store(pid.Pretty(), NewStreamStore(pid.Pretty(), 1, s))
+	node.services.Store(pid, DefaultServices)
+
+	ns.Bye(pid, nil, s, pid.Pretty())
+	assert.True(t, s.closed)
+	_, ok := node.stream.Load(pid.Pretty())
+	assert.False(t, ok)
+	_, ok = node.services.Load(pid)
+	assert.False(t, ok)
+}
@@ -24,7 +24,6 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	mrand "math/rand"
 	"net"
@@ -37,6 +36,7 @@ import (
 	libnet "github.com/libp2p/go-libp2p-net"
 	"github.com/libp2p/go-libp2p-peer"
 	"github.com/libp2p/go-libp2p-peerstore"
+	protocol "github.com/libp2p/go-libp2p-protocol"
 	"github.com/libp2p/go-libp2p-swarm"
 	"github.com/libp2p/go-libp2p/p2p/host/basic"
 	"github.com/multiformats/go-multiaddr"
@@ -68,9 +68,12 @@ type Node struct {
 	running       bool
 	synchronizing bool
 	syncList      []string
-	// key: datachecksum value: peer.ID
-	relayness      *lru.Cache
-	bootIds        []string
+	// key: peer.ID value: digests of messages known by the peer
+	knownMsgs *knownMessages
+	// key: peer.ID value: ServiceFlag advertised in handshake
+	services       *sync.Map
+	addrBook       *addrBook
+	bootNodes      *bootNodes
 	networkIDCache *lru.Cache
 }
 
@@ -116,6 +119,33 @@ func (node *Node) Config() *Config {
 	return node.config
 }
 
+// sameGenesis returns if a peer advertised the same genesis in handshake.
+// The peers not advertising genesis, or the node not knowing its own, are not checked.
+func (node *Node) sameGenesis(genesis []byte) bool {
+	if len(genesis) == 0 || len(node.config.Genesis) == 0 {
+		return true
+	}
+	return bytes.Equal(genesis, node.config.Genesis)
+}
+
+// latestIrreversibleBlock returns the hash of the latest irreversible block of the node, nil if unknown.
+func (node *Node) latestIrreversibleBlock() []byte {
+	if node.config.LatestIrreversibleBlock == nil {
+		return nil
+	}
+	return node.config.LatestIrreversibleBlock()
+}
+
+// ProtocolID return the protocol ID of the node's chain.
+func (node *Node) ProtocolID() protocol.ID {
+	return ChainProtocolID(node.config.ChainID)
+}
+
+// ProtocolIDs return the protocol IDs the node speaks, the preferred first.
+func (node *Node) ProtocolIDs() []protocol.ID {
+	return []protocol.ID{node.ProtocolID(), LegacyProtocolID}
+}
+
 // ID return node ID.
 func (node *Node) ID() string {
 	return node.id.Pretty()
@@ -143,7 +173,11 @@ func (node *Node) GetStream() *sync.Map {
 
 func (node *Node) checkPort() error {
 	for _, v := range node.config.Listen {
-		conn, err := net.Dial("tcp", v)
+		_, hostPort, err := ParseListenAddr(v)
+		if err != nil {
+			return err
+		}
+		conn, err := net.Dial("tcp", hostPort)
 		if err == nil {
 			conn.Close()
 			return errors.New("The port already in use")
@@ -230,23 +264,16 @@ func (node *Node) init() error {
 	node.routeTable.Update(node.id)
 
 	node.stream = new(sync.Map)
+	node.services = new(sync.Map)
 	node.streamCache = pdeque.NewPriorityDeque(less)
 	node.version = node.config.Version
 
 	var multiaddrs []multiaddr.Multiaddr
 	for _, v := range node.config.Listen {
-		tcpAddr, err := net.ResolveTCPAddr("tcp", v)
+		address, _, err := ParseListenAddr(v)
 		if err != nil {
 			return err
 		}
-
-		address, err := multiaddr.NewMultiaddr(
-			fmt.Sprintf(
-				"/ip4/%s/tcp/%d",
-				tcpAddr.IP,
-				tcpAddr.Port,
-			),
-		)
 		multiaddrs = append(multiaddrs, address)
 	}
 
@@ -257,7 +284,9 @@ func (node *Node) init() error {
 		node.peerstore,
 		nil,
 	)
-	node.relayness, err = lru.New(node.config.RelayCacheSize)
+	node.addrBook = newAddrBook()
+	node.bootNodes = newBootNodes(node.config.BootNodes)
+	node.knownMsgs = newKnownMessages(node.config.RelayCacheSize)
 	node.networkIDCache, err = lru.New(node.config.StreamStoreSize)
 
 	options := &basichost.HostOpts{}
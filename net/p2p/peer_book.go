@@ -0,0 +1,229 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// peer scores in the address book, higher is better.
+const (
+	PeerScoreKnown     = 0
+	PeerScoreRouted    = 1
+	PeerScoreConnected = 2
+)
+
+// peerScoreKey is the key of the imported score of a peer in the peerstore.
+const peerScoreKey = "nebulas/peer_score"
+
+// ImportedPeerScoreDecay is the time in which the imported score of a peer drops by one.
+var ImportedPeerScoreDecay = 10 * time.Minute
+
+// importedScore is the score of a peer read from an address book, it's only a starting
+// value and decays till the peer is seen again.
+type importedScore struct {
+	score int
+	at    time.Time
+}
+
+// current returns the decayed score at now.
+func (s *importedScore) current(now time.Time) int {
+	score := s.score - int(now.Sub(s.at)/ImportedPeerScoreDecay)
+	if score < PeerScoreKnown {
+		return PeerScoreKnown
+	}
+	return score
+}
+
+// errors
+var (
+	ErrInvalidPeerBook = errors.New("invalid peer address book")
+	ErrPeerNotBanned   = errors.New("peer is not banned")
+)
+
+// PeerRecord is an entry of the peer address book.
+type PeerRecord struct {
+	ID    string   `json:"id"`
+	Addrs []string `json:"addrs"`
+	Score int      `json:"score"`
+}
+
+// PeerBook is the exported peer address book.
+type PeerBook struct {
+	ChainID uint32        `json:"chain_id"`
+	Peers   []*PeerRecord `json:"peers"`
+}
+
+// ExportPeers writes the known peers with their addresses and scores to w in JSON.
+func (ns *NetService) ExportPeers(w io.Writer) error {
+	book := &PeerBook{ChainID: ns.node.config.ChainID, Peers: ns.Peers()}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(book); err != nil {
+		return err
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"count": len(book.Peers),
+	}).Info("Exported peer address book.")
+	return nil
+}
+
+// Peers returns the known peers with their addresses and scores, the best first.
+func (ns *NetService) Peers() []*PeerRecord {
+	node := ns.node
+
+	routed := make(map[peer.ID]bool)
+	for _, v := range node.routeTable.ListPeers() {
+		routed[v] = true
+	}
+
+	now := time.Now()
+	var peers []*PeerRecord
+	for _, pid := range node.peerstore.Peers() {
+		if pid == node.id {
+			continue
+		}
+		addrs := node.peerstore.Addrs(pid)
+		if len(addrs) == 0 {
+			continue
+		}
+		record := &PeerRecord{ID: pid.Pretty(), Score: PeerScoreKnown}
+		for _, addr := range addrs {
+			record.Addrs = append(record.Addrs, addr.String())
+		}
+		if routed[pid] {
+			record.Score = PeerScoreRouted
+		}
+		if _, ok := node.stream.Load(pid.Pretty()); ok {
+			record.Score = PeerScoreConnected
+		}
+		// the imported score of a peer decays till it's seen better.
+		if v, err := node.peerstore.Get(pid, peerScoreKey); err == nil {
+			if imported, ok := v.(*importedScore); ok {
+				if score := imported.current(now); score > record.Score {
+					record.Score = score
+				}
+			}
+		}
+		peers = append(peers, record)
+	}
+	sort.SliceStable(peers, func(i, j int) bool {
+		return peers[i].Score > peers[j].Score
+	})
+	return peers
+}
+
+// BanPeer bans the peer for the duration, it's disconnected and refused till then.
+func (ns *NetService) BanPeer(id string, d time.Duration) error {
+	pid, err := peer.IDB58Decode(id)
+	if err != nil {
+		return err
+	}
+	ns.validation.Ban(pid, d)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"pid":   id,
+		"until": time.Now().Add(d),
+	}).Warn("Banned the peer by admin.")
+	return nil
+}
+
+// UnbanPeer lifts the ban of the peer.
+func (ns *NetService) UnbanPeer(id string) error {
+	pid, err := peer.IDB58Decode(id)
+	if err != nil {
+		return err
+	}
+	if !ns.validation.Unban(pid) {
+		return ErrPeerNotBanned
+	}
+	return nil
+}
+
+// ImportPeers reads a JSON peer address book from r and seeds the peerstore with it, the peers
+// routed or connected before are added to the route table too. It returns the number of imported peers.
+func (ns *NetService) ImportPeers(r io.Reader) (int, error) {
+	node := ns.node
+
+	book := new(PeerBook)
+	if err := json.NewDecoder(r).Decode(book); err != nil {
+		return 0, err
+	}
+	if book.ChainID != 0 && book.ChainID != node.config.ChainID {
+		logging.VLog().WithFields(logrus.Fields{
+			"expect": node.config.ChainID,
+			"actual": book.ChainID,
+		}).Error("Failed to import peer address book of another chain.")
+		return 0, ErrInvalidPeerBook
+	}
+
+	// seed the best peers first, the route table may be full before the end.
+	sort.SliceStable(book.Peers, func(i, j int) bool {
+		return book.Peers[i].Score > book.Peers[j].Score
+	})
+
+	count := 0
+	for _, record := range book.Peers {
+		pid, err := peer.IDB58Decode(record.ID)
+		if err != nil || pid == node.id {
+			continue
+		}
+		var addrs []ma.Multiaddr
+		for _, v := range record.Addrs {
+			addr, err := ma.NewMultiaddr(v)
+			if err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"id":   record.ID,
+					"addr": v,
+					"err":  err,
+				}).Warn("Skip invalid address in peer address book.")
+				continue
+			}
+			addrs = append(addrs, addr)
+		}
+		if len(addrs) == 0 {
+			continue
+		}
+
+		node.addrBook.Mark(pid, AddrClassDiscovered)
+		node.peerstore.AddAddrs(pid, addrs, DiscoveredAddrTTL)
+		node.peerstore.Put(pid, peerScoreKey, &importedScore{score: record.Score, at: time.Now()})
+		if record.Score >= PeerScoreRouted {
+			node.routeTable.Update(pid)
+		}
+		count++
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"count": count,
+		"total": len(book.Peers),
+	}).Info("Imported peer address book.")
+	return count, nil
+}
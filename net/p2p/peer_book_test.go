@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerBook_RoundTrip(t *testing.T) {
+	ns := mockPeerNetService(t, 100)
+	node := ns.node
+
+	known := mockPeerID(t, "QmP7HDFcYmJL12Ez4ZNVCKjKedfE7f48f1LAkUc3Whz4jP")
+	routed := mockPeerID(t, "QmWxEZdpvRQP5xX8bN8jDpXT8wM9AWZ5Lxy1M1ZgVXz8Mo")
+	connected := mockPeerID(t, "QmUSVNVYYqCeS6M9b9eDgdnX8sPRsNT7h3JFZtMfHdBfJ8")
+	node.peerstore.AddAddrs(known, mockMultiaddrs(t, "/ip4/10.0.0.1/tcp/8680"), DiscoveredAddrTTL)
+	node.peerstore.AddAddrs(routed, mockMultiaddrs(t, "/ip4/10.0.0.2/tcp/8680", "/ip4/10.0.0.2/tcp/8681"), DiscoveredAddrTTL)
+	node.peerstore.AddAddrs(connected, mockMultiaddrs(t, "/ip4/10.0.0.3/tcp/8680"), DiscoveredAddrTTL)
+	node.routeTable.Update(routed)
+	node.routeTable.Update(connected)
+	node.stream.Store(connected.Pretty(), NewStreamStore(connected.Pretty(), 1, &mockStream{}))
+
+	var buf bytes.Buffer
+	assert.Nil(t, ns.ExportPeers(&buf))
+	exported := ns.Peers()
+	assert.Len(t, exported, 3)
+	assert.Equal(t, connected.Pretty(), exported[0].ID)
+	assert.Equal(t, PeerScoreConnected, exported[0].Score)
+
+	fresh := mockPeerNetService(t, 100)
+	count, err := fresh.ImportPeers(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	imported := fresh.Peers()
+	assert.Len(t, imported, 3)
+	for i, record := range exported {
+		assert.Equal(t, record.ID, imported[i].ID)
+		assert.ElementsMatch(t, record.Addrs, imported[i].Addrs)
+		assert.Equal(t, record.Score, imported[i].Score)
+	}
+	// the peer only known before is not routed.
+	assert.ElementsMatch(t, []string{routed.Pretty(), connected.Pretty()}, func() []string {
+		var ids []string
+		for _, v := range fresh.node.routeTable.ListPeers() {
+			ids = append(ids, v.Pretty())
+		}
+		return ids
+	}())
+}
+
+func TestPeerBook_ImportedScoreDecay(t *testing.T) {
+	ns := mockPeerNetService(t, 100)
+
+	pid := mockPeerID(t, "QmWxEZdpvRQP5xX8bN8jDpXT8wM9AWZ5Lxy1M1ZgVXz8Mo")
+	count, err := ns.ImportPeers(strings.NewReader(`{"chain_id": 100, "peers": [{"id": "` + pid.Pretty() + `", "addrs": ["/ip4/10.0.0.1/tcp/8680"], "score": 2}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, PeerScoreConnected, ns.Peers()[0].Score)
+
+	// the imported score drops by one each decay interval, but it's still routed.
+	ns.node.peerstore.Put(pid, peerScoreKey, &importedScore{score: PeerScoreConnected, at: time.Now().Add(-ImportedPeerScoreDecay)})
+	assert.Equal(t, PeerScoreRouted, ns.Peers()[0].Score)
+
+	ns.node.peerstore.Put(pid, peerScoreKey, &importedScore{score: PeerScoreConnected, at: time.Now().Add(-10 * ImportedPeerScoreDecay)})
+	assert.Equal(t, PeerScoreRouted, ns.Peers()[0].Score)
+
+	ns.node.routeTable.Remove(pid)
+	assert.Equal(t, PeerScoreKnown, ns.Peers()[0].Score)
+}
+
+func TestPeerBook_ImportInvalid(t *testing.T) {
+	ns := mockPeerNetService(t, 100)
+
+	_, err := ns.ImportPeers(strings.NewReader(`{"chain_id": 100, "peers": [`))
+	assert.NotNil(t, err)
+
+	_, err = ns.ImportPeers(strings.NewReader(`{"chain_id": 1, "peers": [{"id": "QmP7HDFcYmJL12Ez4ZNVCKjKedfE7f48f1LAkUc3Whz4jP", "addrs": ["/ip4/10.0.0.1/tcp/8680"], "score": 1}]}`))
+	assert.Equal(t, ErrInvalidPeerBook, err)
+	assert.Empty(t, ns.Peers())
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"errors"
+	"strings"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// ServiceFlag is the bitfield of services a node provides, advertised in HELLO.
+type ServiceFlag uint64
+
+// services
+const (
+	// ServiceFullBlocks the node stores and serves full blocks.
+	ServiceFullBlocks ServiceFlag = 1 << iota
+	// ServiceLightServer the node serves headers and proofs to light clients.
+	ServiceLightServer
+	// ServiceArchive the node keeps the whole history of states.
+	ServiceArchive
+	// ServiceRelay the node relays blocks and transactions.
+	ServiceRelay
+	// ServiceTxIndexer the node indexes transactions by address.
+	ServiceTxIndexer
+)
+
+// DefaultServices the services of a node if not configured.
+// Peers who don't advertise services are also considered to provide them.
+const DefaultServices = ServiceFullBlocks | ServiceRelay
+
+// errors
+var (
+	ErrUnknownService = errors.New("unknown service")
+)
+
+var serviceNames = []struct {
+	flag ServiceFlag
+	name string
+}{
+	{ServiceFullBlocks, "full"},
+	{ServiceLightServer, "light_server"},
+	{ServiceArchive, "archive"},
+	{ServiceRelay, "relay"},
+	{ServiceTxIndexer, "tx_indexer"},
+}
+
+// ParseServices parses the service names in config.
+func ParseServices(names []string) (ServiceFlag, error) {
+	var services ServiceFlag
+	for _, name := range names {
+		found := false
+		for _, v := range serviceNames {
+			if v.name == name {
+				services |= v.flag
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, ErrUnknownService
+		}
+	}
+	return services, nil
+}
+
+// Has returns if all the services are provided.
+func (s ServiceFlag) Has(services ServiceFlag) bool {
+	return s&services == services
+}
+
+func (s ServiceFlag) String() string {
+	var names []string
+	for _, v := range serviceNames {
+		if s.Has(v.flag) {
+			names = append(names, v.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// setPeerServices records the services a peer advertised in handshake.
+func (node *Node) setPeerServices(pid peer.ID, services ServiceFlag) {
+	if services == 0 {
+		services = DefaultServices
+	}
+	node.services.Store(pid, services)
+}
+
+// Services returns the services of the local node.
+func (ns *NetService) Services() ServiceFlag {
+	return ns.node.config.Services
+}
+
+// PeerServices returns the services a connected peer advertised.
+func (ns *NetService) PeerServices(pid peer.ID) (ServiceFlag, bool) {
+	services, ok := ns.node.services.Load(pid)
+	if !ok {
+		return 0, false
+	}
+	return services.(ServiceFlag), true
+}
+
+// PeersWithServices returns the peers in route table who provide all the services.
+func (ns *NetService) PeersWithServices(services ServiceFlag) []peer.ID {
+	var peers []peer.ID
+	for _, pid := range ns.node.routeTable.ListPeers() {
+		if s, ok := ns.PeerServices(pid); ok && s.Has(services) {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServices(t *testing.T) {
+	services, err := ParseServices([]string{"full", "archive", "tx_indexer"})
+	assert.Nil(t, err)
+	assert.Equal(t, ServiceFullBlocks|ServiceArchive|ServiceTxIndexer, services)
+	assert.Equal(t, "full|archive|tx_indexer", services.String())
+
+	services, err = ParseServices(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, ServiceFlag(0), services)
+
+	_, err = ParseServices([]string{"full", "miner"})
+	assert.Equal(t, ErrUnknownService, err)
+}
+
+func TestServiceFlag_Has(t *testing.T) {
+	services := ServiceFullBlocks | ServiceLightServer
+
+	assert.True(t, services.Has(ServiceFullBlocks))
+	assert.True(t, services.Has(ServiceFullBlocks|ServiceLightServer))
+	assert.False(t, services.Has(ServiceFullBlocks|ServiceArchive))
+	assert.True(t, services.Has(0))
+}
@@ -57,12 +57,14 @@ func (ns *NetService) Sync(tail net.Serializable) error {
 		return ErrNodeNotEnough
 	}
 
+	// only peers serving full blocks can reply the sync.
+	fullNodes := ns.PeersWithServices(ServiceFullBlocks)
 	count := 0
-	for i := 0; i < len(allNode); i++ {
-		nodeID := allNode[i]
+	for i := 0; i < len(fullNodes); i++ {
+		nodeID := fullNodes[i]
 		addrs := node.peerstore.PeerInfo(nodeID).Addrs
 		if len(addrs) > 0 {
-			if node.host.Addrs()[0] == addrs[0] {
+			if nodeID == node.id {
 				logging.VLog().Warn("Sync: skip self")
 				continue
 			}
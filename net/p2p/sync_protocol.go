@@ -0,0 +1,120 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/pb"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// caps of a sync request, the replies are paged if cut by them.
+const (
+	MaxBlockHashesPerRequest  = 1024
+	MaxBlockBodiesPerRequest  = 128
+	MaxBlockBodiesSize        = 2 * 1024 * 1024
+	MaxTrieNodesPerRequest    = 384
+	MaxTrieNodesSize          = 2 * 1024 * 1024
+	MaxLightHeadersPerRequest = 192
+	MaxProofNodes             = 64
+)
+
+var (
+	syncMsgRejected = metrics.GetOrRegisterMeter("neb.net.sync.rejected", nil)
+)
+
+// verifySyncMsg checks a sync protocol message is well-formed and within the caps before it is dispatched.
+// Other messages are always accepted.
+func verifySyncMsg(msgName string, data []byte) bool {
+	switch msgName {
+	case net.MessageTypeGetBlockHashes:
+		req := new(netpb.GetBlockHashes)
+		if err := proto.Unmarshal(data, req); err != nil {
+			return false
+		}
+		return req.From > 0 && req.Count > 0 && req.Count <= MaxBlockHashesPerRequest
+	case net.MessageTypeGetBlocksByRange:
+		req := new(netpb.GetBlocksByRange)
+		if err := proto.Unmarshal(data, req); err != nil {
+			return false
+		}
+		return req.From > 0 && req.Count > 0 && req.Count <= MaxBlockBodiesPerRequest
+	case net.MessageTypeBlockHashes:
+		reply := new(netpb.BlockHashes)
+		if err := proto.Unmarshal(data, reply); err != nil {
+			return false
+		}
+		return len(reply.Hashes) <= MaxBlockHashesPerRequest
+	case net.MessageTypeBlockBodies:
+		reply := new(netpb.BlockBodies)
+		if err := proto.Unmarshal(data, reply); err != nil {
+			return false
+		}
+		// a single block may exceed the size cap.
+		return len(reply.Blocks) <= MaxBlockBodiesPerRequest &&
+			(len(reply.Blocks) <= 1 || totalSize(reply.Blocks) <= MaxBlockBodiesSize)
+	case net.MessageTypeGetTrieNodes:
+		req := new(netpb.GetTrieNodes)
+		if err := proto.Unmarshal(data, req); err != nil {
+			return false
+		}
+		return len(req.Hashes) > 0 && len(req.Hashes) <= MaxTrieNodesPerRequest
+	case net.MessageTypeTrieNodes:
+		reply := new(netpb.TrieNodes)
+		if err := proto.Unmarshal(data, reply); err != nil {
+			return false
+		}
+		return len(reply.Nodes) <= MaxTrieNodesPerRequest && totalSize(reply.Nodes) <= MaxTrieNodesSize
+	case net.MessageTypeGetLightHeaders:
+		req := new(netpb.GetLightHeaders)
+		if err := proto.Unmarshal(data, req); err != nil {
+			return false
+		}
+		return req.Count > 0 && req.Count <= MaxLightHeadersPerRequest
+	case net.MessageTypeLightHeaders:
+		reply := new(netpb.LightHeaders)
+		if err := proto.Unmarshal(data, reply); err != nil {
+			return false
+		}
+		return len(reply.Headers) <= MaxLightHeadersPerRequest
+	case net.MessageTypeGetProof:
+		req := new(netpb.GetProof)
+		if err := proto.Unmarshal(data, req); err != nil {
+			return false
+		}
+		return len(req.BlockHash) > 0 && len(req.Key) > 0
+	case net.MessageTypeProof:
+		reply := new(netpb.Proof)
+		if err := proto.Unmarshal(data, reply); err != nil {
+			return false
+		}
+		return len(reply.Nodes) <= MaxProofNodes
+	}
+	return true
+}
+
+// totalSize returns the size of the items without the framing of the message, as the replies are capped.
+func totalSize(items [][]byte) int {
+	size := 0
+	for _, v := range items {
+		size += len(v)
+	}
+	return size
+}
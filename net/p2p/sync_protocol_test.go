@@ -0,0 +1,66 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySyncMsg(t *testing.T) {
+	marshal := func(msg proto.Message) []byte {
+		data, err := proto.Marshal(msg)
+		assert.Nil(t, err)
+		return data
+	}
+	hashes := make([][]byte, MaxBlockHashesPerRequest+1)
+	bodies := make([][]byte, MaxBlockBodiesPerRequest+1)
+
+	tests := []struct {
+		name    string
+		msgName string
+		data    []byte
+		valid   bool
+	}{
+		{"hashes request", net.MessageTypeGetBlockHashes, marshal(&netpb.GetBlockHashes{From: 1, Count: MaxBlockHashesPerRequest}), true},
+		{"hashes request over cap", net.MessageTypeGetBlockHashes, marshal(&netpb.GetBlockHashes{From: 1, Count: MaxBlockHashesPerRequest + 1}), false},
+		{"hashes request of nothing", net.MessageTypeGetBlockHashes, marshal(&netpb.GetBlockHashes{From: 1}), false},
+		{"bodies request", net.MessageTypeGetBlocksByRange, marshal(&netpb.GetBlocksByRange{From: 1, Count: MaxBlockBodiesPerRequest}), true},
+		{"bodies request over cap", net.MessageTypeGetBlocksByRange, marshal(&netpb.GetBlocksByRange{From: 1, Count: MaxBlockBodiesPerRequest + 1}), false},
+		{"bodies request from 0", net.MessageTypeGetBlocksByRange, marshal(&netpb.GetBlocksByRange{Count: 1}), false},
+		{"hashes", net.MessageTypeBlockHashes, marshal(&netpb.BlockHashes{Hashes: hashes[1:]}), true},
+		{"hashes over cap", net.MessageTypeBlockHashes, marshal(&netpb.BlockHashes{Hashes: hashes}), false},
+		{"bodies", net.MessageTypeBlockBodies, marshal(&netpb.BlockBodies{Blocks: bodies[1:]}), true},
+		{"bodies over cap", net.MessageTypeBlockBodies, marshal(&netpb.BlockBodies{Blocks: bodies}), false},
+		{"huge body", net.MessageTypeBlockBodies, marshal(&netpb.BlockBodies{Blocks: [][]byte{make([]byte, MaxBlockBodiesSize+1)}}), true},
+		{"huge bodies", net.MessageTypeBlockBodies, marshal(&netpb.BlockBodies{Blocks: [][]byte{make([]byte, MaxBlockBodiesSize), {1}}}), false},
+		{"bodies of the size cap", net.MessageTypeBlockBodies, marshal(&netpb.BlockBodies{Id: 1, From: 1, Blocks: [][]byte{make([]byte, MaxBlockBodiesSize/2), make([]byte, MaxBlockBodiesSize/2)}}), true},
+		{"malformed", net.MessageTypeBlockBodies, []byte{0xff}, false},
+		{"other", net.MessageTypeNewBlock, []byte{0xff}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, verifySyncMsg(tt.msgName, tt.data))
+		})
+	}
+}
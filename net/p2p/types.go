@@ -18,7 +18,12 @@
 
 package p2p
 
-import "github.com/nebulasio/go-nebulas/net"
+import (
+	"io"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/net"
+)
 
 // Manager manager interface
 // TODO(leon): this interface should be in net package.
@@ -33,6 +38,7 @@ type Manager interface {
 
 	Register(...*net.Subscriber)
 	Deregister(...*net.Subscriber)
+	RegisterValidator(string, net.Validator)
 
 	Broadcast(string, net.Serializable)
 	Relay(string, net.Serializable)
@@ -41,4 +47,15 @@ type Manager interface {
 	BroadcastNetworkID([]byte)
 
 	BuildData([]byte, string) []byte
+
+	ExportPeers(io.Writer) error
+	ImportPeers(io.Reader) (int, error)
+
+	Peers() []*PeerRecord
+	BanPeer(string, time.Duration) error
+	UnbanPeer(string) error
+
+	AddBootNode(string) error
+	RemoveBootNode(string) error
+	BootNodes() []BootNodeStatus
 }
@@ -0,0 +1,215 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// validation settings
+var (
+	// DefaultValidationWorkers is the count of goroutines validating messages in parallel.
+	DefaultValidationWorkers = 4
+	// MaxPeerPenalty is the count of invalid messages in PeerPenaltyWindow before a peer is banned.
+	MaxPeerPenalty = 10
+	// PeerPenaltyWindow is the time after which the penalty of a peer is forgotten.
+	PeerPenaltyWindow = 10 * time.Minute
+	// PeerBanDuration is the time a banned peer can't connect.
+	PeerBanDuration = 30 * time.Minute
+)
+
+// ErrPeerBanned the peer is banned for sending invalid messages.
+var ErrPeerBanned = errors.New("peer is banned")
+
+var (
+	validationRejected = metrics.GetOrRegisterMeter("neb.net.validation.rejected", nil)
+	peerBanned         = metrics.GetOrRegisterMeter("neb.net.peer.banned", nil)
+)
+
+type validationJob struct {
+	msg net.Message
+	pid peer.ID
+}
+
+type penalty struct {
+	score int
+	since time.Time
+}
+
+// validation checks the messages with the validators of their types in parallel workers before they are
+// dispatched, so that garbage never reaches the subscribers. Peers sending too many invalid messages are banned.
+type validation struct {
+	validators *sync.Map
+	jobCh      chan *validationJob
+	quitCh     chan bool
+	workers    int
+	dispatch   func(net.Message)
+	disconnect func(peer.ID)
+	now        func() time.Time
+
+	mu        sync.Mutex
+	penalties map[peer.ID]*penalty
+	banned    map[peer.ID]time.Time
+}
+
+func newValidation(workers int, dispatch func(net.Message), disconnect func(peer.ID)) *validation {
+	return &validation{
+		validators: new(sync.Map),
+		jobCh:      make(chan *validationJob, 1024),
+		quitCh:     make(chan bool),
+		workers:    workers,
+		dispatch:   dispatch,
+		disconnect: disconnect,
+		now:        time.Now,
+		penalties:  make(map[peer.ID]*penalty),
+		banned:     make(map[peer.ID]time.Time),
+	}
+}
+
+// Register sets the validator of a message type.
+func (v *validation) Register(msgType string, validator net.Validator) {
+	v.validators.Store(msgType, validator)
+}
+
+// Start starts the workers.
+func (v *validation) Start() {
+	for i := 0; i < v.workers; i++ {
+		go v.loop()
+	}
+}
+
+// Stop stops the workers.
+func (v *validation) Stop() {
+	close(v.quitCh)
+}
+
+func (v *validation) loop() {
+	for {
+		select {
+		case <-v.quitCh:
+			return
+		case job := <-v.jobCh:
+			v.validate(job)
+		}
+	}
+}
+
+// Submit passes the message to the workers if its type has a validator, otherwise dispatches it directly.
+// It blocks while the workers are busy, which slows down the reading of the peer.
+// The consensus messages are validated in place, never queued behind the gossip.
+func (v *validation) Submit(msg net.Message, pid peer.ID) {
+	if _, ok := v.validators.Load(msg.MessageType()); !ok {
+		v.dispatch(msg)
+		return
+	}
+	if net.IsConsensusMessage(msg.MessageType()) {
+		v.validate(&validationJob{msg, pid})
+		return
+	}
+	select {
+	case v.jobCh <- &validationJob{msg, pid}:
+	case <-v.quitCh:
+	}
+}
+
+func (v *validation) validate(job *validationJob) {
+	validator, _ := v.validators.Load(job.msg.MessageType())
+	if err := validator.(net.Validator)(job.msg); err != nil {
+		validationRejected.Mark(1)
+		logging.VLog().WithFields(logrus.Fields{
+			"msgName": job.msg.MessageType(),
+			"pid":     job.pid.Pretty(),
+			"err":     err,
+		}).Warn("Rejected the invalid message.")
+		net.TraceOf(job.msg).Finish("invalid")
+		v.Penalize(job.pid)
+		return
+	}
+	v.dispatch(job.msg)
+}
+
+// Penalize records an invalid message from the peer, the peer is banned and disconnected
+// if it sends too many of them.
+func (v *validation) Penalize(pid peer.ID) {
+	v.mu.Lock()
+	now := v.now()
+	p, ok := v.penalties[pid]
+	if !ok || now.Sub(p.since) > PeerPenaltyWindow {
+		p = &penalty{since: now}
+		v.penalties[pid] = p
+	}
+	p.score++
+	if p.score < MaxPeerPenalty {
+		v.mu.Unlock()
+		return
+	}
+	delete(v.penalties, pid)
+	v.banned[pid] = now.Add(PeerBanDuration)
+	v.mu.Unlock()
+
+	peerBanned.Mark(1)
+	logging.VLog().WithFields(logrus.Fields{
+		"pid":   pid.Pretty(),
+		"until": now.Add(PeerBanDuration),
+	}).Warn("Banned the peer sending invalid messages.")
+	v.disconnect(pid)
+}
+
+// Ban bans the peer for the duration and disconnects it.
+func (v *validation) Ban(pid peer.ID, d time.Duration) {
+	v.mu.Lock()
+	delete(v.penalties, pid)
+	v.banned[pid] = v.now().Add(d)
+	v.mu.Unlock()
+
+	peerBanned.Mark(1)
+	v.disconnect(pid)
+}
+
+// Unban lifts the ban of the peer, it returns false if the peer isn't banned.
+func (v *validation) Unban(pid peer.ID) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	_, ok := v.banned[pid]
+	delete(v.banned, pid)
+	return ok
+}
+
+// Banned returns if the peer is banned now.
+func (v *validation) Banned(pid peer.ID) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	until, ok := v.banned[pid]
+	if !ok {
+		return false
+	}
+	if v.now().After(until) {
+		delete(v.banned, pid)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package p2p
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_Submit(t *testing.T) {
+	dispatched := make(chan net.Message, 10)
+	disconnected := make(chan peer.ID, 10)
+	v := newValidation(2, func(msg net.Message) { dispatched <- msg }, func(pid peer.ID) { disconnected <- pid })
+	v.Register("newtx", func(msg net.Message) error {
+		if string(msg.Data().([]byte)) != "valid" {
+			return errors.New("invalid")
+		}
+		return nil
+	})
+	v.Start()
+	defer v.Stop()
+
+	pid := peer.ID("peer")
+	// messages without validators are dispatched directly.
+	v.Submit(messages.NewBaseMessage("newblock", pid.Pretty(), []byte("garbage")), pid)
+	assert.Equal(t, "newblock", (<-dispatched).MessageType())
+
+	v.Submit(messages.NewBaseMessage("newtx", pid.Pretty(), []byte("valid")), pid)
+	assert.Equal(t, "newtx", (<-dispatched).MessageType())
+
+	for i := 0; i < MaxPeerPenalty; i++ {
+		v.Submit(messages.NewBaseMessage("newtx", pid.Pretty(), []byte("garbage")), pid)
+	}
+	assert.Equal(t, pid, <-disconnected)
+	assert.True(t, v.Banned(pid))
+	assert.Equal(t, 0, len(dispatched))
+}
+
+func TestValidation_Penalize(t *testing.T) {
+	now := time.Now()
+	v := newValidation(1, func(net.Message) {}, func(peer.ID) {})
+	v.now = func() time.Time { return now }
+
+	pid := peer.ID("peer")
+	for i := 0; i < MaxPeerPenalty-1; i++ {
+		v.Penalize(pid)
+	}
+	assert.False(t, v.Banned(pid))
+
+	// the penalty is forgotten after the window.
+	now = now.Add(PeerPenaltyWindow + time.Second)
+	v.Penalize(pid)
+	assert.False(t, v.Banned(pid))
+	for i := 0; i < MaxPeerPenalty-1; i++ {
+		v.Penalize(pid)
+	}
+	assert.True(t, v.Banned(pid))
+	assert.False(t, v.Banned(peer.ID("other")))
+
+	now = now.Add(PeerBanDuration + time.Second)
+	assert.False(t, v.Banned(pid))
+}
+
+func TestValidation_Ban(t *testing.T) {
+	now := time.Now()
+	disconnected := make(chan peer.ID, 1)
+	v := newValidation(1, func(net.Message) {}, func(pid peer.ID) { disconnected <- pid })
+	v.now = func() time.Time { return now }
+
+	pid := peer.ID("peer")
+	v.Ban(pid, time.Minute)
+	assert.Equal(t, pid, <-disconnected)
+	assert.True(t, v.Banned(pid))
+	assert.True(t, v.Unban(pid))
+	assert.False(t, v.Banned(pid))
+	assert.False(t, v.Unban(pid))
+
+	v.Ban(pid, time.Minute)
+	<-disconnected
+	now = now.Add(time.Minute + time.Second)
+	assert.False(t, v.Banned(pid))
+}
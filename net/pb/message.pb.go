@@ -11,6 +11,16 @@ It has these top-level messages:
 	Hello
 	Peers
 	PeerInfo
+	GetBlockHashes
+	BlockHashes
+	GetBlocksByRange
+	BlockBodies
+	GetTrieNodes
+	TrieNodes
+	GetLightHeaders
+	LightHeaders
+	GetProof
+	Proof
 */
 package netpb
 
@@ -33,6 +43,12 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type Hello struct {
 	NodeId        string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	ClientVersion string `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	// bitfield of the services the node provides.
+	Services uint64 `protobuf:"varint,3,opt,name=services,proto3" json:"services,omitempty"`
+	// digest of the genesis block, peers of different genesis are rejected.
+	Genesis []byte `protobuf:"bytes,4,opt,name=genesis,proto3" json:"genesis,omitempty"`
+	// hash of the latest irreversible block of the node.
+	Lib []byte `protobuf:"bytes,5,opt,name=lib,proto3" json:"lib,omitempty"`
 }
 
 func (m *Hello) Reset()                    { *m = Hello{} }
@@ -54,6 +70,27 @@ func (m *Hello) GetClientVersion() string {
 	return ""
 }
 
+func (m *Hello) GetServices() uint64 {
+	if m != nil {
+		return m.Services
+	}
+	return 0
+}
+
+func (m *Hello) GetGenesis() []byte {
+	if m != nil {
+		return m.Genesis
+	}
+	return nil
+}
+
+func (m *Hello) GetLib() []byte {
+	if m != nil {
+		return m.Lib
+	}
+	return nil
+}
+
 type Peers struct {
 	Peers []*PeerInfo `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
 }
@@ -94,26 +131,433 @@ func (m *PeerInfo) GetAddrs() []string {
 	return nil
 }
 
+// sync protocol, requests are paged by from and count, see the caps in p2p.
+// more in replies is true if the peer has blocks after the last one replied.
+type GetBlockHashes struct {
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From  uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *GetBlockHashes) Reset()                    { *m = GetBlockHashes{} }
+func (m *GetBlockHashes) String() string            { return proto.CompactTextString(m) }
+func (*GetBlockHashes) ProtoMessage()               {}
+func (*GetBlockHashes) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{3} }
+
+func (m *GetBlockHashes) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetBlockHashes) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GetBlockHashes) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type BlockHashes struct {
+	Id     uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From   uint64   `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	Hashes [][]byte `protobuf:"bytes,3,rep,name=hashes" json:"hashes,omitempty"`
+	More   bool     `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"`
+	// height of the tail block of the replier.
+	Tail uint64 `protobuf:"varint,5,opt,name=tail,proto3" json:"tail,omitempty"`
+}
+
+func (m *BlockHashes) Reset()                    { *m = BlockHashes{} }
+func (m *BlockHashes) String() string            { return proto.CompactTextString(m) }
+func (*BlockHashes) ProtoMessage()               {}
+func (*BlockHashes) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{4} }
+
+func (m *BlockHashes) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *BlockHashes) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *BlockHashes) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *BlockHashes) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+func (m *BlockHashes) GetTail() uint64 {
+	if m != nil {
+		return m.Tail
+	}
+	return 0
+}
+
+type GetBlocksByRange struct {
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From  uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *GetBlocksByRange) Reset()                    { *m = GetBlocksByRange{} }
+func (m *GetBlocksByRange) String() string            { return proto.CompactTextString(m) }
+func (*GetBlocksByRange) ProtoMessage()               {}
+func (*GetBlocksByRange) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{5} }
+
+func (m *GetBlocksByRange) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetBlocksByRange) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GetBlocksByRange) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type BlockBodies struct {
+	Id   uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// marshaled corepb.Block
+	Blocks [][]byte `protobuf:"bytes,3,rep,name=blocks" json:"blocks,omitempty"`
+	More   bool     `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"`
+}
+
+func (m *BlockBodies) Reset()                    { *m = BlockBodies{} }
+func (m *BlockBodies) String() string            { return proto.CompactTextString(m) }
+func (*BlockBodies) ProtoMessage()               {}
+func (*BlockBodies) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{6} }
+
+func (m *BlockBodies) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *BlockBodies) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *BlockBodies) GetBlocks() [][]byte {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *BlockBodies) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+type GetTrieNodes struct {
+	Id     uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hashes [][]byte `protobuf:"bytes,2,rep,name=hashes" json:"hashes,omitempty"`
+}
+
+func (m *GetTrieNodes) Reset()                    { *m = GetTrieNodes{} }
+func (m *GetTrieNodes) String() string            { return proto.CompactTextString(m) }
+func (*GetTrieNodes) ProtoMessage()               {}
+func (*GetTrieNodes) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{7} }
+
+func (m *GetTrieNodes) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetTrieNodes) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type TrieNodes struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the nodes found, each is verified by its hash.
+	Nodes [][]byte `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *TrieNodes) Reset()                    { *m = TrieNodes{} }
+func (m *TrieNodes) String() string            { return proto.CompactTextString(m) }
+func (*TrieNodes) ProtoMessage()               {}
+func (*TrieNodes) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{8} }
+
+func (m *TrieNodes) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *TrieNodes) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+// light client messages, headers are served with their transaction hashes to be verified.
+type GetLightHeaders struct {
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From  uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *GetLightHeaders) Reset()                    { *m = GetLightHeaders{} }
+func (m *GetLightHeaders) String() string            { return proto.CompactTextString(m) }
+func (*GetLightHeaders) ProtoMessage()               {}
+func (*GetLightHeaders) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{9} }
+
+func (m *GetLightHeaders) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetLightHeaders) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GetLightHeaders) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type LightHeaders struct {
+	Id   uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// marshaled corepb.LightHeader
+	Headers [][]byte `protobuf:"bytes,3,rep,name=headers" json:"headers,omitempty"`
+	More    bool     `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"`
+}
+
+func (m *LightHeaders) Reset()                    { *m = LightHeaders{} }
+func (m *LightHeaders) String() string            { return proto.CompactTextString(m) }
+func (*LightHeaders) ProtoMessage()               {}
+func (*LightHeaders) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{10} }
+
+func (m *LightHeaders) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *LightHeaders) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *LightHeaders) GetHeaders() [][]byte {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *LightHeaders) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+// key of account is the address, of transaction is the hash,
+// and of event is the transaction hash followed by the index of event.
+type GetProof struct {
+	Id        uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlockHash []byte `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Kind      string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Key       []byte `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *GetProof) Reset()                    { *m = GetProof{} }
+func (m *GetProof) String() string            { return proto.CompactTextString(m) }
+func (*GetProof) ProtoMessage()               {}
+func (*GetProof) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{11} }
+
+func (m *GetProof) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetProof) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *GetProof) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *GetProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type Proof struct {
+	Id        uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlockHash []byte `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Kind      string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Key       []byte `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	// empty if the key is not found.
+	Value []byte `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// marshaled triepb.Node from root to leaf.
+	Nodes [][]byte `protobuf:"bytes,6,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *Proof) Reset()                    { *m = Proof{} }
+func (m *Proof) String() string            { return proto.CompactTextString(m) }
+func (*Proof) ProtoMessage()               {}
+func (*Proof) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{12} }
+
+func (m *Proof) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Proof) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Proof) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Proof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *Proof) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Proof) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Hello)(nil), "netpb.Hello")
 	proto.RegisterType((*Peers)(nil), "netpb.Peers")
 	proto.RegisterType((*PeerInfo)(nil), "netpb.PeerInfo")
+	proto.RegisterType((*GetBlockHashes)(nil), "netpb.GetBlockHashes")
+	proto.RegisterType((*BlockHashes)(nil), "netpb.BlockHashes")
+	proto.RegisterType((*GetBlocksByRange)(nil), "netpb.GetBlocksByRange")
+	proto.RegisterType((*BlockBodies)(nil), "netpb.BlockBodies")
+	proto.RegisterType((*GetTrieNodes)(nil), "netpb.GetTrieNodes")
+	proto.RegisterType((*TrieNodes)(nil), "netpb.TrieNodes")
+	proto.RegisterType((*GetLightHeaders)(nil), "netpb.GetLightHeaders")
+	proto.RegisterType((*LightHeaders)(nil), "netpb.LightHeaders")
+	proto.RegisterType((*GetProof)(nil), "netpb.GetProof")
+	proto.RegisterType((*Proof)(nil), "netpb.Proof")
 }
 
 func init() { proto.RegisterFile("message.proto", fileDescriptorMessage) }
 
 var fileDescriptorMessage = []byte{
-	// 177 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0x8e, 0x41, 0xeb, 0x82, 0x40,
-	0x10, 0x47, 0x71, 0x65, 0xfd, 0xff, 0x9d, 0xd0, 0x60, 0x09, 0xda, 0xa3, 0x08, 0x82, 0xa7, 0x25,
-	0xea, 0x43, 0x94, 0xb7, 0xf0, 0xd0, 0x55, 0xb4, 0x9d, 0x62, 0xc1, 0x76, 0x64, 0x57, 0xfa, 0xfc,
-	0xa1, 0x4b, 0xdd, 0xe6, 0xf7, 0xde, 0xe1, 0x0d, 0x64, 0x2f, 0xf4, 0xbe, 0x7f, 0xa2, 0x9a, 0x1c,
-	0xcd, 0x24, 0xb8, 0xc5, 0x79, 0x1a, 0xca, 0x33, 0xf0, 0x0b, 0x8e, 0x23, 0x89, 0x3d, 0xfc, 0x59,
-	0xd2, 0xd8, 0x19, 0x2d, 0xa3, 0x22, 0xaa, 0xd3, 0x36, 0x59, 0x66, 0xa3, 0x45, 0x05, 0xf9, 0x7d,
-	0x34, 0x68, 0xe7, 0xee, 0x8d, 0xce, 0x1b, 0xb2, 0x92, 0xad, 0x3e, 0x0b, 0xf4, 0x16, 0x60, 0xa9,
-	0x80, 0x5f, 0x11, 0x9d, 0x17, 0x15, 0xf0, 0x69, 0x39, 0x64, 0x54, 0xc4, 0xf5, 0xe6, 0xb8, 0x55,
-	0x6b, 0x48, 0x2d, 0xb2, 0xb1, 0x0f, 0x6a, 0x83, 0x2d, 0x0f, 0xf0, 0xff, 0x45, 0x22, 0x07, 0xf6,
-	0xcb, 0x32, 0xa3, 0xc5, 0x0e, 0x78, 0xaf, 0xb5, 0xf3, 0x92, 0x15, 0x71, 0x9d, 0xb6, 0x61, 0x0c,
-	0xc9, 0xfa, 0xf8, 0xe9, 0x13, 0x00, 0x00, 0xff, 0xff, 0x87, 0x8b, 0x73, 0xcc, 0xc9, 0x00, 0x00,
-	0x00,
+	// 471 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x5d, 0x6b, 0xdb, 0x30,
+	0x14, 0xc5, 0xb1, 0x9d, 0xc4, 0xb7, 0x6e, 0x5a, 0x44, 0xd9, 0xc4, 0x60, 0x60, 0x04, 0x05, 0x3f,
+	0x85, 0x7d, 0xc0, 0x7e, 0x40, 0x5e, 0x92, 0x6e, 0x65, 0x14, 0x31, 0xf6, 0x36, 0x32, 0x3b, 0xba,
+	0x49, 0x44, 0x1c, 0x2b, 0x93, 0xd4, 0x40, 0xff, 0xc1, 0xde, 0xf6, 0x97, 0x87, 0x64, 0xbb, 0x0d,
+	0x34, 0x83, 0x96, 0xd1, 0xb7, 0x73, 0xae, 0x74, 0x8f, 0xef, 0x39, 0x92, 0x0c, 0xa7, 0x5b, 0x34,
+	0xa6, 0x58, 0xe1, 0x78, 0xa7, 0x95, 0x55, 0x24, 0xae, 0xd1, 0xee, 0x4a, 0xf6, 0x27, 0x80, 0x78,
+	0x86, 0x55, 0xa5, 0xc8, 0x6b, 0x18, 0xd4, 0x4a, 0xe0, 0x5c, 0x0a, 0x1a, 0x64, 0x41, 0x9e, 0xf0,
+	0xbe, 0xa3, 0x57, 0x82, 0x5c, 0xc2, 0x68, 0x51, 0x49, 0xac, 0xed, 0x7c, 0x8f, 0xda, 0x48, 0x55,
+	0xd3, 0x9e, 0x5f, 0x3f, 0x6d, 0xaa, 0xdf, 0x9b, 0x22, 0x79, 0x03, 0x43, 0x83, 0x7a, 0x2f, 0x17,
+	0x68, 0x68, 0x98, 0x05, 0x79, 0xc4, 0xef, 0x39, 0xa1, 0x30, 0x58, 0x61, 0x8d, 0x46, 0x1a, 0x1a,
+	0x65, 0x41, 0x9e, 0xf2, 0x8e, 0x92, 0x73, 0x08, 0x2b, 0x59, 0xd2, 0xd8, 0x57, 0x1d, 0x64, 0x63,
+	0x88, 0x6f, 0x10, 0xb5, 0x21, 0x97, 0x10, 0xef, 0x1c, 0xa0, 0x41, 0x16, 0xe6, 0x27, 0x1f, 0xce,
+	0xc6, 0x7e, 0xe2, 0xb1, 0x5b, 0xbc, 0xaa, 0x97, 0x8a, 0x37, 0xab, 0xec, 0x1d, 0x0c, 0xbb, 0x12,
+	0x19, 0x41, 0xef, 0x7e, 0xfc, 0x9e, 0x14, 0xe4, 0x02, 0xe2, 0x42, 0x08, 0x6d, 0x68, 0x2f, 0x0b,
+	0xf3, 0x84, 0x37, 0x84, 0x7d, 0x86, 0xd1, 0x14, 0xed, 0xa4, 0x52, 0x8b, 0xcd, 0xac, 0x30, 0x6b,
+	0x34, 0x07, 0x7d, 0x91, 0xef, 0x23, 0x10, 0x2d, 0xb5, 0xda, 0x7a, 0xa3, 0x11, 0xf7, 0xd8, 0x69,
+	0x2d, 0xd4, 0x6d, 0x6d, 0x5b, 0x73, 0x0d, 0x61, 0xbf, 0xe0, 0xe4, 0xb9, 0x42, 0xaf, 0xa0, 0xbf,
+	0xf6, 0xbb, 0x69, 0x98, 0x85, 0x79, 0xca, 0x5b, 0xe6, 0xf6, 0x6e, 0x95, 0x46, 0x9f, 0xd0, 0x90,
+	0x7b, 0xec, 0x6a, 0xb6, 0x90, 0x95, 0xcf, 0x27, 0xe2, 0x1e, 0xb3, 0x6b, 0x38, 0xef, 0xc6, 0x37,
+	0x93, 0x3b, 0x5e, 0xd4, 0x2b, 0xfc, 0x0f, 0x03, 0x3f, 0x5a, 0x03, 0x13, 0x25, 0xe4, 0xd3, 0x0d,
+	0x94, 0xfe, 0xeb, 0x9d, 0x81, 0x86, 0x1d, 0x33, 0xc0, 0x3e, 0x41, 0x3a, 0x45, 0xfb, 0x4d, 0x4b,
+	0xfc, 0xaa, 0xc4, 0x11, 0xfd, 0x87, 0x30, 0x7a, 0x87, 0x61, 0xb0, 0xf7, 0x90, 0xfc, 0xbb, 0xe9,
+	0x02, 0x62, 0x77, 0x37, 0xbb, 0x9e, 0x86, 0xb0, 0x2f, 0x70, 0x36, 0x45, 0x7b, 0x2d, 0x57, 0x6b,
+	0x3b, 0xc3, 0x42, 0xa0, 0x7e, 0xdc, 0xf8, 0xf4, 0x58, 0x7e, 0x42, 0xfa, 0x6c, 0x25, 0x0a, 0x83,
+	0x75, 0xb3, 0xbd, 0x0d, 0xa6, 0xa3, 0x47, 0x93, 0x99, 0xc3, 0x70, 0x8a, 0xf6, 0x46, 0x2b, 0xb5,
+	0x7c, 0xa4, 0xfe, 0x16, 0xc0, 0x67, 0x3a, 0x77, 0x69, 0xf8, 0x6f, 0xa4, 0x3c, 0x29, 0xbb, 0x7b,
+	0xe6, 0xe4, 0x36, 0xb2, 0x16, 0x7e, 0xe2, 0x84, 0x7b, 0xec, 0x1e, 0xd2, 0x06, 0xef, 0xda, 0xe7,
+	0xe5, 0x20, 0xfb, 0x1d, 0x40, 0xfc, 0x72, 0xf2, 0x2e, 0xb7, 0x7d, 0x51, 0xdd, 0x62, 0xfb, 0x76,
+	0x1b, 0xf2, 0x70, 0x34, 0xfd, 0x83, 0xa3, 0x29, 0xfb, 0xfe, 0x9f, 0xf3, 0xf1, 0xef, 0x00, 0x2a,
+	0xce, 0xba, 0x8e, 0x84, 0x04, 0x00, 0x00,
 }
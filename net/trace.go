@@ -0,0 +1,138 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// trace stages shared by the network and dispatcher.
+const (
+	TraceStageReceived   = "received"
+	TraceStageDispatched = "dispatched"
+)
+
+// traceOtherType is the type in the metric names of the traces of unregistered message types, so the
+// peers can't grow the metrics registry by sending messages of arbitrary types.
+const traceOtherType = "other"
+
+// TraceStage is the time elapsed since a traced message was received when it reached a stage.
+type TraceStage struct {
+	Name    string
+	Elapsed time.Duration
+}
+
+// Trace stamps an inbound message with a correlation ID and records the latencies of the stages it passes,
+// from the network, through the dispatcher, to the handlers.
+// All methods of a nil Trace are no-op, so handlers can mark stages without checking if tracing is enabled.
+type Trace struct {
+	id         string
+	msgType    string
+	metricType string
+	start      time.Time
+
+	mu     sync.Mutex
+	stages []TraceStage
+}
+
+// NewTrace returns a new trace of a message received now.
+func NewTrace(msgType string) *Trace {
+	metricType := msgType
+	if _, ok := PacketsInByTypes.Load(msgType); !ok {
+		metricType = traceOtherType
+	}
+	t := &Trace{
+		id:         uuid.NewV4().String(),
+		msgType:    msgType,
+		metricType: metricType,
+		start:      time.Now(),
+	}
+	t.Mark(TraceStageReceived)
+	return t
+}
+
+// TraceOf returns the trace of a message, nil if the message is not traced.
+func TraceOf(msg Message) *Trace {
+	if traced, ok := msg.(interface {
+		Trace() *Trace
+	}); ok {
+		return traced.Trace()
+	}
+	return nil
+}
+
+// ID returns the correlation ID.
+func (t *Trace) ID() string {
+	if t == nil {
+		return ""
+	}
+	return t.id
+}
+
+// Mark records the message reached a stage.
+func (t *Trace) Mark(stage string) {
+	if t == nil {
+		return
+	}
+	elapsed := time.Since(t.start)
+
+	t.mu.Lock()
+	t.stages = append(t.stages, TraceStage{Name: stage, Elapsed: elapsed})
+	t.mu.Unlock()
+
+	metrics.GetOrRegisterTimer(fmt.Sprintf("neb.trace.%s.%s", t.metricType, stage), nil).Update(elapsed)
+}
+
+// Stages returns the stages the message passed in order.
+func (t *Trace) Stages() []TraceStage {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stages := make([]TraceStage, len(t.stages))
+	copy(stages, t.stages)
+	return stages
+}
+
+// Finish marks the last stage and logs the latencies of all stages.
+func (t *Trace) Finish(stage string) {
+	if t == nil {
+		return
+	}
+	t.Mark(stage)
+
+	stages := make(logrus.Fields)
+	for _, v := range t.Stages() {
+		stages[v.Name] = v.Elapsed.String()
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"trace":   t.id,
+		"msgType": t.msgType,
+		"stages":  stages,
+	}).Debug("Message trace finished.")
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"testing"
+
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTracedMessage struct {
+	trace *Trace
+}
+
+func (msg *mockTracedMessage) MessageType() string { return "mock" }
+func (msg *mockTracedMessage) Data() interface{}   { return nil }
+func (msg *mockTracedMessage) MessageFrom() string { return "" }
+func (msg *mockTracedMessage) Trace() *Trace       { return msg.trace }
+
+func TestTrace(t *testing.T) {
+	trace := NewTrace("mock")
+	assert.NotEmpty(t, trace.ID())
+	assert.NotEqual(t, trace.ID(), NewTrace("mock").ID())
+
+	msg := &mockTracedMessage{trace}
+	TraceOf(msg).Mark(TraceStageDispatched)
+	TraceOf(msg).Finish("handled")
+
+	stages := trace.Stages()
+	assert.Equal(t, 3, len(stages))
+	assert.Equal(t, TraceStageReceived, stages[0].Name)
+	assert.Equal(t, TraceStageDispatched, stages[1].Name)
+	assert.Equal(t, "handled", stages[2].Name)
+	assert.True(t, stages[1].Elapsed <= stages[2].Elapsed)
+}
+
+func TestTrace_Nil(t *testing.T) {
+	var trace *Trace
+	msg := &mockTracedMessage{}
+
+	assert.Nil(t, TraceOf(msg))
+	assert.Equal(t, "", trace.ID())
+	trace.Mark(TraceStageDispatched)
+	trace.Finish("handled")
+	assert.Nil(t, trace.Stages())
+}
+
+func TestTrace_MetricType(t *testing.T) {
+	PacketsInByTypes.LoadOrStore("registered", nil)
+	defer PacketsInByTypes.Delete("registered")
+
+	NewTrace("registered")
+	assert.NotNil(t, metrics.DefaultRegistry.Get("neb.trace.registered.received"))
+
+	// the types not registered are traced in one metric.
+	NewTrace("arbitrary")
+	assert.Nil(t, metrics.DefaultRegistry.Get("neb.trace.arbitrary.received"))
+	assert.NotNil(t, metrics.DefaultRegistry.Get("neb.trace.other.received"))
+}
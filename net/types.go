@@ -24,8 +24,33 @@ import "github.com/gogo/protobuf/proto"
 const (
 	MessageTypeSyncBlock = "syncblock"
 	MessageTypeSyncReply = "syncreply"
+	// MessageTypeNewBlock is the same as core.MessageTypeNewBlock, the NetService deduplicates it.
+	MessageTypeNewBlock = "newblock"
+	// MessageTypeCompactBlock is the same as core.MessageTypeCompactBlock, the NetService deduplicates it.
+	MessageTypeCompactBlock = "cmpctblock"
+
+	// sync protocol, see p2p.MaxBlockHashesPerRequest for the caps.
+	MessageTypeGetBlockHashes   = "getblkhashes"
+	MessageTypeBlockHashes      = "blkhashes"
+	MessageTypeGetBlocksByRange = "getblkrange"
+	MessageTypeBlockBodies      = "blkbodies"
+	MessageTypeGetTrieNodes     = "gettrienodes"
+	MessageTypeTrieNodes        = "trienodes"
+	MessageTypeGetLightHeaders  = "getlighthdrs"
+	MessageTypeLightHeaders     = "lighthdrs"
+	MessageTypeGetProof         = "getproof"
+	MessageTypeProof            = "proof"
+
+	// consensus messages of proposers, dispatched apart from the gossip, see IsConsensusMessage.
+	MessageTypeConsensusPrepare = "cnsprepare"
+	MessageTypeConsensusVote    = "cnsvote"
 )
 
+// IsConsensusMessage returns if the message type is of the consensus messages.
+func IsConsensusMessage(msgType string) bool {
+	return msgType == MessageTypeConsensusPrepare || msgType == MessageTypeConsensusVote
+}
+
 // MessageType a string for message type.
 type MessageType string
 
@@ -42,6 +67,10 @@ type Serializable interface {
 	FromProto(proto.Message) error
 }
 
+// Validator checks the cheap invariants of a message before it is dispatched,
+// the message failing the check is dropped and its sender is penalized.
+type Validator func(msg Message) error
+
 // Subscriber subscriber.
 type Subscriber struct {
 	// id usually the owner/creator, used for troubleshooting .
@@ -22,6 +22,8 @@ import "C"
 
 import (
 	"encoding/json"
+	"errors"
+	"math/big"
 	"unsafe"
 
 	"github.com/nebulasio/go-nebulas/util"
@@ -36,6 +38,7 @@ func GetTxByHashFunc(handler unsafe.Pointer, hash *C.char) *C.char {
 	if engine == nil || engine.ctx.block == nil {
 		return nil
 	}
+	engine.chargeInstructions(QueryInstructions)
 	tx, err := engine.ctx.SerializeTxByHash([]byte(C.GoString(hash)))
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
@@ -55,6 +58,7 @@ func GetAccountStateFunc(handler unsafe.Pointer, address *C.char) *C.char {
 	if engine == nil || engine.ctx.block == nil {
 		return nil
 	}
+	engine.chargeInstructions(QueryInstructions)
 	addr := C.GoString(address)
 	valid := engine.ctx.block.VerifyAddress(addr)
 	if !valid {
@@ -81,6 +85,7 @@ func TransferFunc(handler unsafe.Pointer, to *C.char, v *C.char) int {
 	if engine == nil || engine.ctx.block == nil {
 		return 1
 	}
+	engine.chargeInstructions(TransferInstructions)
 
 	addr := C.GoString(to)
 	valid := engine.ctx.block.VerifyAddress(addr)
@@ -112,6 +117,7 @@ func TransferFunc(handler unsafe.Pointer, to *C.char, v *C.char) int {
 	}
 
 	toAcc.AddBalance(amount)
+	engine.ctx.recordTransferUndo(engine.ctx.contract, toAcc, amount)
 	return 0
 }
 
@@ -128,3 +134,108 @@ func VerifyAddressFunc(handler unsafe.Pointer, address *C.char) int {
 	}
 	return 0
 }
+
+// CallContractFunc calls the function of contract at address with the value, returns the failure
+//export CallContractFunc
+func CallContractFunc(handler unsafe.Pointer, address, function, args, value *C.char) *C.char {
+	engine, _ := getEngineByStorageHandler(uint64(uintptr(handler)))
+	if engine == nil || engine.ctx.block == nil {
+		return C.CString(ErrCallUnavailable.Error())
+	}
+	engine.chargeInstructions(CallInstructions)
+
+	err := engine.callContract(C.GoString(address), C.GoString(function), C.GoString(args), C.GoString(value))
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"handler":  uint64(uintptr(handler)),
+			"address":  C.GoString(address),
+			"function": C.GoString(function),
+			"err":      err,
+		}).Debug("CallContractFunc call failed.")
+		return C.CString(err.Error())
+	}
+	return nil
+}
+
+// callContract runs the function of contract at address in a new engine with the gas left, the value
+// is transferred from the caller first. The changes of a failed call are reverted, then the caller
+// may catch the failure and go on, or fail itself.
+func (e *V8Engine) callContract(address, function, args, value string) error {
+	ctx := e.ctx
+	if ctx.depth >= MaxCallDepth {
+		return ErrCallDepthExceeded
+	}
+	v, ok := new(big.Int).SetString(value, 10)
+	if !ok || v.Sign() < 0 {
+		return ErrInvalidCallValue
+	}
+	amount := util.NewUint128FromBigInt(v)
+	contract, err := ctx.block.LoadContract(ctx.state, address)
+	if err != nil {
+		return err
+	}
+
+	var limits uint64
+	if e.enableLimits {
+		used := uint64(e.v8engine.stats.count_of_executed_instructions)
+		if used >= e.limitsOfExecutionInstructions {
+			return ErrInsufficientGas
+		}
+		limits = e.limitsOfExecutionInstructions - used
+	}
+
+	snapshot := ctx.journal.snapshot()
+	if err := ctx.contract.SubBalance(amount); err != nil {
+		return err
+	}
+	contract.Account.AddBalance(amount)
+	ctx.journal.record(func() {
+		contract.Account.SubBalance(amount)
+		ctx.contract.AddBalance(amount)
+	})
+
+	callCtx := ctx.newCallContext(contract, amount.String())
+	engine := NewV8Engine(callCtx)
+	defer engine.Dispose()
+	engine.SetExecutionLimits(limits, e.limitsOfTotalMemorySize)
+
+	err = engine.Call(contract.Source, contract.SourceType, function, args)
+	e.chargeInstructions(engine.ExecutionInstructions())
+	if err == nil {
+		err = callCtx.block.(*callBlock).flush()
+	}
+	if err != nil {
+		ctx.journal.revert(snapshot)
+		if msg := engine.Exception(); err == ErrExecutionFailed && msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	if ctx.depth == 0 {
+		ctx.journal.forget(snapshot)
+	}
+	return nil
+}
+
+// GetOracleDataFunc returns the record of the oracle data of key
+//export GetOracleDataFunc
+func GetOracleDataFunc(handler unsafe.Pointer, key *C.char) *C.char {
+	engine, _ := getEngineByStorageHandler(uint64(uintptr(handler)))
+	if engine == nil || engine.ctx.block == nil {
+		return nil
+	}
+	engine.chargeInstructions(QueryInstructions)
+
+	data, err := engine.ctx.block.OracleData(engine.ctx.state, C.GoString(key))
+	if err != nil {
+		if err != ErrKeyNotFound {
+			logging.VLog().WithFields(logrus.Fields{
+				"handler": uint64(uintptr(handler)),
+				"key":     C.GoString(key),
+				"err":     err,
+			}).Error("GetOracleDataFunc get oracle data failed.")
+		}
+		return nil
+	}
+	return C.CString(string(data))
+}
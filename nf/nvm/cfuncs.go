@@ -37,6 +37,8 @@ char *GetTxByHashFunc(void *handler, const char *hash);
 char *GetAccountStateFunc(void *handler, const char *address);
 int TransferFunc(void *handler, const char *to, const char *value);
 int VerifyAddressFunc(void *handler, const char *address);
+char *CallContractFunc(void *handler, const char *address, const char *function, const char *args, const char *value);
+char *GetOracleDataFunc(void *handler, const char *key);
 
 // event.
 void EventTriggerFunc(void *handler, const char *topic, const char *data);
@@ -72,6 +74,12 @@ int TransferFunc_cgo(void *handler, const char *to, const char *value) {
 int VerifyAddressFunc_cgo(void *handler, const char *address) {
 	return VerifyAddressFunc(handler, address);
 };
+char *CallContractFunc_cgo(void *handler, const char *address, const char *function, const char *args, const char *value) {
+	return CallContractFunc(handler, address, function, args, value);
+};
+char *GetOracleDataFunc_cgo(void *handler, const char *key) {
+	return GetOracleDataFunc(handler, key);
+};
 
 void EventTriggerFunc_cgo(void *handler, const char *topic, const char *data) {
 	EventTriggerFunc(handler, topic, data);
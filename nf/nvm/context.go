@@ -26,6 +26,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/nebulasio/go-nebulas/core/pb"
 	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/byteutils"
 )
@@ -33,6 +34,16 @@ import (
 const (
 	// DefaultLimitsOfTotalMemorySize default limits of total memory size
 	DefaultLimitsOfTotalMemorySize uint64 = 40 * 1000 * 1000
+
+	// MaxCallDepth is the max depth of the calls between contracts in a transaction.
+	MaxCallDepth = 8
+)
+
+// Errors of the calls between contracts
+var (
+	ErrCallDepthExceeded = errors.New("exceed the max depth of contract calls")
+	ErrInvalidCallValue  = errors.New("invalid value of contract call")
+	ErrCallUnavailable   = errors.New("contract call is unavailable without block")
 )
 
 // Block interface breaks cycle import dependency and hides unused services.
@@ -40,10 +51,24 @@ type Block interface {
 	CoinbaseHash() byteutils.Hash
 	Nonce() uint64
 	Hash() byteutils.Hash
+	ParentHash() byteutils.Hash
 	Height() uint64
+	Timestamp() int64
 	VerifyAddress(str string) bool
 	SerializeTxByHash(hash byteutils.Hash) (proto.Message, error)
 	RecordEvent(txHash byteutils.Hash, topic, data string) error
+	LoadContract(state state.AccountState, address string) (*Contract, error)
+	OracleData(state state.AccountState, key string) ([]byte, error)
+}
+
+// Contract is the code of a deployed contract, loaded for the calls from other contracts.
+type Contract struct {
+	// Address is in the format of transactions.
+	Address    string
+	Account    state.Account
+	Owner      byteutils.Hash
+	Source     string
+	SourceType string
 }
 
 // AccountState context account state
@@ -54,10 +79,12 @@ type AccountState struct {
 
 // ContextBlock warpper block
 type ContextBlock struct {
-	Coinbase string `json:"coinbase"`
-	Nonce    uint64 `json:"nonce"`
-	Hash     string `json:"hash"`
-	Height   uint64 `json:"height"`
+	Coinbase  string `json:"coinbase"`
+	Nonce     uint64 `json:"nonce"`
+	Hash      string `json:"hash"`
+	Height    uint64 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Seed      string `json:"seed"`
 }
 
 // ContextTransaction warpper transaction
@@ -79,6 +106,39 @@ type Context struct {
 	owner    state.Account
 	contract state.Account
 	state    state.AccountState
+
+	// the depth of call, 0 for the contract of transaction.
+	depth   int
+	journal *journal
+}
+
+// callBlock is the block seen by a called contract, the events it records go into the block of its
+// caller once the call succeeds.
+type callBlock struct {
+	Block
+	events []*callEvent
+}
+
+type callEvent struct {
+	txHash byteutils.Hash
+	topic  string
+	data   string
+}
+
+// RecordEvent keeps the event until the call succeeds.
+func (block *callBlock) RecordEvent(txHash byteutils.Hash, topic, data string) error {
+	block.events = append(block.events, &callEvent{txHash: txHash, topic: topic, data: data})
+	return nil
+}
+
+func (block *callBlock) flush() error {
+	for _, event := range block.events {
+		if err := block.Block.RecordEvent(event.txHash, event.topic, event.data); err != nil {
+			return err
+		}
+	}
+	block.events = nil
+	return nil
 }
 
 // NewContext create a engine context
@@ -89,10 +149,52 @@ func NewContext(block Block, tx *ContextTransaction, owner state.Account, contra
 		owner:    owner,
 		contract: contract,
 		state:    state,
+		journal:  &journal{},
 	}
 	return ctx
 }
 
+// newCallContext returns the context of the call to contract from the contract of ctx.
+func (ctx *Context) newCallContext(contract *Contract, value string) *Context {
+	tx := *ctx.tx
+	tx.From, tx.To, tx.Value = ctx.tx.To, contract.Address, value
+	return &Context{
+		block:    &callBlock{Block: ctx.block},
+		tx:       &tx,
+		owner:    ctx.state.GetOrCreateUserAccount(contract.Owner),
+		contract: contract.Account,
+		state:    ctx.state,
+		depth:    ctx.depth + 1,
+		journal:  ctx.journal,
+	}
+}
+
+// recordStorageUndo records how to restore the key in storage, if the change may be reverted by a call.
+func (ctx *Context) recordStorageUndo(storage state.Account, key []byte) {
+	if ctx.depth == 0 {
+		return
+	}
+	old, err := storage.Get(key)
+	ctx.journal.record(func() {
+		if err == nil {
+			storage.Put(key, old)
+		} else {
+			storage.Del(key)
+		}
+	})
+}
+
+// recordTransferUndo records how to return the value transferred, if the change may be reverted by a call.
+func (ctx *Context) recordTransferUndo(from, to state.Account, value *util.Uint128) {
+	if ctx.depth == 0 {
+		return
+	}
+	ctx.journal.record(func() {
+		to.SubBalance(value)
+		from.AddBalance(value)
+	})
+}
+
 // State returns account state
 func (ctx *Context) State() state.AccountState {
 	return ctx.state
@@ -113,16 +215,31 @@ func (ctx *Context) SerializeContextBlock() ([]byte, error) {
 
 	if ctx.block != nil {
 		block := &ContextBlock{
-			Coinbase: ctx.block.CoinbaseHash().String(),
-			Nonce:    ctx.block.Nonce(),
-			Hash:     ctx.block.Hash().String(),
-			Height:   ctx.block.Height(),
+			Coinbase:  ctx.block.CoinbaseHash().String(),
+			Nonce:     ctx.block.Nonce(),
+			Hash:      ctx.block.Hash().String(),
+			Height:    ctx.block.Height(),
+			Timestamp: ctx.block.Timestamp(),
+			Seed:      byteutils.Hex(ctx.randomSeed()),
 		}
 		return json.Marshal(block)
 	}
 	return nil, errors.New("no block in context")
 }
 
+// randomSeed returns the seed of Math.random of the contract, derived from the parent block hash, the tx hash and
+// the contract address. The hash of the block is unknown until it is sealed, so the parent hash is used instead.
+func (ctx *Context) randomSeed() []byte {
+	var txHash, contract []byte
+	if ctx.tx != nil {
+		txHash, _ = byteutils.FromHex(ctx.tx.Hash)
+	}
+	if ctx.contract != nil {
+		contract = ctx.contract.Address()
+	}
+	return hash.Sha3256(ctx.block.ParentHash(), txHash, contract)
+}
+
 // SerializeContextTx Serialize current tx
 func (ctx *Context) SerializeContextTx() ([]byte, error) {
 	return json.Marshal(ctx.tx)
@@ -0,0 +1,107 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventBlock struct {
+	mockBlock
+	topics []string
+}
+
+func (b *eventBlock) RecordEvent(txHash byteutils.Hash, topic, data string) error {
+	b.topics = append(b.topics, topic)
+	return nil
+}
+
+func TestContext_CallRevert(t *testing.T) {
+	mem, _ := storage.NewMemoryStorage()
+	as, _ := state.NewAccountState(nil, mem)
+	caller, _ := as.CreateContractAccount([]byte("caller"), nil)
+	callee, _ := as.CreateContractAccount([]byte("callee"), nil)
+	caller.AddBalance(util.NewUint128FromInt(100))
+	callee.Put([]byte("kept"), []byte("1"))
+
+	block := new(eventBlock)
+	ctx := NewContext(block, testContextTransaction(), caller, caller, as)
+	callCtx := ctx.newCallContext(&Contract{Address: "callee", Account: callee}, "10")
+	assert.Equal(t, 1, callCtx.depth)
+	assert.Equal(t, ctx.tx.To, callCtx.tx.From)
+	assert.Equal(t, "callee", callCtx.tx.To)
+
+	// the changes of the call are recorded, then reverted as the call fails.
+	snapshot := ctx.journal.snapshot()
+	callCtx.recordStorageUndo(callee, []byte("kept"))
+	callee.Put([]byte("kept"), []byte("2"))
+	callCtx.recordStorageUndo(callee, []byte("added"))
+	callee.Put([]byte("added"), []byte("3"))
+	caller.SubBalance(util.NewUint128FromInt(10))
+	callee.AddBalance(util.NewUint128FromInt(10))
+	callCtx.recordTransferUndo(caller, callee, util.NewUint128FromInt(10))
+	callCtx.block.RecordEvent(nil, "topic", "data")
+
+	ctx.journal.revert(snapshot)
+	value, _ := callee.Get([]byte("kept"))
+	assert.Equal(t, []byte("1"), value)
+	_, err := callee.Get([]byte("added"))
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	assert.Equal(t, "100", caller.Balance().String())
+	assert.Equal(t, "0", callee.Balance().String())
+	assert.Equal(t, 0, len(block.topics))
+
+	// the events of a succeeded call go into the block of caller.
+	assert.Nil(t, callCtx.block.(*callBlock).flush())
+	assert.Equal(t, []string{"topic"}, block.topics)
+
+	// the changes of the transaction are reverted out of the engine.
+	ctx.recordStorageUndo(caller, []byte("key"))
+	assert.Equal(t, 0, ctx.journal.snapshot())
+}
+
+func TestContext_RandomSeed(t *testing.T) {
+	mem, _ := storage.NewMemoryStorage()
+	as, _ := state.NewAccountState(nil, mem)
+	first, _ := as.CreateContractAccount([]byte("first"), nil)
+	second, _ := as.CreateContractAccount([]byte("second"), nil)
+
+	seed := func(contract state.Account, txHash string) string {
+		tx := testContextTransaction()
+		tx.Hash = txHash
+		data, err := NewContext(testContextBlock(), tx, contract, contract, as).SerializeContextBlock()
+		assert.Nil(t, err)
+		block := new(ContextBlock)
+		assert.Nil(t, json.Unmarshal(data, block))
+		return block.Seed
+	}
+
+	// every node draws the same, while the contracts and transactions draw apart.
+	assert.Equal(t, 64, len(seed(first, "c7174759e86c59dcb7df87def82f61eb")))
+	assert.Equal(t, seed(first, "c7174759e86c59dcb7df87def82f61eb"), seed(first, "c7174759e86c59dcb7df87def82f61eb"))
+	assert.NotEqual(t, seed(first, "c7174759e86c59dcb7df87def82f61eb"), seed(second, "c7174759e86c59dcb7df87def82f61eb"))
+	assert.NotEqual(t, seed(first, "c7174759e86c59dcb7df87def82f61eb"), seed(first, "5e6d587f26121f96a07cf4b8b569aac1"))
+}
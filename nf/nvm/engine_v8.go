@@ -38,6 +38,8 @@ char *GetTxByHashFunc_cgo(void *handler, const char *hash);
 char *GetAccountStateFunc_cgo(void *handler, const char *address);
 int TransferFunc_cgo(void *handler, const char *to, const char *value);
 int VerifyAddressFunc_cgo(void *handler, const char *address);
+char *CallContractFunc_cgo(void *handler, const char *address, const char *function, const char *args, const char *value);
+char *GetOracleDataFunc_cgo(void *handler, const char *key);
 
 void EventTriggerFunc_cgo(void *handler, const char *topic, const char *data);
 
@@ -63,6 +65,18 @@ const (
 	SourceTypeTypeScript = "ts"
 )
 
+// Instructions counted for the builtin functions called by contracts, besides the instructions of
+// scripts, so the gas of a call covers the work done out of the engine.
+const (
+	StorageGetInstructions   uint64 = 100
+	StorageWriteInstructions uint64 = 200
+	StorageByteInstructions  uint64 = 1
+	QueryInstructions        uint64 = 100
+	TransferInstructions     uint64 = 1000
+	EventInstructions        uint64 = 200
+	CallInstructions         uint64 = 1000
+)
+
 // Errors
 var (
 	ErrExecutionFailed                = errors.New("execution failed")
@@ -99,6 +113,11 @@ type V8Engine struct {
 	gcsHandler                         uint64
 }
 
+// V8Version returns the version of the linked v8 engine.
+func V8Version() string {
+	return C.GoString(C.GetV8Version())
+}
+
 // InitV8Engine initialize the v8 engine.
 func InitV8Engine() {
 	C.Initialize()
@@ -113,7 +132,7 @@ func InitV8Engine() {
 	C.InitializeStorage((C.StorageGetFunc)(unsafe.Pointer(C.StorageGetFunc_cgo)), (C.StoragePutFunc)(unsafe.Pointer(C.StoragePutFunc_cgo)), (C.StorageDelFunc)(unsafe.Pointer(C.StorageDelFunc_cgo)))
 
 	// Blockchain.
-	C.InitializeBlockchain((C.GetTxByHashFunc)(unsafe.Pointer(C.GetTxByHashFunc_cgo)), (C.GetAccountStateFunc)(unsafe.Pointer(C.GetAccountStateFunc_cgo)), (C.TransferFunc)(unsafe.Pointer(C.TransferFunc_cgo)), (C.VerifyAddressFunc)(unsafe.Pointer(C.VerifyAddressFunc_cgo)))
+	C.InitializeBlockchain((C.GetTxByHashFunc)(unsafe.Pointer(C.GetTxByHashFunc_cgo)), (C.GetAccountStateFunc)(unsafe.Pointer(C.GetAccountStateFunc_cgo)), (C.TransferFunc)(unsafe.Pointer(C.TransferFunc_cgo)), (C.VerifyAddressFunc)(unsafe.Pointer(C.VerifyAddressFunc_cgo)), (C.CallContractFunc)(unsafe.Pointer(C.CallContractFunc_cgo)), (C.GetOracleDataFunc)(unsafe.Pointer(C.GetOracleDataFunc_cgo)))
 
 	// Event.
 	C.InitializeEvent((C.EventTriggerFunc)(unsafe.Pointer(C.EventTriggerFunc_cgo)))
@@ -215,6 +234,23 @@ func (e *V8Engine) ExecutionInstructions() uint64 {
 	return e.actualCountOfExecutionInstructions
 }
 
+// chargeInstructions counts the instructions of a builtin function, the execution is terminated once
+// they exceed the limits, as the instructions of scripts do.
+func (e *V8Engine) chargeInstructions(count uint64) {
+	e.v8engine.stats.count_of_executed_instructions += C.size_t(count)
+	if e.enableLimits && C.IsEngineLimitsExceeded(e.v8engine) != 0 {
+		C.TerminateExecution(e.v8engine)
+	}
+}
+
+// Exception returns the message of the exception thrown by the last script run, empty if none.
+func (e *V8Engine) Exception() string {
+	if e.v8engine.exception == nil {
+		return ""
+	}
+	return C.GoString(e.v8engine.exception)
+}
+
 // TranspileTypeScript transpile typescript to javascript and return it.
 func (e *V8Engine) TranspileTypeScript(source string) (string, int, error) {
 	cSource := C.CString(source)
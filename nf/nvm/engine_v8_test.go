@@ -59,10 +59,18 @@ func (m *mockBlock) Hash() byteutils.Hash {
 	return []byte("c7174759e86c59dcb7df87def82f61eb")
 }
 
+func (m *mockBlock) ParentHash() byteutils.Hash {
+	return []byte("b3bb6a6c2b0e2ad47d3f1a6c0a38c3e8")
+}
+
 func (m *mockBlock) Height() uint64 {
 	return 2
 }
 
+func (m *mockBlock) Timestamp() int64 {
+	return 1514764800
+}
+
 func (m *mockBlock) VerifyAddress(str string) bool {
 	return true
 }
@@ -88,6 +96,14 @@ func (m *mockBlock) SerializeTxByHash(hash byteutils.Hash) (proto.Message, error
 	return proto.Message(block), nil
 }
 
+func (m *mockBlock) LoadContract(state state.AccountState, address string) (*Contract, error) {
+	return nil, ErrCallUnavailable
+}
+
+func (m *mockBlock) OracleData(state state.AccountState, key string) ([]byte, error) {
+	return nil, ErrKeyNotFound
+}
+
 func testContextBlock() Block {
 	return new(mockBlock)
 }
@@ -115,6 +131,7 @@ func TestRunScriptSource(t *testing.T) {
 		{"test/test_storage_class.js", nil},
 		{"test/test_storage.js", nil},
 		{"test/test_eval.js", ErrExecutionFailed},
+		{"test/test_sandbox.js", nil},
 	}
 
 	for _, tt := range tests {
@@ -230,6 +247,24 @@ func TestRunScriptSourceWithLimits(t *testing.T) {
 	}
 }
 
+func TestRunScriptSourceException(t *testing.T) {
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewAccountState(nil, mem)
+	owner := context.GetOrCreateUserAccount([]byte("account1"))
+	contract, _ := context.CreateContractAccount([]byte("account2"), nil)
+	ctx := NewContext(testContextBlock(), testContextTransaction(), owner, contract, context)
+
+	engine := NewV8Engine(ctx)
+	defer engine.Dispose()
+	err := engine.RunScriptSource("throw new Error(\"not enough balance\");", 0)
+	assert.Equal(t, ErrExecutionFailed, err)
+	assert.Equal(t, "Error: not enough balance", engine.Exception())
+
+	// the exception is cleared by the next run.
+	assert.Nil(t, engine.RunScriptSource("var a = 1;", 0))
+	assert.Equal(t, "", engine.Exception())
+}
+
 func TestRunScriptSourceTimeout(t *testing.T) {
 	tests := []struct {
 		filepath string
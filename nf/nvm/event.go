@@ -33,6 +33,12 @@ const (
 	EventNameSpaceContract = "chain.contract"
 )
 
+// ContractEventTopic returns the topic recording the event of topic triggered by the contract, prefixed
+// with the address of contract, e.g. "chain.contract.<address>.transfer", so dapps filter their own events.
+func ContractEventTopic(contract, topic string) string {
+	return EventNameSpaceContract + "." + contract + "." + topic
+}
+
 // EventTriggerFunc export EventTriggerFunc
 //export EventTriggerFunc
 func EventTriggerFunc(handler unsafe.Pointer, topic, data *C.char) {
@@ -55,7 +61,17 @@ func EventTriggerFunc(handler unsafe.Pointer, topic, data *C.char) {
 		"data":     gData,
 	}).Info("Event triggered from V8 engine.")
 
+	e.chargeInstructions(EventInstructions + StorageByteInstructions*uint64(len(gData)))
+
+	if len(gTopic) == 0 {
+		logging.VLog().WithFields(logrus.Fields{
+			"data": gData,
+		}).Error("Event.Trigger without topic.")
+		return
+	}
+
+	// the events of a called contract are recorded under the transaction calling it, as its others.
 	txHash, _ := byteutils.FromHex(e.ctx.tx.Hash)
-	contractTopic := EventNameSpaceContract + "." + gTopic
+	contractTopic := ContractEventTopic(e.ctx.contract.Address().String(), gTopic)
 	e.ctx.block.RecordEvent(txHash, contractTopic, gData)
 }
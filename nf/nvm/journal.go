@@ -0,0 +1,46 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+// journal records how to undo the changes made by the called contracts, so a failed call reverts its
+// changes while its caller goes on. The changes of a whole transaction are reverted out of the engine.
+type journal struct {
+	undoes []func()
+}
+
+func (j *journal) record(undo func()) {
+	j.undoes = append(j.undoes, undo)
+}
+
+func (j *journal) snapshot() int {
+	return len(j.undoes)
+}
+
+// revert undoes the changes after the snapshot, the latest first.
+func (j *journal) revert(snapshot int) {
+	for i := len(j.undoes) - 1; i >= snapshot; i-- {
+		j.undoes[i]()
+	}
+	j.undoes = j.undoes[:snapshot]
+}
+
+// forget drops the undoes after the snapshot, once the changes can't be reverted by a call any more.
+func (j *journal) forget(snapshot int) {
+	j.undoes = j.undoes[:snapshot]
+}
@@ -63,10 +63,11 @@ func hashStorageKey(key string) []byte {
 // StorageGetFunc export StorageGetFunc
 //export StorageGetFunc
 func StorageGetFunc(handler unsafe.Pointer, key *C.char) *C.char {
-	_, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
+	engine, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
 	if storage == nil {
 		return nil
 	}
+	engine.chargeInstructions(StorageGetInstructions)
 
 	val, err := storage.Get([]byte(hashStorageKey(C.GoString(key))))
 	if err != nil {
@@ -86,12 +87,16 @@ func StorageGetFunc(handler unsafe.Pointer, key *C.char) *C.char {
 // StoragePutFunc export StoragePutFunc
 //export StoragePutFunc
 func StoragePutFunc(handler unsafe.Pointer, key *C.char, value *C.char) int {
-	_, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
+	engine, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
 	if storage == nil {
 		return 1
 	}
+	val := C.GoString(value)
+	engine.chargeInstructions(StorageWriteInstructions + StorageByteInstructions*uint64(len(val)))
 
-	err := storage.Put([]byte(hashStorageKey(C.GoString(key))), []byte(C.GoString(value)))
+	hashedKey := hashStorageKey(C.GoString(key))
+	engine.ctx.recordStorageUndo(storage, hashedKey)
+	err := storage.Put(hashedKey, []byte(val))
 	if err != nil && err != ErrKeyNotFound {
 		logging.VLog().WithFields(logrus.Fields{
 			"handler": uint64(uintptr(handler)),
@@ -106,12 +111,15 @@ func StoragePutFunc(handler unsafe.Pointer, key *C.char, value *C.char) int {
 // StorageDelFunc export StorageDelFunc
 //export StorageDelFunc
 func StorageDelFunc(handler unsafe.Pointer, key *C.char) int {
-	_, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
+	engine, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
 	if storage == nil {
 		return 1
 	}
+	engine.chargeInstructions(StorageWriteInstructions)
 
-	err := storage.Del([]byte(hashStorageKey(C.GoString(key))))
+	hashedKey := hashStorageKey(C.GoString(key))
+	engine.ctx.recordStorageUndo(storage, hashedKey)
+	err := storage.Del(hashedKey)
 
 	if err != nil && err != ErrKeyNotFound {
 		logging.VLog().WithFields(logrus.Fields{
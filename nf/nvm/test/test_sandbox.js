@@ -0,0 +1,41 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+'use strict';
+
+Blockchain.blockParse("{\"height\":2,\"timestamp\":1514764800,\"seed\":\"5b0d86c0f9a9ba1d2e2e6a4e0e2a8e3aa6b1c9f3f4d2e1c0b9a8f7e6d5c4b3a2\"}");
+if (Math.random() !== 0.41741278140715765 || Math.random() !== 0.3124233259604239) {
+    throw new Error("Math.random should be seeded by the block.");
+}
+if (Date.now() !== 1514764800000 || new Date().getTime() !== 1514764800000) {
+    throw new Error("the time should be the timestamp of block.");
+}
+if (new Date(0).getTime() !== 0) {
+    throw new Error("the date of the time should be kept.");
+}
+var date = new Date(2018, 0, 1, 8);
+if (date.getTime() !== 1514793600000 || date.getHours() !== 8 || date.getDate() !== 1 || date.getTimezoneOffset() !== 0) {
+    throw new Error("the date should be in UTC.");
+}
+if (date.toString() !== "Mon, 01 Jan 2018 08:00:00 GMT" || date.toLocaleDateString() !== "2018-01-01" ||
+    date.toLocaleTimeString() !== "08:00:00.000Z" || Date() !== "Mon, 01 Jan 2018 00:00:00 GMT") {
+    throw new Error("the strings of date should be in UTC.");
+}
+date.setHours(23);
+if (date.getTime() !== 1514847600000) {
+    throw new Error("the date should be set in UTC.");
+}
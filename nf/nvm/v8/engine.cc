@@ -32,12 +32,14 @@
 #include <v8.h>
 
 #include <assert.h>
+#include <string.h>
 
 using namespace v8;
 
 static Platform *platformPtr = NULL;
 
 void PrintException(Local<Context> context, TryCatch &trycatch);
+void RecordException(V8Engine *e, TryCatch &trycatch);
 void EngineLimitsCheckDelegate(Isolate *isolate, size_t count,
                                void *listenerContext);
 
@@ -104,6 +106,9 @@ void DeleteEngine(V8Engine *e) {
 
   delete static_cast<ArrayBuffer::Allocator *>(e->allocator);
 
+  if (e->exception != NULL) {
+    free(e->exception);
+  }
   free(e);
 }
 
@@ -129,6 +134,7 @@ int ExecuteSourceDataDelegate(Isolate *isolate, const char *source,
   // Run the script to get the result.
   MaybeLocal<Value> ret = script.ToLocalChecked()->Run(context);
   if (ret.IsEmpty()) {
+    RecordException(static_cast<V8Engine *>(delegateContext), trycatch);
     PrintException(context, trycatch);
     return 1;
   }
@@ -163,8 +169,12 @@ char *TranspileTypeScriptModule(V8Engine *e, const char *source,
 
 int RunScriptSource(V8Engine *e, const char *source, int source_line_offset,
                     uintptr_t lcsHandler, uintptr_t gcsHandler) {
+  if (e->exception != NULL) {
+    free(e->exception);
+    e->exception = NULL;
+  }
   return Execute(e, source, source_line_offset, (void *)lcsHandler,
-                 (void *)gcsHandler, ExecuteSourceDataDelegate, NULL);
+                 (void *)gcsHandler, ExecuteSourceDataDelegate, (void *)e);
 }
 
 int Execute(V8Engine *e, const char *source, int source_line_offset,
@@ -263,6 +273,16 @@ void PrintException(Local<Context> context, TryCatch &trycatch) {
   }
 }
 
+void RecordException(V8Engine *e, TryCatch &trycatch) {
+  if (e == NULL || !trycatch.HasCaught()) {
+    return;
+  }
+  String::Utf8Value exception_str(trycatch.Exception());
+  if (*exception_str != NULL) {
+    e->exception = strdup(*exception_str);
+  }
+}
+
 void ReadMemoryStatistics(V8Engine *e) {
   Isolate *isolate = static_cast<Isolate *>(e->isolate);
   ArrayBufferAllocator *allocator =
@@ -63,11 +63,19 @@ typedef char *(*GetTxByHashFunc)(void *handler, const char *hash);
 typedef char *(*GetAccountStateFunc)(void *handler, const char *address);
 typedef int (*TransferFunc)(void *handler, const char *to, const char *value);
 typedef int (*VerifyAddressFunc)(void *handler, const char *address);
+// returns NULL if the call succeeded, otherwise the message of failure.
+typedef char *(*CallContractFunc)(void *handler, const char *address,
+                                  const char *function, const char *args,
+                                  const char *value);
+// returns the record of the oracle data of key, NULL if not found.
+typedef char *(*GetOracleDataFunc)(void *handler, const char *key);
 
 EXPORT void InitializeBlockchain(GetTxByHashFunc getTx,
                                  GetAccountStateFunc getAccount,
                                  TransferFunc transfer,
-                                 VerifyAddressFunc verifyAddress);
+                                 VerifyAddressFunc verifyAddress,
+                                 CallContractFunc callContract,
+                                 GetOracleDataFunc getOracleData);
 
 // version
 EXPORT char *GetV8Version();
@@ -100,6 +108,7 @@ typedef struct V8Engine {
   int is_requested_terminate_execution;
   int testing;
   V8EngineStats stats;
+  char *exception; // the message of the exception thrown by the last script run.
 } V8Engine;
 
 EXPORT void Initialize();
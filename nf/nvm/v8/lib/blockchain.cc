@@ -24,14 +24,20 @@ static GetTxByHashFunc sGetTxByHash = NULL;
 static GetAccountStateFunc sGetAccountState = NULL;
 static TransferFunc sTransfer = NULL;
 static VerifyAddressFunc sVerifyAddress = NULL;
+static CallContractFunc sCallContract = NULL;
+static GetOracleDataFunc sGetOracleData = NULL;
 
 void InitializeBlockchain(GetTxByHashFunc getTx,
                           GetAccountStateFunc getAccount,
-                          TransferFunc transfer, VerifyAddressFunc verifyAddress) {
+                          TransferFunc transfer, VerifyAddressFunc verifyAddress,
+                          CallContractFunc callContract,
+                          GetOracleDataFunc getOracleData) {
   sGetTxByHash = getTx;
   sGetAccountState = getAccount;
   sTransfer = transfer;
   sVerifyAddress = verifyAddress;
+  sCallContract = callContract;
+  sGetOracleData = getOracleData;
 }
 
 void NewBlockchainInstance(Isolate *isolate, Local<Context> context,
@@ -59,6 +65,16 @@ void NewBlockchainInstance(Isolate *isolate, Local<Context> context,
                 static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
                                                PropertyAttribute::ReadOnly));
 
+  blockTpl->Set(String::NewFromUtf8(isolate, "call"),
+                FunctionTemplate::New(isolate, CallContractCallback),
+                static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                               PropertyAttribute::ReadOnly));
+
+  blockTpl->Set(String::NewFromUtf8(isolate, "getOracleData"),
+                FunctionTemplate::New(isolate, GetOracleDataCallback),
+                static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                               PropertyAttribute::ReadOnly));
+
   Local<Object> instance = blockTpl->NewInstance(context).ToLocalChecked();
   instance->SetInternalField(0, External::New(isolate, handler));
 
@@ -177,3 +193,63 @@ void VerifyAddressCallback(const FunctionCallbackInfo<Value> &info) {
   int ret = sVerifyAddress(handler->Value(), *String::Utf8Value(address->ToString()));
   info.GetReturnValue().Set(ret);
 }
+
+// CallContractCallback
+void CallContractCallback(const FunctionCallbackInfo<Value> &info) {
+  Isolate *isolate = info.GetIsolate();
+  Local<Object> thisArg = info.Holder();
+  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+
+  if (info.Length() != 4) {
+    isolate->ThrowException(
+        String::NewFromUtf8(isolate, "Blockchain.call() requires 4 arguments"));
+    return;
+  }
+
+  for (int i = 0; i < 4; i++) {
+    if (!info[i]->IsString()) {
+      isolate->ThrowException(String::NewFromUtf8(
+          isolate, "address, function, args and value must be string"));
+      return;
+    }
+  }
+
+  char *err = sCallContract(handler->Value(),
+                            *String::Utf8Value(info[0]->ToString()),
+                            *String::Utf8Value(info[1]->ToString()),
+                            *String::Utf8Value(info[2]->ToString()),
+                            *String::Utf8Value(info[3]->ToString()));
+  if (err != NULL) {
+    isolate->ThrowException(
+        Exception::Error(String::NewFromUtf8(isolate, err)));
+    free(err);
+  }
+}
+
+// GetOracleDataCallback
+void GetOracleDataCallback(const FunctionCallbackInfo<Value> &info) {
+  Isolate *isolate = info.GetIsolate();
+  Local<Object> thisArg = info.Holder();
+  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+
+  if (info.Length() != 1) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.getOracleData() requires only 1 argument"));
+    return;
+  }
+
+  Local<Value> key = info[0];
+  if (!key->IsString()) {
+    isolate->ThrowException(String::NewFromUtf8(isolate, "key must be string"));
+    return;
+  }
+
+  char *value =
+      sGetOracleData(handler->Value(), *String::Utf8Value(key->ToString()));
+  if (value == NULL) {
+    info.GetReturnValue().SetNull();
+  } else {
+    info.GetReturnValue().Set(String::NewFromUtf8(isolate, value));
+    free(value);
+  }
+}
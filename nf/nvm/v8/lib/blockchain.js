@@ -73,6 +73,21 @@ Blockchain.prototype = {
     },
     verifyAddress: function (address) {
         return this.nativeBlockchain.verifyAddress(address);
+    },
+    // get the latest record of the oracle data of key, {key, value, feeder, height, timestamp}, null if never fed.
+    getOracleData: function (key) {
+        var data = this.nativeBlockchain.getOracleData(key);
+        if (data === null) {
+            return null;
+        }
+        return JSON.parse(data);
+    },
+    // call the function of contract at address with the args array, transferring the value to it.
+    // the changes of a failed call are reverted and an error is thrown, which the caller may catch.
+    call: function (address, func, args, value) {
+        args = args === undefined ? [] : args;
+        value = value === undefined ? "0" : new BigNumber(value).toString(10);
+        this.nativeBlockchain.call(address, func, JSON.stringify(args), value);
     }
 };
 
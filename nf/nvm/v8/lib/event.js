@@ -18,6 +18,8 @@
 
 'use strict';
 
+// trigger the event of topic with the data, recorded under the transaction as the topic
+// "chain.contract.<address of the contract>.<topic>", which the dapps filter on.
 exports["Trigger"] = function (topic, data) {
     _native_event_trigger(topic, JSON.stringify(data));
 };
@@ -107,3 +107,85 @@ const GlobalContractStorage = ContractStorage.gcs;
 const BigNumber = require('bignumber.js');
 const Blockchain = require('blockchain.js');
 const Event = require('event.js');
+
+// the features differing among the nodes are sandboxed, so every node executes a contract alike.
+// Math.random is a xoshiro128** generator seeded by the parent block hash, the transaction hash and the contract
+// address, so the draws of a contract in a transaction are the same on every node.
+Math.random = (function () {
+    var s = null;
+    var rotl = function (x, k) {
+        return (x << k) | (x >>> (32 - k));
+    };
+    var next = function () {
+        var result = Math.imul(rotl(Math.imul(s[1], 5), 7), 9);
+        var t = s[1] << 9;
+        s[2] ^= s[0];
+        s[3] ^= s[1];
+        s[1] ^= s[2];
+        s[0] ^= s[3];
+        s[2] ^= t;
+        s[3] = rotl(s[3], 11);
+        return result >>> 0;
+    };
+    return function () {
+        if (s === null) {
+            var seed = Blockchain.block === undefined ? undefined : Blockchain.block.seed;
+            if (typeof seed !== "string" || !/^[0-9a-f]{32,}$/.test(seed)) {
+                throw new Error("the seed of random is unknown.");
+            }
+            s = [];
+            for (var i = 0; i < 4; i++) {
+                s.push(parseInt(seed.substr(i * 8, 8), 16) | 0);
+            }
+        }
+        // 53 bits from two draws, as many as a double holds.
+        return ((next() >>> 5) * 67108864 + (next() >>> 6)) / 9007199254740992;
+    };
+})();
+
+Date = (function (NativeDate) {
+    // the time of contract is the timestamp of the block packing the transaction.
+    var blockTime = function () {
+        if (Blockchain.block === undefined || Blockchain.block.timestamp === undefined) {
+            throw new Error("the time of block is unknown.");
+        }
+        return Blockchain.block.timestamp * 1000;
+    };
+
+    // the dates are in UTC whatever the timezone of the node, the local getters, setters and
+    // strings are the UTC ones.
+    var proto = NativeDate.prototype;
+    ["FullYear", "Month", "Date", "Day", "Hours", "Minutes", "Seconds", "Milliseconds"].forEach(function (name) {
+        proto["get" + name] = proto["getUTC" + name];
+        if (name !== "Day") {
+            proto["set" + name] = proto["setUTC" + name];
+        }
+    });
+    proto.getTimezoneOffset = function () {
+        return 0;
+    };
+    proto.toString = proto.toLocaleString = proto.toUTCString;
+    proto.toDateString = proto.toLocaleDateString = function () {
+        return this.toISOString().slice(0, 10);
+    };
+    proto.toTimeString = proto.toLocaleTimeString = function () {
+        return this.toISOString().slice(11);
+    };
+
+    var SandboxDate = function () {
+        if (!(this instanceof SandboxDate)) {
+            return new NativeDate(blockTime()).toString();
+        }
+        var args = arguments.length === 0 ? [blockTime()] : Array.prototype.slice.call(arguments);
+        // the date of components is in UTC too.
+        if (args.length > 1) {
+            args = [NativeDate.UTC.apply(null, args)];
+        }
+        return new (Function.prototype.bind.apply(NativeDate, [null].concat(args)))();
+    };
+    SandboxDate.prototype = proto;
+    SandboxDate.now = blockTime;
+    SandboxDate.parse = NativeDate.parse;
+    SandboxDate.UTC = NativeDate.UTC;
+    return SandboxDate;
+})(Date);
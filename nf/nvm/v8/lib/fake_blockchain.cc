@@ -49,3 +49,10 @@ char *GetAccountState(void *handler, const char *address) {
 int Transfer(void *handler, const char *to, const char *value) { return 1; }
 
 int VerifyAddress(void *handler, const char *address) { return 1; }
+
+char *CallContract(void *handler, const char *address, const char *function,
+                   const char *args, const char *value) {
+  return NULL;
+}
+
+char *GetOracleData(void *handler, const char *key) { return NULL; }
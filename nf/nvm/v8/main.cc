@@ -220,7 +220,8 @@ int main(int argc, const char *argv[]) {
   InitializeLogger(logFunc);
   InitializeRequireDelegate(RequireDelegateFunc);
   InitializeStorage(StorageGet, StoragePut, StorageDel);
-  InitializeBlockchain(GetTxByHash, GetAccountState, Transfer, VerifyAddress);
+  InitializeBlockchain(GetTxByHash, GetAccountState, Transfer, VerifyAddress,
+                       CallContract, GetOracleData);
   InitializeEvent(eventTriggerFunc);
 
   int argcIdx = 1;
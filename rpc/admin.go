@@ -0,0 +1,282 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AdminMetadata is the metadata key of the admin token in gRPC, it's header "X-Admin-Token" in HTTP.
+const AdminMetadata = "x-admin-token"
+
+// DefaultProfilingListen is the listen address of pprof if not given.
+const DefaultProfilingListen = "127.0.0.1:6060"
+
+// ChainExportDir is the directory of the chain dump files under the datadir.
+const ChainExportDir = "export"
+
+const (
+	adminServicePrefix = "/rpcpb.AdminService/"
+	adminHTTPPrefix    = "/v1/admin/"
+)
+
+// Errors of admin
+var (
+	ErrAdminTokenRequired = status.Error(codes.Unauthenticated, "admin token required")
+	ErrInvalidAdminToken  = status.Error(codes.Unauthenticated, "invalid admin token")
+	ErrAdminNotLocal      = status.Error(codes.PermissionDenied, "admin service only accepts requests from localhost")
+	ErrChainExportRunning = errors.New("chain export is running")
+	ErrInvalidExportPath  = errors.New("export file must be a relative path in the export directory")
+	ErrProfilingRunning   = errors.New("pprof is serving on another address")
+)
+
+// AdminGuard guards the admin service, the requests need the admin token if it's set, or must be
+// from localhost otherwise. The other services are not checked.
+type AdminGuard struct {
+	token []byte
+}
+
+// NewAdminGuard returns the guard of the admin token.
+func NewAdminGuard(token string) *AdminGuard {
+	return &AdminGuard{token: []byte(token)}
+}
+
+func (g *AdminGuard) check(ctx context.Context, method string) error {
+	if !strings.HasPrefix(method, adminServicePrefix) {
+		return nil
+	}
+	if len(g.token) == 0 {
+		p, ok := peer.FromContext(ctx)
+		if !ok || !isLoopback(p.Addr.String()) {
+			return ErrAdminNotLocal
+		}
+		return nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[AdminMetadata]) == 0 {
+		return ErrAdminTokenRequired
+	}
+	if subtle.ConstantTimeCompare([]byte(md[AdminMetadata][0]), g.token) != 1 {
+		return ErrInvalidAdminToken
+	}
+	return nil
+}
+
+// UnaryInterceptor checks the admin requests before the handler.
+func (g *AdminGuard) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := g.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor checks the admin requests before the handler.
+func (g *AdminGuard) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := g.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// isLoopback returns if the host of the address is a loopback IP.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// guardAdmin refuses the admin requests of the gateway from other hosts if the admin token is not set,
+// the gRPC server sees the gateway as localhost. The admin token is passed to the gRPC server.
+func guardAdmin(cfg *nebletpb.RPCConfig, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, adminHTTPPrefix) {
+			if cfg.AdminToken == "" && !isLoopback(r.RemoteAddr) {
+				http.Error(w, ErrAdminNotLocal.Error(), http.StatusForbidden)
+				return
+			}
+			if token := r.Header.Get(AdminMetadata); token != "" {
+				r.Header.Set("Grpc-Metadata-"+AdminMetadata, token)
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// adminEndpoint returns the gRPC address of the admin service in config.
+func adminEndpoint(cfg *nebletpb.RPCConfig) string {
+	if len(cfg.AdminListen) > 0 {
+		return cfg.AdminListen[0]
+	}
+	return cfg.RpcListen[0]
+}
+
+// profiler serves pprof on demand.
+type profiler struct {
+	mu     sync.Mutex
+	server *http.Server
+	listen string
+}
+
+// start serves pprof on the address, it's a no-op if pprof is serving on it already.
+func (p *profiler) start(listen string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.server != nil {
+		if p.listen != listen {
+			return ErrProfilingRunning
+		}
+		return nil
+	}
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	p.server = &http.Server{Handler: mux}
+	p.listen = listen
+	go p.server.Serve(listener)
+
+	logging.CLog().WithFields(logrus.Fields{
+		"listen": listen,
+	}).Info("Started pprof.")
+	return nil
+}
+
+// stop stops pprof if it's serving.
+func (p *profiler) stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.server == nil {
+		return nil
+	}
+	err := p.server.Close()
+	p.server = nil
+	p.listen = ""
+
+	logging.CLog().Info("Stopped pprof.")
+	return err
+}
+
+// chainExporter exports the chain in background, one export at a time.
+type chainExporter struct {
+	running int32
+}
+
+// exportPath returns the path of the file in the export directory, the file must not escape it.
+func exportPath(dir, file string) (string, error) {
+	if len(file) == 0 || filepath.IsAbs(file) {
+		return "", ErrInvalidExportPath
+	}
+	for _, v := range strings.Split(filepath.ToSlash(file), "/") {
+		if v == ".." {
+			return "", ErrInvalidExportPath
+		}
+	}
+	return filepath.Join(dir, filepath.Clean(file)), nil
+}
+
+// export starts to export the blocks into the file in the export directory dir, the range is checked
+// before. The blocks are written into a temporary file first, so the file is complete once it exists.
+func (e *chainExporter) export(bc *core.BlockChain, dir, file string, from, to uint64) error {
+	file, err := exportPath(dir, file)
+	if err != nil {
+		return err
+	}
+	tail := bc.TailBlock().Height()
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 {
+		to = tail
+	}
+	if from > to || to > tail {
+		return core.ErrInvalidExportRange
+	}
+	if !atomic.CompareAndSwapInt32(&e.running, 0, 1) {
+		return ErrChainExportRunning
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		atomic.StoreInt32(&e.running, 0)
+		return err
+	}
+	tmp := file + ".tmp"
+	w, err := os.Create(tmp)
+	if err != nil {
+		atomic.StoreInt32(&e.running, 0)
+		return err
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&e.running, 0)
+		err := bc.Export(w, from, to)
+		if e := w.Close(); err == nil {
+			err = e
+		}
+		if err == nil {
+			err = os.Rename(tmp, file)
+		}
+		if err != nil {
+			os.Remove(tmp)
+			logging.CLog().WithFields(logrus.Fields{
+				"file": file,
+				"err":  err,
+			}).Error("Failed to export chain.")
+			return
+		}
+		logging.CLog().WithFields(logrus.Fields{
+			"file": file,
+			"from": from,
+			"to":   to,
+		}).Info("Exported chain.")
+	}()
+	return nil
+}
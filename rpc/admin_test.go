@@ -0,0 +1,120 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestAdminGuard(t *testing.T) {
+	fromAddr := func(addr string, pairs ...string) context.Context {
+		tcp, _ := net.ResolveTCPAddr("tcp", addr)
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tcp})
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...))
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	admin := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.AdminService/GetPeers"}
+	api := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/GetNebState"}
+
+	// only localhost without the token.
+	guard := NewAdminGuard("").UnaryInterceptor()
+	_, err := guard(fromAddr("127.0.0.1:5000"), nil, admin, handler)
+	assert.Nil(t, err)
+	_, err = guard(fromAddr("[::1]:5000"), nil, admin, handler)
+	assert.Nil(t, err)
+	_, err = guard(fromAddr("10.0.0.1:5000"), nil, admin, handler)
+	assert.Equal(t, ErrAdminNotLocal, err)
+	_, err = guard(fromAddr("10.0.0.1:5000"), nil, api, handler)
+	assert.Nil(t, err)
+
+	// anywhere with the token.
+	guard = NewAdminGuard("secret").UnaryInterceptor()
+	_, err = guard(fromAddr("127.0.0.1:5000"), nil, admin, handler)
+	assert.Equal(t, ErrAdminTokenRequired, err)
+	_, err = guard(fromAddr("10.0.0.1:5000", AdminMetadata, "wrong"), nil, admin, handler)
+	assert.Equal(t, ErrInvalidAdminToken, err)
+	_, err = guard(fromAddr("10.0.0.1:5000", AdminMetadata, "secret"), nil, admin, handler)
+	assert.Nil(t, err)
+}
+
+func TestGuardAdmin_HTTP(t *testing.T) {
+	var forwarded string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("Grpc-Metadata-" + AdminMetadata)
+	})
+
+	serve := func(cfg *nebletpb.RPCConfig, path, remote, token string) int {
+		r := httptest.NewRequest("GET", path, nil)
+		r.RemoteAddr = remote
+		if token != "" {
+			r.Header.Set(AdminMetadata, token)
+		}
+		w := httptest.NewRecorder()
+		guardAdmin(cfg, h).ServeHTTP(w, r)
+		return w.Code
+	}
+
+	cfg := &nebletpb.RPCConfig{}
+	assert.Equal(t, http.StatusForbidden, serve(cfg, "/v1/admin/peers", "10.0.0.1:5000", ""))
+	assert.Equal(t, http.StatusOK, serve(cfg, "/v1/admin/peers", "127.0.0.1:5000", ""))
+	assert.Equal(t, http.StatusOK, serve(cfg, "/v1/user/nebstate", "10.0.0.1:5000", ""))
+
+	cfg.AdminToken = "secret"
+	assert.Equal(t, http.StatusOK, serve(cfg, "/v1/admin/peers", "10.0.0.1:5000", "secret"))
+	assert.Equal(t, "secret", forwarded)
+}
+
+func TestProfiler(t *testing.T) {
+	var p profiler
+	assert.Nil(t, p.start("127.0.0.1:0"))
+	assert.Nil(t, p.start("127.0.0.1:0"))
+	assert.Equal(t, ErrProfilingRunning, p.start("127.0.0.1:1"))
+	assert.Nil(t, p.stop())
+	assert.Nil(t, p.stop())
+}
+
+func TestExportPath(t *testing.T) {
+	dir := filepath.Join("data.db", ChainExportDir)
+
+	path, err := exportPath(dir, "chain.dump")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "chain.dump"), path)
+
+	path, err = exportPath(dir, "daily/./chain.dump")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "daily", "chain.dump"), path)
+
+	for _, file := range []string{"", "/etc/passwd", "../chain.dump", "daily/../../chain.dump", ".."} {
+		_, err := exportPath(dir, file)
+		assert.Equal(t, ErrInvalidExportPath, err, file)
+	}
+}
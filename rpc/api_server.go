@@ -8,6 +8,7 @@ import (
 	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/rpc/pb"
 	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -18,6 +19,9 @@ type APIServer struct {
 
 	rpcServer *grpc.Server
 
+	// adminServer serves the admin service apart if admin listen addresses are set.
+	adminServer *grpc.Server
+
 	rpcConfig *nebletpb.RPCConfig
 }
 
@@ -25,13 +29,26 @@ type APIServer struct {
 func NewAPIServer(neblet Neblet) *APIServer {
 	cfg := neblet.Config().Rpc
 
-	rpc := grpc.NewServer()
+	opts, err := serverOptions(cfg, NewQuotaManager(cfg))
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"cert": cfg.TlsCert,
+			"key":  cfg.TlsKey,
+			"err":  err,
+		}).Fatal("Failed to load the TLS certificate of RPC server.")
+	}
+	rpc := grpc.NewServer(opts...)
 
 	srv := &APIServer{neblet: neblet, rpcServer: rpc, rpcConfig: cfg}
-	api := &APIService{srv}
+	api := &APIService{server: srv}
 
 	rpcpb.RegisterApiServiceServer(rpc, api)
-	rpcpb.RegisterAdminServiceServer(rpc, api)
+	if len(cfg.AdminListen) > 0 {
+		srv.adminServer = grpc.NewServer(opts...)
+		rpcpb.RegisterAdminServiceServer(srv.adminServer, api)
+	} else {
+		rpcpb.RegisterAdminServiceServer(rpc, api)
+	}
 	// Register reflection service on gRPC server.
 	// TODO: Enable reflection only for testing mode.
 	reflection.Register(rpc)
@@ -41,9 +58,14 @@ func NewAPIServer(neblet Neblet) *APIServer {
 
 // Start starts the rpc server and serves incoming requests.
 func (s *APIServer) Start() error {
+	if s.adminServer != nil {
+		for _, v := range s.rpcConfig.AdminListen {
+			go s.start(s.adminServer, v)
+		}
+	}
 	if len(s.rpcConfig.RpcListen) > 0 {
 		for _, v := range s.rpcConfig.RpcListen {
-			err := s.start(v)
+			err := s.start(s.rpcServer, v)
 			if err != nil {
 				return errors.New("parse rpc-config rpc-listen occurs error")
 			}
@@ -55,14 +77,14 @@ func (s *APIServer) Start() error {
 	return nil
 }
 
-func (s *APIServer) start(addr string) error {
+func (s *APIServer) start(srv *grpc.Server, addr string) error {
 	logging.VLog().Info("Starting RPC server at: ", addr)
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		logging.VLog().Error("RPC server failed to listen: ", err)
 		return err
 	}
-	if err := s.rpcServer.Serve(listener); err != nil {
+	if err := srv.Serve(listener); err != nil {
 		logging.VLog().Error("RPC server failed to serve: ", err)
 		return err
 	}
@@ -73,11 +95,8 @@ func (s *APIServer) start(addr string) error {
 func (s *APIServer) RunGateway() error {
 	//todo make sure rpc server has run before gateway start.
 	time.Sleep(3 * time.Second)
-	rpcListen := s.rpcConfig.RpcListen[0]
-	gatewayListen := s.rpcConfig.HttpListen
-	httpModule := s.rpcConfig.HttpModule
-	logging.VLog().Info("Starting api gateway server bind rpc-server: ", rpcListen, " to:", gatewayListen)
-	if err := Run(rpcListen, gatewayListen, httpModule); err != nil {
+	logging.VLog().Info("Starting api gateway server bind rpc-server: ", s.rpcConfig.RpcListen[0], " to:", s.rpcConfig.HttpListen)
+	if err := Run(s.rpcConfig); err != nil {
 		logging.VLog().Error("RPC server gateway failed to serve: ", err)
 		return err
 	}
@@ -88,6 +107,9 @@ func (s *APIServer) RunGateway() error {
 func (s *APIServer) Stop() {
 	logging.VLog().Info("Stopping RPC server at: ", s.rpcConfig.RpcListen)
 	s.rpcServer.Stop()
+	if s.adminServer != nil {
+		s.adminServer.Stop()
+	}
 }
 
 // Neblet returns weak reference to Neblet.
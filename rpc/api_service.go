@@ -19,16 +19,22 @@
 package rpc
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/nebulasio/go-nebulas/common/trie"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/account"
 	"github.com/nebulasio/go-nebulas/core"
 	corepb "github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/pbjson"
 	"github.com/nebulasio/go-nebulas/crypto/hash"
 	nnet "github.com/nebulasio/go-nebulas/net"
 	"github.com/nebulasio/go-nebulas/net/p2p"
@@ -38,11 +44,21 @@ import (
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors of state queries
+var (
+	ErrStatePruned = status.Error(codes.FailedPrecondition, "the states of block are pruned, query an archive node")
 )
 
 // APIService implements the RPC API service interface.
 type APIService struct {
 	server Server
+
+	profiler profiler
+	exporter chainExporter
 }
 
 // GetNebState is the RPC API handler.
@@ -61,7 +77,9 @@ func (s *APIService) GetNebState(ctx context.Context, req *rpcpb.NonParamsReques
 	resp.Coinbase = tail.Coinbase().String()
 	resp.Synchronized = neb.NetManager().Node().GetSynchronizing()
 	resp.PeerCount = getStreamCount(neb.NetManager().Node().GetStream())
-	resp.ProtocolVersion = p2p.ProtocolID
+	resp.ProtocolVersion = string(neb.NetManager().Node().ProtocolID())
+	resp.NodeMode = neb.BlockChain().NodeMode()
+	resp.PrunedHeight = neb.BlockChain().PrunedHeight()
 
 	return resp, nil
 }
@@ -83,7 +101,7 @@ func (s *APIService) NodeInfo(ctx context.Context, req *rpcpb.NonParamsRequest)
 	resp.StreamStoreExtendSize = int32(node.Config().StreamStoreExtendSize)
 	resp.RelayCacheSize = int32(node.Config().RelayCacheSize)
 	resp.PeerCount = getStreamCount(node.GetStream())
-	resp.ProtocolVersion = p2p.ProtocolID
+	resp.ProtocolVersion = string(node.ProtocolID())
 	for _, v := range node.PeerStore().Peers() {
 		routeTable := &rpcpb.RouteTable{}
 		routeTable.Id = v.Pretty()
@@ -149,6 +167,7 @@ func (s *APIService) GetAccountState(ctx context.Context, req *rpcpb.GetAccountS
 	logging.VLog().WithFields(logrus.Fields{
 		"address": req.Address,
 		"block":   req.Block,
+		"height":  req.Height,
 		"api":     "/v1/user/accountstate",
 	}).Info("Rpc request.")
 
@@ -159,22 +178,43 @@ func (s *APIService) GetAccountState(ctx context.Context, req *rpcpb.GetAccountS
 		return nil, err
 	}
 
-	block := neb.BlockChain().TailBlock()
-	if len(req.Block) > 0 {
-		blockHash, err := byteutils.FromHex(req.Block)
-		if err != nil {
-			return nil, err
-		}
-		block = neb.BlockChain().GetBlock(blockHash)
-		if block == nil {
-			return nil, errors.New("block hash not found")
-		}
+	block, err := stateBlock(neb.BlockChain(), req.Block, req.Height)
+	if err != nil {
+		return nil, err
 	}
 
 	balance := block.GetBalance(addr.Bytes())
 	nonce := block.GetNonce(addr.Bytes())
 
-	return &rpcpb.GetAccountStateResponse{Balance: balance.String(), Nonce: fmt.Sprintf("%d", nonce)}, nil
+	return &rpcpb.GetAccountStateResponse{
+		Balance:   balance.String(),
+		Nonce:     fmt.Sprintf("%d", nonce),
+		Height:    block.Height(),
+		BlockHash: block.Hash().String(),
+	}, nil
+}
+
+// stateBlock returns the block the states are read at, by the hex hash or else by the height in canonical
+// chain, the tail if neither is given. The block must keep its states in the pruning mode.
+func stateBlock(bc *core.BlockChain, hash string, height uint64) (*core.Block, error) {
+	block := bc.TailBlock()
+	if len(hash) > 0 {
+		blockHash, err := byteutils.FromHex(hash)
+		if err != nil {
+			return nil, err
+		}
+		if block = bc.GetBlock(blockHash); block == nil {
+			return nil, core.ErrBlockNotFound
+		}
+	} else if height > 0 {
+		if block = bc.GetBlockByHeight(height); block == nil {
+			return nil, core.ErrBlockNotFound
+		}
+	}
+	if block.StatesPruned() {
+		return nil, ErrStatePruned
+	}
+	return block, nil
 }
 
 // GetDynasty is the RPC API handler.
@@ -200,6 +240,80 @@ func (s *APIService) GetDynasty(ctx context.Context, req *rpcpb.NonParamsRequest
 	return &rpcpb.GetDynastyResponse{Delegatees: result}, nil
 }
 
+// GetDynastySnapshot is the RPC API handler.
+func (s *APIService) GetDynastySnapshot(ctx context.Context, req *rpcpb.GetDynastySnapshotRequest) (*rpcpb.GetDynastySnapshotResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"height": req.Height,
+		"api":    "/v1/user/dynastySnapshot",
+	}).Info("Rpc request.")
+
+	bc := s.server.Neblet().BlockChain()
+	height := req.Height
+	if height == 0 {
+		height = bc.TailBlock().Height()
+	}
+	snapshot, err := bc.DynastyAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpcpb.GetDynastySnapshotResponse{Height: snapshot.Height, DynastyId: snapshot.DynastyID}
+	for _, v := range snapshot.Validators {
+		resp.Validators = append(resp.Validators, &rpcpb.DynastyValidator{Address: v.Address, MintCount: v.MintCount})
+	}
+	for _, v := range snapshot.Candidates {
+		resp.Candidates = append(resp.Candidates, &rpcpb.DynastyCandidate{Address: v.Address, Votes: v.Votes})
+	}
+	return resp, nil
+}
+
+// GetProof is the RPC API handler.
+func (s *APIService) GetProof(ctx context.Context, req *rpcpb.GetProofRequest) (*rpcpb.GetProofResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"kind":   req.Kind,
+		"key":    req.Key,
+		"height": req.Height,
+		"api":    "/v1/user/proof",
+	}).Info("Rpc request.")
+
+	block, err := stateBlock(s.server.Neblet().BlockChain(), "", req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	if req.Kind == core.ProofAccount {
+		addr, err := core.AddressParse(req.Key)
+		if err != nil {
+			return nil, err
+		}
+		key = addr.Bytes()
+	} else {
+		if key, err = byteutils.FromHex(req.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	root, err := block.ProofRoot(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	value, merkle, err := block.Prove(req.Kind, key)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := trie.NewProof(merkle)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetProofResponse{
+		Height:    block.Height(),
+		BlockHash: block.Hash().String(),
+		Root:      root.String(),
+		Value:     byteutils.Hex(value),
+		Proof:     byteutils.Hex(proof),
+	}, nil
+}
+
 // GetDelegateVoters is the RPC API handler.
 func (s *APIService) GetDelegateVoters(ctx context.Context, req *rpcpb.GetDelegateVotersRequest) (*rpcpb.GetDelegateVotersResponse, error) {
 	logging.VLog().WithFields(logrus.Fields{
@@ -299,9 +413,17 @@ func parseTransaction(neb Neblet, reqTx *rpcpb.TransactionRequest) (*core.Transa
 		payloadType string
 		payload     []byte
 	)
-	if reqTx.Contract != nil && len(reqTx.Contract.Source) > 0 {
+	if reqTx.Contract != nil && len(reqTx.Contract.Source) > 0 && reqTx.Contract.Upgrade {
+		payloadType = core.TxPayloadUpgradeType
+		upgrade := core.NewUpgradePayload(reqTx.Contract.Source, reqTx.Contract.SourceType)
+		upgrade.Metadata = reqTx.Contract.Metadata
+		payload, err = upgrade.ToBytes()
+	} else if reqTx.Contract != nil && len(reqTx.Contract.Source) > 0 {
 		payloadType = core.TxPayloadDeployType
-		payload, err = core.NewDeployPayload(reqTx.Contract.Source, reqTx.Contract.SourceType, reqTx.Contract.Args).ToBytes()
+		deploy := core.NewDeployPayload(reqTx.Contract.Source, reqTx.Contract.SourceType, reqTx.Contract.Args)
+		deploy.Upgradable = reqTx.Contract.Upgradable
+		deploy.Metadata = reqTx.Contract.Metadata
+		payload, err = deploy.ToBytes()
 	} else if reqTx.Contract != nil && len(reqTx.Contract.Function) > 0 {
 		payloadType = core.TxPayloadCallType
 		payload, err = core.NewCallPayload(reqTx.Contract.Function, reqTx.Contract.Args).ToBytes()
@@ -311,6 +433,33 @@ func parseTransaction(neb Neblet, reqTx *rpcpb.TransactionRequest) (*core.Transa
 	} else if reqTx.Delegate != nil {
 		payloadType = core.TxPayloadDelegateType
 		payload, err = core.NewDelegatePayload(reqTx.Delegate.Action, reqTx.Delegate.Delegatee).ToBytes()
+	} else if reqTx.Multisig != nil {
+		payloadType = core.TxPayloadMultisigType
+		payload, err = (&core.MultisigPayload{
+			Action:    reqTx.Multisig.Action,
+			Owners:    reqTx.Multisig.Owners,
+			Threshold: reqTx.Multisig.Threshold,
+			Account:   reqTx.Multisig.Account,
+			To:        reqTx.Multisig.To,
+			Value:     reqTx.Multisig.Value,
+			Proposal:  reqTx.Multisig.Proposal,
+		}).ToBytes()
+	} else if reqTx.Batch != nil {
+		payloadType = core.TxPayloadBatchTransferType
+		outputs := make([]*core.BatchTransferOutput, len(reqTx.Batch.Outputs))
+		for i, v := range reqTx.Batch.Outputs {
+			outputs[i] = &core.BatchTransferOutput{To: v.To, Value: v.Value}
+		}
+		payload, err = core.NewBatchTransferPayload(outputs).ToBytes()
+	} else if reqTx.Slash != nil {
+		payloadType = core.TxPayloadSlashType
+		var evidence []byte
+		if evidence, err = byteutils.FromHex(reqTx.Slash.Evidence); err == nil {
+			payload, err = (&core.SlashPayload{Evidence: evidence}).ToBytes()
+		}
+	} else if reqTx.Oracle != nil {
+		payloadType = core.TxPayloadOracleType
+		payload, err = core.NewOraclePayload(reqTx.Oracle.Key, reqTx.Oracle.Value).ToBytes()
 	} else {
 		payloadType = core.TxPayloadBinaryType
 	}
@@ -319,28 +468,82 @@ func parseTransaction(neb Neblet, reqTx *rpcpb.TransactionRequest) (*core.Transa
 	}
 
 	tx := core.NewTransaction(neb.BlockChain().ChainID(), fromAddr, toAddr, value, reqTx.Nonce, payloadType, payload, gasPrice, gasLimit)
+	tx.SetValidUntil(reqTx.ValidUntil)
 	return tx, nil
 }
 
-// SendRawTransaction submit the signed transaction raw data to txpool
-func (s *APIService) SendRawTransaction(ctx context.Context, req *rpcpb.SendRawTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+// GetNextNonce is the RPC API handler.
+func (s *APIService) GetNextNonce(ctx context.Context, req *rpcpb.GetNextNonceRequest) (*rpcpb.GetNextNonceResponse, error) {
 	logging.VLog().WithFields(logrus.Fields{
-		"api": "/v1/user/rawtransaction",
+		"address": req.Address,
+		"api":     "/v1/user/nonce",
 	}).Info("Rpc request.")
 
-	// Validate and sign the tx, then submit it to the tx pool.
-	neb := s.server.Neblet()
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	nonce, deadline := s.server.Neblet().BlockChain().TransactionPool().NextNonce(addr)
+	return &rpcpb.GetNextNonceResponse{Nonce: nonce, Expires: deadline.Unix()}, nil
+}
 
-	pbTx := new(corepb.Transaction)
-	if err := proto.Unmarshal(req.GetData(), pbTx); err != nil {
+// ReleaseNonce is the RPC API handler.
+func (s *APIService) ReleaseNonce(ctx context.Context, req *rpcpb.ReleaseNonceRequest) (*rpcpb.ReleaseNonceResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"address": req.Address,
+		"nonce":   req.Nonce,
+		"api":     "/v1/user/nonce/release",
+	}).Info("Rpc request.")
+
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
 		return nil, err
 	}
-	tx := new(core.Transaction)
-	if err := tx.FromProto(pbTx); err != nil {
+	if err := s.server.Neblet().BlockChain().TransactionPool().ReleaseNonce(addr, req.Nonce); err != nil {
 		return nil, err
 	}
+	return &rpcpb.ReleaseNonceResponse{Result: true}, nil
+}
 
-	if err := neb.BlockChain().TransactionPool().PushAndBroadcast(tx); err != nil {
+// GetContractMetadata is the RPC API handler.
+func (s *APIService) GetContractMetadata(ctx context.Context, req *rpcpb.GetContractMetadataRequest) (*rpcpb.GetContractMetadataResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"address": req.Address,
+		"block":   req.Block,
+		"height":  req.Height,
+		"api":     "/v1/user/contract/metadata",
+	}).Info("Rpc request.")
+
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	block, err := stateBlock(s.server.Neblet().BlockChain(), req.Block, req.Height)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := block.GetContractMetadata(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.GetContractMetadataResponse{
+		Metadata:  metadata,
+		Height:    block.Height(),
+		BlockHash: block.Hash().String(),
+	}, nil
+}
+
+// SendRawTransaction submit the signed transaction raw data to txpool
+func (s *APIService) SendRawTransaction(ctx context.Context, req *rpcpb.SendRawTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/user/rawtransaction",
+	}).Info("Rpc request.")
+
+	// Validate and sign the tx, then submit it to the tx pool.
+	neb := s.server.Neblet()
+
+	tx, err := neb.BlockChain().TransactionPool().SendRawTransaction(req.GetData())
+	if err != nil {
 		return nil, err
 	}
 
@@ -373,6 +576,53 @@ func (s *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByHa
 	return pbBlock.(*corepb.Block), nil
 }
 
+// GetBlockByHeight is the RPC API handler.
+func (s *APIService) GetBlockByHeight(ctx context.Context, req *rpcpb.GetBlockByHeightRequest) (*corepb.Block, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"height": req.Height,
+		"api":    "/v1/user/getBlockByHeight",
+	}).Info("Rpc request.")
+
+	bc := s.server.Neblet().BlockChain()
+	block := bc.TailBlock()
+	if req.Height > 0 {
+		if block = bc.GetBlockByHeight(req.Height); block == nil {
+			return nil, core.ErrBlockNotFound
+		}
+	}
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return pbBlock.(*corepb.Block), nil
+}
+
+// GetTransactionByHash is the RPC API handler.
+func (s *APIService) GetTransactionByHash(ctx context.Context, req *rpcpb.GetTransactionByHashRequest) (*corepb.Transaction, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"hash": req.Hash,
+		"api":  "/v1/user/getTransactionByHash",
+	}).Info("Rpc request.")
+
+	hash, err := byteutils.FromHex(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	bc := s.server.Neblet().BlockChain()
+	tx := bc.GetTransaction(hash)
+	if tx == nil {
+		tx = bc.TransactionPool().GetTransaction(hash)
+	}
+	if tx == nil {
+		return nil, errors.New("transaction not found")
+	}
+	pbTx, err := tx.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return pbTx.(*corepb.Transaction), nil
+}
+
 // BlockDump is the RPC API handler.
 func (s *APIService) BlockDump(ctx context.Context, req *rpcpb.BlockDumpRequest) (*rpcpb.BlockDumpResponse, error) {
 	logging.VLog().WithFields(logrus.Fields{
@@ -400,17 +650,18 @@ func (s *APIService) GetTransactionReceipt(ctx context.Context, req *rpcpb.GetTr
 	}
 
 	receipt := &rpcpb.TransactionReceiptResponse{
-		ChainId:   tx.ChainID(),
-		Hash:      byteutils.Hex(tx.Hash()),
-		From:      tx.From().String(),
-		To:        tx.To().String(),
-		Value:     tx.Value().String(),
-		Nonce:     tx.Nonce(),
-		Timestamp: tx.Timestamp(),
-		Type:      tx.Type(),
-		Data:      byteutils.Hex(tx.Data()),
-		GasPrice:  tx.GasPrice().String(),
-		GasLimit:  tx.GasLimit().String(),
+		ChainId:    tx.ChainID(),
+		Hash:       byteutils.Hex(tx.Hash()),
+		From:       tx.From().String(),
+		To:         tx.To().String(),
+		Value:      tx.Value().String(),
+		Nonce:      tx.Nonce(),
+		Timestamp:  tx.Timestamp(),
+		Type:       tx.Type(),
+		Data:       byteutils.Hex(tx.Data()),
+		GasPrice:   tx.GasPrice().String(),
+		GasLimit:   tx.GasLimit().String(),
+		ValidUntil: tx.ValidUntil(),
 	}
 	if tx.Type() == core.TxPayloadDeployType {
 		contractAddr, err := tx.GenerateContractAddress()
@@ -419,6 +670,15 @@ func (s *APIService) GetTransactionReceipt(ctx context.Context, req *rpcpb.GetTr
 		}
 		receipt.ContractAddress = contractAddr.String()
 	}
+	if _, block, err := neb.BlockChain().GetTransactionByHash(bhash); err == nil {
+		if r, err := block.GetReceipt(bhash); err == nil {
+			receipt.Status = r.Status()
+			if failure := r.Failure(); failure != nil {
+				receipt.ErrorCode = failure.Code
+				receipt.ErrorMessage = failure.Message
+			}
+		}
+	}
 	return receipt, nil
 }
 
@@ -447,7 +707,7 @@ func (s *APIService) UnlockAccount(ctx context.Context, req *rpcpb.UnlockAccount
 	if err != nil {
 		return nil, err
 	}
-	err = neb.AccountManager().Unlock(addr, []byte(req.Passphrase))
+	err = neb.AccountManager().UnlockWithDuration(addr, []byte(req.Passphrase), time.Duration(req.Duration))
 	if err != nil {
 		return nil, err
 	}
@@ -545,15 +805,23 @@ func (s *APIService) Subscribe(req *rpcpb.SubscribeRequest, gs rpcpb.ApiService_
 	defer net.Deregister(nnet.NewSubscriber(s, netEventCh, core.MessageTypeNewBlock))
 	defer net.Deregister(nnet.NewSubscriber(s, netEventCh, core.MessageTypeNewTx))
 
+	// the events over the quota of consumer are dropped.
+	quota := consumerFromContext(gs.Context())
 	var err error
 	for {
 		select {
 		case event := <-chainEventCh:
+			if quota != nil && !quota.allowEvent() {
+				continue
+			}
 			err = gs.Send(&rpcpb.SubscribeResponse{MsgType: event.Topic, Data: event.Data})
 			if err != nil {
 				return err
 			}
 		case event := <-netEventCh:
+			if quota != nil && !quota.allowEvent() {
+				continue
+			}
 			switch event.MessageType() {
 			case core.MessageTypeNewBlock:
 				block := new(core.Block)
@@ -619,11 +887,15 @@ func (s *APIService) EstimateGas(ctx context.Context, req *rpcpb.TransactionRequ
 	if err != nil {
 		return nil, err
 	}
-	estimateGas, err := neb.BlockChain().EstimateGas(tx)
+	estimate, err := neb.BlockChain().EstimateGasLimit(tx)
 	if err != nil {
 		return nil, err
 	}
-	return &rpcpb.EstimateGasResponse{EstimateGas: estimateGas.String()}, nil
+	resp := &rpcpb.EstimateGasResponse{EstimateGas: estimate.Gas.String()}
+	if estimate.Failure != nil {
+		resp.Err = estimate.Failure.Error()
+	}
+	return resp, nil
 }
 
 // GetEventsByHash return events by tx hash.
@@ -656,6 +928,154 @@ func (s *APIService) GetEventsByHash(ctx context.Context, req *rpcpb.GetTransact
 
 }
 
+// NewEventFilter is the RPC API handler.
+func (s *APIService) NewEventFilter(ctx context.Context, req *rpcpb.NewEventFilterRequest) (*rpcpb.NewEventFilterResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"topics":   req.Topics,
+		"contract": req.Contract,
+		"from":     req.FromHeight,
+		"to":       req.ToHeight,
+		"api":      "/v1/user/filter/new",
+	}).Info("Rpc request.")
+
+	criteria := &core.EventFilterCriteria{Topics: req.Topics, FromHeight: req.FromHeight, ToHeight: req.ToHeight}
+	if len(req.Contract) > 0 {
+		contract, err := core.AddressParse(req.Contract)
+		if err != nil {
+			return nil, err
+		}
+		criteria.Contract = contract
+	}
+	id, err := s.server.Neblet().BlockChain().EventFilters().Install(criteria)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.NewEventFilterResponse{Id: id}, nil
+}
+
+// GetFilterChanges is the RPC API handler.
+func (s *APIService) GetFilterChanges(ctx context.Context, req *rpcpb.EventFilterRequest) (*rpcpb.FilterEventsResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"id":  req.Id,
+		"api": "/v1/user/filter/changes",
+	}).Info("Rpc request.")
+
+	events, err := s.server.Neblet().BlockChain().EventFilters().Changes(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toFilterEventsResponse(events), nil
+}
+
+// GetFilterLogs is the RPC API handler.
+func (s *APIService) GetFilterLogs(ctx context.Context, req *rpcpb.EventFilterRequest) (*rpcpb.FilterEventsResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"id":  req.Id,
+		"api": "/v1/user/filter/logs",
+	}).Info("Rpc request.")
+
+	events, err := s.server.Neblet().BlockChain().EventFilters().Logs(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toFilterEventsResponse(events), nil
+}
+
+// UninstallEventFilter is the RPC API handler.
+func (s *APIService) UninstallEventFilter(ctx context.Context, req *rpcpb.EventFilterRequest) (*rpcpb.UninstallEventFilterResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"id":  req.Id,
+		"api": "/v1/user/filter/uninstall",
+	}).Info("Rpc request.")
+
+	if err := s.server.Neblet().BlockChain().EventFilters().Uninstall(req.Id); err != nil {
+		return nil, err
+	}
+	return &rpcpb.UninstallEventFilterResponse{Result: true}, nil
+}
+
+func toFilterEventsResponse(events []*core.StoredEvent) *rpcpb.FilterEventsResponse {
+	resp := &rpcpb.FilterEventsResponse{}
+	for _, v := range events {
+		event := &rpcpb.StoredEvent{
+			Height: v.Height,
+			TxHash: v.TxHash.String(),
+			Topic:  v.Event.Topic,
+			Data:   v.Event.Data,
+		}
+		if v.Contract != nil {
+			event.Contract = v.Contract.String()
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	return resp
+}
+
+// GetExecutionEnvironment returns the execution environment of the node, validators compare the fingerprints.
+func (s *APIService) GetExecutionEnvironment(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ExecutionEnvironmentResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/user/environment",
+	}).Info("Rpc request.")
+
+	env := core.CurrentExecutionEnvironment()
+	return &rpcpb.ExecutionEnvironmentResponse{
+		GoVersion:   env.GoVersion,
+		Os:          env.OS,
+		Arch:        env.Arch,
+		V8Version:   env.V8Version,
+		TimeZone:    env.TimeZone,
+		ZoneOffset:  int32(env.ZoneOffset),
+		FloatDigest: env.FloatDigest,
+		Fingerprint: env.Fingerprint(),
+		Problems:    env.Check(),
+	}, nil
+}
+
+// GetSyncStatus returns the progress of syncing with peers.
+func (s *APIService) GetSyncStatus(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.SyncStatusResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/user/syncStatus",
+	}).Info("Rpc request.")
+
+	status := s.server.Neblet().SyncManager().Status()
+	return &rpcpb.SyncStatusResponse{
+		Synchronizing: status.Synchronizing,
+		StartHeight:   status.StartHeight,
+		CurrentHeight: status.CurrentHeight,
+		HighestHeight: status.HighestHeight,
+		Rate:          status.Rate,
+		Eta:           int64(status.ETA.Seconds()),
+	}, nil
+}
+
+// ProtoToJSON converts the chain data from protobuf to JSON.
+func (s *APIService) ProtoToJSON(ctx context.Context, req *rpcpb.ConvertRequest) (*rpcpb.ConvertResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api":  "/v1/user/protoToJson",
+		"type": req.Type,
+	}).Info("Rpc request.")
+
+	data, err := pbjson.ProtoToJSON(req.Type, req.Proto)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.ConvertResponse{Version: pbjson.Version, Proto: req.Proto, Json: string(data)}, nil
+}
+
+// JSONToProto converts the chain data from JSON to protobuf.
+func (s *APIService) JSONToProto(ctx context.Context, req *rpcpb.ConvertRequest) (*rpcpb.ConvertResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api":  "/v1/user/jsonToProto",
+		"type": req.Type,
+	}).Info("Rpc request.")
+
+	data, err := pbjson.JSONToProto(req.Type, []byte(req.Json))
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.ConvertResponse{Version: pbjson.Version, Proto: data, Json: req.Json}, nil
+}
+
 // ChangeNetworkID change the network id
 func (s *APIService) ChangeNetworkID(ctx context.Context, req *rpcpb.ChangeNetworkIDRequest) (*rpcpb.ChangeNetworkIDResponse, error) {
 	logging.VLog().WithFields(logrus.Fields{
@@ -668,3 +1088,275 @@ func (s *APIService) ChangeNetworkID(ctx context.Context, req *rpcpb.ChangeNetwo
 	neb.NetManager().BroadcastNetworkID(byteutils.FromUint32(req.NetworkId))
 	return &rpcpb.ChangeNetworkIDResponse{Result: true}, nil
 }
+
+// InspectTransactionPool is the RPC API handler.
+func (s *APIService) InspectTransactionPool(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.InspectTransactionPoolResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/admin/txpool/inspect",
+	}).Info("Rpc request.")
+
+	resp := &rpcpb.InspectTransactionPoolResponse{}
+	for _, v := range s.server.Neblet().BlockChain().TransactionPool().Inspect() {
+		resp.Senders = append(resp.Senders, &rpcpb.TxPoolSender{
+			Address:    v.Address.String(),
+			Count:      uint32(v.Count),
+			Queued:     uint32(v.Queued),
+			MinNonce:   v.MinNonce,
+			MaxNonce:   v.MaxNonce,
+			TotalValue: v.TotalValue.String(),
+			TotalFees:  v.TotalFees.String(),
+		})
+	}
+	return resp, nil
+}
+
+// DropTransaction is the RPC API handler.
+func (s *APIService) DropTransaction(ctx context.Context, req *rpcpb.GetTransactionByHashRequest) (*rpcpb.DropTransactionResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"hash": req.Hash,
+		"api":  "/v1/admin/txpool/drop",
+	}).Info("Rpc request.")
+
+	hash, err := byteutils.FromHex(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.server.Neblet().BlockChain().TransactionPool().Drop(hash); err != nil {
+		return nil, err
+	}
+	return &rpcpb.DropTransactionResponse{Result: true}, nil
+}
+
+// SetAccountPolicy is the RPC API handler.
+func (s *APIService) SetAccountPolicy(ctx context.Context, req *rpcpb.SetAccountPolicyRequest) (*rpcpb.SetAccountPolicyResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"address": req.Address,
+		"api":     "/v1/admin/account/policy",
+	}).Info("Rpc request.")
+
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	policy := &account.AccountPolicy{UnlockDuration: time.Duration(req.UnlockDuration)}
+	if len(req.SpendLimit) > 0 {
+		limit, ok := util.NewUint128().FromString(req.SpendLimit)
+		if !ok {
+			return nil, account.ErrInvalidSpendLimit
+		}
+		policy.SpendLimit = limit
+	}
+	if err := s.server.Neblet().AccountManager().SetPolicy(addr, policy); err != nil {
+		return nil, err
+	}
+	return &rpcpb.SetAccountPolicyResponse{Result: true}, nil
+}
+
+// GetSigningAudit is the RPC API handler.
+func (s *APIService) GetSigningAudit(ctx context.Context, req *rpcpb.GetSigningAuditRequest) (*rpcpb.GetSigningAuditResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"address": req.Address,
+		"api":     "/v1/admin/account/audit",
+	}).Info("Rpc request.")
+
+	var addr *core.Address
+	if len(req.Address) > 0 {
+		var err error
+		if addr, err = core.AddressParse(req.Address); err != nil {
+			return nil, err
+		}
+	}
+	resp := &rpcpb.GetSigningAuditResponse{}
+	for _, record := range s.server.Neblet().AccountManager().SigningAudit(addr, int(req.Limit)) {
+		resp.Records = append(resp.Records, &rpcpb.SigningRecord{
+			Timestamp: record.Time,
+			Address:   record.Address,
+			Kind:      record.Kind,
+			Hash:      record.Hash,
+			Value:     record.Value,
+			Error:     record.Err,
+		})
+	}
+	return resp, nil
+}
+
+// GetPeers is the RPC API handler.
+func (s *APIService) GetPeers(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.GetPeersResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/admin/peers",
+	}).Info("Rpc request.")
+
+	resp := &rpcpb.GetPeersResponse{}
+	for _, v := range s.server.Neblet().NetManager().Peers() {
+		resp.Peers = append(resp.Peers, &rpcpb.PeerInfo{Id: v.ID, Addrs: v.Addrs, Score: int32(v.Score)})
+	}
+	return resp, nil
+}
+
+// BanPeer is the RPC API handler.
+func (s *APIService) BanPeer(ctx context.Context, req *rpcpb.BanPeerRequest) (*rpcpb.BanPeerResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"id":       req.Id,
+		"duration": req.Duration,
+		"api":      "/v1/admin/peers/ban",
+	}).Info("Rpc request.")
+
+	duration := p2p.PeerBanDuration
+	if req.Duration > 0 {
+		duration = time.Duration(req.Duration) * time.Second
+	}
+	if err := s.server.Neblet().NetManager().BanPeer(req.Id, duration); err != nil {
+		return nil, err
+	}
+	return &rpcpb.BanPeerResponse{Result: true}, nil
+}
+
+// UnbanPeer is the RPC API handler.
+func (s *APIService) UnbanPeer(ctx context.Context, req *rpcpb.UnbanPeerRequest) (*rpcpb.BanPeerResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"id":  req.Id,
+		"api": "/v1/admin/peers/unban",
+	}).Info("Rpc request.")
+
+	if err := s.server.Neblet().NetManager().UnbanPeer(req.Id); err != nil {
+		return nil, err
+	}
+	return &rpcpb.BanPeerResponse{Result: true}, nil
+}
+
+// ExportPeers is the RPC API handler.
+func (s *APIService) ExportPeers(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ExportPeersResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/admin/peers/export",
+	}).Info("Rpc request.")
+
+	var buf bytes.Buffer
+	if err := s.server.Neblet().NetManager().ExportPeers(&buf); err != nil {
+		return nil, err
+	}
+	return &rpcpb.ExportPeersResponse{Peers: buf.String()}, nil
+}
+
+// ImportPeers is the RPC API handler.
+func (s *APIService) ImportPeers(ctx context.Context, req *rpcpb.ImportPeersRequest) (*rpcpb.ImportPeersResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/admin/peers/import",
+	}).Info("Rpc request.")
+
+	if len(req.Peers) == 0 {
+		return nil, errors.New("peers is required")
+	}
+	count, err := s.server.Neblet().NetManager().ImportPeers(strings.NewReader(req.Peers))
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.ImportPeersResponse{Count: uint32(count)}, nil
+}
+
+// GetBootNodes is the RPC API handler.
+func (s *APIService) GetBootNodes(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.GetBootNodesResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"api": "/v1/admin/bootNodes",
+	}).Info("Rpc request.")
+
+	resp := &rpcpb.GetBootNodesResponse{}
+	for _, v := range s.server.Neblet().NetManager().BootNodes() {
+		info := &rpcpb.BootNodeInfo{
+			Addr:      v.Addr,
+			Healthy:   v.Healthy,
+			Failures:  int32(v.Failures),
+			LastError: v.LastError,
+		}
+		if !v.LastSuccess.IsZero() {
+			info.LastSuccess = v.LastSuccess.Unix()
+		}
+		if !v.NextRetry.IsZero() {
+			info.NextRetry = v.NextRetry.Unix()
+		}
+		resp.BootNodes = append(resp.BootNodes, info)
+	}
+	return resp, nil
+}
+
+// AddBootNode is the RPC API handler.
+func (s *APIService) AddBootNode(ctx context.Context, req *rpcpb.BootNodeRequest) (*rpcpb.BootNodeResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"addr": req.Addr,
+		"api":  "/v1/admin/bootNodes/add",
+	}).Info("Rpc request.")
+
+	if err := s.server.Neblet().NetManager().AddBootNode(req.Addr); err != nil {
+		return nil, err
+	}
+	return &rpcpb.BootNodeResponse{Result: true}, nil
+}
+
+// RemoveBootNode is the RPC API handler.
+func (s *APIService) RemoveBootNode(ctx context.Context, req *rpcpb.BootNodeRequest) (*rpcpb.BootNodeResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"addr": req.Addr,
+		"api":  "/v1/admin/bootNodes/remove",
+	}).Info("Rpc request.")
+
+	if err := s.server.Neblet().NetManager().RemoveBootNode(req.Addr); err != nil {
+		return nil, err
+	}
+	return &rpcpb.BootNodeResponse{Result: true}, nil
+}
+
+// SetLogLevel is the RPC API handler.
+func (s *APIService) SetLogLevel(ctx context.Context, req *rpcpb.SetLogLevelRequest) (*rpcpb.SetLogLevelResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"level": req.Level,
+		"api":   "/v1/admin/logLevel",
+	}).Info("Rpc request.")
+
+	if err := logging.SetLevel(req.Level); err != nil {
+		return nil, err
+	}
+	return &rpcpb.SetLogLevelResponse{Result: true}, nil
+}
+
+// ExportChain is the RPC API handler.
+func (s *APIService) ExportChain(ctx context.Context, req *rpcpb.ExportChainRequest) (*rpcpb.ExportChainResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"file": req.File,
+		"from": req.From,
+		"to":   req.To,
+		"api":  "/v1/admin/chain/export",
+	}).Info("Rpc request.")
+
+	if len(req.File) == 0 {
+		return nil, errors.New("file is required")
+	}
+	neb := s.server.Neblet()
+	dir := filepath.Join(neb.Config().Chain.Datadir, ChainExportDir)
+	if err := s.exporter.export(neb.BlockChain(), dir, req.File, req.From, req.To); err != nil {
+		return nil, err
+	}
+	return &rpcpb.ExportChainResponse{Result: true}, nil
+}
+
+// SetProfiling is the RPC API handler.
+func (s *APIService) SetProfiling(ctx context.Context, req *rpcpb.SetProfilingRequest) (*rpcpb.SetProfilingResponse, error) {
+	logging.VLog().WithFields(logrus.Fields{
+		"enable": req.Enable,
+		"listen": req.Listen,
+		"api":    "/v1/admin/pprof",
+	}).Info("Rpc request.")
+
+	if !req.Enable {
+		if err := s.profiler.stop(); err != nil {
+			return nil, err
+		}
+		return &rpcpb.SetProfilingResponse{}, nil
+	}
+	listen := req.Listen
+	if len(listen) == 0 {
+		listen = DefaultProfilingListen
+	}
+	if err := s.profiler.start(listen); err != nil {
+		return nil, err
+	}
+	return &rpcpb.SetProfilingResponse{Listen: listen}, nil
+}
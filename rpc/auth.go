@@ -0,0 +1,200 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthMetadata is the metadata key of the auth token in gRPC, it's header "Authorization" in HTTP.
+const AuthMetadata = "authorization"
+
+const bearerPrefix = "Bearer "
+
+// Errors of auth
+var (
+	ErrAuthTokenRequired   = status.Error(codes.Unauthenticated, "auth token required")
+	ErrInvalidAuthToken    = status.Error(codes.Unauthenticated, "invalid auth token")
+	ErrInvalidTLSCert      = errors.New("invalid tls certificate")
+	ErrUnexpectedServerTLS = errors.New("unexpected tls certificate of rpc server")
+)
+
+// TokenAuth checks the auth token of the requests, every request is allowed if the token is empty.
+type TokenAuth struct {
+	token []byte
+}
+
+// NewTokenAuth returns the auth of the token.
+func NewTokenAuth(token string) *TokenAuth {
+	return &TokenAuth{token: []byte(token)}
+}
+
+func (a *TokenAuth) check(ctx context.Context) error {
+	if len(a.token) == 0 {
+		return nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[AuthMetadata]) == 0 {
+		return ErrAuthTokenRequired
+	}
+	token := md[AuthMetadata][0]
+	if !strings.HasPrefix(token, bearerPrefix) {
+		return ErrInvalidAuthToken
+	}
+	if subtle.ConstantTimeCompare([]byte(token[len(bearerPrefix):]), a.token) != 1 {
+		return ErrInvalidAuthToken
+	}
+	return nil
+}
+
+// UnaryInterceptor checks the auth token before the handler.
+func (a *TokenAuth) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor checks the auth token before the handler.
+func (a *TokenAuth) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.check(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// tokenCredentials passes the auth token in the metadata of every request, over TLS only.
+type tokenCredentials struct {
+	token string
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{AuthMetadata: bearerPrefix + c.token}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// tlsEnabled returns whether the config serves TLS.
+func tlsEnabled(cfg *nebletpb.RPCConfig) bool {
+	return cfg.TlsCert != "" && cfg.TlsKey != ""
+}
+
+// serverOptions returns the options of the gRPC server of the config, TLS and the interceptors of the
+// auth token, the admin guard and quotas.
+func serverOptions(cfg *nebletpb.RPCConfig, quota *QuotaManager) ([]grpc.ServerOption, error) {
+	auth := NewTokenAuth(cfg.AuthToken)
+	admin := NewAdminGuard(cfg.AdminToken)
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(chainUnaryInterceptors(auth.UnaryInterceptor(), admin.UnaryInterceptor(), quota.UnaryInterceptor())),
+		grpc.StreamInterceptor(chainStreamInterceptors(auth.StreamInterceptor(), admin.StreamInterceptor(), quota.StreamInterceptor())),
+	}
+	if tlsEnabled(cfg) {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TlsCert, cfg.TlsKey)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	return opts, nil
+}
+
+// chainUnaryInterceptors composes the interceptors into one, as a server takes only one, the first is the
+// outermost.
+func chainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+// chainStreamInterceptors composes the interceptors into one as chainUnaryInterceptors.
+func chainStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return chained(srv, ss)
+	}
+}
+
+// gatewayDialOptions returns the options of the gateway to dial the gRPC server of the config. The server
+// is on the same node, it's trusted by its certificate in config rather than the host of the listen address.
+func gatewayDialOptions(cfg *nebletpb.RPCConfig) ([]grpc.DialOption, error) {
+	if !tlsEnabled(cfg) {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+	cert, err := readCertificate(cfg.TlsCert)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], cert) {
+				return ErrUnexpectedServerTLS
+			}
+			return nil
+		},
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}, nil
+}
+
+// readCertificate returns the DER bytes of the first certificate in the PEM file.
+func readCertificate(file string) ([]byte, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, ErrInvalidTLSCert
+	}
+	return block.Bytes, nil
+}
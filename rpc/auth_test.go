@@ -0,0 +1,184 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenAuth(t *testing.T) {
+	withAuth := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthMetadata, token))
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/GetNebState"}
+
+	unary := NewTokenAuth("secret").UnaryInterceptor()
+	_, err := unary(context.Background(), nil, info, handler)
+	assert.Equal(t, ErrAuthTokenRequired, err)
+	_, err = unary(withAuth("secret"), nil, info, handler)
+	assert.Equal(t, ErrInvalidAuthToken, err)
+	_, err = unary(withAuth("Bearer wrong"), nil, info, handler)
+	assert.Equal(t, ErrInvalidAuthToken, err)
+	got, err := unary(withAuth("Bearer secret"), nil, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", got)
+
+	// every request is allowed without a token in config.
+	_, err = NewTokenAuth("").UnaryInterceptor()(context.Background(), nil, info, handler)
+	assert.Nil(t, err)
+}
+
+// writeCertificate writes a self-signed certificate of 127.0.0.1 and its key into the dir.
+func writeCertificate(t *testing.T, dir, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	assert.Nil(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.Nil(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func TestServerOptions_TLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpctls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir, "node")
+	otherCert, _ := writeCertificate(t, dir, "other")
+
+	cfg := &nebletpb.RPCConfig{TlsCert: certFile, TlsKey: keyFile, AuthToken: "secret"}
+	opts, err := serverOptions(cfg, NewQuotaManager(cfg))
+	assert.Nil(t, err)
+	srv := grpc.NewServer(opts...)
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	go srv.Serve(listener)
+	defer srv.Stop()
+	addr := listener.Addr().String()
+
+	check := func(conn *grpc.ClientConn, err error) codes.Code {
+		assert.Nil(t, err)
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return status.Code(err)
+	}
+
+	// a client trusting the certificate passes with the token only.
+	assert.Equal(t, codes.OK, check(DialSecure(addr, certFile, "secret")))
+	assert.Equal(t, codes.Unauthenticated, check(DialSecure(addr, certFile, "")))
+	assert.Equal(t, codes.Unauthenticated, check(DialSecure(addr, certFile, "wrong")))
+	assert.Equal(t, codes.Unavailable, check(DialSecure(addr, otherCert, "secret")))
+	assert.Equal(t, codes.Unavailable, check(Dial(addr)))
+
+	// the gateway trusts the certificate in config only.
+	dialOpts, err := gatewayDialOptions(cfg)
+	assert.Nil(t, err)
+	token := grpc.WithPerRPCCredentials(&tokenCredentials{token: "secret"})
+	assert.Equal(t, codes.OK, check(grpc.Dial(addr, append(dialOpts, token)...)))
+	dialOpts, err = gatewayDialOptions(&nebletpb.RPCConfig{TlsCert: otherCert, TlsKey: keyFile})
+	assert.Nil(t, err)
+	assert.Equal(t, codes.Unavailable, check(grpc.Dial(addr, append(dialOpts, token)...)))
+}
+
+func TestChainInterceptors(t *testing.T) {
+	var calls []string
+	unary := func(name string, err error) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	// the interceptors run in order before the handler.
+	resp, err := chainUnaryInterceptors(unary("auth", nil), unary("admin", nil), unary("quota", nil))(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "req", resp)
+	assert.Equal(t, []string{"auth", "admin", "quota", "handler"}, calls)
+
+	// a rejecting interceptor stops the rest.
+	calls = nil
+	_, err = chainUnaryInterceptors(unary("auth", ErrInvalidAuthToken), unary("quota", nil))(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, ErrInvalidAuthToken, err)
+	assert.Equal(t, []string{"auth"}, calls)
+
+	calls = nil
+	stream := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, name)
+			return handler(srv, ss)
+		}
+	}
+	err = chainStreamInterceptors(stream("auth"), stream("quota"))(nil, nil, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		calls = append(calls, "handler")
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"auth", "quota", "handler"}, calls)
+}
@@ -3,14 +3,39 @@ package rpc
 import (
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // Dial returns a client connection.
 func Dial(target string) (*grpc.ClientConn, error) {
-	// TODO: support secure connection.
 	conn, err := grpc.Dial(target, grpc.WithInsecure())
 	if err != nil {
 		logging.CLog().Error("rpc.Dial() failed: ", err)
 	}
 	return conn, err
 }
+
+// DialSecure returns a client connection in TLS trusting the CA certificate file, the system roots if
+// it's empty, and passing the auth token in every request if it's not empty.
+func DialSecure(target, caFile, token string) (*grpc.ClientConn, error) {
+	var (
+		creds credentials.TransportCredentials
+		err   error
+	)
+	if caFile != "" {
+		if creds, err = credentials.NewClientTLSFromFile(caFile, ""); err != nil {
+			return nil, err
+		}
+	} else {
+		creds = credentials.NewTLS(nil)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(&tokenCredentials{token: token}))
+	}
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		logging.CLog().Error("rpc.DialSecure() failed: ", err)
+	}
+	return conn, err
+}
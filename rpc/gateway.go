@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/rpc/pb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -17,26 +18,46 @@ const (
 	Admin = "admin"
 )
 
-// Run start gateway proxy to mapping grpc to http.
-func Run(rpcListen string, gatewayListen []string, httpModule []string) error {
+// Run start gateway proxy to mapping grpc to http, in TLS if the config serves it.
+func Run(cfg *nebletpb.RPCConfig) error {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithInsecure()}
-	echoEndpoint := flag.String("rpc", rpcListen, "")
-	for _, v := range httpModule {
+	opts, err := gatewayDialOptions(cfg)
+	if err != nil {
+		return err
+	}
+	echoEndpoint := flag.String("rpc", cfg.RpcListen[0], "")
+	var ws http.Handler
+	for _, v := range cfg.HttpModule {
 		switch v {
 		case API:
 			rpcpb.RegisterApiServiceHandlerFromEndpoint(ctx, mux, *echoEndpoint, opts)
+
+			// the WebSocket endpoint bridges the event subscriptions of the API.
+			conn, err := grpc.Dial(*echoEndpoint, opts...)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			ws = newWebSocketHandler(rpcpb.NewApiServiceClient(conn))
 		case Admin:
-			rpcpb.RegisterAdminServiceHandlerFromEndpoint(ctx, mux, *echoEndpoint, opts)
+			rpcpb.RegisterAdminServiceHandlerFromEndpoint(ctx, mux, adminEndpoint(cfg), opts)
 		}
 	}
 
-	for _, v := range gatewayListen {
-		err := http.ListenAndServe(v, allowCORS(mux))
+	handler := allowCORS(forwardAPIKey(guardAdmin(cfg, mux)))
+	if ws != nil {
+		handler = serveWebSocket(ws, handler)
+	}
+	for _, v := range cfg.HttpListen {
+		if tlsEnabled(cfg) {
+			err = http.ListenAndServeTLS(v, cfg.TlsCert, cfg.TlsKey, handler)
+		} else {
+			err = http.ListenAndServe(v, handler)
+		}
 		if err != nil {
 			return err
 		}
@@ -59,7 +80,7 @@ func allowCORS(h http.Handler) http.Handler {
 }
 
 func preflightHandler(w http.ResponseWriter, r *http.Request) {
-	headers := []string{"Content-Type", "Accept"}
+	headers := []string{"Content-Type", "Accept", "X-Api-Key", "Authorization", "X-Admin-Token"}
 	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ","))
 	methods := []string{"GET", "HEAD", "POST", "PUT", "DELETE"}
 	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ","))
@@ -20,16 +20,34 @@ It has these top-level messages:
 	AccountsResponse
 	GetAccountStateRequest
 	GetAccountStateResponse
+	GetContractMetadataRequest
+	GetContractMetadataResponse
 	GetDynastyResponse
+	GetDynastySnapshotRequest
+	GetDynastySnapshotResponse
+	DynastyValidator
+	DynastyCandidate
+	GetNextNonceRequest
+	GetNextNonceResponse
+	ReleaseNonceRequest
+	ReleaseNonceResponse
+	GetProofRequest
+	GetProofResponse
 	GetDelegateVotersRequest
 	GetDelegateVotersResponse
 	TransactionRequest
 	ContractRequest
 	CandidateRequest
 	DelegateRequest
+	MultisigRequest
+	BatchTransferRequest
+	BatchTransferOutput
+	SlashRequest
+	OracleRequest
 	SendRawTransactionRequest
 	SendTransactionResponse
 	GetBlockByHashRequest
+	GetBlockByHeightRequest
 	GetTransactionByHashRequest
 	BlockDumpRequest
 	BlockDumpResponse
@@ -47,6 +65,42 @@ It has these top-level messages:
 	EstimateGasResponse
 	EventsResponse
 	Event
+	NewEventFilterRequest
+	NewEventFilterResponse
+	EventFilterRequest
+	StoredEvent
+	FilterEventsResponse
+	UninstallEventFilterResponse
+	SyncStatusResponse
+	ExecutionEnvironmentResponse
+	ConvertRequest
+	ConvertResponse
+	TxPoolSender
+	InspectTransactionPoolResponse
+	DropTransactionResponse
+	SetAccountPolicyRequest
+	SetAccountPolicyResponse
+	GetSigningAuditRequest
+	SigningRecord
+	GetSigningAuditResponse
+	PeerInfo
+	GetPeersResponse
+	BanPeerRequest
+	UnbanPeerRequest
+	BanPeerResponse
+	ExportPeersResponse
+	ImportPeersRequest
+	ImportPeersResponse
+	BootNodeInfo
+	GetBootNodesResponse
+	BootNodeRequest
+	BootNodeResponse
+	SetLogLevelRequest
+	SetLogLevelResponse
+	ExportChainRequest
+	ExportChainResponse
+	SetProfilingRequest
+	SetProfilingResponse
 */
 package rpcpb
 
@@ -344,6 +398,10 @@ type GetNebStateResponse struct {
 	ProtocolVersion string `protobuf:"bytes,6,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
 	// The peer sync status.
 	Synchronized bool `protobuf:"varint,7,opt,name=synchronized,proto3" json:"synchronized,omitempty"`
+	// The node mode, "archive" keeps all the historical states, "full" keeps the recent states only.
+	NodeMode string `protobuf:"bytes,8,opt,name=node_mode,json=nodeMode,proto3" json:"node_mode,omitempty"`
+	// The states of blocks below the height are pruned, except the checkpoints.
+	PrunedHeight uint64 `protobuf:"varint,9,opt,name=pruned_height,json=prunedHeight,proto3" json:"pruned_height,omitempty"`
 }
 
 func (m *GetNebStateResponse) Reset()                    { *m = GetNebStateResponse{} }
@@ -400,6 +458,20 @@ func (m *GetNebStateResponse) GetSynchronized() bool {
 	return false
 }
 
+func (m *GetNebStateResponse) GetNodeMode() string {
+	if m != nil {
+		return m.NodeMode
+	}
+	return ""
+}
+
+func (m *GetNebStateResponse) GetPrunedHeight() uint64 {
+	if m != nil {
+		return m.PrunedHeight
+	}
+	return 0
+}
+
 // Response message of Accounts rpc.
 type AccountsResponse struct {
 	// Account list
@@ -422,8 +494,10 @@ func (m *AccountsResponse) GetAddresses() []string {
 type GetAccountStateRequest struct {
 	// Hex string of the account addresss.
 	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	// Hex string block number, or one of "latest", "earliest" or "pending". If not specified, use "latest".
+	// Hex string of the block hash the state is read at. If not specified, use the height.
 	Block string `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	// the height of block in canonical chain the state is read at, 0 for the tail.
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
 }
 
 func (m *GetAccountStateRequest) Reset()                    { *m = GetAccountStateRequest{} }
@@ -445,12 +519,22 @@ func (m *GetAccountStateRequest) GetBlock() string {
 	return ""
 }
 
+func (m *GetAccountStateRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 // Response message of GetAccountState rpc.
 type GetAccountStateResponse struct {
 	// Current balance in unit of 1/(10^18) nas.
 	Balance string `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
 	// Current transaction count.
 	Nonce string `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// the height and hash of block the state is read at.
+	Height    uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash string `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
 }
 
 func (m *GetAccountStateResponse) Reset()                    { *m = GetAccountStateResponse{} }
@@ -472,6 +556,95 @@ func (m *GetAccountStateResponse) GetNonce() string {
 	return ""
 }
 
+func (m *GetAccountStateResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetAccountStateResponse) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+// Request message of GetContractMetadata rpc.
+type GetContractMetadataRequest struct {
+	// Hex string of the contract address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex string of the block hash the metadata is read at. If not specified, use the height.
+	Block string `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	// the height of block in canonical chain the metadata is read at, 0 for the tail.
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetContractMetadataRequest) Reset()         { *m = GetContractMetadataRequest{} }
+func (m *GetContractMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*GetContractMetadataRequest) ProtoMessage()    {}
+func (*GetContractMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiRpc, []int{12}
+}
+
+func (m *GetContractMetadataRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetContractMetadataRequest) GetBlock() string {
+	if m != nil {
+		return m.Block
+	}
+	return ""
+}
+
+func (m *GetContractMetadataRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// Response message of GetContractMetadata rpc.
+type GetContractMetadataResponse struct {
+	// JSON object of the ABI and metadata of the contract.
+	Metadata string `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// the height and hash of block the metadata is read at.
+	Height    uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash string `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+}
+
+func (m *GetContractMetadataResponse) Reset()         { *m = GetContractMetadataResponse{} }
+func (m *GetContractMetadataResponse) String() string { return proto.CompactTextString(m) }
+func (*GetContractMetadataResponse) ProtoMessage()    {}
+func (*GetContractMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiRpc, []int{13}
+}
+
+func (m *GetContractMetadataResponse) GetMetadata() string {
+	if m != nil {
+		return m.Metadata
+	}
+	return ""
+}
+
+func (m *GetContractMetadataResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetContractMetadataResponse) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
 // Response message of GetDynastyRequest rpc
 type GetDynastyResponse struct {
 	Delegatees []string `protobuf:"bytes,1,rep,name=delegatees" json:"delegatees,omitempty"`
@@ -480,7 +653,7 @@ type GetDynastyResponse struct {
 func (m *GetDynastyResponse) Reset()                    { *m = GetDynastyResponse{} }
 func (m *GetDynastyResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetDynastyResponse) ProtoMessage()               {}
-func (*GetDynastyResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{12} }
+func (*GetDynastyResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{14} }
 
 func (m *GetDynastyResponse) GetDelegatees() []string {
 	if m != nil {
@@ -489,6 +662,292 @@ func (m *GetDynastyResponse) GetDelegatees() []string {
 	return nil
 }
 
+// Request message of GetDynastySnapshot rpc.
+type GetDynastySnapshotRequest struct {
+	// the height of block in canonical chain, 0 for the tail.
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetDynastySnapshotRequest) Reset()                    { *m = GetDynastySnapshotRequest{} }
+func (m *GetDynastySnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDynastySnapshotRequest) ProtoMessage()               {}
+func (*GetDynastySnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{15} }
+
+func (m *GetDynastySnapshotRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// Response message of GetDynastySnapshot rpc.
+type GetDynastySnapshotResponse struct {
+	Height    uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	DynastyId int64  `protobuf:"varint,2,opt,name=dynasty_id,json=dynastyId,proto3" json:"dynasty_id,omitempty"`
+	// the validators of the dynasty, with the count of blocks each minted in it.
+	Validators []*DynastyValidator `protobuf:"bytes,3,rep,name=validators" json:"validators,omitempty"`
+	// the candidates sorted by the votes tallied from the balances of their delegators.
+	Candidates []*DynastyCandidate `protobuf:"bytes,4,rep,name=candidates" json:"candidates,omitempty"`
+}
+
+func (m *GetDynastySnapshotResponse) Reset()         { *m = GetDynastySnapshotResponse{} }
+func (m *GetDynastySnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDynastySnapshotResponse) ProtoMessage()    {}
+func (*GetDynastySnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiRpc, []int{16}
+}
+
+func (m *GetDynastySnapshotResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetDynastySnapshotResponse) GetDynastyId() int64 {
+	if m != nil {
+		return m.DynastyId
+	}
+	return 0
+}
+
+func (m *GetDynastySnapshotResponse) GetValidators() []*DynastyValidator {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+func (m *GetDynastySnapshotResponse) GetCandidates() []*DynastyCandidate {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+type DynastyValidator struct {
+	Address   string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	MintCount int64  `protobuf:"varint,2,opt,name=mint_count,json=mintCount,proto3" json:"mint_count,omitempty"`
+}
+
+func (m *DynastyValidator) Reset()                    { *m = DynastyValidator{} }
+func (m *DynastyValidator) String() string            { return proto.CompactTextString(m) }
+func (*DynastyValidator) ProtoMessage()               {}
+func (*DynastyValidator) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{17} }
+
+func (m *DynastyValidator) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *DynastyValidator) GetMintCount() int64 {
+	if m != nil {
+		return m.MintCount
+	}
+	return 0
+}
+
+type DynastyCandidate struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Votes   string `protobuf:"bytes,2,opt,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (m *DynastyCandidate) Reset()                    { *m = DynastyCandidate{} }
+func (m *DynastyCandidate) String() string            { return proto.CompactTextString(m) }
+func (*DynastyCandidate) ProtoMessage()               {}
+func (*DynastyCandidate) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{18} }
+
+func (m *DynastyCandidate) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *DynastyCandidate) GetVotes() string {
+	if m != nil {
+		return m.Votes
+	}
+	return ""
+}
+
+// Request message of GetNextNonce rpc.
+type GetNextNonceRequest struct {
+	// Hex string of the account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *GetNextNonceRequest) Reset()                    { *m = GetNextNonceRequest{} }
+func (m *GetNextNonceRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNextNonceRequest) ProtoMessage()               {}
+func (*GetNextNonceRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{19} }
+
+func (m *GetNextNonceRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+// Response message of GetNextNonce rpc.
+type GetNextNonceResponse struct {
+	Nonce uint64 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// the unix time the reservation of the nonce times out, if no tx of it is sent.
+	Expires int64 `protobuf:"varint,2,opt,name=expires,proto3" json:"expires,omitempty"`
+}
+
+func (m *GetNextNonceResponse) Reset()                    { *m = GetNextNonceResponse{} }
+func (m *GetNextNonceResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNextNonceResponse) ProtoMessage()               {}
+func (*GetNextNonceResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{20} }
+
+func (m *GetNextNonceResponse) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *GetNextNonceResponse) GetExpires() int64 {
+	if m != nil {
+		return m.Expires
+	}
+	return 0
+}
+
+// Request message of ReleaseNonce rpc.
+type ReleaseNonceRequest struct {
+	// Hex string of the account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Nonce   uint64 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (m *ReleaseNonceRequest) Reset()                    { *m = ReleaseNonceRequest{} }
+func (m *ReleaseNonceRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseNonceRequest) ProtoMessage()               {}
+func (*ReleaseNonceRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{21} }
+
+func (m *ReleaseNonceRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ReleaseNonceRequest) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+// Response message of ReleaseNonce rpc.
+type ReleaseNonceResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *ReleaseNonceResponse) Reset()                    { *m = ReleaseNonceResponse{} }
+func (m *ReleaseNonceResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseNonceResponse) ProtoMessage()               {}
+func (*ReleaseNonceResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{22} }
+
+func (m *ReleaseNonceResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// Request message of GetProof rpc.
+type GetProofRequest struct {
+	// the trie of the proof, one of "account", "transaction", "receipt" and "event".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// the address of account, the hex hash of transaction or receipt, or the hex key of event.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// the height of block in canonical chain, 0 for the tail.
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetProofRequest) Reset()                    { *m = GetProofRequest{} }
+func (m *GetProofRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetProofRequest) ProtoMessage()               {}
+func (*GetProofRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{23} }
+
+func (m *GetProofRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *GetProofRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetProofRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// Response message of GetProof rpc.
+type GetProofResponse struct {
+	Height    uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash string `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// the hex root of the trie in the header of the block.
+	Root string `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	// the hex value of the key, the marshaled account, transaction, receipt or event.
+	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// the hex serialized proof, verified by trie.VerifyProof against the root.
+	Proof string `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *GetProofResponse) Reset()                    { *m = GetProofResponse{} }
+func (m *GetProofResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetProofResponse) ProtoMessage()               {}
+func (*GetProofResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{24} }
+
+func (m *GetProofResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetProofResponse) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *GetProofResponse) GetRoot() string {
+	if m != nil {
+		return m.Root
+	}
+	return ""
+}
+
+func (m *GetProofResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *GetProofResponse) GetProof() string {
+	if m != nil {
+		return m.Proof
+	}
+	return ""
+}
+
 // Response message of GetDelegateVoters rpc
 type GetDelegateVotersRequest struct {
 	Delegatee string `protobuf:"bytes,1,opt,name=delegatee,proto3" json:"delegatee,omitempty"`
@@ -497,7 +956,7 @@ type GetDelegateVotersRequest struct {
 func (m *GetDelegateVotersRequest) Reset()                    { *m = GetDelegateVotersRequest{} }
 func (m *GetDelegateVotersRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetDelegateVotersRequest) ProtoMessage()               {}
-func (*GetDelegateVotersRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{13} }
+func (*GetDelegateVotersRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{25} }
 
 func (m *GetDelegateVotersRequest) GetDelegatee() string {
 	if m != nil {
@@ -514,7 +973,7 @@ type GetDelegateVotersResponse struct {
 func (m *GetDelegateVotersResponse) Reset()                    { *m = GetDelegateVotersResponse{} }
 func (m *GetDelegateVotersResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetDelegateVotersResponse) ProtoMessage()               {}
-func (*GetDelegateVotersResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{14} }
+func (*GetDelegateVotersResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{26} }
 
 func (m *GetDelegateVotersResponse) GetVoters() []string {
 	if m != nil {
@@ -543,12 +1002,23 @@ type TransactionRequest struct {
 	Candidate *CandidateRequest `protobuf:"bytes,8,opt,name=candidate" json:"candidate,omitempty"`
 	// delegate vote sending with this transaction.
 	Delegate *DelegateRequest `protobuf:"bytes,9,opt,name=delegate" json:"delegate,omitempty"`
+	// multisig account operation sending with this transaction.
+	Multisig *MultisigRequest `protobuf:"bytes,10,opt,name=multisig" json:"multisig,omitempty"`
+	// the last block height, or unix timestamp if not less than 500000000, the transaction
+	// can be packed at. 0 if it never expires.
+	ValidUntil uint64 `protobuf:"varint,11,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	// batch transfer to many recipients sending with this transaction.
+	Batch *BatchTransferRequest `protobuf:"bytes,12,opt,name=batch" json:"batch,omitempty"`
+	// evidence of a validator double signed sending with this transaction.
+	Slash *SlashRequest `protobuf:"bytes,13,opt,name=slash" json:"slash,omitempty"`
+	// oracle data fed by a whitelisted feeder sending with this transaction.
+	Oracle *OracleRequest `protobuf:"bytes,14,opt,name=oracle" json:"oracle,omitempty"`
 }
 
 func (m *TransactionRequest) Reset()                    { *m = TransactionRequest{} }
 func (m *TransactionRequest) String() string            { return proto.CompactTextString(m) }
 func (*TransactionRequest) ProtoMessage()               {}
-func (*TransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{15} }
+func (*TransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{27} }
 
 func (m *TransactionRequest) GetFrom() string {
 	if m != nil {
@@ -613,34 +1083,75 @@ func (m *TransactionRequest) GetDelegate() *DelegateRequest {
 	return nil
 }
 
-type ContractRequest struct {
-	// contract source code.
-	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	// contract source type, support javascript and typescript
-	SourceType string `protobuf:"bytes,2,opt,name=source_type,json=sourceType,proto3" json:"source_type,omitempty"`
-	// call contract function name
-	Function string `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
-	// the params of contract.
-	Args string `protobuf:"bytes,4,opt,name=args,proto3" json:"args,omitempty"`
-}
-
-func (m *ContractRequest) Reset()                    { *m = ContractRequest{} }
-func (m *ContractRequest) String() string            { return proto.CompactTextString(m) }
-func (*ContractRequest) ProtoMessage()               {}
-func (*ContractRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{16} }
-
-func (m *ContractRequest) GetSource() string {
+func (m *TransactionRequest) GetMultisig() *MultisigRequest {
 	if m != nil {
-		return m.Source
+		return m.Multisig
 	}
-	return ""
+	return nil
 }
 
-func (m *ContractRequest) GetSourceType() string {
+func (m *TransactionRequest) GetValidUntil() uint64 {
 	if m != nil {
-		return m.SourceType
+		return m.ValidUntil
 	}
-	return ""
+	return 0
+}
+
+func (m *TransactionRequest) GetBatch() *BatchTransferRequest {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetSlash() *SlashRequest {
+	if m != nil {
+		return m.Slash
+	}
+	return nil
+}
+
+func (m *TransactionRequest) GetOracle() *OracleRequest {
+	if m != nil {
+		return m.Oracle
+	}
+	return nil
+}
+
+type ContractRequest struct {
+	// contract source code.
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// contract source type, support javascript and typescript
+	SourceType string `protobuf:"bytes,2,opt,name=source_type,json=sourceType,proto3" json:"source_type,omitempty"`
+	// call contract function name
+	Function string `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
+	// the params of contract.
+	Args string `protobuf:"bytes,4,opt,name=args,proto3" json:"args,omitempty"`
+	// deploy the contract upgradable by its owner.
+	Upgradable bool `protobuf:"varint,5,opt,name=upgradable,proto3" json:"upgradable,omitempty"`
+	// replace the code of the contract at to with the source, the storage is kept.
+	Upgrade bool `protobuf:"varint,6,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
+	// JSON object of the ABI and metadata of the contract deployed or upgraded, optional.
+	Metadata string `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *ContractRequest) Reset()                    { *m = ContractRequest{} }
+func (m *ContractRequest) String() string            { return proto.CompactTextString(m) }
+func (*ContractRequest) ProtoMessage()               {}
+func (*ContractRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{28} }
+
+func (m *ContractRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ContractRequest) GetSourceType() string {
+	if m != nil {
+		return m.SourceType
+	}
+	return ""
 }
 
 func (m *ContractRequest) GetFunction() string {
@@ -657,6 +1168,27 @@ func (m *ContractRequest) GetArgs() string {
 	return ""
 }
 
+func (m *ContractRequest) GetUpgradable() bool {
+	if m != nil {
+		return m.Upgradable
+	}
+	return false
+}
+
+func (m *ContractRequest) GetUpgrade() bool {
+	if m != nil {
+		return m.Upgrade
+	}
+	return false
+}
+
+func (m *ContractRequest) GetMetadata() string {
+	if m != nil {
+		return m.Metadata
+	}
+	return ""
+}
+
 type CandidateRequest struct {
 	// candidate action.
 	Action string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
@@ -665,7 +1197,7 @@ type CandidateRequest struct {
 func (m *CandidateRequest) Reset()                    { *m = CandidateRequest{} }
 func (m *CandidateRequest) String() string            { return proto.CompactTextString(m) }
 func (*CandidateRequest) ProtoMessage()               {}
-func (*CandidateRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{17} }
+func (*CandidateRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{29} }
 
 func (m *CandidateRequest) GetAction() string {
 	if m != nil {
@@ -684,7 +1216,7 @@ type DelegateRequest struct {
 func (m *DelegateRequest) Reset()                    { *m = DelegateRequest{} }
 func (m *DelegateRequest) String() string            { return proto.CompactTextString(m) }
 func (*DelegateRequest) ProtoMessage()               {}
-func (*DelegateRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{18} }
+func (*DelegateRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{30} }
 
 func (m *DelegateRequest) GetAction() string {
 	if m != nil {
@@ -700,6 +1232,161 @@ func (m *DelegateRequest) GetDelegatee() string {
 	return ""
 }
 
+type MultisigRequest struct {
+	// multisig action: create, propose or sign.
+	Action string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	// owners and threshold of the account to create.
+	Owners    []string `protobuf:"bytes,2,rep,name=owners" json:"owners,omitempty"`
+	Threshold uint32   `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// the multisig account to propose a transfer from or sign a proposal of.
+	Account string `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	// receiver and value of the transfer proposed.
+	To    string `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Value string `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	// hex string of the hash of the proposing transaction to sign.
+	Proposal string `protobuf:"bytes,7,opt,name=proposal,proto3" json:"proposal,omitempty"`
+}
+
+func (m *MultisigRequest) Reset()                    { *m = MultisigRequest{} }
+func (m *MultisigRequest) String() string            { return proto.CompactTextString(m) }
+func (*MultisigRequest) ProtoMessage()               {}
+func (*MultisigRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{31} }
+
+func (m *MultisigRequest) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *MultisigRequest) GetOwners() []string {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *MultisigRequest) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *MultisigRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *MultisigRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *MultisigRequest) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *MultisigRequest) GetProposal() string {
+	if m != nil {
+		return m.Proposal
+	}
+	return ""
+}
+
+type BatchTransferRequest struct {
+	// the recipients and amounts, transferred all or none.
+	Outputs []*BatchTransferOutput `protobuf:"bytes,1,rep,name=outputs" json:"outputs,omitempty"`
+}
+
+func (m *BatchTransferRequest) Reset()                    { *m = BatchTransferRequest{} }
+func (m *BatchTransferRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchTransferRequest) ProtoMessage()               {}
+func (*BatchTransferRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{32} }
+
+func (m *BatchTransferRequest) GetOutputs() []*BatchTransferOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type BatchTransferOutput struct {
+	// Hex string of the receiver account addresss.
+	To string `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
+	// Amount of value transferred to the receiver.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *BatchTransferOutput) Reset()                    { *m = BatchTransferOutput{} }
+func (m *BatchTransferOutput) String() string            { return proto.CompactTextString(m) }
+func (*BatchTransferOutput) ProtoMessage()               {}
+func (*BatchTransferOutput) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{33} }
+
+func (m *BatchTransferOutput) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *BatchTransferOutput) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SlashRequest struct {
+	// Hex string of the double sign evidence, as in the event chain.doubleSign.
+	Evidence string `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+}
+
+func (m *SlashRequest) Reset()                    { *m = SlashRequest{} }
+func (m *SlashRequest) String() string            { return proto.CompactTextString(m) }
+func (*SlashRequest) ProtoMessage()               {}
+func (*SlashRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{34} }
+
+func (m *SlashRequest) GetEvidence() string {
+	if m != nil {
+		return m.Evidence
+	}
+	return ""
+}
+
+type OracleRequest struct {
+	// the key of the data.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// the data, such as a price in decimal string.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *OracleRequest) Reset()                    { *m = OracleRequest{} }
+func (m *OracleRequest) String() string            { return proto.CompactTextString(m) }
+func (*OracleRequest) ProtoMessage()               {}
+func (*OracleRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{35} }
+
+func (m *OracleRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *OracleRequest) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 // Request message of SendRawTransactionRequest rpc.
 type SendRawTransactionRequest struct {
 	// Signed data of transaction
@@ -709,7 +1396,7 @@ type SendRawTransactionRequest struct {
 func (m *SendRawTransactionRequest) Reset()                    { *m = SendRawTransactionRequest{} }
 func (m *SendRawTransactionRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendRawTransactionRequest) ProtoMessage()               {}
-func (*SendRawTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{19} }
+func (*SendRawTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{36} }
 
 func (m *SendRawTransactionRequest) GetData() []byte {
 	if m != nil {
@@ -729,7 +1416,7 @@ type SendTransactionResponse struct {
 func (m *SendTransactionResponse) Reset()                    { *m = SendTransactionResponse{} }
 func (m *SendTransactionResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendTransactionResponse) ProtoMessage()               {}
-func (*SendTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{20} }
+func (*SendTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{37} }
 
 func (m *SendTransactionResponse) GetTxhash() string {
 	if m != nil {
@@ -761,7 +1448,7 @@ type GetBlockByHashRequest struct {
 func (m *GetBlockByHashRequest) Reset()                    { *m = GetBlockByHashRequest{} }
 func (m *GetBlockByHashRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetBlockByHashRequest) ProtoMessage()               {}
-func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{21} }
+func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{38} }
 
 func (m *GetBlockByHashRequest) GetHash() string {
 	if m != nil {
@@ -770,6 +1457,24 @@ func (m *GetBlockByHashRequest) GetHash() string {
 	return ""
 }
 
+// Request message of GetBlockByHeight rpc.
+type GetBlockByHeightRequest struct {
+	// Height of block in canonical chain, 0 for the tail.
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetBlockByHeightRequest) Reset()                    { *m = GetBlockByHeightRequest{} }
+func (m *GetBlockByHeightRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetBlockByHeightRequest) ProtoMessage()               {}
+func (*GetBlockByHeightRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{39} }
+
+func (m *GetBlockByHeightRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 // Request message of GetTransactionByHash rpc.
 type GetTransactionByHashRequest struct {
 	// Hex string of transaction hash.
@@ -780,7 +1485,7 @@ func (m *GetTransactionByHashRequest) Reset()         { *m = GetTransactionByHas
 func (m *GetTransactionByHashRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTransactionByHashRequest) ProtoMessage()    {}
 func (*GetTransactionByHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorApiRpc, []int{22}
+	return fileDescriptorApiRpc, []int{40}
 }
 
 func (m *GetTransactionByHashRequest) GetHash() string {
@@ -799,7 +1504,7 @@ type BlockDumpRequest struct {
 func (m *BlockDumpRequest) Reset()                    { *m = BlockDumpRequest{} }
 func (m *BlockDumpRequest) String() string            { return proto.CompactTextString(m) }
 func (*BlockDumpRequest) ProtoMessage()               {}
-func (*BlockDumpRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{23} }
+func (*BlockDumpRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{41} }
 
 func (m *BlockDumpRequest) GetCount() int32 {
 	if m != nil {
@@ -817,7 +1522,7 @@ type BlockDumpResponse struct {
 func (m *BlockDumpResponse) Reset()                    { *m = BlockDumpResponse{} }
 func (m *BlockDumpResponse) String() string            { return proto.CompactTextString(m) }
 func (*BlockDumpResponse) ProtoMessage()               {}
-func (*BlockDumpResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{24} }
+func (*BlockDumpResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{42} }
 
 func (m *BlockDumpResponse) GetData() string {
 	if m != nil {
@@ -844,13 +1549,20 @@ type TransactionReceiptResponse struct {
 	GasPrice        string `protobuf:"bytes,10,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
 	GasLimit        string `protobuf:"bytes,11,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
 	ContractAddress string `protobuf:"bytes,12,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	ValidUntil      uint64 `protobuf:"varint,13,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	// execution status of the transaction packed in block, 1 for success and 0 for failure.
+	Status uint32 `protobuf:"varint,14,opt,name=status,proto3" json:"status,omitempty"`
+	// the reason of the failed transaction: out_of_gas, insufficient_balance, invalid_payload, reverted or failed.
+	ErrorCode string `protobuf:"bytes,15,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// the message of the failure, the message thrown by the contract if reverted.
+	ErrorMessage string `protobuf:"bytes,16,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 }
 
 func (m *TransactionReceiptResponse) Reset()         { *m = TransactionReceiptResponse{} }
 func (m *TransactionReceiptResponse) String() string { return proto.CompactTextString(m) }
 func (*TransactionReceiptResponse) ProtoMessage()    {}
 func (*TransactionReceiptResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorApiRpc, []int{25}
+	return fileDescriptorApiRpc, []int{43}
 }
 
 func (m *TransactionReceiptResponse) GetHash() string {
@@ -937,6 +1649,34 @@ func (m *TransactionReceiptResponse) GetContractAddress() string {
 	return ""
 }
 
+func (m *TransactionReceiptResponse) GetValidUntil() uint64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+func (m *TransactionReceiptResponse) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *TransactionReceiptResponse) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+func (m *TransactionReceiptResponse) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
 type NewAccountRequest struct {
 	Passphrase string `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
 }
@@ -944,7 +1684,7 @@ type NewAccountRequest struct {
 func (m *NewAccountRequest) Reset()                    { *m = NewAccountRequest{} }
 func (m *NewAccountRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewAccountRequest) ProtoMessage()               {}
-func (*NewAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{26} }
+func (*NewAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{44} }
 
 func (m *NewAccountRequest) GetPassphrase() string {
 	if m != nil {
@@ -960,7 +1700,7 @@ type NewAccountResponse struct {
 func (m *NewAccountResponse) Reset()                    { *m = NewAccountResponse{} }
 func (m *NewAccountResponse) String() string            { return proto.CompactTextString(m) }
 func (*NewAccountResponse) ProtoMessage()               {}
-func (*NewAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{27} }
+func (*NewAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{45} }
 
 func (m *NewAccountResponse) GetAddress() string {
 	if m != nil {
@@ -972,12 +1712,14 @@ func (m *NewAccountResponse) GetAddress() string {
 type UnlockAccountRequest struct {
 	Address    string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Passphrase string `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// nanoseconds to unlock for, capped by the policy of the account, its default if 0.
+	Duration uint64 `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
 }
 
 func (m *UnlockAccountRequest) Reset()                    { *m = UnlockAccountRequest{} }
 func (m *UnlockAccountRequest) String() string            { return proto.CompactTextString(m) }
 func (*UnlockAccountRequest) ProtoMessage()               {}
-func (*UnlockAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{28} }
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{46} }
 
 func (m *UnlockAccountRequest) GetAddress() string {
 	if m != nil {
@@ -993,6 +1735,13 @@ func (m *UnlockAccountRequest) GetPassphrase() string {
 	return ""
 }
 
+func (m *UnlockAccountRequest) GetDuration() uint64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
 type UnlockAccountResponse struct {
 	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 }
@@ -1000,7 +1749,7 @@ type UnlockAccountResponse struct {
 func (m *UnlockAccountResponse) Reset()                    { *m = UnlockAccountResponse{} }
 func (m *UnlockAccountResponse) String() string            { return proto.CompactTextString(m) }
 func (*UnlockAccountResponse) ProtoMessage()               {}
-func (*UnlockAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{29} }
+func (*UnlockAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{47} }
 
 func (m *UnlockAccountResponse) GetResult() bool {
 	if m != nil {
@@ -1016,7 +1765,7 @@ type LockAccountRequest struct {
 func (m *LockAccountRequest) Reset()                    { *m = LockAccountRequest{} }
 func (m *LockAccountRequest) String() string            { return proto.CompactTextString(m) }
 func (*LockAccountRequest) ProtoMessage()               {}
-func (*LockAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{30} }
+func (*LockAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{48} }
 
 func (m *LockAccountRequest) GetAddress() string {
 	if m != nil {
@@ -1032,7 +1781,7 @@ type LockAccountResponse struct {
 func (m *LockAccountResponse) Reset()                    { *m = LockAccountResponse{} }
 func (m *LockAccountResponse) String() string            { return proto.CompactTextString(m) }
 func (*LockAccountResponse) ProtoMessage()               {}
-func (*LockAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{31} }
+func (*LockAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{49} }
 
 func (m *LockAccountResponse) GetResult() bool {
 	if m != nil {
@@ -1048,7 +1797,7 @@ type SignTransactionResponse struct {
 func (m *SignTransactionResponse) Reset()                    { *m = SignTransactionResponse{} }
 func (m *SignTransactionResponse) String() string            { return proto.CompactTextString(m) }
 func (*SignTransactionResponse) ProtoMessage()               {}
-func (*SignTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{32} }
+func (*SignTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{50} }
 
 func (m *SignTransactionResponse) GetData() []byte {
 	if m != nil {
@@ -1068,7 +1817,7 @@ func (m *SendTransactionPassphraseRequest) Reset()         { *m = SendTransactio
 func (m *SendTransactionPassphraseRequest) String() string { return proto.CompactTextString(m) }
 func (*SendTransactionPassphraseRequest) ProtoMessage()    {}
 func (*SendTransactionPassphraseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorApiRpc, []int{33}
+	return fileDescriptorApiRpc, []int{51}
 }
 
 func (m *SendTransactionPassphraseRequest) GetTransaction() *TransactionRequest {
@@ -1094,7 +1843,7 @@ func (m *SendTransactionPassphraseResponse) Reset()         { *m = SendTransacti
 func (m *SendTransactionPassphraseResponse) String() string { return proto.CompactTextString(m) }
 func (*SendTransactionPassphraseResponse) ProtoMessage()    {}
 func (*SendTransactionPassphraseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorApiRpc, []int{34}
+	return fileDescriptorApiRpc, []int{52}
 }
 
 func (m *SendTransactionPassphraseResponse) GetHash() string {
@@ -1111,7 +1860,7 @@ type GasPriceResponse struct {
 func (m *GasPriceResponse) Reset()                    { *m = GasPriceResponse{} }
 func (m *GasPriceResponse) String() string            { return proto.CompactTextString(m) }
 func (*GasPriceResponse) ProtoMessage()               {}
-func (*GasPriceResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{35} }
+func (*GasPriceResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{53} }
 
 func (m *GasPriceResponse) GetGasPrice() string {
 	if m != nil {
@@ -1122,12 +1871,14 @@ func (m *GasPriceResponse) GetGasPrice() string {
 
 type EstimateGasResponse struct {
 	EstimateGas string `protobuf:"bytes,1,opt,name=estimate_gas,json=estimateGas,proto3" json:"estimate_gas,omitempty"`
+	// the reason of the transaction failed at the max gas limit, it fails at any limit then.
+	Err string `protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
 }
 
 func (m *EstimateGasResponse) Reset()                    { *m = EstimateGasResponse{} }
 func (m *EstimateGasResponse) String() string            { return proto.CompactTextString(m) }
 func (*EstimateGasResponse) ProtoMessage()               {}
-func (*EstimateGasResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{36} }
+func (*EstimateGasResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{54} }
 
 func (m *EstimateGasResponse) GetEstimateGas() string {
 	if m != nil {
@@ -1136,6 +1887,13 @@ func (m *EstimateGasResponse) GetEstimateGas() string {
 	return ""
 }
 
+func (m *EstimateGasResponse) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
 type EventsResponse struct {
 	Events []*Event `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
 }
@@ -1143,7 +1901,7 @@ type EventsResponse struct {
 func (m *EventsResponse) Reset()                    { *m = EventsResponse{} }
 func (m *EventsResponse) String() string            { return proto.CompactTextString(m) }
 func (*EventsResponse) ProtoMessage()               {}
-func (*EventsResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{37} }
+func (*EventsResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{55} }
 
 func (m *EventsResponse) GetEvents() []*Event {
 	if m != nil {
@@ -1160,7 +1918,7 @@ type Event struct {
 func (m *Event) Reset()                    { *m = Event{} }
 func (m *Event) String() string            { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()               {}
-func (*Event) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{38} }
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{56} }
 
 func (m *Event) GetTopic() string {
 	if m != nil {
@@ -1176,532 +1934,2089 @@ func (m *Event) GetData() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
-	proto.RegisterType((*ChangeNetworkIDRequest)(nil), "rpcpb.ChangeNetworkIDRequest")
-	proto.RegisterType((*ChangeNetworkIDResponse)(nil), "rpcpb.ChangeNetworkIDResponse")
-	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
-	proto.RegisterType((*NonParamsRequest)(nil), "rpcpb.NonParamsRequest")
-	proto.RegisterType((*NodeInfoResponse)(nil), "rpcpb.NodeInfoResponse")
-	proto.RegisterType((*StatisticsNodeInfoResponse)(nil), "rpcpb.StatisticsNodeInfoResponse")
-	proto.RegisterType((*RouteTable)(nil), "rpcpb.RouteTable")
-	proto.RegisterType((*GetNebStateResponse)(nil), "rpcpb.GetNebStateResponse")
-	proto.RegisterType((*AccountsResponse)(nil), "rpcpb.AccountsResponse")
-	proto.RegisterType((*GetAccountStateRequest)(nil), "rpcpb.GetAccountStateRequest")
-	proto.RegisterType((*GetAccountStateResponse)(nil), "rpcpb.GetAccountStateResponse")
-	proto.RegisterType((*GetDynastyResponse)(nil), "rpcpb.GetDynastyResponse")
-	proto.RegisterType((*GetDelegateVotersRequest)(nil), "rpcpb.GetDelegateVotersRequest")
-	proto.RegisterType((*GetDelegateVotersResponse)(nil), "rpcpb.GetDelegateVotersResponse")
-	proto.RegisterType((*TransactionRequest)(nil), "rpcpb.TransactionRequest")
-	proto.RegisterType((*ContractRequest)(nil), "rpcpb.ContractRequest")
-	proto.RegisterType((*CandidateRequest)(nil), "rpcpb.CandidateRequest")
-	proto.RegisterType((*DelegateRequest)(nil), "rpcpb.DelegateRequest")
-	proto.RegisterType((*SendRawTransactionRequest)(nil), "rpcpb.SendRawTransactionRequest")
-	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
-	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
-	proto.RegisterType((*GetTransactionByHashRequest)(nil), "rpcpb.GetTransactionByHashRequest")
-	proto.RegisterType((*BlockDumpRequest)(nil), "rpcpb.BlockDumpRequest")
-	proto.RegisterType((*BlockDumpResponse)(nil), "rpcpb.BlockDumpResponse")
-	proto.RegisterType((*TransactionReceiptResponse)(nil), "rpcpb.TransactionReceiptResponse")
-	proto.RegisterType((*NewAccountRequest)(nil), "rpcpb.NewAccountRequest")
-	proto.RegisterType((*NewAccountResponse)(nil), "rpcpb.NewAccountResponse")
-	proto.RegisterType((*UnlockAccountRequest)(nil), "rpcpb.UnlockAccountRequest")
-	proto.RegisterType((*UnlockAccountResponse)(nil), "rpcpb.UnlockAccountResponse")
-	proto.RegisterType((*LockAccountRequest)(nil), "rpcpb.LockAccountRequest")
-	proto.RegisterType((*LockAccountResponse)(nil), "rpcpb.LockAccountResponse")
-	proto.RegisterType((*SignTransactionResponse)(nil), "rpcpb.SignTransactionResponse")
-	proto.RegisterType((*SendTransactionPassphraseRequest)(nil), "rpcpb.SendTransactionPassphraseRequest")
-	proto.RegisterType((*SendTransactionPassphraseResponse)(nil), "rpcpb.SendTransactionPassphraseResponse")
-	proto.RegisterType((*GasPriceResponse)(nil), "rpcpb.GasPriceResponse")
-	proto.RegisterType((*EstimateGasResponse)(nil), "rpcpb.EstimateGasResponse")
-	proto.RegisterType((*EventsResponse)(nil), "rpcpb.EventsResponse")
-	proto.RegisterType((*Event)(nil), "rpcpb.Event")
+type NewEventFilterRequest struct {
+	// topics of the events.
+	Topics []string `protobuf:"bytes,1,rep,name=topics" json:"topics,omitempty"`
+	// hex string of the contract emitting the events, any if empty.
+	Contract string `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	// heights of the events, from 1 if 0, and unbounded if to_height is 0.
+	FromHeight uint64 `protobuf:"varint,3,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	ToHeight   uint64 `protobuf:"varint,4,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *NewEventFilterRequest) Reset()                    { *m = NewEventFilterRequest{} }
+func (m *NewEventFilterRequest) String() string            { return proto.CompactTextString(m) }
+func (*NewEventFilterRequest) ProtoMessage()               {}
+func (*NewEventFilterRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{57} }
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *NewEventFilterRequest) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
 
-// Client API for ApiService service
+func (m *NewEventFilterRequest) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
 
-type ApiServiceClient interface {
-	// Return the state of the neb.
-	GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error)
-	// Return the p2p node info.
-	NodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error)
-	// Return the dump info of blockchain.
-	BlockDump(ctx context.Context, in *BlockDumpRequest, opts ...grpc.CallOption) (*BlockDumpResponse, error)
-	// Accounts return account list.
-	Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
-	// Return the state of the account.
-	GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error)
-	// Verify, sign, and send the transaction.
-	SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
-	// Call smart contract.
-	Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
-	// Submit the signed transaction.
-	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
-	// Get block header info by the block hash.
-	GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*corepb.Block, error)
-	// Get transactionReceipt info by tansaction hash.
-	GetTransactionReceipt(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*TransactionReceiptResponse, error)
-	// Subscribe message
-	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
-	// Get GasPrice
-	GetGasPrice(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GasPriceResponse, error)
-	// EstimateGas
-	EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error)
-	GetEventsByHash(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+func (m *NewEventFilterRequest) GetFromHeight() uint64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
 }
 
-type apiServiceClient struct {
-	cc *grpc.ClientConn
+func (m *NewEventFilterRequest) GetToHeight() uint64 {
+	if m != nil {
+		return m.ToHeight
+	}
+	return 0
 }
 
-func NewApiServiceClient(cc *grpc.ClientConn) ApiServiceClient {
-	return &apiServiceClient{cc}
+type NewEventFilterResponse struct {
+	// filter id.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (c *apiServiceClient) GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error) {
-	out := new(GetNebStateResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetNebState", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *NewEventFilterResponse) Reset()                    { *m = NewEventFilterResponse{} }
+func (m *NewEventFilterResponse) String() string            { return proto.CompactTextString(m) }
+func (*NewEventFilterResponse) ProtoMessage()               {}
+func (*NewEventFilterResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{58} }
+
+func (m *NewEventFilterResponse) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) NodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error) {
-	out := new(NodeInfoResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/NodeInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type EventFilterRequest struct {
+	// filter id.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *EventFilterRequest) Reset()                    { *m = EventFilterRequest{} }
+func (m *EventFilterRequest) String() string            { return proto.CompactTextString(m) }
+func (*EventFilterRequest) ProtoMessage()               {}
+func (*EventFilterRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{59} }
+
+func (m *EventFilterRequest) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) BlockDump(ctx context.Context, in *BlockDumpRequest, opts ...grpc.CallOption) (*BlockDumpResponse, error) {
-	out := new(BlockDumpResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/BlockDump", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+// An event in canonical chain.
+type StoredEvent struct {
+	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	TxHash   string `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Contract string `protobuf:"bytes,3,opt,name=contract,proto3" json:"contract,omitempty"`
+	Topic    string `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
+	Data     string `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *StoredEvent) Reset()                    { *m = StoredEvent{} }
+func (m *StoredEvent) String() string            { return proto.CompactTextString(m) }
+func (*StoredEvent) ProtoMessage()               {}
+func (*StoredEvent) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{60} }
+
+func (m *StoredEvent) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
 	}
-	return out, nil
+	return 0
 }
 
-func (c *apiServiceClient) Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
-	out := new(AccountsResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/Accounts", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *StoredEvent) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error) {
-	out := new(GetAccountStateResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetAccountState", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *StoredEvent) GetContract() string {
+	if m != nil {
+		return m.Contract
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
-	out := new(SendTransactionResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/SendTransaction", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *StoredEvent) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
-	out := new(SendTransactionResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/Call", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *StoredEvent) GetData() string {
+	if m != nil {
+		return m.Data
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
-	out := new(SendTransactionResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/SendRawTransaction", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type FilterEventsResponse struct {
+	Events []*StoredEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+}
+
+func (m *FilterEventsResponse) Reset()                    { *m = FilterEventsResponse{} }
+func (m *FilterEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*FilterEventsResponse) ProtoMessage()               {}
+func (*FilterEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{61} }
+
+func (m *FilterEventsResponse) GetEvents() []*StoredEvent {
+	if m != nil {
+		return m.Events
 	}
-	return out, nil
+	return nil
 }
 
-func (c *apiServiceClient) GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*corepb.Block, error) {
-	out := new(corepb.Block)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHash", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type UninstallEventFilterResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *UninstallEventFilterResponse) Reset()         { *m = UninstallEventFilterResponse{} }
+func (m *UninstallEventFilterResponse) String() string { return proto.CompactTextString(m) }
+func (*UninstallEventFilterResponse) ProtoMessage()    {}
+func (*UninstallEventFilterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiRpc, []int{62}
+}
+
+func (m *UninstallEventFilterResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
 	}
-	return out, nil
+	return false
 }
 
-func (c *apiServiceClient) GetTransactionReceipt(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*TransactionReceiptResponse, error) {
-	out := new(TransactionReceiptResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetTransactionReceipt", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type SyncStatusResponse struct {
+	Synchronizing bool   `protobuf:"varint,1,opt,name=synchronizing,proto3" json:"synchronizing,omitempty"`
+	StartHeight   uint64 `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	CurrentHeight uint64 `protobuf:"varint,3,opt,name=current_height,json=currentHeight,proto3" json:"current_height,omitempty"`
+	// highest tail height reported by peers.
+	HighestHeight uint64 `protobuf:"varint,4,opt,name=highest_height,json=highestHeight,proto3" json:"highest_height,omitempty"`
+	// blocks synced per second.
+	Rate float64 `protobuf:"fixed64,5,opt,name=rate,proto3" json:"rate,omitempty"`
+	// estimated seconds to reach the highest height, 0 if unknown.
+	Eta int64 `protobuf:"varint,6,opt,name=eta,proto3" json:"eta,omitempty"`
+}
+
+func (m *SyncStatusResponse) Reset()                    { *m = SyncStatusResponse{} }
+func (m *SyncStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*SyncStatusResponse) ProtoMessage()               {}
+func (*SyncStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{63} }
+
+func (m *SyncStatusResponse) GetSynchronizing() bool {
+	if m != nil {
+		return m.Synchronizing
 	}
-	return out, nil
+	return false
 }
 
-func (c *apiServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_ApiService_serviceDesc.Streams[0], c.cc, "/rpcpb.ApiService/Subscribe", opts...)
-	if err != nil {
-		return nil, err
+func (m *SyncStatusResponse) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
 	}
-	x := &apiServiceSubscribeClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *SyncStatusResponse) GetCurrentHeight() uint64 {
+	if m != nil {
+		return m.CurrentHeight
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *SyncStatusResponse) GetHighestHeight() uint64 {
+	if m != nil {
+		return m.HighestHeight
 	}
-	return x, nil
+	return 0
 }
 
-type ApiService_SubscribeClient interface {
-	Recv() (*SubscribeResponse, error)
-	grpc.ClientStream
+func (m *SyncStatusResponse) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
 }
 
-type apiServiceSubscribeClient struct {
-	grpc.ClientStream
+func (m *SyncStatusResponse) GetEta() int64 {
+	if m != nil {
+		return m.Eta
+	}
+	return 0
 }
 
-func (x *apiServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
-	m := new(SubscribeResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+type ExecutionEnvironmentResponse struct {
+	GoVersion   string `protobuf:"bytes,1,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	Os          string `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	Arch        string `protobuf:"bytes,3,opt,name=arch,proto3" json:"arch,omitempty"`
+	V8Version   string `protobuf:"bytes,4,opt,name=v8_version,json=v8Version,proto3" json:"v8_version,omitempty"`
+	TimeZone    string `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	ZoneOffset  int32  `protobuf:"varint,6,opt,name=zone_offset,json=zoneOffset,proto3" json:"zone_offset,omitempty"`
+	FloatDigest string `protobuf:"bytes,7,opt,name=float_digest,json=floatDigest,proto3" json:"float_digest,omitempty"`
+	// hash of the properties which must be the same on all validators.
+	Fingerprint string `protobuf:"bytes,8,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// problems which may break the determinism of execution.
+	Problems []string `protobuf:"bytes,9,rep,name=problems" json:"problems,omitempty"`
+}
+
+func (m *ExecutionEnvironmentResponse) Reset()         { *m = ExecutionEnvironmentResponse{} }
+func (m *ExecutionEnvironmentResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecutionEnvironmentResponse) ProtoMessage()    {}
+func (*ExecutionEnvironmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiRpc, []int{64}
+}
+
+func (m *ExecutionEnvironmentResponse) GetGoVersion() string {
+	if m != nil {
+		return m.GoVersion
 	}
-	return m, nil
+	return ""
 }
 
-func (c *apiServiceClient) GetGasPrice(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GasPriceResponse, error) {
-	out := new(GasPriceResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetGasPrice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExecutionEnvironmentResponse) GetOs() string {
+	if m != nil {
+		return m.Os
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error) {
-	out := new(EstimateGasResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/EstimateGas", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExecutionEnvironmentResponse) GetArch() string {
+	if m != nil {
+		return m.Arch
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) GetEventsByHash(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*EventsResponse, error) {
-	out := new(EventsResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetEventsByHash", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExecutionEnvironmentResponse) GetV8Version() string {
+	if m != nil {
+		return m.V8Version
 	}
-	return out, nil
+	return ""
 }
 
-// Server API for ApiService service
+func (m *ExecutionEnvironmentResponse) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
 
-type ApiServiceServer interface {
-	// Return the state of the neb.
-	GetNebState(context.Context, *NonParamsRequest) (*GetNebStateResponse, error)
-	// Return the p2p node info.
-	NodeInfo(context.Context, *NonParamsRequest) (*NodeInfoResponse, error)
-	// Return the dump info of blockchain.
-	BlockDump(context.Context, *BlockDumpRequest) (*BlockDumpResponse, error)
-	// Accounts return account list.
-	Accounts(context.Context, *NonParamsRequest) (*AccountsResponse, error)
-	// Return the state of the account.
-	GetAccountState(context.Context, *GetAccountStateRequest) (*GetAccountStateResponse, error)
-	// Verify, sign, and send the transaction.
-	SendTransaction(context.Context, *TransactionRequest) (*SendTransactionResponse, error)
-	// Call smart contract.
-	Call(context.Context, *TransactionRequest) (*SendTransactionResponse, error)
-	// Submit the signed transaction.
-	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendTransactionResponse, error)
-	// Get block header info by the block hash.
-	GetBlockByHash(context.Context, *GetBlockByHashRequest) (*corepb.Block, error)
-	// Get transactionReceipt info by tansaction hash.
-	GetTransactionReceipt(context.Context, *GetTransactionByHashRequest) (*TransactionReceiptResponse, error)
-	// Subscribe message
-	Subscribe(*SubscribeRequest, ApiService_SubscribeServer) error
-	// Get GasPrice
-	GetGasPrice(context.Context, *NonParamsRequest) (*GasPriceResponse, error)
-	// EstimateGas
-	EstimateGas(context.Context, *TransactionRequest) (*EstimateGasResponse, error)
-	GetEventsByHash(context.Context, *GetTransactionByHashRequest) (*EventsResponse, error)
+func (m *ExecutionEnvironmentResponse) GetZoneOffset() int32 {
+	if m != nil {
+		return m.ZoneOffset
+	}
+	return 0
 }
 
-func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
-	s.RegisterService(&_ApiService_serviceDesc, srv)
+func (m *ExecutionEnvironmentResponse) GetFloatDigest() string {
+	if m != nil {
+		return m.FloatDigest
+	}
+	return ""
+}
+
+func (m *ExecutionEnvironmentResponse) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+func (m *ExecutionEnvironmentResponse) GetProblems() []string {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+type ConvertRequest struct {
+	// Type of the data: block, transaction or account.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Protobuf bytes of the data, base64 in http.
+	Proto []byte `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+	// JSON of the data.
+	Json string `protobuf:"bytes,3,opt,name=json,proto3" json:"json,omitempty"`
+}
+
+func (m *ConvertRequest) Reset()                    { *m = ConvertRequest{} }
+func (m *ConvertRequest) String() string            { return proto.CompactTextString(m) }
+func (*ConvertRequest) ProtoMessage()               {}
+func (*ConvertRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{65} }
+
+func (m *ConvertRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ConvertRequest) GetProto() []byte {
+	if m != nil {
+		return m.Proto
+	}
+	return nil
+}
+
+func (m *ConvertRequest) GetJson() string {
+	if m != nil {
+		return m.Json
+	}
+	return ""
+}
+
+type ConvertResponse struct {
+	// Version of the JSON representation.
+	Version int32  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Proto   []byte `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+	Json    string `protobuf:"bytes,3,opt,name=json,proto3" json:"json,omitempty"`
+}
+
+func (m *ConvertResponse) Reset()                    { *m = ConvertResponse{} }
+func (m *ConvertResponse) String() string            { return proto.CompactTextString(m) }
+func (*ConvertResponse) ProtoMessage()               {}
+func (*ConvertResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{66} }
+
+func (m *ConvertResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ConvertResponse) GetProto() []byte {
+	if m != nil {
+		return m.Proto
+	}
+	return nil
+}
+
+func (m *ConvertResponse) GetJson() string {
+	if m != nil {
+		return m.Json
+	}
+	return ""
+}
+
+// Summary of the transactions of a sender in pool.
+type TxPoolSender struct {
+	// Hex string of the sender address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Count   uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// count of the transactions waiting for the nonce gaps before them.
+	Queued     uint32 `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`
+	MinNonce   uint64 `protobuf:"varint,4,opt,name=min_nonce,json=minNonce,proto3" json:"min_nonce,omitempty"`
+	MaxNonce   uint64 `protobuf:"varint,5,opt,name=max_nonce,json=maxNonce,proto3" json:"max_nonce,omitempty"`
+	TotalValue string `protobuf:"bytes,6,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	// sum of gas_price * gas_limit of the transactions.
+	TotalFees string `protobuf:"bytes,7,opt,name=total_fees,json=totalFees,proto3" json:"total_fees,omitempty"`
+}
+
+func (m *TxPoolSender) Reset()                    { *m = TxPoolSender{} }
+func (m *TxPoolSender) String() string            { return proto.CompactTextString(m) }
+func (*TxPoolSender) ProtoMessage()               {}
+func (*TxPoolSender) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{67} }
+
+func (m *TxPoolSender) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *TxPoolSender) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *TxPoolSender) GetQueued() uint32 {
+	if m != nil {
+		return m.Queued
+	}
+	return 0
+}
+
+func (m *TxPoolSender) GetMinNonce() uint64 {
+	if m != nil {
+		return m.MinNonce
+	}
+	return 0
+}
+
+func (m *TxPoolSender) GetMaxNonce() uint64 {
+	if m != nil {
+		return m.MaxNonce
+	}
+	return 0
+}
+
+func (m *TxPoolSender) GetTotalValue() string {
+	if m != nil {
+		return m.TotalValue
+	}
+	return ""
+}
+
+func (m *TxPoolSender) GetTotalFees() string {
+	if m != nil {
+		return m.TotalFees
+	}
+	return ""
+}
+
+// Response message of InspectTransactionPool rpc.
+type InspectTransactionPoolResponse struct {
+	Senders []*TxPoolSender `protobuf:"bytes,1,rep,name=senders" json:"senders,omitempty"`
+}
+
+func (m *InspectTransactionPoolResponse) Reset()         { *m = InspectTransactionPoolResponse{} }
+func (m *InspectTransactionPoolResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectTransactionPoolResponse) ProtoMessage()    {}
+func (*InspectTransactionPoolResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorApiRpc, []int{68}
+}
+
+func (m *InspectTransactionPoolResponse) GetSenders() []*TxPoolSender {
+	if m != nil {
+		return m.Senders
+	}
+	return nil
+}
+
+// Response message of DropTransaction rpc.
+type DropTransactionResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *DropTransactionResponse) Reset()                    { *m = DropTransactionResponse{} }
+func (m *DropTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*DropTransactionResponse) ProtoMessage()               {}
+func (*DropTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{69} }
+
+func (m *DropTransactionResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type SetAccountPolicyRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// nanoseconds the account is unlocked for at most, the default of keystore if 0.
+	UnlockDuration uint64 `protobuf:"varint,2,opt,name=unlock_duration,json=unlockDuration,proto3" json:"unlock_duration,omitempty"`
+	// the most value and fees of the transactions signed between unlocks, no limit if empty.
+	SpendLimit string `protobuf:"bytes,3,opt,name=spend_limit,json=spendLimit,proto3" json:"spend_limit,omitempty"`
+}
+
+func (m *SetAccountPolicyRequest) Reset()                    { *m = SetAccountPolicyRequest{} }
+func (m *SetAccountPolicyRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetAccountPolicyRequest) ProtoMessage()               {}
+func (*SetAccountPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{70} }
+
+func (m *SetAccountPolicyRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SetAccountPolicyRequest) GetUnlockDuration() uint64 {
+	if m != nil {
+		return m.UnlockDuration
+	}
+	return 0
+}
+
+func (m *SetAccountPolicyRequest) GetSpendLimit() string {
+	if m != nil {
+		return m.SpendLimit
+	}
+	return ""
+}
+
+type SetAccountPolicyResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *SetAccountPolicyResponse) Reset()                    { *m = SetAccountPolicyResponse{} }
+func (m *SetAccountPolicyResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetAccountPolicyResponse) ProtoMessage()               {}
+func (*SetAccountPolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{71} }
+
+func (m *SetAccountPolicyResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type GetSigningAuditRequest struct {
+	// the address of the operations, all if empty.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// the count of the latest operations, all kept if 0.
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *GetSigningAuditRequest) Reset()                    { *m = GetSigningAuditRequest{} }
+func (m *GetSigningAuditRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetSigningAuditRequest) ProtoMessage()               {}
+func (*GetSigningAuditRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{72} }
+
+func (m *GetSigningAuditRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetSigningAuditRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SigningRecord struct {
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// transaction or block.
+	Kind  string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Hash  string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Value string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// the error of the operation refused or failed.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *SigningRecord) Reset()                    { *m = SigningRecord{} }
+func (m *SigningRecord) String() string            { return proto.CompactTextString(m) }
+func (*SigningRecord) ProtoMessage()               {}
+func (*SigningRecord) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{73} }
+
+func (m *SigningRecord) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *SigningRecord) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SigningRecord) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *SigningRecord) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *SigningRecord) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *SigningRecord) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GetSigningAuditResponse struct {
+	Records []*SigningRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *GetSigningAuditResponse) Reset()                    { *m = GetSigningAuditResponse{} }
+func (m *GetSigningAuditResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetSigningAuditResponse) ProtoMessage()               {}
+func (*GetSigningAuditResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{74} }
+
+func (m *GetSigningAuditResponse) GetRecords() []*SigningRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type PeerInfo struct {
+	// peer id.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// known addresses of the peer.
+	Addrs []string `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	// 0 known, 1 in route table, 2 connected.
+	Score int32 `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (m *PeerInfo) Reset()                    { *m = PeerInfo{} }
+func (m *PeerInfo) String() string            { return proto.CompactTextString(m) }
+func (*PeerInfo) ProtoMessage()               {}
+func (*PeerInfo) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{75} }
+
+func (m *PeerInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetAddrs() []string {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *PeerInfo) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+// Response message of GetPeers rpc.
+type GetPeersResponse struct {
+	Peers []*PeerInfo `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *GetPeersResponse) Reset()                    { *m = GetPeersResponse{} }
+func (m *GetPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetPeersResponse) ProtoMessage()               {}
+func (*GetPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{76} }
+
+func (m *GetPeersResponse) GetPeers() []*PeerInfo {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type BanPeerRequest struct {
+	// peer id.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// seconds to ban, the default 1800 if 0.
+	Duration uint64 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (m *BanPeerRequest) Reset()                    { *m = BanPeerRequest{} }
+func (m *BanPeerRequest) String() string            { return proto.CompactTextString(m) }
+func (*BanPeerRequest) ProtoMessage()               {}
+func (*BanPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{77} }
+
+func (m *BanPeerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BanPeerRequest) GetDuration() uint64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type UnbanPeerRequest struct {
+	// peer id.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *UnbanPeerRequest) Reset()                    { *m = UnbanPeerRequest{} }
+func (m *UnbanPeerRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnbanPeerRequest) ProtoMessage()               {}
+func (*UnbanPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{78} }
+
+func (m *UnbanPeerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// Response message of BanPeer and UnbanPeer rpc.
+type BanPeerResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *BanPeerResponse) Reset()                    { *m = BanPeerResponse{} }
+func (m *BanPeerResponse) String() string            { return proto.CompactTextString(m) }
+func (*BanPeerResponse) ProtoMessage()               {}
+func (*BanPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{79} }
+
+func (m *BanPeerResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// Response message of ExportPeers rpc.
+type ExportPeersResponse struct {
+	// the peer address book in JSON.
+	Peers string `protobuf:"bytes,1,opt,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (m *ExportPeersResponse) Reset()                    { *m = ExportPeersResponse{} }
+func (m *ExportPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportPeersResponse) ProtoMessage()               {}
+func (*ExportPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{80} }
+
+func (m *ExportPeersResponse) GetPeers() string {
+	if m != nil {
+		return m.Peers
+	}
+	return ""
+}
+
+type ImportPeersRequest struct {
+	// the peer address book in JSON, as exported.
+	Peers string `protobuf:"bytes,1,opt,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (m *ImportPeersRequest) Reset()                    { *m = ImportPeersRequest{} }
+func (m *ImportPeersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportPeersRequest) ProtoMessage()               {}
+func (*ImportPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{81} }
+
+func (m *ImportPeersRequest) GetPeers() string {
+	if m != nil {
+		return m.Peers
+	}
+	return ""
+}
+
+// Response message of ImportPeers rpc.
+type ImportPeersResponse struct {
+	// number of the imported peers.
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *ImportPeersResponse) Reset()                    { *m = ImportPeersResponse{} }
+func (m *ImportPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportPeersResponse) ProtoMessage()               {}
+func (*ImportPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{82} }
+
+func (m *ImportPeersResponse) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type BootNodeInfo struct {
+	// multiaddr of the boot node.
+	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	// the last dial succeeded.
+	Healthy bool `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// continuous dial failures.
+	Failures int32 `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`
+	// unix time of the last successful dial, 0 if never.
+	LastSuccess int64  `protobuf:"varint,4,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	LastError   string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// unix time of the next dial, 0 if due now.
+	NextRetry int64 `protobuf:"varint,6,opt,name=next_retry,json=nextRetry,proto3" json:"next_retry,omitempty"`
+}
+
+func (m *BootNodeInfo) Reset()                    { *m = BootNodeInfo{} }
+func (m *BootNodeInfo) String() string            { return proto.CompactTextString(m) }
+func (*BootNodeInfo) ProtoMessage()               {}
+func (*BootNodeInfo) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{83} }
+
+func (m *BootNodeInfo) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *BootNodeInfo) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *BootNodeInfo) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *BootNodeInfo) GetLastSuccess() int64 {
+	if m != nil {
+		return m.LastSuccess
+	}
+	return 0
+}
+
+func (m *BootNodeInfo) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *BootNodeInfo) GetNextRetry() int64 {
+	if m != nil {
+		return m.NextRetry
+	}
+	return 0
+}
+
+// Response message of GetBootNodes rpc.
+type GetBootNodesResponse struct {
+	BootNodes []*BootNodeInfo `protobuf:"bytes,1,rep,name=boot_nodes,json=bootNodes" json:"boot_nodes,omitempty"`
+}
+
+func (m *GetBootNodesResponse) Reset()                    { *m = GetBootNodesResponse{} }
+func (m *GetBootNodesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetBootNodesResponse) ProtoMessage()               {}
+func (*GetBootNodesResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{84} }
+
+func (m *GetBootNodesResponse) GetBootNodes() []*BootNodeInfo {
+	if m != nil {
+		return m.BootNodes
+	}
+	return nil
+}
+
+type BootNodeRequest struct {
+	// multiaddr of the boot node, e.g. /ip4/127.0.0.1/tcp/8680/ipfs/<peer id>.
+	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+}
+
+func (m *BootNodeRequest) Reset()                    { *m = BootNodeRequest{} }
+func (m *BootNodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*BootNodeRequest) ProtoMessage()               {}
+func (*BootNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{85} }
+
+func (m *BootNodeRequest) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+// Response message of AddBootNode and RemoveBootNode rpc.
+type BootNodeResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *BootNodeResponse) Reset()                    { *m = BootNodeResponse{} }
+func (m *BootNodeResponse) String() string            { return proto.CompactTextString(m) }
+func (*BootNodeResponse) ProtoMessage()               {}
+func (*BootNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{86} }
+
+func (m *BootNodeResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type SetLogLevelRequest struct {
+	// one of panic, fatal, error, warn, info and debug.
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (m *SetLogLevelRequest) Reset()                    { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()               {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{87} }
+
+func (m *SetLogLevelRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+// Response message of SetLogLevel rpc.
+type SetLogLevelResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *SetLogLevelResponse) Reset()                    { *m = SetLogLevelResponse{} }
+func (m *SetLogLevelResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetLogLevelResponse) ProtoMessage()               {}
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{88} }
+
+func (m *SetLogLevelResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type ExportChainRequest struct {
+	// path of the dump file, relative to the export directory under the datadir.
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// heights to export, from 1 and to the tail if 0.
+	From uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   uint64 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (m *ExportChainRequest) Reset()                    { *m = ExportChainRequest{} }
+func (m *ExportChainRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportChainRequest) ProtoMessage()               {}
+func (*ExportChainRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{89} }
+
+func (m *ExportChainRequest) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *ExportChainRequest) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ExportChainRequest) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+// Response message of ExportChain rpc.
+type ExportChainResponse struct {
+	// the export is started, it runs in background.
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *ExportChainResponse) Reset()                    { *m = ExportChainResponse{} }
+func (m *ExportChainResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportChainResponse) ProtoMessage()               {}
+func (*ExportChainResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{90} }
+
+func (m *ExportChainResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type SetProfilingRequest struct {
+	// start or stop pprof.
+	Enable bool `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	// listen address of pprof, the default 127.0.0.1:6060 if empty.
+	Listen string `protobuf:"bytes,2,opt,name=listen,proto3" json:"listen,omitempty"`
+}
+
+func (m *SetProfilingRequest) Reset()                    { *m = SetProfilingRequest{} }
+func (m *SetProfilingRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetProfilingRequest) ProtoMessage()               {}
+func (*SetProfilingRequest) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{91} }
+
+func (m *SetProfilingRequest) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+func (m *SetProfilingRequest) GetListen() string {
+	if m != nil {
+		return m.Listen
+	}
+	return ""
+}
+
+// Response message of SetProfiling rpc.
+type SetProfilingResponse struct {
+	// listen address of pprof, empty if stopped.
+	Listen string `protobuf:"bytes,1,opt,name=listen,proto3" json:"listen,omitempty"`
+}
+
+func (m *SetProfilingResponse) Reset()                    { *m = SetProfilingResponse{} }
+func (m *SetProfilingResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetProfilingResponse) ProtoMessage()               {}
+func (*SetProfilingResponse) Descriptor() ([]byte, []int) { return fileDescriptorApiRpc, []int{92} }
+
+func (m *SetProfilingResponse) GetListen() string {
+	if m != nil {
+		return m.Listen
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
+	proto.RegisterType((*ChangeNetworkIDRequest)(nil), "rpcpb.ChangeNetworkIDRequest")
+	proto.RegisterType((*ChangeNetworkIDResponse)(nil), "rpcpb.ChangeNetworkIDResponse")
+	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
+	proto.RegisterType((*NonParamsRequest)(nil), "rpcpb.NonParamsRequest")
+	proto.RegisterType((*NodeInfoResponse)(nil), "rpcpb.NodeInfoResponse")
+	proto.RegisterType((*StatisticsNodeInfoResponse)(nil), "rpcpb.StatisticsNodeInfoResponse")
+	proto.RegisterType((*RouteTable)(nil), "rpcpb.RouteTable")
+	proto.RegisterType((*GetNebStateResponse)(nil), "rpcpb.GetNebStateResponse")
+	proto.RegisterType((*AccountsResponse)(nil), "rpcpb.AccountsResponse")
+	proto.RegisterType((*GetAccountStateRequest)(nil), "rpcpb.GetAccountStateRequest")
+	proto.RegisterType((*GetAccountStateResponse)(nil), "rpcpb.GetAccountStateResponse")
+	proto.RegisterType((*GetContractMetadataRequest)(nil), "rpcpb.GetContractMetadataRequest")
+	proto.RegisterType((*GetContractMetadataResponse)(nil), "rpcpb.GetContractMetadataResponse")
+	proto.RegisterType((*GetDynastyResponse)(nil), "rpcpb.GetDynastyResponse")
+	proto.RegisterType((*GetDynastySnapshotRequest)(nil), "rpcpb.GetDynastySnapshotRequest")
+	proto.RegisterType((*GetDynastySnapshotResponse)(nil), "rpcpb.GetDynastySnapshotResponse")
+	proto.RegisterType((*DynastyValidator)(nil), "rpcpb.DynastyValidator")
+	proto.RegisterType((*DynastyCandidate)(nil), "rpcpb.DynastyCandidate")
+	proto.RegisterType((*GetNextNonceRequest)(nil), "rpcpb.GetNextNonceRequest")
+	proto.RegisterType((*GetNextNonceResponse)(nil), "rpcpb.GetNextNonceResponse")
+	proto.RegisterType((*ReleaseNonceRequest)(nil), "rpcpb.ReleaseNonceRequest")
+	proto.RegisterType((*ReleaseNonceResponse)(nil), "rpcpb.ReleaseNonceResponse")
+	proto.RegisterType((*GetProofRequest)(nil), "rpcpb.GetProofRequest")
+	proto.RegisterType((*GetProofResponse)(nil), "rpcpb.GetProofResponse")
+	proto.RegisterType((*GetDelegateVotersRequest)(nil), "rpcpb.GetDelegateVotersRequest")
+	proto.RegisterType((*GetDelegateVotersResponse)(nil), "rpcpb.GetDelegateVotersResponse")
+	proto.RegisterType((*TransactionRequest)(nil), "rpcpb.TransactionRequest")
+	proto.RegisterType((*ContractRequest)(nil), "rpcpb.ContractRequest")
+	proto.RegisterType((*CandidateRequest)(nil), "rpcpb.CandidateRequest")
+	proto.RegisterType((*DelegateRequest)(nil), "rpcpb.DelegateRequest")
+	proto.RegisterType((*MultisigRequest)(nil), "rpcpb.MultisigRequest")
+	proto.RegisterType((*BatchTransferRequest)(nil), "rpcpb.BatchTransferRequest")
+	proto.RegisterType((*BatchTransferOutput)(nil), "rpcpb.BatchTransferOutput")
+	proto.RegisterType((*SlashRequest)(nil), "rpcpb.SlashRequest")
+	proto.RegisterType((*OracleRequest)(nil), "rpcpb.OracleRequest")
+	proto.RegisterType((*SendRawTransactionRequest)(nil), "rpcpb.SendRawTransactionRequest")
+	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
+	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
+	proto.RegisterType((*GetBlockByHeightRequest)(nil), "rpcpb.GetBlockByHeightRequest")
+	proto.RegisterType((*GetTransactionByHashRequest)(nil), "rpcpb.GetTransactionByHashRequest")
+	proto.RegisterType((*BlockDumpRequest)(nil), "rpcpb.BlockDumpRequest")
+	proto.RegisterType((*BlockDumpResponse)(nil), "rpcpb.BlockDumpResponse")
+	proto.RegisterType((*TransactionReceiptResponse)(nil), "rpcpb.TransactionReceiptResponse")
+	proto.RegisterType((*NewAccountRequest)(nil), "rpcpb.NewAccountRequest")
+	proto.RegisterType((*NewAccountResponse)(nil), "rpcpb.NewAccountResponse")
+	proto.RegisterType((*UnlockAccountRequest)(nil), "rpcpb.UnlockAccountRequest")
+	proto.RegisterType((*UnlockAccountResponse)(nil), "rpcpb.UnlockAccountResponse")
+	proto.RegisterType((*LockAccountRequest)(nil), "rpcpb.LockAccountRequest")
+	proto.RegisterType((*LockAccountResponse)(nil), "rpcpb.LockAccountResponse")
+	proto.RegisterType((*SignTransactionResponse)(nil), "rpcpb.SignTransactionResponse")
+	proto.RegisterType((*SendTransactionPassphraseRequest)(nil), "rpcpb.SendTransactionPassphraseRequest")
+	proto.RegisterType((*SendTransactionPassphraseResponse)(nil), "rpcpb.SendTransactionPassphraseResponse")
+	proto.RegisterType((*GasPriceResponse)(nil), "rpcpb.GasPriceResponse")
+	proto.RegisterType((*EstimateGasResponse)(nil), "rpcpb.EstimateGasResponse")
+	proto.RegisterType((*EventsResponse)(nil), "rpcpb.EventsResponse")
+	proto.RegisterType((*Event)(nil), "rpcpb.Event")
+	proto.RegisterType((*NewEventFilterRequest)(nil), "rpcpb.NewEventFilterRequest")
+	proto.RegisterType((*NewEventFilterResponse)(nil), "rpcpb.NewEventFilterResponse")
+	proto.RegisterType((*EventFilterRequest)(nil), "rpcpb.EventFilterRequest")
+	proto.RegisterType((*StoredEvent)(nil), "rpcpb.StoredEvent")
+	proto.RegisterType((*FilterEventsResponse)(nil), "rpcpb.FilterEventsResponse")
+	proto.RegisterType((*UninstallEventFilterResponse)(nil), "rpcpb.UninstallEventFilterResponse")
+	proto.RegisterType((*SyncStatusResponse)(nil), "rpcpb.SyncStatusResponse")
+	proto.RegisterType((*ExecutionEnvironmentResponse)(nil), "rpcpb.ExecutionEnvironmentResponse")
+	proto.RegisterType((*ConvertRequest)(nil), "rpcpb.ConvertRequest")
+	proto.RegisterType((*ConvertResponse)(nil), "rpcpb.ConvertResponse")
+	proto.RegisterType((*TxPoolSender)(nil), "rpcpb.TxPoolSender")
+	proto.RegisterType((*InspectTransactionPoolResponse)(nil), "rpcpb.InspectTransactionPoolResponse")
+	proto.RegisterType((*DropTransactionResponse)(nil), "rpcpb.DropTransactionResponse")
+	proto.RegisterType((*SetAccountPolicyRequest)(nil), "rpcpb.SetAccountPolicyRequest")
+	proto.RegisterType((*SetAccountPolicyResponse)(nil), "rpcpb.SetAccountPolicyResponse")
+	proto.RegisterType((*GetSigningAuditRequest)(nil), "rpcpb.GetSigningAuditRequest")
+	proto.RegisterType((*SigningRecord)(nil), "rpcpb.SigningRecord")
+	proto.RegisterType((*GetSigningAuditResponse)(nil), "rpcpb.GetSigningAuditResponse")
+	proto.RegisterType((*PeerInfo)(nil), "rpcpb.PeerInfo")
+	proto.RegisterType((*GetPeersResponse)(nil), "rpcpb.GetPeersResponse")
+	proto.RegisterType((*BanPeerRequest)(nil), "rpcpb.BanPeerRequest")
+	proto.RegisterType((*UnbanPeerRequest)(nil), "rpcpb.UnbanPeerRequest")
+	proto.RegisterType((*BanPeerResponse)(nil), "rpcpb.BanPeerResponse")
+	proto.RegisterType((*ExportPeersResponse)(nil), "rpcpb.ExportPeersResponse")
+	proto.RegisterType((*ImportPeersRequest)(nil), "rpcpb.ImportPeersRequest")
+	proto.RegisterType((*ImportPeersResponse)(nil), "rpcpb.ImportPeersResponse")
+	proto.RegisterType((*BootNodeInfo)(nil), "rpcpb.BootNodeInfo")
+	proto.RegisterType((*GetBootNodesResponse)(nil), "rpcpb.GetBootNodesResponse")
+	proto.RegisterType((*BootNodeRequest)(nil), "rpcpb.BootNodeRequest")
+	proto.RegisterType((*BootNodeResponse)(nil), "rpcpb.BootNodeResponse")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "rpcpb.SetLogLevelRequest")
+	proto.RegisterType((*SetLogLevelResponse)(nil), "rpcpb.SetLogLevelResponse")
+	proto.RegisterType((*ExportChainRequest)(nil), "rpcpb.ExportChainRequest")
+	proto.RegisterType((*ExportChainResponse)(nil), "rpcpb.ExportChainResponse")
+	proto.RegisterType((*SetProfilingRequest)(nil), "rpcpb.SetProfilingRequest")
+	proto.RegisterType((*SetProfilingResponse)(nil), "rpcpb.SetProfilingResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for ApiService service
+
+type ApiServiceClient interface {
+	// Return the state of the neb.
+	GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error)
+	// Return the p2p node info.
+	NodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error)
+	// Return the dump info of blockchain.
+	BlockDump(ctx context.Context, in *BlockDumpRequest, opts ...grpc.CallOption) (*BlockDumpResponse, error)
+	// Accounts return account list.
+	Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
+	// Return the state of the account.
+	GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error)
+	// Verify, sign, and send the transaction.
+	SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// Call smart contract.
+	Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// Submit the signed transaction.
+	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// Get block header info by the block hash.
+	GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*corepb.Block, error)
+	// Get block header info by the height of block in canonical chain.
+	GetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*corepb.Block, error)
+	// Get the transaction by hash, in chain or pending in the tx pool.
+	GetTransactionByHash(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*corepb.Transaction, error)
+	// Get transactionReceipt info by tansaction hash.
+	GetTransactionReceipt(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*TransactionReceiptResponse, error)
+	// Subscribe message
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
+	// Get GasPrice
+	GetGasPrice(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GasPriceResponse, error)
+	// EstimateGas returns the least gas limit the transaction succeeds with, simulated on the tail.
+	EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error)
+	GetEventsByHash(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+	// NewEventFilter installs a filter of events by topics, contract and heights, it expires if not polled in 5 minutes.
+	NewEventFilter(ctx context.Context, in *NewEventFilterRequest, opts ...grpc.CallOption) (*NewEventFilterResponse, error)
+	// GetFilterChanges returns the events of the filter in canonical chain since the last poll.
+	GetFilterChanges(ctx context.Context, in *EventFilterRequest, opts ...grpc.CallOption) (*FilterEventsResponse, error)
+	// GetFilterLogs returns the events of the filter in the event store.
+	GetFilterLogs(ctx context.Context, in *EventFilterRequest, opts ...grpc.CallOption) (*FilterEventsResponse, error)
+	// UninstallEventFilter removes a filter.
+	UninstallEventFilter(ctx context.Context, in *EventFilterRequest, opts ...grpc.CallOption) (*UninstallEventFilterResponse, error)
+	// Return the execution environment and its fingerprint, which should be the same on all validators.
+	GetExecutionEnvironment(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ExecutionEnvironmentResponse, error)
+	// Return the progress of syncing with peers.
+	GetSyncStatus(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*SyncStatusResponse, error)
+	// Return the validators of the dynasty with their mint counts, and the candidates with their votes, at a block.
+	GetDynastySnapshot(ctx context.Context, in *GetDynastySnapshotRequest, opts ...grpc.CallOption) (*GetDynastySnapshotResponse, error)
+	// Return the value of a key in a trie of a block, and its merkle proof against the root in the header,
+	// proving the balance of an account or the inclusion of a transaction to the clients without the chain.
+	GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*GetProofResponse, error)
+	// Convert the chain data from protobuf to the JSON representation of package core/pbjson.
+	ProtoToJSON(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// Convert the chain data from the JSON representation of package core/pbjson to protobuf.
+	JSONToProto(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// Return the next safe nonce of an account after the chain and the txs in pool, reserved for the caller
+	// for a while, so the concurrent submitters of an account never get the same nonce.
+	GetNextNonce(ctx context.Context, in *GetNextNonceRequest, opts ...grpc.CallOption) (*GetNextNonceResponse, error)
+	// Give back a nonce reserved by GetNextNonce not to be used.
+	ReleaseNonce(ctx context.Context, in *ReleaseNonceRequest, opts ...grpc.CallOption) (*ReleaseNonceResponse, error)
+	// Return the ABI and metadata a contract is deployed or last upgraded with, for the wallets to render
+	// its callable functions.
+	GetContractMetadata(ctx context.Context, in *GetContractMetadataRequest, opts ...grpc.CallOption) (*GetContractMetadataResponse, error)
+}
+
+type apiServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewApiServiceClient(cc *grpc.ClientConn) ApiServiceClient {
+	return &apiServiceClient{cc}
+}
+
+func (c *apiServiceClient) GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error) {
+	out := new(GetNebStateResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetNebState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) NodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error) {
+	out := new(NodeInfoResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/NodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) BlockDump(ctx context.Context, in *BlockDumpRequest, opts ...grpc.CallOption) (*BlockDumpResponse, error) {
+	out := new(BlockDumpResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/BlockDump", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
+	out := new(AccountsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/Accounts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error) {
+	out := new(GetAccountStateResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetAccountState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/SendTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/Call", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/SendRawTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*corepb.Block, error) {
+	out := new(corepb.Block)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHash", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*corepb.Block, error) {
+	out := new(corepb.Block)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHeight", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetTransactionByHash(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*corepb.Transaction, error) {
+	out := new(corepb.Transaction)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetTransactionByHash", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetTransactionReceipt(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*TransactionReceiptResponse, error) {
+	out := new(TransactionReceiptResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetTransactionReceipt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ApiService_serviceDesc.Streams[0], c.cc, "/rpcpb.ApiService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApiService_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
+	grpc.ClientStream
+}
+
+type apiServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *apiServiceClient) GetGasPrice(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GasPriceResponse, error) {
+	out := new(GasPriceResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetGasPrice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error) {
+	out := new(EstimateGasResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/EstimateGas", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetEventsByHash(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*EventsResponse, error) {
+	out := new(EventsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetEventsByHash", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) NewEventFilter(ctx context.Context, in *NewEventFilterRequest, opts ...grpc.CallOption) (*NewEventFilterResponse, error) {
+	out := new(NewEventFilterResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/NewEventFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetFilterChanges(ctx context.Context, in *EventFilterRequest, opts ...grpc.CallOption) (*FilterEventsResponse, error) {
+	out := new(FilterEventsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetFilterChanges", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetFilterLogs(ctx context.Context, in *EventFilterRequest, opts ...grpc.CallOption) (*FilterEventsResponse, error) {
+	out := new(FilterEventsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetFilterLogs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) UninstallEventFilter(ctx context.Context, in *EventFilterRequest, opts ...grpc.CallOption) (*UninstallEventFilterResponse, error) {
+	out := new(UninstallEventFilterResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/UninstallEventFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetExecutionEnvironment(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ExecutionEnvironmentResponse, error) {
+	out := new(ExecutionEnvironmentResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetExecutionEnvironment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetSyncStatus(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*SyncStatusResponse, error) {
+	out := new(SyncStatusResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetSyncStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetDynastySnapshot(ctx context.Context, in *GetDynastySnapshotRequest, opts ...grpc.CallOption) (*GetDynastySnapshotResponse, error) {
+	out := new(GetDynastySnapshotResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetDynastySnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*GetProofResponse, error) {
+	out := new(GetProofResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetProof", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ProtoToJSON(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	out := new(ConvertResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/ProtoToJSON", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) JSONToProto(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	out := new(ConvertResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/JSONToProto", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetNextNonce(ctx context.Context, in *GetNextNonceRequest, opts ...grpc.CallOption) (*GetNextNonceResponse, error) {
+	out := new(GetNextNonceResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetNextNonce", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ReleaseNonce(ctx context.Context, in *ReleaseNonceRequest, opts ...grpc.CallOption) (*ReleaseNonceResponse, error) {
+	out := new(ReleaseNonceResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/ReleaseNonce", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetContractMetadata(ctx context.Context, in *GetContractMetadataRequest, opts ...grpc.CallOption) (*GetContractMetadataResponse, error) {
+	out := new(GetContractMetadataResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetContractMetadata", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ApiService service
+
+type ApiServiceServer interface {
+	// Return the state of the neb.
+	GetNebState(context.Context, *NonParamsRequest) (*GetNebStateResponse, error)
+	// Return the p2p node info.
+	NodeInfo(context.Context, *NonParamsRequest) (*NodeInfoResponse, error)
+	// Return the dump info of blockchain.
+	BlockDump(context.Context, *BlockDumpRequest) (*BlockDumpResponse, error)
+	// Accounts return account list.
+	Accounts(context.Context, *NonParamsRequest) (*AccountsResponse, error)
+	// Return the state of the account.
+	GetAccountState(context.Context, *GetAccountStateRequest) (*GetAccountStateResponse, error)
+	// Verify, sign, and send the transaction.
+	SendTransaction(context.Context, *TransactionRequest) (*SendTransactionResponse, error)
+	// Call smart contract.
+	Call(context.Context, *TransactionRequest) (*SendTransactionResponse, error)
+	// Submit the signed transaction.
+	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendTransactionResponse, error)
+	// Get block header info by the block hash.
+	GetBlockByHash(context.Context, *GetBlockByHashRequest) (*corepb.Block, error)
+	// Get block header info by the height of block in canonical chain.
+	GetBlockByHeight(context.Context, *GetBlockByHeightRequest) (*corepb.Block, error)
+	// Get the transaction by hash, in chain or pending in the tx pool.
+	GetTransactionByHash(context.Context, *GetTransactionByHashRequest) (*corepb.Transaction, error)
+	// Get transactionReceipt info by tansaction hash.
+	GetTransactionReceipt(context.Context, *GetTransactionByHashRequest) (*TransactionReceiptResponse, error)
+	// Subscribe message
+	Subscribe(*SubscribeRequest, ApiService_SubscribeServer) error
+	// Get GasPrice
+	GetGasPrice(context.Context, *NonParamsRequest) (*GasPriceResponse, error)
+	// EstimateGas returns the least gas limit the transaction succeeds with, simulated on the tail.
+	EstimateGas(context.Context, *TransactionRequest) (*EstimateGasResponse, error)
+	GetEventsByHash(context.Context, *GetTransactionByHashRequest) (*EventsResponse, error)
+	// NewEventFilter installs a filter of events by topics, contract and heights, it expires if not polled in 5 minutes.
+	NewEventFilter(context.Context, *NewEventFilterRequest) (*NewEventFilterResponse, error)
+	// GetFilterChanges returns the events of the filter in canonical chain since the last poll.
+	GetFilterChanges(context.Context, *EventFilterRequest) (*FilterEventsResponse, error)
+	// GetFilterLogs returns the events of the filter in the event store.
+	GetFilterLogs(context.Context, *EventFilterRequest) (*FilterEventsResponse, error)
+	// UninstallEventFilter removes a filter.
+	UninstallEventFilter(context.Context, *EventFilterRequest) (*UninstallEventFilterResponse, error)
+	// Return the execution environment and its fingerprint, which should be the same on all validators.
+	GetExecutionEnvironment(context.Context, *NonParamsRequest) (*ExecutionEnvironmentResponse, error)
+	// Return the progress of syncing with peers.
+	GetSyncStatus(context.Context, *NonParamsRequest) (*SyncStatusResponse, error)
+	// Return the validators of the dynasty with their mint counts, and the candidates with their votes, at a block.
+	GetDynastySnapshot(context.Context, *GetDynastySnapshotRequest) (*GetDynastySnapshotResponse, error)
+	// Return the value of a key in a trie of a block, and its merkle proof against the root in the header,
+	// proving the balance of an account or the inclusion of a transaction to the clients without the chain.
+	GetProof(context.Context, *GetProofRequest) (*GetProofResponse, error)
+	// Convert the chain data from protobuf to the JSON representation of package core/pbjson.
+	ProtoToJSON(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// Convert the chain data from the JSON representation of package core/pbjson to protobuf.
+	JSONToProto(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// Return the next safe nonce of an account after the chain and the txs in pool, reserved for the caller
+	// for a while, so the concurrent submitters of an account never get the same nonce.
+	GetNextNonce(context.Context, *GetNextNonceRequest) (*GetNextNonceResponse, error)
+	// Give back a nonce reserved by GetNextNonce not to be used.
+	ReleaseNonce(context.Context, *ReleaseNonceRequest) (*ReleaseNonceResponse, error)
+	// Return the ABI and metadata a contract is deployed or last upgraded with, for the wallets to render
+	// its callable functions.
+	GetContractMetadata(context.Context, *GetContractMetadataRequest) (*GetContractMetadataResponse, error)
+}
+
+func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
+	s.RegisterService(&_ApiService_serviceDesc, srv)
+}
+
+func _ApiService_GetNebState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetNebState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetNebState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetNebState(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_NodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).NodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/NodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).NodeInfo(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_BlockDump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockDumpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).BlockDump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/BlockDump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).BlockDump(ctx, req.(*BlockDumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_Accounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).Accounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/Accounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).Accounts(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetAccountState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetAccountState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetAccountState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetAccountState(ctx, req.(*GetAccountStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/SendTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SendTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).Call(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/SendRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SendRawTransaction(ctx, req.(*SendRawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockByHash(ctx, req.(*GetBlockByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlockByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockByHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockByHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockByHeight(ctx, req.(*GetBlockByHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetTransactionByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTransactionByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTransactionByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTransactionByHash(ctx, req.(*GetTransactionByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetTransactionReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTransactionReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTransactionReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTransactionReceipt(ctx, req.(*GetTransactionByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiServiceServer).Subscribe(m, &apiServiceSubscribeServer{stream})
+}
+
+type ApiService_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type apiServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiServiceSubscribeServer) Send(m *SubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ApiService_GetGasPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetGasPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetGasPrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetGasPrice(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetNebState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NonParamsRequest)
+func _ApiService_EstimateGas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetNebState(ctx, in)
+		return srv.(ApiServiceServer).EstimateGas(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetNebState",
+		FullMethod: "/rpcpb.ApiService/EstimateGas",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetNebState(ctx, req.(*NonParamsRequest))
+		return srv.(ApiServiceServer).EstimateGas(ctx, req.(*TransactionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_NodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NonParamsRequest)
+func _ApiService_GetEventsByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionByHashRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).NodeInfo(ctx, in)
+		return srv.(ApiServiceServer).GetEventsByHash(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/NodeInfo",
+		FullMethod: "/rpcpb.ApiService/GetEventsByHash",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).NodeInfo(ctx, req.(*NonParamsRequest))
+		return srv.(ApiServiceServer).GetEventsByHash(ctx, req.(*GetTransactionByHashRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_BlockDump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BlockDumpRequest)
+func _ApiService_NewEventFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewEventFilterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).BlockDump(ctx, in)
+		return srv.(ApiServiceServer).NewEventFilter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/BlockDump",
+		FullMethod: "/rpcpb.ApiService/NewEventFilter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).BlockDump(ctx, req.(*BlockDumpRequest))
+		return srv.(ApiServiceServer).NewEventFilter(ctx, req.(*NewEventFilterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_Accounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NonParamsRequest)
+func _ApiService_GetFilterChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventFilterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).Accounts(ctx, in)
+		return srv.(ApiServiceServer).GetFilterChanges(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/Accounts",
+		FullMethod: "/rpcpb.ApiService/GetFilterChanges",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).Accounts(ctx, req.(*NonParamsRequest))
+		return srv.(ApiServiceServer).GetFilterChanges(ctx, req.(*EventFilterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetAccountState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAccountStateRequest)
+func _ApiService_GetFilterLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventFilterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetAccountState(ctx, in)
+		return srv.(ApiServiceServer).GetFilterLogs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetAccountState",
+		FullMethod: "/rpcpb.ApiService/GetFilterLogs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetAccountState(ctx, req.(*GetAccountStateRequest))
+		return srv.(ApiServiceServer).GetFilterLogs(ctx, req.(*EventFilterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionRequest)
+func _ApiService_UninstallEventFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventFilterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).SendTransaction(ctx, in)
+		return srv.(ApiServiceServer).UninstallEventFilter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/SendTransaction",
+		FullMethod: "/rpcpb.ApiService/UninstallEventFilter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).SendTransaction(ctx, req.(*TransactionRequest))
+		return srv.(ApiServiceServer).UninstallEventFilter(ctx, req.(*EventFilterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionRequest)
+func _ApiService_GetExecutionEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).Call(ctx, in)
+		return srv.(ApiServiceServer).GetExecutionEnvironment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/Call",
+		FullMethod: "/rpcpb.ApiService/GetExecutionEnvironment",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).Call(ctx, req.(*TransactionRequest))
+		return srv.(ApiServiceServer).GetExecutionEnvironment(ctx, req.(*NonParamsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendRawTransactionRequest)
+func _ApiService_GetSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).SendRawTransaction(ctx, in)
+		return srv.(ApiServiceServer).GetSyncStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/SendRawTransaction",
+		FullMethod: "/rpcpb.ApiService/GetSyncStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).SendRawTransaction(ctx, req.(*SendRawTransactionRequest))
+		return srv.(ApiServiceServer).GetSyncStatus(ctx, req.(*NonParamsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBlockByHashRequest)
+func _ApiService_GetDynastySnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDynastySnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetBlockByHash(ctx, in)
+		return srv.(ApiServiceServer).GetDynastySnapshot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetBlockByHash",
+		FullMethod: "/rpcpb.ApiService/GetDynastySnapshot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetBlockByHash(ctx, req.(*GetBlockByHashRequest))
+		return srv.(ApiServiceServer).GetDynastySnapshot(ctx, req.(*GetDynastySnapshotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetTransactionReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionByHashRequest)
+func _ApiService_GetProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProofRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetTransactionReceipt(ctx, in)
+		return srv.(ApiServiceServer).GetProof(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetTransactionReceipt",
+		FullMethod: "/rpcpb.ApiService/GetProof",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetTransactionReceipt(ctx, req.(*GetTransactionByHashRequest))
+		return srv.(ApiServiceServer).GetProof(ctx, req.(*GetProofRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubscribeRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _ApiService_ProtoToJSON_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(ApiServiceServer).Subscribe(m, &apiServiceSubscribeServer{stream})
-}
-
-type ApiService_SubscribeServer interface {
-	Send(*SubscribeResponse) error
-	grpc.ServerStream
-}
-
-type apiServiceSubscribeServer struct {
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ProtoToJSON(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/ProtoToJSON",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ProtoToJSON(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *apiServiceSubscribeServer) Send(m *SubscribeResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _ApiService_JSONToProto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).JSONToProto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/JSONToProto",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).JSONToProto(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetGasPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NonParamsRequest)
+func _ApiService_GetNextNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextNonceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetGasPrice(ctx, in)
+		return srv.(ApiServiceServer).GetNextNonce(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetGasPrice",
+		FullMethod: "/rpcpb.ApiService/GetNextNonce",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetGasPrice(ctx, req.(*NonParamsRequest))
+		return srv.(ApiServiceServer).GetNextNonce(ctx, req.(*GetNextNonceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_EstimateGas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionRequest)
+func _ApiService_ReleaseNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseNonceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).EstimateGas(ctx, in)
+		return srv.(ApiServiceServer).ReleaseNonce(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/EstimateGas",
+		FullMethod: "/rpcpb.ApiService/ReleaseNonce",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).EstimateGas(ctx, req.(*TransactionRequest))
+		return srv.(ApiServiceServer).ReleaseNonce(ctx, req.(*ReleaseNonceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ApiService_GetEventsByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionByHashRequest)
+func _ApiService_GetContractMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContractMetadataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApiServiceServer).GetEventsByHash(ctx, in)
+		return srv.(ApiServiceServer).GetContractMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.ApiService/GetEventsByHash",
+		FullMethod: "/rpcpb.ApiService/GetContractMetadata",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApiServiceServer).GetEventsByHash(ctx, req.(*GetTransactionByHashRequest))
+		return srv.(ApiServiceServer).GetContractMetadata(ctx, req.(*GetContractMetadataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1746,6 +4061,14 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockByHash",
 			Handler:    _ApiService_GetBlockByHash_Handler,
 		},
+		{
+			MethodName: "GetBlockByHeight",
+			Handler:    _ApiService_GetBlockByHeight_Handler,
+		},
+		{
+			MethodName: "GetTransactionByHash",
+			Handler:    _ApiService_GetTransactionByHash_Handler,
+		},
 		{
 			MethodName: "GetTransactionReceipt",
 			Handler:    _ApiService_GetTransactionReceipt_Handler,
@@ -1762,6 +4085,58 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetEventsByHash",
 			Handler:    _ApiService_GetEventsByHash_Handler,
 		},
+		{
+			MethodName: "NewEventFilter",
+			Handler:    _ApiService_NewEventFilter_Handler,
+		},
+		{
+			MethodName: "GetFilterChanges",
+			Handler:    _ApiService_GetFilterChanges_Handler,
+		},
+		{
+			MethodName: "GetFilterLogs",
+			Handler:    _ApiService_GetFilterLogs_Handler,
+		},
+		{
+			MethodName: "UninstallEventFilter",
+			Handler:    _ApiService_UninstallEventFilter_Handler,
+		},
+		{
+			MethodName: "GetExecutionEnvironment",
+			Handler:    _ApiService_GetExecutionEnvironment_Handler,
+		},
+		{
+			MethodName: "GetSyncStatus",
+			Handler:    _ApiService_GetSyncStatus_Handler,
+		},
+		{
+			MethodName: "GetDynastySnapshot",
+			Handler:    _ApiService_GetDynastySnapshot_Handler,
+		},
+		{
+			MethodName: "GetProof",
+			Handler:    _ApiService_GetProof_Handler,
+		},
+		{
+			MethodName: "ProtoToJSON",
+			Handler:    _ApiService_ProtoToJSON_Handler,
+		},
+		{
+			MethodName: "JSONToProto",
+			Handler:    _ApiService_JSONToProto_Handler,
+		},
+		{
+			MethodName: "GetNextNonce",
+			Handler:    _ApiService_GetNextNonce_Handler,
+		},
+		{
+			MethodName: "ReleaseNonce",
+			Handler:    _ApiService_ReleaseNonce_Handler,
+		},
+		{
+			MethodName: "GetContractMetadata",
+			Handler:    _ApiService_GetContractMetadata_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1790,6 +4165,36 @@ type AdminServiceClient interface {
 	GetDynasty(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetDynastyResponse, error)
 	GetDelegateVoters(ctx context.Context, in *GetDelegateVotersRequest, opts ...grpc.CallOption) (*GetDelegateVotersResponse, error)
 	ChangeNetworkID(ctx context.Context, in *ChangeNetworkIDRequest, opts ...grpc.CallOption) (*ChangeNetworkIDResponse, error)
+	// InspectTransactionPool summarizes the transactions in pool by sender.
+	InspectTransactionPool(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*InspectTransactionPoolResponse, error)
+	// DropTransaction evicts a stuck transaction from pool.
+	DropTransaction(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*DropTransactionResponse, error)
+	// SetAccountPolicy sets the unlock duration and spend limit of an account.
+	SetAccountPolicy(ctx context.Context, in *SetAccountPolicyRequest, opts ...grpc.CallOption) (*SetAccountPolicyResponse, error)
+	// GetSigningAudit returns the latest signing operations of the accounts.
+	GetSigningAudit(ctx context.Context, in *GetSigningAuditRequest, opts ...grpc.CallOption) (*GetSigningAuditResponse, error)
+	// GetPeers returns the known peers with their addresses and scores.
+	GetPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetPeersResponse, error)
+	// BanPeer disconnects a peer and refuses it for a duration.
+	BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BanPeerResponse, error)
+	// UnbanPeer lifts the ban of a peer.
+	UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*BanPeerResponse, error)
+	// ExportPeers returns the peer address book in JSON.
+	ExportPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ExportPeersResponse, error)
+	// ImportPeers seeds the peers from a peer address book in JSON.
+	ImportPeers(ctx context.Context, in *ImportPeersRequest, opts ...grpc.CallOption) (*ImportPeersResponse, error)
+	// GetBootNodes returns the boot nodes with their health.
+	GetBootNodes(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetBootNodesResponse, error)
+	// AddBootNode adds a boot node at runtime, it's dialed in background.
+	AddBootNode(ctx context.Context, in *BootNodeRequest, opts ...grpc.CallOption) (*BootNodeResponse, error)
+	// RemoveBootNode removes a boot node at runtime, the connection to it is kept.
+	RemoveBootNode(ctx context.Context, in *BootNodeRequest, opts ...grpc.CallOption) (*BootNodeResponse, error)
+	// SetLogLevel changes the level of the verbose log at runtime.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// ExportChain starts to export the canonical blocks into a chain dump file in the export directory of the node.
+	ExportChain(ctx context.Context, in *ExportChainRequest, opts ...grpc.CallOption) (*ExportChainResponse, error)
+	// SetProfiling starts or stops serving pprof.
+	SetProfiling(ctx context.Context, in *SetProfilingRequest, opts ...grpc.CallOption) (*SetProfilingResponse, error)
 }
 
 type adminServiceClient struct {
@@ -1809,72 +4214,207 @@ func (c *adminServiceClient) NewAccount(ctx context.Context, in *NewAccountReque
 	return out, nil
 }
 
-func (c *adminServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
-	out := new(UnlockAccountResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/UnlockAccount", in, out, c.cc, opts...)
+func (c *adminServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
+	out := new(UnlockAccountResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/UnlockAccount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*LockAccountResponse, error) {
+	out := new(LockAccountResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/LockAccount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SignTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error) {
+	out := new(SignTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SignTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SendTransactionWithPassphrase(ctx context.Context, in *SendTransactionPassphraseRequest, opts ...grpc.CallOption) (*SendTransactionPassphraseResponse, error) {
+	out := new(SendTransactionPassphraseResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SendTransactionWithPassphrase", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StatisticsNodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*StatisticsNodeInfoResponse, error) {
+	out := new(StatisticsNodeInfoResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/StatisticsNodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetDynasty(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetDynastyResponse, error) {
+	out := new(GetDynastyResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetDynasty", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetDelegateVoters(ctx context.Context, in *GetDelegateVotersRequest, opts ...grpc.CallOption) (*GetDelegateVotersResponse, error) {
+	out := new(GetDelegateVotersResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetDelegateVoters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ChangeNetworkID(ctx context.Context, in *ChangeNetworkIDRequest, opts ...grpc.CallOption) (*ChangeNetworkIDResponse, error) {
+	out := new(ChangeNetworkIDResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/ChangeNetworkID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) InspectTransactionPool(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*InspectTransactionPoolResponse, error) {
+	out := new(InspectTransactionPoolResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/InspectTransactionPool", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DropTransaction(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*DropTransactionResponse, error) {
+	out := new(DropTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/DropTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetAccountPolicy(ctx context.Context, in *SetAccountPolicyRequest, opts ...grpc.CallOption) (*SetAccountPolicyResponse, error) {
+	out := new(SetAccountPolicyResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SetAccountPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetSigningAudit(ctx context.Context, in *GetSigningAuditRequest, opts ...grpc.CallOption) (*GetSigningAuditResponse, error) {
+	out := new(GetSigningAuditResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetSigningAudit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetPeersResponse, error) {
+	out := new(GetPeersResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BanPeerResponse, error) {
+	out := new(BanPeerResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/BanPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*BanPeerResponse, error) {
+	out := new(BanPeerResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/UnbanPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ExportPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ExportPeersResponse, error) {
+	out := new(ExportPeersResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/ExportPeers", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*LockAccountResponse, error) {
-	out := new(LockAccountResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/LockAccount", in, out, c.cc, opts...)
+func (c *adminServiceClient) ImportPeers(ctx context.Context, in *ImportPeersRequest, opts ...grpc.CallOption) (*ImportPeersResponse, error) {
+	out := new(ImportPeersResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/ImportPeers", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) SignTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error) {
-	out := new(SignTransactionResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SignTransaction", in, out, c.cc, opts...)
+func (c *adminServiceClient) GetBootNodes(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetBootNodesResponse, error) {
+	out := new(GetBootNodesResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetBootNodes", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) SendTransactionWithPassphrase(ctx context.Context, in *SendTransactionPassphraseRequest, opts ...grpc.CallOption) (*SendTransactionPassphraseResponse, error) {
-	out := new(SendTransactionPassphraseResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SendTransactionWithPassphrase", in, out, c.cc, opts...)
+func (c *adminServiceClient) AddBootNode(ctx context.Context, in *BootNodeRequest, opts ...grpc.CallOption) (*BootNodeResponse, error) {
+	out := new(BootNodeResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/AddBootNode", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) StatisticsNodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*StatisticsNodeInfoResponse, error) {
-	out := new(StatisticsNodeInfoResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/StatisticsNodeInfo", in, out, c.cc, opts...)
+func (c *adminServiceClient) RemoveBootNode(ctx context.Context, in *BootNodeRequest, opts ...grpc.CallOption) (*BootNodeResponse, error) {
+	out := new(BootNodeResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/RemoveBootNode", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) GetDynasty(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetDynastyResponse, error) {
-	out := new(GetDynastyResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetDynasty", in, out, c.cc, opts...)
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SetLogLevel", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) GetDelegateVoters(ctx context.Context, in *GetDelegateVotersRequest, opts ...grpc.CallOption) (*GetDelegateVotersResponse, error) {
-	out := new(GetDelegateVotersResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetDelegateVoters", in, out, c.cc, opts...)
+func (c *adminServiceClient) ExportChain(ctx context.Context, in *ExportChainRequest, opts ...grpc.CallOption) (*ExportChainResponse, error) {
+	out := new(ExportChainResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/ExportChain", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) ChangeNetworkID(ctx context.Context, in *ChangeNetworkIDRequest, opts ...grpc.CallOption) (*ChangeNetworkIDResponse, error) {
-	out := new(ChangeNetworkIDResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/ChangeNetworkID", in, out, c.cc, opts...)
+func (c *adminServiceClient) SetProfiling(ctx context.Context, in *SetProfilingRequest, opts ...grpc.CallOption) (*SetProfilingResponse, error) {
+	out := new(SetProfilingResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SetProfiling", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1898,6 +4438,36 @@ type AdminServiceServer interface {
 	GetDynasty(context.Context, *NonParamsRequest) (*GetDynastyResponse, error)
 	GetDelegateVoters(context.Context, *GetDelegateVotersRequest) (*GetDelegateVotersResponse, error)
 	ChangeNetworkID(context.Context, *ChangeNetworkIDRequest) (*ChangeNetworkIDResponse, error)
+	// InspectTransactionPool summarizes the transactions in pool by sender.
+	InspectTransactionPool(context.Context, *NonParamsRequest) (*InspectTransactionPoolResponse, error)
+	// DropTransaction evicts a stuck transaction from pool.
+	DropTransaction(context.Context, *GetTransactionByHashRequest) (*DropTransactionResponse, error)
+	// SetAccountPolicy sets the unlock duration and spend limit of an account.
+	SetAccountPolicy(context.Context, *SetAccountPolicyRequest) (*SetAccountPolicyResponse, error)
+	// GetSigningAudit returns the latest signing operations of the accounts.
+	GetSigningAudit(context.Context, *GetSigningAuditRequest) (*GetSigningAuditResponse, error)
+	// GetPeers returns the known peers with their addresses and scores.
+	GetPeers(context.Context, *NonParamsRequest) (*GetPeersResponse, error)
+	// BanPeer disconnects a peer and refuses it for a duration.
+	BanPeer(context.Context, *BanPeerRequest) (*BanPeerResponse, error)
+	// UnbanPeer lifts the ban of a peer.
+	UnbanPeer(context.Context, *UnbanPeerRequest) (*BanPeerResponse, error)
+	// ExportPeers returns the peer address book in JSON.
+	ExportPeers(context.Context, *NonParamsRequest) (*ExportPeersResponse, error)
+	// ImportPeers seeds the peers from a peer address book in JSON.
+	ImportPeers(context.Context, *ImportPeersRequest) (*ImportPeersResponse, error)
+	// GetBootNodes returns the boot nodes with their health.
+	GetBootNodes(context.Context, *NonParamsRequest) (*GetBootNodesResponse, error)
+	// AddBootNode adds a boot node at runtime, it's dialed in background.
+	AddBootNode(context.Context, *BootNodeRequest) (*BootNodeResponse, error)
+	// RemoveBootNode removes a boot node at runtime, the connection to it is kept.
+	RemoveBootNode(context.Context, *BootNodeRequest) (*BootNodeResponse, error)
+	// SetLogLevel changes the level of the verbose log at runtime.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// ExportChain starts to export the canonical blocks into a chain dump file in the export directory of the node.
+	ExportChain(context.Context, *ExportChainRequest) (*ExportChainResponse, error)
+	// SetProfiling starts or stops serving pprof.
+	SetProfiling(context.Context, *SetProfilingRequest) (*SetProfilingResponse, error)
 }
 
 func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
@@ -2066,6 +4636,276 @@ func _AdminService_ChangeNetworkID_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_InspectTransactionPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).InspectTransactionPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/InspectTransactionPool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).InspectTransactionPool(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DropTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DropTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/DropTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DropTransaction(ctx, req.(*GetTransactionByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetAccountPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetAccountPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetAccountPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetAccountPolicy(ctx, req.(*SetAccountPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetSigningAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetSigningAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/GetSigningAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetSigningAudit(ctx, req.(*GetSigningAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/GetPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetPeers(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/BanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BanPeer(ctx, req.(*BanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/UnbanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnbanPeer(ctx, req.(*UnbanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExportPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ExportPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ExportPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ExportPeers(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ImportPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ImportPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ImportPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ImportPeers(ctx, req.(*ImportPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetBootNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetBootNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/GetBootNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetBootNodes(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddBootNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddBootNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/AddBootNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddBootNode(ctx, req.(*BootNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveBootNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveBootNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RemoveBootNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveBootNode(ctx, req.(*BootNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExportChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ExportChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ExportChain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ExportChain(ctx, req.(*ExportChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetProfiling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProfilingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetProfiling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetProfiling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetProfiling(ctx, req.(*SetProfilingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AdminService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.AdminService",
 	HandlerType: (*AdminServiceServer)(nil),
@@ -2106,6 +4946,66 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ChangeNetworkID",
 			Handler:    _AdminService_ChangeNetworkID_Handler,
 		},
+		{
+			MethodName: "InspectTransactionPool",
+			Handler:    _AdminService_InspectTransactionPool_Handler,
+		},
+		{
+			MethodName: "DropTransaction",
+			Handler:    _AdminService_DropTransaction_Handler,
+		},
+		{
+			MethodName: "SetAccountPolicy",
+			Handler:    _AdminService_SetAccountPolicy_Handler,
+		},
+		{
+			MethodName: "GetSigningAudit",
+			Handler:    _AdminService_GetSigningAudit_Handler,
+		},
+		{
+			MethodName: "GetPeers",
+			Handler:    _AdminService_GetPeers_Handler,
+		},
+		{
+			MethodName: "BanPeer",
+			Handler:    _AdminService_BanPeer_Handler,
+		},
+		{
+			MethodName: "UnbanPeer",
+			Handler:    _AdminService_UnbanPeer_Handler,
+		},
+		{
+			MethodName: "ExportPeers",
+			Handler:    _AdminService_ExportPeers_Handler,
+		},
+		{
+			MethodName: "ImportPeers",
+			Handler:    _AdminService_ImportPeers_Handler,
+		},
+		{
+			MethodName: "GetBootNodes",
+			Handler:    _AdminService_GetBootNodes_Handler,
+		},
+		{
+			MethodName: "AddBootNode",
+			Handler:    _AdminService_AddBootNode_Handler,
+		},
+		{
+			MethodName: "RemoveBootNode",
+			Handler:    _AdminService_RemoveBootNode_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "ExportChain",
+			Handler:    _AdminService_ExportChain_Handler,
+		},
+		{
+			MethodName: "SetProfiling",
+			Handler:    _AdminService_SetProfiling_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api_rpc.proto",
@@ -2114,131 +5014,283 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("api_rpc.proto", fileDescriptorApiRpc) }
 
 var fileDescriptorApiRpc = []byte{
-	// 2008 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x6e, 0x1b, 0xc9,
-	0x11, 0x06, 0xa9, 0x3f, 0xb2, 0xa8, 0xdf, 0xb6, 0x25, 0x91, 0xb4, 0x24, 0xcb, 0xed, 0x0d, 0x96,
-	0xab, 0xc0, 0xe2, 0x9a, 0x4e, 0xd6, 0x0b, 0xe7, 0x64, 0xcb, 0x86, 0x2c, 0xc0, 0x11, 0x84, 0x91,
-	0xb3, 0x7b, 0x58, 0x2c, 0x88, 0xe6, 0x4c, 0x7b, 0x38, 0x30, 0x39, 0x33, 0x3b, 0xdd, 0x94, 0x56,
-	0x0a, 0x90, 0x00, 0xb9, 0xe5, 0x9c, 0x37, 0xc8, 0x21, 0x40, 0x1e, 0x22, 0x87, 0x1c, 0xf2, 0x04,
-	0x79, 0x85, 0xdc, 0xf2, 0x12, 0x41, 0xd7, 0x74, 0xcf, 0x3f, 0xad, 0x5d, 0xe4, 0x36, 0x55, 0x5d,
-	0x5d, 0x55, 0x5d, 0x5d, 0xf5, 0x55, 0x4d, 0xc3, 0x1a, 0x0b, 0xbd, 0x61, 0x14, 0xda, 0xc7, 0x61,
-	0x14, 0xc8, 0x80, 0x2c, 0x45, 0xa1, 0x1d, 0x8e, 0xba, 0x7b, 0x6e, 0x10, 0xb8, 0x13, 0xde, 0x67,
-	0xa1, 0xd7, 0x67, 0xbe, 0x1f, 0x48, 0x26, 0xbd, 0xc0, 0x17, 0xb1, 0x50, 0xf7, 0x99, 0xeb, 0xc9,
-	0xf1, 0x6c, 0x74, 0x6c, 0x07, 0xd3, 0xbe, 0xcf, 0x47, 0xb3, 0x09, 0x13, 0x5e, 0xd0, 0x77, 0x83,
-	0x27, 0x9a, 0xe8, 0xdb, 0x41, 0xc4, 0xfb, 0xe1, 0xa8, 0x3f, 0x9a, 0x04, 0xf6, 0xc7, 0x78, 0x13,
-	0xed, 0xc1, 0xe6, 0xe5, 0x6c, 0x24, 0xec, 0xc8, 0x1b, 0x71, 0x8b, 0xff, 0x30, 0xe3, 0x42, 0x92,
-	0xfb, 0xb0, 0x24, 0x83, 0xd0, 0xb3, 0xdb, 0xb5, 0xc3, 0x85, 0x5e, 0xd3, 0x8a, 0x09, 0xfa, 0x1c,
-	0x76, 0x4e, 0xc6, 0xcc, 0x77, 0xf9, 0x39, 0x97, 0xd7, 0x41, 0xf4, 0xf1, 0xec, 0xb5, 0x91, 0xdf,
-	0x07, 0xf0, 0x63, 0xde, 0xd0, 0x73, 0xda, 0xb5, 0xc3, 0x5a, 0x6f, 0xcd, 0x6a, 0x6a, 0xce, 0x99,
-	0x43, 0x9f, 0xc2, 0x6e, 0x69, 0xa3, 0x08, 0x03, 0x5f, 0x70, 0xb2, 0x03, 0xcb, 0x11, 0x17, 0xb3,
-	0x89, 0xc4, 0x5d, 0x0d, 0x4b, 0x53, 0xf4, 0x15, 0x6c, 0x65, 0xbc, 0xd2, 0xc2, 0x1d, 0x68, 0x4c,
-	0x85, 0x3b, 0x94, 0x37, 0x21, 0x47, 0xf1, 0xa6, 0xb5, 0x32, 0x15, 0xee, 0xfb, 0x9b, 0x90, 0x13,
-	0x02, 0x8b, 0x0e, 0x93, 0xac, 0x5d, 0x47, 0x36, 0x7e, 0x53, 0x02, 0x9b, 0xe7, 0x81, 0x7f, 0xc1,
-	0x22, 0x36, 0x15, 0xda, 0x53, 0xfa, 0xf7, 0x05, 0xc5, 0x74, 0xf8, 0x99, 0xff, 0x21, 0x48, 0xf4,
-	0xae, 0x43, 0x5d, 0xbb, 0xdd, 0xb4, 0xea, 0x9e, 0xa3, 0xec, 0xd8, 0x63, 0xe6, 0xf9, 0xea, 0x30,
-	0x75, 0x3c, 0xcc, 0x0a, 0xd2, 0x67, 0x0e, 0x69, 0xc3, 0xca, 0x15, 0x8f, 0x84, 0x17, 0xf8, 0xed,
-	0x85, 0x78, 0x45, 0x93, 0x2a, 0x06, 0x21, 0xe7, 0xd1, 0xd0, 0x0e, 0x66, 0xbe, 0x6c, 0x2f, 0xc6,
-	0x31, 0x50, 0x9c, 0x13, 0xc5, 0x20, 0x14, 0x56, 0xc5, 0x8d, 0x6f, 0x8f, 0xa3, 0xc0, 0xf7, 0x6e,
-	0xb9, 0xd3, 0x5e, 0xc2, 0xe3, 0xe6, 0x78, 0xe4, 0x21, 0xb4, 0x46, 0x33, 0xfb, 0x23, 0x97, 0x43,
-	0xe1, 0xdd, 0xf2, 0xf6, 0xf2, 0x61, 0xad, 0xb7, 0x64, 0x41, 0xcc, 0xba, 0xf4, 0x6e, 0x39, 0xe9,
-	0xc1, 0x66, 0xc4, 0x27, 0xec, 0x66, 0x68, 0x33, 0x7b, 0xcc, 0x63, 0xa9, 0x15, 0x94, 0x5a, 0x47,
-	0xfe, 0x89, 0x62, 0xa3, 0xe4, 0x11, 0x6c, 0x09, 0x19, 0x71, 0x36, 0x1d, 0x0a, 0x19, 0x44, 0x5a,
-	0xb4, 0x81, 0xa2, 0x1b, 0xf1, 0xc2, 0xa5, 0xe2, 0xa3, 0xec, 0x73, 0x68, 0xe7, 0x64, 0xf9, 0x8f,
-	0x92, 0xfb, 0x4e, 0xbc, 0xa5, 0x89, 0x5b, 0xb6, 0x33, 0x5b, 0xde, 0xe0, 0x2a, 0x6e, 0xfc, 0x02,
-	0x36, 0x31, 0x87, 0xec, 0x60, 0x32, 0x34, 0x51, 0x01, 0x8c, 0xe2, 0x86, 0xe1, 0x7f, 0xa3, 0xa3,
-	0x33, 0x80, 0x56, 0x14, 0xcc, 0x24, 0x1f, 0x4a, 0x36, 0x9a, 0xf0, 0x76, 0xeb, 0x70, 0xa1, 0xd7,
-	0x1a, 0x6c, 0x1d, 0x63, 0x56, 0x1f, 0x5b, 0x6a, 0xe5, 0xbd, 0x5a, 0xb0, 0x20, 0x4a, 0xbe, 0xe9,
-	0x1f, 0xa0, 0x7b, 0xa9, 0x12, 0x5c, 0x48, 0xcf, 0x16, 0xa5, 0x4b, 0xdb, 0x81, 0x65, 0xe4, 0xbd,
-	0xd6, 0x17, 0xa7, 0x29, 0xc5, 0x7f, 0xcb, 0x3d, 0x77, 0x2c, 0xf1, 0xea, 0x16, 0x2d, 0x4d, 0xa9,
-	0x0c, 0x79, 0xcb, 0xc4, 0x18, 0xaf, 0xad, 0x69, 0xe1, 0x37, 0xd9, 0x83, 0xe6, 0x85, 0xb9, 0x21,
-	0x73, 0x65, 0x09, 0x83, 0x7e, 0x05, 0x90, 0x7a, 0x56, 0x4a, 0x92, 0x36, 0xac, 0x30, 0xc7, 0x89,
-	0xb8, 0x10, 0xed, 0x3a, 0x56, 0x89, 0x21, 0xe9, 0x7f, 0x6b, 0x70, 0xef, 0x94, 0xcb, 0x73, 0x3e,
-	0x52, 0xee, 0xe7, 0xd2, 0x37, 0x49, 0xab, 0x5a, 0x3e, 0xad, 0x08, 0x2c, 0x4a, 0xe6, 0x4d, 0x4c,
-	0xfa, 0xaa, 0x6f, 0xd2, 0x85, 0x86, 0x1d, 0x78, 0xfe, 0x88, 0x09, 0xae, 0x9d, 0x4e, 0xe8, 0xbb,
-	0x92, 0xed, 0x01, 0x34, 0x3d, 0x31, 0x9c, 0x7a, 0xbe, 0xe7, 0xbb, 0x3a, 0xd3, 0x1a, 0x9e, 0xf8,
-	0x2d, 0xd2, 0x95, 0xb7, 0xb6, 0x5c, 0x7d, 0x6b, 0xc5, 0xa4, 0x5d, 0x29, 0x27, 0x2d, 0xfd, 0x12,
-	0x36, 0x5f, 0xda, 0xe8, 0x87, 0x48, 0x4e, 0xba, 0x07, 0x4d, 0x1d, 0x0c, 0x2e, 0x34, 0x86, 0xa4,
-	0x0c, 0xfa, 0x16, 0x76, 0x4e, 0xb9, 0xd4, 0x9b, 0x74, 0x88, 0x62, 0x1c, 0xc9, 0xc4, 0x54, 0xd7,
-	0xb7, 0x26, 0x15, 0x22, 0x21, 0x68, 0xe9, 0x08, 0xc5, 0x04, 0x3d, 0x83, 0xdd, 0x92, 0x26, 0xed,
-	0x42, 0x1b, 0x56, 0x46, 0x6c, 0xc2, 0x7c, 0x3b, 0x81, 0x0a, 0x4d, 0x2a, 0x55, 0x7e, 0xa0, 0xf8,
-	0x5a, 0x15, 0x12, 0xf4, 0x57, 0x40, 0x4e, 0xb9, 0x7c, 0x7d, 0xe3, 0x33, 0x21, 0x6f, 0x12, 0x2d,
-	0x07, 0x00, 0x0e, 0x9f, 0x70, 0x97, 0x49, 0x9e, 0x9c, 0x24, 0xc3, 0xa1, 0x5f, 0x43, 0x5b, 0xed,
-	0xd2, 0x8c, 0x6f, 0x02, 0xc9, 0x23, 0x03, 0x35, 0x2a, 0x08, 0x89, 0xa4, 0xf6, 0x21, 0x65, 0xd0,
-	0x67, 0xd0, 0xa9, 0xd8, 0x99, 0xe6, 0xf6, 0x15, 0x72, 0xb4, 0x49, 0x4d, 0xd1, 0x7f, 0xd4, 0x81,
-	0xbc, 0x8f, 0x98, 0x2f, 0x98, 0xad, 0x70, 0xdf, 0x58, 0x22, 0xb0, 0xf8, 0x21, 0x0a, 0xa6, 0xda,
-	0x08, 0x7e, 0xab, 0x74, 0x95, 0x81, 0x3e, 0x62, 0x5d, 0x06, 0xea, 0xd4, 0x57, 0x6c, 0x32, 0x33,
-	0xa9, 0x14, 0x13, 0x69, 0x2c, 0x16, 0xb1, 0x56, 0x62, 0x42, 0xa5, 0x8f, 0xcb, 0xc4, 0x30, 0x8c,
-	0x3c, 0x9b, 0x63, 0xfa, 0x34, 0xad, 0x86, 0xcb, 0xc4, 0x85, 0xa2, 0xcd, 0xe2, 0xc4, 0x9b, 0x7a,
-	0x52, 0xe7, 0x8d, 0x5a, 0x7c, 0xa7, 0x68, 0x32, 0x50, 0x39, 0xeb, 0xcb, 0x88, 0xd9, 0x12, 0x93,
-	0xa5, 0x35, 0xd8, 0xd1, 0x35, 0x7e, 0xa2, 0xd9, 0xda, 0x67, 0x2b, 0x91, 0x23, 0xbf, 0x86, 0xa6,
-	0xcd, 0x7c, 0xc7, 0x73, 0x98, 0x8c, 0x21, 0xaa, 0x35, 0xd8, 0x35, 0x9b, 0x0c, 0xdf, 0xec, 0x4a,
-	0x25, 0x95, 0x29, 0x13, 0x4d, 0x44, 0xa9, 0xd4, 0x94, 0x09, 0x6a, 0x62, 0xca, 0xc8, 0xd1, 0x5b,
-	0xd8, 0x28, 0xf8, 0xa1, 0x42, 0x2d, 0x82, 0x59, 0x94, 0xa4, 0x89, 0xa6, 0x14, 0x16, 0xc7, 0x5f,
-	0x71, 0xbb, 0x89, 0x03, 0x09, 0x31, 0x0b, 0x3b, 0x4e, 0x17, 0x1a, 0x1f, 0x66, 0x3e, 0xde, 0x83,
-	0x29, 0x4f, 0x43, 0xab, 0x0b, 0x61, 0x91, 0x2b, 0x30, 0xaa, 0x4d, 0x0b, 0xbf, 0xe9, 0x11, 0x6c,
-	0x16, 0x8f, 0xa3, 0x8c, 0xc7, 0x37, 0x69, 0x8c, 0xc7, 0x14, 0x3d, 0x85, 0x8d, 0xc2, 0x21, 0xe6,
-	0x89, 0xe6, 0xb3, 0xac, 0x5e, 0xcc, 0xb2, 0x3e, 0x74, 0x2e, 0xb9, 0xef, 0x58, 0xec, 0xba, 0x3a,
-	0x6d, 0xb0, 0x67, 0x2a, 0x85, 0xab, 0xba, 0x67, 0x4a, 0xd8, 0x55, 0x1b, 0x72, 0xd2, 0x69, 0x52,
-	0xca, 0x1f, 0xc7, 0x0a, 0x42, 0xb5, 0x07, 0x31, 0xa5, 0xf0, 0xc4, 0xdc, 0xe5, 0x30, 0x45, 0x44,
-	0xc4, 0x13, 0xc3, 0x7f, 0xa9, 0xab, 0x38, 0xed, 0xf6, 0x0b, 0xb9, 0x6e, 0xff, 0x4b, 0xd8, 0x3e,
-	0xe5, 0xf2, 0x95, 0xaa, 0xe9, 0x57, 0x37, 0x0a, 0x99, 0x33, 0x2e, 0x66, 0x2c, 0xe2, 0x37, 0x7d,
-	0x0a, 0x0f, 0x4e, 0xb9, 0xcc, 0x78, 0x78, 0xf7, 0x96, 0x1e, 0x6c, 0xa2, 0xf2, 0xd7, 0xb3, 0x69,
-	0x98, 0x99, 0x71, 0x62, 0xf4, 0xac, 0x61, 0x8b, 0x8b, 0x09, 0xfa, 0x39, 0x6c, 0x65, 0x24, 0xf5,
-	0xc9, 0xb3, 0x81, 0x32, 0xc3, 0xc5, 0xbf, 0xea, 0xd0, 0xcd, 0x45, 0xc9, 0xe6, 0x5e, 0x28, 0xb3,
-	0x5b, 0x8a, 0x5e, 0x28, 0x48, 0xd2, 0x78, 0x5f, 0x9c, 0x2a, 0x4c, 0x01, 0x2f, 0x94, 0x0a, 0x78,
-	0xb1, 0x5c, 0xc0, 0x4b, 0x95, 0x05, 0xbc, 0x9c, 0x2d, 0xe0, 0x3d, 0x68, 0x4a, 0x6f, 0xca, 0x85,
-	0x64, 0xd3, 0x10, 0xeb, 0x70, 0xc1, 0x4a, 0x19, 0xd8, 0x6c, 0x54, 0x4e, 0x37, 0x74, 0xb3, 0xc9,
-	0xce, 0x4f, 0xcd, 0xf4, 0x88, 0x79, 0x18, 0x80, 0x4f, 0xc1, 0x40, 0xab, 0x00, 0x03, 0x55, 0x29,
-	0xb1, 0x5a, 0x99, 0x12, 0xf4, 0x19, 0x6c, 0x9d, 0xf3, 0x6b, 0x0d, 0xe1, 0xe6, 0x6e, 0x0e, 0x00,
-	0x42, 0x26, 0x44, 0x38, 0x8e, 0x54, 0xf3, 0x8b, 0x63, 0x98, 0xe1, 0xd0, 0x63, 0x20, 0xd9, 0x4d,
-	0x29, 0xe4, 0x57, 0x77, 0x0f, 0x7a, 0x01, 0xf7, 0x7f, 0xe7, 0xab, 0x6b, 0x2d, 0xd8, 0x99, 0xdf,
-	0x6f, 0xf2, 0x1e, 0xd4, 0x4b, 0x1e, 0xf4, 0x61, 0xbb, 0xa0, 0xf1, 0x8e, 0x81, 0xf6, 0x18, 0xc8,
-	0xbb, 0x9f, 0xe1, 0x00, 0x7d, 0x02, 0xf7, 0xde, 0xfd, 0x0c, 0xf5, 0x4f, 0x60, 0xf7, 0xd2, 0x73,
-	0xfd, 0xaa, 0xba, 0xad, 0x2a, 0xf3, 0x3f, 0xc2, 0x61, 0xa1, 0xcc, 0x2f, 0x92, 0xb3, 0x19, 0xdf,
-	0x7e, 0x03, 0x2d, 0x99, 0xae, 0xe3, 0xf6, 0xd6, 0xa0, 0xa3, 0x31, 0xb6, 0x0c, 0x27, 0x56, 0x56,
-	0xfa, 0xce, 0xf8, 0x3d, 0x87, 0x47, 0x9f, 0x70, 0x60, 0x7e, 0x11, 0xd1, 0x3e, 0x6c, 0x9e, 0xea,
-	0x1c, 0x4c, 0xe4, 0x72, 0x89, 0x5a, 0xcb, 0x27, 0x2a, 0xfd, 0x1a, 0xee, 0xbd, 0x11, 0xd2, 0x9b,
-	0x32, 0xc9, 0x4f, 0x59, 0xda, 0x62, 0x1f, 0xc1, 0x2a, 0xd7, 0xec, 0xa1, 0xcb, 0x4c, 0xf8, 0x5b,
-	0x3c, 0x15, 0xa5, 0x5f, 0xc1, 0xfa, 0x9b, 0x2b, 0x9e, 0x9d, 0x6b, 0x3e, 0x83, 0x65, 0x8e, 0x1c,
-	0xec, 0xcb, 0xad, 0xc1, 0xaa, 0x8e, 0x06, 0x8a, 0x59, 0x7a, 0x8d, 0x3e, 0x85, 0x25, 0x64, 0x64,
-	0x7f, 0xa3, 0x6a, 0xc9, 0x6f, 0x54, 0xd5, 0xaf, 0xca, 0xe0, 0x9f, 0x2d, 0x80, 0x97, 0xa1, 0x77,
-	0xc9, 0xa3, 0x2b, 0x55, 0x5c, 0xdf, 0x43, 0x2b, 0x33, 0x40, 0x12, 0xd3, 0x0e, 0x8b, 0x7f, 0x33,
-	0xdd, 0xae, 0x5e, 0xa8, 0x98, 0x36, 0x69, 0xe7, 0x4f, 0xff, 0xfe, 0xcf, 0x5f, 0xea, 0xf7, 0xc8,
-	0x56, 0xff, 0xea, 0x69, 0x7f, 0x26, 0x78, 0xa4, 0x7e, 0x09, 0x05, 0xea, 0xfb, 0x16, 0x1a, 0x66,
-	0x9c, 0x9e, 0xaf, 0x3b, 0x5d, 0xc8, 0x0f, 0xde, 0x55, 0x8a, 0x03, 0x87, 0x7b, 0x4a, 0xd9, 0xf7,
-	0xd0, 0x4c, 0xd0, 0x33, 0xd1, 0x5c, 0x44, 0xde, 0x6e, 0xbb, 0xbc, 0xa0, 0x55, 0xef, 0xa3, 0xea,
-	0x5d, 0x4a, 0x12, 0xd5, 0x38, 0xe7, 0x39, 0xb3, 0x69, 0xf8, 0xa2, 0x76, 0xa4, 0xfc, 0x36, 0xa3,
-	0xe6, 0xdd, 0x7e, 0x17, 0x87, 0xd2, 0x0a, 0xbf, 0x99, 0x51, 0x16, 0xc1, 0x46, 0x61, 0x8e, 0x24,
-	0xfb, 0x69, 0x68, 0x2b, 0x26, 0xd5, 0xee, 0xc1, 0xbc, 0x65, 0x6d, 0xec, 0x10, 0x8d, 0x75, 0xe9,
-	0x76, 0xc9, 0x98, 0x12, 0x53, 0x87, 0x99, 0xc2, 0x46, 0xa1, 0x02, 0xc8, 0xfc, 0xe2, 0x4a, 0xec,
-	0xcd, 0x69, 0xce, 0xf4, 0x21, 0xda, 0xeb, 0xd0, 0xfb, 0x89, 0xbd, 0x4c, 0x35, 0x2a, 0x73, 0xdf,
-	0xc1, 0xe2, 0x09, 0x9b, 0x4c, 0xfe, 0x1f, 0x1b, 0x6d, 0xb4, 0x41, 0xe8, 0x5a, 0x62, 0xc3, 0x66,
-	0x93, 0x89, 0x52, 0x7e, 0x0b, 0xa4, 0x3c, 0x66, 0x90, 0xc3, 0x8c, 0xbe, 0xca, 0x09, 0xe4, 0x4e,
-	0x8b, 0x14, 0x2d, 0xee, 0xd1, 0xdd, 0xc4, 0x62, 0xc4, 0xae, 0x0b, 0x07, 0x63, 0xb0, 0x9e, 0x9f,
-	0x1d, 0xc8, 0x5e, 0x7a, 0x37, 0xe5, 0x91, 0xa2, 0xbb, 0x76, 0x6c, 0x07, 0x11, 0x37, 0xe9, 0x57,
-	0x61, 0xc2, 0xcd, 0x6d, 0x53, 0x26, 0xfe, 0x5c, 0xc3, 0xf9, 0xa4, 0xdc, 0xee, 0x09, 0x4d, 0x4d,
-	0xcd, 0x1b, 0x48, 0xba, 0x8f, 0xaa, 0x22, 0x9e, 0x9b, 0x16, 0xe8, 0x17, 0xe8, 0xc4, 0x63, 0x7a,
-	0x90, 0x75, 0xa2, 0x2c, 0xaf, 0x7c, 0x19, 0x42, 0x33, 0x79, 0x18, 0x49, 0x8a, 0xa0, 0xf8, 0x80,
-	0x93, 0x94, 0x58, 0xe9, 0x0d, 0xa5, 0xa2, 0xc4, 0x84, 0x91, 0x79, 0x51, 0x3b, 0xfa, 0xb2, 0xa6,
-	0xb1, 0xc7, 0x60, 0xec, 0xdd, 0x75, 0x56, 0x44, 0x63, 0xba, 0x87, 0x16, 0x76, 0xc8, 0xfd, 0xec,
-	0x61, 0x12, 0x7d, 0x1c, 0x5a, 0x19, 0x38, 0xfe, 0x54, 0x3a, 0x1a, 0x70, 0xab, 0x40, 0xef, 0x8a,
-	0x74, 0xcf, 0x00, 0xb7, 0x0a, 0xd3, 0x0f, 0x58, 0xd1, 0x31, 0x7c, 0xeb, 0xb4, 0xf8, 0x29, 0x77,
-	0xb5, 0x9d, 0x05, 0xf4, 0xd4, 0xdc, 0x63, 0x34, 0xb7, 0x4f, 0xdb, 0xd9, 0x23, 0x65, 0x95, 0xbf,
-	0xa8, 0x1d, 0x0d, 0xfe, 0xd6, 0x80, 0xd5, 0x97, 0xce, 0xd4, 0xf3, 0x0d, 0x8a, 0xdb, 0x00, 0xe9,
-	0x94, 0x42, 0xcc, 0x95, 0x94, 0xa6, 0x9d, 0x6e, 0xa7, 0x62, 0xa5, 0x0a, 0x46, 0x98, 0x52, 0x6e,
-	0x70, 0xa4, 0xef, 0xf3, 0x6b, 0x75, 0xd0, 0x00, 0xd6, 0x72, 0x83, 0x08, 0x79, 0xa0, 0xb5, 0x55,
-	0x0d, 0x3c, 0xdd, 0xbd, 0xea, 0xc5, 0xaa, 0x63, 0xe6, 0xad, 0xcd, 0x70, 0x83, 0x32, 0xe8, 0x42,
-	0x2b, 0x33, 0x98, 0x24, 0x17, 0x58, 0x1e, 0x6e, 0x92, 0x0b, 0xac, 0x98, 0x63, 0xe8, 0x23, 0x34,
-	0xf5, 0x80, 0xee, 0x94, 0x4d, 0xa5, 0x86, 0x36, 0x0a, 0x23, 0xcd, 0x4f, 0x02, 0xaf, 0xea, 0x29,
-	0xc8, 0xa0, 0x3f, 0x5d, 0x4f, 0x0d, 0x0a, 0xcf, 0x45, 0x04, 0xf9, 0x6b, 0x0d, 0xf6, 0x0b, 0x08,
-	0xf4, 0xad, 0x27, 0xc7, 0xe9, 0x40, 0x42, 0x3e, 0xaf, 0xc6, 0xa9, 0xd2, 0xcc, 0xd4, 0xed, 0xdd,
-	0x2d, 0xa8, 0xfd, 0x39, 0x46, 0x7f, 0x7a, 0xf4, 0x71, 0xea, 0x8f, 0x9c, 0x67, 0x5f, 0x39, 0x79,
-	0x0d, 0xa4, 0xfc, 0x18, 0x36, 0xbf, 0x3a, 0x0d, 0xe8, 0xcc, 0x7f, 0x40, 0xa3, 0xbf, 0x40, 0x0f,
-	0x1e, 0x92, 0xfd, 0x4c, 0x44, 0x12, 0x69, 0xec, 0xe9, 0x68, 0xe2, 0x3b, 0x80, 0xf4, 0x61, 0x64,
-	0xbe, 0xc1, 0x4e, 0x5a, 0x5d, 0x85, 0x47, 0x94, 0x7c, 0xe3, 0x8d, 0x0d, 0x39, 0x5a, 0xdd, 0xef,
-	0x61, 0xab, 0xf4, 0x0a, 0x42, 0x1e, 0x66, 0x54, 0x55, 0xbd, 0xac, 0x74, 0x0f, 0xe7, 0x0b, 0xcc,
-	0xcf, 0x64, 0x27, 0x27, 0xa9, 0x42, 0x7a, 0x05, 0x1b, 0x85, 0x67, 0xe9, 0xa4, 0xeb, 0x57, 0xbf,
-	0x73, 0x27, 0x49, 0x36, 0xe7, 0x35, 0x9b, 0x7e, 0x86, 0x66, 0x0f, 0x68, 0x27, 0x35, 0x6b, 0xe7,
-	0x45, 0x5f, 0xd4, 0x8e, 0x46, 0xcb, 0xf8, 0xcc, 0xf6, 0xec, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xc0, 0xc4, 0x7d, 0x5e, 0xe3, 0x17, 0x00, 0x00,
+	// 4444 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5b, 0x4b, 0x73, 0xdc, 0x48,
+	0x72, 0x8e, 0xee, 0xe6, 0xab, 0xb3, 0xf9, 0x12, 0x48, 0x91, 0x2d, 0x50, 0xa4, 0xa4, 0xd2, 0x28,
+	0x86, 0xc3, 0xf1, 0xb0, 0x57, 0x9c, 0xf5, 0x68, 0x3c, 0xeb, 0x8b, 0x5e, 0xa3, 0xd1, 0x58, 0xaf,
+	0x00, 0x25, 0xad, 0xc3, 0x1b, 0xe3, 0x5e, 0x34, 0x50, 0xec, 0xc6, 0x0a, 0x8d, 0xc2, 0x00, 0xd5,
+	0x7c, 0xc8, 0xf6, 0xda, 0x61, 0xfb, 0xb2, 0x67, 0x1f, 0x1d, 0x61, 0x47, 0xf8, 0xe6, 0x83, 0x0f,
+	0x3e, 0x3a, 0x7c, 0x73, 0x84, 0x8f, 0x8e, 0x58, 0x87, 0xff, 0x82, 0x7f, 0x88, 0x23, 0xeb, 0x01,
+	0x14, 0xd0, 0x00, 0x5b, 0x33, 0xde, 0x5b, 0x57, 0x56, 0x56, 0x7d, 0xf5, 0xc8, 0xca, 0xca, 0xfc,
+	0x0a, 0x0d, 0x2b, 0x6e, 0x1c, 0xf4, 0x93, 0xd8, 0x3b, 0x8c, 0x13, 0xc6, 0x99, 0x35, 0x9f, 0xc4,
+	0x5e, 0x3c, 0xb0, 0xaf, 0x0f, 0x19, 0x1b, 0x86, 0xb4, 0xe7, 0xc6, 0x41, 0xcf, 0x8d, 0x22, 0xc6,
+	0x5d, 0x1e, 0xb0, 0x28, 0x95, 0x4a, 0xf6, 0xe7, 0xc3, 0x80, 0x8f, 0x26, 0x83, 0x43, 0x8f, 0x8d,
+	0x7b, 0x11, 0x1d, 0x4c, 0x42, 0x37, 0x0d, 0x58, 0x6f, 0xc8, 0x3e, 0x53, 0x85, 0x9e, 0xc7, 0x12,
+	0xda, 0x8b, 0x07, 0xbd, 0x41, 0xc8, 0xbc, 0x77, 0xb2, 0x11, 0xd9, 0x87, 0xf5, 0xe3, 0xc9, 0x20,
+	0xf5, 0x92, 0x60, 0x40, 0x1d, 0xfa, 0xfd, 0x84, 0xa6, 0xdc, 0xda, 0x84, 0x79, 0xce, 0xe2, 0xc0,
+	0xeb, 0x36, 0x6e, 0xb6, 0xf6, 0xdb, 0x8e, 0x2c, 0x90, 0x7b, 0xb0, 0xf5, 0x70, 0xe4, 0x46, 0x43,
+	0xfa, 0x82, 0xf2, 0x33, 0x96, 0xbc, 0x7b, 0xfa, 0x48, 0xeb, 0xef, 0x02, 0x44, 0x52, 0xd6, 0x0f,
+	0xfc, 0x6e, 0xe3, 0x66, 0x63, 0x7f, 0xc5, 0x69, 0x2b, 0xc9, 0x53, 0x9f, 0xdc, 0x85, 0xed, 0xa9,
+	0x86, 0x69, 0xcc, 0xa2, 0x94, 0x5a, 0x5b, 0xb0, 0x90, 0xd0, 0x74, 0x12, 0x72, 0xd1, 0x6a, 0xc9,
+	0x51, 0x25, 0xf2, 0x00, 0xae, 0x18, 0xa3, 0x52, 0xca, 0xd7, 0x60, 0x69, 0x9c, 0x0e, 0xfb, 0xfc,
+	0x22, 0xa6, 0x42, 0xbd, 0xed, 0x2c, 0x8e, 0xd3, 0xe1, 0xeb, 0x8b, 0x98, 0x5a, 0x16, 0xcc, 0xf9,
+	0x2e, 0x77, 0xbb, 0x4d, 0x21, 0x16, 0xbf, 0x89, 0x05, 0xeb, 0x2f, 0x58, 0xf4, 0xca, 0x4d, 0xdc,
+	0x71, 0xaa, 0x46, 0x4a, 0xfe, 0xb9, 0x85, 0x42, 0x9f, 0x3e, 0x8d, 0x4e, 0x58, 0xd6, 0xef, 0x2a,
+	0x34, 0xd5, 0xb0, 0xdb, 0x4e, 0x33, 0xf0, 0x11, 0xc7, 0x1b, 0xb9, 0x41, 0x84, 0x93, 0x69, 0x8a,
+	0xc9, 0x2c, 0x8a, 0xf2, 0x53, 0xdf, 0xea, 0xc2, 0xe2, 0x29, 0x4d, 0xd2, 0x80, 0x45, 0xdd, 0x96,
+	0xac, 0x51, 0x45, 0x5c, 0x83, 0x98, 0xd2, 0xa4, 0xef, 0xb1, 0x49, 0xc4, 0xbb, 0x73, 0x72, 0x0d,
+	0x50, 0xf2, 0x10, 0x05, 0x16, 0x81, 0xe5, 0xf4, 0x22, 0xf2, 0x46, 0x09, 0x8b, 0x82, 0xf7, 0xd4,
+	0xef, 0xce, 0x8b, 0xe9, 0x16, 0x64, 0xd6, 0x0d, 0xe8, 0x0c, 0x26, 0xde, 0x3b, 0xca, 0xfb, 0x69,
+	0xf0, 0x9e, 0x76, 0x17, 0x6e, 0x36, 0xf6, 0xe7, 0x1d, 0x90, 0xa2, 0xe3, 0xe0, 0x3d, 0xb5, 0xf6,
+	0x61, 0x3d, 0xa1, 0xa1, 0x7b, 0xd1, 0xf7, 0x5c, 0x6f, 0x44, 0xa5, 0xd6, 0xa2, 0xd0, 0x5a, 0x15,
+	0xf2, 0x87, 0x28, 0x16, 0x9a, 0x07, 0x70, 0x25, 0xe5, 0x09, 0x75, 0xc7, 0xfd, 0x94, 0xb3, 0x44,
+	0xa9, 0x2e, 0x09, 0xd5, 0x35, 0x59, 0x71, 0x8c, 0x72, 0xa1, 0x7b, 0x0f, 0xba, 0x05, 0x5d, 0x7a,
+	0xce, 0x69, 0xe4, 0xcb, 0x26, 0x6d, 0xd1, 0xe4, 0xaa, 0xd1, 0xe4, 0xb1, 0xa8, 0x15, 0x0d, 0x3f,
+	0x81, 0x75, 0x61, 0x43, 0x1e, 0x0b, 0xfb, 0x7a, 0x55, 0x40, 0xac, 0xe2, 0x9a, 0x96, 0xbf, 0x55,
+	0xab, 0x73, 0x04, 0x9d, 0x84, 0x4d, 0x38, 0xed, 0x73, 0x77, 0x10, 0xd2, 0x6e, 0xe7, 0x66, 0x6b,
+	0xbf, 0x73, 0x74, 0xe5, 0x50, 0x58, 0xf5, 0xa1, 0x83, 0x35, 0xaf, 0xb1, 0xc2, 0x81, 0x24, 0xfb,
+	0x4d, 0x7e, 0x0d, 0xf6, 0x31, 0x1a, 0x78, 0xca, 0x03, 0x2f, 0x9d, 0xda, 0xb4, 0x2d, 0x58, 0x10,
+	0xb2, 0x47, 0x6a, 0xe3, 0x54, 0x09, 0xe5, 0xdf, 0xd0, 0x60, 0x38, 0xe2, 0x62, 0xeb, 0xe6, 0x1c,
+	0x55, 0x42, 0x0b, 0xf9, 0xc6, 0x4d, 0x47, 0x62, 0xdb, 0xda, 0x8e, 0xf8, 0x6d, 0x5d, 0x87, 0xf6,
+	0x2b, 0xbd, 0x43, 0x7a, 0xcb, 0x32, 0x01, 0xf9, 0x02, 0x20, 0x1f, 0xd9, 0x94, 0x91, 0x74, 0x61,
+	0xd1, 0xf5, 0xfd, 0x84, 0xa6, 0x69, 0xb7, 0x29, 0x4e, 0x89, 0x2e, 0x92, 0x7f, 0x69, 0xc2, 0xc6,
+	0x13, 0xca, 0x5f, 0xd0, 0x01, 0x0e, 0xbf, 0x60, 0xbe, 0x99, 0x59, 0x35, 0x8a, 0x66, 0x65, 0xc1,
+	0x1c, 0x77, 0x83, 0x50, 0x9b, 0x2f, 0xfe, 0xb6, 0x6c, 0x58, 0xf2, 0x58, 0x10, 0x0d, 0xdc, 0x94,
+	0xaa, 0x41, 0x67, 0xe5, 0x59, 0xc6, 0xb6, 0x03, 0xed, 0x20, 0xed, 0x8f, 0x83, 0x28, 0x88, 0x86,
+	0xca, 0xd2, 0x96, 0x82, 0xf4, 0xb9, 0x28, 0x57, 0xee, 0xda, 0x42, 0xf5, 0xae, 0x95, 0x8d, 0x76,
+	0xb1, 0xc2, 0x68, 0x77, 0xa0, 0x1d, 0x31, 0x9f, 0xf6, 0xc7, 0xcc, 0x97, 0x16, 0xd6, 0x76, 0x96,
+	0x50, 0xf0, 0x9c, 0xf9, 0xd4, 0xba, 0x0d, 0x2b, 0x71, 0x32, 0x89, 0xa8, 0xdf, 0x1f, 0xc9, 0x3d,
+	0x69, 0x8b, 0x3d, 0x59, 0x96, 0x42, 0xb9, 0x33, 0xe4, 0x27, 0xb0, 0x7e, 0xdf, 0x13, 0x33, 0x49,
+	0xb3, 0xb5, 0xba, 0x0e, 0x6d, 0xb5, 0x9c, 0x34, 0x55, 0x5e, 0x28, 0x17, 0x90, 0x5f, 0xc2, 0xd6,
+	0x13, 0xca, 0x55, 0x23, 0xb5, 0xc8, 0xd2, 0x13, 0x19, 0xbb, 0xa2, 0x3c, 0x84, 0x2a, 0xa2, 0x4f,
+	0x13, 0x6e, 0x4f, 0xad, 0xb1, 0x2c, 0xa0, 0xb5, 0xa8, 0x91, 0xb5, 0xa4, 0xb5, 0xc8, 0x12, 0xf9,
+	0xab, 0x06, 0x6c, 0x4f, 0x41, 0xa8, 0xb1, 0x75, 0x61, 0x71, 0xe0, 0x86, 0x6e, 0xe4, 0x65, 0x5e,
+	0x48, 0x15, 0x11, 0x23, 0x62, 0x28, 0x57, 0x18, 0xa2, 0x50, 0x87, 0x81, 0x9b, 0x28, 0x06, 0xd1,
+	0x1f, 0xa1, 0x5d, 0xce, 0x89, 0x26, 0x6d, 0x21, 0x41, 0xe3, 0x24, 0x3e, 0xd8, 0x4f, 0x28, 0x7f,
+	0xc8, 0x22, 0x9e, 0xb8, 0x1e, 0x7f, 0x4e, 0xb9, 0x8b, 0x5e, 0xed, 0x77, 0x3d, 0xd1, 0x18, 0x76,
+	0x2a, 0x51, 0xd4, 0x5c, 0x6d, 0x58, 0x1a, 0x2b, 0x99, 0xc2, 0xc9, 0xca, 0x46, 0x97, 0xcd, 0x4b,
+	0xe6, 0xd5, 0x2a, 0xcf, 0xeb, 0xa7, 0x60, 0x3d, 0xa1, 0xfc, 0xd1, 0x45, 0xe4, 0xa6, 0xfc, 0x22,
+	0x03, 0xda, 0x03, 0xf0, 0x69, 0x48, 0x87, 0x2e, 0xa7, 0xd9, 0x8e, 0x1b, 0x12, 0xf2, 0x39, 0x5c,
+	0xcb, 0x5b, 0x1d, 0x47, 0x6e, 0x9c, 0x8e, 0x18, 0xd7, 0x8b, 0x91, 0x8f, 0xa4, 0x51, 0x98, 0xdc,
+	0x7f, 0x36, 0xc0, 0xae, 0x6a, 0x95, 0xbb, 0x90, 0xaa, 0x66, 0x38, 0x01, 0x5f, 0x36, 0xd1, 0x37,
+	0x40, 0xcb, 0x69, 0x2b, 0xc9, 0x53, 0xdf, 0xba, 0x07, 0x70, 0xea, 0x86, 0x81, 0xef, 0x72, 0x96,
+	0xa4, 0xdd, 0x96, 0x70, 0x65, 0xdb, 0xca, 0x95, 0x29, 0xa8, 0xb7, 0xba, 0xde, 0x31, 0x54, 0xb1,
+	0xa1, 0xe7, 0x46, 0x3e, 0x16, 0x69, 0xda, 0x9d, 0xab, 0x6a, 0xf8, 0x50, 0xd7, 0x3b, 0x86, 0x2a,
+	0xf9, 0x23, 0x58, 0x2f, 0x77, 0x7c, 0x89, 0x01, 0xec, 0x02, 0x8c, 0x83, 0x88, 0x2b, 0xe7, 0xa0,
+	0x86, 0x8f, 0x12, 0xe9, 0xd6, 0x1e, 0xc0, 0x7a, 0x19, 0xec, 0x72, 0x6b, 0x3a, 0x65, 0x38, 0x5c,
+	0x65, 0x4d, 0xa2, 0x40, 0x7a, 0xca, 0xc3, 0x9d, 0xf3, 0x17, 0x68, 0xe2, 0x33, 0x8d, 0x92, 0x7c,
+	0x0d, 0x9b, 0xc5, 0x06, 0x6a, 0x0b, 0xb2, 0x13, 0x23, 0x77, 0x40, 0x16, 0xb0, 0x1f, 0x7a, 0x1e,
+	0x07, 0x89, 0x82, 0x6d, 0x39, 0xba, 0x48, 0x1e, 0xc3, 0x86, 0x43, 0x43, 0xea, 0xa6, 0xf4, 0xc3,
+	0x80, 0x8b, 0x47, 0x52, 0x03, 0x90, 0x43, 0xd8, 0x2c, 0x76, 0x33, 0x23, 0x1c, 0x79, 0x09, 0x6b,
+	0x4f, 0x28, 0x7f, 0x95, 0x30, 0x76, 0xa2, 0x21, 0x2d, 0x98, 0x7b, 0x17, 0x44, 0xfa, 0x46, 0x10,
+	0xbf, 0xad, 0x75, 0x68, 0xbd, 0xa3, 0x17, 0x6a, 0xa9, 0xf0, 0x67, 0xed, 0xb1, 0xfb, 0x4d, 0x03,
+	0xd6, 0xf3, 0x1e, 0x67, 0xdb, 0xa3, 0x71, 0xa0, 0x9a, 0xa5, 0x03, 0x85, 0x23, 0x49, 0x18, 0xe3,
+	0xfa, 0x66, 0xc3, 0xdf, 0x62, 0xdb, 0xdc, 0x70, 0x42, 0x95, 0x5b, 0x91, 0x05, 0x94, 0xc6, 0x88,
+	0x28, 0xee, 0x84, 0xb6, 0x23, 0x0b, 0xe4, 0x4b, 0xe8, 0xe2, 0x21, 0x51, 0x67, 0xed, 0x2d, 0xe3,
+	0x34, 0xd1, 0xf1, 0x12, 0xfa, 0xe1, 0xec, 0x10, 0xaa, 0xa9, 0xe6, 0x02, 0x7d, 0x28, 0x4b, 0x2d,
+	0xf3, 0xd9, 0x9c, 0x0a, 0x89, 0x3a, 0xcd, 0xaa, 0x44, 0xfe, 0x61, 0x0e, 0xac, 0xd7, 0x89, 0x1b,
+	0xa5, 0xae, 0x87, 0xc1, 0xab, 0xb1, 0x9e, 0x27, 0x09, 0x1b, 0xeb, 0xf5, 0xc4, 0xdf, 0x78, 0xe7,
+	0x72, 0xa6, 0x26, 0xdc, 0xe4, 0x2c, 0x9f, 0x55, 0xab, 0x34, 0x2b, 0xb9, 0xc5, 0x73, 0xa6, 0x0d,
+	0xed, 0x40, 0x7b, 0xe8, 0xa6, 0xfd, 0x38, 0x09, 0x3c, 0xaa, 0xe6, 0xbb, 0x34, 0x74, 0xd3, 0x57,
+	0x49, 0x90, 0x57, 0x86, 0xc1, 0x38, 0xe0, 0xdd, 0x85, 0xac, 0xf2, 0x19, 0x96, 0xad, 0x23, 0xbc,
+	0x78, 0xa5, 0x3f, 0x14, 0x37, 0x5e, 0xe7, 0x68, 0x4b, 0x1d, 0x52, 0xed, 0x26, 0xd5, 0x98, 0x9d,
+	0x4c, 0xcf, 0xfa, 0x7d, 0x68, 0x67, 0xe7, 0x55, 0xdc, 0x82, 0xf9, 0xc9, 0xce, 0x8f, 0xb4, 0x6a,
+	0x95, 0x6b, 0x22, 0x94, 0x5e, 0xcd, 0x6e, 0xbb, 0x00, 0xa5, 0x17, 0x35, 0x83, 0xd2, 0x7a, 0xd8,
+	0x66, 0x3c, 0x09, 0x79, 0x90, 0x06, 0xc3, 0x2e, 0x14, 0xda, 0x3c, 0x57, 0xe2, 0xac, 0x8d, 0xd6,
+	0xc3, 0xc8, 0x52, 0xf8, 0xa1, 0xfe, 0x24, 0xe2, 0x41, 0xd8, 0xed, 0x88, 0x85, 0x92, 0xae, 0xe9,
+	0x0d, 0x4a, 0xac, 0xbb, 0x30, 0x3f, 0x70, 0xb9, 0x37, 0xea, 0x2e, 0x8b, 0x1e, 0x77, 0x54, 0x8f,
+	0x0f, 0x50, 0x26, 0x36, 0xeb, 0x84, 0x26, 0xba, 0x5b, 0xa9, 0x69, 0x7d, 0x02, 0xf3, 0x69, 0x88,
+	0x06, 0xb9, 0x22, 0x9a, 0x6c, 0xa8, 0x26, 0xc7, 0x28, 0xcb, 0x54, 0x85, 0x86, 0xf5, 0x7b, 0xb0,
+	0xc0, 0x12, 0xd7, 0x0b, 0x69, 0x77, 0x55, 0xe8, 0x6e, 0x2a, 0xdd, 0x97, 0x42, 0xa8, 0x95, 0x95,
+	0x0e, 0xf9, 0x6d, 0x03, 0xd6, 0x4a, 0x2b, 0x8d, 0xc6, 0x94, 0xb2, 0x49, 0x92, 0x5d, 0xb9, 0xaa,
+	0x84, 0x13, 0x93, 0xbf, 0x64, 0x56, 0x20, 0x4d, 0x05, 0xa4, 0x48, 0x24, 0x06, 0x36, 0x2c, 0x9d,
+	0x4c, 0x22, 0x61, 0x69, 0x3a, 0x8a, 0xd2, 0x65, 0x34, 0x39, 0x37, 0x19, 0xa6, 0xea, 0x8c, 0x88,
+	0xdf, 0x78, 0x0f, 0x4d, 0xe2, 0x61, 0xe2, 0xfa, 0x22, 0x4e, 0x95, 0xb1, 0x93, 0x21, 0x41, 0x4f,
+	0x23, 0x4b, 0x32, 0x3e, 0x5f, 0x72, 0x74, 0xb1, 0x70, 0x55, 0x2e, 0x16, 0xaf, 0x4a, 0x72, 0x00,
+	0xeb, 0x65, 0x33, 0xc0, 0x29, 0xc9, 0x13, 0xa0, 0xa7, 0x24, 0x4b, 0xe4, 0x09, 0xac, 0x95, 0x36,
+	0xbf, 0x4e, 0xb5, 0x78, 0x3a, 0x9b, 0xe5, 0xd3, 0xf9, 0xef, 0x0d, 0x58, 0x2b, 0x99, 0x44, 0x6d,
+	0x4f, 0x5b, 0xb0, 0xc0, 0xce, 0x22, 0x9a, 0xe8, 0x60, 0x56, 0x95, 0x10, 0x81, 0x8f, 0x12, 0x9a,
+	0x8e, 0x58, 0xe8, 0xab, 0x8c, 0x27, 0x17, 0x08, 0xb7, 0xeb, 0xe5, 0x31, 0x68, 0xdb, 0xd1, 0x45,
+	0x75, 0x72, 0xe7, 0xa7, 0x4f, 0xee, 0x82, 0x79, 0x72, 0x6d, 0x58, 0x8a, 0x13, 0x16, 0xb3, 0xd4,
+	0x0d, 0xf5, 0x92, 0xe9, 0x32, 0x79, 0x06, 0x9b, 0x55, 0xd6, 0x67, 0xfd, 0x14, 0x16, 0xd9, 0x84,
+	0xc7, 0x13, 0x2e, 0xfd, 0x4a, 0xe7, 0xc8, 0xae, 0xb2, 0xd5, 0x97, 0x42, 0xc5, 0xd1, 0xaa, 0xe4,
+	0x67, 0xb0, 0x51, 0x51, 0xaf, 0x86, 0xd9, 0x98, 0x1e, 0x66, 0xd3, 0x18, 0x26, 0x39, 0x80, 0x65,
+	0xd3, 0xaa, 0x71, 0xd8, 0xf4, 0x34, 0xf0, 0x69, 0x1e, 0x01, 0x66, 0x65, 0x72, 0x0f, 0x56, 0x0a,
+	0x56, 0xad, 0xef, 0x84, 0x46, 0x7e, 0x27, 0x54, 0x83, 0xf4, 0xe0, 0xda, 0x31, 0x8d, 0x7c, 0xc7,
+	0x3d, 0xab, 0x76, 0x8e, 0x59, 0x08, 0xb6, 0xac, 0xd2, 0x5b, 0x0e, 0xdb, 0xd8, 0xa0, 0xa0, 0x9d,
+	0xbb, 0x5e, 0x7e, 0x2e, 0x2e, 0x0b, 0xb5, 0xcb, 0xb2, 0x84, 0xa1, 0xbf, 0xf6, 0x58, 0xfd, 0x3c,
+	0x79, 0x11, 0xa1, 0xbf, 0x96, 0xdf, 0x97, 0x62, 0xe3, 0x26, 0x6c, 0x15, 0x6e, 0xc2, 0x4f, 0xe1,
+	0xea, 0x13, 0xca, 0x1f, 0xe0, 0xe5, 0xf3, 0xe0, 0xe2, 0x1b, 0x63, 0x51, 0x2c, 0x98, 0x33, 0x10,
+	0xc5, 0x6f, 0x4c, 0xfc, 0x0d, 0x65, 0x71, 0x99, 0xcd, 0x0a, 0xd9, 0xee, 0x8a, 0x78, 0xd4, 0x98,
+	0xd4, 0x6c, 0x94, 0x7d, 0x58, 0x17, 0x10, 0x8f, 0x26, 0xe3, 0xd8, 0x60, 0x30, 0xa4, 0x5d, 0x36,
+	0x44, 0x02, 0x2b, 0x0b, 0xe4, 0x63, 0xb8, 0x62, 0x68, 0xaa, 0xc5, 0x32, 0xd7, 0x56, 0x53, 0x07,
+	0xff, 0xd1, 0x02, 0xbb, 0xb0, 0xb0, 0x1e, 0x0d, 0x62, 0x6e, 0x36, 0x29, 0x8f, 0x02, 0xcf, 0x82,
+	0xca, 0xe6, 0xca, 0x9c, 0x81, 0xbe, 0xd9, 0x5a, 0x53, 0x37, 0xdb, 0xdc, 0xb4, 0xe1, 0xcd, 0x57,
+	0xde, 0x6c, 0x0b, 0xe6, 0xcd, 0x86, 0x67, 0x32, 0x18, 0xd3, 0x94, 0xbb, 0xe3, 0x58, 0x1c, 0x9b,
+	0x96, 0x93, 0x0b, 0x10, 0x4d, 0xb8, 0x42, 0x99, 0x8a, 0x89, 0xdf, 0xd9, 0x14, 0xdb, 0xf9, 0x14,
+	0x8b, 0xf7, 0x23, 0x5c, 0x76, 0x3f, 0x76, 0x4a, 0xf7, 0x63, 0x95, 0x15, 0x2d, 0x57, 0x5b, 0x51,
+	0xe9, 0xde, 0x59, 0x99, 0xba, 0x77, 0xd0, 0xaf, 0x73, 0x97, 0x4f, 0x52, 0x71, 0x33, 0xac, 0x38,
+	0xaa, 0x84, 0x21, 0x0f, 0x4d, 0x12, 0x86, 0x19, 0xae, 0x4f, 0xbb, 0x6b, 0xd2, 0xb5, 0x09, 0xc9,
+	0x43, 0x95, 0x57, 0xca, 0xea, 0x31, 0x4d, 0x53, 0x77, 0x48, 0xbb, 0xeb, 0x42, 0x63, 0x59, 0x08,
+	0x9f, 0x4b, 0x19, 0xf9, 0x1c, 0xae, 0xbc, 0xa0, 0x67, 0x2a, 0x85, 0xd3, 0x86, 0xb1, 0x07, 0x10,
+	0xbb, 0x69, 0x1a, 0x8f, 0x12, 0xcc, 0xab, 0xe5, 0x06, 0x1a, 0x12, 0x72, 0x08, 0x96, 0xd9, 0x28,
+	0x4f, 0xf9, 0x6a, 0x02, 0xdb, 0x10, 0x36, 0xdf, 0x44, 0x68, 0x53, 0x25, 0x9c, 0xda, 0x16, 0xa5,
+	0x11, 0x34, 0xcb, 0x23, 0x40, 0xef, 0xe2, 0x4f, 0x12, 0x37, 0xbb, 0xb1, 0xe6, 0x9c, 0xac, 0x4c,
+	0x7a, 0x70, 0xb5, 0x84, 0x36, 0x23, 0x70, 0x3d, 0x04, 0xeb, 0xd9, 0x0f, 0x18, 0x1c, 0xf9, 0x0c,
+	0x36, 0x9e, 0xfd, 0x80, 0xee, 0x3f, 0x83, 0xed, 0xe3, 0x60, 0x18, 0x55, 0xf9, 0xa0, 0x2a, 0x97,
+	0xf5, 0x97, 0x70, 0xb3, 0xe4, 0xb2, 0x5e, 0x65, 0xf3, 0xd6, 0x63, 0xfb, 0x19, 0x74, 0x78, 0x5e,
+	0x2f, 0x9a, 0x77, 0x8e, 0xae, 0x29, 0x1f, 0x3f, 0xed, 0x1a, 0x1d, 0x53, 0x7b, 0xd6, 0xda, 0x92,
+	0x7b, 0x70, 0xeb, 0x92, 0x01, 0xd4, 0x9f, 0x6e, 0xd2, 0x83, 0xf5, 0x27, 0xea, 0x70, 0x64, 0x7a,
+	0x85, 0x13, 0xd4, 0x28, 0x9e, 0x20, 0xf2, 0x2d, 0x6c, 0x3c, 0x4e, 0x79, 0x30, 0x76, 0x39, 0x7d,
+	0xe2, 0xe6, 0x41, 0xf1, 0x2d, 0x58, 0xa6, 0x4a, 0xdc, 0x1f, 0xba, 0x7a, 0xf9, 0x3b, 0x34, 0x57,
+	0xc5, 0x0b, 0x83, 0x26, 0x89, 0x4e, 0x22, 0x68, 0x92, 0x90, 0x2f, 0x60, 0xf5, 0xf1, 0x29, 0x35,
+	0xe9, 0x91, 0x8f, 0x60, 0x81, 0x0a, 0x89, 0xba, 0x03, 0x97, 0xd5, 0xfa, 0x08, 0x35, 0x47, 0xd5,
+	0x91, 0xbb, 0x30, 0x2f, 0x04, 0x26, 0x9f, 0xdb, 0xc8, 0xf8, 0xdc, 0x4a, 0xce, 0xf4, 0x37, 0x0d,
+	0xb8, 0xfa, 0x82, 0x9e, 0x89, 0x66, 0x5f, 0x07, 0x21, 0xcf, 0xef, 0x5d, 0xbc, 0x53, 0xb0, 0x59,
+	0x16, 0xce, 0xcb, 0x92, 0xa4, 0xa9, 0x54, 0xb4, 0xdc, 0xd4, 0x34, 0x95, 0x2c, 0xe3, 0xf1, 0x47,
+	0x6f, 0xd7, 0x2f, 0xa4, 0x40, 0x80, 0x22, 0x45, 0xca, 0xed, 0x40, 0x9b, 0x33, 0x5d, 0x2d, 0xc3,
+	0xf7, 0x25, 0xce, 0x64, 0x25, 0xd9, 0x87, 0xad, 0xf2, 0x50, 0xaa, 0x09, 0x5b, 0xf2, 0x11, 0x58,
+	0x15, 0x23, 0x2e, 0x6b, 0xfd, 0x6d, 0x03, 0x3a, 0x82, 0xc2, 0xf4, 0xe5, 0xaa, 0xd4, 0xa5, 0x5b,
+	0xdb, 0xb0, 0xc8, 0xcf, 0xcd, 0x5c, 0x6b, 0x81, 0x9f, 0x8b, 0x44, 0xcb, 0x9c, 0x6a, 0xab, 0x34,
+	0xd5, 0x6c, 0x89, 0xe7, 0xaa, 0x96, 0x78, 0xde, 0x58, 0xe2, 0x07, 0xb0, 0x29, 0xc7, 0x59, 0xda,
+	0xd3, 0x83, 0xd2, 0x9e, 0x5a, 0x3a, 0xa0, 0xce, 0x87, 0x9c, 0xed, 0xec, 0x17, 0x70, 0xfd, 0x4d,
+	0x14, 0x44, 0x29, 0x77, 0xc3, 0xb0, 0x6a, 0x81, 0xea, 0xce, 0xeb, 0x7f, 0x37, 0xc0, 0x3a, 0xbe,
+	0x88, 0xbc, 0x63, 0xe1, 0x65, 0x0d, 0x73, 0x5a, 0xc9, 0x39, 0x3d, 0xe4, 0x0c, 0x65, 0xab, 0xa2,
+	0x10, 0x6d, 0x37, 0xe5, 0x6e, 0xc2, 0xfb, 0x05, 0xd6, 0xa7, 0x23, 0x64, 0x6a, 0x3f, 0xef, 0xc0,
+	0xaa, 0x37, 0x49, 0x12, 0x1a, 0xf1, 0xe2, 0x9e, 0xaf, 0x28, 0x69, 0xae, 0x36, 0x0a, 0x86, 0x23,
+	0x9a, 0xf2, 0xe2, 0xde, 0xaf, 0x28, 0x69, 0x4e, 0xd9, 0x26, 0x98, 0x19, 0xe1, 0xea, 0x35, 0x1c,
+	0xf1, 0x5b, 0x9c, 0x0e, 0xee, 0x8a, 0x0b, 0xb1, 0xe5, 0xe0, 0x4f, 0xf2, 0x8f, 0x4d, 0xb8, 0xfe,
+	0xf8, 0x9c, 0x7a, 0x13, 0x3c, 0xce, 0x8f, 0xa3, 0xd3, 0x20, 0x61, 0xd1, 0x98, 0x1a, 0xce, 0x6b,
+	0x17, 0x60, 0xc8, 0x32, 0xaa, 0x53, 0x25, 0xb1, 0x43, 0xa6, 0x49, 0xce, 0x55, 0x68, 0x32, 0x1d,
+	0x06, 0x35, 0x59, 0x2a, 0xb3, 0x02, 0x2f, 0x23, 0x8a, 0xf1, 0x37, 0x76, 0x71, 0xfa, 0x65, 0xd6,
+	0x85, 0xa2, 0xea, 0x4e, 0xbf, 0xd4, 0x5d, 0xec, 0xc8, 0x1b, 0xb9, 0xff, 0x9e, 0x45, 0x59, 0xae,
+	0x89, 0x82, 0x3f, 0x61, 0x91, 0x48, 0x51, 0x50, 0xde, 0x67, 0x27, 0x27, 0x29, 0xe5, 0x9a, 0xd5,
+	0x47, 0xd1, 0x4b, 0x21, 0xc1, 0x75, 0x3d, 0x09, 0x99, 0xcb, 0xfb, 0x7e, 0x30, 0xa4, 0x29, 0x57,
+	0x91, 0x70, 0x47, 0xc8, 0x1e, 0x09, 0x91, 0x75, 0x13, 0x3a, 0x27, 0x41, 0x34, 0xa4, 0x49, 0x9c,
+	0x04, 0x11, 0x57, 0x77, 0xbb, 0x29, 0x52, 0xa1, 0xf4, 0x20, 0xa4, 0xe3, 0xb4, 0xdb, 0x16, 0x07,
+	0x34, 0x2b, 0x93, 0x17, 0xb0, 0xfa, 0x90, 0x45, 0xa7, 0x34, 0xe1, 0x46, 0x18, 0x65, 0xbc, 0xa2,
+	0x88, 0xdf, 0x8a, 0x1c, 0x50, 0xf9, 0xf6, 0xb2, 0x23, 0x0b, 0xa8, 0xf9, 0xab, 0x34, 0xcb, 0x9d,
+	0xc4, 0x6f, 0xf2, 0x06, 0xd6, 0xb2, 0xfe, 0xf2, 0x0b, 0xd2, 0x5c, 0xe0, 0xf9, 0xfc, 0x5d, 0xe4,
+	0xc3, 0xbb, 0xfd, 0x6d, 0x03, 0x96, 0x5f, 0x9f, 0xbf, 0x62, 0x2c, 0x44, 0x1f, 0x4d, 0x93, 0xcb,
+	0x59, 0x9d, 0x9c, 0xdd, 0x5a, 0x51, 0xe1, 0x1d, 0x5a, 0xfd, 0xf7, 0x13, 0x3a, 0xa1, 0x3a, 0x53,
+	0x51, 0x25, 0xdc, 0x9e, 0x71, 0x10, 0xf5, 0x4d, 0x92, 0x60, 0x69, 0x1c, 0x44, 0x2f, 0x34, 0x4f,
+	0x30, 0x76, 0xcf, 0x55, 0xe5, 0xbc, 0xaa, 0x74, 0xcf, 0x65, 0xe5, 0x0d, 0xe8, 0x70, 0xc6, 0xdd,
+	0xb0, 0x6f, 0x26, 0x2f, 0x20, 0x44, 0x6f, 0x51, 0x82, 0x86, 0x21, 0x15, 0x4e, 0x28, 0x4d, 0xd5,
+	0xce, 0xb5, 0x85, 0xe4, 0x6b, 0x4a, 0x53, 0xf2, 0x12, 0xf6, 0x9e, 0x46, 0x69, 0x4c, 0x3d, 0x33,
+	0xa2, 0xc5, 0x19, 0x66, 0x0b, 0xf7, 0x19, 0x2c, 0xa6, 0x62, 0xb6, 0xfa, 0xd8, 0xeb, 0x3c, 0xda,
+	0x5c, 0x09, 0x47, 0xeb, 0x60, 0x44, 0xfd, 0x28, 0x61, 0x71, 0x4d, 0xd0, 0x5f, 0x79, 0xe6, 0xff,
+	0x02, 0xf3, 0x04, 0xcd, 0x64, 0xbf, 0x62, 0x61, 0xe0, 0x5d, 0xcc, 0x0e, 0x52, 0x3e, 0x86, 0xb5,
+	0x89, 0x08, 0x34, 0xfa, 0x59, 0x2c, 0x22, 0x8f, 0xfb, 0xaa, 0x14, 0x3f, 0x52, 0x52, 0x91, 0x80,
+	0xc7, 0xf8, 0x5c, 0x24, 0x63, 0xc5, 0x96, 0x4a, 0xc0, 0x51, 0x24, 0xa2, 0x45, 0x72, 0x04, 0xdd,
+	0x69, 0xf8, 0x19, 0x43, 0xfe, 0x46, 0xf0, 0xfb, 0x18, 0x59, 0x04, 0xd1, 0xf0, 0xfe, 0xc4, 0x0f,
+	0xf8, 0x07, 0x11, 0x7d, 0x72, 0x08, 0xca, 0x24, 0x44, 0x81, 0xfc, 0x7d, 0x03, 0x56, 0x54, 0x3f,
+	0x0e, 0xf5, 0x58, 0xe2, 0x17, 0xa3, 0xe7, 0x46, 0x39, 0x7a, 0x2e, 0xbc, 0xea, 0x14, 0xfa, 0xd7,
+	0x7c, 0x5f, 0xcb, 0xe0, 0xfb, 0x74, 0xa4, 0x30, 0x67, 0xe4, 0x01, 0xb5, 0x91, 0xbc, 0x88, 0x4d,
+	0x75, 0xfe, 0x2b, 0x0a, 0xe4, 0xa9, 0xc8, 0x8f, 0x8a, 0xf3, 0x54, 0x4b, 0x73, 0x08, 0x8b, 0x89,
+	0x18, 0xb0, 0xb6, 0x0b, 0xcd, 0x99, 0x14, 0x66, 0xe3, 0x68, 0x25, 0xf2, 0x35, 0x2c, 0xe1, 0xcb,
+	0x15, 0x3e, 0x91, 0x4d, 0x3d, 0x55, 0x6d, 0xc2, 0x3c, 0xce, 0x42, 0xe7, 0xf6, 0xb2, 0x80, 0xd2,
+	0xd4, 0x63, 0x89, 0x24, 0xd3, 0xe6, 0x1d, 0x59, 0x20, 0x7f, 0x20, 0x79, 0x49, 0x6a, 0x32, 0x79,
+	0x77, 0x60, 0x3e, 0xa6, 0xb9, 0x85, 0xae, 0xa9, 0x91, 0x68, 0x3c, 0x47, 0xd6, 0x92, 0x3f, 0x84,
+	0xd5, 0x07, 0x6e, 0x84, 0xd2, 0x9a, 0x1b, 0xb8, 0x10, 0xda, 0x36, 0x4b, 0xa1, 0x2d, 0x81, 0xf5,
+	0x37, 0xd1, 0xe0, 0xd2, 0xf6, 0xe4, 0x13, 0x58, 0xcb, 0x10, 0x66, 0x98, 0xd0, 0xa7, 0xb0, 0xf1,
+	0xf8, 0x3c, 0x66, 0x49, 0x69, 0x2a, 0x9b, 0xf9, 0x54, 0x24, 0x03, 0x2a, 0x46, 0x7e, 0x00, 0xd6,
+	0xd3, 0xb1, 0xa1, 0x9c, 0xe5, 0x90, 0x15, 0xba, 0x9f, 0xc2, 0xc6, 0xd3, 0x71, 0x65, 0xc7, 0x79,
+	0xc2, 0xa9, 0x3d, 0x12, 0xf9, 0xb7, 0x06, 0x2c, 0x3f, 0x60, 0x8c, 0xeb, 0xd7, 0x4b, 0x71, 0xb9,
+	0xf8, 0x7e, 0xa2, 0x1d, 0x2f, 0xfe, 0x46, 0x9b, 0x1b, 0x51, 0x37, 0xe4, 0x23, 0xc9, 0x1c, 0x2f,
+	0x39, 0xba, 0x28, 0xc8, 0x2b, 0x37, 0x08, 0x27, 0x48, 0x84, 0xcb, 0x5d, 0xca, 0xca, 0x78, 0x6b,
+	0x84, 0x6e, 0xca, 0xfb, 0xe9, 0xc4, 0xf3, 0xd0, 0x5c, 0xe7, 0x84, 0x29, 0x77, 0x50, 0x76, 0x2c,
+	0x45, 0xe8, 0x9c, 0x84, 0x8a, 0xb4, 0x3c, 0x69, 0x8f, 0x6d, 0x94, 0x3c, 0x46, 0x81, 0x7c, 0xb5,
+	0x3f, 0xe7, 0xfd, 0x84, 0xf2, 0xe4, 0x42, 0xdd, 0xa8, 0x6d, 0x94, 0x38, 0x28, 0x20, 0xdf, 0x0a,
+	0xca, 0x5e, 0x8f, 0x3e, 0x9f, 0xe9, 0x11, 0xc0, 0x80, 0x31, 0xde, 0xc7, 0x47, 0xbe, 0xb2, 0xd3,
+	0x32, 0xe7, 0xea, 0xb4, 0x07, 0xba, 0x2d, 0xb9, 0x03, 0x6b, 0xba, 0xca, 0xb8, 0x82, 0xca, 0x2b,
+	0x81, 0x34, 0x59, 0xae, 0x36, 0x63, 0x83, 0x0f, 0xc0, 0x3a, 0xa6, 0xfc, 0x19, 0x1b, 0x3e, 0xa3,
+	0xa7, 0x34, 0x34, 0xf6, 0x2c, 0xc4, 0xb2, 0xde, 0x33, 0x51, 0xc0, 0xac, 0xa6, 0xa0, 0x3b, 0xa3,
+	0xeb, 0x67, 0x60, 0x49, 0xdb, 0x79, 0x88, 0x19, 0xbc, 0x49, 0x50, 0x07, 0x61, 0x76, 0x67, 0xe2,
+	0xef, 0x2c, 0xb5, 0x97, 0xc6, 0x6c, 0xa6, 0xf6, 0x32, 0xee, 0x69, 0x72, 0x86, 0xe0, 0x85, 0xde,
+	0x66, 0x80, 0x3f, 0x16, 0x63, 0x7d, 0x95, 0xb0, 0x93, 0x20, 0x14, 0xe7, 0x3c, 0x0b, 0xbf, 0x69,
+	0x24, 0x38, 0x49, 0xa5, 0x2e, 0x4b, 0x28, 0x0f, 0x83, 0x94, 0xd3, 0x48, 0xc7, 0xaa, 0xb2, 0x84,
+	0x2f, 0x1c, 0xc5, 0x6e, 0x72, 0x58, 0xa5, 0xdf, 0x30, 0xf5, 0x8f, 0xfe, 0xb5, 0x0b, 0x70, 0x3f,
+	0x0e, 0x8e, 0x69, 0x72, 0x8a, 0x04, 0xc0, 0x77, 0xd0, 0x31, 0x9e, 0xb0, 0x2d, 0xcd, 0x65, 0x97,
+	0xbf, 0xa7, 0xb0, 0x35, 0xf9, 0x56, 0xf1, 0xde, 0x4d, 0xae, 0xfd, 0xf5, 0xff, 0xfc, 0xef, 0xdf,
+	0x35, 0x37, 0xac, 0x2b, 0xbd, 0xd3, 0xbb, 0xbd, 0x49, 0x4a, 0x13, 0xfc, 0x28, 0x25, 0x15, 0xfd,
+	0xfd, 0x1c, 0x96, 0xb2, 0x23, 0x51, 0xdb, 0x77, 0x5e, 0x51, 0x7c, 0xfa, 0xaf, 0xea, 0x98, 0xf9,
+	0x34, 0xc0, 0xce, 0xbe, 0x83, 0x76, 0xc6, 0xf0, 0x64, 0x3d, 0x97, 0xd9, 0x21, 0xbb, 0x3b, 0x5d,
+	0xa1, 0xba, 0xde, 0x15, 0x5d, 0x6f, 0x13, 0x2b, 0xeb, 0x5a, 0xbc, 0xb3, 0xf8, 0x93, 0x71, 0xfc,
+	0x55, 0xe3, 0x00, 0xc7, 0xad, 0x9f, 0xaa, 0x67, 0x8f, 0xbb, 0xfc, 0xa8, 0x5d, 0x31, 0x6e, 0x57,
+	0x77, 0x96, 0x88, 0x07, 0x26, 0xf3, 0xb9, 0xd9, 0xda, 0xcd, 0x97, 0xb6, 0xe2, 0xa5, 0xdb, 0xde,
+	0xab, 0xab, 0x56, 0x60, 0x37, 0x05, 0x98, 0xfd, 0x55, 0xe3, 0x80, 0x5c, 0x9d, 0xc2, 0x13, 0x00,
+	0x63, 0x58, 0x2b, 0x25, 0xc3, 0x56, 0x7d, 0x9e, 0x9d, 0xe1, 0xd5, 0x70, 0x8e, 0xe4, 0x86, 0xc0,
+	0xbb, 0x46, 0x36, 0x33, 0x30, 0x23, 0x31, 0xc7, 0xb5, 0xfb, 0x05, 0xcc, 0x3d, 0x74, 0xc3, 0xf0,
+	0xff, 0x83, 0xd1, 0x15, 0x18, 0x16, 0x59, 0xc9, 0x30, 0x3c, 0x37, 0x0c, 0xb1, 0xf3, 0xf7, 0x60,
+	0x4d, 0xb3, 0xa7, 0xd6, 0x4d, 0xa3, 0xbf, 0x4a, 0x62, 0x75, 0x26, 0x22, 0x11, 0x88, 0xd7, 0xc9,
+	0x76, 0x86, 0x98, 0xb8, 0x67, 0xa5, 0x89, 0xb9, 0xb0, 0x5a, 0xa4, 0x44, 0xad, 0xeb, 0xf9, 0xde,
+	0x4c, 0x33, 0xa5, 0xf6, 0xca, 0x21, 0xde, 0xb4, 0xda, 0xfc, 0x2a, 0x20, 0x86, 0x85, 0x66, 0x08,
+	0x31, 0x14, 0xb7, 0x72, 0x81, 0x48, 0xb5, 0xf6, 0xa6, 0x41, 0x4c, 0x86, 0xb5, 0x0c, 0xf3, 0x91,
+	0x80, 0xd9, 0x23, 0xd7, 0xaa, 0x60, 0x44, 0x43, 0x04, 0xba, 0x10, 0x4e, 0x7f, 0x8a, 0x7e, 0xb5,
+	0x48, 0x0e, 0x56, 0xc7, 0xcd, 0xda, 0x1b, 0x1a, 0xd0, 0xd0, 0x20, 0xfb, 0x02, 0x96, 0xa0, 0x19,
+	0xee, 0x9a, 0xc8, 0xd3, 0x10, 0x48, 0x3d, 0x14, 0xbb, 0x57, 0xb4, 0xeb, 0x07, 0x81, 0xdf, 0xaa,
+	0xb2, 0xaa, 0x02, 0x6b, 0x4b, 0x3e, 0x11, 0x43, 0xb9, 0x8d, 0x43, 0xd9, 0xab, 0x19, 0x8a, 0x46,
+	0xec, 0x43, 0x3b, 0xfb, 0xfc, 0x2c, 0x3b, 0xe8, 0xe5, 0xcf, 0xe4, 0xec, 0xee, 0x74, 0x45, 0xd1,
+	0x8d, 0x20, 0x54, 0xee, 0x49, 0x52, 0xad, 0xf6, 0x93, 0x86, 0xf2, 0xaf, 0x9a, 0x52, 0x9a, 0xed,
+	0x4b, 0xca, 0xe4, 0x13, 0xb9, 0x2e, 0x10, 0xb6, 0xac, 0x4d, 0x73, 0x26, 0x59, 0x7f, 0x14, 0x3a,
+	0x06, 0xfb, 0x74, 0xd9, 0x91, 0xd3, 0x0e, 0xbc, 0x82, 0xac, 0xd2, 0x47, 0x1a, 0x67, 0x91, 0xc3,
+	0x98, 0x54, 0xd5, 0xf7, 0xc2, 0x6b, 0x49, 0x1e, 0xe3, 0x07, 0x18, 0xca, 0x55, 0x93, 0xad, 0xca,
+	0xe1, 0x6e, 0x0b, 0xb8, 0x5d, 0x84, 0xeb, 0x9a, 0xb3, 0x2a, 0xf4, 0x3f, 0x86, 0xd5, 0x22, 0x29,
+	0x94, 0x1d, 0xb6, 0x4a, 0xda, 0xca, 0xde, 0xad, 0xa9, 0x55, 0x98, 0x7b, 0x02, 0xb3, 0x8b, 0x98,
+	0x1b, 0x19, 0xe6, 0x89, 0xd0, 0xe9, 0x45, 0xf4, 0xcc, 0x8a, 0xc4, 0xc1, 0x93, 0x8d, 0xe4, 0x37,
+	0x8c, 0xf9, 0x6a, 0x56, 0xa0, 0xe9, 0x77, 0xd3, 0x2a, 0x82, 0xa7, 0xe2, 0xa0, 0x2b, 0x20, 0x4f,
+	0x76, 0x8c, 0xe7, 0x6f, 0x04, 0x2b, 0x19, 0xde, 0x33, 0x36, 0xfc, 0xf1, 0x60, 0xd3, 0xee, 0x58,
+	0x81, 0x85, 0x6c, 0x28, 0x90, 0xfe, 0x1c, 0x36, 0xab, 0x28, 0xa4, 0xcb, 0x00, 0x6f, 0xab, 0xaa,
+	0xcb, 0xa8, 0x27, 0xed, 0x67, 0x70, 0x45, 0xaf, 0x95, 0xb1, 0x27, 0xba, 0xa1, 0x35, 0x11, 0x99,
+	0x4f, 0x15, 0x6d, 0x53, 0x7f, 0x16, 0x34, 0xfc, 0x65, 0x64, 0x4f, 0xc5, 0xb9, 0xa0, 0x46, 0xdf,
+	0xbf, 0x14, 0xcb, 0x9b, 0x33, 0x60, 0xf5, 0x60, 0x7a, 0x19, 0xa6, 0xd9, 0x32, 0xb2, 0x23, 0x20,
+	0xae, 0x5a, 0xb9, 0xc1, 0xa4, 0x79, 0x87, 0xbf, 0x06, 0x6b, 0xfa, 0x8b, 0xa3, 0xec, 0x22, 0xaa,
+	0xfd, 0x84, 0xc9, 0xbe, 0x75, 0x89, 0x46, 0xf1, 0x7c, 0x18, 0x87, 0xc3, 0x2f, 0x6a, 0xe2, 0xb6,
+	0xbe, 0x85, 0x25, 0xfd, 0x5d, 0x89, 0xb5, 0x95, 0xf7, 0x69, 0x7e, 0xba, 0x62, 0x6f, 0x4f, 0xc9,
+	0x8b, 0x01, 0x0a, 0xee, 0xdd, 0x6a, 0x06, 0x22, 0x3e, 0x12, 0x41, 0x87, 0xf5, 0x2a, 0x61, 0x9c,
+	0xbd, 0x66, 0xdf, 0x1e, 0xbf, 0x7c, 0x61, 0x5d, 0xcd, 0xbf, 0x88, 0x30, 0x78, 0x25, 0x7b, 0xab,
+	0x2c, 0xae, 0xb5, 0xc6, 0x58, 0x75, 0x96, 0xca, 0x3b, 0xf4, 0x3b, 0xe8, 0x60, 0xbf, 0xaf, 0x99,
+	0x00, 0xf9, 0x91, 0xdd, 0x17, 0x1d, 0x15, 0x72, 0x4a, 0xba, 0xbf, 0x01, 0x2c, 0x9b, 0x9f, 0x1f,
+	0x59, 0x85, 0xb0, 0xb5, 0xf8, 0x11, 0x93, 0xbd, 0x53, 0x59, 0x57, 0x5c, 0x21, 0x63, 0x79, 0x04,
+	0x71, 0x84, 0x53, 0xf8, 0x15, 0x2c, 0x9b, 0xdf, 0x14, 0x65, 0x18, 0x15, 0xdf, 0x2b, 0xd9, 0x3b,
+	0x95, 0x75, 0x0a, 0xe3, 0x96, 0xc0, 0xd8, 0x21, 0x5b, 0x45, 0x8c, 0x5e, 0x22, 0x95, 0x11, 0xeb,
+	0x6f, 0x1a, 0xe2, 0x03, 0xac, 0xf2, 0x67, 0x7b, 0x96, 0x61, 0x45, 0x35, 0x1f, 0x0e, 0xda, 0xe4,
+	0x32, 0x15, 0x35, 0x82, 0x3b, 0x62, 0x04, 0x37, 0x88, 0x9d, 0xc7, 0x59, 0x4a, 0xb5, 0xa7, 0x3f,
+	0x69, 0xf8, 0xaa, 0x71, 0x70, 0xf4, 0x5f, 0x9b, 0xb0, 0x7c, 0xdf, 0x1f, 0x07, 0x91, 0xce, 0x1a,
+	0x3c, 0x80, 0xfc, 0xf1, 0xcc, 0xea, 0xe6, 0xae, 0xb7, 0xf8, 0xfe, 0x64, 0x5f, 0xab, 0xa8, 0x29,
+	0x86, 0xad, 0x32, 0x66, 0x75, 0xb1, 0x73, 0x1d, 0xb4, 0xa2, 0x3f, 0xc6, 0xb9, 0x33, 0x58, 0x29,
+	0xbc, 0x81, 0x59, 0x3b, 0x99, 0x5b, 0x9a, 0x7e, 0x87, 0xb3, 0xaf, 0x57, 0x57, 0x56, 0x1d, 0xa9,
+	0x22, 0x9a, 0xe4, 0xb9, 0x64, 0xf0, 0xd5, 0x31, 0xde, 0xc4, 0x32, 0x07, 0x39, 0xfd, 0xae, 0x66,
+	0xdb, 0x55, 0x55, 0x55, 0xbb, 0x5a, 0x84, 0xca, 0x81, 0xd6, 0x4a, 0xaf, 0x69, 0x1f, 0x14, 0x2c,
+	0x57, 0x3f, 0xc0, 0x4d, 0x1d, 0x66, 0x89, 0x99, 0x06, 0xc3, 0xc8, 0xfa, 0xa7, 0x06, 0xec, 0x96,
+	0x22, 0xde, 0x9f, 0x07, 0x7c, 0x94, 0xbf, 0x85, 0x59, 0x1f, 0x57, 0xc7, 0xc5, 0x53, 0xcf, 0x75,
+	0xf6, 0xfe, 0x6c, 0x45, 0x35, 0x9e, 0x43, 0x31, 0x9e, 0x7d, 0x1c, 0xcf, 0xed, 0x7c, 0x3c, 0xbc,
+	0x76, 0x08, 0x67, 0x60, 0x4d, 0x7f, 0xfe, 0x5d, 0xef, 0xb0, 0xb5, 0xe9, 0xd7, 0x7f, 0x32, 0xae,
+	0xcd, 0xda, 0xda, 0x35, 0x96, 0x23, 0xd3, 0xee, 0x45, 0x4a, 0xdd, 0xfa, 0x05, 0x40, 0xee, 0x85,
+	0x67, 0xdf, 0x10, 0xd3, 0x1f, 0xb3, 0x16, 0x13, 0x3d, 0x09, 0xa4, 0x5c, 0xb5, 0xf5, 0x67, 0x70,
+	0x65, 0xea, 0x93, 0x39, 0xeb, 0x86, 0xd1, 0x55, 0xd5, 0x67, 0x78, 0xf6, 0xcd, 0x7a, 0x85, 0x7a,
+	0x4b, 0xf6, 0x0b, 0x9a, 0x68, 0x60, 0xa7, 0xb0, 0x56, 0xfa, 0x23, 0x46, 0x96, 0x65, 0x56, 0xff,
+	0xb3, 0xc3, 0xde, 0xab, 0xab, 0xae, 0xb9, 0xed, 0x25, 0xb2, 0x57, 0x02, 0x79, 0x0f, 0x5b, 0xd5,
+	0x34, 0x78, 0xfd, 0xea, 0xde, 0x51, 0x15, 0x97, 0xd3, 0xe7, 0xda, 0x5d, 0x58, 0xc6, 0xb4, 0xf9,
+	0x79, 0xcc, 0x58, 0xd8, 0x0b, 0x64, 0x43, 0xeb, 0x0c, 0xd6, 0x4a, 0x8c, 0xf9, 0x07, 0xc5, 0xa8,
+	0x7a, 0xe2, 0x35, 0x6c, 0x7b, 0x95, 0x9f, 0x52, 0xc0, 0x7e, 0xc2, 0x04, 0x57, 0x70, 0x0e, 0xeb,
+	0x65, 0xe2, 0xdb, 0xca, 0xd3, 0xcd, 0x4a, 0x42, 0xde, 0xbe, 0x51, 0x5b, 0x3f, 0xdb, 0x61, 0xc5,
+	0x42, 0x13, 0x91, 0xb9, 0x08, 0xcb, 0x4d, 0x5a, 0xd9, 0x24, 0x13, 0x2a, 0x68, 0x75, 0x7b, 0xaf,
+	0xae, 0xba, 0x2a, 0x74, 0x2d, 0xc2, 0xba, 0xa8, 0x88, 0xa8, 0x6f, 0x64, 0xe4, 0x41, 0xd1, 0xa0,
+	0x67, 0xe7, 0x33, 0x25, 0x8e, 0x99, 0x6c, 0x0b, 0x84, 0x2b, 0xd6, 0x5a, 0x8e, 0x20, 0xf8, 0x56,
+	0xeb, 0x8f, 0x61, 0x51, 0x71, 0xbe, 0x59, 0x54, 0x50, 0x64, 0x99, 0xed, 0xad, 0xb2, 0xb8, 0x18,
+	0xdb, 0x93, 0x8d, 0x52, 0x97, 0xbd, 0x81, 0x2b, 0x62, 0x8e, 0x3f, 0x85, 0x76, 0xc6, 0x38, 0x67,
+	0x23, 0x2e, 0x73, 0xd0, 0xb5, 0xbd, 0x97, 0xf9, 0x15, 0x13, 0x60, 0x82, 0x9d, 0x58, 0x03, 0xe8,
+	0x18, 0x14, 0xf4, 0x6c, 0x0e, 0xad, 0x82, 0xaf, 0xd6, 0x73, 0xb0, 0xb6, 0xca, 0x10, 0x54, 0x28,
+	0xe3, 0xdd, 0x64, 0xb0, 0xd1, 0xd9, 0x75, 0x31, 0xcd, 0x66, 0xdb, 0x76, 0x55, 0x55, 0xfd, 0xdd,
+	0x24, 0x51, 0x02, 0xa1, 0x2c, 0x49, 0x8e, 0x65, 0x93, 0x0d, 0xae, 0x9f, 0x8d, 0x11, 0x3e, 0x4d,
+	0x71, 0xc7, 0xc5, 0xd0, 0x59, 0x02, 0x65, 0x24, 0x31, 0xae, 0xd7, 0x7d, 0xdf, 0xd7, 0x8d, 0xb2,
+	0xe8, 0xb5, 0x44, 0x1c, 0xdb, 0xdb, 0x53, 0xf2, 0x7a, 0x23, 0xcd, 0x3a, 0xef, 0xb9, 0xbe, 0x2f,
+	0xf3, 0xab, 0x55, 0x87, 0x8e, 0xd9, 0x29, 0xfd, 0xf1, 0x30, 0xea, 0x16, 0xc1, 0x8d, 0xb7, 0xab,
+	0x90, 0x12, 0xd1, 0xbf, 0xe5, 0x41, 0xc7, 0xe0, 0x9c, 0xb3, 0x9d, 0x99, 0xe6, 0xac, 0x6d, 0xbb,
+	0xaa, 0xaa, 0x8a, 0x8f, 0x94, 0x48, 0xa1, 0xd2, 0x51, 0xa1, 0x89, 0xc1, 0x2d, 0xe7, 0xb9, 0xdb,
+	0x14, 0x7b, 0x6d, 0xdb, 0x55, 0x55, 0xf5, 0xdb, 0x2f, 0xbe, 0x5d, 0x53, 0x46, 0x86, 0x40, 0x1e,
+	0x2c, 0x9b, 0x74, 0xb2, 0x65, 0x8c, 0xb9, 0x4c, 0x55, 0xdb, 0x3b, 0x95, 0x75, 0x0a, 0xcb, 0x16,
+	0x58, 0x9b, 0xc4, 0x3c, 0xe7, 0x71, 0xc2, 0x4e, 0xbe, 0x6a, 0x1c, 0x0c, 0x16, 0x44, 0x52, 0xf0,
+	0xf9, 0xff, 0x0d, 0x00, 0x69, 0x15, 0xd6, 0x4d, 0xfc, 0x38, 0x00, 0x00,
 }
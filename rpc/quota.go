@@ -0,0 +1,275 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyMetadata is the metadata key of the API key in gRPC, it's header "X-Api-Key" in HTTP.
+const APIKeyMetadata = "x-api-key"
+
+const anonymousConsumer = "anonymous"
+
+// Errors of quota
+var (
+	ErrAPIKeyRequired       = status.Error(codes.Unauthenticated, "api key required")
+	ErrInvalidAPIKey        = status.Error(codes.Unauthenticated, "invalid api key")
+	ErrRequestQuotaExceeded = status.Error(codes.ResourceExhausted, "request quota exceeded")
+	ErrSubscriptionExceeded = status.Error(codes.ResourceExhausted, "too many subscriptions")
+)
+
+// tokenBucket allows rate events per second on average, and burst events at once.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst uint32) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := float64(burst)
+	if b < rate {
+		b = rate
+	}
+	if b < 1 {
+		b = 1
+	}
+	return &tokenBucket{rate: rate, burst: b, tokens: b, last: time.Now()}
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// consumer is the usage of an API key, all requests without a key share the anonymous one.
+type consumer struct {
+	name string
+
+	mu               sync.Mutex
+	requests         *tokenBucket
+	events           *tokenBucket
+	maxSubscriptions uint32
+	subscriptions    uint32
+
+	requestMeter      metrics.Meter
+	rejectedMeter     metrics.Meter
+	droppedMeter      metrics.Meter
+	subscriptionGauge metrics.Gauge
+}
+
+func newConsumer(name string, quota *nebletpb.QuotaConfig) *consumer {
+	if quota == nil {
+		quota = &nebletpb.QuotaConfig{}
+	}
+	return &consumer{
+		name:              name,
+		requests:          newTokenBucket(quota.RequestsPerSecond, quota.Burst),
+		events:            newTokenBucket(quota.EventsPerSecond, 0),
+		maxSubscriptions:  quota.MaxSubscriptions,
+		requestMeter:      metrics.GetOrRegisterMeter(fmt.Sprintf("neb.rpc.quota.%s.request", name), nil),
+		rejectedMeter:     metrics.GetOrRegisterMeter(fmt.Sprintf("neb.rpc.quota.%s.rejected", name), nil),
+		droppedMeter:      metrics.GetOrRegisterMeter(fmt.Sprintf("neb.rpc.quota.%s.dropped", name), nil),
+		subscriptionGauge: metrics.GetOrRegisterGauge(fmt.Sprintf("neb.rpc.quota.%s.subscription", name), nil),
+	}
+}
+
+// allowRequest takes a request from the quota.
+func (c *consumer) allowRequest() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestMeter.Mark(1)
+	if c.requests != nil && !c.requests.take(time.Now()) {
+		c.rejectedMeter.Mark(1)
+		return false
+	}
+	return true
+}
+
+// openSubscription takes a subscription from the quota, it must be closed after.
+func (c *consumer) openSubscription() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxSubscriptions > 0 && c.subscriptions >= c.maxSubscriptions {
+		c.rejectedMeter.Mark(1)
+		return false
+	}
+	c.subscriptions++
+	c.subscriptionGauge.Update(int64(c.subscriptions))
+	return true
+}
+
+func (c *consumer) closeSubscription() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscriptions--
+	c.subscriptionGauge.Update(int64(c.subscriptions))
+}
+
+// allowEvent takes an event sent to subscriptions from the quota, the event is dropped if not allowed.
+func (c *consumer) allowEvent() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.events != nil && !c.events.take(time.Now()) {
+		c.droppedMeter.Mark(1)
+		return false
+	}
+	return true
+}
+
+// QuotaManager enforces the quotas of API keys on the gRPC services, the HTTP gateway forwards the keys to them.
+type QuotaManager struct {
+	consumers  map[string]*consumer
+	anonymous  *consumer
+	requireKey bool
+}
+
+// NewQuotaManager returns the quota manager of the API keys in config.
+func NewQuotaManager(cfg *nebletpb.RPCConfig) *QuotaManager {
+	qm := &QuotaManager{
+		consumers:  make(map[string]*consumer),
+		anonymous:  newConsumer(anonymousConsumer, cfg.AnonymousQuota),
+		requireKey: cfg.RequireApiKey,
+	}
+	for _, v := range cfg.ApiKeys {
+		name := v.Name
+		if name == "" {
+			name = fmt.Sprintf("key%d", len(qm.consumers))
+		}
+		qm.consumers[v.Key] = newConsumer(name, v.Quota)
+	}
+	return qm
+}
+
+type consumerKey struct{}
+
+// consumerFromContext returns the consumer of the request, nil if quotas are not enforced.
+func consumerFromContext(ctx context.Context) *consumer {
+	c, _ := ctx.Value(consumerKey{}).(*consumer)
+	return c
+}
+
+func (qm *QuotaManager) consumer(ctx context.Context) (*consumer, error) {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[APIKeyMetadata]) > 0 {
+		key = md[APIKeyMetadata][0]
+	}
+	if key == "" {
+		if qm.requireKey {
+			return nil, ErrAPIKeyRequired
+		}
+		return qm.anonymous, nil
+	}
+	c, ok := qm.consumers[key]
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+	return c, nil
+}
+
+func (qm *QuotaManager) reject(c *consumer, method string, err error) error {
+	name := anonymousConsumer
+	if c != nil {
+		name = c.name
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"consumer": name,
+		"method":   method,
+		"err":      err,
+	}).Debug("Rejected rpc request.")
+	return err
+}
+
+// UnaryInterceptor checks the request quota before the handler.
+func (qm *QuotaManager) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		c, err := qm.consumer(ctx)
+		if err != nil {
+			return nil, qm.reject(nil, info.FullMethod, err)
+		}
+		if !c.allowRequest() {
+			return nil, qm.reject(c, info.FullMethod, ErrRequestQuotaExceeded)
+		}
+		return handler(context.WithValue(ctx, consumerKey{}, c), req)
+	}
+}
+
+// quotaStream passes the consumer to the stream handler.
+type quotaStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *quotaStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamInterceptor checks the request and subscription quotas before the handler,
+// every stream is a subscription until it ends.
+func (qm *QuotaManager) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		c, err := qm.consumer(ss.Context())
+		if err != nil {
+			return qm.reject(nil, info.FullMethod, err)
+		}
+		if !c.allowRequest() {
+			return qm.reject(c, info.FullMethod, ErrRequestQuotaExceeded)
+		}
+		if !c.openSubscription() {
+			return qm.reject(c, info.FullMethod, ErrSubscriptionExceeded)
+		}
+		defer c.closeSubscription()
+		return handler(srv, &quotaStream{ss, context.WithValue(ss.Context(), consumerKey{}, c)})
+	}
+}
+
+// forwardAPIKey passes the API key in HTTP header to the gRPC metadata.
+func forwardAPIKey(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(APIKeyMetadata); key != "" {
+			r.Header.Set("Grpc-Metadata-"+APIKeyMetadata, key)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTokenBucket(t *testing.T) {
+	assert.Nil(t, newTokenBucket(0, 10))
+
+	now := time.Now()
+	b := newTokenBucket(2, 3)
+	b.last = now
+	for i := 0; i < 3; i++ {
+		assert.True(t, b.take(now))
+	}
+	assert.False(t, b.take(now))
+	assert.True(t, b.take(now.Add(500*time.Millisecond)))
+	assert.False(t, b.take(now.Add(500*time.Millisecond)))
+}
+
+type mockStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestQuotaManager(t *testing.T) {
+	qm := NewQuotaManager(&nebletpb.RPCConfig{
+		ApiKeys: []*nebletpb.APIKeyConfig{
+			{Key: "gold", Name: "gold", Quota: &nebletpb.QuotaConfig{RequestsPerSecond: 100, MaxSubscriptions: 1}},
+			{Key: "free", Name: "free", Quota: &nebletpb.QuotaConfig{RequestsPerSecond: 1, Burst: 1, EventsPerSecond: 1}},
+		},
+	})
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyMetadata, key))
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return consumerFromContext(ctx).name, nil
+	}
+	unary := qm.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/GetNebState"}
+
+	name, err := unary(withKey("free"), nil, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "free", name)
+	_, err = unary(withKey("free"), nil, info, handler)
+	assert.Equal(t, ErrRequestQuotaExceeded, err)
+	_, err = unary(withKey("gold"), nil, info, handler)
+	assert.Nil(t, err)
+	_, err = unary(withKey("fake"), nil, info, handler)
+	assert.Equal(t, ErrInvalidAPIKey, err)
+	name, err = unary(context.Background(), nil, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, anonymousConsumer, name)
+
+	// subscriptions of a key are limited while they are open.
+	stream := qm.StreamInterceptor()
+	sinfo := &grpc.StreamServerInfo{FullMethod: "/rpcpb.ApiService/Subscribe"}
+	opened, done, closed := make(chan bool), make(chan bool), make(chan error)
+	go func() {
+		closed <- stream(nil, &mockStream{ctx: withKey("gold")}, sinfo, func(srv interface{}, ss grpc.ServerStream) error {
+			opened <- true
+			<-done
+			return nil
+		})
+	}()
+	<-opened
+	noop := func(srv interface{}, ss grpc.ServerStream) error { return nil }
+	assert.Equal(t, ErrSubscriptionExceeded, stream(nil, &mockStream{ctx: withKey("gold")}, sinfo, noop))
+	done <- true
+	assert.Nil(t, <-closed)
+	assert.Nil(t, stream(nil, &mockStream{ctx: withKey("gold")}, sinfo, noop))
+
+	free := qm.consumers["free"]
+	assert.True(t, free.allowEvent())
+	assert.False(t, free.allowEvent())
+
+	qm = NewQuotaManager(&nebletpb.RPCConfig{RequireApiKey: true})
+	_, err = qm.UnaryInterceptor()(context.Background(), nil, info, handler)
+	assert.Equal(t, ErrAPIKeyRequired, err)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/net/p2p"
+	nsync "github.com/nebulasio/go-nebulas/sync"
 )
 
 // Neblet interface breaks cycle import dependency and hides unused services.
@@ -32,6 +33,7 @@ type Neblet interface {
 	AccountManager() *account.Manager
 	NetManager() p2p.Manager
 	EventEmitter() *core.EventEmitter
+	SyncManager() *nsync.Manager
 }
 
 // Server server interface for api & management etc.
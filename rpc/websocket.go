@@ -0,0 +1,256 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/rpc/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// WebSocketPath is the path of the WebSocket endpoint of the gateway, the topics and addresses to
+	// subscribe are given in the query, e.g. "/v1/ws?topic=chain.linkBlock&topic=chain.contract.*&address=n1...".
+	WebSocketPath = "/v1/ws"
+
+	// TopicEventsDropped is the topic of the message sent to a client, with the count of the events
+	// dropped since the last message as it didn't read them in time.
+	TopicEventsDropped = "ws.eventsDropped"
+
+	// webSocketQueueSize is the count of events queued for a client, the new ones are dropped if it's full.
+	webSocketQueueSize = 256
+
+	webSocketWriteTimeout = 10 * time.Second
+	webSocketPongTimeout  = 60 * time.Second
+	webSocketPingPeriod   = webSocketPongTimeout * 9 / 10
+)
+
+var (
+	webSocketConnGauge   = metrics.GetOrRegisterGauge("neb.rpc.ws.connections", nil)
+	webSocketDropped     = metrics.GetOrRegisterMeter("neb.rpc.ws.dropped", nil)
+	webSocketConnections int64
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// the gateway allows every origin, as in allowCORS.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// eventFilter is the filter of a WebSocket client on the events of its topics.
+type eventFilter struct {
+	topics    []string
+	addresses []string
+}
+
+// newEventFilter returns the filter in the query of the request, the addresses are either in the
+// string form or hex.
+func newEventFilter(r *http.Request) (*eventFilter, error) {
+	query := r.URL.Query()
+	filter := &eventFilter{topics: query["topic"]}
+	if len(filter.topics) == 0 {
+		filter.topics = []string{core.TopicLinkBlock, core.TopicPendingTransaction}
+	}
+	for _, v := range query["address"] {
+		addr, err := core.AddressParse(v)
+		if err != nil {
+			return nil, err
+		}
+		filter.addresses = append(filter.addresses, addr.String(), byteutils.Hex(addr.Bytes()))
+	}
+	return filter, nil
+}
+
+// match returns if the event passes the filter. The chain events are of the topics subscribed, but
+// the blocks and txs received from network are sent to every subscription, so they are of the topics
+// only if named exactly. An event of the addresses mentions one of them in its data.
+func (f *eventFilter) match(event *rpcpb.SubscribeResponse) bool {
+	if event.MsgType == core.MessageTypeNewBlock || event.MsgType == core.MessageTypeNewTx {
+		found := false
+		for _, v := range f.topics {
+			if v == event.MsgType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.addresses) == 0 {
+		return true
+	}
+	for _, v := range f.addresses {
+		if strings.Contains(event.Data, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// webSocketHandler bridges the events subscribed through the API service to WebSocket clients.
+type webSocketHandler struct {
+	client rpcpb.ApiServiceClient
+}
+
+func newWebSocketHandler(client rpcpb.ApiServiceClient) *webSocketHandler {
+	return &webSocketHandler{client: client}
+}
+
+// serveWebSocket serves the WebSocket endpoint on the path, and the others by next.
+func serveWebSocket(ws http.Handler, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == WebSocketPath {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// outgoingContext passes the API key and auth token of the client to the API service. Browsers can't
+// set the headers of WebSocket, so they are read from the query as well.
+func outgoingContext(ctx context.Context, r *http.Request) context.Context {
+	var pairs []string
+	if key := r.Header.Get(APIKeyMetadata); key != "" {
+		pairs = append(pairs, APIKeyMetadata, key)
+	} else if key := r.URL.Query().Get("api_key"); key != "" {
+		pairs = append(pairs, APIKeyMetadata, key)
+	}
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		pairs = append(pairs, AuthMetadata, auth)
+	} else if token := r.URL.Query().Get("token"); token != "" {
+		pairs = append(pairs, AuthMetadata, bearerPrefix+token)
+	}
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs(pairs...))
+}
+
+func (h *webSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter, err := newEventFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := context.WithCancel(outgoingContext(context.Background(), r))
+	defer cancel()
+	stream, err := h.client.Subscribe(ctx, &rpcpb.SubscribeRequest{Topic: filter.topics})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	webSocketConnGauge.Update(atomic.AddInt64(&webSocketConnections, 1))
+	defer func() {
+		webSocketConnGauge.Update(atomic.AddInt64(&webSocketConnections, -1))
+	}()
+
+	logging.VLog().WithFields(logrus.Fields{
+		"remote": r.RemoteAddr,
+		"topics": filter.topics,
+	}).Info("WebSocket client subscribed.")
+
+	// the client only sends control messages, the connection is closed once reading fails.
+	go func() {
+		defer cancel()
+		conn.SetReadLimit(512)
+		conn.SetReadDeadline(time.Now().Add(webSocketPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(webSocketPongTimeout))
+		})
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// the events are queued for the writer, so a slow client never blocks the stream.
+	queue := make(chan *rpcpb.SubscribeResponse, webSocketQueueSize)
+	var dropped int64
+	go func() {
+		defer cancel()
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"remote": r.RemoteAddr,
+					"err":    err,
+				}).Debug("WebSocket subscription closed.")
+				return
+			}
+			if !filter.match(event) {
+				continue
+			}
+			select {
+			case queue <- event:
+			default:
+				atomic.AddInt64(&dropped, 1)
+				webSocketDropped.Mark(1)
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(webSocketPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(webSocketWriteTimeout))
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteTimeout)); err != nil {
+				return
+			}
+		case event := <-queue:
+			if n := atomic.SwapInt64(&dropped, 0); n > 0 {
+				if err := writeEvent(conn, &rpcpb.SubscribeResponse{MsgType: TopicEventsDropped, Data: strconv.FormatInt(n, 10)}); err != nil {
+					return
+				}
+			}
+			if err := writeEvent(conn, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(conn *websocket.Conn, event *rpcpb.SubscribeResponse) error {
+	conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	return conn.WriteJSON(event)
+}
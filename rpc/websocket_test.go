@@ -0,0 +1,107 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// subscribeClient streams the events in its channel to every subscription.
+type subscribeClient struct {
+	rpcpb.ApiServiceClient
+	events chan *rpcpb.SubscribeResponse
+	reqs   chan *rpcpb.SubscribeRequest
+	md     chan metadata.MD
+}
+
+type subscribeStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *rpcpb.SubscribeResponse
+}
+
+func (s *subscribeStream) Recv() (*rpcpb.SubscribeResponse, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	case event := <-s.events:
+		return event, nil
+	}
+}
+
+func (c *subscribeClient) Subscribe(ctx context.Context, in *rpcpb.SubscribeRequest, opts ...grpc.CallOption) (rpcpb.ApiService_SubscribeClient, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.md <- md
+	c.reqs <- in
+	return &subscribeStream{ctx: ctx, events: c.events}, nil
+}
+
+func TestWebSocket_Subscribe(t *testing.T) {
+	client := &subscribeClient{
+		events: make(chan *rpcpb.SubscribeResponse),
+		reqs:   make(chan *rpcpb.SubscribeRequest, 1),
+		md:     make(chan metadata.MD, 1),
+	}
+	srv := httptest.NewServer(serveWebSocket(newWebSocketHandler(client), nil))
+	defer srv.Close()
+
+	addr, err := core.NewAddressFromPublicKey([]byte("websocket"))
+	assert.Nil(t, err)
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + WebSocketPath +
+		"?topic=chain.contract.*&topic=" + core.MessageTypeNewTx + "&address=" + addr.String() + "&token=secret"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	assert.Equal(t, []string{"chain.contract.*", core.MessageTypeNewTx}, (<-client.reqs).Topic)
+	assert.Equal(t, []string{"Bearer secret"}, (<-client.md)[AuthMetadata])
+
+	// the events of other addresses and the blocks not subscribed are filtered out.
+	client.events <- &rpcpb.SubscribeResponse{MsgType: "chain.contract.foo", Data: `{"from":"other"}`}
+	client.events <- &rpcpb.SubscribeResponse{MsgType: core.MessageTypeNewBlock, Data: `{"coinbase":"` + addr.String() + `"}`}
+	client.events <- &rpcpb.SubscribeResponse{MsgType: core.MessageTypeNewTx, Data: `{"from":"` + addr.String() + `"}`}
+
+	event := new(rpcpb.SubscribeResponse)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	assert.Nil(t, conn.ReadJSON(event))
+	assert.Equal(t, core.MessageTypeNewTx, event.MsgType)
+	assert.Contains(t, event.Data, addr.String())
+}
+
+func TestEventFilter_Match(t *testing.T) {
+	filter := &eventFilter{topics: []string{core.TopicLinkBlock}}
+	assert.True(t, filter.match(&rpcpb.SubscribeResponse{MsgType: core.TopicLinkBlock}))
+	assert.False(t, filter.match(&rpcpb.SubscribeResponse{MsgType: core.MessageTypeNewBlock}))
+
+	filter.addresses = []string{"abc"}
+	assert.False(t, filter.match(&rpcpb.SubscribeResponse{MsgType: core.TopicLinkBlock, Data: "{}"}))
+	assert.True(t, filter.match(&rpcpb.SubscribeResponse{MsgType: core.TopicLinkBlock, Data: `{"to":"abc"}`}))
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+//go:build badger
+// +build badger
+
+package storage
+
+import (
+	"github.com/dgraph-io/badger"
+)
+
+// BadgerDriver is the pure-go badger on disk, in the builds with tag badger.
+const BadgerDriver = "badger"
+
+func init() {
+	Register(BadgerDriver, func(path string) (Database, error) {
+		return NewBadgerStorage(path)
+	})
+}
+
+// BadgerStorage the nodes in trie.
+type BadgerStorage struct {
+	db *badger.DB
+}
+
+// NewBadgerStorage init a storage
+func NewBadgerStorage(path string) (*BadgerStorage, error) {
+	opts := badger.DefaultOptions
+	opts.Dir = path
+	opts.ValueDir = path
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &BadgerStorage{
+		db: db,
+	}, nil
+}
+
+// Get return value to the key in Storage
+func (storage *BadgerStorage) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := storage.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrKeyNotFound
+	}
+	return value, err
+}
+
+// Put put the key-value entry to Storage
+func (storage *BadgerStorage) Put(key []byte, value []byte) error {
+	return storage.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+// Del delete the key in Storage.
+func (storage *BadgerStorage) Del(key []byte) error {
+	return storage.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// NewBatch returns a batch written in a badger transaction.
+func (storage *BadgerStorage) NewBatch() Batch {
+	return &badgerBatch{db: storage.db}
+}
+
+// NewIterator returns an iterator of the entries with keys of the prefix, in a read-only transaction.
+func (storage *BadgerStorage) NewIterator(prefix []byte) Iterator {
+	txn := storage.db.NewTransaction(false)
+	return &badgerIterator{txn: txn, it: txn.NewIterator(badger.DefaultIteratorOptions), prefix: prefix}
+}
+
+// Close badger
+func (storage *BadgerStorage) Close() error {
+	return storage.db.Close()
+}
+
+// Stats returns the size of the lsm tree and the value log of badger, it compacts the tree by
+// itself, so no level is reported. The sizes of prefixes are summed up by iteration.
+func (storage *BadgerStorage) Stats(prefixes [][]byte) (*Stats, error) {
+	lsm, vlog := storage.db.Size()
+	stats := &Stats{
+		Size:        lsm + vlog,
+		PrefixSizes: make(map[string]int64),
+	}
+	for _, prefix := range prefixes {
+		var size int64
+		err := storage.db.View(func(txn *badger.Txn) error {
+			it := txn.NewIterator(badger.IteratorOptions{})
+			defer it.Close()
+			for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+				size += it.Item().EstimatedSize()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		stats.PrefixSizes[string(prefix)] = size
+	}
+	return stats, nil
+}
+
+// Compact collects the garbage of the value log of badger till nothing to collect, the range is
+// ignored as the value log is shared by all the keys.
+func (storage *BadgerStorage) Compact(r *Range) error {
+	for {
+		err := storage.db.RunValueLogGC(0.5)
+		if err == badger.ErrNoRewrite {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type badgerBatch struct {
+	db  *badger.DB
+	ops []func(txn *badger.Txn) error
+}
+
+func (b *badgerBatch) Put(key []byte, value []byte) {
+	b.ops = append(b.ops, func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+func (b *badgerBatch) Del(key []byte) {
+	b.ops = append(b.ops, func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+func (b *badgerBatch) Write() error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		for _, op := range b.ops {
+			if err := op(txn); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type badgerIterator struct {
+	txn     *badger.Txn
+	it      *badger.Iterator
+	prefix  []byte
+	started bool
+	value   []byte
+	err     error
+}
+
+func (it *badgerIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.started {
+		it.it.Next()
+	} else {
+		it.it.Seek(it.prefix)
+		it.started = true
+	}
+	if !it.it.ValidForPrefix(it.prefix) {
+		return false
+	}
+	it.value, it.err = it.it.Item().ValueCopy(it.value[:0])
+	return it.err == nil
+}
+
+func (it *badgerIterator) Key() []byte {
+	return it.it.Item().Key()
+}
+
+func (it *badgerIterator) Value() []byte {
+	return it.value
+}
+
+func (it *badgerIterator) Release() {
+	it.it.Close()
+	it.txn.Discard()
+}
+
+func (it *badgerIterator) Error() error {
+	return it.err
+}
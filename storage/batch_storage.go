@@ -0,0 +1,216 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// JournalKey is the key of the undo journal of a flush in progress, on the storages without batches.
+var JournalKey = []byte("storage_journal")
+
+// BatchStorage buffers the puts and dels to a Storage while batching, the buffered entries are read
+// back, and written at once by Flush. They are written in a batch of a Database, or through an undo
+// journal on the other storages, which RecoverJournal rolls back if the node crashed in the middle.
+type BatchStorage struct {
+	storage Storage
+
+	mu       sync.RWMutex
+	batching bool
+	entries  map[string]*batchEntry
+	keys     []string
+}
+
+type batchEntry struct {
+	key   []byte
+	value []byte
+	del   bool
+}
+
+// journalEntry is the value of a key before a flush, in hex.
+type journalEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Exists bool   `json:"exists"`
+}
+
+// NewBatchStorage returns the batch storage on the storage, not batching.
+func NewBatchStorage(storage Storage) *BatchStorage {
+	return &BatchStorage{
+		storage: storage,
+		entries: make(map[string]*batchEntry),
+	}
+}
+
+// Get return value to the key in Storage, the buffered one if batching.
+func (s *BatchStorage) Get(key []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if entry, ok := s.entries[byteutils.Hex(key)]; ok {
+		if entry.del {
+			return nil, ErrKeyNotFound
+		}
+		return entry.value, nil
+	}
+	return s.storage.Get(key)
+}
+
+// Put put the key-value entry to Storage, it's buffered if batching.
+func (s *BatchStorage) Put(key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.batching {
+		return s.storage.Put(key, value)
+	}
+	s.buffer(&batchEntry{key: key, value: value})
+	return nil
+}
+
+// Del delete the key in Storage, it's buffered if batching.
+func (s *BatchStorage) Del(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.batching {
+		return s.storage.Del(key)
+	}
+	s.buffer(&batchEntry{key: key, del: true})
+	return nil
+}
+
+func (s *BatchStorage) buffer(entry *batchEntry) {
+	k := byteutils.Hex(entry.key)
+	if _, ok := s.entries[k]; !ok {
+		s.keys = append(s.keys, k)
+	}
+	s.entries[k] = entry
+}
+
+// Stats returns the statistics of the storage under the batches.
+func (s *BatchStorage) Stats(prefixes [][]byte) (*Stats, error) {
+	return GetStats(s.storage, prefixes)
+}
+
+// Compact compacts the entries of the storage under the batches, the buffered entries are not
+// written by it.
+func (s *BatchStorage) Compact(r *Range) error {
+	return Compact(s.storage, r)
+}
+
+// EnableBatch buffers the puts and dels till Flush.
+func (s *BatchStorage) EnableBatch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batching = true
+}
+
+// Flush writes the buffered entries at once and stops batching.
+func (s *BatchStorage) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batching = false
+	if len(s.keys) == 0 {
+		return nil
+	}
+	entries := make([]*batchEntry, len(s.keys))
+	for i, k := range s.keys {
+		entries[i] = s.entries[k]
+	}
+	s.entries = make(map[string]*batchEntry)
+	s.keys = nil
+
+	if db, ok := s.storage.(Database); ok {
+		batch := db.NewBatch()
+		for _, entry := range entries {
+			if entry.del {
+				batch.Del(entry.key)
+			} else {
+				batch.Put(entry.key, entry.value)
+			}
+		}
+		return batch.Write()
+	}
+	return writeJournaled(s.storage, entries)
+}
+
+// writeJournaled writes the entries one by one, after the journal of their values before is written.
+func writeJournaled(storage Storage, entries []*batchEntry) error {
+	journal := make([]*journalEntry, len(entries))
+	for i, entry := range entries {
+		value, err := storage.Get(entry.key)
+		if err != nil && err != ErrKeyNotFound {
+			return err
+		}
+		journal[i] = &journalEntry{Key: byteutils.Hex(entry.key), Value: byteutils.Hex(value), Exists: err == nil}
+	}
+	data, err := json.Marshal(journal)
+	if err != nil {
+		return err
+	}
+	if err := storage.Put(JournalKey, data); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.del {
+			err = storage.Del(entry.key)
+		} else {
+			err = storage.Put(entry.key, entry.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return storage.Del(JournalKey)
+}
+
+// RecoverJournal rolls back the entries of a flush interrupted by a crash, it returns the count of
+// the entries rolled back, 0 if no flush was interrupted.
+func RecoverJournal(storage Storage) (int, error) {
+	data, err := storage.Get(JournalKey)
+	if err == ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var journal []*journalEntry
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return 0, err
+	}
+	for _, entry := range journal {
+		key, err := byteutils.FromHex(entry.Key)
+		if err != nil {
+			return 0, err
+		}
+		if !entry.Exists {
+			err = storage.Del(key)
+		} else {
+			var value []byte
+			if value, err = byteutils.FromHex(entry.Value); err == nil {
+				err = storage.Put(key, value)
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(journal), storage.Del(JournalKey)
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// plainStorage hides the batches of a memory storage, and fails the puts after the limit.
+type plainStorage struct {
+	db    *MemoryStorage
+	puts  int
+	limit int
+}
+
+func (s *plainStorage) Get(key []byte) ([]byte, error) { return s.db.Get(key) }
+func (s *plainStorage) Del(key []byte) error           { return s.db.Del(key) }
+func (s *plainStorage) Put(key []byte, value []byte) error {
+	if s.limit > 0 && s.puts >= s.limit {
+		return errors.New("crashed")
+	}
+	s.puts++
+	return s.db.Put(key, value)
+}
+
+func TestBatchStorage_Flush(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	s := NewBatchStorage(db)
+	assert.Nil(t, s.Put([]byte("a"), []byte("1")))
+	assert.Nil(t, s.Put([]byte("b"), []byte("2")))
+
+	s.EnableBatch()
+	assert.Nil(t, s.Put([]byte("a"), []byte("3")))
+	assert.Nil(t, s.Del([]byte("b")))
+	assert.Nil(t, s.Put([]byte("c"), []byte("4")))
+
+	// the buffered entries are read back, and not written till the flush.
+	value, err := s.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("3"), value)
+	_, err = s.Get([]byte("b"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	value, err = db.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+	_, err = db.Get([]byte("c"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	assert.Nil(t, s.Flush())
+	value, err = db.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("3"), value)
+	_, err = db.Get([]byte("b"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	value, err = db.Get([]byte("c"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("4"), value)
+
+	// not batching after the flush.
+	assert.Nil(t, s.Put([]byte("d"), []byte("5")))
+	_, err = db.Get([]byte("d"))
+	assert.Nil(t, err)
+}
+
+func TestBatchStorage_RecoverJournal(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	assert.Nil(t, db.Put([]byte("a"), []byte("1")))
+	assert.Nil(t, db.Put([]byte("b"), []byte("2")))
+
+	// crash after the journal and the first entry are written.
+	plain := &plainStorage{db: db, limit: 2}
+	s := NewBatchStorage(plain)
+	s.EnableBatch()
+	assert.Nil(t, s.Put([]byte("a"), []byte("3")))
+	assert.Nil(t, s.Put([]byte("c"), []byte("4")))
+	assert.Nil(t, s.Del([]byte("b")))
+	assert.NotNil(t, s.Flush())
+	value, _ := db.Get([]byte("a"))
+	assert.Equal(t, []byte("3"), value)
+
+	count, err := RecoverJournal(db)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	value, err = db.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+	value, err = db.Get([]byte("b"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("2"), value)
+	_, err = db.Get([]byte("c"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = db.Get(JournalKey)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	// nothing to recover after a complete flush.
+	plain.limit = 0
+	s.EnableBatch()
+	assert.Nil(t, s.Put([]byte("c"), []byte("4")))
+	assert.Nil(t, s.Flush())
+	count, err = RecoverJournal(db)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+	value, _ = db.Get([]byte("c"))
+	assert.Equal(t, []byte("4"), value)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"errors"
+)
+
+// ErrCompactionUnsupported is returned if the storage can't be compacted.
+var ErrCompactionUnsupported = errors.New("storage does not support compaction")
+
+// Range is the keys from Start to Limit, not including Limit. It's from the first key if Start
+// is nil, and to the last if Limit is nil.
+type Range struct {
+	Start []byte
+	Limit []byte
+}
+
+// PrefixRange returns the range of keys with the prefix.
+func PrefixRange(prefix []byte) *Range {
+	r := &Range{Start: prefix}
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			r.Limit = append(append([]byte{}, prefix[:i]...), prefix[i]+1)
+			break
+		}
+	}
+	return r
+}
+
+// LevelStats is the statistics of a level of a Database.
+type LevelStats struct {
+	Level  int
+	Tables int
+	Size   int64
+}
+
+// Stats is the statistics of a Database, the sizes are approximate in bytes.
+type Stats struct {
+	// Size is the size of all the entries.
+	Size int64
+
+	// PrefixSizes is the size of the entries of each prefix asked.
+	PrefixSizes map[string]int64
+
+	// Levels is the levels of the Database, none if it isn't leveled.
+	Levels []*LevelStats
+
+	// PendingCompactions is the count of the levels waiting for compaction.
+	PendingCompactions int
+}
+
+// Compactor is a Database reclaiming the space of the deleted entries on demand.
+type Compactor interface {
+	// Stats returns the statistics with the sizes of the prefixes.
+	Stats(prefixes [][]byte) (*Stats, error)
+
+	// Compact compacts the entries in the range, all if nil.
+	Compact(r *Range) error
+}
+
+// GetStats returns the statistics of the storage with the sizes of the prefixes.
+func GetStats(storage Storage, prefixes [][]byte) (*Stats, error) {
+	c, ok := storage.(Compactor)
+	if !ok {
+		return nil, ErrCompactionUnsupported
+	}
+	return c.Stats(prefixes)
+}
+
+// Compact compacts the entries of the storage in the range, all if nil.
+func Compact(storage Storage, r *Range) error {
+	c, ok := storage.(Compactor)
+	if !ok {
+		return ErrCompactionUnsupported
+	}
+	return c.Compact(r)
+}
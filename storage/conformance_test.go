@@ -0,0 +1,174 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDrivers_Conformance runs the suite every driver must pass, the drivers of the build tags
+// are covered in the builds with them.
+func TestDrivers_Conformance(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, db Database)
+	}{
+		{"key not found", testKeyNotFound},
+		{"put get del", testPutGetDel},
+		{"batch atomicity", testBatchAtomicity},
+		{"iterator", testIterator},
+		{"compaction", testCompaction},
+	}
+	for _, driver := range Drivers() {
+		for _, tt := range tests {
+			t.Run(driver+"/"+tt.name, func(t *testing.T) {
+				dir, err := ioutil.TempDir("", "storage")
+				assert.Nil(t, err)
+				defer os.RemoveAll(dir)
+				db, err := Open(driver, dir)
+				assert.Nil(t, err)
+				defer db.Close()
+				tt.fn(t, db)
+			})
+		}
+	}
+}
+
+func TestOpen_UnknownDriver(t *testing.T) {
+	_, err := Open("unknown", "")
+	assert.Equal(t, ErrUnknownDriver, err)
+	assert.Contains(t, Drivers(), DefaultDriver)
+	assert.Contains(t, Drivers(), MemoryDriver)
+}
+
+func testKeyNotFound(t *testing.T, db Database) {
+	_, err := db.Get([]byte("missing"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Nil(t, db.Del([]byte("missing")))
+}
+
+func testPutGetDel(t *testing.T, db Database) {
+	key := []byte("key")
+	assert.Nil(t, db.Put(key, []byte("1")))
+	assert.Nil(t, db.Put(key, []byte("2")))
+	value, err := db.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("2"), value)
+	assert.Nil(t, db.Del(key))
+	_, err = db.Get(key)
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func testBatchAtomicity(t *testing.T, db Database) {
+	assert.Nil(t, db.Put([]byte("b"), []byte("old")))
+
+	batch := db.NewBatch()
+	batch.Put([]byte("a"), []byte("1"))
+	batch.Del([]byte("b"))
+	batch.Put([]byte("c"), []byte("3"))
+	batch.Put([]byte("c"), []byte("4"))
+
+	// nothing is written before the batch.
+	_, err := db.Get([]byte("a"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	value, err := db.Get([]byte("b"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("old"), value)
+
+	assert.Nil(t, batch.Write())
+	value, err = db.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+	_, err = db.Get([]byte("b"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	value, err = db.Get([]byte("c"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("4"), value)
+
+	// a batch dropped without writing changes nothing.
+	batch = db.NewBatch()
+	batch.Del([]byte("a"))
+	value, err = db.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func testIterator(t *testing.T, db Database) {
+	entries := map[string]string{"p/2": "2", "p/1": "1", "p/3": "3", "q/1": "x", "o": "y"}
+	for k, v := range entries {
+		assert.Nil(t, db.Put([]byte(k), []byte(v)))
+	}
+
+	collect := func(prefix []byte) (keys []string, values []string) {
+		it := db.NewIterator(prefix)
+		defer it.Release()
+		for it.Next() {
+			keys = append(keys, string(it.Key()))
+			values = append(values, string(it.Value()))
+		}
+		assert.Nil(t, it.Error())
+		assert.False(t, it.Next())
+		return keys, values
+	}
+
+	// the entries of the prefix in ascending order of keys.
+	keys, values := collect([]byte("p/"))
+	assert.Equal(t, []string{"p/1", "p/2", "p/3"}, keys)
+	assert.Equal(t, []string{"1", "2", "3"}, values)
+
+	keys, _ = collect(nil)
+	assert.Equal(t, []string{"o", "p/1", "p/2", "p/3", "q/1"}, keys)
+
+	keys, _ = collect([]byte("r"))
+	assert.Equal(t, 0, len(keys))
+}
+
+func testCompaction(t *testing.T, db Database) {
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, db.Put(append([]byte("p/"), byte(i)), make([]byte, 100)))
+		assert.Nil(t, db.Put(append([]byte("q/"), byte(i)), make([]byte, 10)))
+	}
+	for i := 0; i < 50; i++ {
+		assert.Nil(t, db.Del(append([]byte("p/"), byte(i))))
+	}
+	assert.Nil(t, Compact(db, PrefixRange([]byte("p/"))))
+	assert.Nil(t, Compact(db, nil))
+
+	stats, err := GetStats(db, [][]byte{[]byte("p/"), []byte("r/")})
+	assert.Nil(t, err)
+	assert.True(t, stats.Size > 0)
+	assert.True(t, stats.PrefixSizes["p/"] >= 0)
+	assert.Equal(t, int64(0), stats.PrefixSizes["r/"])
+
+	// the entries are kept by compaction.
+	value, err := db.Get([]byte("p/\x63"))
+	assert.Nil(t, err)
+	assert.Equal(t, 100, len(value))
+}
+
+func TestPrefixRange(t *testing.T) {
+	assert.Equal(t, &Range{Start: []byte("ab"), Limit: []byte("ac")}, PrefixRange([]byte("ab")))
+	assert.Equal(t, &Range{Start: []byte{1, 0xff}, Limit: []byte{2}}, PrefixRange([]byte{1, 0xff}))
+	assert.Equal(t, &Range{Start: []byte{0xff}}, PrefixRange([]byte{0xff}))
+	assert.Equal(t, &Range{}, PrefixRange(nil))
+}
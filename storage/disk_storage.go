@@ -19,9 +19,12 @@
 package storage
 
 import (
+	"math"
+
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // DiskStorage the nodes in trie.
@@ -65,7 +68,75 @@ func (storage *DiskStorage) Del(key []byte) error {
 	return storage.db.Delete(key, nil)
 }
 
+// NewBatch returns a batch written in a leveldb batch.
+func (storage *DiskStorage) NewBatch() Batch {
+	return &diskBatch{db: storage.db, batch: new(leveldb.Batch)}
+}
+
+// NewIterator returns an iterator of the entries with keys of the prefix.
+func (storage *DiskStorage) NewIterator(prefix []byte) Iterator {
+	return storage.db.NewIterator(util.BytesPrefix(prefix), nil)
+}
+
 // Close levelDB
 func (storage *DiskStorage) Close() error {
 	return storage.db.Close()
 }
+
+// Stats returns the statistics of the levels of leveldb, a level is pending for compaction
+// if it exceeds the default limits of leveldb.
+func (storage *DiskStorage) Stats(prefixes [][]byte) (*Stats, error) {
+	dbStats := new(leveldb.DBStats)
+	if err := storage.db.Stats(dbStats); err != nil {
+		return nil, err
+	}
+	stats := &Stats{PrefixSizes: make(map[string]int64)}
+	for level, size := range dbStats.LevelSizes {
+		tables := dbStats.LevelTablesCounts[level]
+		stats.Size += size
+		stats.Levels = append(stats.Levels, &LevelStats{Level: level, Tables: tables, Size: size})
+		if level == 0 && tables >= opt.DefaultCompactionL0Trigger ||
+			level > 0 && float64(size) > float64(opt.DefaultCompactionTotalSize)*math.Pow(opt.DefaultCompactionTotalSizeMultiplier, float64(level-1)) {
+			stats.PendingCompactions++
+		}
+	}
+	if len(prefixes) > 0 {
+		ranges := make([]util.Range, len(prefixes))
+		for i, prefix := range prefixes {
+			ranges[i] = *util.BytesPrefix(prefix)
+		}
+		sizes, err := storage.db.SizeOf(ranges)
+		if err != nil {
+			return nil, err
+		}
+		for i, prefix := range prefixes {
+			stats.PrefixSizes[string(prefix)] = sizes[i]
+		}
+	}
+	return stats, nil
+}
+
+// Compact compacts the tables of leveldb in the range, all if nil.
+func (storage *DiskStorage) Compact(r *Range) error {
+	if r == nil {
+		r = new(Range)
+	}
+	return storage.db.CompactRange(util.Range{Start: r.Start, Limit: r.Limit})
+}
+
+type diskBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+func (b *diskBatch) Put(key []byte, value []byte) {
+	b.batch.Put(key, value)
+}
+
+func (b *diskBatch) Del(key []byte) {
+	b.batch.Delete(key)
+}
+
+func (b *diskBatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
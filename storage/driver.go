@@ -0,0 +1,93 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"sort"
+	"sync"
+)
+
+// Storage drivers
+const (
+	// LevelDBDriver is the pure-go leveldb on disk, the default driver.
+	LevelDBDriver = "leveldb"
+
+	// MemoryDriver keeps the entries in memory, the path is ignored.
+	MemoryDriver = "memory"
+
+	// DefaultDriver is the driver used if not configured.
+	DefaultDriver = LevelDBDriver
+)
+
+// Driver opens the Database at the path.
+type Driver func(path string) (Database, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]Driver)
+)
+
+func init() {
+	Register(LevelDBDriver, func(path string) (Database, error) {
+		return NewDiskStorage(path)
+	})
+	Register(MemoryDriver, func(path string) (Database, error) {
+		return NewMemoryStorage()
+	})
+}
+
+// Register makes the driver available by the name, it panics if the name is registered already.
+// The drivers depending on native libraries register themselves in the builds with their tags.
+func Register(name string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if driver == nil {
+		panic("storage: register a nil driver")
+	}
+	if _, ok := drivers[name]; ok {
+		panic("storage: register driver twice " + name)
+	}
+	drivers[name] = driver
+}
+
+// Drivers returns the names of the registered drivers in order.
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open opens the Database at the path by the driver, DefaultDriver if the name is empty.
+func Open(name string, path string) (Database, error) {
+	if len(name) == 0 {
+		name = DefaultDriver
+	}
+	driversMu.RLock()
+	driver, ok := drivers[name]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownDriver
+	}
+	return driver(path)
+}
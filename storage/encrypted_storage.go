@@ -0,0 +1,306 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"golang.org/x/crypto/scrypt"
+)
+
+// parameters of the key derived from passphrase by scrypt.
+const (
+	EncryptionKeyLength = 32
+
+	encryptionScryptN = 1 << 15
+	encryptionScryptR = 8
+	encryptionScryptP = 1
+	encryptionSaltLen = 32
+)
+
+// EncryptionMetaKey is the key of the salt and the check of the encryption key in storage, in plaintext.
+var EncryptionMetaKey = []byte("storage_encryption")
+
+// encryptionCheck is sealed in the meta to verify the encryption key.
+var encryptionCheck = []byte("nebulas storage encryption")
+
+// Errors of storage encryption
+var (
+	ErrEncryptionKeyMismatch = errors.New("storage is encrypted by another key")
+	ErrStorageNotEncrypted   = errors.New("storage has data not encrypted, import it into a new datadir")
+	ErrStorageEncrypted      = errors.New("storage is encrypted, the passphrase or kms is required")
+	ErrInvalidEncryptionKey  = errors.New("invalid storage encryption key")
+	ErrDecryptValue          = errors.New("failed to decrypt the value in storage")
+)
+
+// KeySource supplies the key of storage encryption.
+type KeySource interface {
+	// Key returns the key of storage encryption, with the salt generated at the first open.
+	Key(salt []byte) ([]byte, error)
+}
+
+// PassphraseKey derives the key from the passphrase supplied by operator, by scrypt with the salt.
+type PassphraseKey string
+
+// Key returns the key derived from the passphrase.
+func (p PassphraseKey) Key(salt []byte) ([]byte, error) {
+	if len(p) == 0 {
+		return nil, ErrInvalidEncryptionKey
+	}
+	return scrypt.Key([]byte(p), salt, encryptionScryptN, encryptionScryptR, encryptionScryptP, EncryptionKeyLength)
+}
+
+// KMSKey runs the command of a KMS client, which prints the key in hex to stdout.
+// The salt is ignored, the KMS keeps the key.
+type KMSKey string
+
+// Key returns the key printed by the command.
+func (k KMSKey) Key(salt []byte) ([]byte, error) {
+	args := strings.Fields(string(k))
+	if len(args) == 0 {
+		return nil, ErrInvalidEncryptionKey
+	}
+	out, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return nil, err
+	}
+	key, err := byteutils.FromHex(strings.TrimSpace(string(out)))
+	if err != nil || len(key) != EncryptionKeyLength {
+		return nil, ErrInvalidEncryptionKey
+	}
+	return key, nil
+}
+
+// EncryptedStorage encrypts the values of a Database by AES-256-GCM, the keys are kept in plaintext
+// to be looked up and iterated. A value is bound to its key, it can't be moved to another key.
+type EncryptedStorage struct {
+	db   Database
+	aead cipher.AEAD
+}
+
+// NewEncryptedStorage returns the encrypted storage on the Database by the key from the source.
+// The salt and the check of the key are put at the first open, the Database must be empty then.
+func NewEncryptedStorage(db Database, source KeySource) (*EncryptedStorage, error) {
+	meta, err := db.Get(EncryptionMetaKey)
+	if err != nil && err != ErrKeyNotFound {
+		return nil, err
+	}
+	fresh := err == ErrKeyNotFound
+	if fresh {
+		it := db.NewIterator(nil)
+		exist := it.Next()
+		it.Release()
+		if exist {
+			return nil, ErrStorageNotEncrypted
+		}
+		meta = make([]byte, encryptionSaltLen)
+		if _, err := io.ReadFull(rand.Reader, meta); err != nil {
+			return nil, err
+		}
+	}
+	if len(meta) < encryptionSaltLen {
+		return nil, ErrEncryptionKeyMismatch
+	}
+
+	key, err := source.Key(meta[:encryptionSaltLen])
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrInvalidEncryptionKey
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	s := &EncryptedStorage{db: db, aead: aead}
+
+	if fresh {
+		check, err := s.seal(EncryptionMetaKey, encryptionCheck)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Put(EncryptionMetaKey, append(meta, check...)); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	check, err := s.open(EncryptionMetaKey, meta[encryptionSaltLen:])
+	if err != nil || !bytes.Equal(check, encryptionCheck) {
+		return nil, ErrEncryptionKeyMismatch
+	}
+	return s, nil
+}
+
+// CheckNotEncrypted returns ErrStorageEncrypted if the storage was encrypted, it's opened
+// without encryption.
+func CheckNotEncrypted(storage Storage) error {
+	_, err := storage.Get(EncryptionMetaKey)
+	if err == nil {
+		return ErrStorageEncrypted
+	}
+	if err != ErrKeyNotFound {
+		return err
+	}
+	return nil
+}
+
+// seal returns the nonce followed by the value encrypted with the key as additional data.
+func (s *EncryptedStorage) seal(key []byte, value []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, value, key), nil
+}
+
+func (s *EncryptedStorage) open(key []byte, data []byte) ([]byte, error) {
+	size := s.aead.NonceSize()
+	if len(data) < size {
+		return nil, ErrDecryptValue
+	}
+	value, err := s.aead.Open(nil, data[:size], data[size:], key)
+	if err != nil {
+		return nil, ErrDecryptValue
+	}
+	return value, nil
+}
+
+// Get return the decrypted value to the key in Storage.
+func (s *EncryptedStorage) Get(key []byte) ([]byte, error) {
+	data, err := s.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(key, data)
+}
+
+// Put put the key and the encrypted value to Storage.
+func (s *EncryptedStorage) Put(key []byte, value []byte) error {
+	data, err := s.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(key, data)
+}
+
+// Del delete the key in Storage.
+func (s *EncryptedStorage) Del(key []byte) error {
+	return s.db.Del(key)
+}
+
+// NewBatch returns a batch encrypting the values put.
+func (s *EncryptedStorage) NewBatch() Batch {
+	return &encryptedBatch{s: s, batch: s.db.NewBatch()}
+}
+
+// NewIterator returns an iterator decrypting the values, the meta of encryption is skipped.
+func (s *EncryptedStorage) NewIterator(prefix []byte) Iterator {
+	return &encryptedIterator{s: s, it: s.db.NewIterator(prefix)}
+}
+
+// Close closes the Database.
+func (s *EncryptedStorage) Close() error {
+	return s.db.Close()
+}
+
+// Stats returns the statistics of the Database, in the sizes of the encrypted values.
+func (s *EncryptedStorage) Stats(prefixes [][]byte) (*Stats, error) {
+	return GetStats(s.db, prefixes)
+}
+
+// Compact compacts the entries of the Database in the range, all if nil.
+func (s *EncryptedStorage) Compact(r *Range) error {
+	return Compact(s.db, r)
+}
+
+type encryptedBatch struct {
+	s     *EncryptedStorage
+	batch Batch
+	err   error
+}
+
+func (b *encryptedBatch) Put(key []byte, value []byte) {
+	data, err := b.s.seal(key, value)
+	if err != nil {
+		b.err = err
+		return
+	}
+	b.batch.Put(key, data)
+}
+
+func (b *encryptedBatch) Del(key []byte) {
+	b.batch.Del(key)
+}
+
+func (b *encryptedBatch) Write() error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.batch.Write()
+}
+
+type encryptedIterator struct {
+	s     *EncryptedStorage
+	it    Iterator
+	value []byte
+	err   error
+}
+
+func (it *encryptedIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.it.Next() {
+		if bytes.Equal(it.it.Key(), EncryptionMetaKey) {
+			continue
+		}
+		it.value, it.err = it.s.open(it.it.Key(), it.it.Value())
+		return it.err == nil
+	}
+	return false
+}
+
+func (it *encryptedIterator) Key() []byte {
+	return it.it.Key()
+}
+
+func (it *encryptedIterator) Value() []byte {
+	return it.value
+}
+
+func (it *encryptedIterator) Release() {
+	it.it.Release()
+}
+
+func (it *encryptedIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Error()
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rawKey is a fixed key for the tests, without the cost of scrypt.
+type rawKey byte
+
+func (k rawKey) Key(salt []byte) ([]byte, error) {
+	return bytes.Repeat([]byte{byte(k)}, EncryptionKeyLength), nil
+}
+
+func TestEncryptedStorage_Conformance(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, db Database)
+	}{
+		{"key not found", testKeyNotFound},
+		{"put get del", testPutGetDel},
+		{"batch atomicity", testBatchAtomicity},
+		{"iterator", testIterator},
+		{"compaction", testCompaction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := NewMemoryStorage()
+			s, err := NewEncryptedStorage(db, rawKey(1))
+			assert.Nil(t, err)
+			tt.fn(t, s)
+		})
+	}
+}
+
+func TestEncryptedStorage_Passphrase(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	s, err := NewEncryptedStorage(db, PassphraseKey("passphrase"))
+	assert.Nil(t, err)
+	assert.Nil(t, s.Put([]byte("key"), []byte("secret value")))
+
+	// the values are not kept in plaintext.
+	data, err := db.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(data, []byte("secret value")))
+	assert.Equal(t, ErrStorageEncrypted, CheckNotEncrypted(db))
+
+	// reopened by the passphrase.
+	s, err = NewEncryptedStorage(db, PassphraseKey("passphrase"))
+	assert.Nil(t, err)
+	value, err := s.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("secret value"), value)
+
+	_, err = NewEncryptedStorage(db, PassphraseKey("wrong"))
+	assert.Equal(t, ErrEncryptionKeyMismatch, err)
+	_, err = NewEncryptedStorage(db, PassphraseKey(""))
+	assert.Equal(t, ErrInvalidEncryptionKey, err)
+}
+
+func TestEncryptedStorage_Tampered(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	s, err := NewEncryptedStorage(db, rawKey(1))
+	assert.Nil(t, err)
+	assert.Nil(t, s.Put([]byte("a"), []byte("1")))
+	assert.Nil(t, s.Put([]byte("b"), []byte("2")))
+
+	// a value moved to another key is rejected.
+	data, _ := db.Get([]byte("a"))
+	assert.Nil(t, db.Put([]byte("b"), data))
+	_, err = s.Get([]byte("b"))
+	assert.Equal(t, ErrDecryptValue, err)
+
+	it := s.NewIterator(nil)
+	defer it.Release()
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.Equal(t, ErrDecryptValue, it.Error())
+}
+
+func TestEncryptedStorage_PlaintextData(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	assert.Nil(t, CheckNotEncrypted(db))
+	assert.Nil(t, db.Put([]byte("key"), []byte("value")))
+	_, err := NewEncryptedStorage(db, rawKey(1))
+	assert.Equal(t, ErrStorageNotEncrypted, err)
+}
+
+func TestKMSKey(t *testing.T) {
+	key, err := KMSKey("echo " + string(bytes.Repeat([]byte("ab"), EncryptionKeyLength))).Key(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0xab}, EncryptionKeyLength), key)
+
+	_, err = KMSKey("echo abcd").Key(nil)
+	assert.Equal(t, ErrInvalidEncryptionKey, err)
+	_, err = KMSKey("").Key(nil)
+	assert.Equal(t, ErrInvalidEncryptionKey, err)
+}
@@ -19,6 +19,8 @@
 package storage
 
 import (
+	"bytes"
+	"sort"
 	"sync"
 
 	"github.com/nebulasio/go-nebulas/util/byteutils"
@@ -27,6 +29,9 @@ import (
 // MemoryStorage the nodes in trie.
 type MemoryStorage struct {
 	data *sync.Map
+
+	// batches are written holding mu, the others hold it shared.
+	mu sync.RWMutex
 }
 
 // NewMemoryStorage init a storage
@@ -38,6 +43,8 @@ func NewMemoryStorage() (*MemoryStorage, error) {
 
 // Get return value to the key in Storage
 func (db *MemoryStorage) Get(key []byte) ([]byte, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 	if entry, ok := db.data.Load(byteutils.Hex(key)); ok {
 		return entry.([]byte), nil
 	}
@@ -46,12 +53,147 @@ func (db *MemoryStorage) Get(key []byte) ([]byte, error) {
 
 // Put put the key-value entry to Storage
 func (db *MemoryStorage) Put(key []byte, value []byte) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 	db.data.Store(byteutils.Hex(key), value)
 	return nil
 }
 
 // Del delete the key in Storage.
 func (db *MemoryStorage) Del(key []byte) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 	db.data.Delete(byteutils.Hex(key))
 	return nil
 }
+
+// NewBatch returns a batch written holding the storage.
+func (db *MemoryStorage) NewBatch() Batch {
+	return &memoryBatch{db: db}
+}
+
+// NewIterator returns an iterator of a snapshot of the entries with keys of the prefix.
+func (db *MemoryStorage) NewIterator(prefix []byte) Iterator {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	it := &memoryIterator{index: -1}
+	db.data.Range(func(k, v interface{}) bool {
+		key, err := byteutils.FromHex(k.(string))
+		if err == nil && bytes.HasPrefix(key, prefix) {
+			it.keys = append(it.keys, key)
+			it.values = append(it.values, v.([]byte))
+		}
+		return true
+	})
+	sort.Sort(it)
+	return it
+}
+
+// Close does nothing, the entries are dropped with the storage.
+func (db *MemoryStorage) Close() error {
+	return nil
+}
+
+// Stats returns the size of the keys and values in memory, it isn't leveled.
+func (db *MemoryStorage) Stats(prefixes [][]byte) (*Stats, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	stats := &Stats{PrefixSizes: make(map[string]int64)}
+	for _, prefix := range prefixes {
+		stats.PrefixSizes[string(prefix)] = 0
+	}
+	db.data.Range(func(k, v interface{}) bool {
+		key, err := byteutils.FromHex(k.(string))
+		if err != nil {
+			return true
+		}
+		size := int64(len(key) + len(v.([]byte)))
+		stats.Size += size
+		for _, prefix := range prefixes {
+			if bytes.HasPrefix(key, prefix) {
+				stats.PrefixSizes[string(prefix)] += size
+			}
+		}
+		return true
+	})
+	return stats, nil
+}
+
+// Compact does nothing, the entries deleted are released at once.
+func (db *MemoryStorage) Compact(r *Range) error {
+	return nil
+}
+
+type memoryBatch struct {
+	db  *MemoryStorage
+	ops []*memoryBatchOp
+}
+
+type memoryBatchOp struct {
+	key   []byte
+	value []byte
+	del   bool
+}
+
+func (b *memoryBatch) Put(key []byte, value []byte) {
+	b.ops = append(b.ops, &memoryBatchOp{key: key, value: value})
+}
+
+func (b *memoryBatch) Del(key []byte) {
+	b.ops = append(b.ops, &memoryBatchOp{key: key, del: true})
+}
+
+func (b *memoryBatch) Write() error {
+	b.db.mu.Lock()
+	defer b.db.mu.Unlock()
+	for _, op := range b.ops {
+		if op.del {
+			b.db.data.Delete(byteutils.Hex(op.key))
+		} else {
+			b.db.data.Store(byteutils.Hex(op.key), op.value)
+		}
+	}
+	return nil
+}
+
+type memoryIterator struct {
+	keys   [][]byte
+	values [][]byte
+	index  int
+}
+
+func (it *memoryIterator) Len() int           { return len(it.keys) }
+func (it *memoryIterator) Less(i, j int) bool { return bytes.Compare(it.keys[i], it.keys[j]) < 0 }
+func (it *memoryIterator) Swap(i, j int) {
+	it.keys[i], it.keys[j] = it.keys[j], it.keys[i]
+	it.values[i], it.values[j] = it.values[j], it.values[i]
+}
+
+func (it *memoryIterator) Next() bool {
+	if it.index < len(it.keys) {
+		it.index++
+	}
+	return it.index < len(it.keys)
+}
+
+func (it *memoryIterator) Key() []byte {
+	if it.index < 0 || it.index >= len(it.keys) {
+		return nil
+	}
+	return it.keys[it.index]
+}
+
+func (it *memoryIterator) Value() []byte {
+	if it.index < 0 || it.index >= len(it.keys) {
+		return nil
+	}
+	return it.values[it.index]
+}
+
+func (it *memoryIterator) Release() {
+	it.keys, it.values = nil, nil
+}
+
+func (it *memoryIterator) Error() error {
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// Namespaces of the keys of the subsystems, the keys of a namespace are put with its prefix.
+// The keys stored before the namespaces stay in the flat keyspace.
+const (
+	NamespaceBlocks  = "blocks"
+	NamespaceHeaders = "headers"
+	NamespaceState   = "state"
+	NamespaceTxIndex = "txindex"
+	NamespaceEvents  = "events"
+	NamespaceP2P     = "p2p"
+)
+
+// Namespace dump format: the header of magic, version, the length of name followed by the name,
+// then the entries in ascending order of keys, each is the length of key, the key without the prefix,
+// the length of value and the value. The dump ends with a zero length of key.
+const (
+	NamespaceDumpMagic   = "NEBSPACE"
+	NamespaceDumpVersion = uint32(1)
+
+	// MaxNamespaceDumpEntrySize is the max size of a key or value in dump.
+	MaxNamespaceDumpEntrySize = 32 * 1024 * 1024
+)
+
+// Errors of namespace
+var (
+	ErrUnknownNamespace      = errors.New("unknown storage namespace")
+	ErrInvalidNamespaceDump  = errors.New("invalid namespace dump")
+	ErrNamespaceDumpMismatch = errors.New("namespace dump is of another namespace")
+	ErrNamespaceNotIterable  = errors.New("storage of namespace is not iterable")
+	ErrNamespaceEmptyKey     = errors.New("empty key in namespace")
+)
+
+// Namespaces returns the names of the namespaces.
+func Namespaces() []string {
+	return []string{NamespaceBlocks, NamespaceHeaders, NamespaceState, NamespaceTxIndex, NamespaceEvents, NamespaceP2P}
+}
+
+func checkNamespace(name string) error {
+	for _, ns := range Namespaces() {
+		if ns == name {
+			return nil
+		}
+	}
+	return ErrUnknownNamespace
+}
+
+// NamespacePrefix returns the prefix of the keys of the namespace.
+func NamespacePrefix(name string) []byte {
+	return []byte(name + "/")
+}
+
+// NamespaceStorage is the keys of a namespace in a Storage, with the metrics of the namespace.
+type NamespaceStorage struct {
+	storage Storage
+	prefix  []byte
+
+	reads   metrics.Meter
+	writes  metrics.Meter
+	deletes metrics.Meter
+	bytes   metrics.Meter
+}
+
+// NewNamespaceStorage returns the storage of the namespace in the storage, it panics if the
+// namespace is unknown.
+func NewNamespaceStorage(storage Storage, name string) *NamespaceStorage {
+	if err := checkNamespace(name); err != nil {
+		panic("storage: unknown namespace " + name)
+	}
+	return &NamespaceStorage{
+		storage: storage,
+		prefix:  NamespacePrefix(name),
+		reads:   metrics.GetOrRegisterMeter("neb.storage."+name+".read", nil),
+		writes:  metrics.GetOrRegisterMeter("neb.storage."+name+".write", nil),
+		deletes: metrics.GetOrRegisterMeter("neb.storage."+name+".delete", nil),
+		bytes:   metrics.GetOrRegisterMeter("neb.storage."+name+".bytes", nil),
+	}
+}
+
+func (ns *NamespaceStorage) key(key []byte) []byte {
+	return append(append([]byte{}, ns.prefix...), key...)
+}
+
+// Get return value to the key in the namespace.
+func (ns *NamespaceStorage) Get(key []byte) ([]byte, error) {
+	ns.reads.Mark(1)
+	return ns.storage.Get(ns.key(key))
+}
+
+// Put put the key-value entry to the namespace.
+func (ns *NamespaceStorage) Put(key []byte, value []byte) error {
+	ns.writes.Mark(1)
+	ns.bytes.Mark(int64(len(key) + len(value)))
+	return ns.storage.Put(ns.key(key), value)
+}
+
+// Del delete the key in the namespace.
+func (ns *NamespaceStorage) Del(key []byte) error {
+	ns.deletes.Mark(1)
+	return ns.storage.Del(ns.key(key))
+}
+
+// NamespaceSizes returns the approximate sizes of the namespaces in the storage.
+func NamespaceSizes(storage Storage) (map[string]int64, error) {
+	var prefixes [][]byte
+	for _, name := range Namespaces() {
+		prefixes = append(prefixes, NamespacePrefix(name))
+	}
+	stats, err := GetStats(storage, prefixes)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64)
+	for _, name := range Namespaces() {
+		sizes[name] = stats.PrefixSizes[string(NamespacePrefix(name))]
+	}
+	return sizes, nil
+}
+
+// PruneNamespace deletes all the keys of the namespace in the Database, it returns the count of
+// the keys deleted. The keys are deleted in batches of limited size.
+func PruneNamespace(db Database, name string) (int, error) {
+	if err := checkNamespace(name); err != nil {
+		return 0, err
+	}
+	const batchSize = 1024
+	deleted := 0
+	for {
+		var keys [][]byte
+		it := db.NewIterator(NamespacePrefix(name))
+		for len(keys) < batchSize && it.Next() {
+			keys = append(keys, append([]byte{}, it.Key()...))
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return deleted, err
+		}
+		if len(keys) == 0 {
+			return deleted, nil
+		}
+		batch := db.NewBatch()
+		for _, key := range keys {
+			batch.Del(key)
+		}
+		if err := batch.Write(); err != nil {
+			return deleted, err
+		}
+		deleted += len(keys)
+	}
+}
+
+// ExportNamespace writes the entries of the namespace in the Database into w, it returns the count
+// of the entries written.
+func ExportNamespace(db Database, name string, w io.Writer) (int, error) {
+	if err := checkNamespace(name); err != nil {
+		return 0, err
+	}
+	header := []byte(NamespaceDumpMagic)
+	header = append(header, byteutils.FromUint32(NamespaceDumpVersion)...)
+	header = append(header, byteutils.FromUint32(uint32(len(name)))...)
+	header = append(header, name...)
+	if _, err := w.Write(header); err != nil {
+		return 0, err
+	}
+
+	prefix := NamespacePrefix(name)
+	it := db.NewIterator(prefix)
+	defer it.Release()
+	count := 0
+	for it.Next() {
+		key := it.Key()[len(prefix):]
+		if len(key) == 0 {
+			return count, ErrNamespaceEmptyKey
+		}
+		for _, data := range [][]byte{key, it.Value()} {
+			if _, err := w.Write(byteutils.FromUint32(uint32(len(data)))); err != nil {
+				return count, err
+			}
+			if _, err := w.Write(data); err != nil {
+				return count, err
+			}
+		}
+		count++
+	}
+	if err := it.Error(); err != nil {
+		return count, err
+	}
+	_, err := w.Write(byteutils.FromUint32(0))
+	return count, err
+}
+
+// ImportNamespace puts the entries of the namespace dumped by ExportNamespace from r into storage,
+// it returns the count of the entries put.
+func ImportNamespace(storage Storage, name string, r io.Reader) (int, error) {
+	header := make([]byte, len(NamespaceDumpMagic)+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, ErrInvalidNamespaceDump
+	}
+	if !bytes.Equal(header[:len(NamespaceDumpMagic)], []byte(NamespaceDumpMagic)) ||
+		byteutils.Uint32(header[len(NamespaceDumpMagic):len(NamespaceDumpMagic)+4]) != NamespaceDumpVersion {
+		return 0, ErrInvalidNamespaceDump
+	}
+	dumped, err := readNamespaceDumpData(r, byteutils.Uint32(header[len(NamespaceDumpMagic)+4:]))
+	if err != nil {
+		return 0, err
+	}
+	if string(dumped) != name {
+		return 0, ErrNamespaceDumpMismatch
+	}
+
+	ns := NewNamespaceStorage(storage, name)
+	count := 0
+	for {
+		key, err := readNamespaceDumpEntry(r)
+		if err != nil {
+			return count, err
+		}
+		if len(key) == 0 {
+			return count, nil
+		}
+		value, err := readNamespaceDumpEntry(r)
+		if err != nil {
+			return count, err
+		}
+		if err := ns.Put(key, value); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+func readNamespaceDumpEntry(r io.Reader) ([]byte, error) {
+	size := make([]byte, 4)
+	if _, err := io.ReadFull(r, size); err != nil {
+		return nil, ErrInvalidNamespaceDump
+	}
+	return readNamespaceDumpData(r, byteutils.Uint32(size))
+}
+
+func readNamespaceDumpData(r io.Reader, n uint32) ([]byte, error) {
+	if n > MaxNamespaceDumpEntrySize {
+		return nil, ErrInvalidNamespaceDump
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, ErrInvalidNamespaceDump
+	}
+	return data, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceStorage(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	blocks := NewNamespaceStorage(db, NamespaceBlocks)
+	index := NewNamespaceStorage(db, NamespaceTxIndex)
+	writes := blocks.writes.Count()
+	assert.Nil(t, blocks.Put([]byte("key"), []byte("block")))
+	assert.Nil(t, index.Put([]byte("key"), []byte("index")))
+
+	// the same key in namespaces are apart.
+	value, err := blocks.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("block"), value)
+	value, err = db.Get([]byte("txindex/key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("index"), value)
+	_, err = db.Get([]byte("key"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	assert.Nil(t, index.Del([]byte("key")))
+	_, err = index.Get([]byte("key"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Equal(t, writes+1, blocks.writes.Count())
+
+	assert.Panics(t, func() { NewNamespaceStorage(db, "unknown") })
+}
+
+func TestNamespace_PruneAndExport(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	headers := NewNamespaceStorage(db, NamespaceHeaders)
+	for _, k := range []string{"b", "a", "c"} {
+		assert.Nil(t, headers.Put([]byte(k), []byte("header "+k)))
+	}
+	assert.Nil(t, db.Put([]byte("flat"), []byte("value")))
+	assert.Nil(t, NewNamespaceStorage(db, NamespaceState).Put([]byte("a"), []byte("state")))
+
+	sizes, err := NamespaceSizes(db)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3*len("headers/a")+3*len("header a")), sizes[NamespaceHeaders])
+	assert.Equal(t, int64(0), sizes[NamespaceEvents])
+
+	buf := new(bytes.Buffer)
+	count, err := ExportNamespace(db, NamespaceHeaders, buf)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	dump := buf.Bytes()
+
+	count, err = PruneNamespace(db, NamespaceHeaders)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	_, err = headers.Get([]byte("a"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = db.Get([]byte("flat"))
+	assert.Nil(t, err)
+	_, err = db.Get([]byte("state/a"))
+	assert.Nil(t, err)
+
+	_, err = ImportNamespace(db, NamespaceState, bytes.NewReader(dump))
+	assert.Equal(t, ErrNamespaceDumpMismatch, err)
+	_, err = ImportNamespace(db, NamespaceHeaders, bytes.NewReader(dump[:len(dump)-1]))
+	assert.Equal(t, ErrInvalidNamespaceDump, err)
+	count, err = ImportNamespace(db, NamespaceHeaders, bytes.NewReader(dump))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	value, err := headers.Get([]byte("c"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("header c"), value)
+
+	_, err = PruneNamespace(db, "unknown")
+	assert.Equal(t, ErrUnknownNamespace, err)
+}
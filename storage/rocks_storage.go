@@ -0,0 +1,210 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+//go:build rocksdb
+// +build rocksdb
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tecbot/gorocksdb"
+)
+
+// rocksLevels is the count of levels of rocksdb by default.
+const rocksLevels = 7
+
+// RocksDBDriver is the rocksdb on disk, in the builds with tag rocksdb linking librocksdb.
+const RocksDBDriver = "rocksdb"
+
+func init() {
+	Register(RocksDBDriver, func(path string) (Database, error) {
+		return NewRocksStorage(path)
+	})
+}
+
+// RocksStorage the nodes in trie.
+type RocksStorage struct {
+	db *gorocksdb.DB
+	ro *gorocksdb.ReadOptions
+	wo *gorocksdb.WriteOptions
+}
+
+// NewRocksStorage init a storage
+func NewRocksStorage(path string) (*RocksStorage, error) {
+	bbto := gorocksdb.NewDefaultBlockBasedTableOptions()
+	bbto.SetBlockCache(gorocksdb.NewLRUCache(8 << 20))
+	bbto.SetFilterPolicy(gorocksdb.NewBloomFilter(10))
+	opts := gorocksdb.NewDefaultOptions()
+	opts.SetBlockBasedTableFactory(bbto)
+	opts.SetCreateIfMissing(true)
+	opts.SetMaxOpenFiles(4096)
+
+	db, err := gorocksdb.OpenDb(opts, path)
+	if err != nil {
+		return nil, err
+	}
+	return &RocksStorage{
+		db: db,
+		ro: gorocksdb.NewDefaultReadOptions(),
+		wo: gorocksdb.NewDefaultWriteOptions(),
+	}, nil
+}
+
+// Get return value to the key in Storage
+func (storage *RocksStorage) Get(key []byte) ([]byte, error) {
+	value, err := storage.db.Get(storage.ro, key)
+	if err != nil {
+		return nil, err
+	}
+	defer value.Free()
+	if !value.Exists() {
+		return nil, ErrKeyNotFound
+	}
+	return append([]byte{}, value.Data()...), nil
+}
+
+// Put put the key-value entry to Storage
+func (storage *RocksStorage) Put(key []byte, value []byte) error {
+	return storage.db.Put(storage.wo, key, value)
+}
+
+// Del delete the key in Storage.
+func (storage *RocksStorage) Del(key []byte) error {
+	return storage.db.Delete(storage.wo, key)
+}
+
+// NewBatch returns a batch written in a rocksdb write batch.
+func (storage *RocksStorage) NewBatch() Batch {
+	return &rocksBatch{storage: storage, batch: gorocksdb.NewWriteBatch()}
+}
+
+// NewIterator returns an iterator of the entries with keys of the prefix.
+func (storage *RocksStorage) NewIterator(prefix []byte) Iterator {
+	return &rocksIterator{it: storage.db.NewIterator(storage.ro), prefix: prefix}
+}
+
+// Close rocksdb
+func (storage *RocksStorage) Close() error {
+	storage.db.Close()
+	return nil
+}
+
+// Stats returns the statistics of rocksdb from its properties, the sizes of levels are not
+// reported by rocksdb, only their tables.
+func (storage *RocksStorage) Stats(prefixes [][]byte) (*Stats, error) {
+	property := func(name string) int64 {
+		value, _ := strconv.ParseInt(storage.db.GetProperty(name), 10, 64)
+		return value
+	}
+	stats := &Stats{
+		Size:        property("rocksdb.total-sst-files-size"),
+		PrefixSizes: make(map[string]int64),
+	}
+	for level := 0; level < rocksLevels; level++ {
+		stats.Levels = append(stats.Levels, &LevelStats{
+			Level:  level,
+			Tables: int(property(fmt.Sprintf("rocksdb.num-files-at-level%d", level))),
+		})
+	}
+	if property("rocksdb.compaction-pending") > 0 {
+		stats.PendingCompactions = 1
+	}
+	if len(prefixes) > 0 {
+		ranges := make([]gorocksdb.Range, len(prefixes))
+		for i, prefix := range prefixes {
+			r := PrefixRange(prefix)
+			ranges[i] = gorocksdb.Range{Start: r.Start, Limit: r.Limit}
+		}
+		for i, size := range storage.db.GetApproximateSizes(ranges) {
+			stats.PrefixSizes[string(prefixes[i])] = int64(size)
+		}
+	}
+	return stats, nil
+}
+
+// Compact compacts the files of rocksdb in the range, all if nil.
+func (storage *RocksStorage) Compact(r *Range) error {
+	if r == nil {
+		r = new(Range)
+	}
+	storage.db.CompactRange(gorocksdb.Range{Start: r.Start, Limit: r.Limit})
+	return nil
+}
+
+type rocksBatch struct {
+	storage *RocksStorage
+	batch   *gorocksdb.WriteBatch
+}
+
+func (b *rocksBatch) Put(key []byte, value []byte) {
+	b.batch.Put(key, value)
+}
+
+func (b *rocksBatch) Del(key []byte) {
+	b.batch.Delete(key)
+}
+
+func (b *rocksBatch) Write() error {
+	defer b.batch.Destroy()
+	return b.storage.db.Write(b.storage.wo, b.batch)
+}
+
+type rocksIterator struct {
+	it      *gorocksdb.Iterator
+	prefix  []byte
+	started bool
+	key     []byte
+	value   []byte
+}
+
+func (it *rocksIterator) Next() bool {
+	if it.started {
+		it.it.Next()
+	} else {
+		it.it.Seek(it.prefix)
+		it.started = true
+	}
+	if !it.it.ValidForPrefix(it.prefix) {
+		return false
+	}
+	key, value := it.it.Key(), it.it.Value()
+	it.key = append(it.key[:0], key.Data()...)
+	it.value = append(it.value[:0], value.Data()...)
+	key.Free()
+	value.Free()
+	return true
+}
+
+func (it *rocksIterator) Key() []byte {
+	return it.key
+}
+
+func (it *rocksIterator) Value() []byte {
+	return it.value
+}
+
+func (it *rocksIterator) Release() {
+	it.it.Close()
+}
+
+func (it *rocksIterator) Error() error {
+	return it.it.Err()
+}
@@ -22,7 +22,8 @@ import "errors"
 
 // const
 var (
-	ErrKeyNotFound = errors.New("not found")
+	ErrKeyNotFound   = errors.New("not found")
+	ErrUnknownDriver = errors.New("unknown storage driver")
 )
 
 // Storage interface of Storage.
@@ -36,3 +37,48 @@ type Storage interface {
 	// Del delete the key entry in Storage.
 	Del(key []byte) error
 }
+
+// Batch collects the puts and dels to a Database, they are written at once by Write, all or none.
+type Batch interface {
+	// Put put the key-value entry to the batch.
+	Put(key []byte, value []byte)
+
+	// Del delete the key entry in the batch.
+	Del(key []byte)
+
+	// Write writes the entries of the batch to the Database.
+	Write() error
+}
+
+// Iterator iterates the entries of a Database in ascending order of keys. It's positioned before
+// the first entry, and the key and value are valid till the next call of Next.
+type Iterator interface {
+	// Next moves to the next entry, it returns false if exhausted.
+	Next() bool
+
+	// Key returns the key of the current entry.
+	Key() []byte
+
+	// Value returns the value of the current entry.
+	Value() []byte
+
+	// Release releases the iterator.
+	Release()
+
+	// Error returns the error in iteration.
+	Error() error
+}
+
+// Database is a Storage opened by a driver, with batches and iterators.
+type Database interface {
+	Storage
+
+	// NewBatch returns an empty batch.
+	NewBatch() Batch
+
+	// NewIterator returns an iterator of the entries with keys of the prefix, all if nil.
+	NewIterator(prefix []byte) Iterator
+
+	// Close closes the Database.
+	Close() error
+}
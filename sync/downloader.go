@@ -0,0 +1,466 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/p2p"
+	"github.com/nebulasio/go-nebulas/net/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// download settings
+var (
+	// DownloadTimeout is how long to wait for a reply before retrying the request from another peer.
+	DownloadTimeout = 30 * time.Second
+	// DownloadChunkSize is the count of blocks in a chunk, a chunk is downloaded from a peer at a time.
+	DownloadChunkSize = 32
+	// MaxPendingChunks is the count of chunks downloaded ahead of execution.
+	MaxPendingChunks = 64
+)
+
+// errors
+var (
+	ErrUnexpectedBlock  = errors.New("sync: the block doesn't match the hash chain")
+	ErrEmptyReply       = errors.New("sync: the peer replied nothing")
+	ErrNoPeerToDownload = errors.New("sync: all peers failed to download the chunk")
+	ErrDownloadTimeout  = errors.New("sync: download request timeout")
+)
+
+var (
+	chunksDownloaded = metrics.GetOrRegisterMeter("neb.sync.chunk.downloaded", nil)
+	chunksRetried    = metrics.GetOrRegisterMeter("neb.sync.chunk.retried", nil)
+	chunksExecuted   = metrics.GetOrRegisterMeter("neb.sync.chunk.executed", nil)
+)
+
+type chunkState int
+
+const (
+	chunkPending chunkState = iota
+	chunkInflight
+	chunkDone
+)
+
+// chunk is a range of blocks whose hashes are known, the blocks are downloaded from a peer in a request.
+type chunk struct {
+	from   uint64
+	hashes []byteutils.Hash
+	blocks []*core.Block
+	state  chunkState
+	peer   string
+	// peers who failed to download the chunk, it is not requested from them again.
+	failures map[string]bool
+}
+
+// downloader downloads the blocks after a tail from multiple peers in parallel.
+// The hash chain is fetched from a peer first and split into chunks, every chunk is downloaded
+// from an idle peer, verified against the hash chain, and executed in order.
+// A failed chunk is retried from the other peers.
+type downloader struct {
+	mu sync.Mutex
+
+	peers     []string
+	busy      map[string]bool
+	requestID *uint64
+	send      func(string, pb.Message, string) error
+	push      func(*core.Block) error
+	// afterTimeout runs the func in the message loop after DownloadTimeout.
+	afterTimeout func(func())
+	// done is called in the message loop with the lock held when the download ends.
+	done func(error)
+
+	tailHash   byteutils.Hash
+	nextHash   uint64
+	moreHashes bool
+	// the peer asked for the hash chain, and the peers failed to reply it.
+	hashPeer     string
+	hashID       uint64
+	hashFailures map[string]bool
+
+	chunks   []*chunk
+	requests map[uint64]*chunk
+	executed int
+	finished bool
+}
+
+func newDownloader(tail *core.Block, peers []string, requestID *uint64) *downloader {
+	return &downloader{
+		peers:        peers,
+		busy:         make(map[string]bool),
+		requestID:    requestID,
+		tailHash:     tail.Hash(),
+		nextHash:     tail.Height() + 1,
+		moreHashes:   true,
+		hashFailures: make(map[string]bool),
+		requests:     make(map[uint64]*chunk),
+	}
+}
+
+// Start requests the hash chain and the first chunks.
+func (d *downloader) Start() {
+	d.mu.Lock()
+	d.requestHashes()
+	d.mu.Unlock()
+}
+
+func (d *downloader) newRequestID() uint64 {
+	return atomic.AddUint64(d.requestID, 1)
+}
+
+func (d *downloader) requestHashes() {
+	var peer string
+	for _, v := range d.peers {
+		if !d.hashFailures[v] {
+			peer = v
+			break
+		}
+	}
+	if len(peer) == 0 {
+		d.finish(ErrNoPeerToDownload)
+		return
+	}
+	d.hashPeer = peer
+	d.hashID = d.newRequestID()
+	req := &netpb.GetBlockHashes{
+		Id:    d.hashID,
+		From:  d.nextHash,
+		Count: p2p.MaxBlockHashesPerRequest,
+	}
+	d.sendRequest(net.MessageTypeGetBlockHashes, req, peer)
+
+	id := d.hashID
+	d.afterTimeout(func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.hashID == id && !d.finished {
+			d.hashFailures[peer] = true
+			d.requestHashes()
+		}
+	})
+}
+
+func (d *downloader) requestChunk(c *chunk, peer string) {
+	id := d.newRequestID()
+	c.state = chunkInflight
+	c.peer = peer
+	d.busy[peer] = true
+	d.requests[id] = c
+
+	req := &netpb.GetBlocksByRange{
+		Id:    id,
+		From:  c.from + uint64(len(c.blocks)),
+		Count: uint64(len(c.hashes) - len(c.blocks)),
+	}
+	d.sendRequest(net.MessageTypeGetBlocksByRange, req, peer)
+
+	d.afterTimeout(func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.requests[id] == c {
+			d.failChunk(id, c, ErrDownloadTimeout)
+			d.schedule()
+		}
+	})
+}
+
+func (d *downloader) sendRequest(msgType string, req pb.Message, peer string) {
+	if err := d.send(msgType, req, peer); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msgType,
+			"peer":    peer,
+			"err":     err,
+		}).Error("Failed to send the download request.")
+	}
+}
+
+// schedule assigns the pending chunks to the idle peers, and fetches more hashes if few chunks are pending.
+func (d *downloader) schedule() {
+	if d.finished {
+		return
+	}
+	for _, peer := range d.peers {
+		if d.busy[peer] {
+			continue
+		}
+		for _, c := range d.chunks[d.executed:] {
+			if c.state == chunkPending && !c.failures[peer] {
+				d.requestChunk(c, peer)
+				break
+			}
+		}
+	}
+	if len(d.chunks)-d.executed == 0 && !d.moreHashes {
+		d.finish(nil)
+	}
+}
+
+func (d *downloader) failChunk(id uint64, c *chunk, err error) {
+	delete(d.requests, id)
+	d.busy[c.peer] = false
+	c.failures[c.peer] = true
+	c.state = chunkPending
+	chunksRetried.Mark(1)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"from": c.from,
+		"peer": c.peer,
+		"err":  err,
+	}).Warn("Failed to download the chunk, retry it from another peer.")
+
+	if len(c.failures) >= len(d.peers) {
+		d.finish(ErrNoPeerToDownload)
+	}
+}
+
+func (d *downloader) finish(err error) {
+	if d.finished {
+		return
+	}
+	d.finished = true
+	d.done(err)
+}
+
+// Handle handles a reply of the download.
+func (d *downloader) Handle(msg net.Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.finished {
+		return
+	}
+
+	switch msg.MessageType() {
+	case net.MessageTypeBlockHashes:
+		reply := new(netpb.BlockHashes)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err != nil {
+			return
+		}
+		if reply.Id != d.hashID || reply.From != d.nextHash || msg.MessageFrom() != d.hashPeer {
+			return
+		}
+		d.hashID = 0
+		d.addHashes(reply.Hashes)
+		d.moreHashes = reply.More && len(reply.Hashes) > 0
+		if d.moreHashes && len(d.chunks)-d.executed < MaxPendingChunks {
+			d.requestHashes()
+		}
+	case net.MessageTypeBlockBodies:
+		reply := new(netpb.BlockBodies)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err != nil {
+			return
+		}
+		c, ok := d.requests[reply.Id]
+		if !ok || msg.MessageFrom() != c.peer || reply.From != c.from+uint64(len(c.blocks)) {
+			return
+		}
+		if err := d.verifyBodies(c, reply.Blocks); err != nil {
+			d.failChunk(reply.Id, c, err)
+			break
+		}
+		delete(d.requests, reply.Id)
+		d.busy[c.peer] = false
+		if len(c.blocks) == len(c.hashes) {
+			c.state = chunkDone
+			chunksDownloaded.Mark(1)
+		} else {
+			// the reply was cut by the size cap, the rest is requested again.
+			c.state = chunkPending
+		}
+		d.execute()
+	}
+	d.schedule()
+}
+
+// addHashes splits the hashes into chunks.
+func (d *downloader) addHashes(hashes [][]byte) {
+	for len(hashes) > 0 {
+		size := DownloadChunkSize
+		if size > len(hashes) {
+			size = len(hashes)
+		}
+		c := &chunk{
+			from:     d.nextHash,
+			failures: make(map[string]bool),
+		}
+		for _, v := range hashes[:size] {
+			c.hashes = append(c.hashes, v)
+		}
+		d.chunks = append(d.chunks, c)
+		d.nextHash += uint64(size)
+		hashes = hashes[size:]
+	}
+}
+
+// parentHash returns the expected parent hash of the block at the index of the chunk.
+func (d *downloader) parentHash(c *chunk, index int) byteutils.Hash {
+	if index > 0 {
+		return c.hashes[index-1]
+	}
+	for i, v := range d.chunks {
+		if v == c && i > 0 {
+			prev := d.chunks[i-1]
+			return prev.hashes[len(prev.hashes)-1]
+		}
+	}
+	return d.tailHash
+}
+
+// verifyBodies checks the downloaded blocks match the hash chain before they are executed.
+func (d *downloader) verifyBodies(c *chunk, bodies [][]byte) error {
+	if len(bodies) == 0 {
+		return ErrEmptyReply
+	}
+	if len(c.blocks)+len(bodies) > len(c.hashes) {
+		return ErrUnexpectedBlock
+	}
+	var blocks []*core.Block
+	for i, data := range bodies {
+		pbBlock := new(corepb.Block)
+		if err := pb.Unmarshal(data, pbBlock); err != nil {
+			return err
+		}
+		block := new(core.Block)
+		if err := block.FromProto(pbBlock); err != nil {
+			return err
+		}
+		index := len(c.blocks) + i
+		if !block.Hash().Equals(c.hashes[index]) ||
+			!core.HashBlock(block).Equals(block.Hash()) ||
+			!block.ParentHash().Equals(d.parentHash(c, index)) {
+			return ErrUnexpectedBlock
+		}
+		blocks = append(blocks, block)
+	}
+	c.blocks = append(c.blocks, blocks...)
+	return nil
+}
+
+// execute pushes the downloaded chunks into block pool in order.
+func (d *downloader) execute() {
+	for d.executed < len(d.chunks) && d.chunks[d.executed].state == chunkDone {
+		c := d.chunks[d.executed]
+		for _, block := range c.blocks {
+			if err := d.push(block); err != nil && err != core.ErrDuplicatedBlock {
+				logging.VLog().WithFields(logrus.Fields{
+					"block": block,
+					"err":   err,
+				}).Error("Failed to execute the downloaded block.")
+				d.finish(err)
+				return
+			}
+		}
+		c.blocks = nil
+		d.executed++
+		chunksExecuted.Mark(1)
+	}
+	if d.moreHashes && d.hashID == 0 && len(d.chunks)-d.executed < MaxPendingChunks {
+		d.requestHashes()
+	}
+}
+
+// startDownload downloads the blocks after tail from the peers agreed on it, the sync ends when the download is done.
+func (m *Manager) startDownload(tail *core.Block, peers []string) {
+	// fast sync is only tried once, the blocks after the pivot are downloaded as usual.
+	m.downloaderMu.Lock()
+	fast := m.fastSyncEnabled
+	m.fastSyncEnabled = false
+	m.downloaderMu.Unlock()
+	if fast {
+		m.startFastSync(tail, peers)
+		return
+	}
+
+	d := newDownloader(tail, peers, &m.requestID)
+	d.send = m.sendSyncMsg
+	d.push = m.blockChain.BlockPool().Push
+	d.afterTimeout = m.afterTimeout
+	d.done = func(err error) {
+		m.downloaderMu.Lock()
+		if m.downloader == d {
+			m.downloader = nil
+		}
+		m.downloaderMu.Unlock()
+
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"err": err,
+			}).Warn("Failed to download blocks, sync again.")
+			m.curTail = m.blockChain.TailBlock()
+			m.requestSync()
+			return
+		}
+		logging.VLog().Info("Download blocks finished.")
+		m.endSyncCh <- true
+	}
+
+	m.downloaderMu.Lock()
+	m.downloader = d
+	m.downloaderMu.Unlock()
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tail":  tail,
+		"peers": peers,
+	}).Info("Start to download blocks.")
+	d.Start()
+}
+
+// afterTimeout passes the func to the message loop after DownloadTimeout, the timer doesn't run it itself.
+func (m *Manager) afterTimeout(fn func()) {
+	time.AfterFunc(DownloadTimeout, func() {
+		m.timeoutCh <- fn
+	})
+}
+
+// requestSync asks the sync loop to sync again from the current tail, unless it's asked already.
+func (m *Manager) requestSync() {
+	select {
+	case m.syncCh <- true:
+	default:
+	}
+}
+
+// handleSyncProtocolReply records the tail heights of peers, and passes the replies to the running fast sync or download.
+func (m *Manager) handleSyncProtocolReply(msg net.Message) {
+	if msg.MessageType() == net.MessageTypeBlockHashes {
+		reply := new(netpb.BlockHashes)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err == nil {
+			m.observePeerHeight(reply.Tail)
+		}
+	}
+
+	m.downloaderMu.Lock()
+	f, d := m.fastSyncer, m.downloader
+	m.downloaderMu.Unlock()
+	if f != nil {
+		f.Handle(msg)
+	}
+	if d != nil {
+		d.Handle(msg)
+	}
+}
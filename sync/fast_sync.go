@@ -0,0 +1,452 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	pb "github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/p2p"
+	"github.com/nebulasio/go-nebulas/net/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// fast sync settings
+var (
+	// FastSyncPivotDistance is how far the pivot block is behind the lowest tail of the peers,
+	// the recent blocks may be reverted.
+	FastSyncPivotDistance uint64 = 64
+	// FastSyncMinDistance is the least count of blocks skipped by fast sync, or the blocks are replayed.
+	FastSyncMinDistance uint64 = 1024
+	// FastSyncMaxPeerFailures is the count of failed requests a peer is dropped after.
+	FastSyncMaxPeerFailures = 3
+)
+
+// errors
+var (
+	ErrPivotDisagreed   = errors.New("sync: peers disagree on the pivot block")
+	ErrInvalidPivot     = errors.New("sync: the pivot block doesn't match the agreed hash")
+	ErrNoPeerToFastSync = errors.New("sync: no peer left to fast sync")
+)
+
+var (
+	trieNodesFetched = metrics.GetOrRegisterMeter("neb.sync.fast.node", nil)
+	trieNodesPending = metrics.GetOrRegisterGauge("neb.sync.fast.pending", nil)
+)
+
+type fastSyncStage int
+
+const (
+	stageTails fastSyncStage = iota
+	stagePivotHash
+	stagePivotBlock
+	stageState
+)
+
+// fastSync fetches the states of a recent block instead of replaying the blocks before it.
+// The pivot block is chosen behind the tails of all peers, its hash must be agreed by all of them,
+// and the tries referred by it are fetched node by node and verified against its roots.
+type fastSync struct {
+	mu sync.Mutex
+
+	peers     []string
+	failures  map[string]int
+	requestID *uint64
+	send      func(string, pb.Message, string) error
+	storage   storage.Storage
+	// afterTimeout runs the func in the message loop after DownloadTimeout.
+	afterTimeout func(func())
+	// done is called in the message loop with the lock held, with the pivot block whose states are
+	// fetched, or nil if fast sync is not worthwhile.
+	done func(*corepb.Block, error)
+
+	stage       fastSyncStage
+	stageID     uint64
+	local       uint64
+	replies     map[string]*netpb.BlockHashes
+	pivotHash   byteutils.Hash
+	pivotHeight uint64
+	pivot       *corepb.Block
+	// checkpoint is trusted as the pivot if it's far enough, so the peers aren't asked to agree on one.
+	checkpoint *core.Checkpoint
+
+	trieSync *trie.Sync
+	requests map[uint64]*trieRequest
+	busy     map[string]bool
+	finished bool
+}
+
+type trieRequest struct {
+	peer   string
+	hashes [][]byte
+}
+
+func newFastSync(tail *core.Block, peers []string, requestID *uint64, stor storage.Storage) *fastSync {
+	return &fastSync{
+		peers:     peers,
+		failures:  make(map[string]int),
+		requestID: requestID,
+		storage:   stor,
+		local:     tail.Height(),
+		requests:  make(map[uint64]*trieRequest),
+		busy:      make(map[string]bool),
+	}
+}
+
+// Start asks the peers for their tails.
+func (f *fastSync) Start() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcast(&netpb.GetBlockHashes{From: f.local + 1, Count: 1})
+}
+
+// broadcast sends a request of block hashes to all peers, the stage times out if any of them doesn't reply.
+func (f *fastSync) broadcast(req *netpb.GetBlockHashes) {
+	f.stageID = atomic.AddUint64(f.requestID, 1)
+	f.replies = make(map[string]*netpb.BlockHashes)
+	req.Id = f.stageID
+	for _, peer := range f.peers {
+		f.sendRequest(net.MessageTypeGetBlockHashes, req, peer)
+	}
+	f.afterStageTimeout(f.stageID, func() {
+		// the peers not replied are dropped.
+		var peers []string
+		for _, peer := range f.peers {
+			if _, ok := f.replies[peer]; ok {
+				peers = append(peers, peer)
+			}
+		}
+		f.peers = peers
+		f.nextStage()
+	})
+}
+
+func (f *fastSync) afterStageTimeout(id uint64, fn func()) {
+	f.afterTimeout(func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.stageID == id && !f.finished {
+			fn()
+		}
+	})
+}
+
+func (f *fastSync) sendRequest(msgType string, req pb.Message, peer string) {
+	if err := f.send(msgType, req, peer); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msgType,
+			"peer":    peer,
+			"err":     err,
+		}).Error("Failed to send the fast sync request.")
+	}
+}
+
+func (f *fastSync) finish(pivot *corepb.Block, err error) {
+	if f.finished {
+		return
+	}
+	f.finished = true
+	f.done(pivot, err)
+}
+
+// nextStage moves on when all peers replied to a broadcast or the broadcast times out.
+func (f *fastSync) nextStage() {
+	if len(f.peers) == 0 {
+		f.finish(nil, ErrNoPeerToFastSync)
+		return
+	}
+	switch f.stage {
+	case stageTails:
+		lowest := f.replies[f.peers[0]].Tail
+		for _, reply := range f.replies {
+			if reply.Tail < lowest {
+				lowest = reply.Tail
+			}
+		}
+		// the peers of old versions don't tell their tails.
+		if lowest < f.local+FastSyncMinDistance+FastSyncPivotDistance {
+			f.finish(nil, nil)
+			return
+		}
+		if cp := f.checkpoint; cp != nil && cp.Height >= f.local+FastSyncMinDistance && cp.Height <= lowest {
+			f.pivotHash = cp.Hash
+			f.pivotHeight = cp.Height
+			f.stage = stagePivotBlock
+			f.requestPivot()
+			return
+		}
+		f.stage = stagePivotHash
+		f.broadcast(&netpb.GetBlockHashes{From: lowest - FastSyncPivotDistance, Count: 1})
+	case stagePivotHash:
+		var agreed byteutils.Hash
+		for _, reply := range f.replies {
+			if len(reply.Hashes) != 1 || (agreed != nil && !agreed.Equals(reply.Hashes[0])) {
+				f.finish(nil, ErrPivotDisagreed)
+				return
+			}
+			agreed = reply.Hashes[0]
+		}
+		f.pivotHeight = f.replies[f.peers[0]].From
+		if cp := f.checkpoint; cp != nil && cp.Height == f.pivotHeight && !cp.Hash.Equals(agreed) {
+			f.finish(nil, ErrInvalidPivot)
+			return
+		}
+		f.pivotHash = agreed
+		f.stage = stagePivotBlock
+		f.requestPivot()
+	}
+}
+
+func (f *fastSync) requestPivot() {
+	f.stageID = atomic.AddUint64(f.requestID, 1)
+	f.sendRequest(net.MessageTypeGetBlocksByRange, &netpb.GetBlocksByRange{Id: f.stageID, From: f.pivotHeight, Count: 1}, f.peers[0])
+	f.afterStageTimeout(f.stageID, func() {
+		f.failPeer(f.peers[0])
+		if len(f.peers) == 0 {
+			f.finish(nil, ErrNoPeerToFastSync)
+			return
+		}
+		f.requestPivot()
+	})
+}
+
+// failPeer records a failed request of the peer, and drops it after too many failures.
+func (f *fastSync) failPeer(peer string) {
+	f.failures[peer]++
+	if f.failures[peer] < FastSyncMaxPeerFailures && f.stage == stageState {
+		return
+	}
+	for i, v := range f.peers {
+		if v == peer {
+			f.peers = append(f.peers[:i], f.peers[i+1:]...)
+			break
+		}
+	}
+}
+
+// verifyPivot checks the pivot block matches the agreed hash, and schedules the tries it refers to.
+func (f *fastSync) verifyPivot(data []byte) error {
+	pbBlock := new(corepb.Block)
+	if err := pb.Unmarshal(data, pbBlock); err != nil {
+		return err
+	}
+	block := new(core.Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return err
+	}
+	// the roots are covered by the block hash.
+	if !block.Hash().Equals(f.pivotHash) || !core.HashBlock(block).Equals(block.Hash()) {
+		return ErrInvalidPivot
+	}
+
+	f.trieSync = trie.NewSync(f.storage, accountVariables)
+	roots := [][]byte{block.StateRoot(), block.TxsRoot(), block.EventsRoot(), block.ReceiptsRoot()}
+	if ctx := block.DposContext(); ctx != nil {
+		roots = append(roots, ctx.DynastyRoot, ctx.NextDynastyRoot, ctx.DelegateRoot,
+			ctx.CandidateRoot, ctx.VoteRoot, ctx.MintCntRoot)
+	}
+	for _, root := range roots {
+		if err := f.trieSync.AddRoot(root); err != nil {
+			return err
+		}
+	}
+	f.pivot = pbBlock
+	return nil
+}
+
+// accountVariables returns the root of the variables trie of an account in state trie.
+func accountVariables(value []byte) [][]byte {
+	acc := new(corepb.Account)
+	if err := pb.Unmarshal(value, acc); err != nil {
+		return nil
+	}
+	return [][]byte{acc.VarsHash}
+}
+
+// schedule requests the missing trie nodes from the idle peers.
+func (f *fastSync) schedule() {
+	if f.finished {
+		return
+	}
+	for _, peer := range f.peers {
+		if f.busy[peer] {
+			continue
+		}
+		hashes := f.trieSync.Missing(p2p.MaxTrieNodesPerRequest)
+		if len(hashes) == 0 {
+			break
+		}
+		id := atomic.AddUint64(f.requestID, 1)
+		req := &trieRequest{peer: peer, hashes: hashes}
+		f.requests[id] = req
+		f.busy[peer] = true
+		f.sendRequest(net.MessageTypeGetTrieNodes, &netpb.GetTrieNodes{Id: id, Hashes: hashes}, peer)
+
+		f.afterTimeout(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if f.requests[id] == req {
+				f.failRequest(id, req)
+				f.schedule()
+			}
+		})
+	}
+
+	trieNodesPending.Update(int64(f.trieSync.Pending()))
+	if f.trieSync.Pending() == 0 {
+		f.finish(f.pivot, nil)
+	} else if len(f.peers) == 0 {
+		f.finish(nil, ErrNoPeerToFastSync)
+	}
+}
+
+func (f *fastSync) failRequest(id uint64, req *trieRequest) {
+	delete(f.requests, id)
+	f.busy[req.peer] = false
+	f.trieSync.Retry(req.hashes)
+	f.failPeer(req.peer)
+}
+
+// Handle handles a reply of fast sync.
+func (f *fastSync) Handle(msg net.Message) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.finished {
+		return
+	}
+
+	switch msg.MessageType() {
+	case net.MessageTypeBlockHashes:
+		reply := new(netpb.BlockHashes)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err != nil || reply.Id != f.stageID {
+			return
+		}
+		f.replies[msg.MessageFrom()] = reply
+		if len(f.replies) >= len(f.peers) {
+			f.nextStage()
+		}
+	case net.MessageTypeBlockBodies:
+		reply := new(netpb.BlockBodies)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err != nil || reply.Id != f.stageID || f.stage != stagePivotBlock {
+			return
+		}
+		if len(reply.Blocks) != 1 {
+			f.finish(nil, ErrInvalidPivot)
+			return
+		}
+		if err := f.verifyPivot(reply.Blocks[0]); err != nil {
+			f.finish(nil, err)
+			return
+		}
+		logging.VLog().WithFields(logrus.Fields{
+			"pivot": f.pivotHash.Hex(),
+			"peers": f.peers,
+		}).Info("Start to fetch the states of the pivot block.")
+		f.stage = stageState
+		f.stageID = 0
+		f.schedule()
+	case net.MessageTypeTrieNodes:
+		reply := new(netpb.TrieNodes)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err != nil {
+			return
+		}
+		req, ok := f.requests[reply.Id]
+		if !ok || req.peer != msg.MessageFrom() {
+			return
+		}
+		if len(reply.Nodes) == 0 {
+			f.failRequest(reply.Id, req)
+		} else if err := f.trieSync.Process(reply.Nodes); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"peer": req.peer,
+				"err":  err,
+			}).Warn("Received invalid trie nodes.")
+			f.failRequest(reply.Id, req)
+		} else {
+			delete(f.requests, reply.Id)
+			f.busy[req.peer] = false
+			// the nodes not replied are requested again.
+			f.trieSync.Retry(req.hashes)
+			trieNodesFetched.Mark(int64(len(reply.Nodes)))
+		}
+		f.schedule()
+	}
+}
+
+// SetFastSync enables to fetch the states of a recent block instead of replaying the blocks before it,
+// in the next download of blocks.
+func (m *Manager) SetFastSync(enabled bool) {
+	m.downloaderMu.Lock()
+	defer m.downloaderMu.Unlock()
+	m.fastSyncEnabled = enabled
+}
+
+// startFastSync fetches the states of a pivot block, then downloads the blocks after it.
+// The blocks are downloaded after tail if fast sync fails or isn't worthwhile.
+func (m *Manager) startFastSync(tail *core.Block, peers []string) {
+	f := newFastSync(tail, peers, &m.requestID, m.blockChain.Storage())
+	f.checkpoint = m.blockChain.LatestCheckpoint()
+	f.send = m.sendSyncMsg
+	f.afterTimeout = m.afterTimeout
+	f.done = func(pivot *corepb.Block, err error) {
+		m.downloaderMu.Lock()
+		if m.fastSyncer == f {
+			m.fastSyncer = nil
+		}
+		m.downloaderMu.Unlock()
+
+		if err == nil && pivot != nil {
+			var block *core.Block
+			if block, err = m.blockChain.SetTailBlockFromSnapshot(pivot); err == nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"pivot": block,
+				}).Info("Fast sync finished.")
+				m.curTail = block
+				m.startDownload(block, peers)
+				return
+			}
+		}
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"err": err,
+			}).Warn("Failed to fast sync, replay the blocks.")
+		}
+		m.startDownload(tail, peers)
+	}
+
+	m.downloaderMu.Lock()
+	m.fastSyncer = f
+	m.downloaderMu.Unlock()
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tail":  tail,
+		"peers": peers,
+	}).Info("Start to fast sync.")
+	f.Start()
+}
@@ -0,0 +1,346 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/p2p"
+	"github.com/nebulasio/go-nebulas/net/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// light client settings
+var (
+	// LightSyncInterval is the interval to sync the headers after tail.
+	LightSyncInterval = 10 * time.Second
+	// MaxLightRewind is the most headers rewound in a sync if the canonical chain of peer forks from ours.
+	MaxLightRewind = 64
+)
+
+// errors of light client
+var (
+	ErrNoLightServer   = errors.New("sync: no light server to request")
+	ErrRequestTimeout  = errors.New("sync: request timeout")
+	ErrUnknownHeader   = errors.New("sync: the header is not synced")
+	ErrProofNotFound   = errors.New("sync: no peer proved the key")
+	ErrInvalidReply    = errors.New("sync: invalid reply")
+	errLightClientStop = errors.New("sync: light client stopped")
+)
+
+// LightClient syncs only the headers of canonical chain, and queries the accounts, transactions and events
+// from full nodes with merkle proofs, which are verified against the roots in the synced headers.
+// The headers are verified by hash and link, not by their proposers, so the light servers should be trusted.
+type LightClient struct {
+	chain     *core.HeaderChain
+	ns        p2p.Manager
+	peers     func() []string
+	quitCh    chan bool
+	receiveCh chan net.Message
+	requestID uint64
+	syncing   int32
+
+	mu      sync.Mutex
+	pending map[uint64]chan net.Message
+}
+
+// NewLightClient returns a light client, peers lists the peers serving light clients.
+func NewLightClient(chain *core.HeaderChain, ns p2p.Manager, peers func() []string) *LightClient {
+	return &LightClient{
+		chain:     chain,
+		ns:        ns,
+		peers:     peers,
+		quitCh:    make(chan bool, 1),
+		receiveCh: make(chan net.Message, 128),
+		pending:   make(map[uint64]chan net.Message),
+	}
+}
+
+// HeaderChain returns the synced headers.
+func (lc *LightClient) HeaderChain() *core.HeaderChain {
+	return lc.chain
+}
+
+// Start starts to sync headers.
+func (lc *LightClient) Start() {
+	lc.ns.Register(net.NewSubscriber(lc, lc.receiveCh, net.MessageTypeLightHeaders, net.MessageTypeProof))
+	go lc.loop()
+}
+
+// Stop stops the light client.
+func (lc *LightClient) Stop() {
+	lc.ns.Deregister(net.NewSubscriber(lc, lc.receiveCh, net.MessageTypeLightHeaders, net.MessageTypeProof))
+	lc.quitCh <- true
+}
+
+func (lc *LightClient) loop() {
+	logging.CLog().Info("Started light client.")
+	ticker := time.NewTicker(LightSyncInterval)
+	defer ticker.Stop()
+
+	go lc.syncHeaders()
+	for {
+		select {
+		case <-lc.quitCh:
+			lc.mu.Lock()
+			for id, ch := range lc.pending {
+				close(ch)
+				delete(lc.pending, id)
+			}
+			lc.mu.Unlock()
+			logging.CLog().Info("Stopped light client.")
+			return
+		case <-ticker.C:
+			go lc.syncHeaders()
+		case msg := <-lc.receiveCh:
+			lc.dispatch(msg)
+		}
+	}
+}
+
+// dispatch passes a reply to the request waiting for it.
+func (lc *LightClient) dispatch(msg net.Message) {
+	var id uint64
+	switch msg.MessageType() {
+	case net.MessageTypeLightHeaders:
+		reply := new(netpb.LightHeaders)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err != nil {
+			return
+		}
+		id = reply.Id
+	case net.MessageTypeProof:
+		reply := new(netpb.Proof)
+		if err := pb.Unmarshal(msg.Data().([]byte), reply); err != nil {
+			return
+		}
+		id = reply.Id
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if ch, ok := lc.pending[id]; ok {
+		ch <- msg
+		delete(lc.pending, id)
+	}
+}
+
+// request sends a request to peer and waits for the reply from it.
+func (lc *LightClient) request(peer string, msgType string, id uint64, req pb.Message, reply pb.Message) error {
+	data, err := pb.Marshal(req)
+	if err != nil {
+		return err
+	}
+	ch := make(chan net.Message, 1)
+	lc.mu.Lock()
+	lc.pending[id] = ch
+	lc.mu.Unlock()
+	defer func() {
+		lc.mu.Lock()
+		delete(lc.pending, id)
+		lc.mu.Unlock()
+	}()
+
+	if err := lc.ns.SendMsg(msgType, data, peer); err != nil {
+		return err
+	}
+	select {
+	case msg, ok := <-ch:
+		if !ok {
+			return errLightClientStop
+		}
+		if msg.MessageFrom() != peer {
+			return ErrInvalidReply
+		}
+		return pb.Unmarshal(msg.Data().([]byte), reply)
+	case <-time.After(DownloadTimeout):
+		return ErrRequestTimeout
+	}
+}
+
+func (lc *LightClient) syncHeaders() {
+	if err := lc.SyncHeaders(); err != nil && err != ErrNoLightServer {
+		logging.VLog().WithFields(logrus.Fields{
+			"tail": lc.chain.Tail().Height,
+			"err":  err,
+		}).Warn("Failed to sync light headers.")
+	}
+}
+
+// SyncHeaders syncs the headers after tail from the peers in turn, until a peer has no more.
+func (lc *LightClient) SyncHeaders() error {
+	if !atomic.CompareAndSwapInt32(&lc.syncing, 0, 1) {
+		return nil
+	}
+	defer atomic.StoreInt32(&lc.syncing, 0)
+
+	peers := lc.peers()
+	if len(peers) == 0 {
+		return ErrNoLightServer
+	}
+	var err error
+	for _, peer := range peers {
+		if err = lc.syncHeadersFrom(peer); err == nil {
+			return nil
+		}
+		logging.VLog().WithFields(logrus.Fields{
+			"peer": peer,
+			"err":  err,
+		}).Debug("Failed to sync light headers from peer.")
+	}
+	return err
+}
+
+func (lc *LightClient) syncHeadersFrom(peer string) error {
+	rewound := 0
+	for {
+		from := lc.chain.Tail().Height + 1
+		req := &netpb.GetLightHeaders{
+			Id:    atomic.AddUint64(&lc.requestID, 1),
+			From:  from,
+			Count: p2p.MaxLightHeadersPerRequest,
+		}
+		reply := new(netpb.LightHeaders)
+		if err := lc.request(peer, net.MessageTypeGetLightHeaders, req.Id, req, reply); err != nil {
+			return err
+		}
+		if reply.From != from {
+			return ErrInvalidReply
+		}
+		var headers []*corepb.LightHeader
+		for _, data := range reply.Headers {
+			h := new(corepb.LightHeader)
+			if err := pb.Unmarshal(data, h); err != nil {
+				return err
+			}
+			headers = append(headers, h)
+		}
+		err := lc.chain.Append(headers)
+		if err == core.ErrLightHeaderNotChain && len(headers) > 0 && headers[0].Height == from &&
+			from > 1 && rewound < MaxLightRewind {
+			// the canonical chain of peer forks before our tail.
+			rewound++
+			if err = lc.chain.Rewind(from - 2); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !reply.More || len(headers) == 0 {
+			return nil
+		}
+	}
+}
+
+// GetProof returns the value of key in the trie of kind at the block, proved by a peer
+// against the root in the synced header. A key not proved by any peer may be absent or withheld.
+func (lc *LightClient) GetProof(kind string, blockHash byteutils.Hash, key []byte) ([]byte, error) {
+	header, err := lc.chain.GetHeader(blockHash)
+	if err != nil {
+		return nil, ErrUnknownHeader
+	}
+	peers := lc.peers()
+	if len(peers) == 0 {
+		return nil, ErrNoLightServer
+	}
+	for _, peer := range peers {
+		req := &netpb.GetProof{
+			Id:        atomic.AddUint64(&lc.requestID, 1),
+			BlockHash: blockHash,
+			Kind:      kind,
+			Key:       key,
+		}
+		reply := new(netpb.Proof)
+		if err := lc.request(peer, net.MessageTypeGetProof, req.Id, req, reply); err != nil || len(reply.Nodes) == 0 {
+			continue
+		}
+		proof, err := trie.MerkleProofFromBytes(reply.Nodes)
+		if err != nil {
+			continue
+		}
+		if err := core.VerifyProof(header.Header, kind, key, reply.Value, proof); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"peer": peer,
+				"kind": kind,
+				"err":  err,
+			}).Warn("Received an invalid proof.")
+			continue
+		}
+		return reply.Value, nil
+	}
+	return nil, ErrProofNotFound
+}
+
+// GetAccount returns the account in the state of the block.
+func (lc *LightClient) GetAccount(blockHash byteutils.Hash, addr *core.Address) (*corepb.Account, error) {
+	value, err := lc.GetProof(core.ProofAccount, blockHash, addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	acc := new(corepb.Account)
+	if err := pb.Unmarshal(value, acc); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// GetTransaction returns the transaction included in the block.
+func (lc *LightClient) GetTransaction(blockHash byteutils.Hash, txHash byteutils.Hash) (*corepb.Transaction, error) {
+	value, err := lc.GetProof(core.ProofTransaction, blockHash, txHash)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(corepb.Transaction)
+	if err := pb.Unmarshal(value, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// GetEvents returns the events of the transaction in the block, they are fetched one by one
+// until an index not proved.
+func (lc *LightClient) GetEvents(blockHash byteutils.Hash, txHash byteutils.Hash) ([]*core.Event, error) {
+	var events []*core.Event
+	for index := int64(1); ; index++ {
+		value, err := lc.GetProof(core.ProofEvent, blockHash, core.EventProofKey(txHash, index))
+		if err == ErrProofNotFound {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		event := new(core.Event)
+		if err := json.Unmarshal(value, event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+}
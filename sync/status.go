@@ -0,0 +1,122 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Status is the progress of sync.
+type Status struct {
+	Synchronizing bool   `json:"synchronizing"`
+	StartHeight   uint64 `json:"start_height"`
+	CurrentHeight uint64 `json:"current_height"`
+	// HighestHeight is the highest tail height reported by peers, it's at least the current height.
+	HighestHeight uint64 `json:"highest_height"`
+	// Rate is the blocks synced per second since the sync started.
+	Rate float64 `json:"rate"`
+	// ETA is the estimated time to reach the highest height, 0 if unknown.
+	ETA time.Duration `json:"eta"`
+}
+
+// progress records the sync in progress.
+type progress struct {
+	mu            sync.Mutex
+	synchronizing bool
+	startHeight   uint64
+	startTime     time.Time
+	highestHeight uint64
+}
+
+// Status returns the progress of sync.
+func (m *Manager) Status() *Status {
+	current := m.blockChain.TailBlock().Height()
+
+	m.progress.mu.Lock()
+	defer m.progress.mu.Unlock()
+	s := &Status{
+		Synchronizing: m.progress.synchronizing,
+		StartHeight:   m.progress.startHeight,
+		CurrentHeight: current,
+		HighestHeight: m.progress.highestHeight,
+	}
+	if s.HighestHeight < current {
+		s.HighestHeight = current
+	}
+	if !s.Synchronizing {
+		return s
+	}
+	if elapsed := time.Since(m.progress.startTime).Seconds(); elapsed > 0 && current > s.StartHeight {
+		s.Rate = float64(current-s.StartHeight) / elapsed
+		s.ETA = time.Duration(float64(s.HighestHeight-current) / s.Rate * float64(time.Second))
+	}
+	return s
+}
+
+// observePeerHeight records the tail height reported by a peer.
+func (m *Manager) observePeerHeight(height uint64) {
+	m.progress.mu.Lock()
+	defer m.progress.mu.Unlock()
+	if height > m.progress.highestHeight {
+		m.progress.highestHeight = height
+	}
+}
+
+func (m *Manager) syncStarted() {
+	m.progress.mu.Lock()
+	m.progress.synchronizing = true
+	m.progress.startHeight = m.blockChain.TailBlock().Height()
+	m.progress.startTime = time.Now()
+	m.progress.mu.Unlock()
+
+	m.triggerSyncEvent(core.TopicSyncStarted)
+}
+
+func (m *Manager) syncFinished() {
+	m.progress.mu.Lock()
+	if !m.progress.synchronizing {
+		m.progress.mu.Unlock()
+		return
+	}
+	m.progress.synchronizing = false
+	m.progress.mu.Unlock()
+
+	m.triggerSyncEvent(core.TopicSyncFinished)
+}
+
+func (m *Manager) triggerSyncEvent(topic string) {
+	data, err := json.Marshal(m.Status())
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"topic": topic,
+			"err":   err,
+		}).Error("Failed to marshal sync status.")
+		return
+	}
+	m.blockChain.EventEmitter().Trigger(&core.Event{
+		Topic: topic,
+		Data:  string(data),
+	})
+}
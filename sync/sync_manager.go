@@ -19,6 +19,7 @@
 package sync
 
 import (
+	"sync"
 	"time"
 
 	pb "github.com/gogo/protobuf/proto"
@@ -55,6 +56,19 @@ type Manager struct {
 	curTail                *core.Block
 	canSyncWithBlockListCh chan bool
 	goParentSyncCh         chan bool
+
+	receiveSyncRequestCh       chan net.Message
+	receiveSyncProtocolReplyCh chan net.Message
+	requestID                  uint64
+	downloaderMu               sync.Mutex
+	downloader                 *downloader
+	fastSyncEnabled            bool
+	fastSyncer                 *fastSync
+	progress                   progress
+
+	// timeoutCh delivers the timeouts of the download requests to the message loop, which drives
+	// the download, so its state is only changed there.
+	timeoutCh chan func()
 }
 
 // NewManager new sync manager
@@ -72,9 +86,19 @@ func NewManager(blockChain *core.BlockChain, consensus consensus.Consensus, ns p
 		blockChain.TailBlock(),
 		make(chan bool, 1),
 		make(chan bool, 1),
+		make(chan net.Message, 128),
+		make(chan net.Message, 128),
+		0,
+		sync.Mutex{},
+		nil,
+		false,
+		nil,
+		progress{},
+		make(chan func(), 128),
 	}
 	m.RegisterSyncBlockInNetwork(ns)
 	m.RegisterSyncReplyInNetwork(ns)
+	m.RegisterSyncProtocolInNetwork(ns)
 	return m
 }
 
@@ -105,6 +129,7 @@ func (m *Manager) Start() {
 	m.startMsgHandle()
 	if len(m.ns.Node().Config().BootNodes) > 0 {
 		m.ns.Node().SetSynchronizing(true)
+		m.syncStarted()
 		m.startSync()
 		m.curTail = m.blockChain.TailBlock()
 	} else {
@@ -130,6 +155,7 @@ func (m *Manager) loop() {
 				m.ns.Node().SetSynchronizing(false)
 			}
 			m.consensus.SetCanMining(true)
+			m.syncFinished()
 			logging.VLog().Info("sync finish.")
 		case <-m.syncCh:
 			if m.curTail == nil {
@@ -235,6 +261,12 @@ func (m *Manager) startMsgHandle() {
 				}).Info("StartMsgHandle.receiveTailCh: receive receiveTailCh message.")
 				m.ns.SendSyncReply(tail.from, blocks)
 
+			case msg := <-m.receiveSyncRequestCh:
+				m.handleSyncRequest(msg)
+			case msg := <-m.receiveSyncProtocolReplyCh:
+				m.handleSyncProtocolReply(msg)
+			case timeout := <-m.timeoutCh:
+				timeout()
 			case msg := <-m.receiveSyncReplyCh:
 				// 1. compare the common ancestors, if over n+1 are the same, suppose the ancestor is the right ancestor
 				// 2. find overlapping blocks in 10 blocks who has the same ancestors
@@ -254,6 +286,9 @@ func (m *Manager) startMsgHandle() {
 					continue
 				}
 				blocks := data.Blocks()
+				for _, block := range blocks {
+					m.observePeerHeight(block.Height())
+				}
 
 				if len(blocks) == 0 {
 					msgErrCount++
@@ -343,6 +378,11 @@ func (m *Manager) doSyncBlocksWithCommonAncestor(addrsArray []string) {
 		for k := range m.cacheList {
 			delete(m.cacheList, k)
 		}
+		// download the rest blocks in pages instead of exchanging tails again.
+		if tail != nil {
+			m.startDownload(tail, addrsArray)
+			return
+		}
 		m.curTail = tail
 		m.syncCh <- true
 	} else { // sync finish
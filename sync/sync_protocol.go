@@ -0,0 +1,181 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	pb "github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/net/p2p"
+	"github.com/nebulasio/go-nebulas/net/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterSyncProtocolInNetwork register message subscriber of sync protocol in network.
+func (m *Manager) RegisterSyncProtocolInNetwork(nm p2p.Manager) {
+	nm.Register(net.NewSubscriber(m, m.receiveSyncRequestCh, net.MessageTypeGetBlockHashes, net.MessageTypeGetBlocksByRange,
+		net.MessageTypeGetTrieNodes, net.MessageTypeGetLightHeaders, net.MessageTypeGetProof))
+	nm.Register(net.NewSubscriber(m, m.receiveSyncProtocolReplyCh, net.MessageTypeBlockHashes, net.MessageTypeBlockBodies, net.MessageTypeTrieNodes))
+}
+
+func (m *Manager) sendSyncMsg(msgType string, msg pb.Message, peer string) error {
+	data, err := pb.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return m.ns.SendMsg(msgType, data, peer)
+}
+
+// handleSyncRequest serves the blocks in canonical chain requested by a peer.
+func (m *Manager) handleSyncRequest(msg net.Message) {
+	tail := m.blockChain.TailBlock()
+	var (
+		reply     pb.Message
+		replyType string
+		err       error
+	)
+	switch msg.MessageType() {
+	case net.MessageTypeGetBlockHashes:
+		req := new(netpb.GetBlockHashes)
+		if err = pb.Unmarshal(msg.Data().([]byte), req); err != nil {
+			break
+		}
+		hashes := &netpb.BlockHashes{Id: req.Id, From: req.From, Tail: tail.Height()}
+		for _, block := range m.blockChain.GetCanonicalBlocks(req.From, req.Count) {
+			hashes.Hashes = append(hashes.Hashes, block.Hash())
+		}
+		hashes.More = req.From+uint64(len(hashes.Hashes)) <= tail.Height()
+		reply, replyType = hashes, net.MessageTypeBlockHashes
+	case net.MessageTypeGetBlocksByRange:
+		req := new(netpb.GetBlocksByRange)
+		if err = pb.Unmarshal(msg.Data().([]byte), req); err != nil {
+			break
+		}
+		bodies := &netpb.BlockBodies{Id: req.Id, From: req.From}
+		size := 0
+		for _, block := range m.blockChain.GetCanonicalBlocks(req.From, req.Count) {
+			var data []byte
+			if data, err = marshalBlock(block); err != nil {
+				break
+			}
+			size += len(data)
+			if size > p2p.MaxBlockBodiesSize && len(bodies.Blocks) > 0 {
+				break
+			}
+			bodies.Blocks = append(bodies.Blocks, data)
+		}
+		bodies.More = req.From+uint64(len(bodies.Blocks)) <= tail.Height()
+		reply, replyType = bodies, net.MessageTypeBlockBodies
+	case net.MessageTypeGetTrieNodes:
+		req := new(netpb.GetTrieNodes)
+		if err = pb.Unmarshal(msg.Data().([]byte), req); err != nil {
+			break
+		}
+		nodes := &netpb.TrieNodes{Id: req.Id}
+		size := 0
+		for _, h := range req.Hashes {
+			// trie nodes are stored by the hash of content, other data is never served.
+			data, e := m.blockChain.Storage().Get(h)
+			if e != nil || !byteutils.Equal(hash.Sha3256(data), h) {
+				continue
+			}
+			size += len(data)
+			if size > p2p.MaxTrieNodesSize {
+				break
+			}
+			nodes.Nodes = append(nodes.Nodes, data)
+		}
+		reply, replyType = nodes, net.MessageTypeTrieNodes
+	case net.MessageTypeGetLightHeaders:
+		req := new(netpb.GetLightHeaders)
+		if err = pb.Unmarshal(msg.Data().([]byte), req); err != nil {
+			break
+		}
+		headers := &netpb.LightHeaders{Id: req.Id, From: req.From}
+		for _, block := range m.blockChain.GetCanonicalBlocks(req.From, req.Count) {
+			var data []byte
+			if data, err = marshalLightHeader(block); err != nil {
+				break
+			}
+			headers.Headers = append(headers.Headers, data)
+		}
+		headers.More = req.From+uint64(len(headers.Headers)) <= tail.Height()
+		reply, replyType = headers, net.MessageTypeLightHeaders
+	case net.MessageTypeGetProof:
+		req := new(netpb.GetProof)
+		if err = pb.Unmarshal(msg.Data().([]byte), req); err != nil {
+			break
+		}
+		reply, replyType = m.prove(req), net.MessageTypeProof
+	}
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"from":    msg.MessageFrom(),
+			"err":     err,
+		}).Error("Failed to serve the sync request.")
+		return
+	}
+
+	if err := m.sendSyncMsg(replyType, reply, msg.MessageFrom()); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": replyType,
+			"to":      msg.MessageFrom(),
+			"err":     err,
+		}).Error("Failed to reply the sync request.")
+	}
+}
+
+func marshalBlock(block *core.Block) ([]byte, error) {
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return pb.Marshal(pbBlock)
+}
+
+func marshalLightHeader(block *core.Block) ([]byte, error) {
+	header, err := block.LightHeader()
+	if err != nil {
+		return nil, err
+	}
+	return pb.Marshal(header)
+}
+
+// prove replies the value with proof of the key, or an empty proof if the key or block is not found.
+func (m *Manager) prove(req *netpb.GetProof) *netpb.Proof {
+	reply := &netpb.Proof{Id: req.Id, BlockHash: req.BlockHash, Kind: req.Kind, Key: req.Key}
+	block := m.blockChain.GetBlock(req.BlockHash)
+	if block == nil {
+		return reply
+	}
+	value, proof, err := block.Prove(req.Kind, req.Key)
+	if err != nil {
+		return reply
+	}
+	if reply.Nodes, err = proof.ToBytes(); err != nil {
+		reply.Nodes = nil
+		return reply
+	}
+	reply.Value = value
+	return reply
+}
@@ -19,6 +19,7 @@
 package logging
 
 import (
+	"errors"
 	"os"
 
 	"github.com/sirupsen/logrus"
@@ -34,6 +35,9 @@ const (
 	DebugLevel = "debug"
 )
 
+// ErrInvalidLogLevel the log level is not one of the levels above.
+var ErrInvalidLogLevel = errors.New("invalid log level")
+
 type emptyWriter struct{}
 
 func (ew emptyWriter) Write(p []byte) (int, error) {
@@ -94,3 +98,14 @@ func Init(path string, level string) {
 	vlog.Formatter = &logrus.TextFormatter{FullTimestamp: true}
 	vlog.Level = convertLevel(level)
 }
+
+// SetLevel changes the level of the verbose logger at runtime.
+func SetLevel(level string) error {
+	switch level {
+	case PanicLevel, FatalLevel, ErrorLevel, WarnLevel, InfoLevel, DebugLevel:
+	default:
+		return ErrInvalidLogLevel
+	}
+	VLog().Level = convertLevel(level)
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Package rlp implements the recursive length prefix encoding of byte strings and lists.
+//
+// An item is either a byte string, []byte, or a list of items, []interface{}.
+// Unsigned integers are byte strings in big endian without leading zeros, zero is the empty string.
+// Only the canonical encoding is decoded, every item in the shortest form.
+package rlp
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Errors
+var (
+	ErrUnsupportedType = errors.New("rlp: item must be []byte or []interface{}")
+	ErrUnexpectedEnd   = errors.New("rlp: unexpected end of input")
+	ErrTrailingBytes   = errors.New("rlp: trailing bytes after the item")
+	ErrNonCanonical    = errors.New("rlp: non-canonical encoding")
+	ErrExpectedString  = errors.New("rlp: expected a byte string")
+	ErrExpectedList    = errors.New("rlp: expected a list")
+	ErrUintOverflow    = errors.New("rlp: integer overflows uint64")
+)
+
+const (
+	offsetShortString = 0x80
+	offsetLongString  = 0xb7
+	offsetShortList   = 0xc0
+	offsetLongList    = 0xf7
+)
+
+// Encode returns the encoding of the item.
+func Encode(item interface{}) ([]byte, error) {
+	return appendItem(nil, item)
+}
+
+func appendItem(buf []byte, item interface{}) ([]byte, error) {
+	switch v := item.(type) {
+	case []byte:
+		if len(v) == 1 && v[0] < offsetShortString {
+			return append(buf, v[0]), nil
+		}
+		return append(appendHeader(buf, offsetShortString, uint64(len(v))), v...), nil
+	case []interface{}:
+		var payload []byte
+		for _, e := range v {
+			var err error
+			if payload, err = appendItem(payload, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(appendHeader(buf, offsetShortList, uint64(len(payload))), payload...), nil
+	}
+	return nil, ErrUnsupportedType
+}
+
+func appendHeader(buf []byte, offset byte, size uint64) []byte {
+	if size < 56 {
+		return append(buf, offset+byte(size))
+	}
+	length := Uint(size)
+	return append(append(buf, offset+55+byte(len(length))), length...)
+}
+
+// Decode returns the item encoded in data, which must be exactly one item.
+func Decode(data []byte) (interface{}, error) {
+	item, rest, err := decodeItem(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, ErrTrailingBytes
+	}
+	return item, nil
+}
+
+func decodeItem(data []byte) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, ErrUnexpectedEnd
+	}
+	prefix := data[0]
+	switch {
+	case prefix < offsetShortString:
+		return []byte{prefix}, data[1:], nil
+	case prefix < offsetShortList:
+		content, rest, err := splitContent(data, offsetShortString)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(content) == 1 && content[0] < offsetShortString {
+			return nil, nil, ErrNonCanonical
+		}
+		return content, rest, nil
+	default:
+		content, rest, err := splitContent(data, offsetShortList)
+		if err != nil {
+			return nil, nil, err
+		}
+		list := []interface{}{}
+		for len(content) > 0 {
+			var item interface{}
+			if item, content, err = decodeItem(content); err != nil {
+				return nil, nil, err
+			}
+			list = append(list, item)
+		}
+		return list, rest, nil
+	}
+}
+
+// splitContent returns the content of the item at the start of data, and the bytes after it.
+func splitContent(data []byte, offset byte) ([]byte, []byte, error) {
+	prefix, data := data[0]-offset, data[1:]
+	size := uint64(prefix)
+	if prefix > 55 {
+		n := int(prefix - 55)
+		if len(data) < n {
+			return nil, nil, ErrUnexpectedEnd
+		}
+		var err error
+		if size, err = ToUint(data[:n]); err != nil {
+			return nil, nil, err
+		}
+		if size < 56 || n == 0 {
+			return nil, nil, ErrNonCanonical
+		}
+		data = data[n:]
+	}
+	if uint64(len(data)) < size {
+		return nil, nil, ErrUnexpectedEnd
+	}
+	return data[:size], data[size:], nil
+}
+
+// Uint returns the byte string of an unsigned integer.
+func Uint(v uint64) []byte {
+	b := byteutils.FromUint64(v)
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	return b
+}
+
+// ToUint returns the unsigned integer of the byte string.
+func ToUint(b []byte) (uint64, error) {
+	if len(b) > 8 {
+		return 0, ErrUintOverflow
+	}
+	if len(b) > 0 && b[0] == 0 {
+		return 0, ErrNonCanonical
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+// String returns the byte string of the item.
+func String(item interface{}) ([]byte, error) {
+	s, ok := item.([]byte)
+	if !ok {
+		return nil, ErrExpectedString
+	}
+	return s, nil
+}
+
+// List returns the items of the list, it fails if the list isn't of size items.
+func List(item interface{}, size int) ([]interface{}, error) {
+	l, ok := item.([]interface{})
+	if !ok || len(l) != size {
+		return nil, ErrExpectedList
+	}
+	return l, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rlp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	long := bytes.Repeat([]byte{'a'}, 56)
+	tests := []struct {
+		name string
+		item interface{}
+		want string
+	}{
+		{"empty string", []byte{}, "80"},
+		{"single byte", []byte{0x0f}, "0f"},
+		{"single byte above 0x7f", []byte{0x80}, "8180"},
+		{"short string", []byte("dog"), "83646f67"},
+		{"long string", long, "b838" + byteutils.Hex(long)},
+		{"empty list", []interface{}{}, "c0"},
+		{"list", []interface{}{[]byte("cat"), []byte("dog")}, "c88363617483646f67"},
+		{"nested list", []interface{}{[]interface{}{}, []interface{}{[]interface{}{}}}, "c3c0c1c0"},
+		{"zero", Uint(0), "80"},
+		{"integer", Uint(1024), "820400"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encode(tt.item)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, byteutils.Hex(data))
+			item, err := Decode(data)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.item, item)
+		})
+	}
+
+	_, err := Encode("dog")
+	assert.Equal(t, ErrUnsupportedType, err)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"empty", "", ErrUnexpectedEnd},
+		{"short string", "83646f", ErrUnexpectedEnd},
+		{"trailing bytes", "8064", ErrTrailingBytes},
+		{"single byte in string", "8101", ErrNonCanonical},
+		{"short string in long form", "b803646f67", ErrNonCanonical},
+		{"list of short string", "c283646f", ErrUnexpectedEnd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := byteutils.FromHex(tt.data)
+			_, err := Decode(data)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestUint(t *testing.T) {
+	for _, v := range []uint64{0, 1, 127, 128, 1 << 32, 1<<64 - 1} {
+		got, err := ToUint(Uint(v))
+		assert.Nil(t, err)
+		assert.Equal(t, v, got)
+	}
+	_, err := ToUint([]byte{0, 1})
+	assert.Equal(t, ErrNonCanonical, err)
+	_, err = ToUint(make([]byte, 9))
+	assert.Equal(t, ErrUintOverflow, err)
+}